@@ -0,0 +1,239 @@
+package auth
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/google"
+	"gopkg.in/yaml.v3"
+)
+
+// APIKeyHeader is the HTTP header carrying a static API key.
+const APIKeyHeader = "X-API-Key"
+
+// ErrInvalidAPIKey is returned when a presented API key is not configured.
+var ErrInvalidAPIKey = errors.New("invalid API key")
+
+// APIKey is a static API key for trusted internal clients that cannot
+// complete the browser OAuth flow. Only the SHA-256 hash of the key is
+// stored. Each key maps to exactly one Google credential: a refresh token
+// (exchanged with the server's OAuth client) or a service account key file.
+// Scope optionally restricts the key, e.g. "gtm:read" for read-only access.
+type APIKey struct {
+	Name               string `yaml:"name"`
+	SHA256             string `yaml:"sha256"`
+	RefreshToken       string `yaml:"refresh_token"`
+	ServiceAccountFile string `yaml:"service_account_file"`
+	Scope              string `yaml:"scope"`
+
+	hash        []byte
+	tokenSource oauth2.TokenSource
+}
+
+// TokenSource returns the Google token source for this key.
+func (k *APIKey) TokenSource() oauth2.TokenSource {
+	return k.tokenSource
+}
+
+// apiKeysFile is the on-disk layout of an API key file:
+//
+//	keys:
+//	  - name: ci-publisher
+//	    sha256: 9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08
+//	    refresh_token: "1//0g..."
+//	  - name: nightly-audit
+//	    sha256: 60303ae22b998861bce3b28f33eec1be758a213c86c93c076dbe9f558c11c752
+//	    service_account_file: /secrets/gtm-audit.json
+//	    scope: gtm:read
+type apiKeysFile struct {
+	Keys []*APIKey `yaml:"keys"`
+}
+
+// APIKeyStore authenticates requests by static API key.
+type APIKeyStore struct {
+	keys []*APIKey
+}
+
+// HashAPIKey returns the hex-encoded SHA-256 hash of an API key, as stored in the key file.
+func HashAPIKey(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
+
+// LoadAPIKeys reads and validates an API key file. Refresh-token keys require
+// the Google provider; service-account keys do not.
+func LoadAPIKeys(path string, provider *GoogleProvider) (*APIKeyStore, error) {
+	keys, err := ReadAPIKeyFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return NewAPIKeyStore(keys, provider)
+}
+
+// ReadAPIKeyFile reads and validates the key definitions of an API key file.
+func ReadAPIKeyFile(path string) ([]*APIKey, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read API key file: %w", err)
+	}
+	keys, err := parseAPIKeys(data)
+	if err != nil {
+		return nil, fmt.Errorf("invalid API key file %s: %w", path, err)
+	}
+	return keys, nil
+}
+
+// ParseInlineAPIKeys parses MCP_API_KEYS entries of the form
+// "<key>:<refresh_token>". Unlike the key file, the plain key is configured;
+// the keys are named env-1, env-2, ... in order.
+func ParseInlineAPIKeys(entries []string) ([]*APIKey, error) {
+	var keys []*APIKey
+	for i, entry := range entries {
+		name := fmt.Sprintf("env-%d", i+1)
+		sep := strings.LastIndex(entry, ":")
+		if sep <= 0 || sep == len(entry)-1 {
+			return nil, fmt.Errorf("MCP_API_KEYS entry %d must have the form <key>:<refresh_token>", i+1)
+		}
+		sum := sha256.Sum256([]byte(entry[:sep]))
+		keys = append(keys, &APIKey{
+			Name:         name,
+			SHA256:       hex.EncodeToString(sum[:]),
+			RefreshToken: entry[sep+1:],
+			hash:         sum[:],
+		})
+	}
+	return keys, nil
+}
+
+// NewAPIKeyStore creates the Google token source of each key. Keys from
+// different sources must not share a name or a key.
+func NewAPIKeyStore(keys []*APIKey, provider *GoogleProvider) (*APIKeyStore, error) {
+	names := make(map[string]bool)
+	hashes := make(map[string]bool)
+	for _, k := range keys {
+		if names[k.Name] {
+			return nil, fmt.Errorf("API key %q: duplicate name", k.Name)
+		}
+		names[k.Name] = true
+		if hashes[string(k.hash)] {
+			return nil, fmt.Errorf("API key %q: duplicate key", k.Name)
+		}
+		hashes[string(k.hash)] = true
+
+		switch {
+		case k.RefreshToken != "":
+			if provider == nil {
+				return nil, fmt.Errorf("API key %q uses a refresh token, which requires GOOGLE_CLIENT_ID and GOOGLE_CLIENT_SECRET", k.Name)
+			}
+			k.tokenSource = oauth2.ReuseTokenSource(nil, provider.Config().TokenSource(context.Background(), &oauth2.Token{
+				RefreshToken: k.RefreshToken,
+			}))
+		case k.ServiceAccountFile != "":
+			saJSON, err := os.ReadFile(k.ServiceAccountFile)
+			if err != nil {
+				return nil, fmt.Errorf("API key %q: failed to read service account file: %w", k.Name, err)
+			}
+			jwtConfig, err := google.JWTConfigFromJSON(saJSON, GoogleScopes...)
+			if err != nil {
+				return nil, fmt.Errorf("API key %q: invalid service account file: %w", k.Name, err)
+			}
+			k.tokenSource = jwtConfig.TokenSource(context.Background())
+		}
+	}
+
+	return &APIKeyStore{keys: keys}, nil
+}
+
+// parseAPIKeys decodes and validates API key definitions.
+func parseAPIKeys(data []byte) ([]*APIKey, error) {
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+
+	var file apiKeysFile
+	if err := dec.Decode(&file); err != nil && !errors.Is(err, io.EOF) {
+		return nil, err
+	}
+
+	names := make(map[string]bool)
+	hashes := make(map[string]bool)
+	for i, k := range file.Keys {
+		if k == nil || k.Name == "" {
+			return nil, fmt.Errorf("key %d: name is required", i)
+		}
+		if names[k.Name] {
+			return nil, fmt.Errorf("key %q: duplicate name", k.Name)
+		}
+		names[k.Name] = true
+
+		hash, err := hex.DecodeString(strings.ToLower(k.SHA256))
+		if err != nil || len(hash) != sha256.Size {
+			return nil, fmt.Errorf("key %q: sha256 must be a hex-encoded SHA-256 hash of the key", k.Name)
+		}
+		if hashes[string(hash)] {
+			return nil, fmt.Errorf("key %q: duplicate sha256", k.Name)
+		}
+		hashes[string(hash)] = true
+		k.hash = hash
+
+		if (k.RefreshToken == "") == (k.ServiceAccountFile == "") {
+			return nil, fmt.Errorf("key %q: exactly one of refresh_token or service_account_file is required", k.Name)
+		}
+		for _, s := range strings.Fields(k.Scope) {
+			if !slices.Contains(ServerScopes, s) {
+				return nil, fmt.Errorf("key %q: unknown scope %q (valid scopes: %s)", k.Name, s, strings.Join(ServerScopes, ", "))
+			}
+		}
+	}
+	return file.Keys, nil
+}
+
+// Lookup returns the configured key matching a presented API key.
+func (s *APIKeyStore) Lookup(key string) (*APIKey, error) {
+	if s == nil || key == "" {
+		return nil, ErrInvalidAPIKey
+	}
+	sum := sha256.Sum256([]byte(key))
+
+	// Compare against every key so timing does not reveal which one matched.
+	var match *APIKey
+	for _, k := range s.keys {
+		if subtle.ConstantTimeCompare(sum[:], k.hash) == 1 {
+			match = k
+		}
+	}
+	if match == nil {
+		return nil, ErrInvalidAPIKey
+	}
+	return match, nil
+}
+
+// ByName returns the configured key with the given name, or nil.
+func (s *APIKeyStore) ByName(name string) *APIKey {
+	if s == nil {
+		return nil
+	}
+	for _, k := range s.keys {
+		if k.Name == name {
+			return k
+		}
+	}
+	return nil
+}
+
+// Len returns the number of configured keys.
+func (s *APIKeyStore) Len() int {
+	if s == nil {
+		return 0
+	}
+	return len(s.keys)
+}
@@ -0,0 +1,226 @@
+package auth
+
+import (
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func writeAPIKeyFile(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "api_keys.yaml")
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatalf("failed to write key file: %v", err)
+	}
+	return path
+}
+
+func TestParseAPIKeys(t *testing.T) {
+	hash := HashAPIKey("secret")
+
+	tests := []struct {
+		name    string
+		yaml    string
+		wantErr string
+	}{
+		{
+			name: "refresh token key",
+			yaml: "keys:\n  - name: ci\n    sha256: " + hash + "\n    refresh_token: rt\n",
+		},
+		{
+			name: "empty file",
+			yaml: "",
+		},
+		{
+			name:    "missing name",
+			yaml:    "keys:\n  - sha256: " + hash + "\n    refresh_token: rt\n",
+			wantErr: "name is required",
+		},
+		{
+			name:    "bad hash",
+			yaml:    "keys:\n  - name: ci\n    sha256: secret\n    refresh_token: rt\n",
+			wantErr: "sha256 must be",
+		},
+		{
+			name:    "no credential",
+			yaml:    "keys:\n  - name: ci\n    sha256: " + hash + "\n",
+			wantErr: "exactly one of",
+		},
+		{
+			name:    "two credentials",
+			yaml:    "keys:\n  - name: ci\n    sha256: " + hash + "\n    refresh_token: rt\n    service_account_file: sa.json\n",
+			wantErr: "exactly one of",
+		},
+		{
+			name:    "duplicate hash",
+			yaml:    "keys:\n  - name: a\n    sha256: " + hash + "\n    refresh_token: rt\n  - name: b\n    sha256: " + hash + "\n    refresh_token: rt\n",
+			wantErr: "duplicate sha256",
+		},
+		{
+			name: "read-only key",
+			yaml: "keys:\n  - name: ci\n    sha256: " + hash + "\n    refresh_token: rt\n    scope: gtm:read\n",
+		},
+		{
+			name:    "unknown scope",
+			yaml:    "keys:\n  - name: ci\n    sha256: " + hash + "\n    refresh_token: rt\n    scope: admin\n",
+			wantErr: "unknown scope",
+		},
+		{
+			name:    "unknown field",
+			yaml:    "keys:\n  - name: ci\n    key: secret\n",
+			wantErr: "field key not found",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := parseAPIKeys([]byte(tt.yaml))
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("expected error containing %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestLoadAPIKeys_RefreshTokenRequiresOAuth(t *testing.T) {
+	path := writeAPIKeyFile(t, "keys:\n  - name: ci\n    sha256: "+HashAPIKey("secret")+"\n    refresh_token: rt\n")
+
+	if _, err := LoadAPIKeys(path, nil); err == nil {
+		t.Fatal("expected error without Google provider")
+	}
+
+	store, err := LoadAPIKeys(path, NewGoogleProvider("id", "secret", "http://localhost/callback"))
+	if err != nil {
+		t.Fatalf("LoadAPIKeys failed: %v", err)
+	}
+	key, err := store.Lookup("secret")
+	if err != nil {
+		t.Fatalf("Lookup failed: %v", err)
+	}
+	if key.Name != "ci" || key.TokenSource() == nil {
+		t.Errorf("unexpected key %q with token source %v", key.Name, key.TokenSource())
+	}
+	if _, err := store.Lookup("wrong"); err != ErrInvalidAPIKey {
+		t.Errorf("expected ErrInvalidAPIKey, got %v", err)
+	}
+}
+
+func TestMiddleware_APIKey(t *testing.T) {
+	path := writeAPIKeyFile(t, "keys:\n  - name: ci\n    sha256: "+HashAPIKey("secret")+"\n    refresh_token: rt\n")
+	google := NewGoogleProvider("id", "secret", "http://localhost/callback")
+	apiKeys, err := LoadAPIKeys(path, google)
+	if err != nil {
+		t.Fatalf("LoadAPIKeys failed: %v", err)
+	}
+	store := NewMemoryTokenStore()
+	defer store.Close()
+
+	var gotClient string
+	var gotSource bool
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotClient = GetTokenInfo(r.Context()).ClientID
+		gotSource = GetGoogleTokenSource(r.Context()) != nil
+	})
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	handler := Middleware(store, google, apiKeys, logger, "http://localhost")(next)
+
+	tests := []struct {
+		name       string
+		apiKey     string
+		wantStatus int
+	}{
+		{name: "valid key", apiKey: "secret", wantStatus: http.StatusOK},
+		{name: "invalid key", apiKey: "wrong", wantStatus: http.StatusUnauthorized},
+		{name: "no key falls back to bearer", apiKey: "", wantStatus: http.StatusUnauthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotClient, gotSource = "", false
+			req := httptest.NewRequest(http.MethodPost, "/", nil)
+			if tt.apiKey != "" {
+				req.Header.Set(APIKeyHeader, tt.apiKey)
+			}
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+
+			if rec.Code != tt.wantStatus {
+				t.Fatalf("expected status %d, got %d", tt.wantStatus, rec.Code)
+			}
+			if tt.wantStatus == http.StatusOK && (gotClient != "api-key:ci" || !gotSource) {
+				t.Errorf("expected api-key:ci with token source, got %q (source %v)", gotClient, gotSource)
+			}
+		})
+	}
+}
+
+func TestParseInlineAPIKeys(t *testing.T) {
+	keys, err := ParseInlineAPIKeys([]string{"key-one:1//rt-one", "key:two:1//rt-two"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(keys) != 2 || keys[0].Name != "env-1" || keys[0].RefreshToken != "1//rt-one" || keys[1].SHA256 != HashAPIKey("key:two") {
+		t.Errorf("unexpected keys %+v %+v", keys[0], keys[1])
+	}
+
+	for _, entry := range []string{"no-separator", ":rt", "key:"} {
+		if _, err := ParseInlineAPIKeys([]string{entry}); err == nil {
+			t.Errorf("expected error for %q", entry)
+		}
+	}
+
+	google := NewGoogleProvider("id", "secret", "http://localhost/callback")
+	dup, _ := ParseInlineAPIKeys([]string{"same:rt1", "same:rt2"})
+	if _, err := NewAPIKeyStore(dup, google); err == nil || !strings.Contains(err.Error(), "duplicate key") {
+		t.Errorf("expected duplicate key error, got %v", err)
+	}
+}
+
+func TestAPIKeyMiddleware_Bearer(t *testing.T) {
+	google := NewGoogleProvider("id", "secret", "http://localhost/callback")
+	keys, _ := ParseInlineAPIKeys([]string{"vpn-key:rt"})
+	apiKeys, err := NewAPIKeyStore(keys, google)
+	if err != nil {
+		t.Fatalf("NewAPIKeyStore failed: %v", err)
+	}
+	store := NewMemoryTokenStore()
+	defer store.Close()
+
+	var gotClient string
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotClient = GetTokenInfo(r.Context()).ClientID
+	})
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+	handlers := map[string]http.Handler{
+		"oauth":    Middleware(store, google, apiKeys, logger, "http://localhost")(next),
+		"api keys": APIKeyMiddleware(apiKeys, logger, "http://localhost")(next),
+	}
+	for name, handler := range handlers {
+		for token, want := range map[string]int{"vpn-key": http.StatusOK, "wrong": http.StatusUnauthorized} {
+			gotClient = ""
+			req := httptest.NewRequest(http.MethodPost, "/", nil)
+			req.Header.Set("Authorization", "Bearer "+token)
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+
+			if rec.Code != want {
+				t.Errorf("%s middleware, token %s: expected status %d, got %d", name, token, want, rec.Code)
+			}
+			if want == http.StatusOK && gotClient != "api-key:env-1" {
+				t.Errorf("%s middleware: expected api-key:env-1, got %q", name, gotClient)
+			}
+		}
+	}
+}
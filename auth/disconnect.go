@@ -0,0 +1,100 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+
+	"golang.org/x/oauth2"
+)
+
+// googleRevokeURL is Google's OAuth token revocation endpoint.
+var googleRevokeURL = "https://oauth2.googleapis.com/revoke"
+
+// ErrCannotDisconnect is returned for sessions that cannot be ended from the
+// client, such as static API keys.
+var ErrCannotDisconnect = errors.New("session cannot be disconnected")
+
+// RevokeGoogleToken revokes a Google token. Revoking the refresh token ends
+// the whole grant, so it is preferred over the access token.
+func RevokeGoogleToken(ctx context.Context, token *oauth2.Token) error {
+	value := token.RefreshToken
+	if value == "" {
+		value = token.AccessToken
+	}
+	if value == "" {
+		return nil
+	}
+
+	form := url.Values{"token": {value}}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, googleRevokeURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to revoke Google token: %w", err)
+	}
+	defer resp.Body.Close()
+
+	// 400 invalid_token means the token was already revoked or expired
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusBadRequest {
+		return fmt.Errorf("failed to revoke Google token: status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// Disconnect ends the caller's session: its token is deleted from the store
+// and the underlying Google grant is revoked. In stdio mode the saved
+// credentials file is removed as well.
+func Disconnect(ctx context.Context) error {
+	if local, ok := GetGoogleTokenSource(ctx).(*LocalTokenSource); ok {
+		return local.Disconnect(ctx)
+	}
+
+	tokenInfo := GetTokenInfo(ctx)
+	if tokenInfo == nil {
+		return fmt.Errorf("%w: not authenticated", ErrCannotDisconnect)
+	}
+	if GetGoogleTokenSource(ctx) != nil {
+		return fmt.Errorf("%w: %s uses a static API key; ask the server administrator to remove the key", ErrCannotDisconnect, tokenInfo.ClientID)
+	}
+
+	if store := GetTokenStore(ctx); store != nil {
+		if err := store.DeleteToken(tokenInfo.AccessToken); err != nil {
+			return fmt.Errorf("failed to delete token: %w", err)
+		}
+	}
+	if tokenInfo.GoogleToken != nil {
+		return RevokeGoogleToken(ctx, tokenInfo.GoogleToken)
+	}
+	return nil
+}
+
+// Disconnect revokes the local user's Google grant and deletes the
+// credentials file, so the next tool call signs in again.
+func (s *LocalTokenSource) Disconnect(ctx context.Context) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	token, err := s.loadSaved()
+	if err != nil {
+		return err
+	}
+	if token != nil {
+		if err := RevokeGoogleToken(ctx, token); err != nil {
+			return err
+		}
+	}
+	if err := os.Remove(s.path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("failed to delete credentials file: %w", err)
+	}
+	s.source = nil
+	return nil
+}
@@ -0,0 +1,91 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"golang.org/x/oauth2"
+)
+
+// fakeRevokeEndpoint points googleRevokeURL at a test server and returns the
+// tokens it receives.
+func fakeRevokeEndpoint(t *testing.T) *[]string {
+	t.Helper()
+	var revoked []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		r.ParseForm()
+		revoked = append(revoked, r.FormValue("token"))
+	}))
+	t.Cleanup(srv.Close)
+
+	old := googleRevokeURL
+	googleRevokeURL = srv.URL
+	t.Cleanup(func() { googleRevokeURL = old })
+	return &revoked
+}
+
+func TestDisconnect_OAuthSession(t *testing.T) {
+	revoked := fakeRevokeEndpoint(t)
+	store := NewMemoryTokenStore()
+	defer store.Close()
+
+	info := &TokenInfo{
+		AccessToken:  "access",
+		RefreshToken: "refresh",
+		ExpiresAt:    time.Now().Add(time.Hour),
+		GoogleToken:  &oauth2.Token{AccessToken: "google-access", RefreshToken: "google-refresh"},
+	}
+	store.StoreToken(info)
+
+	ctx := context.WithValue(context.Background(), TokenInfoKey, info)
+	ctx = context.WithValue(ctx, TokenStoreKey, TokenStore(store))
+
+	if err := Disconnect(ctx); err != nil {
+		t.Fatalf("Disconnect failed: %v", err)
+	}
+	if _, err := store.GetTokenByAccess("access"); err == nil {
+		t.Error("expected access token to be deleted")
+	}
+	if _, err := store.GetTokenByRefresh("refresh"); err == nil {
+		t.Error("expected refresh token to be deleted")
+	}
+	if len(*revoked) != 1 || (*revoked)[0] != "google-refresh" {
+		t.Errorf("revoked = %v, want the Google refresh token", *revoked)
+	}
+}
+
+func TestDisconnect_APIKey(t *testing.T) {
+	ctx := context.WithValue(context.Background(), TokenInfoKey, &TokenInfo{ClientID: "api-key:ci"})
+	ctx = context.WithValue(ctx, GoogleTokenSourceKey, oauth2.StaticTokenSource(&oauth2.Token{AccessToken: "x"}))
+
+	if err := Disconnect(ctx); !errors.Is(err, ErrCannotDisconnect) {
+		t.Errorf("expected ErrCannotDisconnect, got %v", err)
+	}
+}
+
+func TestDisconnect_Local(t *testing.T) {
+	revoked := fakeRevokeEndpoint(t)
+	path := filepath.Join(t.TempDir(), "credentials.json")
+	ts := NewLocalTokenSource("client", "secret", path, slog.New(slog.NewTextHandler(io.Discard, nil)))
+	if err := ts.save(&oauth2.Token{RefreshToken: "saved-refresh"}); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := Disconnect(LocalContext(context.Background(), ts)); err != nil {
+		t.Fatalf("Disconnect failed: %v", err)
+	}
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Errorf("expected credentials file to be removed, stat error %v", err)
+	}
+	if len(*revoked) != 1 || (*revoked)[0] != "saved-refresh" {
+		t.Errorf("revoked = %v, want the saved refresh token", *revoked)
+	}
+}
@@ -4,6 +4,8 @@ import (
 	"context"
 	"fmt"
 	"log/slog"
+	"slices"
+	"strings"
 	"sync"
 
 	"golang.org/x/oauth2"
@@ -15,12 +17,99 @@ type GoogleProvider struct {
 	config *oauth2.Config
 }
 
-// GoogleScopes defines the scopes needed for GTM API access.
-var GoogleScopes = []string{
-	"https://www.googleapis.com/auth/tagmanager.delete.containers",
-	"https://www.googleapis.com/auth/tagmanager.edit.containers",
-	"https://www.googleapis.com/auth/tagmanager.edit.containerversions",
-	"https://www.googleapis.com/auth/tagmanager.publish",
+// Google Tag Manager API scopes.
+const (
+	GoogleScopeReadonly         = "https://www.googleapis.com/auth/tagmanager.readonly"
+	GoogleScopeEditContainers   = "https://www.googleapis.com/auth/tagmanager.edit.containers"
+	GoogleScopeEditVersions     = "https://www.googleapis.com/auth/tagmanager.edit.containerversions"
+	GoogleScopePublish          = "https://www.googleapis.com/auth/tagmanager.publish"
+	GoogleScopeDeleteContainers = "https://www.googleapis.com/auth/tagmanager.delete.containers"
+)
+
+// GoogleScopeAnalyticsReadonly lets the GA4 tools read Analytics properties
+// and data streams. It is only requested when listed in GOOGLE_SCOPES.
+const GoogleScopeAnalyticsReadonly = "https://www.googleapis.com/auth/analytics.readonly"
+
+// googleScopePrefix is stripped from scopes to form their short names.
+const googleScopePrefix = "https://www.googleapis.com/auth/tagmanager."
+
+// analyticsScopeShortName is the short name of GoogleScopeAnalyticsReadonly.
+const analyticsScopeShortName = "analytics.readonly"
+
+// GoogleScopeSets are the named scope sets selectable with GOOGLE_SCOPES.
+var GoogleScopeSets = map[string][]string{
+	"full":     {GoogleScopeDeleteContainers, GoogleScopeEditContainers, GoogleScopeEditVersions, GoogleScopePublish},
+	"edit":     {GoogleScopeEditContainers, GoogleScopeEditVersions},
+	"readonly": {GoogleScopeReadonly},
+}
+
+// GoogleScopes defines the scopes requested for GTM API access. It defaults
+// to the full set and is replaced at startup by SetGoogleScopes.
+var GoogleScopes = GoogleScopeSets["full"]
+
+// SetGoogleScopes replaces the requested Google scopes. It must be called
+// before any provider, token source or metadata handler is used.
+func SetGoogleScopes(scopes []string) {
+	GoogleScopes = scopes
+}
+
+// ParseGoogleScopes resolves a GOOGLE_SCOPES value: a named set ("full",
+// "edit", "readonly") or a comma-separated list of Tag Manager scopes, given
+// in full or by short name (e.g. "edit.containers,edit.containerversions").
+// Lists may include named sets and analytics.readonly, e.g.
+// "full,analytics.readonly". An empty value selects the full set.
+func ParseGoogleScopes(value string) ([]string, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return GoogleScopeSets["full"], nil
+	}
+	if set, ok := GoogleScopeSets[strings.ToLower(value)]; ok {
+		return set, nil
+	}
+
+	known := []string{GoogleScopeReadonly, GoogleScopeEditContainers, GoogleScopeEditVersions, GoogleScopePublish, GoogleScopeDeleteContainers, GoogleScopeAnalyticsReadonly}
+	var scopes []string
+	for _, s := range strings.Split(value, ",") {
+		s = strings.TrimSpace(s)
+		if s == "" {
+			continue
+		}
+		expanded, ok := GoogleScopeSets[strings.ToLower(s)]
+		if !ok {
+			switch {
+			case s == analyticsScopeShortName:
+				s = GoogleScopeAnalyticsReadonly
+			case !strings.HasPrefix(s, "https://"):
+				s = googleScopePrefix + s
+			}
+			if !slices.Contains(known, s) {
+				return nil, fmt.Errorf("unknown Google scope %q (use full, edit, readonly or a list of: %s)", s, strings.Join(GoogleScopeShortNames(known), ", "))
+			}
+			expanded = []string{s}
+		}
+		for _, s := range expanded {
+			if !slices.Contains(scopes, s) {
+				scopes = append(scopes, s)
+			}
+		}
+	}
+	if len(scopes) == 0 {
+		return nil, fmt.Errorf("GOOGLE_SCOPES lists no scopes")
+	}
+	return scopes, nil
+}
+
+// GoogleScopeShortNames strips the Tag Manager prefix from scopes and names
+// the Analytics scope analytics.readonly.
+func GoogleScopeShortNames(scopes []string) []string {
+	names := make([]string, len(scopes))
+	for i, s := range scopes {
+		names[i] = strings.TrimPrefix(s, googleScopePrefix)
+		if s == GoogleScopeAnalyticsReadonly {
+			names[i] = analyticsScopeShortName
+		}
+	}
+	return names
 }
 
 // NewGoogleProvider creates a new Google OAuth provider.
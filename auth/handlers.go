@@ -19,6 +19,7 @@ type Server struct {
 	store          TokenStore
 	logger         *slog.Logger
 	accessTokenTTL time.Duration
+	jwt            *JWTSigner
 }
 
 // NewServer creates a new OAuth server.
@@ -32,6 +33,30 @@ func NewServer(baseURL string, google *GoogleProvider, store TokenStore, logger
 	}
 }
 
+// UseJWT makes the server issue signed JWT access tokens instead of opaque
+// random strings. The token store passed to NewServer and Middleware should
+// be wrapped with NewJWTTokenStore using the same signer.
+func (s *Server) UseJWT(signer *JWTSigner) {
+	s.jwt = signer
+}
+
+// issueAccessToken sets info's access token: a signed JWT when configured,
+// otherwise a random opaque token.
+func (s *Server) issueAccessToken(info *TokenInfo) error {
+	var token string
+	var err error
+	if s.jwt != nil {
+		token, err = s.jwt.Sign(info)
+	} else {
+		token, err = GenerateToken(32)
+	}
+	if err != nil {
+		return err
+	}
+	info.AccessToken = token
+	return nil
+}
+
 // AuthorizeHandler handles GET /authorize - redirects to Google OAuth.
 func (s *Server) AuthorizeHandler(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodGet {
@@ -46,7 +71,8 @@ func (s *Server) AuthorizeHandler(w http.ResponseWriter, r *http.Request) {
 	state := r.URL.Query().Get("state")
 	codeChallenge := r.URL.Query().Get("code_challenge")
 	codeChallengeMethod := r.URL.Query().Get("code_challenge_method")
-	resource := r.URL.Query().Get("resource") // RFC 9728: resource indicator
+	requestedResource := r.URL.Query().Get("resource") // RFC 8707: resource indicator
+	scope := ParseScope(r.URL.Query().Get("scope"))
 
 	// Validate required parameters
 	if responseType != "code" {
@@ -94,6 +120,13 @@ func (s *Server) AuthorizeHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// Tokens are bound to the requested resource, which must be this server
+	resource, err := bindResource(requestedResource, s.baseURL)
+	if err != nil {
+		s.errorResponse(w, "invalid_target", err.Error())
+		return
+	}
+
 	// Generate our own state for Google OAuth
 	googleState, err := GenerateToken(32)
 	if err != nil {
@@ -108,7 +141,8 @@ func (s *Server) AuthorizeHandler(w http.ResponseWriter, r *http.Request) {
 		CodeVerifier: codeChallenge, // Store the challenge, we'll verify later
 		RedirectURI:  redirectURI,
 		ClientID:     clientID,
-		Resource:     resource, // Store resource for audience binding
+		Resource:     resource, // Audience the issued token is bound to
+		Scope:        scope,
 		CreatedAt:    time.Now(),
 	}
 
@@ -206,6 +240,7 @@ func (s *Server) CallbackHandler(w http.ResponseWriter, r *http.Request) {
 		RedirectURI:  authState.RedirectURI,
 		ClientID:     authState.ClientID,
 		Resource:     authState.Resource, // Preserve resource for token endpoint
+		Scope:        authState.Scope,
 		CreatedAt:    time.Now(),
 	}
 
@@ -291,6 +326,15 @@ func (s *Server) handleAuthorizationCodeGrant(w http.ResponseWriter, r *http.Req
 		return
 	}
 
+	// RFC 8707: a resource sent to the token endpoint must match the one authorized
+	if requested := r.FormValue("resource"); requested != "" {
+		if normalized, err := normalizeResource(requested); err != nil || normalized != codeState.Resource {
+			s.logger.Error("resource mismatch", "expected", codeState.Resource, "got", requested)
+			s.tokenError(w, "invalid_target", "resource does not match the authorization request")
+			return
+		}
+	}
+
 	// Verify PKCE
 	if codeVerifier == "" {
 		s.tokenError(w, "invalid_request", "Missing code_verifier")
@@ -319,13 +363,6 @@ func (s *Server) handleAuthorizationCodeGrant(w http.ResponseWriter, r *http.Req
 	_ = s.store.DeleteToken(code)
 
 	// Generate real tokens
-	accessToken, err := GenerateToken(32)
-	if err != nil {
-		s.logger.Error("failed to generate access token", "error", err)
-		s.tokenError(w, "server_error", "Internal server error")
-		return
-	}
-
 	refreshToken, err := GenerateToken(32)
 	if err != nil {
 		s.logger.Error("failed to generate refresh token", "error", err)
@@ -335,25 +372,32 @@ func (s *Server) handleAuthorizationCodeGrant(w http.ResponseWriter, r *http.Req
 
 	// Create and store the real token
 	tokenInfo := &TokenInfo{
-		AccessToken:      accessToken,
 		RefreshToken:     refreshToken,
 		ExpiresAt:        time.Now().Add(s.accessTokenTTL),
 		RefreshExpiresAt: time.Now().Add(30 * 24 * time.Hour),
 		GoogleToken:      tempToken.GoogleToken,
 		ClientID:         codeState.ClientID,
+		Scope:            codeState.Scope,
+		Resource:         codeState.Resource,
 		CreatedAt:        time.Now(),
 	}
 
+	if err := s.issueAccessToken(tokenInfo); err != nil {
+		s.logger.Error("failed to generate access token", "error", err)
+		s.tokenError(w, "server_error", "Internal server error")
+		return
+	}
+
 	if err := s.store.StoreToken(tokenInfo); err != nil {
 		s.logger.Error("failed to store token", "error", err)
 		s.tokenError(w, "server_error", "Internal server error")
 		return
 	}
 
-	s.logger.Info("issued access token", "client_id", codeState.ClientID)
+	s.logger.Info("issued access token", "client_id", codeState.ClientID, "scope", codeState.Scope)
 
 	// Return token response
-	s.tokenResponse(w, accessToken, refreshToken, int(s.accessTokenTTL.Seconds()))
+	s.tokenResponse(w, tokenInfo.AccessToken, refreshToken, tokenInfo.Scope, int(s.accessTokenTTL.Seconds()))
 }
 
 func (s *Server) handleRefreshTokenGrant(w http.ResponseWriter, r *http.Request) {
@@ -372,8 +416,21 @@ func (s *Server) handleRefreshTokenGrant(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
-	// Refresh the Google token if needed
-	if tokenInfo.GoogleToken.Expiry.Before(time.Now()) {
+	// RFC 8707: a refreshed token cannot be re-targeted at another resource
+	if requested := r.FormValue("resource"); requested != "" {
+		if normalized, err := normalizeResource(requested); err != nil || (tokenInfo.Resource != "" && normalized != tokenInfo.Resource) {
+			s.tokenError(w, "invalid_target", "resource does not match the original grant")
+			return
+		}
+	}
+
+	// Refresh the Google token if needed. A JWT carries the Google token, so
+	// it must stay valid for the lifetime of the new access token.
+	refreshBefore := time.Now()
+	if s.jwt != nil {
+		refreshBefore = refreshBefore.Add(s.accessTokenTTL)
+	}
+	if tokenInfo.GoogleToken.Expiry.Before(refreshBefore) {
 		newGoogleToken, err := s.google.RefreshToken(r.Context(), tokenInfo.GoogleToken.RefreshToken)
 		if err != nil {
 			s.logger.Error("failed to refresh Google token", "error", err)
@@ -383,14 +440,7 @@ func (s *Server) handleRefreshTokenGrant(w http.ResponseWriter, r *http.Request)
 		tokenInfo.GoogleToken = newGoogleToken
 	}
 
-	// Generate new access token and new refresh token (rotation)
-	newAccessToken, err := GenerateToken(32)
-	if err != nil {
-		s.logger.Error("failed to generate access token", "error", err)
-		s.tokenError(w, "server_error", "Internal server error")
-		return
-	}
-
+	// Generate new refresh token (rotation)
 	newRefreshToken, err := GenerateToken(32)
 	if err != nil {
 		s.logger.Error("failed to generate refresh token", "error", err)
@@ -403,15 +453,22 @@ func (s *Server) handleRefreshTokenGrant(w http.ResponseWriter, r *http.Request)
 
 	// Store new token with rotated refresh token
 	newTokenInfo := &TokenInfo{
-		AccessToken:      newAccessToken,
 		RefreshToken:     newRefreshToken,
 		ExpiresAt:        time.Now().Add(s.accessTokenTTL),
 		RefreshExpiresAt: time.Now().Add(30 * 24 * time.Hour),
 		GoogleToken:      tokenInfo.GoogleToken,
 		ClientID:         tokenInfo.ClientID,
+		Scope:            tokenInfo.Scope,
+		Resource:         tokenInfo.Resource,
 		CreatedAt:        time.Now(),
 	}
 
+	if err := s.issueAccessToken(newTokenInfo); err != nil {
+		s.logger.Error("failed to generate access token", "error", err)
+		s.tokenError(w, "server_error", "Internal server error")
+		return
+	}
+
 	if err := s.store.StoreToken(newTokenInfo); err != nil {
 		s.logger.Error("failed to store new token", "error", err)
 		s.tokenError(w, "server_error", "Internal server error")
@@ -421,16 +478,19 @@ func (s *Server) handleRefreshTokenGrant(w http.ResponseWriter, r *http.Request)
 	s.logger.Info("refreshed access token", "client_id", tokenInfo.ClientID)
 
 	// Return token response with new refresh token
-	s.tokenResponse(w, newAccessToken, newRefreshToken, int(s.accessTokenTTL.Seconds()))
+	s.tokenResponse(w, newTokenInfo.AccessToken, newRefreshToken, newTokenInfo.Scope, int(s.accessTokenTTL.Seconds()))
 }
 
-func (s *Server) tokenResponse(w http.ResponseWriter, accessToken, refreshToken string, expiresIn int) {
+func (s *Server) tokenResponse(w http.ResponseWriter, accessToken, refreshToken, scope string, expiresIn int) {
 	resp := map[string]interface{}{
 		"access_token":  accessToken,
 		"token_type":    "Bearer",
 		"expires_in":    expiresIn,
 		"refresh_token": refreshToken,
 	}
+	if scope != "" {
+		resp["scope"] = scope
+	}
 
 	w.Header().Set("Content-Type", "application/json")
 	w.Header().Set("Cache-Control", "no-store")
@@ -11,6 +11,8 @@ import (
 	"strings"
 	"testing"
 	"time"
+
+	"golang.org/x/oauth2"
 )
 
 func TestIsValidRedirectURI(t *testing.T) {
@@ -638,6 +640,43 @@ func TestServer_HandleRefreshTokenGrant_InvalidRefreshToken(t *testing.T) {
 	}
 }
 
+func TestServer_HandleRefreshTokenGrant_KeepsScope(t *testing.T) {
+	store := NewMemoryTokenStore()
+	defer store.Close()
+
+	logger := slog.New(slog.NewTextHandler(os.Stdout, nil))
+	server := NewServer("http://localhost:8080", nil, store, logger)
+
+	store.StoreToken(&TokenInfo{
+		AccessToken:      "old-access",
+		RefreshToken:     "old-refresh",
+		ExpiresAt:        time.Now().Add(time.Hour),
+		RefreshExpiresAt: time.Now().Add(time.Hour),
+		GoogleToken:      &oauth2.Token{AccessToken: "google", Expiry: time.Now().Add(time.Hour)},
+		Scope:            ScopeRead,
+	})
+
+	form := url.Values{}
+	form.Set("grant_type", "refresh_token")
+	form.Set("refresh_token", "old-refresh")
+
+	req := httptest.NewRequest(http.MethodPost, "/token", strings.NewReader(form.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	w := httptest.NewRecorder()
+
+	server.TokenHandler(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d: %s", http.StatusOK, w.Code, w.Body.String())
+	}
+	if !strings.Contains(w.Body.String(), `"scope":"gtm:read"`) {
+		t.Errorf("expected scope in token response, got %s", w.Body.String())
+	}
+	if _, err := store.GetTokenByRefresh("old-refresh"); err == nil {
+		t.Error("expected old refresh token to be rotated out")
+	}
+}
+
 func TestServer_CallbackHandler_MethodNotAllowed(t *testing.T) {
 	store := NewMemoryTokenStore()
 	defer store.Close()
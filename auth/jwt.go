@@ -0,0 +1,266 @@
+package auth
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/oauth2"
+)
+
+// ErrInvalidToken is returned when a JWT access token fails verification.
+var ErrInvalidToken = errors.New("invalid token")
+
+// MinJWTSecretLength is the minimum JWT_SECRET length for JWT access tokens.
+const MinJWTSecretLength = 32
+
+// jwtHeader is the fixed, pre-encoded header of every issued token.
+var jwtHeader = base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"HS256","typ":"JWT"}`))
+
+// jwtClaims are the claims of an access token. The Google token is encrypted
+// into gtk so any node holding the secret can call the GTM API without
+// looking the token up.
+type jwtClaims struct {
+	Issuer    string `json:"iss"`
+	Audience  string `json:"aud,omitempty"`
+	ClientID  string `json:"client_id"`
+	Scope     string `json:"scope,omitempty"`
+	IssuedAt  int64  `json:"iat"`
+	ExpiresAt int64  `json:"exp"`
+	ID        string `json:"jti"`
+	Google    string `json:"gtk"`
+}
+
+// JWTSigner issues and verifies HS256 access tokens, so a token can be
+// validated statelessly on nodes that do not share the token store.
+type JWTSigner struct {
+	issuer     string
+	signingKey []byte
+	aead       cipher.AEAD
+}
+
+// NewJWTSigner creates a signer from the server secret. Separate keys for
+// signing and for encrypting the embedded Google token are derived from it.
+func NewJWTSigner(secret, issuer string) (*JWTSigner, error) {
+	if len(secret) < MinJWTSecretLength {
+		return nil, fmt.Errorf("JWT_SECRET must be at least %d characters for JWT access tokens", MinJWTSecretLength)
+	}
+
+	block, err := aes.NewCipher(deriveKey(secret, "google-token-encryption"))
+	if err != nil {
+		return nil, err
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+
+	return &JWTSigner{
+		issuer:     issuer,
+		signingKey: deriveKey(secret, "access-token-signing"),
+		aead:       aead,
+	}, nil
+}
+
+func deriveKey(secret, purpose string) []byte {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(purpose))
+	return mac.Sum(nil)
+}
+
+// Sign issues a JWT access token for info.
+func (s *JWTSigner) Sign(info *TokenInfo) (string, error) {
+	googleToken, err := s.encrypt(info.GoogleToken)
+	if err != nil {
+		return "", fmt.Errorf("failed to encrypt Google token: %w", err)
+	}
+	id, err := GenerateToken(16)
+	if err != nil {
+		return "", err
+	}
+
+	claims, err := json.Marshal(jwtClaims{
+		Issuer:    s.issuer,
+		Audience:  info.Resource,
+		ClientID:  info.ClientID,
+		Scope:     info.Scope,
+		IssuedAt:  info.CreatedAt.Unix(),
+		ExpiresAt: info.ExpiresAt.Unix(),
+		ID:        id,
+		Google:    googleToken,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	unsigned := jwtHeader + "." + base64.RawURLEncoding.EncodeToString(claims)
+	return unsigned + "." + s.signature(unsigned), nil
+}
+
+// Verify checks the token's signature, issuer and expiry and returns the
+// TokenInfo it carries. The caller checks the audience.
+func (s *JWTSigner) Verify(token string) (*TokenInfo, error) {
+	info, _, err := s.verify(token)
+	return info, err
+}
+
+// verify is Verify that also returns the token's jti claim.
+func (s *JWTSigner) verify(token string) (*TokenInfo, string, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 || parts[0] != jwtHeader {
+		return nil, "", ErrInvalidToken
+	}
+	if !hmac.Equal([]byte(parts[2]), []byte(s.signature(parts[0]+"."+parts[1]))) {
+		return nil, "", ErrInvalidToken
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return nil, "", ErrInvalidToken
+	}
+	var claims jwtClaims
+	if err := json.Unmarshal(payload, &claims); err != nil {
+		return nil, "", ErrInvalidToken
+	}
+	if claims.Issuer != s.issuer {
+		return nil, "", ErrInvalidToken
+	}
+
+	expiresAt := time.Unix(claims.ExpiresAt, 0)
+	if time.Now().After(expiresAt) {
+		return nil, "", ErrTokenExpired
+	}
+
+	googleToken, err := s.decrypt(claims.Google)
+	if err != nil {
+		return nil, "", ErrInvalidToken
+	}
+
+	return &TokenInfo{
+		AccessToken: token,
+		ExpiresAt:   expiresAt,
+		GoogleToken: googleToken,
+		ClientID:    claims.ClientID,
+		Scope:       claims.Scope,
+		Resource:    claims.Audience,
+		CreatedAt:   time.Unix(claims.IssuedAt, 0),
+	}, claims.ID, nil
+}
+
+func (s *JWTSigner) signature(unsigned string) string {
+	mac := hmac.New(sha256.New, s.signingKey)
+	mac.Write([]byte(unsigned))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+func (s *JWTSigner) encrypt(token *oauth2.Token) (string, error) {
+	plaintext, err := json.Marshal(token)
+	if err != nil {
+		return "", err
+	}
+	nonce := make([]byte, s.aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(s.aead.Seal(nonce, nonce, plaintext, nil)), nil
+}
+
+func (s *JWTSigner) decrypt(encoded string) (*oauth2.Token, error) {
+	data, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, err
+	}
+	if len(data) < s.aead.NonceSize() {
+		return nil, ErrInvalidToken
+	}
+	nonce, ciphertext := data[:s.aead.NonceSize()], data[s.aead.NonceSize():]
+	plaintext, err := s.aead.Open(nil, nonce, ciphertext, nil)
+	if err != nil {
+		return nil, err
+	}
+	var token oauth2.Token
+	if err := json.Unmarshal(plaintext, &token); err != nil {
+		return nil, err
+	}
+	return &token, nil
+}
+
+// isJWT reports whether an access token has the shape of a JWT.
+func isJWT(token string) bool {
+	return strings.Count(token, ".") == 2
+}
+
+// JWTTokenStore wraps a TokenStore so that JWT access tokens are verified
+// statelessly. Refresh tokens, OAuth state and client registrations still
+// live in the wrapped store.
+type JWTTokenStore struct {
+	TokenStore
+	signer *JWTSigner
+
+	mu      sync.Mutex
+	revoked map[string]time.Time // jti -> expiry of tokens deleted before they expired
+}
+
+// NewJWTTokenStore wraps store with stateless JWT verification.
+func NewJWTTokenStore(store TokenStore, signer *JWTSigner) *JWTTokenStore {
+	return &JWTTokenStore{TokenStore: store, signer: signer, revoked: make(map[string]time.Time)}
+}
+
+// GetTokenByAccess verifies JWT access tokens and looks up any other token
+// (e.g. temporary authorization codes) in the wrapped store.
+func (s *JWTTokenStore) GetTokenByAccess(accessToken string) (*TokenInfo, error) {
+	if !isJWT(accessToken) {
+		return s.TokenStore.GetTokenByAccess(accessToken)
+	}
+	info, id, err := s.signer.verify(accessToken)
+	if err != nil {
+		return nil, err
+	}
+	s.mu.Lock()
+	_, revoked := s.revoked[id]
+	s.mu.Unlock()
+	if revoked {
+		return nil, ErrInvalidToken
+	}
+	return info, nil
+}
+
+// DeleteToken deletes the token from the wrapped store and revokes a JWT
+// until it expires. Revocation is kept in memory on this node: other nodes
+// accept the token until it expires, although the Google grant it carries
+// is revoked on disconnect.
+func (s *JWTTokenStore) DeleteToken(accessToken string) error {
+	if isJWT(accessToken) {
+		if info, id, err := s.signer.verify(accessToken); err == nil {
+			s.mu.Lock()
+			now := time.Now()
+			for k, expiresAt := range s.revoked {
+				if now.After(expiresAt) {
+					delete(s.revoked, k)
+				}
+			}
+			s.revoked[id] = info.ExpiresAt
+			s.mu.Unlock()
+		}
+	}
+	return s.TokenStore.DeleteToken(accessToken)
+}
+
+// UpdateGoogleToken records a refreshed Google token when this node issued
+// the access token. Other nodes do not hold it, which is not an error.
+func (s *JWTTokenStore) UpdateGoogleToken(accessToken string, googleToken *oauth2.Token) error {
+	err := s.TokenStore.UpdateGoogleToken(accessToken, googleToken)
+	if isJWT(accessToken) && errors.Is(err, ErrTokenNotFound) {
+		return nil
+	}
+	return err
+}
@@ -0,0 +1,224 @@
+package auth
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"golang.org/x/oauth2"
+)
+
+const testJWTSecret = "0123456789abcdef0123456789abcdef"
+
+func newTestSigner(t *testing.T, secret string) *JWTSigner {
+	t.Helper()
+	signer, err := NewJWTSigner(secret, "https://gtm.example.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return signer
+}
+
+func TestJWTSigner_RoundTrip(t *testing.T) {
+	signer := newTestSigner(t, testJWTSecret)
+	token, err := signer.Sign(&TokenInfo{
+		ExpiresAt:   time.Now().Add(time.Hour),
+		GoogleToken: &oauth2.Token{AccessToken: "google-access", RefreshToken: "google-refresh"},
+		ClientID:    "client-1",
+		Scope:       ScopeRead,
+		Resource:    "https://gtm.example.com",
+		CreatedAt:   time.Now(),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(token, "google-refresh") {
+		t.Error("Google token must not appear in the JWT in clear text")
+	}
+
+	info, err := signer.Verify(token)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.ClientID != "client-1" || info.Scope != ScopeRead || info.Resource != "https://gtm.example.com" || info.AccessToken != token {
+		t.Errorf("info = %+v", info)
+	}
+	if info.GoogleToken == nil || info.GoogleToken.RefreshToken != "google-refresh" {
+		t.Errorf("google token = %+v", info.GoogleToken)
+	}
+}
+
+func TestJWTSigner_Rejects(t *testing.T) {
+	signer := newTestSigner(t, testJWTSecret)
+	sign := func(expiresAt time.Time) string {
+		token, err := signer.Sign(&TokenInfo{ExpiresAt: expiresAt, GoogleToken: &oauth2.Token{}, CreatedAt: time.Now()})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return token
+	}
+	valid := sign(time.Now().Add(time.Hour))
+	parts := strings.Split(valid, ".")
+
+	// Re-encode the claims with a widened scope but keep the old signature
+	payload, _ := json.Marshal(map[string]any{"iss": "https://gtm.example.com", "client_id": "x", "scope": ScopeWrite, "exp": time.Now().Add(time.Hour).Unix()})
+	tampered := parts[0] + "." + base64.RawURLEncoding.EncodeToString(payload) + "." + parts[2]
+
+	other := newTestSigner(t, strings.Repeat("z", MinJWTSecretLength))
+
+	tests := []struct {
+		name   string
+		token  string
+		signer *JWTSigner
+		want   error
+	}{
+		{name: "expired", token: sign(time.Now().Add(-time.Minute)), signer: signer, want: ErrTokenExpired},
+		{name: "tampered claims", token: tampered, signer: signer, want: ErrInvalidToken},
+		{name: "other secret", token: valid, signer: other, want: ErrInvalidToken},
+		{name: "malformed", token: "a.b.c", signer: signer, want: ErrInvalidToken},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := tt.signer.Verify(tt.token); !errors.Is(err, tt.want) {
+				t.Errorf("error = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewJWTSigner_ShortSecret(t *testing.T) {
+	if _, err := NewJWTSigner("short", "https://gtm.example.com"); err == nil {
+		t.Error("expected error for short secret")
+	}
+}
+
+func TestMiddleware_JWTStateless(t *testing.T) {
+	signer := newTestSigner(t, testJWTSecret)
+	token, err := signer.Sign(&TokenInfo{
+		ExpiresAt:   time.Now().Add(time.Hour),
+		GoogleToken: &oauth2.Token{AccessToken: "google-access"},
+		ClientID:    "client-1",
+		Resource:    "https://gtm.example.com",
+		CreatedAt:   time.Now(),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// A node that never saw the token validates it from the signature alone
+	store := NewMemoryTokenStore()
+	defer store.Close()
+
+	var got *TokenInfo
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) { got = GetTokenInfo(r.Context()) })
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	handler := Middleware(NewJWTTokenStore(store, signer), nil, nil, logger, "https://gtm.example.com")(next)
+
+	req := httptest.NewRequest(http.MethodPost, "/mcp", nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK || got == nil || got.ClientID != "client-1" {
+		t.Fatalf("status %d, token info %+v", w.Code, got)
+	}
+}
+
+func TestServer_HandleRefreshTokenGrant_IssuesJWT(t *testing.T) {
+	signer := newTestSigner(t, testJWTSecret)
+	store := NewMemoryTokenStore()
+	defer store.Close()
+	jwtStore := NewJWTTokenStore(store, signer)
+
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	server := NewServer("https://gtm.example.com", nil, jwtStore, logger)
+	server.UseJWT(signer)
+
+	jwtStore.StoreToken(&TokenInfo{
+		AccessToken:      "old-access",
+		RefreshToken:     "old-refresh",
+		ExpiresAt:        time.Now().Add(time.Hour),
+		RefreshExpiresAt: time.Now().Add(time.Hour),
+		GoogleToken:      &oauth2.Token{AccessToken: "google", Expiry: time.Now().Add(2 * time.Hour)},
+		ClientID:         "client-1",
+		Scope:            ScopeRead,
+	})
+
+	form := url.Values{}
+	form.Set("grant_type", "refresh_token")
+	form.Set("refresh_token", "old-refresh")
+
+	req := httptest.NewRequest(http.MethodPost, "/token", strings.NewReader(form.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	w := httptest.NewRecorder()
+
+	server.TokenHandler(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d: %s", http.StatusOK, w.Code, w.Body.String())
+	}
+	var resp struct {
+		AccessToken string `json:"access_token"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatal(err)
+	}
+	info, err := signer.Verify(resp.AccessToken)
+	if err != nil {
+		t.Fatalf("issued token is not a valid JWT: %v", err)
+	}
+	if info.ClientID != "client-1" || info.Scope != ScopeRead || info.GoogleToken.AccessToken != "google" {
+		t.Errorf("info = %+v", info)
+	}
+}
+
+func TestMiddleware_JWTRejectedAfterDisconnect(t *testing.T) {
+	fakeRevokeEndpoint(t)
+	signer := newTestSigner(t, testJWTSecret)
+	info := &TokenInfo{
+		ExpiresAt:   time.Now().Add(time.Hour),
+		GoogleToken: &oauth2.Token{AccessToken: "google-access", RefreshToken: "google-refresh"},
+		ClientID:    "client-1",
+		Resource:    "https://gtm.example.com",
+		CreatedAt:   time.Now(),
+	}
+	token, err := signer.Sign(info)
+	if err != nil {
+		t.Fatal(err)
+	}
+	info.AccessToken = token
+
+	memory := NewMemoryTokenStore()
+	defer memory.Close()
+	store := NewJWTTokenStore(memory, signer)
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	handler := Middleware(store, nil, nil, logger, "https://gtm.example.com")(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	call := func() int {
+		req := httptest.NewRequest(http.MethodPost, "/mcp", nil)
+		req.Header.Set("Authorization", "Bearer "+token)
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, req)
+		return w.Code
+	}
+
+	if code := call(); code != http.StatusOK {
+		t.Fatalf("before disconnect: status %d", code)
+	}
+	ctx := context.WithValue(context.Background(), TokenInfoKey, info)
+	ctx = context.WithValue(ctx, TokenStoreKey, TokenStore(store))
+	if err := Disconnect(ctx); err != nil {
+		t.Fatalf("Disconnect failed: %v", err)
+	}
+	if code := call(); code != http.StatusUnauthorized {
+		t.Errorf("after disconnect: status %d, want 401", code)
+	}
+}
@@ -0,0 +1,236 @@
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net"
+	"net/http"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"sync"
+	"time"
+
+	"golang.org/x/oauth2"
+)
+
+// localLoginTimeout bounds how long the loopback login waits for the user.
+const localLoginTimeout = 5 * time.Minute
+
+// LocalCredentials is the credentials file written after a local login.
+type LocalCredentials struct {
+	RefreshToken string `json:"refresh_token"`
+}
+
+// DefaultCredentialsFile returns the default credentials path for stdio mode,
+// e.g. ~/.config/gtm-mcp-server/credentials.json on Linux.
+func DefaultCredentialsFile() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "gtm-mcp-server", "credentials.json"), nil
+}
+
+// LocalTokenSource supplies Google tokens for a single local user. The first
+// call without saved credentials runs the OAuth flow through a temporary
+// loopback redirect and saves the refresh token to the credentials file.
+type LocalTokenSource struct {
+	mu     sync.Mutex
+	config *oauth2.Config
+	path   string
+	logger *slog.Logger
+	source oauth2.TokenSource
+}
+
+// NewLocalTokenSource creates a token source backed by the credentials file at path.
+// The OAuth client should be of the "Desktop app" type so that Google accepts
+// loopback redirects on any port.
+func NewLocalTokenSource(clientID, clientSecret, path string, logger *slog.Logger) *LocalTokenSource {
+	return &LocalTokenSource{
+		config: NewGoogleProvider(clientID, clientSecret, "").Config(),
+		path:   path,
+		logger: logger,
+	}
+}
+
+// Token returns a valid Google token, signing in first if needed.
+func (s *LocalTokenSource) Token() (*oauth2.Token, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.source == nil {
+		token, err := s.loadOrLogin()
+		if err != nil {
+			return nil, err
+		}
+		s.source = s.config.TokenSource(context.Background(), token)
+	}
+
+	token, err := s.source.Token()
+	if err != nil {
+		return nil, fmt.Errorf("failed to refresh Google token (delete %s to sign in again): %w", s.path, err)
+	}
+	return token, nil
+}
+
+// loadOrLogin reads the saved refresh token, running the login flow when
+// there is none.
+func (s *LocalTokenSource) loadOrLogin() (*oauth2.Token, error) {
+	token, err := s.loadSaved()
+	if err != nil || token != nil {
+		return token, err
+	}
+
+	token, err = s.login()
+	if err != nil {
+		return nil, err
+	}
+	if err := s.save(token); err != nil {
+		return nil, err
+	}
+	return token, nil
+}
+
+// loadSaved reads the saved refresh token, or returns nil when there is none.
+func (s *LocalTokenSource) loadSaved() (*oauth2.Token, error) {
+	data, err := os.ReadFile(s.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read credentials file: %w", err)
+	}
+	var creds LocalCredentials
+	if err := json.Unmarshal(data, &creds); err != nil {
+		return nil, fmt.Errorf("invalid credentials file %s: %w", s.path, err)
+	}
+	if creds.RefreshToken == "" {
+		return nil, nil
+	}
+	return &oauth2.Token{RefreshToken: creds.RefreshToken}, nil
+}
+
+// login runs the Google OAuth flow with PKCE, receiving the code on a
+// temporary 127.0.0.1 listener.
+func (s *LocalTokenSource) login() (*oauth2.Token, error) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return nil, fmt.Errorf("failed to start loopback listener: %w", err)
+	}
+	defer listener.Close()
+
+	config := *s.config
+	config.RedirectURL = fmt.Sprintf("http://%s/callback", listener.Addr())
+
+	state, err := GenerateToken(16)
+	if err != nil {
+		return nil, err
+	}
+	verifier := oauth2.GenerateVerifier()
+
+	type result struct {
+		code string
+		err  error
+	}
+	results := make(chan result, 1)
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /callback", func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		var res result
+		switch {
+		case q.Get("state") != state:
+			res.err = fmt.Errorf("login failed: state mismatch")
+		case q.Get("error") != "":
+			res.err = fmt.Errorf("login failed: %s", q.Get("error"))
+		case q.Get("code") == "":
+			res.err = fmt.Errorf("login failed: missing code")
+		default:
+			res.code = q.Get("code")
+		}
+		if res.err != nil {
+			http.Error(w, res.err.Error(), http.StatusBadRequest)
+		} else {
+			fmt.Fprintln(w, "Signed in to Google Tag Manager. You can close this window.")
+		}
+		select {
+		case results <- res:
+		default:
+		}
+	})
+	server := &http.Server{Handler: mux, ReadHeaderTimeout: 10 * time.Second}
+	go server.Serve(listener)
+	defer server.Close()
+
+	authURL := config.AuthCodeURL(state, oauth2.AccessTypeOffline, oauth2.ApprovalForce, oauth2.S256ChallengeOption(verifier))
+	s.logger.Info("sign in to Google to use GTM tools", "url", authURL)
+	if err := openBrowser(authURL); err != nil {
+		s.logger.Warn("could not open a browser, open the URL manually", "error", err)
+	}
+
+	var res result
+	select {
+	case res = <-results:
+	case <-time.After(localLoginTimeout):
+		return nil, fmt.Errorf("login timed out after %s", localLoginTimeout)
+	}
+	if res.err != nil {
+		return nil, res.err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	token, err := config.Exchange(ctx, res.code, oauth2.VerifierOption(verifier))
+	if err != nil {
+		return nil, fmt.Errorf("failed to exchange code: %w", err)
+	}
+	if token.RefreshToken == "" {
+		return nil, fmt.Errorf("google did not return a refresh token")
+	}
+	s.logger.Info("signed in to Google", "file", s.path)
+	return token, nil
+}
+
+// save writes the refresh token to the credentials file, readable only by the user.
+func (s *LocalTokenSource) save(token *oauth2.Token) error {
+	data, err := json.MarshalIndent(LocalCredentials{RefreshToken: token.RefreshToken}, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(s.path), 0o700); err != nil {
+		return fmt.Errorf("failed to create credentials directory: %w", err)
+	}
+	if err := os.WriteFile(s.path, data, 0o600); err != nil {
+		return fmt.Errorf("failed to write credentials file: %w", err)
+	}
+	return nil
+}
+
+// LocalContext returns a context authenticated as the local stdio user.
+func LocalContext(ctx context.Context, tokenSource oauth2.TokenSource) context.Context {
+	tokenInfo := &TokenInfo{
+		ClientID:  "stdio",
+		CreatedAt: time.Now(),
+	}
+	ctx = context.WithValue(ctx, TokenInfoKey, tokenInfo)
+	return context.WithValue(ctx, GoogleTokenSourceKey, tokenSource)
+}
+
+// openBrowser opens url in the user's default browser.
+func openBrowser(url string) error {
+	var cmd *exec.Cmd
+	switch runtime.GOOS {
+	case "darwin":
+		cmd = exec.Command("open", url)
+	case "windows":
+		cmd = exec.Command("rundll32", "url.dll,FileProtocolHandler", url)
+	default:
+		cmd = exec.Command("xdg-open", url)
+	}
+	return cmd.Start()
+}
@@ -0,0 +1,61 @@
+package auth
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"golang.org/x/oauth2"
+)
+
+func TestLocalTokenSource_SavedCredentials(t *testing.T) {
+	var gotRefresh string
+	tokenServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		r.ParseForm()
+		gotRefresh = r.FormValue("refresh_token")
+		w.Header().Set("Content-Type", "application/json")
+		io.WriteString(w, `{"access_token":"google-access","token_type":"Bearer","expires_in":3600}`)
+	}))
+	defer tokenServer.Close()
+
+	path := filepath.Join(t.TempDir(), "gtm", "credentials.json")
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	ts := NewLocalTokenSource("client", "secret", path, logger)
+	ts.config.Endpoint = oauth2.Endpoint{TokenURL: tokenServer.URL}
+
+	if err := ts.save(&oauth2.Token{RefreshToken: "saved-refresh"}); err != nil {
+		t.Fatal(err)
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Mode().Perm() != 0o600 {
+		t.Errorf("credentials file mode = %v, want 0600", info.Mode().Perm())
+	}
+
+	token, err := ts.Token()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if token.AccessToken != "google-access" || gotRefresh != "saved-refresh" {
+		t.Errorf("token = %+v, refresh sent = %q", token, gotRefresh)
+	}
+}
+
+func TestLocalContext(t *testing.T) {
+	ts := oauth2.StaticTokenSource(&oauth2.Token{AccessToken: "x"})
+	ctx := LocalContext(context.Background(), ts)
+
+	if info := GetTokenInfo(ctx); info == nil || info.ClientID != "stdio" {
+		t.Errorf("token info = %+v", info)
+	}
+	if GetGoogleTokenSource(ctx) != ts {
+		t.Error("expected the local token source in the context")
+	}
+}
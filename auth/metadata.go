@@ -25,7 +25,7 @@ func NewOAuthMetadata(baseURL string) *OAuthMetadata {
 		AuthorizationEndpoint: baseURL + "/authorize",
 		TokenEndpoint:         baseURL + "/token",
 		RegistrationEndpoint:  baseURL + "/register",
-		ScopesSupported: GoogleScopes,
+		ScopesSupported: supportedScopes(),
 		ResponseTypesSupported:            []string{"code"},
 		GrantTypesSupported:               []string{"authorization_code", "refresh_token"},
 		TokenEndpointAuthMethodsSupported: []string{"client_secret_post", "none"},
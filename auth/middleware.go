@@ -7,6 +7,7 @@ import (
 	"log/slog"
 	"net/http"
 	"strings"
+	"time"
 
 	"golang.org/x/oauth2"
 )
@@ -23,12 +24,24 @@ const (
 	TokenStoreKey ContextKey = "token_store"
 	// GoogleProviderKey is the context key for the Google OAuth provider.
 	GoogleProviderKey ContextKey = "google_provider"
+	// GoogleTokenSourceKey is the context key for a Google token source
+	// supplied by API key authentication.
+	GoogleTokenSourceKey ContextKey = "google_token_source"
 )
 
 // Middleware creates HTTP middleware that validates bearer tokens.
-func Middleware(store TokenStore, google *GoogleProvider, logger *slog.Logger, baseURL string) func(http.Handler) http.Handler {
+// When apiKeys is non-nil, requests carrying an X-API-Key header, or a
+// bearer token matching a configured API key, are authenticated against it
+// instead.
+func Middleware(store TokenStore, google *GoogleProvider, apiKeys *APIKeyStore, logger *slog.Logger, baseURL string) func(http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			// Static API key branch for trusted internal clients
+			if apiKeys != nil && r.Header.Get(APIKeyHeader) != "" {
+				serveWithAPIKey(w, r, next, apiKeys, logger, baseURL)
+				return
+			}
+
 			// Extract bearer token from Authorization header
 			authHeader := r.Header.Get("Authorization")
 			if authHeader == "" {
@@ -44,6 +57,12 @@ func Middleware(store TokenStore, google *GoogleProvider, logger *slog.Logger, b
 
 			accessToken := parts[1]
 
+			// Pre-shared API keys may also be sent as bearer tokens
+			if key, err := apiKeys.Lookup(accessToken); err == nil {
+				serveAPIKey(w, r, next, key, logger)
+				return
+			}
+
 			// Look up the token
 			tokenInfo, err := store.GetTokenByAccess(accessToken)
 			if err != nil {
@@ -55,6 +74,16 @@ func Middleware(store TokenStore, google *GoogleProvider, logger *slog.Logger, b
 				return
 			}
 
+			// RFC 8707: reject tokens issued for a different resource
+			if tokenInfo.Resource != "" && !resourceMatches(tokenInfo.Resource, baseURL) {
+				logger.Warn("rejected token for another resource",
+					"client_id", tokenInfo.ClientID,
+					"resource", tokenInfo.Resource,
+				)
+				unauthorized(w, baseURL, "Token not valid for this resource")
+				return
+			}
+
 			// Add token info and dependencies to context
 			ctx := context.WithValue(r.Context(), TokenInfoKey, tokenInfo)
 			ctx = context.WithValue(ctx, GoogleTokenKey, tokenInfo.GoogleToken)
@@ -70,6 +99,61 @@ func Middleware(store TokenStore, google *GoogleProvider, logger *slog.Logger, b
 	}
 }
 
+// APIKeyMiddleware creates HTTP middleware that only accepts static API keys,
+// sent in the X-API-Key header or as a bearer token. It is used when the
+// OAuth server is not configured.
+func APIKeyMiddleware(apiKeys *APIKeyStore, logger *slog.Logger, baseURL string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Header.Get(APIKeyHeader) != "" {
+				serveWithAPIKey(w, r, next, apiKeys, logger, baseURL)
+				return
+			}
+
+			parts := strings.SplitN(r.Header.Get("Authorization"), " ", 2)
+			if len(parts) != 2 || !strings.EqualFold(parts[0], "bearer") {
+				unauthorized(w, baseURL, "Missing API key")
+				return
+			}
+			key, err := apiKeys.Lookup(parts[1])
+			if err != nil {
+				logger.Warn("rejected API key", "remote_addr", r.RemoteAddr)
+				unauthorized(w, baseURL, "Invalid API key")
+				return
+			}
+			serveAPIKey(w, r, next, key, logger)
+		})
+	}
+}
+
+// serveWithAPIKey authenticates the request's X-API-Key header.
+func serveWithAPIKey(w http.ResponseWriter, r *http.Request, next http.Handler, apiKeys *APIKeyStore, logger *slog.Logger, baseURL string) {
+	key, err := apiKeys.Lookup(r.Header.Get(APIKeyHeader))
+	if err != nil {
+		logger.Warn("rejected API key", "remote_addr", r.RemoteAddr)
+		unauthorized(w, baseURL, "Invalid API key")
+		return
+	}
+	serveAPIKey(w, r, next, key, logger)
+}
+
+// serveAPIKey adds an authenticated API key's Google token source to the context.
+func serveAPIKey(w http.ResponseWriter, r *http.Request, next http.Handler, key *APIKey, logger *slog.Logger) {
+	tokenInfo := &TokenInfo{
+		ClientID:  "api-key:" + key.Name,
+		Scope:     key.Scope,
+		CreatedAt: time.Now(),
+	}
+	ctx := context.WithValue(r.Context(), TokenInfoKey, tokenInfo)
+	ctx = context.WithValue(ctx, GoogleTokenSourceKey, key.TokenSource())
+
+	logger.Debug("authenticated request",
+		"client_id", tokenInfo.ClientID,
+	)
+
+	next.ServeHTTP(w, r.WithContext(ctx))
+}
+
 // OptionalMiddleware allows unauthenticated requests but adds token info if present.
 func OptionalMiddleware(store TokenStore, logger *slog.Logger) func(http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
@@ -117,6 +201,24 @@ func GetGoogleToken(ctx context.Context) *oauth2.Token {
 	return nil
 }
 
+// WithIdentity returns ctx authenticated as the caller of from: its token,
+// token store, Google provider and Google token source, e.g. to run a call
+// held for approval with the credentials of the user who made it.
+func WithIdentity(ctx, from context.Context) context.Context {
+	for _, key := range []ContextKey{TokenInfoKey, GoogleTokenKey, TokenStoreKey, GoogleProviderKey, GoogleTokenSourceKey} {
+		ctx = context.WithValue(ctx, key, from.Value(key))
+	}
+	return ctx
+}
+
+// GetGoogleTokenSource retrieves the Google token source set by API key authentication.
+func GetGoogleTokenSource(ctx context.Context) oauth2.TokenSource {
+	if ts, ok := ctx.Value(GoogleTokenSourceKey).(oauth2.TokenSource); ok {
+		return ts
+	}
+	return nil
+}
+
 // GetTokenStore retrieves the TokenStore from context.
 func GetTokenStore(ctx context.Context) TokenStore {
 	if store, ok := ctx.Value(TokenStoreKey).(TokenStore); ok {
@@ -18,7 +18,7 @@ func NewProtectedResourceMetadata(baseURL, resourceURL string) *ProtectedResourc
 	return &ProtectedResourceMetadata{
 		Resource:               resourceURL,
 		AuthorizationServers:   []string{baseURL},
-		ScopesSupported:        GoogleScopes,
+		ScopesSupported:        supportedScopes(),
 		BearerMethodsSupported: []string{"header"},
 	}
 }
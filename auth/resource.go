@@ -0,0 +1,54 @@
+package auth
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// normalizeResource canonicalizes a resource indicator (RFC 8707) for
+// comparison: absolute URL, lowercase scheme and host, no query or fragment
+// and no trailing slash.
+func normalizeResource(resource string) (string, error) {
+	u, err := url.Parse(resource)
+	if err != nil || !u.IsAbs() || u.Host == "" {
+		return "", fmt.Errorf("resource must be an absolute URI")
+	}
+	if u.Fragment != "" {
+		return "", fmt.Errorf("resource must not contain a fragment")
+	}
+	u.Scheme = strings.ToLower(u.Scheme)
+	u.Host = strings.ToLower(u.Host)
+	u.RawQuery = ""
+	u.Path = strings.TrimSuffix(u.Path, "/")
+	u.RawPath = ""
+	return u.String(), nil
+}
+
+// resourceMatches reports whether resource identifies the MCP server at
+// baseURL. The MCP endpoint is served on every path below the base URL, so
+// any resource on the same origin and under the base path matches.
+func resourceMatches(resource, baseURL string) bool {
+	res, err := normalizeResource(resource)
+	if err != nil {
+		return false
+	}
+	base, err := normalizeResource(baseURL)
+	if err != nil {
+		return false
+	}
+	return res == base || strings.HasPrefix(res, base+"/")
+}
+
+// bindResource validates a requested resource indicator against the server
+// and returns the audience to bind the token to. An empty request binds the
+// token to the base URL.
+func bindResource(requested, baseURL string) (string, error) {
+	if requested == "" {
+		return normalizeResource(baseURL)
+	}
+	if !resourceMatches(requested, baseURL) {
+		return "", fmt.Errorf("resource %s is not served by this server", requested)
+	}
+	return normalizeResource(requested)
+}
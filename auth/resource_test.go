@@ -0,0 +1,126 @@
+package auth
+
+import (
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestBindResource(t *testing.T) {
+	tests := []struct {
+		name      string
+		requested string
+		baseURL   string
+		want      string
+		wantErr   bool
+	}{
+		{name: "default", baseURL: "https://gtm.example.com", want: "https://gtm.example.com"},
+		{name: "base URL", requested: "https://gtm.example.com/", baseURL: "https://gtm.example.com", want: "https://gtm.example.com"},
+		{name: "MCP path", requested: "https://GTM.example.com/mcp", baseURL: "https://gtm.example.com", want: "https://gtm.example.com/mcp"},
+		{name: "under base path", requested: "https://example.com/gtm/mcp", baseURL: "https://example.com/gtm", want: "https://example.com/gtm/mcp"},
+		{name: "other host", requested: "https://evil.example.com/mcp", baseURL: "https://gtm.example.com", wantErr: true},
+		{name: "other scheme", requested: "http://gtm.example.com", baseURL: "https://gtm.example.com", wantErr: true},
+		{name: "outside base path", requested: "https://example.com/gtmx", baseURL: "https://example.com/gtm", wantErr: true},
+		{name: "relative", requested: "/mcp", baseURL: "https://gtm.example.com", wantErr: true},
+		{name: "fragment", requested: "https://gtm.example.com/mcp#x", baseURL: "https://gtm.example.com", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := bindResource(tt.requested, tt.baseURL)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestServer_AuthorizeHandler_InvalidResource(t *testing.T) {
+	store := NewMemoryTokenStore()
+	defer store.Close()
+
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	server := NewServer("http://localhost:8080", nil, store, logger)
+
+	params := url.Values{}
+	params.Set("response_type", "code")
+	params.Set("state", "test-state")
+	params.Set("redirect_uri", "http://localhost:8080/callback")
+	params.Set("code_challenge", "test")
+	params.Set("code_challenge_method", "S256")
+	params.Set("resource", "https://other.example.com/mcp")
+
+	req := httptest.NewRequest(http.MethodGet, "/authorize?"+params.Encode(), nil)
+	w := httptest.NewRecorder()
+
+	server.AuthorizeHandler(w, req)
+
+	if w.Code != http.StatusBadRequest || !strings.Contains(w.Body.String(), "invalid_target") {
+		t.Errorf("expected invalid_target, got %d: %s", w.Code, w.Body.String())
+	}
+}
+
+func TestServer_HandleAuthorizationCodeGrant_ResourceMismatch(t *testing.T) {
+	store := NewMemoryTokenStore()
+	defer store.Close()
+
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	server := NewServer("http://localhost:8080", nil, store, logger)
+
+	store.StoreState(&AuthState{
+		State:        "valid-code",
+		CodeVerifier: "test-challenge",
+		Resource:     "http://localhost:8080/mcp",
+		CreatedAt:    time.Now(),
+	})
+
+	form := url.Values{}
+	form.Set("grant_type", "authorization_code")
+	form.Set("code", "valid-code")
+	form.Set("code_verifier", "verifier")
+	form.Set("resource", "http://localhost:8080/other")
+
+	req := httptest.NewRequest(http.MethodPost, "/token", strings.NewReader(form.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	w := httptest.NewRecorder()
+
+	server.TokenHandler(w, req)
+
+	if w.Code != http.StatusBadRequest || !strings.Contains(w.Body.String(), "invalid_target") {
+		t.Errorf("expected invalid_target, got %d: %s", w.Code, w.Body.String())
+	}
+}
+
+func TestMiddleware_RejectsTokenForOtherResource(t *testing.T) {
+	store := NewMemoryTokenStore()
+	defer store.Close()
+
+	for access, resource := range map[string]string{
+		"bound":   "https://gtm.example.com/mcp",
+		"foreign": "https://other.example.com/mcp",
+		"legacy":  "",
+	} {
+		store.StoreToken(&TokenInfo{AccessToken: access, ExpiresAt: time.Now().Add(time.Hour), Resource: resource})
+	}
+
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {})
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	handler := Middleware(store, nil, nil, logger, "https://gtm.example.com")(next)
+
+	for token, want := range map[string]int{"bound": http.StatusOK, "foreign": http.StatusUnauthorized, "legacy": http.StatusOK} {
+		req := httptest.NewRequest(http.MethodPost, "/mcp", nil)
+		req.Header.Set("Authorization", "Bearer "+token)
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, req)
+		if w.Code != want {
+			t.Errorf("token %s: status %d, want %d", token, w.Code, want)
+		}
+	}
+}
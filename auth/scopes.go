@@ -0,0 +1,49 @@
+package auth
+
+import (
+	"context"
+	"slices"
+	"strings"
+)
+
+// MCP scopes a client can request in addition to the Google scopes. A token
+// granted gtm:read without gtm:write can only call read tools; tokens without
+// either scope have full access.
+const (
+	ScopeRead  = "gtm:read"
+	ScopeWrite = "gtm:write"
+)
+
+// ServerScopes are the MCP scopes this server understands.
+var ServerScopes = []string{ScopeRead, ScopeWrite}
+
+// supportedScopes lists every scope advertised in the OAuth metadata.
+func supportedScopes() []string {
+	return slices.Concat(GoogleScopes, ServerScopes)
+}
+
+// ParseScope keeps the MCP scopes of a space-separated scope request, in
+// canonical order. Other scopes (e.g. the advertised Google scopes) are
+// ignored because they are not granted per token.
+func ParseScope(scope string) string {
+	requested := strings.Fields(scope)
+	var granted []string
+	for _, s := range ServerScopes {
+		if slices.Contains(requested, s) {
+			granted = append(granted, s)
+		}
+	}
+	return strings.Join(granted, " ")
+}
+
+// IsReadOnlyScope reports whether a granted scope only allows reads.
+func IsReadOnlyScope(scope string) bool {
+	fields := strings.Fields(scope)
+	return slices.Contains(fields, ScopeRead) && !slices.Contains(fields, ScopeWrite)
+}
+
+// IsReadOnly reports whether the request was authenticated with a read-only token.
+func IsReadOnly(ctx context.Context) bool {
+	info := GetTokenInfo(ctx)
+	return info != nil && IsReadOnlyScope(info.Scope)
+}
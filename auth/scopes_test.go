@@ -0,0 +1,69 @@
+package auth
+
+import (
+	"context"
+	"slices"
+	"testing"
+)
+
+func TestParseScope(t *testing.T) {
+	tests := []struct {
+		scope string
+		want  string
+	}{
+		{scope: "", want: ""},
+		{scope: "gtm:read", want: "gtm:read"},
+		{scope: "gtm:write  gtm:read", want: "gtm:read gtm:write"},
+		{scope: "https://www.googleapis.com/auth/tagmanager.publish gtm:read", want: "gtm:read"},
+		{scope: "openid", want: ""},
+	}
+	for _, tt := range tests {
+		if got := ParseScope(tt.scope); got != tt.want {
+			t.Errorf("ParseScope(%q) = %q, want %q", tt.scope, got, tt.want)
+		}
+	}
+}
+
+func TestIsReadOnly(t *testing.T) {
+	if !IsReadOnlyScope("gtm:read") {
+		t.Error("gtm:read should be read-only")
+	}
+	if IsReadOnlyScope("gtm:read gtm:write") || IsReadOnlyScope("") {
+		t.Error("write and unscoped tokens should not be read-only")
+	}
+
+	if IsReadOnly(context.Background()) {
+		t.Error("unauthenticated context should not be read-only")
+	}
+	ctx := context.WithValue(context.Background(), TokenInfoKey, &TokenInfo{Scope: ScopeRead})
+	if !IsReadOnly(ctx) {
+		t.Error("gtm:read token should be read-only")
+	}
+}
+
+func TestParseGoogleScopes(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    []string
+		wantErr bool
+	}{
+		{value: "", want: GoogleScopeSets["full"]},
+		{value: "Edit", want: []string{GoogleScopeEditContainers, GoogleScopeEditVersions}},
+		{value: "readonly", want: []string{GoogleScopeReadonly}},
+		{value: "edit.containers, " + GoogleScopePublish, want: []string{GoogleScopeEditContainers, GoogleScopePublish}},
+		{value: "edit.containers,manage.users", wantErr: true},
+		{value: "readonly,analytics.readonly", want: []string{GoogleScopeReadonly, GoogleScopeAnalyticsReadonly}},
+		{value: "edit,edit.containers", want: []string{GoogleScopeEditContainers, GoogleScopeEditVersions}},
+		{value: " , ", wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := ParseGoogleScopes(tt.value)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParseGoogleScopes(%q) error = %v, wantErr %v", tt.value, err, tt.wantErr)
+			continue
+		}
+		if !slices.Equal(got, tt.want) {
+			t.Errorf("ParseGoogleScopes(%q) = %v, want %v", tt.value, got, tt.want)
+		}
+	}
+}
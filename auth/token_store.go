@@ -31,6 +31,8 @@ type TokenInfo struct {
 
 	// Metadata
 	ClientID  string
+	Scope     string // granted MCP scopes, e.g. "gtm:read" (empty = full access)
+	Resource  string // RFC 8707 audience: the MCP resource URL the token is valid for
 	CreatedAt time.Time
 }
 
@@ -40,7 +42,8 @@ type AuthState struct {
 	CodeVerifier string
 	RedirectURI  string
 	ClientID     string
-	Resource     string // RFC 9728: resource parameter for audience binding
+	Resource     string // RFC 8707: resource the issued token is bound to
+	Scope        string // granted MCP scopes
 	CreatedAt    time.Time
 }
 
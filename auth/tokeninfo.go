@@ -0,0 +1,47 @@
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"golang.org/x/oauth2"
+)
+
+// googleTokenInfoURL is Google's OAuth access token introspection endpoint.
+var googleTokenInfoURL = "https://oauth2.googleapis.com/tokeninfo"
+
+// GrantedGoogleScopes returns the Google scopes the current access token of
+// tokenSource was granted. A user may grant fewer scopes than the server
+// requests, and API keys carry whatever their refresh token or service
+// account was issued with.
+func GrantedGoogleScopes(ctx context.Context, tokenSource oauth2.TokenSource) ([]string, error) {
+	token, err := tokenSource.Token()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get Google token: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, googleTokenInfoURL+"?"+url.Values{"access_token": {token.AccessToken}}.Encode(), nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to look up Google token scopes: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to look up Google token scopes: status %d", resp.StatusCode)
+	}
+
+	var info struct {
+		Scope string `json:"scope"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&info); err != nil {
+		return nil, fmt.Errorf("failed to decode Google token info: %w", err)
+	}
+	return strings.Fields(info.Scope), nil
+}
@@ -0,0 +1,39 @@
+package auth
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"golang.org/x/oauth2"
+)
+
+func TestGrantedGoogleScopes(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("access_token") != "google-access" {
+			w.WriteHeader(http.StatusBadRequest)
+			io.WriteString(w, `{"error":"invalid_token"}`)
+			return
+		}
+		io.WriteString(w, `{"azp":"client","scope":"`+GoogleScopeReadonly+` `+GoogleScopePublish+`","expires_in":"3599"}`)
+	}))
+	defer srv.Close()
+	old := googleTokenInfoURL
+	googleTokenInfoURL = srv.URL
+	defer func() { googleTokenInfoURL = old }()
+
+	scopes, err := GrantedGoogleScopes(context.Background(), oauth2.StaticTokenSource(&oauth2.Token{AccessToken: "google-access"}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{GoogleScopeReadonly, GoogleScopePublish}; !reflect.DeepEqual(scopes, want) {
+		t.Errorf("scopes = %v, want %v", scopes, want)
+	}
+
+	if _, err := GrantedGoogleScopes(context.Background(), oauth2.StaticTokenSource(&oauth2.Token{AccessToken: "expired"})); err == nil {
+		t.Error("expected an error for a rejected token")
+	}
+}
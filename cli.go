@@ -0,0 +1,195 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+
+	"github.com/paolobietolini/gtm-mcp-server/auth"
+	"github.com/paolobietolini/gtm-mcp-server/config"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"golang.org/x/oauth2"
+)
+
+// Exit codes of the call subcommand.
+const (
+	exitToolError = 1 // the tool or the call failed
+	exitUsage     = 2 // bad command line or arguments
+)
+
+const callUsage = `usage: gtm-mcp-server call <tool> [--json '{"accountId": "..."}'] [key=value | key:=json ...]
+
+Invokes one tool with the stored local credentials and prints its JSON
+output. --json - reads the arguments from stdin. key=value sets a string
+argument and key:=json a JSON one, such as pageSize:=50 or
+tagIds:='["1","2"]'; both override --json. Tool errors are printed to
+stderr with exit code 1.`
+
+// runCall runs the call subcommand: it invokes one tool for scripts and CI
+// pipelines and returns the process exit code. The call goes through an
+// in-memory MCP session, so it runs the same handlers, validation and
+// safety checks as a call from an MCP client.
+func runCall(server *mcp.Server, cfg *config.Config, args []string) int {
+	if len(args) == 0 || strings.HasPrefix(args[0], "-") {
+		fmt.Fprintln(os.Stderr, callUsage)
+		return exitUsage
+	}
+	name := args[0]
+
+	flags := flag.NewFlagSet("call", flag.ContinueOnError)
+	flags.Usage = func() { fmt.Fprintln(os.Stderr, callUsage) }
+	rawArgs := flags.String("json", "{}", "tool arguments as a JSON object, or - to read them from stdin")
+	// Flags and key=value pairs may be interleaved
+	var pairs []string
+	for rest := args[1:]; ; rest = flags.Args()[1:] {
+		if err := flags.Parse(rest); err != nil {
+			return exitUsage
+		}
+		if flags.NArg() == 0 {
+			break
+		}
+		pairs = append(pairs, flags.Arg(0))
+	}
+
+	arguments, err := callArguments(*rawArgs, pairs, os.Stdin)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return exitUsage
+	}
+
+	if err := cfg.ValidateGoogleClient(); err != nil {
+		fmt.Fprintf(os.Stderr, "call: %v\n", err)
+		return exitUsage
+	}
+	tokenSource, _, err := localTokenSource(cfg)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return exitToolError
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	res, err := callTool(ctx, server, tokenSource, name, arguments)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "call %s: %v\n", name, err)
+		return exitToolError
+	}
+	return printCallResult(os.Stdout, os.Stderr, res)
+}
+
+// callArguments decodes the --json flag, or stdin when it is "-", into a
+// tool arguments object and sets the key=value and key:=json pairs on it.
+func callArguments(raw string, pairs []string, stdin io.Reader) (map[string]any, error) {
+	if raw == "-" {
+		data, err := io.ReadAll(stdin)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read arguments from stdin: %w", err)
+		}
+		raw = string(data)
+	}
+	arguments := map[string]any{}
+	if strings.TrimSpace(raw) != "" {
+		if err := decodeJSON(raw, &arguments); err != nil {
+			return nil, fmt.Errorf("--json must be a JSON object: %w", err)
+		}
+		if arguments == nil {
+			return nil, errors.New("--json must be a JSON object, not null")
+		}
+	}
+
+	for _, pair := range pairs {
+		key, value, ok := strings.Cut(pair, "=")
+		if !ok || key == "" || key == ":" {
+			return nil, fmt.Errorf("argument %q is not key=value or key:=json", pair)
+		}
+		if jsonKey, isJSON := strings.CutSuffix(key, ":"); isJSON {
+			var v any
+			if err := decodeJSON(value, &v); err != nil {
+				return nil, fmt.Errorf("argument %s: invalid JSON value: %w", jsonKey, err)
+			}
+			arguments[jsonKey] = v
+			continue
+		}
+		arguments[key] = value
+	}
+	return arguments, nil
+}
+
+// decodeJSON decodes exactly one JSON value, keeping numbers as written.
+func decodeJSON(data string, v any) error {
+	dec := json.NewDecoder(strings.NewReader(data))
+	dec.UseNumber()
+	if err := dec.Decode(v); err != nil {
+		return err
+	}
+	if dec.More() {
+		return errors.New("unexpected data after the JSON value")
+	}
+	return nil
+}
+
+// callTool connects an in-process MCP client to server, authenticated as
+// the local user, and calls one tool.
+func callTool(ctx context.Context, server *mcp.Server, tokenSource oauth2.TokenSource, name string, arguments map[string]any) (*mcp.CallToolResult, error) {
+	serverTransport, clientTransport := mcp.NewInMemoryTransports()
+	serverSession, err := server.Connect(auth.LocalContext(ctx, tokenSource), serverTransport, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer serverSession.Close()
+
+	client := mcp.NewClient(&mcp.Implementation{Name: serverName + "-cli", Version: serverVersion}, nil)
+	session, err := client.Connect(ctx, clientTransport, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer session.Close()
+
+	return session.CallTool(ctx, &mcp.CallToolParams{Name: name, Arguments: arguments})
+}
+
+// printCallResult writes a tool's structured output, or its text when it
+// has none, to stdout and returns the exit code. Text blocks besides the
+// JSON output, such as workspace sync warnings, go to stderr, as does the
+// error of a failed tool.
+func printCallResult(stdout, stderr io.Writer, res *mcp.CallToolResult) int {
+	if res.IsError {
+		for _, c := range res.Content {
+			if text, ok := c.(*mcp.TextContent); ok {
+				fmt.Fprintln(stderr, text.Text)
+			}
+		}
+		return exitToolError
+	}
+
+	if res.StructuredContent == nil {
+		for _, c := range res.Content {
+			if text, ok := c.(*mcp.TextContent); ok {
+				fmt.Fprintln(stdout, text.Text)
+			}
+		}
+		return 0
+	}
+
+	data, err := json.MarshalIndent(res.StructuredContent, "", "  ")
+	if err != nil {
+		fmt.Fprintf(stderr, "failed to encode output: %v\n", err)
+		return exitToolError
+	}
+	fmt.Fprintln(stdout, string(data))
+	for _, c := range res.Content {
+		if text, ok := c.(*mcp.TextContent); ok && !json.Valid([]byte(text.Text)) {
+			fmt.Fprintln(stderr, text.Text)
+		}
+	}
+	return 0
+}
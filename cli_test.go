@@ -0,0 +1,143 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+func TestCallArguments(t *testing.T) {
+	tests := []struct {
+		name    string
+		raw     string
+		pairs   []string
+		stdin   string
+		want    map[string]any
+		wantErr bool
+	}{
+		{name: "default", raw: "{}", want: map[string]any{}},
+		{name: "empty", raw: "  ", want: map[string]any{}},
+		{
+			name: "json object",
+			raw:  `{"accountId": "1", "pageSize": 50, "confirm": true}`,
+			want: map[string]any{"accountId": "1", "pageSize": json.Number("50"), "confirm": true},
+		},
+		{
+			name:  "stdin",
+			raw:   "-",
+			stdin: `{"accountId": "1"}`,
+			want:  map[string]any{"accountId": "1"},
+		},
+		{
+			name:  "key=value is a string",
+			raw:   "{}",
+			pairs: []string{"accountId=123", "name=a=b", "notes="},
+			want:  map[string]any{"accountId": "123", "name": "a=b", "notes": ""},
+		},
+		{
+			name:  "key:=json",
+			raw:   "{}",
+			pairs: []string{"pageSize:=50", "confirm:=true", `tagIds:=["1","2"]`, `filter:={"type":"equals"}`},
+			want: map[string]any{
+				"pageSize": json.Number("50"),
+				"confirm":  true,
+				"tagIds":   []any{"1", "2"},
+				"filter":   map[string]any{"type": "equals"},
+			},
+		},
+		{
+			name:  "pairs override --json",
+			raw:   `{"accountId": "1", "containerId": "2"}`,
+			pairs: []string{"containerId=3"},
+			want:  map[string]any{"accountId": "1", "containerId": "3"},
+		},
+		{name: "array", raw: `[1]`, wantErr: true},
+		{name: "null", raw: `null`, wantErr: true},
+		{name: "malformed", raw: `{"accountId":`, wantErr: true},
+		{name: "trailing data", raw: `{} {}`, wantErr: true},
+		{name: "pair without =", raw: "{}", pairs: []string{"accountId"}, wantErr: true},
+		{name: "pair without key", raw: "{}", pairs: []string{"=1"}, wantErr: true},
+		{name: "pair without json key", raw: "{}", pairs: []string{":=1"}, wantErr: true},
+		{name: "bad json value", raw: "{}", pairs: []string{"pageSize:=fifty"}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := callArguments(tt.raw, tt.pairs, strings.NewReader(tt.stdin))
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("callArguments() = %v, want error", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("callArguments() = %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPrintCallResult(t *testing.T) {
+	tests := []struct {
+		name       string
+		res        *mcp.CallToolResult
+		wantCode   int
+		wantStdout string
+		wantStderr string
+	}{
+		{
+			name: "structured output",
+			res: &mcp.CallToolResult{
+				Content:           []mcp.Content{&mcp.TextContent{Text: `{"tagId":"7"}`}},
+				StructuredContent: map[string]any{"tagId": "7"},
+			},
+			wantStdout: "{\n  \"tagId\": \"7\"\n}\n",
+		},
+		{
+			name: "warnings go to stderr",
+			res: &mcp.CallToolResult{
+				Content: []mcp.Content{
+					&mcp.TextContent{Text: `{"tagId":"7"}`},
+					&mcp.TextContent{Text: "Warning: workspace 3 is behind"},
+				},
+				StructuredContent: map[string]any{"tagId": "7"},
+			},
+			wantStdout: "{\n  \"tagId\": \"7\"\n}\n",
+			wantStderr: "Warning: workspace 3 is behind\n",
+		},
+		{
+			name:       "text output",
+			res:        &mcp.CallToolResult{Content: []mcp.Content{&mcp.TextContent{Text: "pong"}}},
+			wantStdout: "pong\n",
+		},
+		{
+			name: "tool error",
+			res: &mcp.CallToolResult{
+				IsError: true,
+				Content: []mcp.Content{&mcp.TextContent{Text: `{"error":{"code":"NOT_FOUND"}}`}},
+			},
+			wantCode:   exitToolError,
+			wantStderr: "{\"error\":{\"code\":\"NOT_FOUND\"}}\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var stdout, stderr bytes.Buffer
+			if code := printCallResult(&stdout, &stderr, tt.res); code != tt.wantCode {
+				t.Errorf("exit code = %d, want %d", code, tt.wantCode)
+			}
+			if stdout.String() != tt.wantStdout {
+				t.Errorf("stdout = %q, want %q", stdout.String(), tt.wantStdout)
+			}
+			if stderr.String() != tt.wantStderr {
+				t.Errorf("stderr = %q, want %q", stderr.String(), tt.wantStderr)
+			}
+		})
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"os"
 	"strconv"
+	"strings"
 
 	"github.com/joho/godotenv"
 )
@@ -19,11 +20,122 @@ type Config struct {
 	GoogleClientSecret string
 	GoogleRedirectURI  string
 
+	// Google scopes to request: "full" (default), "edit", "readonly" or a
+	// comma-separated list of Tag Manager scopes
+	GoogleScopes string
+
 	// JWT configuration
 	JWTSecret string
 
+	// Access token format: "opaque" (random tokens kept in the token store) or
+	// "jwt" (signed tokens validated statelessly, for multi-node deployments)
+	AccessTokenFormat string
+
 	// Logging
 	LogLevel string
+	// Key fragments whose values are masked in logs, in addition to credentials and code parameters
+	LogRedactKeys []string
+
+	// Tool profile selecting which GTM tools are registered (empty = all)
+	ToolProfile string
+
+	// Reject mutating tools for every session; individual tokens can be
+	// restricted with the gtm:read scope instead
+	ReadOnly bool
+
+	// Optional YAML file of hashed API keys for clients that cannot use OAuth
+	APIKeysFile string
+
+	// Pre-shared API keys as "<key>:<refresh_token>" entries, for private
+	// deployments that do not run the OAuth server
+	MCPAPIKeys []string
+
+	// Tool names never registered, applied after ToolProfile
+	DisabledTools []string
+
+	// Optional YAML file overriding tool descriptions, hiding tools or adding aliases
+	ToolOverridesFile string
+
+	// Optional directory of YAML/JSON blueprints added to the built-in ones
+	BlueprintDir string
+
+	// Optional YAML file of naming rules checked by lint_naming
+	NamingRulesFile string
+
+	// Optional YAML file of workspace aliases for set_context and the
+	// default workspace of new sessions
+	ContextFile string
+
+	// Optional GitHub token raising the rate limit of search_gallery_templates
+	GitHubToken string
+
+	// Optional GitHub repository (owner/name) receiving a commit of the
+	// container export whenever a version is created or published, with
+	// the branch (empty = default branch), the directory of the exports and
+	// a token with contents write access (empty = GitHubToken)
+	GitHubBackupRepo   string
+	GitHubBackupBranch string
+	GitHubBackupDir    string
+	GitHubBackupToken  string
+	// Container IDs or public IDs to back up (empty = every container)
+	GitHubBackupContainers []string
+
+	// Parameter key fragments whose values are redacted from tool output (empty = defaults)
+	SensitiveParamKeys []string
+
+	// Optional YAML file scheduling audit and backup runs for gtm://digest/latest
+	DigestFile string
+
+	// Google credentials file for --stdio mode (empty = user config directory)
+	CredentialsFile string
+
+	// Optional JSON file keeping scheduled publishes, and the API key whose
+	// Google credential publishes them (unused in --stdio mode, which
+	// publishes with the local credentials file)
+	PublishScheduleFile   string
+	PublishScheduleAPIKey string
+
+	// MCP endpoint rate limits per access token and per OAuth client ID
+	// (requests per second; 0 disables the limit)
+	TokenRateLimit  float64
+	TokenRateBurst  int
+	ClientRateLimit float64
+	ClientRateBurst int
+
+	// Per-user Google Tag Manager API quota: requests per minute (0 = no
+	// limit), concurrent requests (0 = no limit) and the longest a request
+	// is queued before failing
+	GTMQuotaPerMinute int
+	GTMMaxConcurrent  int
+	GTMQuotaMaxWait   int
+
+	// Retries of rate-limited GTM API calls: how many, the backoff ceiling
+	// of the first retry in milliseconds (doubled per retry, with full
+	// jitter) and the longest wait in seconds
+	GTMMaxRetries       int
+	GTMRetryBaseDelayMs int
+	GTMRetryMaxDelay    int
+
+	// Seconds tag, trigger and variable lists are cached per user and
+	// workspace (0 disables the cache)
+	ListCacheTTL int
+
+	// Audit log sinks for mutating tool calls: an append-only JSON Lines
+	// file and a webhook receiving each event (empty = memory only)
+	AuditLogFile    string
+	AuditWebhookURL string
+
+	// Approval mode: mutating tool calls wait for approve_change. Approvals
+	// can be restricted to client IDs, decided by a webhook and expire after
+	// ApprovalTTL seconds
+	ApprovalMode       bool
+	ApprovalClientIDs  []string
+	ApprovalWebhookURL string
+	ApprovalTTL        int
+
+	// Largest serialized tool result in bytes; larger results are truncated
+	// and continued with get_continuation (0 disables the limit)
+	MaxToolOutputBytes int
 }
 
 // Load reads configuration from environment variables.
@@ -40,8 +152,49 @@ func Load() (*Config, error) {
 		GoogleClientID:    getEnv("GOOGLE_CLIENT_ID", ""),
 		GoogleClientSecret: getEnv("GOOGLE_CLIENT_SECRET", ""),
 		GoogleRedirectURI: getEnv("GOOGLE_REDIRECT_URI", ""),
+		GoogleScopes:      getEnv("GOOGLE_SCOPES", ""),
 		JWTSecret:         getEnv("JWT_SECRET", ""),
+		AccessTokenFormat: getEnv("ACCESS_TOKEN_FORMAT", "opaque"),
 		LogLevel:          getEnv("LOG_LEVEL", "info"),
+		LogRedactKeys:     getEnvList("LOG_REDACT_KEYS"),
+		ToolProfile:       getEnv("TOOL_PROFILE", ""),
+		ReadOnly:          getEnvBool("READ_ONLY", false),
+		DisabledTools:     getEnvList("DISABLED_TOOLS"),
+		ToolOverridesFile: getEnv("TOOL_OVERRIDES_FILE", ""),
+		BlueprintDir:      getEnv("BLUEPRINT_DIR", ""),
+		NamingRulesFile:   getEnv("NAMING_RULES_FILE", ""),
+		ContextFile:       getEnv("CONTEXT_FILE", ""),
+		GitHubToken:       getEnv("GITHUB_TOKEN", ""),
+		GitHubBackupRepo:  getEnv("GITHUB_BACKUP_REPO", ""),
+		GitHubBackupBranch: getEnv("GITHUB_BACKUP_BRANCH", ""),
+		GitHubBackupDir:   getEnv("GITHUB_BACKUP_DIR", ""),
+		GitHubBackupToken: getEnv("GITHUB_BACKUP_TOKEN", ""),
+		GitHubBackupContainers: getEnvList("GITHUB_BACKUP_CONTAINERS"),
+		APIKeysFile:       getEnv("API_KEYS_FILE", ""),
+		MCPAPIKeys:        getEnvList("MCP_API_KEYS"),
+		SensitiveParamKeys: getEnvList("SENSITIVE_PARAM_KEYS"),
+		DigestFile:        getEnv("DIGEST_FILE", ""),
+		CredentialsFile:   getEnv("GTM_CREDENTIALS_FILE", ""),
+		PublishScheduleFile: getEnv("PUBLISH_SCHEDULE_FILE", ""),
+		PublishScheduleAPIKey: getEnv("PUBLISH_SCHEDULE_API_KEY", ""),
+		TokenRateLimit:    getEnvFloat("TOKEN_RATE_LIMIT", 5),
+		TokenRateBurst:    getEnvInt("TOKEN_RATE_BURST", 20),
+		ClientRateLimit:   getEnvFloat("CLIENT_RATE_LIMIT", 0),
+		ClientRateBurst:   getEnvInt("CLIENT_RATE_BURST", 50),
+		GTMQuotaPerMinute: getEnvInt("GTM_QUOTA_PER_MINUTE", 15),
+		GTMMaxConcurrent:  getEnvInt("GTM_MAX_CONCURRENT", 4),
+		GTMQuotaMaxWait:   getEnvInt("GTM_QUOTA_MAX_WAIT", 30),
+		GTMMaxRetries:     getEnvInt("GTM_MAX_RETRIES", 3),
+		GTMRetryBaseDelayMs: getEnvInt("GTM_RETRY_BASE_DELAY_MS", 1000),
+		GTMRetryMaxDelay:  getEnvInt("GTM_RETRY_MAX_DELAY", 32),
+		ListCacheTTL:      getEnvInt("LIST_CACHE_TTL", 60),
+		AuditLogFile:      getEnv("AUDIT_LOG_FILE", ""),
+		AuditWebhookURL:   getEnv("AUDIT_WEBHOOK_URL", ""),
+		MaxToolOutputBytes: getEnvInt("MAX_TOOL_OUTPUT_BYTES", 100000),
+		ApprovalMode:      getEnvBool("APPROVAL_MODE", false),
+		ApprovalClientIDs: getEnvList("APPROVAL_CLIENT_IDS"),
+		ApprovalWebhookURL: getEnv("APPROVAL_WEBHOOK_URL", ""),
+		ApprovalTTL:       getEnvInt("APPROVAL_TTL", 86400),
 	}
 
 	// Validation is deferred to when auth is actually needed
@@ -51,6 +204,18 @@ func Load() (*Config, error) {
 	return cfg, nil
 }
 
+// ValidateGoogleClient checks that a Google OAuth client is configured, as
+// needed by --stdio mode and by API keys holding refresh tokens.
+func (c *Config) ValidateGoogleClient() error {
+	if c.GoogleClientID == "" {
+		return fmt.Errorf("GOOGLE_CLIENT_ID is required")
+	}
+	if c.GoogleClientSecret == "" {
+		return fmt.Errorf("GOOGLE_CLIENT_SECRET is required")
+	}
+	return nil
+}
+
 // ValidateAuth checks if OAuth credentials are configured.
 func (c *Config) ValidateAuth() error {
 	if c.GoogleClientID == "" {
@@ -72,6 +237,35 @@ func getEnv(key, defaultValue string) string {
 	return defaultValue
 }
 
+// getEnvList splits a comma-separated variable, dropping empty entries.
+func getEnvList(key string) []string {
+	var values []string
+	for _, v := range strings.Split(os.Getenv(key), ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			values = append(values, v)
+		}
+	}
+	return values
+}
+
+func getEnvBool(key string, defaultValue bool) bool {
+	if value := os.Getenv(key); value != "" {
+		if boolValue, err := strconv.ParseBool(value); err == nil {
+			return boolValue
+		}
+	}
+	return defaultValue
+}
+
+func getEnvFloat(key string, defaultValue float64) float64 {
+	if value := os.Getenv(key); value != "" {
+		if floatValue, err := strconv.ParseFloat(value, 64); err == nil {
+			return floatValue
+		}
+	}
+	return defaultValue
+}
+
 func getEnvInt(key string, defaultValue int) int {
 	if value := os.Getenv(key); value != "" {
 		if intValue, err := strconv.Atoi(value); err == nil {
@@ -15,11 +15,11 @@ type Account struct {
 
 // ListAccounts returns all GTM accounts accessible to the authenticated user.
 func (c *Client) ListAccounts(ctx context.Context) ([]Account, error) {
-	resp, err := retryWithBackoff(ctx, 3, func() (*tagmanager.ListAccountsResponse, error) {
+	resp, err := RetryWithBackoff(ctx, func() (*tagmanager.ListAccountsResponse, error) {
 		return c.Service.Accounts.List().Context(ctx).Do()
 	})
 	if err != nil {
-		return nil, mapGoogleError(err)
+		return nil, MapGoogleError(err)
 	}
 
 	return toAccounts(resp.Account), nil
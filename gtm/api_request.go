@@ -0,0 +1,88 @@
+package gtm
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"strings"
+
+	"google.golang.org/api/googleapi"
+)
+
+// apiRequestMethods are the HTTP methods the Tag Manager API uses.
+var apiRequestMethods = []string{http.MethodGet, http.MethodPost, http.MethodPut, http.MethodDelete}
+
+// APIResponse is the result of a raw Tag Manager API request.
+type APIResponse struct {
+	Status int    `json:"status"`
+	Path   string `json:"path"`           // resource path the request addressed
+	Body   any    `json:"body,omitempty"` // decoded JSON response
+}
+
+// APIRequest sends a request to the Tag Manager API path below
+// tagmanager/v2/, e.g. "accounts/1/containers/2/workspaces/3/tags" with an
+// optional "?query". It goes through the same quota, retry and error
+// handling as the typed methods.
+func (c *Client) APIRequest(ctx context.Context, method, path string, body any) (*APIResponse, error) {
+	method = strings.ToUpper(method)
+	if !slices.Contains(apiRequestMethods, method) {
+		return nil, fmt.Errorf("method must be one of %s", strings.Join(apiRequestMethods, ", "))
+	}
+	path = strings.TrimPrefix(path, "/")
+	resource, _, _ := strings.Cut(path, "?")
+	if (resource != "accounts" && !strings.HasPrefix(resource, "accounts/")) || strings.Contains(resource, "..") || strings.Contains(path, "#") {
+		return nil, fmt.Errorf("path must be relative to tagmanager/v2/ and start with accounts, e.g. accounts/123/containers/456")
+	}
+
+	var payload []byte
+	if body != nil {
+		if method == http.MethodGet || method == http.MethodDelete {
+			return nil, fmt.Errorf("%s requests don't take a body", method)
+		}
+		var err error
+		if payload, err = json.Marshal(body); err != nil {
+			return nil, fmt.Errorf("invalid body: %w", err)
+		}
+	}
+
+	url := googleapi.ResolveRelative(c.Service.BasePath, "tagmanager/v2/"+path)
+	result, err := RetryWithBackoff(ctx, func() (*APIResponse, error) {
+		req, err := http.NewRequestWithContext(ctx, method, url, bytes.NewReader(payload))
+		if err != nil {
+			return nil, err
+		}
+		if payload != nil {
+			req.Header.Set("Content-Type", "application/json")
+		}
+		resp, err := c.http.Do(req)
+		if err != nil {
+			return nil, err
+		}
+		defer resp.Body.Close()
+		if err := googleapi.CheckResponse(resp); err != nil {
+			return nil, err
+		}
+
+		data, err := io.ReadAll(resp.Body)
+		if err != nil {
+			return nil, err
+		}
+		result := &APIResponse{Status: resp.StatusCode, Path: apiPath(resource)}
+		if len(bytes.TrimSpace(data)) > 0 {
+			dec := json.NewDecoder(bytes.NewReader(data))
+			dec.UseNumber()
+			if err := dec.Decode(&result.Body); err != nil {
+				return nil, fmt.Errorf("API returned a non-JSON response: %w", err)
+			}
+		}
+		return result, nil
+	})
+	if err != nil {
+		return nil, MapGoogleError(err)
+	}
+	return result, nil
+}
@@ -0,0 +1,67 @@
+package gtm
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"testing"
+)
+
+func TestAPIRequest(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodPut && r.URL.Path == "/tagmanager/v2/accounts/1/containers/2/workspaces/3/tags/4":
+			body, _ := io.ReadAll(r.Body)
+			if r.URL.Query().Get("fingerprint") != "9" || string(body) != `{"name":"Renamed"}` {
+				t.Errorf("query %q, body %s", r.URL.RawQuery, body)
+			}
+			io.WriteString(w, `{"tagId":"4","name":"Renamed","fingerprint":"10"}`)
+		case r.Method == http.MethodDelete:
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			io.WriteString(w, `{"error":{"code":404,"message":"Not found"}}`)
+		}
+	})
+	ctx := context.Background()
+
+	resp, err := client.APIRequest(ctx, "put", "/accounts/1/containers/2/workspaces/3/tags/4?fingerprint=9", map[string]any{"name": "Renamed"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	body, _ := resp.Body.(map[string]any)
+	if resp.Status != http.StatusOK || resp.Path != "accounts/1/containers/2/workspaces/3/tags/4" || body["name"] != "Renamed" {
+		t.Errorf("response = %+v", resp)
+	}
+
+	resp, err = client.APIRequest(ctx, "DELETE", "accounts/1/containers/2/workspaces/3/tags/4", nil)
+	if err != nil || resp.Status != http.StatusNoContent || resp.Body != nil {
+		t.Errorf("delete: %+v, %v", resp, err)
+	}
+
+	if _, err := client.APIRequest(ctx, "GET", "accounts/1/containers/2/workspaces/3/tags/5", nil); !errors.Is(err, ErrNotFound) {
+		t.Errorf("missing tag: %v", err)
+	}
+}
+
+func TestAPIRequest_Invalid(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected request to %s", r.URL)
+	})
+	tests := []struct {
+		method, path string
+		body         any
+	}{
+		{method: "PATCH", path: "accounts/1"},
+		{method: "GET", path: "https://example.com/accounts/1"},
+		{method: "GET", path: "accounts/1/../../oauth2/v1/userinfo"},
+		{method: "GET", path: "userinfo"},
+		{method: "GET", path: "accounts/1", body: map[string]any{"name": "x"}},
+	}
+	for _, tt := range tests {
+		if _, err := client.APIRequest(context.Background(), tt.method, tt.path, tt.body); err == nil {
+			t.Errorf("%s %s succeeded", tt.method, tt.path)
+		}
+	}
+}
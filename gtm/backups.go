@@ -0,0 +1,266 @@
+package gtm
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	tagmanager "google.golang.org/api/tagmanager/v2"
+)
+
+const (
+	// DeleteTokenTTL is how long a delete_container confirmation token stays valid.
+	DeleteTokenTTL = 10 * time.Minute
+	// undeleteWindow is how long the export of a deleted container is kept for restore_container.
+	undeleteWindow = 7 * 24 * time.Hour
+	// maxBackups bounds the number of container backups kept in memory.
+	maxBackups = 50
+)
+
+var (
+	ErrInvalidDeleteToken = errors.New("invalid or expired confirmation token")
+	ErrBackupNotFound     = errors.New("container backup not found or expired")
+)
+
+// ContainerBackup is a full export of a container taken before deletion.
+type ContainerBackup struct {
+	BackupID     string            `json:"backupId"`
+	AccountID    string            `json:"accountId"`
+	ContainerID  string            `json:"containerId"`
+	Name         string            `json:"name"`
+	PublicID     string            `json:"publicId"`
+	UsageContext []string          `json:"usageContext"`
+	CreatedAt    time.Time         `json:"createdAt"`
+	DeletedAt    time.Time         `json:"deletedAt,omitzero"`
+	ExpiresAt    time.Time         `json:"expiresAt"`
+	Live         *ContainerExport  `json:"-"`
+	Workspaces   []WorkspaceBackup `json:"-"`
+
+	owner string // user who deleted the container
+}
+
+// WorkspaceBackup is the export of a single workspace in a container backup.
+type WorkspaceBackup struct {
+	WorkspaceID string
+	Name        string
+	Export      *ContainerExport
+}
+
+// RestoreExport returns the export used to restore the container: the live
+// version if one was published, otherwise the first workspace.
+func (b *ContainerBackup) RestoreExport() *ContainerExport {
+	if b.Live != nil {
+		return b.Live
+	}
+	if len(b.Workspaces) > 0 {
+		return b.Workspaces[0].Export
+	}
+	return nil
+}
+
+type pendingDeletion struct {
+	backupID  string
+	expiresAt time.Time
+}
+
+// backupStore keeps pending container deletions and the backups of deleted
+// containers in memory. Backups are lost when the server restarts. Tokens
+// and backups belong to the user who requested the deletion; other users
+// see them as missing.
+type backupStore struct {
+	mu      sync.Mutex
+	pending map[string]pendingDeletion  // keyed by confirmation token
+	backups map[string]*ContainerBackup // keyed by backup ID
+	now     func() time.Time
+}
+
+func newBackupStore() *backupStore {
+	return &backupStore{
+		pending: make(map[string]pendingDeletion),
+		backups: make(map[string]*ContainerBackup),
+		now:     time.Now,
+	}
+}
+
+// ContainerBackups is the process-wide backup store used by the container tools.
+var ContainerBackups = newBackupStore()
+
+// prune removes expired tokens and backups. The caller must hold s.mu.
+func (s *backupStore) prune() {
+	now := s.now()
+	for token, p := range s.pending {
+		if now.After(p.expiresAt) {
+			delete(s.pending, token)
+		}
+	}
+	for id, b := range s.backups {
+		if now.After(b.ExpiresAt) {
+			delete(s.backups, id)
+		}
+	}
+	for len(s.backups) > maxBackups {
+		var oldest *ContainerBackup
+		for _, b := range s.backups {
+			if oldest == nil || b.CreatedAt.Before(oldest.CreatedAt) {
+				oldest = b
+			}
+		}
+		delete(s.backups, oldest.BackupID)
+	}
+}
+
+// generateToken returns a random URL-safe token of length bytes.
+func generateToken(length int) (string, error) {
+	b := make([]byte, length)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.URLEncoding.EncodeToString(b), nil
+}
+
+// AddPending stores a backup awaiting deletion by owner and returns its
+// confirmation token.
+func (s *backupStore) AddPending(owner string, backup *ContainerBackup) (string, error) {
+	token, err := generateToken(24)
+	if err != nil {
+		return "", err
+	}
+	backupID, err := generateToken(12)
+	if err != nil {
+		return "", err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.prune()
+
+	now := s.now()
+	backup.BackupID = backupID
+	backup.owner = owner
+	backup.CreatedAt = now
+	backup.ExpiresAt = now.Add(DeleteTokenTTL)
+	s.backups[backupID] = backup
+	s.pending[token] = pendingDeletion{backupID: backupID, expiresAt: now.Add(DeleteTokenTTL)}
+	return token, nil
+}
+
+// Consume validates a confirmation token of owner for the given container
+// and removes it.
+func (s *backupStore) Consume(owner, token, accountID, containerID string) (*ContainerBackup, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.prune()
+
+	p, ok := s.pending[token]
+	if !ok {
+		return nil, ErrInvalidDeleteToken
+	}
+	backup, ok := s.backups[p.backupID]
+	if !ok || backup.owner != owner || backup.AccountID != accountID || backup.ContainerID != containerID {
+		return nil, ErrInvalidDeleteToken
+	}
+	delete(s.pending, token)
+	return backup, nil
+}
+
+// MarkDeleted starts the undelete window of a backup.
+func (s *backupStore) MarkDeleted(backup *ContainerBackup) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	backup.DeletedAt = now
+	backup.ExpiresAt = now.Add(undeleteWindow)
+}
+
+// Get returns a backup of a container owner deleted.
+func (s *backupStore) Get(owner, backupID string) (*ContainerBackup, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.prune()
+
+	backup, ok := s.backups[backupID]
+	if !ok || backup.owner != owner || backup.DeletedAt.IsZero() {
+		return nil, ErrBackupNotFound
+	}
+	return backup, nil
+}
+
+// List returns the backups of containers owner deleted, most recent first.
+func (s *backupStore) List(owner string) []ContainerBackup {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.prune()
+
+	result := make([]ContainerBackup, 0, len(s.backups))
+	for _, b := range s.backups {
+		if b.owner == owner && !b.DeletedAt.IsZero() {
+			result = append(result, *b)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].DeletedAt.After(result[j].DeletedAt) })
+	return result
+}
+
+// BackupContainer exports the live version and every workspace of a container.
+func (c *Client) BackupContainer(ctx context.Context, accountID, containerID string) (*ContainerBackup, error) {
+	container, err := c.GetContainer(ctx, BuildContainerPath(accountID, containerID))
+	if err != nil {
+		return nil, err
+	}
+
+	backup := &ContainerBackup{
+		AccountID:    accountID,
+		ContainerID:  containerID,
+		Name:         container.Name,
+		PublicID:     container.PublicID,
+		UsageContext: container.UsageContext,
+	}
+
+	live, err := c.ExportVersion(ctx, accountID, containerID, "live")
+	if err != nil && !errors.Is(err, ErrNotFound) {
+		return nil, fmt.Errorf("failed to export live version: %w", err)
+	}
+	backup.Live = live
+
+	workspaces, err := c.ListWorkspaces(ctx, accountID, containerID)
+	if err != nil {
+		return nil, err
+	}
+	for _, w := range workspaces {
+		export, err := c.ExportWorkspace(ctx, accountID, containerID, w.WorkspaceID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to export workspace %s: %w", w.WorkspaceID, err)
+		}
+		backup.Workspaces = append(backup.Workspaces, WorkspaceBackup{WorkspaceID: w.WorkspaceID, Name: w.Name, Export: export})
+	}
+
+	return backup, nil
+}
+
+// CreateContainerFromBackup creates a new container with the name and type of a backup.
+func (c *Client) CreateContainerFromBackup(ctx context.Context, accountID, name string, backup *ContainerBackup) (*Container, error) {
+	container := &tagmanager.Container{
+		Name:         name,
+		UsageContext: backup.UsageContext,
+	}
+	if export := backup.RestoreExport(); export != nil && export.ContainerVersion.Container != nil {
+		src := export.ContainerVersion.Container
+		container.DomainName = src.DomainName
+		container.Notes = src.Notes
+		container.TaggingServerUrls = src.TaggingServerUrls
+	}
+
+	created, err := c.Service.Accounts.Containers.Create(fmt.Sprintf("accounts/%s", accountID), container).Context(ctx).Do()
+	if err != nil {
+		return nil, MapGoogleError(err)
+	}
+
+	result := toContainer(created)
+	return &result, nil
+}
@@ -0,0 +1,91 @@
+package gtm
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestBackupStore_TwoPhaseDelete(t *testing.T) {
+	store := newBackupStore()
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	store.now = func() time.Time { return now }
+
+	token, err := store.AddPending("alice", &ContainerBackup{AccountID: "1", ContainerID: "2", Name: "Site"})
+	if err != nil {
+		t.Fatalf("AddPending: %v", err)
+	}
+
+	if _, err := store.Consume("alice", token, "1", "3"); !errors.Is(err, ErrInvalidDeleteToken) {
+		t.Errorf("expected token to be rejected for another container, got %v", err)
+	}
+
+	backup, err := store.Consume("alice", token, "1", "2")
+	if err != nil {
+		t.Fatalf("consume: %v", err)
+	}
+	if _, err := store.Consume("alice", token, "1", "2"); !errors.Is(err, ErrInvalidDeleteToken) {
+		t.Error("expected token to be single-use")
+	}
+
+	if _, err := store.Get("alice", backup.BackupID); !errors.Is(err, ErrBackupNotFound) {
+		t.Error("expected backup to be hidden until the container is deleted")
+	}
+
+	store.MarkDeleted(backup)
+	if _, err := store.Get("alice", backup.BackupID); err != nil {
+		t.Errorf("expected backup after deletion, got %v", err)
+	}
+	if len(store.List("alice")) != 1 {
+		t.Errorf("expected 1 restorable backup, got %d", len(store.List("alice")))
+	}
+
+	now = now.Add(undeleteWindow + time.Minute)
+	if _, err := store.Get("alice", backup.BackupID); !errors.Is(err, ErrBackupNotFound) {
+		t.Error("expected backup to expire after the undelete window")
+	}
+}
+
+func TestBackupStore_TokenExpires(t *testing.T) {
+	store := newBackupStore()
+	now := time.Now()
+	store.now = func() time.Time { return now }
+
+	token, err := store.AddPending("alice", &ContainerBackup{AccountID: "1", ContainerID: "2"})
+	if err != nil {
+		t.Fatalf("AddPending: %v", err)
+	}
+
+	now = now.Add(DeleteTokenTTL + time.Second)
+	if _, err := store.Consume("alice", token, "1", "2"); !errors.Is(err, ErrInvalidDeleteToken) {
+		t.Errorf("expected expired token to be rejected, got %v", err)
+	}
+}
+
+func TestBackupStore_Owner(t *testing.T) {
+	store := newBackupStore()
+
+	token, err := store.AddPending("alice", &ContainerBackup{AccountID: "1", ContainerID: "2"})
+	if err != nil {
+		t.Fatalf("AddPending: %v", err)
+	}
+	if _, err := store.Consume("bob", token, "1", "2"); !errors.Is(err, ErrInvalidDeleteToken) {
+		t.Errorf("expected another user's token to be rejected, got %v", err)
+	}
+
+	backup, err := store.Consume("alice", token, "1", "2")
+	if err != nil {
+		t.Fatalf("consume: %v", err)
+	}
+	store.MarkDeleted(backup)
+
+	if _, err := store.Get("bob", backup.BackupID); !errors.Is(err, ErrBackupNotFound) {
+		t.Errorf("expected another user's backup to be hidden, got %v", err)
+	}
+	if backups := store.List("bob"); len(backups) != 0 {
+		t.Errorf("expected no backups for another user, got %d", len(backups))
+	}
+	if backups := store.List("alice"); len(backups) != 1 {
+		t.Errorf("expected 1 backup for its owner, got %d", len(backups))
+	}
+}
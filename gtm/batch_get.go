@@ -0,0 +1,74 @@
+package gtm
+
+import (
+	"context"
+	"slices"
+
+	"golang.org/x/sync/errgroup"
+)
+
+const (
+	// MaxBatchGet is the most entities a batch get tool fetches in one call.
+	MaxBatchGet = 50
+	// batchGetWorkers bounds the concurrent get requests of a batch, keeping
+	// a large batch from using up the per-user API quota in one burst.
+	batchGetWorkers = 5
+)
+
+// BatchItem is one entity of a batch get: the entity, or why it could not
+// be fetched.
+type BatchItem[T any] struct {
+	ID     string `json:"id"`
+	Entity *T     `json:"entity,omitempty"`
+	Error  string `json:"error,omitempty"`
+}
+
+// BatchGet fetches the entities with the given IDs concurrently, in the
+// order of ids with duplicates removed. A failed get is reported on its
+// item and does not stop the others.
+func BatchGet[T any](ctx context.Context, field string, ids []string, get func(ctx context.Context, id string) (*T, error)) ([]BatchItem[T], error) {
+	var unique []string
+	for _, id := range ids {
+		if id == "" {
+			return nil, InvalidField(field, "%s must not contain empty IDs", field)
+		}
+		if !slices.Contains(unique, id) {
+			unique = append(unique, id)
+		}
+	}
+	switch {
+	case len(unique) == 0:
+		return nil, InvalidField(field, "%s must contain at least one ID", field)
+	case len(unique) > MaxBatchGet:
+		return nil, InvalidField(field, "%s contains %d IDs; fetch at most %d per call", field, len(unique), MaxBatchGet)
+	}
+
+	items := make([]BatchItem[T], len(unique))
+	var g errgroup.Group
+	g.SetLimit(batchGetWorkers)
+	for i, id := range unique {
+		g.Go(func() error {
+			items[i].ID = id
+			entity, err := get(ctx, id)
+			if err != nil {
+				items[i].Error = err.Error()
+				return nil
+			}
+			items[i].Entity = entity
+			return nil
+		})
+	}
+	g.Wait()
+	return items, nil
+}
+
+// BatchFailures counts the items of a batch that could not be fetched.
+func BatchFailures[T any](items []BatchItem[T]) int {
+	n := 0
+	for _, item := range items {
+		if item.Error != "" {
+			n++
+		}
+	}
+	return n
+}
@@ -0,0 +1,77 @@
+package gtm
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"path"
+	"strings"
+	"sync/atomic"
+	"testing"
+)
+
+func TestBatchGet(t *testing.T) {
+	var inFlight, peak atomic.Int32
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		n := inFlight.Add(1)
+		defer inFlight.Add(-1)
+		for {
+			p := peak.Load()
+			if n <= p || peak.CompareAndSwap(p, n) {
+				break
+			}
+		}
+		id := path.Base(r.URL.Path)
+		if id == "404" {
+			w.WriteHeader(http.StatusNotFound)
+			io.WriteString(w, `{"error":{"code":404,"message":"tag not found"}}`)
+			return
+		}
+		fmt.Fprintf(w, `{"tagId":%q,"name":"Tag %s","path":%q}`, id, id, r.URL.Path)
+	})
+
+	ids := []string{"3", "1", "404", "3"}
+	for i := 10; i < 30; i++ {
+		ids = append(ids, fmt.Sprint(i))
+	}
+	items, err := BatchGet(context.Background(), "tagIds", ids, func(ctx context.Context, id string) (*Tag, error) {
+		return client.GetTag(ctx, "1", "2", "3", id)
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(items) != 23 || items[0].ID != "3" || items[1].ID != "1" || items[2].ID != "404" {
+		t.Fatalf("items = %+v", items[:3])
+	}
+	if items[0].Entity == nil || items[0].Entity.Name != "Tag 3" || items[0].Error != "" {
+		t.Errorf("item 0 = %+v", items[0])
+	}
+	if items[2].Entity != nil || !strings.Contains(items[2].Error, "not found") {
+		t.Errorf("missing item = %+v", items[2])
+	}
+	if got := BatchFailures(items); got != 1 {
+		t.Errorf("failures = %d", got)
+	}
+	if p := peak.Load(); p > batchGetWorkers {
+		t.Errorf("peak concurrency = %d, want at most %d", p, batchGetWorkers)
+	}
+}
+
+func TestBatchGet_Invalid(t *testing.T) {
+	get := func(ctx context.Context, id string) (*Tag, error) { return &Tag{TagID: id}, nil }
+	tooMany := make([]string, MaxBatchGet+1)
+	for i := range tooMany {
+		tooMany[i] = fmt.Sprint(i)
+	}
+	for name, ids := range map[string][]string{
+		"empty":    nil,
+		"blank ID": {"1", ""},
+		"too many": tooMany,
+	} {
+		if _, err := BatchGet(context.Background(), "tagIds", ids, get); !errors.Is(err, ErrInvalidRequest) {
+			t.Errorf("%s: err = %v, want ErrInvalidRequest", name, err)
+		}
+	}
+}
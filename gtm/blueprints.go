@@ -0,0 +1,387 @@
+package gtm
+
+import (
+	"bytes"
+	"embed"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// builtInBlueprintFiles are the blueprints shipped with the server.
+//
+//go:embed blueprints/*.yaml
+var builtInBlueprintFiles embed.FS
+
+var (
+	blueprintNamePattern   = regexp.MustCompile(`^[a-z0-9][a-z0-9-]*$`)
+	placeholderNamePattern = regexp.MustCompile(`^[A-Z][A-Z0-9_]*$`)
+
+	// placeholderRef matches {{NAME}}. Only declared placeholders are
+	// replaced, so references to GTM variables like {{Page URL}} or an
+	// upper-case variable name stay as they are.
+	placeholderRef = regexp.MustCompile(`\{\{([A-Z][A-Z0-9_]*)\}\}`)
+)
+
+// Blueprint is a reusable set of tags, triggers and variables with
+// placeholders such as {{MEASUREMENT_ID}}. Entities are written in the Tag
+// Manager API JSON form; tags name the triggers they fire on in
+// firingTriggers and blockingTriggers instead of giving trigger IDs.
+type Blueprint struct {
+	Name         string                 `yaml:"name" json:"name"`
+	Title        string                 `yaml:"title" json:"title"`
+	Description  string                 `yaml:"description" json:"description"`
+	Placeholders []BlueprintPlaceholder `yaml:"placeholders" json:"placeholders"`
+	Variables    []map[string]any       `yaml:"variables" json:"variables,omitempty"`
+	Triggers     []map[string]any       `yaml:"triggers" json:"triggers,omitempty"`
+	Tags         []map[string]any       `yaml:"tags" json:"tags,omitempty"`
+	BuiltIn      bool                   `yaml:"-" json:"builtIn"`
+}
+
+// BlueprintPlaceholder is a value supplied when a blueprint is applied.
+// Placeholders without a default are required.
+type BlueprintPlaceholder struct {
+	Name        string `yaml:"name" json:"name"`
+	Description string `yaml:"description" json:"description,omitempty"`
+	Pattern     string `yaml:"pattern" json:"pattern,omitempty"`
+	Default     string `yaml:"default" json:"default,omitempty"`
+}
+
+// BlueprintExisting is a blueprint entity already in the workspace under
+// the same name. It is left unchanged and reused by the blueprint's tags.
+type BlueprintExisting struct {
+	EntityType string `json:"entityType"`
+	EntityID   string `json:"entityId"`
+	Name       string `json:"name"`
+}
+
+// ParseBlueprint reads a YAML or JSON blueprint and checks its structure.
+func ParseBlueprint(data []byte) (*Blueprint, error) {
+	var b Blueprint
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+	if err := dec.Decode(&b); err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("invalid blueprint: %w", err)
+	}
+	if err := b.validate(); err != nil {
+		return nil, fmt.Errorf("invalid blueprint %q: %w", b.Name, err)
+	}
+	for _, list := range [][]map[string]any{b.Variables, b.Triggers, b.Tags} {
+		for i, e := range list {
+			list[i] = normalizeBlueprintValue(e).(map[string]any)
+		}
+	}
+	return &b, nil
+}
+
+// normalizeBlueprintValue turns the numbers YAML decodes into strings, the
+// form the Tag Manager API uses for parameter values and IDs.
+func normalizeBlueprintValue(v any) any {
+	switch x := v.(type) {
+	case int:
+		return strconv.Itoa(x)
+	case float64:
+		return strconv.FormatFloat(x, 'f', -1, 64)
+	case map[string]any:
+		for k, item := range x {
+			x[k] = normalizeBlueprintValue(item)
+		}
+		return x
+	case []any:
+		for i, item := range x {
+			x[i] = normalizeBlueprintValue(item)
+		}
+		return x
+	default:
+		return v
+	}
+}
+
+func (b *Blueprint) validate() error {
+	if !blueprintNamePattern.MatchString(b.Name) {
+		return errors.New("name must be lower-case letters, digits and dashes")
+	}
+	declared := make(map[string]bool, len(b.Placeholders))
+	for _, p := range b.Placeholders {
+		if !placeholderNamePattern.MatchString(p.Name) {
+			return fmt.Errorf("placeholder %q: names are upper-case letters, digits and underscores", p.Name)
+		}
+		if declared[p.Name] {
+			return fmt.Errorf("placeholder %s is declared twice", p.Name)
+		}
+		declared[p.Name] = true
+		if p.Pattern != "" {
+			if _, err := regexp.Compile(p.Pattern); err != nil {
+				return fmt.Errorf("placeholder %s: invalid pattern: %w", p.Name, err)
+			}
+		}
+	}
+	if len(b.Variables)+len(b.Triggers)+len(b.Tags) == 0 {
+		return errors.New("a blueprint needs at least one tag, trigger or variable")
+	}
+
+	triggers := make(map[string]bool, len(b.Triggers))
+	for _, list := range []struct {
+		entityType string
+		entities   []map[string]any
+	}{{"variable", b.Variables}, {"trigger", b.Triggers}, {"tag", b.Tags}} {
+		names := make(map[string]bool, len(list.entities))
+		for i, e := range list.entities {
+			name, _ := e["name"].(string)
+			if name == "" {
+				return fmt.Errorf("%s %d has no name", list.entityType, i)
+			}
+			if t, _ := e["type"].(string); t == "" {
+				return fmt.Errorf("%s %q has no type", list.entityType, name)
+			}
+			if names[name] {
+				return fmt.Errorf("%s %q is defined twice", list.entityType, name)
+			}
+			names[name] = true
+			if list.entityType == "trigger" {
+				triggers[name] = true
+			}
+			if _, ok := e["firingTriggerId"]; ok {
+				return fmt.Errorf("%s %q: name triggers in firingTriggers instead of giving firingTriggerId", list.entityType, name)
+			}
+			if _, ok := e["blockingTriggerId"]; ok {
+				return fmt.Errorf("%s %q: name triggers in blockingTriggers instead of giving blockingTriggerId", list.entityType, name)
+			}
+			for _, key := range []string{"firingTriggers", "blockingTriggers"} {
+				if _, ok := e[key]; ok && list.entityType != "tag" {
+					return fmt.Errorf("%s %q: only tags have %s", list.entityType, name, key)
+				}
+				if _, err := blueprintTriggerNames(e, key); err != nil {
+					return fmt.Errorf("tag %q: %w", name, err)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// blueprintTriggerNames returns the trigger names a blueprint tag lists
+// under key.
+func blueprintTriggerNames(tag map[string]any, key string) ([]string, error) {
+	raw, ok := tag[key]
+	if !ok {
+		return nil, nil
+	}
+	list, ok := raw.([]any)
+	if !ok {
+		return nil, fmt.Errorf("%s must be a list of trigger names", key)
+	}
+	names := make([]string, 0, len(list))
+	for _, item := range list {
+		name, ok := item.(string)
+		if !ok || name == "" {
+			return nil, fmt.Errorf("%s must be a list of trigger names", key)
+		}
+		names = append(names, name)
+	}
+	return names, nil
+}
+
+// BuiltInBlueprints returns the blueprints shipped with the server, keyed
+// by name.
+func BuiltInBlueprints() map[string]*Blueprint {
+	blueprints := make(map[string]*Blueprint)
+	files, _ := builtInBlueprintFiles.ReadDir("blueprints")
+	for _, f := range files {
+		data, err := builtInBlueprintFiles.ReadFile("blueprints/" + f.Name())
+		if err != nil {
+			panic(err)
+		}
+		b, err := ParseBlueprint(data)
+		if err != nil {
+			panic(fmt.Sprintf("built-in blueprint %s: %v", f.Name(), err))
+		}
+		b.BuiltIn = true
+		blueprints[b.Name] = b
+	}
+	return blueprints
+}
+
+// LoadBlueprints returns the built-in blueprints plus the .yaml, .yml and
+// .json blueprints in dir. A file blueprint replaces a built-in one of the
+// same name.
+func LoadBlueprints(dir string) (map[string]*Blueprint, error) {
+	blueprints := BuiltInBlueprints()
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read blueprint directory: %w", err)
+	}
+	seen := make(map[string]string)
+	for _, entry := range entries {
+		switch strings.ToLower(filepath.Ext(entry.Name())) {
+		case ".yaml", ".yml", ".json":
+		default:
+			continue
+		}
+		if entry.IsDir() {
+			continue
+		}
+		data, err := os.ReadFile(filepath.Join(dir, entry.Name()))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read blueprint: %w", err)
+		}
+		b, err := ParseBlueprint(data)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", entry.Name(), err)
+		}
+		if other, ok := seen[b.Name]; ok {
+			return nil, fmt.Errorf("%s: blueprint %q is also defined in %s", entry.Name(), b.Name, other)
+		}
+		seen[b.Name] = entry.Name()
+		blueprints[b.Name] = b
+	}
+	return blueprints, nil
+}
+
+// SortedBlueprints returns the blueprints ordered by name.
+func SortedBlueprints(blueprints map[string]*Blueprint) []*Blueprint {
+	list := make([]*Blueprint, 0, len(blueprints))
+	for _, b := range blueprints {
+		list = append(list, b)
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].Name < list[j].Name })
+	return list
+}
+
+// resolveValues checks the supplied placeholder values and fills in
+// defaults.
+func (b *Blueprint) resolveValues(values map[string]string) (map[string]string, error) {
+	declared := make(map[string]bool, len(b.Placeholders))
+	resolved := make(map[string]string, len(b.Placeholders))
+	for _, p := range b.Placeholders {
+		declared[p.Name] = true
+		value, ok := values[p.Name]
+		if !ok || value == "" {
+			value = p.Default
+		}
+		if value == "" {
+			return nil, InvalidField("values."+p.Name, "%s is required: %s", p.Name, p.Description)
+		}
+		if p.Pattern != "" && !regexp.MustCompile(p.Pattern).MatchString(value) {
+			return nil, InvalidField("values."+p.Name, "%s %q does not match %s", p.Name, value, p.Pattern)
+		}
+		resolved[p.Name] = value
+	}
+	for name := range values {
+		if !declared[name] {
+			return nil, InvalidField("values."+name, "blueprint %s has no placeholder %s", b.Name, name)
+		}
+	}
+	return resolved, nil
+}
+
+// substitutePlaceholders returns a copy of v with every {{NAME}} of a
+// placeholder in values replaced.
+func substitutePlaceholders(v any, values map[string]string) any {
+	switch x := v.(type) {
+	case string:
+		return placeholderRef.ReplaceAllStringFunc(x, func(ref string) string {
+			if value, ok := values[ref[2:len(ref)-2]]; ok {
+				return value
+			}
+			return ref
+		})
+	case map[string]any:
+		out := make(map[string]any, len(x))
+		for k, item := range x {
+			out[k] = substitutePlaceholders(item, values)
+		}
+		return out
+	case []any:
+		out := make([]any, len(x))
+		for i, item := range x {
+			out[i] = substitutePlaceholders(item, values)
+		}
+		return out
+	default:
+		return v
+	}
+}
+
+// Changeset turns the blueprint into create operations for the entities
+// the workspace does not have yet, matched by name. Tags fire on the
+// blueprint's triggers, built-in triggers such as "All Pages" or existing
+// workspace triggers, all given by name.
+func (b *Blueprint) Changeset(values map[string]string, existing *WorkspaceEntities) ([]ChangeOperation, []BlueprintExisting, error) {
+	resolved, err := b.resolveValues(values)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	existingIDs := map[string]map[string]string{"variable": {}, "trigger": {}, "tag": {}}
+	for _, v := range existing.Variables {
+		existingIDs["variable"][v.Name] = v.VariableID
+	}
+	for _, t := range existing.Triggers {
+		existingIDs["trigger"][t.Name] = t.TriggerID
+	}
+	for _, t := range existing.Tags {
+		existingIDs["tag"][t.Name] = t.TagID
+	}
+	// Trigger names resolve to the blueprint's triggers, then to built-in
+	// triggers, then to any other workspace trigger
+	triggerIDs := make(map[string]string)
+	for id, name := range BuiltInTriggerNames {
+		triggerIDs[name] = id
+	}
+
+	var ops []ChangeOperation
+	var reused []BlueprintExisting
+	for _, list := range []struct {
+		entityType string
+		entities   []map[string]any
+	}{{"variable", b.Variables}, {"trigger", b.Triggers}, {"tag", b.Tags}} {
+		for _, raw := range list.entities {
+			entity := substitutePlaceholders(raw, resolved).(map[string]any)
+			name := entity["name"].(string)
+			ref := list.entityType + ":" + name
+
+			if id, ok := existingIDs[list.entityType][name]; ok {
+				reused = append(reused, BlueprintExisting{EntityType: list.entityType, EntityID: id, Name: name})
+				if list.entityType == "trigger" {
+					triggerIDs[name] = id
+				}
+				continue
+			}
+			if list.entityType == "trigger" {
+				triggerIDs[name] = changesetRefPrefix + ref
+			}
+			if list.entityType == "tag" {
+				for key, idField := range map[string]string{"firingTriggers": "firingTriggerId", "blockingTriggers": "blockingTriggerId"} {
+					names, _ := blueprintTriggerNames(entity, key)
+					delete(entity, key)
+					if len(names) == 0 {
+						continue
+					}
+					ids := make([]any, 0, len(names))
+					for _, triggerName := range names {
+						id, ok := triggerIDs[triggerName]
+						if !ok {
+							id, ok = existingIDs["trigger"][triggerName]
+						}
+						if !ok {
+							return nil, nil, fmt.Errorf("%w: tag %q fires on trigger %q, which is not in the blueprint, the workspace or the built-in triggers", ErrInvalidRequest, name, triggerName)
+						}
+						ids = append(ids, id)
+					}
+					entity[idField] = ids
+				}
+			}
+			ops = append(ops, ChangeOperation{Action: ChangeCreate, EntityType: list.entityType, Ref: ref, Entity: entity})
+		}
+	}
+	return ops, reused, nil
+}
@@ -0,0 +1,184 @@
+package gtm
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestBuiltInBlueprints(t *testing.T) {
+	blueprints := BuiltInBlueprints()
+	for _, name := range []string{"ga4-base", "ga4-ecommerce", "consent-mode", "google-ads-conversions"} {
+		if blueprints[name] == nil {
+			t.Errorf("built-in blueprint %s is missing", name)
+		}
+	}
+
+	samples := map[string]string{"MEASUREMENT_ID": "G-TEST123", "CONVERSION_ID": "123456", "CONVERSION_LABEL": "abc-DEF"}
+	for _, b := range blueprints {
+		values := map[string]string{}
+		for _, p := range b.Placeholders {
+			if p.Default == "" {
+				values[p.Name] = samples[p.Name]
+			}
+		}
+		ops, _, err := b.Changeset(values, &WorkspaceEntities{})
+		if err != nil {
+			t.Errorf("%s: %v", b.Name, err)
+			continue
+		}
+		if err := ValidateChangeset(ops); err != nil {
+			t.Errorf("%s: %v", b.Name, err)
+		}
+		for _, op := range ops {
+			if op.EntityType != "tag" {
+				continue
+			}
+			tag, err := convertEntity[map[string]any, TagPayload](op.Entity)
+			if err != nil {
+				t.Fatalf("%s: %v", b.Name, err)
+			}
+			for _, v := range checkTagPayload(tag, nil, nil) {
+				if v.Severity == SeverityError {
+					t.Errorf("%s: tag %q: %s", b.Name, tag.Name, v.Message)
+				}
+			}
+			if len(toStrings(op.Entity["firingTriggerId"])) == 0 {
+				t.Errorf("%s: tag %q has no firing trigger", b.Name, tag.Name)
+			}
+		}
+	}
+}
+
+func toStrings(v any) []string {
+	list, _ := v.([]any)
+	out := make([]string, 0, len(list))
+	for _, item := range list {
+		out = append(out, item.(string))
+	}
+	return out
+}
+
+func TestBlueprintChangeset(t *testing.T) {
+	b := BuiltInBlueprints()["google-ads-conversions"]
+	existing := &WorkspaceEntities{
+		Triggers:  []Trigger{{TriggerID: "7", Name: "CE - order_complete"}},
+		Variables: []Variable{{VariableID: "8", Name: "DLV - ecommerce.value"}},
+	}
+
+	ops, reused, err := b.Changeset(map[string]string{"CONVERSION_ID": "123", "CONVERSION_LABEL": "xyz", "CONVERSION_EVENT": "order_complete"}, existing)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(reused) != 2 || reused[0].EntityID != "8" || reused[1].EntityID != "7" {
+		t.Errorf("reused = %+v", reused)
+	}
+	if len(ops) != 4 {
+		t.Fatalf("ops = %+v, want 2 variables and 2 tags", ops)
+	}
+	conversion := ops[3].Entity
+	if conversion["name"] != "Google Ads - Conversion - order_complete" {
+		t.Errorf("tag name = %v", conversion["name"])
+	}
+	if got := toStrings(conversion["firingTriggerId"]); len(got) != 1 || got[0] != "7" {
+		t.Errorf("conversion fires on %v, want the existing trigger", got)
+	}
+	if got := toStrings(ops[2].Entity["firingTriggerId"]); len(got) != 1 || got[0] != "2147479553" {
+		t.Errorf("conversion linker fires on %v, want All Pages", got)
+	}
+	if _, ok := conversion["firingTriggers"]; ok {
+		t.Error("firingTriggers was sent to the API")
+	}
+	params := conversion["parameter"].([]any)
+	if params[0].(map[string]any)["value"] != "123" || params[2].(map[string]any)["value"] != "{{DLV - ecommerce.value}}" {
+		t.Errorf("parameters = %v", params)
+	}
+
+	for name, values := range map[string]map[string]string{
+		"missing":     {"CONVERSION_ID": "123"},
+		"bad pattern": {"CONVERSION_ID": "AW-123", "CONVERSION_LABEL": "xyz"},
+		"unknown":     {"CONVERSION_ID": "123", "CONVERSION_LABEL": "xyz", "MEASUREMENT_ID": "G-1"},
+	} {
+		if _, _, err := b.Changeset(values, existing); !errors.Is(err, ErrInvalidRequest) {
+			t.Errorf("%s: err = %v, want ErrInvalidRequest", name, err)
+		}
+	}
+}
+
+func TestApplyBlueprint_CreatesLinkedEntities(t *testing.T) {
+	ws := "accounts/1/containers/2/workspaces/3"
+	fake := &fakeWorkspace{entities: map[string]map[string]any{}}
+	client := newTestClient(t, fake.serve)
+
+	ops, _, err := BuiltInBlueprints()["consent-mode"].Changeset(nil, &WorkspaceEntities{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.ApplyChangeset(context.Background(), "1", "2", "3", ops); err != nil {
+		t.Fatal(err)
+	}
+
+	var update map[string]any
+	var triggerID string
+	for path, e := range fake.entities {
+		switch e["name"] {
+		case "Consent Mode - Update":
+			update = e
+		case "CE - consent_update":
+			triggerID = strings.TrimPrefix(path, ws+"/triggers/")
+		}
+	}
+	if update == nil || triggerID == "" {
+		t.Fatalf("entities = %v", fake.entities)
+	}
+	if got := toStrings(update["firingTriggerId"]); len(got) != 1 || got[0] != triggerID {
+		t.Errorf("update tag fires on %v, want trigger %s", got, triggerID)
+	}
+}
+
+func TestParseBlueprint_Invalid(t *testing.T) {
+	for name, doc := range map[string]string{
+		"bad name":         "name: GA4 Base\ntags: [{name: x, type: html}]",
+		"no entities":      "name: empty",
+		"unknown field":    "name: x\nvariable: [{name: x, type: c}]",
+		"placeholder name": "name: x\nplaceholders: [{name: id}]\ntags: [{name: x, type: html}]",
+		"bad pattern":      "name: x\nplaceholders: [{name: ID, pattern: '('}]\ntags: [{name: x, type: html}]",
+		"duplicate":        "name: x\ntags: [{name: a, type: html}, {name: a, type: img}]",
+		"trigger ids":      "name: x\ntags: [{name: a, type: html, firingTriggerId: ['1']}]",
+		"trigger on var":   "name: x\nvariables: [{name: a, type: c, firingTriggers: [All Pages]}]",
+	} {
+		if _, err := ParseBlueprint([]byte(doc)); err == nil {
+			t.Errorf("%s: blueprint accepted", name)
+		}
+	}
+}
+
+func TestLoadBlueprints(t *testing.T) {
+	dir := t.TempDir()
+	custom := `{"name": "hotjar", "title": "Hotjar", "placeholders": [{"name": "SITE_ID", "pattern": "^[0-9]+$"}],
+		"tags": [{"name": "Hotjar", "type": "hjtc", "parameter": [{"type": "template", "key": "hotjar_site_id", "value": "{{SITE_ID}}"}], "firingTriggers": ["All Pages"]}]}`
+	if err := os.WriteFile(filepath.Join(dir, "hotjar.json"), []byte(custom), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "README.md"), []byte("not a blueprint"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	blueprints, err := LoadBlueprints(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if blueprints["hotjar"] == nil || blueprints["hotjar"].BuiltIn || blueprints["ga4-base"] == nil {
+		t.Fatalf("blueprints = %v", blueprints)
+	}
+	ops, _, err := blueprints["hotjar"].Changeset(map[string]string{"SITE_ID": "42"}, &WorkspaceEntities{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v := ops[0].Entity["parameter"].([]any)[0].(map[string]any)["value"]; v != "42" {
+		t.Errorf("site ID = %v", v)
+	}
+}
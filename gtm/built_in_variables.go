@@ -11,11 +11,11 @@ import (
 func (c *Client) ListBuiltInVariables(ctx context.Context, accountID, containerID, workspaceID string) ([]BuiltInVariable, error) {
 	parent := fmt.Sprintf("accounts/%s/containers/%s/workspaces/%s", accountID, containerID, workspaceID)
 
-	resp, err := retryWithBackoff(ctx, 3, func() (*tagmanager.ListEnabledBuiltInVariablesResponse, error) {
+	resp, err := RetryWithBackoff(ctx, func() (*tagmanager.ListEnabledBuiltInVariablesResponse, error) {
 		return c.Service.Accounts.Containers.Workspaces.BuiltInVariables.List(parent).Context(ctx).Do()
 	})
 	if err != nil {
-		return nil, mapGoogleError(err)
+		return nil, MapGoogleError(err)
 	}
 	if resp == nil {
 		return []BuiltInVariable{}, nil
@@ -28,11 +28,11 @@ func (c *Client) ListBuiltInVariables(ctx context.Context, accountID, containerI
 func (c *Client) EnableBuiltInVariables(ctx context.Context, accountID, containerID, workspaceID string, types []string) ([]BuiltInVariable, error) {
 	parent := fmt.Sprintf("accounts/%s/containers/%s/workspaces/%s", accountID, containerID, workspaceID)
 
-	resp, err := retryWithBackoff(ctx, 3, func() (*tagmanager.CreateBuiltInVariableResponse, error) {
+	resp, err := RetryWithBackoff(ctx, func() (*tagmanager.CreateBuiltInVariableResponse, error) {
 		return c.Service.Accounts.Containers.Workspaces.BuiltInVariables.Create(parent).Type(types...).Context(ctx).Do()
 	})
 	if err != nil {
-		return nil, mapGoogleError(err)
+		return nil, MapGoogleError(err)
 	}
 
 	return toBuiltInVariables(resp.BuiltInVariable), nil
@@ -43,7 +43,7 @@ func (c *Client) DisableBuiltInVariables(ctx context.Context, accountID, contain
 	path := fmt.Sprintf("accounts/%s/containers/%s/workspaces/%s/built_in_variables", accountID, containerID, workspaceID)
 
 	err := c.Service.Accounts.Containers.Workspaces.BuiltInVariables.Delete(path).Type(types...).Context(ctx).Do()
-	return mapGoogleError(err)
+	return MapGoogleError(err)
 }
 
 func toBuiltInVariables(vars []*tagmanager.BuiltInVariable) []BuiltInVariable {
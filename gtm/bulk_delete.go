@@ -0,0 +1,183 @@
+package gtm
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"slices"
+)
+
+// Entity types accepted by bulk operations.
+const (
+	EntityTypeTag      = "tag"
+	EntityTypeTrigger  = "trigger"
+	EntityTypeVariable = "variable"
+)
+
+// BulkDeleteFilter selects workspace entities for deletion.
+type BulkDeleteFilter struct {
+	// EntityTypes limits the entity kinds (tag, trigger, variable). Empty means all three.
+	EntityTypes []string
+	// NamePattern is a regular expression matched against entity names.
+	NamePattern string
+	// Types limits matches to these GTM types (e.g. "ua", "gaawc", "customEvent").
+	Types []string
+}
+
+// BulkDeleteItem is an entity matched by a bulk delete filter.
+type BulkDeleteItem struct {
+	EntityType string `json:"entityType"`
+	EntityID   string `json:"entityId"`
+	Name       string `json:"name"`
+	Type       string `json:"type"`
+	Path       string `json:"-"`
+	Error      string `json:"error,omitempty"`
+}
+
+// bulkDeleteMatcher is a compiled BulkDeleteFilter.
+type bulkDeleteMatcher struct {
+	kinds map[string]bool
+	name  *regexp.Regexp
+	types map[string]bool
+}
+
+// compile validates the filter. At least a name pattern or a type is required
+// so that a filter can never select every entity in the workspace.
+func (f BulkDeleteFilter) compile() (*bulkDeleteMatcher, error) {
+	if f.NamePattern == "" && len(f.Types) == 0 {
+		return nil, fmt.Errorf("namePattern or types is required")
+	}
+
+	kinds, err := EntityKinds(f.EntityTypes)
+	if err != nil {
+		return nil, err
+	}
+	m := &bulkDeleteMatcher{kinds: kinds, types: make(map[string]bool)}
+	if f.NamePattern != "" {
+		re, err := regexp.Compile(f.NamePattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid namePattern: %w", err)
+		}
+		m.name = re
+	}
+	for _, t := range f.Types {
+		m.types[t] = true
+	}
+	return m, nil
+}
+
+func (m *bulkDeleteMatcher) match(kind, name, entityType string) bool {
+	if !m.kinds[kind] {
+		return false
+	}
+	if m.name != nil && !m.name.MatchString(name) {
+		return false
+	}
+	if len(m.types) > 0 && !m.types[entityType] {
+		return false
+	}
+	return true
+}
+
+// BulkDeletePlan lists the entities a filter matches, in deletion order.
+type BulkDeletePlan struct {
+	Items    []BulkDeleteItem `json:"items"`
+	Warnings []string         `json:"warnings,omitempty"`
+}
+
+// PlanBulkDelete lists the tags, triggers and variables in a workspace matching
+// the filter. Tags come first so triggers are no longer in use when deleted.
+func (c *Client) PlanBulkDelete(ctx context.Context, accountID, containerID, workspaceID string, filter BulkDeleteFilter) (*BulkDeletePlan, error) {
+	m, err := filter.compile()
+	if err != nil {
+		return nil, err
+	}
+
+	plan := &BulkDeletePlan{Items: []BulkDeleteItem{}}
+	deletedTags := make(map[string]bool)
+
+	// Tags are listed even when not selected to detect triggers still in use.
+	tags, err := c.ListTags(ctx, accountID, containerID, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+	for _, t := range tags {
+		if m.match(EntityTypeTag, t.Name, t.Type) {
+			deletedTags[t.TagID] = true
+			plan.Items = append(plan.Items, BulkDeleteItem{EntityType: EntityTypeTag, EntityID: t.TagID, Name: t.Name, Type: t.Type, Path: t.Path})
+		}
+	}
+
+	if m.kinds[EntityTypeTrigger] {
+		triggers, err := c.ListTriggers(ctx, accountID, containerID, workspaceID)
+		if err != nil {
+			return nil, err
+		}
+		for _, t := range triggers {
+			if !m.match(EntityTypeTrigger, t.Name, t.Type) {
+				continue
+			}
+			plan.Items = append(plan.Items, BulkDeleteItem{EntityType: EntityTypeTrigger, EntityID: t.TriggerID, Name: t.Name, Type: t.Type, Path: t.Path})
+			if users := triggerUsers(tags, t.TriggerID, deletedTags); len(users) > 0 {
+				plan.Warnings = append(plan.Warnings, fmt.Sprintf("trigger %q is still used by tags that are not being deleted: %v", t.Name, users))
+			}
+		}
+	}
+
+	if m.kinds[EntityTypeVariable] {
+		variables, err := c.ListVariables(ctx, accountID, containerID, workspaceID)
+		if err != nil {
+			return nil, err
+		}
+		for _, v := range variables {
+			if m.match(EntityTypeVariable, v.Name, v.Type) {
+				plan.Items = append(plan.Items, BulkDeleteItem{EntityType: EntityTypeVariable, EntityID: v.VariableID, Name: v.Name, Type: v.Type, Path: v.Path})
+			}
+		}
+	}
+
+	return plan, nil
+}
+
+// triggerUsers returns the names of tags outside the deleted set that fire on
+// or are blocked by a trigger.
+func triggerUsers(tags []Tag, triggerID string, deleted map[string]bool) []string {
+	var names []string
+	for _, t := range tags {
+		if deleted[t.TagID] {
+			continue
+		}
+		if slices.Contains(t.FiringTriggerID, triggerID) || slices.Contains(t.BlockingTriggerID, triggerID) {
+			names = append(names, t.Name)
+		}
+	}
+	return names
+}
+
+// ExecuteBulkDelete deletes the planned entities in order. It continues past
+// individual failures, recording each error on its item, and returns the
+// number of entities deleted.
+func (c *Client) ExecuteBulkDelete(ctx context.Context, plan *BulkDeletePlan) int {
+	deleted := 0
+	ExpectProgress(ctx, len(plan.Items))
+	for i := range plan.Items {
+		item := &plan.Items[i]
+		var err error
+		switch item.EntityType {
+		case EntityTypeTag:
+			err = c.DeleteTag(ctx, item.Path)
+		case EntityTypeTrigger:
+			err = c.DeleteTrigger(ctx, item.Path)
+		case EntityTypeVariable:
+			err = c.DeleteVariable(ctx, item.Path)
+		}
+		if err != nil {
+			item.Error = err.Error()
+			StepProgress(ctx, "Failed to delete %s %q", item.EntityType, item.Name)
+			continue
+		}
+		deleted++
+		StepProgress(ctx, "Deleted %s %q", item.EntityType, item.Name)
+	}
+	return deleted
+}
@@ -0,0 +1,76 @@
+package gtm
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestBulkDeleteFilter_Compile(t *testing.T) {
+	tests := []struct {
+		name    string
+		filter  BulkDeleteFilter
+		wantErr bool
+	}{
+		{name: "name pattern", filter: BulkDeleteFilter{NamePattern: "^UA - "}},
+		{name: "types only", filter: BulkDeleteFilter{Types: []string{"ua"}}},
+		{name: "no criteria", filter: BulkDeleteFilter{EntityTypes: []string{"tag"}}, wantErr: true},
+		{name: "bad regex", filter: BulkDeleteFilter{NamePattern: "("}, wantErr: true},
+		{name: "bad entity type", filter: BulkDeleteFilter{EntityTypes: []string{"folder"}, NamePattern: "x"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := tt.filter.compile()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("compile() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestBulkDeleteMatcher_Match(t *testing.T) {
+	m, err := BulkDeleteFilter{
+		EntityTypes: []string{"tag", "trigger"},
+		NamePattern: "(?i)^ua ",
+		Types:       []string{"ua", "customEvent"},
+	}.compile()
+	if err != nil {
+		t.Fatalf("compile failed: %v", err)
+	}
+
+	tests := []struct {
+		kind, name, entityType string
+		want                   bool
+	}{
+		{"tag", "UA - Pageview", "ua", true},
+		{"tag", "ua event", "ua", true},
+		{"tag", "GA4 - Pageview", "gaawc", false},
+		{"tag", "UA - Custom HTML", "html", false},
+		{"trigger", "UA trigger", "customEvent", true},
+		{"variable", "UA ID", "ua", false},
+	}
+
+	for _, tt := range tests {
+		if got := m.match(tt.kind, tt.name, tt.entityType); got != tt.want {
+			t.Errorf("match(%q, %q, %q) = %v, want %v", tt.kind, tt.name, tt.entityType, got, tt.want)
+		}
+	}
+}
+
+func TestTriggerUsers(t *testing.T) {
+	tags := []Tag{
+		{TagID: "1", Name: "UA - Pageview", FiringTriggerID: []string{"10"}},
+		{TagID: "2", Name: "GA4 - Pageview", FiringTriggerID: []string{"10"}},
+		{TagID: "3", Name: "GA4 - Purchase", FiringTriggerID: []string{"11"}, BlockingTriggerID: []string{"10"}},
+	}
+
+	got := triggerUsers(tags, "10", map[string]bool{"1": true})
+	want := []string{"GA4 - Pageview", "GA4 - Purchase"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("triggerUsers() = %v, want %v", got, want)
+	}
+
+	if got := triggerUsers(tags, "11", map[string]bool{"3": true}); len(got) != 0 {
+		t.Errorf("expected no users, got %v", got)
+	}
+}
@@ -0,0 +1,219 @@
+package gtm
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultListCacheTTL bounds how long a cached list may miss changes made
+// outside this server, e.g. in the GTM web UI.
+const DefaultListCacheTTL = time.Minute
+
+// listCache caches tag, trigger and variable lists per user and workspace.
+// Entries are dropped when any request changes the workspace, when a get
+// returns an entity whose fingerprint differs from the cached list, and
+// after the TTL. Entries hold the raw JSON response, so every hit returns a
+// fresh copy that callers (e.g. redaction) may modify.
+type listCache struct {
+	mu         sync.Mutex
+	ttl        time.Duration // 0 disables the cache
+	workspaces map[string]*workspaceCache
+	now        func() time.Time
+}
+
+// workspaceCache holds the cached lists of one workspace.
+type workspaceCache struct {
+	generation int                    // bumped on invalidation
+	changedAt  time.Time              // last invalidation
+	entries    map[string]*cacheEntry // keyed by user + list kind
+}
+
+type cacheEntry struct {
+	kind         string
+	data         []byte
+	fingerprints map[string]string // entity path -> fingerprint
+	expiresAt    time.Time
+}
+
+// listCaches is the list cache shared by every GTM client.
+var listCaches = newListCache(DefaultListCacheTTL)
+
+func newListCache(ttl time.Duration) *listCache {
+	return &listCache{ttl: ttl, workspaces: make(map[string]*workspaceCache), now: time.Now}
+}
+
+// SetListCacheTTL sets how long tag, trigger and variable lists are cached.
+// 0 disables the cache.
+func SetListCacheTTL(ttl time.Duration) {
+	listCaches.mu.Lock()
+	defer listCaches.mu.Unlock()
+	listCaches.ttl = ttl
+	listCaches.workspaces = make(map[string]*workspaceCache)
+}
+
+// cachedList returns the list of kind ("tags", "triggers" or "variables")
+// in workspace for user from the cache, calling fetch on a miss.
+func cachedList[T any](c *listCache, user, workspace, kind string, fetch func() (T, error)) (T, error) {
+	key := user + "|" + kind
+
+	c.mu.Lock()
+	if c.ttl <= 0 {
+		c.mu.Unlock()
+		return fetch()
+	}
+	ttl := c.ttl
+	ws := c.workspace(workspace)
+	generation := ws.generation
+	entry := ws.entries[key]
+	if entry != nil && c.now().After(entry.expiresAt) {
+		delete(ws.entries, key)
+		entry = nil
+	}
+	c.mu.Unlock()
+
+	if entry != nil {
+		var result T
+		if err := json.Unmarshal(entry.data, &result); err == nil {
+			return result, nil
+		}
+	}
+
+	result, err := fetch()
+	if err != nil {
+		return result, err
+	}
+	data, err := json.Marshal(result)
+	if err != nil {
+		return result, nil
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	// Don't cache a list fetched while the workspace was being changed, or
+	// so soon after a change that the API may not reflect it yet
+	ws = c.workspace(workspace)
+	if ws.generation == generation && c.now().Sub(ws.changedAt) > consistencyWindow {
+		ws.entries[key] = &cacheEntry{
+			kind:         kind,
+			data:         data,
+			fingerprints: listFingerprints(data),
+			expiresAt:    c.now().Add(ttl),
+		}
+	}
+	return result, nil
+}
+
+// workspace returns the cache of a workspace, creating it. Callers hold c.mu.
+func (c *listCache) workspace(path string) *workspaceCache {
+	ws, ok := c.workspaces[path]
+	if !ok {
+		ws = &workspaceCache{entries: make(map[string]*cacheEntry)}
+		c.workspaces[path] = ws
+	}
+	return ws
+}
+
+// invalidate drops the cached lists of the workspace containing path, or of
+// every workspace below path for container and account level changes.
+func (c *listCache) invalidate(path string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if ws := workspaceOf(path); ws != "" {
+		c.drop(ws)
+		return
+	}
+	for ws := range c.workspaces {
+		if strings.HasPrefix(ws, path+"/") {
+			c.drop(ws)
+		}
+	}
+}
+
+// drop clears a workspace's entries. The workspace stays in the map with a
+// new generation so that fetches started before the change are not cached.
+// Callers hold c.mu.
+func (c *listCache) drop(path string) {
+	ws := c.workspace(path)
+	ws.generation++
+	ws.changedAt = c.now()
+	ws.entries = make(map[string]*cacheEntry)
+}
+
+// observe compares an entity read with a get call against the cached lists
+// of its workspace and drops them if the entity changed or is missing.
+func (c *listCache) observe(path, fingerprint string) {
+	workspace := workspaceOf(path)
+	if workspace == "" {
+		return
+	}
+	kind := strings.Split(strings.TrimPrefix(path, workspace+"/"), "/")[0]
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	ws, ok := c.workspaces[workspace]
+	if !ok {
+		return
+	}
+	for _, entry := range ws.entries {
+		if entry.kind == kind && entry.fingerprints[path] != fingerprint {
+			c.drop(workspace)
+			return
+		}
+	}
+}
+
+// listFingerprints extracts entity fingerprints from a raw list response.
+func listFingerprints(data []byte) map[string]string {
+	type entity struct {
+		Path        string `json:"path"`
+		Fingerprint string `json:"fingerprint"`
+	}
+	var resp struct {
+		Tag      []entity `json:"tag"`
+		Trigger  []entity `json:"trigger"`
+		Variable []entity `json:"variable"`
+	}
+	json.Unmarshal(data, &resp)
+
+	fingerprints := make(map[string]string)
+	for _, list := range [][]entity{resp.Tag, resp.Trigger, resp.Variable} {
+		for _, e := range list {
+			fingerprints[e.Path] = e.Fingerprint
+		}
+	}
+	return fingerprints
+}
+
+// cacheInvalidatingTransport drops cached lists whenever a request other
+// than GET reaches the GTM API, so changes made by any tool are seen.
+type cacheInvalidatingTransport struct {
+	wrapped http.RoundTripper
+	cache   *listCache
+}
+
+func (t *cacheInvalidatingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := t.wrapped.RoundTrip(req)
+	if req.Method != http.MethodGet {
+		t.cache.invalidate(apiPath(req.URL.Path))
+	}
+	return resp, err
+}
+
+// apiPath returns the GTM resource path of an API URL path, without any
+// custom method suffix such as ":create_version".
+func apiPath(urlPath string) string {
+	i := strings.Index(urlPath, "accounts/")
+	if i < 0 {
+		return ""
+	}
+	path := urlPath[i:]
+	if j := strings.Index(path, ":"); j >= 0 {
+		path = path[:j]
+	}
+	return strings.TrimSuffix(path, "/")
+}
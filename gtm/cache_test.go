@@ -0,0 +1,155 @@
+package gtm
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	tagmanager "google.golang.org/api/tagmanager/v2"
+)
+
+const testWorkspace = "accounts/1/containers/2/workspaces/3"
+
+// newTestListCache returns a cache whose clock starts past the consistency
+// window, so fetched lists are cached.
+func newTestListCache() (*listCache, *time.Time) {
+	now := time.Now()
+	c := newListCache(time.Minute)
+	c.now = func() time.Time { return now }
+	return c, &now
+}
+
+// countingFetch returns a tag list fetch that counts its calls.
+func countingFetch(calls *int, fingerprint string) func() (*tagmanager.ListTagsResponse, error) {
+	return func() (*tagmanager.ListTagsResponse, error) {
+		*calls++
+		return &tagmanager.ListTagsResponse{Tag: []*tagmanager.Tag{{
+			Path:        testWorkspace + "/tags/4",
+			Name:        "GA4 Config",
+			Fingerprint: fingerprint,
+			Parameter:   []*tagmanager.Parameter{{Key: "apiSecret", Type: ParamTemplate, Value: "s3cr3t"}},
+		}}}, nil
+	}
+}
+
+func TestListCache_HitReturnsCopy(t *testing.T) {
+	c, _ := newTestListCache()
+	calls := 0
+
+	first, err := cachedList(c, "u", testWorkspace, "tags", countingFetch(&calls, "1"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Callers such as redaction modify the result in place
+	first.Tag[0].Parameter[0].Value = RedactedValue
+
+	second, err := cachedList(c, "u", testWorkspace, "tags", countingFetch(&calls, "1"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if calls != 1 {
+		t.Errorf("fetch called %d times, want 1", calls)
+	}
+	if got := second.Tag[0].Parameter[0].Value; got != "s3cr3t" {
+		t.Errorf("cached value = %q, want the original", got)
+	}
+
+	// Another user does not share the entry
+	cachedList(c, "other", testWorkspace, "tags", countingFetch(&calls, "1"))
+	if calls != 2 {
+		t.Errorf("fetch called %d times for a second user, want 2", calls)
+	}
+}
+
+func TestListCache_Invalidation(t *testing.T) {
+	tests := []struct {
+		name       string
+		invalidate func(c *listCache)
+	}{
+		{name: "entity mutation", invalidate: func(c *listCache) { c.invalidate(testWorkspace + "/variables/9") }},
+		{name: "container mutation", invalidate: func(c *listCache) { c.invalidate("accounts/1/containers/2") }},
+		{name: "changed fingerprint", invalidate: func(c *listCache) { c.observe(testWorkspace+"/tags/4", "2") }},
+		{name: "entity missing from list", invalidate: func(c *listCache) { c.observe(testWorkspace+"/tags/5", "1") }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, now := newTestListCache()
+			calls := 0
+			cachedList(c, "u", testWorkspace, "tags", countingFetch(&calls, "1"))
+
+			tt.invalidate(c)
+			cachedList(c, "u", testWorkspace, "tags", countingFetch(&calls, "1"))
+			if calls != 2 {
+				t.Errorf("fetch called %d times after invalidation, want 2", calls)
+			}
+
+			// Lists fetched right after a change are not cached
+			cachedList(c, "u", testWorkspace, "tags", countingFetch(&calls, "1"))
+			if calls != 3 {
+				t.Errorf("fetch called %d times within the consistency window, want 3", calls)
+			}
+			*now = now.Add(consistencyWindow + time.Second)
+			cachedList(c, "u", testWorkspace, "tags", countingFetch(&calls, "1"))
+			cachedList(c, "u", testWorkspace, "tags", countingFetch(&calls, "1"))
+			if calls != 4 {
+				t.Errorf("fetch called %d times after the consistency window, want 4", calls)
+			}
+		})
+	}
+}
+
+func TestListCache_UnchangedFingerprintKeepsEntry(t *testing.T) {
+	c, _ := newTestListCache()
+	calls := 0
+	cachedList(c, "u", testWorkspace, "tags", countingFetch(&calls, "1"))
+
+	c.observe(testWorkspace+"/tags/4", "1")
+	c.invalidate("accounts/1/containers/7/workspaces/3/tags/4")
+	cachedList(c, "u", testWorkspace, "tags", countingFetch(&calls, "1"))
+	if calls != 1 {
+		t.Errorf("fetch called %d times, want 1", calls)
+	}
+}
+
+func TestListCache_Expiry(t *testing.T) {
+	c, now := newTestListCache()
+	calls := 0
+	cachedList(c, "u", testWorkspace, "tags", countingFetch(&calls, "1"))
+
+	*now = now.Add(2 * time.Minute)
+	cachedList(c, "u", testWorkspace, "tags", countingFetch(&calls, "1"))
+	if calls != 2 {
+		t.Errorf("fetch called %d times after expiry, want 2", calls)
+	}
+}
+
+func TestCacheInvalidatingTransport(t *testing.T) {
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer api.Close()
+
+	c, _ := newTestListCache()
+	calls := 0
+	cachedList(c, "u", testWorkspace, "tags", countingFetch(&calls, "1"))
+
+	client := &http.Client{Transport: &cacheInvalidatingTransport{wrapped: http.DefaultTransport, cache: c}}
+	resp, err := client.Get(api.URL + "/tagmanager/v2/" + testWorkspace + "/tags")
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	cachedList(c, "u", testWorkspace, "tags", countingFetch(&calls, "1"))
+	if calls != 1 {
+		t.Errorf("GET request invalidated the cache")
+	}
+
+	resp, err = client.Post(api.URL+"/tagmanager/v2/"+testWorkspace+":sync", "application/json", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	cachedList(c, "u", testWorkspace, "tags", countingFetch(&calls, "1"))
+	if calls != 2 {
+		t.Errorf("POST request did not invalidate the cache")
+	}
+}
@@ -0,0 +1,177 @@
+package gtm
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	tagmanager "google.golang.org/api/tagmanager/v2"
+)
+
+// maxChangelogVersions bounds how many versions a changelog fetches, as
+// each version is one API call.
+const maxChangelogVersions = 50
+
+// ChangelogOptions selects the versions of a changelog.
+type ChangelogOptions struct {
+	Since time.Time // only versions created at or after; zero for no bound
+	Until time.Time // only versions created before; zero for no bound
+	Limit int       // newest versions to include; defaults to maxChangelogVersions
+	Diff  bool      // compare each version with the one before it
+}
+
+// ChangelogEntry is one container version of a changelog.
+type ChangelogEntry struct {
+	VersionID   string    `json:"versionId"`
+	Name        string    `json:"name,omitempty"`
+	Description string    `json:"description,omitempty"`
+	CreatedAt   time.Time `json:"createdAt,omitzero"`
+	// Changes compares the version with the previous one; field changes are
+	// left out to keep the changelog readable.
+	Changes *ContainerDiff `json:"changes,omitempty"`
+}
+
+// Changelog lists container versions newest first.
+type Changelog struct {
+	Versions []ChangelogEntry `json:"versions"`
+	// Truncated is set when older versions in range were left out by the
+	// limit.
+	Truncated bool `json:"truncated,omitempty"`
+}
+
+// GenerateChangelog walks the versions of a container from the newest and
+// returns those created in the options' date range. Version creation times
+// come from the version fingerprint, so every candidate version is fetched.
+func (c *Client) GenerateChangelog(ctx context.Context, accountID, containerID string, opts ChangelogOptions) (*Changelog, error) {
+	limit := opts.Limit
+	if limit <= 0 || limit > maxChangelogVersions {
+		limit = maxChangelogVersions
+	}
+
+	headers, err := c.ListVersions(ctx, accountID, containerID)
+	if err != nil {
+		return nil, err
+	}
+	ids := versionIDsNewestFirst(headers)
+
+	log := &Changelog{Versions: []ChangelogEntry{}}
+	var versions []*tagmanager.ContainerVersion
+	// older is the version just before the range, once fetched.
+	var older *tagmanager.ContainerVersion
+	for _, id := range ids {
+		v, err := c.GetVersion(ctx, accountID, containerID, id)
+		if err != nil {
+			return nil, err
+		}
+		created := fingerprintTime(v.Fingerprint)
+		if !opts.Until.IsZero() && !created.Before(opts.Until) {
+			continue
+		}
+		if !opts.Since.IsZero() && created.Before(opts.Since) {
+			older = v
+			break
+		}
+		if len(versions) == limit {
+			log.Truncated = true
+			older = v
+			break
+		}
+		versions = append(versions, v)
+		log.Versions = append(log.Versions, ChangelogEntry{
+			VersionID:   v.ContainerVersionId,
+			Name:        v.Name,
+			Description: v.Description,
+			CreatedAt:   created,
+		})
+	}
+
+	if opts.Diff && len(versions) > 0 {
+		// The oldest version is compared with the one before the range, or
+		// with an empty container if it is the first version.
+		if older == nil {
+			older = &tagmanager.ContainerVersion{}
+		}
+		versions = append(versions, older)
+		for i := range log.Versions {
+			log.Versions[i].Changes = changelogDiff(versions[i+1], versions[i])
+		}
+	}
+	return log, nil
+}
+
+// versionIDsNewestFirst returns the IDs of versions that are not deleted,
+// newest first.
+func versionIDsNewestFirst(headers []VersionInfo) []string {
+	var ids []string
+	for _, h := range headers {
+		if !h.Deleted {
+			ids = append(ids, h.VersionID)
+		}
+	}
+	sort.Slice(ids, func(i, j int) bool {
+		a, _ := strconv.Atoi(ids[i])
+		b, _ := strconv.Atoi(ids[j])
+		return a > b
+	})
+	return ids
+}
+
+// changelogDiff compares two versions, keeping only which entities changed.
+func changelogDiff(base, head *tagmanager.ContainerVersion) *ContainerDiff {
+	d := DiffVersions(base, head)
+	for i := range d.Entities {
+		d.Entities[i].Changes = nil
+	}
+	return d
+}
+
+// Markdown renders the changelog for stakeholders: one section per version
+// with its notes and, when diffed, the entities it added, changed and
+// removed.
+func (l *Changelog) Markdown(title string) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "# %s\n", title)
+	if len(l.Versions) == 0 {
+		b.WriteString("\nNo versions in this range.\n")
+	}
+	for _, v := range l.Versions {
+		heading := "Version " + v.VersionID
+		if v.Name != "" {
+			heading += ": " + v.Name
+		}
+		fmt.Fprintf(&b, "\n## %s\n\n", heading)
+		if !v.CreatedAt.IsZero() {
+			fmt.Fprintf(&b, "_Created %s_\n\n", v.CreatedAt.Format("2006-01-02 15:04 MST"))
+		}
+		if v.Description != "" {
+			b.WriteString(strings.TrimSpace(v.Description) + "\n\n")
+		}
+		if v.Changes == nil {
+			continue
+		}
+		if len(v.Changes.Entities) == 0 {
+			b.WriteString("No entity changes.\n")
+			continue
+		}
+		for _, status := range []string{DiffAdded, DiffModified, DiffRemoved} {
+			for _, e := range v.Changes.Entities {
+				if e.Status == status {
+					fmt.Fprintf(&b, "- %s %s **%s**\n", changelogVerbs[status], e.EntityType, e.Name)
+				}
+			}
+		}
+	}
+	if l.Truncated {
+		b.WriteString("\n_Older versions omitted._\n")
+	}
+	return b.String()
+}
+
+var changelogVerbs = map[string]string{
+	DiffAdded:    "Added",
+	DiffModified: "Changed",
+	DiffRemoved:  "Removed",
+}
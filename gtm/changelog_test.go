@@ -0,0 +1,78 @@
+package gtm
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestGenerateChangelog(t *testing.T) {
+	day := func(d int) string {
+		return strconv.FormatInt(time.Date(2026, 3, d, 12, 0, 0, 0, time.UTC).UnixMilli(), 10)
+	}
+	versions := map[string]map[string]any{
+		"1": {"containerVersionId": "1", "name": "Initial", "fingerprint": day(1),
+			"tag": []map[string]any{{"tagId": "1", "name": "GA4 Config"}}},
+		"2": {"containerVersionId": "2", "name": "Purchases", "description": "Tracks purchases.", "fingerprint": day(5),
+			"tag": []map[string]any{{"tagId": "1", "name": "GA4 Config"}, {"tagId": "2", "name": "GA4 Purchase"}}},
+		"3": {"containerVersionId": "3", "name": "Cleanup", "fingerprint": day(9),
+			"tag": []map[string]any{{"tagId": "2", "name": "GA4 Purchase", "paused": true}}},
+	}
+	var fetched []string
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, "/version_headers") {
+			json.NewEncoder(w).Encode(map[string]any{"containerVersionHeader": []map[string]any{
+				{"containerVersionId": "1"}, {"containerVersionId": "2"}, {"containerVersionId": "3"},
+				{"containerVersionId": "4", "deleted": true},
+			}})
+			return
+		}
+		id := r.URL.Path[strings.LastIndex(r.URL.Path, "/")+1:]
+		fetched = append(fetched, id)
+		json.NewEncoder(w).Encode(versions[id])
+	})
+	ctx := context.Background()
+
+	log, err := client.GenerateChangelog(ctx, "1", "2", ChangelogOptions{
+		Since: time.Date(2026, 3, 4, 0, 0, 0, 0, time.UTC),
+		Diff:  true,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(log.Versions) != 2 || log.Versions[0].VersionID != "3" || log.Versions[1].VersionID != "2" {
+		t.Fatalf("versions = %+v", log.Versions)
+	}
+	if strings.Join(fetched, ",") != "3,2,1" {
+		t.Errorf("fetched %v, want each version once", fetched)
+	}
+	if c := log.Versions[0].Changes; c.Removed != 1 || c.Modified != 1 || c.Entities[0].Changes != nil {
+		t.Errorf("version 3 changes = %+v", c)
+	}
+	if c := log.Versions[1].Changes; c.Added != 1 || c.Entities[0].Name != "GA4 Purchase" {
+		t.Errorf("version 2 changes = %+v", c)
+	}
+
+	md := log.Markdown("Changelog")
+	for _, want := range []string{"## Version 3: Cleanup", "_Created 2026-03-05 12:00 UTC_", "Tracks purchases.", "- Added tag **GA4 Purchase**", "- Removed tag **GA4 Config**"} {
+		if !strings.Contains(md, want) {
+			t.Errorf("markdown missing %q:\n%s", want, md)
+		}
+	}
+
+	log, err = client.GenerateChangelog(ctx, "1", "2", ChangelogOptions{
+		Until: time.Date(2026, 3, 9, 0, 0, 0, 0, time.UTC),
+		Limit: 1,
+		Diff:  true,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(log.Versions) != 1 || log.Versions[0].VersionID != "2" || !log.Truncated || log.Versions[0].Changes.Added != 1 {
+		t.Errorf("changelog = %+v", log)
+	}
+}
@@ -0,0 +1,481 @@
+package gtm
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	tagmanager "google.golang.org/api/tagmanager/v2"
+)
+
+// MaxChangesetOperations bounds the size of one change set.
+const MaxChangesetOperations = 100
+
+// changesetRollbackTimeout bounds the rollback of a failed change set, which
+// runs even after the caller's context is canceled.
+const changesetRollbackTimeout = 2 * time.Minute
+
+// Change set actions.
+const (
+	ChangeCreate = "create"
+	ChangeUpdate = "update"
+	ChangeDelete = "delete"
+)
+
+// changesetRefPrefix marks a reference to the ID of an entity created
+// earlier in the same change set, e.g. "$ref:pageview" in firingTriggerId.
+const changesetRefPrefix = "$ref:"
+
+// ChangeOperation is one step of a change set.
+type ChangeOperation struct {
+	Action     string         `json:"action" jsonschema:"description:create, update or delete"`
+	EntityType string         `json:"entityType" jsonschema:"description:tag, trigger or variable"`
+	EntityID   string         `json:"entityId,omitempty" jsonschema:"description:ID of the entity to update or delete, or $ref:<name> of an entity created earlier in the change set"`
+	Ref        string         `json:"ref,omitempty" jsonschema:"description:Name for an entity this operation creates; later operations use $ref:<name> wherever its ID is expected"`
+	Entity     map[string]any `json:"entity,omitempty" jsonschema:"description:Tag Manager API JSON of the entity (e.g. name, type, parameter, firingTriggerId). create needs the full entity; update replaces only the fields given"`
+}
+
+// ChangesetStep reports an applied or reverted operation.
+type ChangesetStep struct {
+	Index      int    `json:"index"`
+	Action     string `json:"action"`
+	EntityType string `json:"entityType"`
+	EntityID   string `json:"entityId,omitempty"`
+	Name       string `json:"name,omitempty"`
+	Error      string `json:"error,omitempty"`
+}
+
+// ChangesetResult is the outcome of applying a change set. When an
+// operation fails, every operation applied before it is reverted in
+// reverse order and listed in RolledBack.
+type ChangesetResult struct {
+	Applied    []ChangesetStep `json:"applied"`
+	Failed     *ChangesetStep  `json:"failed,omitempty"`
+	RolledBack []ChangesetStep `json:"rolledBack,omitempty"`
+}
+
+// changesetAdapter runs change set operations on one entity type, with
+// entities in their API JSON form.
+type changesetAdapter struct {
+	idField string
+	path    func(id string) string
+	get     func(ctx context.Context, path string) (map[string]any, error)
+	create  func(ctx context.Context, e map[string]any) (map[string]any, error)
+	update  func(ctx context.Context, path, fingerprint string, e map[string]any) (map[string]any, error)
+	remove  func(ctx context.Context, path string) error
+	// revert restores an entity deleted in the workspace to its state in
+	// the latest container version
+	revert func(ctx context.Context, path, fingerprint string) (map[string]any, error)
+}
+
+// convertEntity converts between an API entity and its JSON object form.
+func convertEntity[From, To any](from From) (To, error) {
+	var to To
+	data, err := json.Marshal(from)
+	if err != nil {
+		return to, err
+	}
+	err = json.Unmarshal(data, &to)
+	return to, err
+}
+
+// newChangesetAdapter adapts the typed API calls of one entity type.
+func newChangesetAdapter[T any](idField string, path func(id string) string,
+	get func(ctx context.Context, path string) (*T, error),
+	create func(ctx context.Context, e *T) (*T, error),
+	update func(ctx context.Context, path, fingerprint string, e *T) (*T, error),
+	remove func(ctx context.Context, path string) error,
+	revert func(ctx context.Context, path, fingerprint string) (*T, error),
+) *changesetAdapter {
+	toMap := func(e *T, err error) (map[string]any, error) {
+		if err != nil {
+			return nil, MapGoogleError(err)
+		}
+		if e == nil {
+			return nil, nil
+		}
+		return convertEntity[*T, map[string]any](e)
+	}
+	return &changesetAdapter{
+		idField: idField,
+		path:    path,
+		get: func(ctx context.Context, path string) (map[string]any, error) {
+			return toMap(readAfterMutation(ctx, path, func() (*T, error) { return get(ctx, path) }))
+		},
+		create: func(ctx context.Context, m map[string]any) (map[string]any, error) {
+			e, err := convertEntity[map[string]any, *T](m)
+			if err != nil {
+				return nil, InvalidField("entity", "invalid entity: %v", err)
+			}
+			return toMap(create(ctx, e))
+		},
+		update: func(ctx context.Context, path, fingerprint string, m map[string]any) (map[string]any, error) {
+			e, err := convertEntity[map[string]any, *T](m)
+			if err != nil {
+				return nil, InvalidField("entity", "invalid entity: %v", err)
+			}
+			return toMap(update(ctx, path, fingerprint, e))
+		},
+		remove: func(ctx context.Context, path string) error {
+			return MapGoogleError(remove(ctx, path))
+		},
+		revert: func(ctx context.Context, path, fingerprint string) (map[string]any, error) {
+			return toMap(revert(ctx, path, fingerprint))
+		},
+	}
+}
+
+// changesetAdapters returns the adapters of the entity types change sets support.
+func (c *Client) changesetAdapters(accountID, containerID, workspaceID string) map[string]*changesetAdapter {
+	ws := c.Service.Accounts.Containers.Workspaces
+	parent := BuildWorkspacePath(accountID, containerID, workspaceID)
+	return map[string]*changesetAdapter{
+		"tag": newChangesetAdapter("tagId",
+			func(id string) string { return BuildTagPath(accountID, containerID, workspaceID, id) },
+			func(ctx context.Context, path string) (*tagmanager.Tag, error) {
+				return ws.Tags.Get(path).Context(ctx).Do()
+			},
+			func(ctx context.Context, e *tagmanager.Tag) (*tagmanager.Tag, error) {
+				return ws.Tags.Create(parent, e).Context(ctx).Do()
+			},
+			func(ctx context.Context, path, fp string, e *tagmanager.Tag) (*tagmanager.Tag, error) {
+				return ws.Tags.Update(path, e).Fingerprint(fp).Context(ctx).Do()
+			},
+			func(ctx context.Context, path string) error { return ws.Tags.Delete(path).Context(ctx).Do() },
+			func(ctx context.Context, path, fp string) (*tagmanager.Tag, error) {
+				resp, err := ws.Tags.Revert(path).Fingerprint(fp).Context(ctx).Do()
+				if err != nil {
+					return nil, err
+				}
+				return resp.Tag, nil
+			},
+		),
+		"trigger": newChangesetAdapter("triggerId",
+			func(id string) string { return BuildTriggerPath(accountID, containerID, workspaceID, id) },
+			func(ctx context.Context, path string) (*tagmanager.Trigger, error) {
+				return ws.Triggers.Get(path).Context(ctx).Do()
+			},
+			func(ctx context.Context, e *tagmanager.Trigger) (*tagmanager.Trigger, error) {
+				return ws.Triggers.Create(parent, e).Context(ctx).Do()
+			},
+			func(ctx context.Context, path, fp string, e *tagmanager.Trigger) (*tagmanager.Trigger, error) {
+				return ws.Triggers.Update(path, e).Fingerprint(fp).Context(ctx).Do()
+			},
+			func(ctx context.Context, path string) error { return ws.Triggers.Delete(path).Context(ctx).Do() },
+			func(ctx context.Context, path, fp string) (*tagmanager.Trigger, error) {
+				resp, err := ws.Triggers.Revert(path).Fingerprint(fp).Context(ctx).Do()
+				if err != nil {
+					return nil, err
+				}
+				return resp.Trigger, nil
+			},
+		),
+		"variable": newChangesetAdapter("variableId",
+			func(id string) string { return BuildVariablePath(accountID, containerID, workspaceID, id) },
+			func(ctx context.Context, path string) (*tagmanager.Variable, error) {
+				return ws.Variables.Get(path).Context(ctx).Do()
+			},
+			func(ctx context.Context, e *tagmanager.Variable) (*tagmanager.Variable, error) {
+				return ws.Variables.Create(parent, e).Context(ctx).Do()
+			},
+			func(ctx context.Context, path, fp string, e *tagmanager.Variable) (*tagmanager.Variable, error) {
+				return ws.Variables.Update(path, e).Fingerprint(fp).Context(ctx).Do()
+			},
+			func(ctx context.Context, path string) error { return ws.Variables.Delete(path).Context(ctx).Do() },
+			func(ctx context.Context, path, fp string) (*tagmanager.Variable, error) {
+				resp, err := ws.Variables.Revert(path).Fingerprint(fp).Context(ctx).Do()
+				if err != nil {
+					return nil, err
+				}
+				return resp.Variable, nil
+			},
+		),
+	}
+}
+
+// ValidateChangeset checks the shape of a change set before anything is applied.
+func ValidateChangeset(ops []ChangeOperation) error {
+	if len(ops) == 0 {
+		return InvalidField("operations", "at least one operation is required")
+	}
+	if len(ops) > MaxChangesetOperations {
+		return InvalidField("operations", "a change set has at most %d operations", MaxChangesetOperations)
+	}
+	refs := make(map[string]bool)
+	for i, op := range ops {
+		field := fmt.Sprintf("operations[%d]", i)
+		switch op.EntityType {
+		case "tag", "trigger", "variable":
+		default:
+			return InvalidField(field+".entityType", "entityType must be tag, trigger or variable")
+		}
+		switch op.Action {
+		case ChangeCreate:
+			if len(op.Entity) == 0 {
+				return InvalidField(field+".entity", "create needs an entity")
+			}
+			if op.EntityID != "" {
+				return InvalidField(field+".entityId", "create does not take an entityId")
+			}
+		case ChangeUpdate:
+			if len(op.Entity) == 0 {
+				return InvalidField(field+".entity", "update needs the fields to change")
+			}
+			fallthrough
+		case ChangeDelete:
+			if op.EntityID == "" {
+				return InvalidField(field+".entityId", "%s needs an entityId", op.Action)
+			}
+			if ref, ok := strings.CutPrefix(op.EntityID, changesetRefPrefix); ok && !refs[ref] {
+				return InvalidField(field+".entityId", "%s is not created by an earlier operation", op.EntityID)
+			}
+		default:
+			return InvalidField(field+".action", "action must be create, update or delete")
+		}
+		if op.Ref != "" {
+			if op.Action != ChangeCreate {
+				return InvalidField(field+".ref", "only create operations take a ref")
+			}
+			if refs[op.Ref] {
+				return InvalidField(field+".ref", "ref %q is used twice", op.Ref)
+			}
+			refs[op.Ref] = true
+		}
+	}
+	return nil
+}
+
+// resolveRefs replaces "$ref:<name>" strings anywhere in v with the IDs of
+// entities created earlier in the change set.
+func resolveRefs(v any, ids map[string]string) (any, error) {
+	switch x := v.(type) {
+	case string:
+		ref, ok := strings.CutPrefix(x, changesetRefPrefix)
+		if !ok {
+			return x, nil
+		}
+		id, ok := ids[ref]
+		if !ok {
+			return nil, fmt.Errorf("%s is not created by an earlier operation", x)
+		}
+		return id, nil
+	case map[string]any:
+		out := make(map[string]any, len(x))
+		for k, item := range x {
+			r, err := resolveRefs(item, ids)
+			if err != nil {
+				return nil, err
+			}
+			out[k] = r
+		}
+		return out, nil
+	case []any:
+		out := make([]any, len(x))
+		for i, item := range x {
+			r, err := resolveRefs(item, ids)
+			if err != nil {
+				return nil, err
+			}
+			out[i] = r
+		}
+		return out, nil
+	default:
+		return v, nil
+	}
+}
+
+// PlanChangeset validates ops and checks that the entities they update or
+// delete exist, returning the steps the change set would apply.
+func (c *Client) PlanChangeset(ctx context.Context, accountID, containerID, workspaceID string, ops []ChangeOperation) ([]ChangesetStep, error) {
+	if err := ValidateChangeset(ops); err != nil {
+		return nil, err
+	}
+	adapters := c.changesetAdapters(accountID, containerID, workspaceID)
+	steps := make([]ChangesetStep, 0, len(ops))
+	for i, op := range ops {
+		step := ChangesetStep{Index: i, Action: op.Action, EntityType: op.EntityType, EntityID: op.EntityID}
+		switch {
+		case op.Action == ChangeCreate:
+			step.Name, _ = op.Entity["name"].(string)
+		case !strings.HasPrefix(op.EntityID, changesetRefPrefix):
+			e, err := adapters[op.EntityType].get(ctx, adapters[op.EntityType].path(op.EntityID))
+			if err != nil {
+				return nil, fmt.Errorf("operation %d: %s %s: %w", i, op.EntityType, op.EntityID, err)
+			}
+			step.Name = mapString(e, "name")
+		}
+		steps = append(steps, step)
+	}
+	return steps, nil
+}
+
+// changesetUndo reverts one applied operation.
+type changesetUndo struct {
+	step ChangesetStep
+	run  func(ctx context.Context) (ChangesetStep, error)
+}
+
+// ApplyChangeset applies ops in order to a workspace. If an operation
+// fails, the operations already applied are reverted in reverse order:
+// created entities are deleted, updated entities get their prior state back
+// and deleted entities are restored with the revert endpoint (keeping their
+// ID) or, for entities not in the latest version, recreated from their prior
+// state under a new ID.
+func (c *Client) ApplyChangeset(ctx context.Context, accountID, containerID, workspaceID string, ops []ChangeOperation) (*ChangesetResult, error) {
+	if err := ValidateChangeset(ops); err != nil {
+		return nil, err
+	}
+	adapters := c.changesetAdapters(accountID, containerID, workspaceID)
+	defer RecentMutations.Mark(ctx, BuildWorkspacePath(accountID, containerID, workspaceID))
+
+	result := &ChangesetResult{Applied: []ChangesetStep{}}
+	ids := make(map[string]string)
+	var undos []changesetUndo
+
+	ExpectProgress(ctx, len(ops))
+	for i, op := range ops {
+		a := adapters[op.EntityType]
+		step := ChangesetStep{Index: i, Action: op.Action, EntityType: op.EntityType}
+		undo, err := applyChangeOperation(ctx, a, op, ids, &step)
+		if err != nil {
+			step.Error = err.Error()
+			result.Failed = &step
+			result.RolledBack = rollbackChangeset(ctx, undos)
+			return result, fmt.Errorf("operation %d (%s %s) failed, %d applied operations rolled back: %w",
+				i, op.Action, op.EntityType, len(undos), err)
+		}
+		result.Applied = append(result.Applied, step)
+		undos = append(undos, changesetUndo{step: step, run: undo})
+		StepProgress(ctx, "Applied %s %s %q", op.Action, op.EntityType, step.Name)
+	}
+	return result, nil
+}
+
+// applyChangeOperation runs one operation, filling in step, and returns the
+// function that reverts it.
+func applyChangeOperation(ctx context.Context, a *changesetAdapter, op ChangeOperation, ids map[string]string, step *ChangesetStep) (func(ctx context.Context) (ChangesetStep, error), error) {
+	resolved, err := resolveRefs(op.Entity, ids)
+	if err != nil {
+		return nil, err
+	}
+	entity, _ := resolved.(map[string]any)
+	id := op.EntityID
+	if ref, ok := strings.CutPrefix(id, changesetRefPrefix); ok {
+		id = ids[ref]
+	}
+	step.EntityID = id
+
+	switch op.Action {
+	case ChangeCreate:
+		created, err := a.create(ctx, entity)
+		if err != nil {
+			return nil, err
+		}
+		step.EntityID = mapString(created, a.idField)
+		step.Name = mapString(created, "name")
+		if op.Ref != "" {
+			ids[op.Ref] = step.EntityID
+		}
+		path := mapString(created, "path")
+		return func(ctx context.Context) (ChangesetStep, error) {
+			undo := ChangesetStep{Action: "deleted", EntityID: step.EntityID, Name: step.Name}
+			return undo, a.remove(ctx, path)
+		}, nil
+
+	case ChangeUpdate:
+		path := a.path(id)
+		prior, err := a.get(ctx, path)
+		if err != nil {
+			return nil, err
+		}
+		merged := make(map[string]any, len(prior)+len(entity))
+		for k, v := range prior {
+			merged[k] = v
+		}
+		for k, v := range entity {
+			merged[k] = v
+		}
+		updated, err := a.update(ctx, path, mapString(prior, "fingerprint"), merged)
+		if err != nil {
+			return nil, err
+		}
+		step.Name = mapString(updated, "name")
+		return func(ctx context.Context) (ChangesetStep, error) {
+			undo := ChangesetStep{Action: "restored", EntityID: id, Name: mapString(prior, "name")}
+			current, err := a.get(ctx, path)
+			if err != nil {
+				return undo, err
+			}
+			_, err = a.update(ctx, path, mapString(current, "fingerprint"), prior)
+			return undo, err
+		}, nil
+
+	default: // ChangeDelete
+		path := a.path(id)
+		prior, err := a.get(ctx, path)
+		if err != nil {
+			return nil, err
+		}
+		step.Name = mapString(prior, "name")
+		if err := a.remove(ctx, path); err != nil {
+			return nil, err
+		}
+		return func(ctx context.Context) (ChangesetStep, error) {
+			undo := ChangesetStep{Action: "restored", EntityID: id, Name: step.Name}
+			// The revert endpoint brings back the entity of the latest
+			// version under its ID; its workspace changes are then reapplied
+			if reverted, err := a.revert(ctx, path, mapString(prior, "fingerprint")); err == nil && reverted != nil {
+				_, err := a.update(ctx, path, mapString(reverted, "fingerprint"), prior)
+				return undo, err
+			}
+			recreated, err := a.create(ctx, withoutIDs(prior, a.idField))
+			if err != nil {
+				return undo, err
+			}
+			undo.Action = "recreated"
+			undo.EntityID = mapString(recreated, a.idField)
+			return undo, nil
+		}, nil
+	}
+}
+
+// withoutIDs copies an entity without the fields the API assigns.
+func withoutIDs(e map[string]any, idField string) map[string]any {
+	out := make(map[string]any, len(e))
+	for k, v := range e {
+		switch k {
+		case idField, "accountId", "containerId", "workspaceId", "path", "fingerprint", "tagManagerUrl":
+		default:
+			out[k] = v
+		}
+	}
+	return out
+}
+
+// rollbackChangeset reverts applied operations, newest first. Failures are
+// reported per operation and do not stop the rollback. A canceled ctx, e.g.
+// of a client that disconnected, does not stop it either: that is often
+// why the change set failed.
+func rollbackChangeset(ctx context.Context, undos []changesetUndo) []ChangesetStep {
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), changesetRollbackTimeout)
+	defer cancel()
+	steps := []ChangesetStep{}
+	for i := len(undos) - 1; i >= 0; i-- {
+		step, err := undos[i].run(ctx)
+		step.Index = undos[i].step.Index
+		step.EntityType = undos[i].step.EntityType
+		if err != nil {
+			step.Error = err.Error()
+		}
+		steps = append(steps, step)
+	}
+	return steps
+}
+
+// mapString returns a string entry of a decoded JSON object, or "".
+func mapString(m map[string]any, key string) string {
+	s, _ := m[key].(string)
+	return s
+}
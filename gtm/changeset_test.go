@@ -0,0 +1,162 @@
+package gtm
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// fakeWorkspace serves tag, trigger and variable CRUD for a single workspace.
+type fakeWorkspace struct {
+	mu       sync.Mutex
+	entities map[string]map[string]any // keyed by path
+	nextID   int
+	failName string // create or update of an entity with this name fails
+	calls    []string
+}
+
+func (f *fakeWorkspace) serve(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	path := strings.TrimPrefix(r.URL.Path, "/tagmanager/v2/")
+	f.calls = append(f.calls, r.Method+" "+path)
+
+	var body map[string]any
+	json.NewDecoder(r.Body).Decode(&body)
+	if body != nil && body["name"] == f.failName {
+		http.Error(w, `{"error":{"code":400,"message":"invalid"}}`, http.StatusBadRequest)
+		return
+	}
+
+	switch {
+	case r.Method == http.MethodPost && strings.HasSuffix(path, ":revert"):
+		http.Error(w, `{"error":{"code":404,"message":"not in version"}}`, http.StatusNotFound)
+	case r.Method == http.MethodPost:
+		f.nextID++
+		kind := path[strings.LastIndex(path, "/")+1:]
+		id := fmt.Sprint(f.nextID)
+		body[strings.TrimSuffix(kind, "s")+"Id"] = id
+		body["path"] = path + "/" + id
+		f.entities[path+"/"+id] = body
+		json.NewEncoder(w).Encode(body)
+	case r.Method == http.MethodPut:
+		if f.entities[path] == nil {
+			http.Error(w, `{"error":{"code":404,"message":"not found"}}`, http.StatusNotFound)
+			return
+		}
+		f.entities[path] = body
+		json.NewEncoder(w).Encode(body)
+	case r.Method == http.MethodDelete:
+		delete(f.entities, path)
+	case f.entities[path] != nil:
+		json.NewEncoder(w).Encode(f.entities[path])
+	default:
+		http.Error(w, `{"error":{"code":404,"message":"not found"}}`, http.StatusNotFound)
+	}
+}
+
+func TestApplyChangeset_RollsBack(t *testing.T) {
+	ws := "accounts/1/containers/2/workspaces/3"
+	fake := &fakeWorkspace{
+		entities: map[string]map[string]any{
+			ws + "/variables/50": {"variableId": "50", "name": "Old", "type": "c", "path": ws + "/variables/50"},
+			ws + "/tags/60":      {"tagId": "60", "name": "Legacy", "type": "html", "path": ws + "/tags/60"},
+		},
+		nextID:   100,
+		failName: "Broken",
+	}
+	client := newTestClient(t, fake.serve)
+
+	result, err := client.ApplyChangeset(context.Background(), "1", "2", "3", []ChangeOperation{
+		{Action: ChangeCreate, EntityType: "trigger", Ref: "pv", Entity: map[string]any{"name": "PV", "type": "pageview"}},
+		{Action: ChangeUpdate, EntityType: "variable", EntityID: "50", Entity: map[string]any{"name": "New"}},
+		{Action: ChangeDelete, EntityType: "tag", EntityID: "60"},
+		{Action: ChangeCreate, EntityType: "tag", Entity: map[string]any{"name": "Broken", "type": "html", "firingTriggerId": []any{"$ref:pv"}}},
+	})
+	if err == nil {
+		t.Fatal("change set with a failing operation succeeded")
+	}
+	if len(result.Applied) != 3 || result.Failed == nil || result.Failed.Index != 3 {
+		t.Fatalf("applied %+v, failed %+v", result.Applied, result.Failed)
+	}
+
+	// Rolled back newest first: the deleted tag is recreated (it is not in
+	// the latest version), the variable restored and the trigger deleted
+	var actions []string
+	for _, step := range result.RolledBack {
+		if step.Error != "" {
+			t.Errorf("rollback of operation %d failed: %s", step.Index, step.Error)
+		}
+		actions = append(actions, fmt.Sprintf("%d:%s", step.Index, step.Action))
+	}
+	if got := strings.Join(actions, ","); got != "2:recreated,1:restored,0:deleted" {
+		t.Errorf("rolled back %s", got)
+	}
+
+	if fake.entities[ws+"/variables/50"]["name"] != "Old" {
+		t.Errorf("variable = %v, want its prior name", fake.entities[ws+"/variables/50"])
+	}
+	if fake.entities[ws+"/triggers/101"] != nil {
+		t.Error("created trigger was not deleted")
+	}
+	names := map[string]bool{}
+	for _, e := range fake.entities {
+		names[e["name"].(string)] = true
+	}
+	if !names["Legacy"] || len(fake.entities) != 2 {
+		t.Errorf("entities after rollback = %v", fake.entities)
+	}
+}
+
+func TestApplyChangeset_RollsBackAfterCancel(t *testing.T) {
+	ws := "accounts/1/containers/2/workspaces/3"
+	fake := &fakeWorkspace{entities: map[string]map[string]any{}, nextID: 100}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		// The client goes away while the tag is being created
+		if r.Method == http.MethodPost && strings.HasSuffix(r.URL.Path, "/tags") {
+			cancel()
+			http.Error(w, `{"error":{"code":503,"message":"unavailable"}}`, http.StatusServiceUnavailable)
+			return
+		}
+		fake.serve(w, r)
+	})
+
+	result, err := client.ApplyChangeset(ctx, "1", "2", "3", []ChangeOperation{
+		{Action: ChangeCreate, EntityType: "trigger", Ref: "pv", Entity: map[string]any{"name": "PV", "type": "pageview"}},
+		{Action: ChangeCreate, EntityType: "tag", Entity: map[string]any{"name": "GA4", "type": "gaawe", "firingTriggerId": []any{"$ref:pv"}}},
+	})
+	if err == nil {
+		t.Fatal("change set with a canceled context succeeded")
+	}
+	if len(result.RolledBack) != 1 || result.RolledBack[0].Error != "" {
+		t.Fatalf("rolled back %+v", result.RolledBack)
+	}
+	if fake.entities[ws+"/triggers/101"] != nil {
+		t.Error("created trigger was not deleted after the context was canceled")
+	}
+}
+
+func TestValidateChangeset(t *testing.T) {
+	for name, ops := range map[string][]ChangeOperation{
+		"empty":          nil,
+		"unknown type":   {{Action: ChangeDelete, EntityType: "folder", EntityID: "1"}},
+		"unknown action": {{Action: "rename", EntityType: "tag", EntityID: "1"}},
+		"create with id": {{Action: ChangeCreate, EntityType: "tag", EntityID: "1", Entity: map[string]any{"name": "x"}}},
+		"update no body": {{Action: ChangeUpdate, EntityType: "tag", EntityID: "1"}},
+		"unknown ref":    {{Action: ChangeDelete, EntityType: "tag", EntityID: "$ref:later"}},
+		"duplicate ref": {
+			{Action: ChangeCreate, EntityType: "tag", Ref: "a", Entity: map[string]any{"name": "x"}},
+			{Action: ChangeCreate, EntityType: "tag", Ref: "a", Entity: map[string]any{"name": "y"}},
+		},
+	} {
+		if err := ValidateChangeset(ops); err == nil {
+			t.Errorf("%s: change set accepted", name)
+		}
+	}
+}
@@ -1,76 +1,94 @@
-// Package gtm provides a client for the Google Tag Manager API.
+// Package gtm provides a client for the Google Tag Manager API. Client
+// methods retry rate-limited and transient failures with backoff and map
+// API errors to the sentinel errors in errors.go, such as ErrNotFound. The
+// package does not depend on the MCP server and can be imported on its own;
+// package tools exposes it as MCP tools.
 package gtm
 
 import (
 	"context"
 	"fmt"
-	"log"
 	"net/http"
-	"net/http/httputil"
-	"os"
-	"regexp"
-	"strings"
 
 	"golang.org/x/oauth2"
+	analyticsadmin "google.golang.org/api/analyticsadmin/v1beta"
 	"google.golang.org/api/option"
 	tagmanager "google.golang.org/api/tagmanager/v2"
 )
 
-var authHeaderRe = regexp.MustCompile(`(?i)(Authorization:\s*)Bearer\s+\S+`)
+// transportWrapper wraps the HTTP transport of every new client; nil leaves
+// it unchanged.
+var transportWrapper func(http.RoundTripper) http.RoundTripper
 
-// loggingTransport wraps an http.RoundTripper and logs request/response bodies
-// with sensitive headers redacted.
-type loggingTransport struct {
-	wrapped http.RoundTripper
-}
-
-func (t *loggingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
-	dump, _ := httputil.DumpRequestOut(req, true)
-	redacted := authHeaderRe.ReplaceAllString(string(dump), "${1}Bearer [REDACTED]")
-	log.Printf("[HTTP REQUEST] %s", redacted)
-
-	resp, err := t.wrapped.RoundTrip(req)
-	if err != nil {
-		return resp, err
-	}
-
-	respDump, _ := httputil.DumpResponse(resp, true)
-	log.Printf("[HTTP RESPONSE] %s", string(respDump))
-
-	return resp, nil
+// SetTransportWrapper makes every client created afterwards send its
+// requests through wrap, e.g. to log them. Nil removes the wrapper.
+func SetTransportWrapper(wrap func(http.RoundTripper) http.RoundTripper) {
+	transportWrapper = wrap
 }
 
 // Client wraps the Google Tag Manager API service.
 type Client struct {
 	Service *tagmanager.Service
+	// Analytics is the Google Analytics Admin API service of the same user,
+	// used to look up GA4 measurement IDs.
+	Analytics *analyticsadmin.Service
+
+	user string       // quota and cache key of the calling user
+	http *http.Client // authenticated client behind Service, for raw API requests
+}
+
+// ClientOptions configures NewClientWithOptions.
+type ClientOptions struct {
+	// User keys the per-user API quota and list cache: clients of the same
+	// user share them. Empty means "default".
+	User string
+
+	// Endpoint replaces the Tag Manager API base URL, e.g. with a test
+	// server. Empty uses Google's.
+	Endpoint string
 }
 
 // NewClient creates a GTM client from an OAuth2 token source.
 // The token source should handle automatic refresh.
 func NewClient(ctx context.Context, tokenSource oauth2.TokenSource) (*Client, error) {
+	return NewClientWithOptions(ctx, tokenSource, ClientOptions{})
+}
+
+// NewClientWithOptions creates a GTM client from an OAuth2 token source,
+// with API calls retried on rate limits and server errors and Google errors
+// mapped to the Err* sentinels.
+func NewClientWithOptions(ctx context.Context, tokenSource oauth2.TokenSource, opts ClientOptions) (*Client, error) {
 	if tokenSource == nil {
 		return nil, fmt.Errorf("token source is required")
 	}
 
-	opts := []option.ClientOption{option.WithTokenSource(tokenSource)}
+	httpClient := oauth2.NewClient(ctx, tokenSource)
+	if transportWrapper != nil {
+		httpClient.Transport = transportWrapper(httpClient.Transport)
+	}
 
-	// Enable HTTP request/response logging when GTM_DEBUG is set
-	if os.Getenv("GTM_DEBUG") != "" {
-		baseURL := os.Getenv("BASE_URL")
-		if baseURL != "" && !strings.Contains(baseURL, "localhost") && !strings.Contains(baseURL, "127.0.0.1") {
-			log.Printf("WARNING: GTM_DEBUG ignored in production (BASE_URL=%s)", baseURL)
-		} else {
-			log.Printf("WARNING: GTM_DEBUG is enabled — HTTP bodies will be logged (headers redacted)")
-			httpClient := oauth2.NewClient(ctx, tokenSource)
-			httpClient.Transport = &loggingTransport{wrapped: httpClient.Transport}
-			opts = []option.ClientOption{option.WithHTTPClient(httpClient)}
-		}
+	// The Analytics Admin API has its own quota and no cached lists
+	analytics, err := analyticsadmin.NewService(ctx, option.WithHTTPClient(&http.Client{Transport: httpClient.Transport}))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create analyticsadmin service: %w", err)
 	}
 
-	service, err := tagmanager.NewService(ctx, opts...)
+	// Queue requests within the user's GTM API quota and drop cached lists
+	// of any workspace a request changes
+	user := opts.User
+	if user == "" {
+		user = "default"
+	}
+	httpClient.Transport = &quotaTransport{wrapped: httpClient.Transport, quota: quotas.forUser(user)}
+	httpClient.Transport = &cacheInvalidatingTransport{wrapped: httpClient.Transport, cache: listCaches}
+	serviceOpts := []option.ClientOption{option.WithHTTPClient(httpClient)}
+	if opts.Endpoint != "" {
+		serviceOpts = append(serviceOpts, option.WithEndpoint(opts.Endpoint))
+	}
+	service, err := tagmanager.NewService(ctx, serviceOpts...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create tagmanager service: %w", err)
 	}
 
-	return &Client{Service: service}, nil
+	return &Client{Service: service, Analytics: analytics, user: user, http: httpClient}, nil
 }
@@ -11,11 +11,11 @@ import (
 func (c *Client) ListClients(ctx context.Context, accountID, containerID, workspaceID string) ([]ClientInfo, error) {
 	parent := fmt.Sprintf("accounts/%s/containers/%s/workspaces/%s", accountID, containerID, workspaceID)
 
-	resp, err := retryWithBackoff(ctx, 3, func() (*tagmanager.ListClientsResponse, error) {
+	resp, err := RetryWithBackoff(ctx, func() (*tagmanager.ListClientsResponse, error) {
 		return c.Service.Accounts.Containers.Workspaces.Clients.List(parent).Context(ctx).Do()
 	})
 	if err != nil {
-		return nil, mapGoogleError(err)
+		return nil, MapGoogleError(err)
 	}
 	if resp == nil {
 		return []ClientInfo{}, nil
@@ -29,11 +29,11 @@ func (c *Client) GetClient(ctx context.Context, accountID, containerID, workspac
 	path := fmt.Sprintf("accounts/%s/containers/%s/workspaces/%s/clients/%s",
 		accountID, containerID, workspaceID, clientID)
 
-	cl, err := retryWithBackoff(ctx, 3, func() (*tagmanager.Client, error) {
+	cl, err := readAfterMutation(ctx, path, func() (*tagmanager.Client, error) {
 		return c.Service.Accounts.Containers.Workspaces.Clients.Get(path).Context(ctx).Do()
 	})
 	if err != nil {
-		return nil, mapGoogleError(err)
+		return nil, MapGoogleError(err)
 	}
 
 	result := toClient(cl)
@@ -54,8 +54,9 @@ func (c *Client) CreateClient(ctx context.Context, accountID, containerID, works
 
 	result, err := c.Service.Accounts.Containers.Workspaces.Clients.Create(parent, cl).Context(ctx).Do()
 	if err != nil {
-		return nil, mapGoogleError(err)
+		return nil, MapGoogleError(err)
 	}
+	RecentMutations.Mark(ctx, result.Path)
 
 	return &CreatedClient{
 		ClientID:    result.ClientId,
@@ -68,9 +69,11 @@ func (c *Client) CreateClient(ctx context.Context, accountID, containerID, works
 
 // UpdateClient updates an existing client. It fetches the current client first to get the fingerprint.
 func (c *Client) UpdateClient(ctx context.Context, path string, input *ClientInput) (*CreatedClient, error) {
-	current, err := c.Service.Accounts.Containers.Workspaces.Clients.Get(path).Context(ctx).Do()
+	current, err := readAfterMutation(ctx, path, func() (*tagmanager.Client, error) {
+		return c.Service.Accounts.Containers.Workspaces.Clients.Get(path).Context(ctx).Do()
+	})
 	if err != nil {
-		return nil, mapGoogleError(err)
+		return nil, MapGoogleError(err)
 	}
 
 	cl := &tagmanager.Client{
@@ -83,8 +86,9 @@ func (c *Client) UpdateClient(ctx context.Context, path string, input *ClientInp
 
 	result, err := c.Service.Accounts.Containers.Workspaces.Clients.Update(path, cl).Fingerprint(current.Fingerprint).Context(ctx).Do()
 	if err != nil {
-		return nil, mapGoogleError(err)
+		return nil, MapGoogleError(err)
 	}
+	RecentMutations.Mark(ctx, result.Path)
 
 	return &CreatedClient{
 		ClientID:    result.ClientId,
@@ -98,7 +102,7 @@ func (c *Client) UpdateClient(ctx context.Context, path string, input *ClientInp
 // DeleteClient deletes a client from the workspace.
 func (c *Client) DeleteClient(ctx context.Context, path string) error {
 	err := c.Service.Accounts.Containers.Workspaces.Clients.Delete(path).Context(ctx).Do()
-	return mapGoogleError(err)
+	return MapGoogleError(err)
 }
 
 func toClients(clients []*tagmanager.Client) []ClientInfo {
@@ -0,0 +1,33 @@
+package gtm
+
+// CompactEntity is an entity's ID, name and type. List tools return it in
+// compact mode instead of the full entity with its parameter tree.
+type CompactEntity struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+	Type string `json:"type,omitempty"`
+}
+
+func CompactTags(tags []Tag) []CompactEntity {
+	result := make([]CompactEntity, 0, len(tags))
+	for _, t := range tags {
+		result = append(result, CompactEntity{ID: t.TagID, Name: t.Name, Type: t.Type})
+	}
+	return result
+}
+
+func CompactTriggers(triggers []Trigger) []CompactEntity {
+	result := make([]CompactEntity, 0, len(triggers))
+	for _, t := range triggers {
+		result = append(result, CompactEntity{ID: t.TriggerID, Name: t.Name, Type: t.Type})
+	}
+	return result
+}
+
+func CompactVariables(variables []Variable) []CompactEntity {
+	result := make([]CompactEntity, 0, len(variables))
+	for _, v := range variables {
+		result = append(result, CompactEntity{ID: v.VariableID, Name: v.Name, Type: v.Type})
+	}
+	return result
+}
@@ -0,0 +1,24 @@
+package gtm
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestCompactTriggers(t *testing.T) {
+	triggers := []Trigger{{
+		TriggerID:         "7",
+		Name:              "Purchase",
+		Type:              "customEvent",
+		Path:              "accounts/1/containers/2/workspaces/3/triggers/7",
+		CustomEventFilter: []map[string]any{{"type": "equals"}},
+	}}
+
+	data, err := json.Marshal(CompactTriggers(triggers))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := `[{"id":"7","name":"Purchase","type":"customEvent"}]`; string(data) != want {
+		t.Errorf("compact = %s, want %s", data, want)
+	}
+}
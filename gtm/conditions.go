@@ -0,0 +1,162 @@
+package gtm
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// SimpleCondition is the human-friendly form of a trigger condition, e.g.
+// {"variable": "{{Click URL}}", "op": "contains", "value": "tel:"}. It is
+// compiled to the arg0/arg1 parameter structure the API expects.
+type SimpleCondition struct {
+	Variable   string `json:"variable"`
+	Op         string `json:"op"`
+	Value      string `json:"value"`
+	Negate     bool   `json:"negate,omitempty"`
+	IgnoreCase bool   `json:"ignoreCase,omitempty"`
+}
+
+// ConditionInput is a trigger condition in tool input: the simplified
+// {variable, op, value} form or raw GTM {type, parameter}.
+type ConditionInput struct {
+	Variable   string      `json:"variable,omitempty" jsonschema:"description:Variable to test, e.g. {{Page Path}} (simplified form)"`
+	Op         string      `json:"op,omitempty" jsonschema:"description:Operator (simplified form): equals, contains, startsWith, endsWith, matchRegex, cssSelector, greater, greaterOrEquals, less, lessOrEquals"`
+	Value      string      `json:"value,omitempty" jsonschema:"description:Value to compare with (simplified form)"`
+	Negate     bool        `json:"negate,omitempty" jsonschema:"description:Invert the condition"`
+	IgnoreCase bool        `json:"ignoreCase,omitempty" jsonschema:"description:Compare case-insensitively (simplified form)"`
+	Type       string      `json:"type,omitempty" jsonschema:"description:GTM condition type (raw form, with parameter)"`
+	Parameter  []Parameter `json:"parameter,omitempty" jsonschema:"description:GTM condition parameters arg0 and arg1 (raw form)"`
+}
+
+// compile converts the condition to the raw GTM form.
+func (c ConditionInput) compile(field string) (Condition, error) {
+	if c.Type == "" && c.Parameter == nil {
+		compiled, err := SimpleCondition{Variable: c.Variable, Op: c.Op, Value: c.Value, Negate: c.Negate, IgnoreCase: c.IgnoreCase}.Compile()
+		if err != nil {
+			return Condition{}, InvalidField(field, "%s: %v", field, err)
+		}
+		return compiled, nil
+	}
+	if c.Variable != "" || c.Op != "" || c.Value != "" || c.IgnoreCase {
+		return Condition{}, InvalidField(field, "%s: use either variable/op/value or type/parameter, not both", field)
+	}
+	normalizeParams(c.Parameter)
+	if err := validateParams(field+".parameter", c.Parameter, true); err != nil {
+		return Condition{}, InvalidField(field, "%v", err)
+	}
+	return Condition{Type: c.Type, Negate: c.Negate, Parameter: c.Parameter}, nil
+}
+
+// ConditionsInput returns the conditions of a tool input, given either as
+// the typed field or, for backward compatibility, as a JSON string in
+// jsonField. Neither returns nil.
+func ConditionsInput(field string, typed []ConditionInput, jsonField, data string) ([]Condition, error) {
+	if typed != nil && data != "" {
+		return nil, InvalidField(field, "provide either %s or %s, not both", field, jsonField)
+	}
+	if data != "" {
+		conditions, err := ParseConditionsJSON(data)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s: %w", jsonField, err)
+		}
+		return conditions, nil
+	}
+	if typed == nil {
+		return nil, nil
+	}
+	conditions := make([]Condition, len(typed))
+	for i, c := range typed {
+		compiled, err := c.compile(fmt.Sprintf("%s[%d]", field, i))
+		if err != nil {
+			return nil, err
+		}
+		conditions[i] = compiled
+	}
+	return conditions, nil
+}
+
+// conditionOps maps lowercased operators and their aliases to GTM condition types.
+var conditionOps = map[string]string{
+	"equals":           "equals",
+	"eq":               "equals",
+	"==":               "equals",
+	"contains":         "contains",
+	"startswith":       "startsWith",
+	"endswith":         "endsWith",
+	"matchregex":       "matchRegex",
+	"regex":            "matchRegex",
+	"matchcssselector": "cssSelector",
+	"cssselector":      "cssSelector",
+	"greater":          "greater",
+	">":                "greater",
+	"greaterorequals":  "greaterOrEquals",
+	">=":               "greaterOrEquals",
+	"less":             "less",
+	"<":                "less",
+	"lessorequals":     "lessOrEquals",
+	"<=":               "lessOrEquals",
+}
+
+// variableRef returns a variable reference, wrapping a bare variable name
+// in {{ }}.
+func variableRef(name string) string {
+	name = strings.TrimSpace(name)
+	if name == "" || strings.HasPrefix(name, "{{") {
+		return name
+	}
+	return "{{" + name + "}}"
+}
+
+// Compile converts the condition to the raw GTM form. A variable name without
+// braces is wrapped in {{ }}.
+func (s SimpleCondition) Compile() (Condition, error) {
+	variable := variableRef(s.Variable)
+	if variable == "" {
+		return Condition{}, fmt.Errorf("variable is required")
+	}
+
+	condType, ok := conditionOps[strings.ToLower(strings.TrimSpace(s.Op))]
+	if !ok {
+		return Condition{}, fmt.Errorf("unknown op %q (valid ops: %s)", s.Op, strings.Join(conditionOpNames(), ", "))
+	}
+
+	c := Condition{
+		Type:      condType,
+		Negate:    s.Negate,
+		Parameter: []Parameter{TemplateParam("arg0", variable), TemplateParam("arg1", s.Value)},
+	}
+	if s.IgnoreCase {
+		if condType != "matchRegex" {
+			return Condition{}, fmt.Errorf("ignoreCase is only supported with op matchRegex")
+		}
+		c.Parameter = append(c.Parameter, BooleanParam("ignore_case", true))
+	}
+	return c, nil
+}
+
+// conditionOpNames returns the canonical operator names, sorted.
+func conditionOpNames() []string {
+	seen := make(map[string]bool)
+	var names []string
+	for _, t := range conditionOps {
+		if !seen[t] {
+			seen[t] = true
+			names = append(names, t)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// isSimpleCondition reports whether a JSON condition object uses the
+// simplified {variable, op, value} form.
+func isSimpleCondition(raw json.RawMessage) bool {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(raw, &fields); err != nil {
+		return false
+	}
+	_, ok := fields["op"]
+	return ok
+}
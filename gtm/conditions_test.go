@@ -0,0 +1,109 @@
+package gtm
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestParseConditionsJSON_Simple(t *testing.T) {
+	conditions, err := ParseConditionsJSON(`[
+		{"variable": "{{Click URL}}", "op": "contains", "value": "tel:", "negate": true},
+		{"variable": "Page Path", "op": "regex", "value": "^/checkout", "ignoreCase": true},
+		{"type": "equals", "parameter": [
+			{"type": "template", "key": "arg0", "value": "{{_event}}"},
+			{"type": "template", "key": "arg1", "value": "purchase"}
+		]}
+	]`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(conditions) != 3 {
+		t.Fatalf("got %d conditions, want 3", len(conditions))
+	}
+
+	c := conditions[0]
+	if c.Type != "contains" || !c.Negate || len(c.Parameter) != 2 {
+		t.Errorf("condition[0] = %+v", c)
+	}
+	if c.Parameter[0].Key != "arg0" || c.Parameter[0].Value != "{{Click URL}}" || c.Parameter[1].Key != "arg1" || c.Parameter[1].Value != "tel:" {
+		t.Errorf("condition[0] parameters = %+v", c.Parameter)
+	}
+
+	c = conditions[1]
+	if c.Type != "matchRegex" || c.Parameter[0].Value != "{{Page Path}}" || len(c.Parameter) != 3 || c.Parameter[2].Key != "ignore_case" {
+		t.Errorf("condition[1] = %+v", c)
+	}
+
+	if conditions[2].Type != "equals" || conditions[2].Parameter[1].Value != "purchase" {
+		t.Errorf("raw condition not kept: %+v", conditions[2])
+	}
+}
+
+func TestParseConditionsJSON_SimpleErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		json string
+		want string
+	}{
+		{name: "unknown op", json: `[{"variable": "Click URL", "op": "like", "value": "x"}]`, want: "unknown op"},
+		{name: "missing variable", json: `[{"op": "equals", "value": "x"}]`, want: "variable is required"},
+		{name: "ignoreCase without regex", json: `[{"variable": "Click URL", "op": "equals", "value": "x", "ignoreCase": true}]`, want: "ignoreCase"},
+		{name: "unknown field", json: `[{"variable": "Click URL", "op": "equals", "val": "x"}]`, want: "condition[0]"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseConditionsJSON(tt.json)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("error = %v, want it to contain %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestConditionsInput(t *testing.T) {
+	conditions, err := ConditionsInput("filter", []ConditionInput{
+		{Variable: "Page Path", Op: "startsWith", Value: "/checkout"},
+		{Type: "Equals", Negate: true, Parameter: []Parameter{{Type: "TEMPLATE", Key: "arg0", Value: "{{_event}}"}, TemplateParam("arg1", "purchase")}},
+	}, "filterJson", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(conditions) != 2 || conditions[0].Type != "startsWith" || conditions[0].Parameter[0].Value != "{{Page Path}}" {
+		t.Errorf("conditions = %+v", conditions)
+	}
+	if c := conditions[1]; c.Type != "Equals" || !c.Negate || c.Parameter[0].Type != "template" {
+		t.Errorf("raw condition = %+v", c)
+	}
+
+	conditions, err = ConditionsInput("filter", nil, "filterJson", `[{"variable": "Page Path", "op": "equals", "value": "/"}]`)
+	if err != nil || len(conditions) != 1 {
+		t.Errorf("JSON conditions = %+v, err = %v", conditions, err)
+	}
+	if conditions, err := ConditionsInput("filter", nil, "filterJson", ""); err != nil || conditions != nil {
+		t.Errorf("no conditions = %+v, err = %v", conditions, err)
+	}
+
+	for _, tt := range []struct {
+		name  string
+		typed []ConditionInput
+		json  string
+		want  string
+	}{
+		{name: "both forms", typed: []ConditionInput{}, json: "[]", want: "either filter or filterJson"},
+		{name: "invalid JSON", json: "[", want: "invalid filterJson"},
+		{name: "unknown op", typed: []ConditionInput{{Variable: "Page Path", Op: "like"}}, want: "filter[0]: unknown op"},
+		{name: "mixed forms", typed: []ConditionInput{{Variable: "Page Path", Type: "equals"}}, want: "not both"},
+		{name: "raw parameter", typed: []ConditionInput{{Type: "equals", Parameter: []Parameter{{Type: "template", Value: "x"}}}}, want: "filter[0].parameter[0]: key is required"},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ConditionsInput("filter", tt.typed, "filterJson", tt.json)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("error = %v, want it to contain %q", err, tt.want)
+			}
+		})
+	}
+	if _, err := ConditionsInput("filter", []ConditionInput{{Op: "equals"}}, "filterJson", ""); !errors.Is(err, ErrInvalidRequest) {
+		t.Errorf("err = %v, want ErrInvalidRequest", err)
+	}
+}
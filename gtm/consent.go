@@ -0,0 +1,87 @@
+package gtm
+
+import (
+	"fmt"
+	"strings"
+
+	tagmanager "google.golang.org/api/tagmanager/v2"
+)
+
+// Tag consent statuses.
+const (
+	ConsentNotSet    = "notSet"
+	ConsentNotNeeded = "notNeeded"
+	ConsentNeeded    = "needed"
+)
+
+// ConsentSettings are the additional consent checks of a tag: with status
+// "needed" the tag only fires once every listed consent type is granted.
+type ConsentSettings struct {
+	ConsentStatus string   `json:"consentStatus"`
+	ConsentType   []string `json:"consentType,omitempty"`
+}
+
+// ParseConsentSettings builds consent settings from tool input. A missing
+// status defaults to "needed" when consent types are given. It returns nil
+// when neither is set.
+func ParseConsentSettings(status string, types []string) (*ConsentSettings, error) {
+	if status == "" && types == nil {
+		return nil, nil
+	}
+	if status == "" {
+		status = ConsentNeeded
+	}
+
+	switch status {
+	case ConsentNeeded:
+		if len(types) == 0 {
+			return nil, fmt.Errorf("consentStatus needed requires at least one consent type (e.g. ad_storage, analytics_storage, ad_user_data, ad_personalization)")
+		}
+	case ConsentNotSet, ConsentNotNeeded:
+		if len(types) > 0 {
+			return nil, fmt.Errorf("consent types can only be set with consentStatus needed")
+		}
+	default:
+		return nil, fmt.Errorf("invalid consentStatus '%s' (valid values: notSet, notNeeded, needed)", status)
+	}
+
+	seen := make(map[string]bool, len(types))
+	for _, t := range types {
+		if strings.TrimSpace(t) == "" {
+			return nil, fmt.Errorf("consent type cannot be empty")
+		}
+		if seen[t] {
+			return nil, fmt.Errorf("duplicate consent type %s", t)
+		}
+		seen[t] = true
+	}
+	return &ConsentSettings{ConsentStatus: status, ConsentType: types}, nil
+}
+
+func toAPIConsentSettings(c *ConsentSettings) *tagmanager.TagConsentSetting {
+	if c == nil {
+		return nil
+	}
+	setting := &tagmanager.TagConsentSetting{ConsentStatus: c.ConsentStatus}
+	if len(c.ConsentType) > 0 {
+		list := make([]*tagmanager.Parameter, len(c.ConsentType))
+		for i, t := range c.ConsentType {
+			list[i] = &tagmanager.Parameter{Type: ParamTemplate, Value: t}
+		}
+		setting.ConsentType = &tagmanager.Parameter{Type: ParamList, List: list}
+	}
+	return setting
+}
+
+func toConsentSettings(s *tagmanager.TagConsentSetting) *ConsentSettings {
+	if s == nil || s.ConsentStatus == "" {
+		return nil
+	}
+	c := &ConsentSettings{ConsentStatus: s.ConsentStatus}
+	if s.ConsentType != nil {
+		for _, p := range s.ConsentType.List {
+			c.ConsentType = append(c.ConsentType, p.Value)
+		}
+	}
+	return c
+}
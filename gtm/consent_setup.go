@@ -0,0 +1,195 @@
+package gtm
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// consentDefaultTagName is the tag setup_consent_mode writes the defaults
+// to, shared with the consent-mode blueprint.
+const consentDefaultTagName = "Consent Mode - Default"
+
+// ConsentInitTriggerID is the built-in Consent Initialization - All Pages trigger.
+const ConsentInitTriggerID = "2147479573"
+
+// maxWaitForUpdate bounds wait_for_update; longer waits delay every Google tag.
+const maxWaitForUpdate = 10000
+
+var (
+	consentRegionPattern = regexp.MustCompile(`^[A-Z]{2}(-[A-Z0-9]{1,3})?$`)
+	consentEventPattern  = regexp.MustCompile(`^[A-Za-z0-9_.-]+$`)
+)
+
+// ConsentDefaults are consent mode defaults for the listed regions, or for
+// every visitor outside them when Regions is empty. The four consent mode
+// v2 types default to denied; the other types are only set when given.
+type ConsentDefaults struct {
+	Regions                []string `json:"regions,omitempty" jsonschema:"description:ISO 3166-2 region codes (e.g. ES or US-CA). Leave empty for the default of every other visitor"`
+	AdStorage              string   `json:"adStorage,omitempty" jsonschema:"description:granted or denied (default denied)"`
+	AnalyticsStorage       string   `json:"analyticsStorage,omitempty" jsonschema:"description:granted or denied (default denied)"`
+	AdUserData             string   `json:"adUserData,omitempty" jsonschema:"description:granted or denied (default denied)"`
+	AdPersonalization      string   `json:"adPersonalization,omitempty" jsonschema:"description:granted or denied (default denied)"`
+	FunctionalityStorage   string   `json:"functionalityStorage,omitempty" jsonschema:"description:granted or denied (not set by default)"`
+	PersonalizationStorage string   `json:"personalizationStorage,omitempty" jsonschema:"description:granted or denied (not set by default)"`
+	SecurityStorage        string   `json:"securityStorage,omitempty" jsonschema:"description:granted or denied (not set by default)"`
+}
+
+// ConsentModeSetup describes the consent mode scaffolding of a workspace.
+type ConsentModeSetup struct {
+	Defaults         []ConsentDefaults
+	WaitForUpdate    int    // milliseconds Google tags wait for an update
+	AdsDataRedaction bool   // redact ad click IDs while ad_storage is denied
+	URLPassthrough   bool   // pass ad click information in URLs while storage is denied
+	UpdateEvent      string // data layer event of the consent banner; empty skips the update tag
+}
+
+// Validate checks the setup, naming the offending input field.
+func (s ConsentModeSetup) Validate() error {
+	if len(s.Defaults) == 0 {
+		return InvalidField("defaults", "at least one set of consent defaults is required")
+	}
+	global := false
+	regions := make(map[string]bool)
+	for i, d := range s.Defaults {
+		field := fmt.Sprintf("defaults[%d]", i)
+		if len(d.Regions) == 0 {
+			if global {
+				return InvalidField(field+".regions", "only one set of defaults can leave regions empty")
+			}
+			global = true
+		}
+		for _, r := range d.Regions {
+			if !consentRegionPattern.MatchString(r) {
+				return InvalidField(field+".regions", "%q is not an ISO 3166-2 region code like ES or US-CA", r)
+			}
+			if regions[r] {
+				return InvalidField(field+".regions", "region %s has defaults twice", r)
+			}
+			regions[r] = true
+		}
+		for _, c := range d.consentTypes() {
+			if c.value != "" && c.value != "granted" && c.value != "denied" {
+				return InvalidField(field+"."+c.field, "%s must be granted or denied", c.field)
+			}
+		}
+	}
+	if s.WaitForUpdate < 0 || s.WaitForUpdate > maxWaitForUpdate {
+		return InvalidField("waitForUpdate", "waitForUpdate must be between 0 and %d milliseconds", maxWaitForUpdate)
+	}
+	if s.UpdateEvent != "" && !consentEventPattern.MatchString(s.UpdateEvent) {
+		return InvalidField("updateEvent", "updateEvent may only contain letters, digits, dots, dashes and underscores")
+	}
+	return nil
+}
+
+type consentTypeValue struct {
+	field, consentType, value, fallback string
+}
+
+func (d ConsentDefaults) consentTypes() []consentTypeValue {
+	return []consentTypeValue{
+		{"adStorage", "ad_storage", d.AdStorage, "denied"},
+		{"analyticsStorage", "analytics_storage", d.AnalyticsStorage, "denied"},
+		{"adUserData", "ad_user_data", d.AdUserData, "denied"},
+		{"adPersonalization", "ad_personalization", d.AdPersonalization, "denied"},
+		{"functionalityStorage", "functionality_storage", d.FunctionalityStorage, ""},
+		{"personalizationStorage", "personalization_storage", d.PersonalizationStorage, ""},
+		{"securityStorage", "security_storage", d.SecurityStorage, ""},
+	}
+}
+
+// DefaultTagHTML renders the gtag consent commands of the default tag.
+// Region-specific defaults come first; gtag applies the most specific
+// region match regardless of order.
+func (s ConsentModeSetup) DefaultTagHTML() string {
+	var b strings.Builder
+	b.WriteString("<script>\n  window.dataLayer = window.dataLayer || [];\n  function gtag(){dataLayer.push(arguments);}\n")
+	ordered := make([]ConsentDefaults, 0, len(s.Defaults))
+	for _, d := range s.Defaults {
+		if len(d.Regions) > 0 {
+			ordered = append(ordered, d)
+		}
+	}
+	for _, d := range s.Defaults {
+		if len(d.Regions) == 0 {
+			ordered = append(ordered, d)
+		}
+	}
+	for _, d := range ordered {
+		params := make(map[string]any)
+		for _, c := range d.consentTypes() {
+			if value := c.value; value != "" {
+				params[c.consentType] = value
+			} else if c.fallback != "" {
+				params[c.consentType] = c.fallback
+			}
+		}
+		if len(d.Regions) > 0 {
+			params["region"] = d.Regions
+		}
+		if s.WaitForUpdate > 0 {
+			params["wait_for_update"] = s.WaitForUpdate
+		}
+		data, _ := json.Marshal(params)
+		fmt.Fprintf(&b, "  gtag('consent', 'default', %s);\n", data)
+	}
+	if s.AdsDataRedaction {
+		b.WriteString("  gtag('set', 'ads_data_redaction', true);\n")
+	}
+	if s.URLPassthrough {
+		b.WriteString("  gtag('set', 'url_passthrough', true);\n")
+	}
+	b.WriteString("</script>\n")
+	return b.String()
+}
+
+// Changeset returns the operations that bring the workspace to this setup:
+// the default tag on the Consent Initialization - All Pages trigger is
+// created, or updated when it exists, and with an UpdateEvent the update
+// tag, trigger and data layer variables of the consent-mode blueprint are
+// created where missing.
+func (s ConsentModeSetup) Changeset(existing *WorkspaceEntities) ([]ChangeOperation, []BlueprintExisting, error) {
+	if err := s.Validate(); err != nil {
+		return nil, nil, err
+	}
+	updateEvent := s.UpdateEvent
+	if updateEvent == "" {
+		updateEvent = "consent_update"
+	}
+	ops, reused, err := BuiltInBlueprints()["consent-mode"].Changeset(map[string]string{"UPDATE_EVENT": updateEvent}, existing)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	defaultTag := map[string]any{
+		"parameter": []any{
+			map[string]any{"type": "template", "key": "html", "value": s.DefaultTagHTML()},
+			map[string]any{"type": "boolean", "key": "supportDocumentWrite", "value": "false"},
+		},
+		"firingTriggerId": []any{ConsentInitTriggerID},
+	}
+	var result []ChangeOperation
+	for _, op := range ops {
+		if op.EntityType == "tag" && op.Entity["name"] == consentDefaultTagName {
+			for k, v := range defaultTag {
+				op.Entity[k] = v
+			}
+			result = append(result, op)
+		} else if s.UpdateEvent != "" {
+			result = append(result, op)
+		}
+	}
+	var kept []BlueprintExisting
+	for _, e := range reused {
+		if e.EntityType == "tag" && e.Name == consentDefaultTagName {
+			result = append(result, ChangeOperation{Action: ChangeUpdate, EntityType: "tag", EntityID: e.EntityID, Entity: defaultTag})
+			continue
+		}
+		if s.UpdateEvent != "" {
+			kept = append(kept, e)
+		}
+	}
+	return result, kept, nil
+}
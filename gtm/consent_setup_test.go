@@ -0,0 +1,77 @@
+package gtm
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestConsentModeSetup_DefaultTagHTML(t *testing.T) {
+	setup := ConsentModeSetup{
+		Defaults: []ConsentDefaults{
+			{AnalyticsStorage: "granted", AdStorage: "granted", AdUserData: "granted", AdPersonalization: "granted"},
+			{Regions: []string{"ES", "DE"}, SecurityStorage: "granted"},
+		},
+		WaitForUpdate:    500,
+		AdsDataRedaction: true,
+	}
+	html := setup.DefaultTagHTML()
+
+	regional := `gtag('consent', 'default', {"ad_personalization":"denied","ad_storage":"denied","ad_user_data":"denied","analytics_storage":"denied","region":["ES","DE"],"security_storage":"granted","wait_for_update":500});`
+	global := `gtag('consent', 'default', {"ad_personalization":"granted","ad_storage":"granted","ad_user_data":"granted","analytics_storage":"granted","wait_for_update":500});`
+	if !strings.Contains(html, regional) || !strings.Contains(html, global) || strings.Index(html, regional) > strings.Index(html, global) {
+		t.Errorf("html =\n%s", html)
+	}
+	if !strings.Contains(html, "'ads_data_redaction', true") || strings.Contains(html, "url_passthrough") {
+		t.Errorf("html =\n%s", html)
+	}
+}
+
+func TestConsentModeSetup_Changeset(t *testing.T) {
+	setup := ConsentModeSetup{Defaults: []ConsentDefaults{{}}}
+
+	ops, _, err := setup.Changeset(&WorkspaceEntities{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(ops) != 1 || ops[0].Action != ChangeCreate || ops[0].Entity["name"] != consentDefaultTagName {
+		t.Fatalf("ops = %+v, want only the default tag", ops)
+	}
+	if got := toStrings(ops[0].Entity["firingTriggerId"]); len(got) != 1 || got[0] != ConsentInitTriggerID {
+		t.Errorf("default tag fires on %v", got)
+	}
+
+	// Rerunning updates the existing tag; the update event adds the rest
+	setup.UpdateEvent = "cmp_update"
+	existing := &WorkspaceEntities{Tags: []Tag{{TagID: "9", Name: consentDefaultTagName, Type: "html"}}}
+	ops, _, err = setup.Changeset(existing)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var update *ChangeOperation
+	for i, op := range ops {
+		if op.Action == ChangeUpdate {
+			update = &ops[i]
+		}
+	}
+	if update == nil || update.EntityID != "9" || len(ops) != 7 {
+		t.Fatalf("ops = %+v, want 4 variables, trigger, update tag and an update of tag 9", ops)
+	}
+	if err := ValidateChangeset(ops); err != nil {
+		t.Error(err)
+	}
+
+	for name, s := range map[string]ConsentModeSetup{
+		"no defaults":    {},
+		"two globals":    {Defaults: []ConsentDefaults{{}, {}}},
+		"bad region":     {Defaults: []ConsentDefaults{{Regions: []string{"Spain"}}}},
+		"repeated":       {Defaults: []ConsentDefaults{{Regions: []string{"ES"}}, {Regions: []string{"ES"}}}},
+		"bad value":      {Defaults: []ConsentDefaults{{AdStorage: "yes"}}},
+		"long wait":      {Defaults: []ConsentDefaults{{}}, WaitForUpdate: 60000},
+		"bad event name": {Defaults: []ConsentDefaults{{}}, UpdateEvent: "consent update"},
+	} {
+		if _, _, err := s.Changeset(&WorkspaceEntities{}); !errors.Is(err, ErrInvalidRequest) {
+			t.Errorf("%s: err = %v, want ErrInvalidRequest", name, err)
+		}
+	}
+}
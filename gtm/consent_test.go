@@ -0,0 +1,59 @@
+package gtm
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestParseConsentSettings(t *testing.T) {
+	tests := []struct {
+		name       string
+		status     string
+		types      []string
+		wantStatus string
+		wantErr    bool
+	}{
+		{name: "unset", wantStatus: ""},
+		{name: "types default to needed", types: []string{"ad_storage"}, wantStatus: ConsentNeeded},
+		{name: "explicit needed", status: "needed", types: []string{"ad_storage", "ad_user_data"}, wantStatus: ConsentNeeded},
+		{name: "not needed", status: "notNeeded", wantStatus: ConsentNotNeeded},
+		{name: "needed without types", status: "needed", wantErr: true},
+		{name: "not needed with types", status: "notNeeded", types: []string{"ad_storage"}, wantErr: true},
+		{name: "invalid status", status: "required", types: []string{"ad_storage"}, wantErr: true},
+		{name: "duplicate type", types: []string{"ad_storage", "ad_storage"}, wantErr: true},
+		{name: "empty type", types: []string{" "}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseConsentSettings(tt.status, tt.types)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			status := ""
+			if got != nil {
+				status = got.ConsentStatus
+			}
+			if status != tt.wantStatus {
+				t.Errorf("status = %q, want %q", status, tt.wantStatus)
+			}
+		})
+	}
+}
+
+func TestConsentSettingsRoundTrip(t *testing.T) {
+	in := &ConsentSettings{ConsentStatus: ConsentNeeded, ConsentType: []string{"ad_storage", "analytics_storage"}}
+	api := toAPIConsentSettings(in)
+	if api.ConsentType.Type != ParamList || api.ConsentType.List[1].Value != "analytics_storage" {
+		t.Fatalf("API consent type = %+v", api.ConsentType)
+	}
+	out := toConsentSettings(api)
+	if out.ConsentStatus != in.ConsentStatus || !slices.Equal(out.ConsentType, in.ConsentType) {
+		t.Errorf("round trip = %+v, want %+v", out, in)
+	}
+	if toAPIConsentSettings(nil) != nil || toConsentSettings(nil) != nil {
+		t.Error("nil settings should stay nil")
+	}
+}
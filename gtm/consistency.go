@@ -0,0 +1,140 @@
+package gtm
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"time"
+
+	"google.golang.org/api/googleapi"
+)
+
+// The GTM API is eventually consistent: reading an entity immediately after
+// creating it occasionally returns 404. Reads in a workspace that the same
+// MCP session mutated within consistencyWindow retry a 404 a few times
+// before giving up.
+const consistencyWindow = 30 * time.Second
+
+// notFoundRetryDelays are the waits between attempts of a read that 404s
+// shortly after a mutation (about 2 seconds in total).
+var notFoundRetryDelays = []time.Duration{
+	250 * time.Millisecond,
+	500 * time.Millisecond,
+	1250 * time.Millisecond,
+}
+
+type sessionContextKey struct{}
+
+// WithSession returns a context carrying the MCP session ID.
+func WithSession(ctx context.Context, sessionID string) context.Context {
+	return context.WithValue(ctx, sessionContextKey{}, sessionID)
+}
+
+// SessionFromContext returns the MCP session ID, or "" outside a session.
+func SessionFromContext(ctx context.Context) string {
+	id, _ := ctx.Value(sessionContextKey{}).(string)
+	return id
+}
+
+type userContextKey struct{}
+
+// WithUser returns a context carrying the calling user's key, see
+// ClientOptions.User.
+func WithUser(ctx context.Context, user string) context.Context {
+	return context.WithValue(ctx, userContextKey{}, user)
+}
+
+// UserFromContext returns the calling user's key, or "" if unknown.
+func UserFromContext(ctx context.Context) string {
+	user, _ := ctx.Value(userContextKey{}).(string)
+	return user
+}
+
+// mutationTracker records when each session last changed each workspace.
+// Transports without session IDs (stdio, stateless HTTP) are told apart by
+// user, so one caller's mutation never delays another's 404s.
+type mutationTracker struct {
+	mu     sync.Mutex
+	recent map[string]time.Time // session + user + workspace path -> last mutation
+	now    func() time.Time
+}
+
+// trackingKey returns the tracker key of the workspace ws for the caller of ctx.
+func trackingKey(ctx context.Context, ws string) string {
+	return SessionFromContext(ctx) + "|" + UserFromContext(ctx) + "|" + ws
+}
+
+var RecentMutations = &mutationTracker{
+	recent: make(map[string]time.Time),
+	now:    time.Now,
+}
+
+// Mark records a mutation of the workspace containing path.
+func (t *mutationTracker) Mark(ctx context.Context, path string) {
+	ws := workspaceOf(path)
+	if ws == "" {
+		return
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	now := t.now()
+	for k, at := range t.recent {
+		if now.Sub(at) > consistencyWindow {
+			delete(t.recent, k)
+		}
+	}
+	t.recent[trackingKey(ctx, ws)] = now
+}
+
+// recentlyMutated reports whether this session and user changed the workspace containing path within the window.
+func (t *mutationTracker) recentlyMutated(ctx context.Context, path string) bool {
+	ws := workspaceOf(path)
+	if ws == "" {
+		return false
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	at, ok := t.recent[trackingKey(ctx, ws)]
+	return ok && t.now().Sub(at) <= consistencyWindow
+}
+
+// workspaceOf returns the workspace path prefix of an entity or workspace path.
+func workspaceOf(path string) string {
+	parts := strings.Split(path, "/")
+	if len(parts) < 6 || parts[0] != "accounts" || parts[2] != "containers" || parts[4] != "workspaces" {
+		return ""
+	}
+	return strings.Join(parts[:6], "/")
+}
+
+// readAfterMutation performs a workspace read with the usual rate-limit
+// backoff. If the read returns 404 and this session recently mutated the
+// workspace, it is retried with short delays to ride out the consistency window.
+func readAfterMutation[T any](ctx context.Context, path string, fn func() (T, error)) (T, error) {
+	result, err := RetryWithBackoff(ctx, fn)
+	if !isNotFound(err) || !RecentMutations.recentlyMutated(ctx, path) {
+		return result, err
+	}
+
+	for _, delay := range notFoundRetryDelays {
+		select {
+		case <-time.After(delay):
+		case <-ctx.Done():
+			return result, ctx.Err()
+		}
+		result, err = RetryWithBackoff(ctx, fn)
+		if !isNotFound(err) {
+			return result, err
+		}
+	}
+	return result, err
+}
+
+// isNotFound reports whether err is a Google API 404.
+func isNotFound(err error) bool {
+	var apiErr *googleapi.Error
+	return errors.As(err, &apiErr) && apiErr.Code == 404
+}
@@ -0,0 +1,110 @@
+package gtm
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"google.golang.org/api/googleapi"
+)
+
+func TestWorkspaceOf(t *testing.T) {
+	tests := []struct {
+		path string
+		want string
+	}{
+		{"accounts/1/containers/2/workspaces/3/tags/4", "accounts/1/containers/2/workspaces/3"},
+		{"accounts/1/containers/2/workspaces/3", "accounts/1/containers/2/workspaces/3"},
+		{"accounts/1/containers/2", ""},
+		{"accounts/1/containers/2/versions/3", ""},
+	}
+
+	for _, tt := range tests {
+		if got := workspaceOf(tt.path); got != tt.want {
+			t.Errorf("workspaceOf(%q) = %q, want %q", tt.path, got, tt.want)
+		}
+	}
+}
+
+func TestMutationTracker(t *testing.T) {
+	now := time.Now()
+	tracker := &mutationTracker{recent: make(map[string]time.Time), now: func() time.Time { return now }}
+
+	ctxA := WithSession(context.Background(), "session-a")
+	ctxB := WithSession(context.Background(), "session-b")
+	tag := "accounts/1/containers/2/workspaces/3/tags/4"
+
+	tracker.Mark(ctxA, "accounts/1/containers/2/workspaces/3/tags/9")
+
+	if !tracker.recentlyMutated(ctxA, tag) {
+		t.Error("expected workspace to be recently mutated in the same session")
+	}
+	if tracker.recentlyMutated(ctxB, tag) {
+		t.Error("expected other sessions to be unaffected")
+	}
+	if tracker.recentlyMutated(ctxA, "accounts/1/containers/2/workspaces/5/tags/4") {
+		t.Error("expected other workspaces to be unaffected")
+	}
+
+	// Without session IDs, callers are told apart by user
+	userA := WithUser(context.Background(), "client:a")
+	userB := WithUser(context.Background(), "client:b")
+	tracker.Mark(userA, tag)
+	if !tracker.recentlyMutated(userA, tag) {
+		t.Error("expected workspace to be recently mutated for the same sessionless user")
+	}
+	if tracker.recentlyMutated(userB, tag) || tracker.recentlyMutated(context.Background(), tag) {
+		t.Error("expected other sessionless callers to be unaffected")
+	}
+
+	now = now.Add(consistencyWindow + time.Second)
+	if tracker.recentlyMutated(ctxA, tag) {
+		t.Error("expected mutation to expire after the consistency window")
+	}
+}
+
+func TestReadAfterMutation(t *testing.T) {
+	saved := notFoundRetryDelays
+	notFoundRetryDelays = []time.Duration{time.Millisecond, time.Millisecond, time.Millisecond}
+	defer func() { notFoundRetryDelays = saved }()
+
+	path := "accounts/1/containers/2/workspaces/3/tags/4"
+	notFound := &googleapi.Error{Code: 404, Message: "not found"}
+
+	tests := []struct {
+		name      string
+		mutated   bool
+		failures  int
+		wantErr   bool
+		wantCalls int
+	}{
+		{name: "no mutation returns 404 at once", mutated: false, failures: 1, wantErr: true, wantCalls: 1},
+		{name: "recent mutation retries until found", mutated: true, failures: 2, wantErr: false, wantCalls: 3},
+		{name: "retries are bounded", mutated: true, failures: 10, wantErr: true, wantCalls: 4},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := WithSession(context.Background(), tt.name)
+			if tt.mutated {
+				RecentMutations.Mark(ctx, path)
+			}
+
+			calls := 0
+			_, err := readAfterMutation(ctx, path, func() (string, error) {
+				calls++
+				if calls <= tt.failures {
+					return "", notFound
+				}
+				return "tag", nil
+			})
+
+			if (err != nil) != tt.wantErr {
+				t.Errorf("error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if calls != tt.wantCalls {
+				t.Errorf("calls = %d, want %d", calls, tt.wantCalls)
+			}
+		})
+	}
+}
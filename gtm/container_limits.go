@@ -0,0 +1,177 @@
+package gtm
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+
+	tagmanager "google.golang.org/api/tagmanager/v2"
+)
+
+const (
+	// maxStandardWorkspaces is the workspace limit of a standard Tag Manager
+	// container, the Default Workspace included. 360 containers have none.
+	maxStandardWorkspaces = 3
+	// maxContainerBytes is the size limit of a container.
+	maxContainerBytes = 200 * 1024
+	// limitWarningPercent is the usage from which a limit is approaching.
+	limitWarningPercent = 80
+	// largestEntitiesShown is the number of biggest entities listed with
+	// the size estimate.
+	largestEntitiesShown = 5
+)
+
+// Container limit statuses.
+const (
+	LimitOK          = "ok"
+	LimitApproaching = "approaching"
+	LimitReached     = "reached"
+	LimitNone        = "no_limit" // counted for context; GTM sets no limit
+)
+
+// ContainerLimit is the usage of one container resource.
+type ContainerLimit struct {
+	Resource string `json:"resource"`
+	Count    int    `json:"count"`
+	Limit    int    `json:"limit,omitempty"`
+	Percent  int    `json:"percent,omitempty"`
+	Status   string `json:"status"`
+	Note     string `json:"note,omitempty"`
+}
+
+// EntitySize is the estimated size of one entity of a version.
+type EntitySize struct {
+	EntityType string `json:"entityType"`
+	Name       string `json:"name"`
+	Bytes      int    `json:"bytes"`
+}
+
+// ContainerLimitsReport compares a container's usage with the Tag Manager
+// limits.
+type ContainerLimitsReport struct {
+	// VersionID is the version the size and entity counts come from.
+	VersionID string           `json:"versionId,omitempty"`
+	Limits    []ContainerLimit `json:"limits"`
+	// Largest are the entities contributing most to the size estimate.
+	Largest []EntitySize `json:"largest,omitempty"`
+}
+
+// newContainerLimit computes the status of count against limit. A zero
+// limit means GTM sets none.
+func newContainerLimit(resource string, count, limit int) ContainerLimit {
+	l := ContainerLimit{Resource: resource, Count: count, Limit: limit, Status: LimitNone}
+	if limit <= 0 {
+		return l
+	}
+	l.Percent = count * 100 / limit
+	switch {
+	case count >= limit:
+		l.Status = LimitReached
+	case l.Percent >= limitWarningPercent:
+		l.Status = LimitApproaching
+	default:
+		l.Status = LimitOK
+	}
+	return l
+}
+
+// versionEntitySizes returns the JSON size of every entity of a version
+// that ends up in the compiled container, largest first.
+func versionEntitySizes(v *tagmanager.ContainerVersion) []EntitySize {
+	var sizes []EntitySize
+	add := func(entityType, name string, entity any) {
+		data, err := json.Marshal(entity)
+		if err != nil {
+			return
+		}
+		sizes = append(sizes, EntitySize{EntityType: entityType, Name: name, Bytes: len(data)})
+	}
+	for _, t := range v.Tag {
+		add(EntityTypeTag, t.Name, t)
+	}
+	for _, t := range v.Trigger {
+		add(EntityTypeTrigger, t.Name, t)
+	}
+	for _, e := range v.Variable {
+		add(EntityTypeVariable, e.Name, e)
+	}
+	for _, t := range v.CustomTemplate {
+		add("template", t.Name, t)
+	}
+	for _, c := range v.Client {
+		add("client", c.Name, c)
+	}
+	for _, t := range v.Transformation {
+		add("transformation", t.Name, t)
+	}
+	sort.SliceStable(sizes, func(i, j int) bool { return sizes[i].Bytes > sizes[j].Bytes })
+	return sizes
+}
+
+// latestVersionID returns the ID of the newest version that is not deleted,
+// or "" when the container has none.
+func latestVersionID(versions []VersionInfo) string {
+	latest, latestN := "", -1
+	for _, v := range versions {
+		n, err := strconv.Atoi(v.VersionID)
+		if err != nil || v.Deleted || n <= latestN {
+			continue
+		}
+		latest, latestN = v.VersionID, n
+	}
+	return latest
+}
+
+// GetContainerLimits reports the workspace count against the workspace
+// limit, and the size and entity counts of a version against the container
+// size limit. versionID defaults to the latest version. With tagManager360
+// set the workspace limit is lifted.
+func (c *Client) GetContainerLimits(ctx context.Context, accountID, containerID, versionID string, tagManager360 bool) (*ContainerLimitsReport, error) {
+	workspaces, err := c.ListWorkspaces(ctx, accountID, containerID)
+	if err != nil {
+		return nil, err
+	}
+	workspaceLimit := maxStandardWorkspaces
+	if tagManager360 {
+		workspaceLimit = 0
+	}
+	report := &ContainerLimitsReport{Limits: []ContainerLimit{newContainerLimit("workspaces", len(workspaces), workspaceLimit)}}
+	if report.Limits[0].Status == LimitReached {
+		report.Limits[0].Note = "No workspace can be created until one is versioned or deleted."
+	}
+
+	if versionID == "" {
+		versions, err := c.ListVersions(ctx, accountID, containerID)
+		if err != nil {
+			return nil, err
+		}
+		if versionID = latestVersionID(versions); versionID == "" {
+			return report, nil
+		}
+	}
+	version, err := c.GetVersion(ctx, accountID, containerID, versionID)
+	if err != nil {
+		return nil, err
+	}
+	report.VersionID = version.ContainerVersionId
+
+	sizes := versionEntitySizes(version)
+	total := 0
+	for _, s := range sizes {
+		total += s.Bytes
+	}
+	size := newContainerLimit("containerSizeBytes", total, maxContainerBytes)
+	size.Note = fmt.Sprintf("Estimated from the JSON size of version %s; the compiled container differs but grows with it. Tag Manager warns in the UI from 70%%.", report.VersionID)
+	report.Limits = append(report.Limits, size)
+	report.Largest = sizes[:min(len(sizes), largestEntitiesShown)]
+
+	report.Limits = append(report.Limits,
+		newContainerLimit("customTemplates", len(version.CustomTemplate), 0),
+		newContainerLimit("tags", len(version.Tag), 0),
+		newContainerLimit("triggers", len(version.Trigger), 0),
+		newContainerLimit("variables", len(version.Variable), 0),
+	)
+	return report, nil
+}
@@ -0,0 +1,88 @@
+package gtm
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestGetContainerLimits(t *testing.T) {
+	var fetched string
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/workspaces"):
+			json.NewEncoder(w).Encode(map[string]any{"workspace": []map[string]any{
+				{"workspaceId": "1"}, {"workspaceId": "2"}, {"workspaceId": "3"},
+			}})
+		case strings.HasSuffix(r.URL.Path, "/version_headers"):
+			json.NewEncoder(w).Encode(map[string]any{"containerVersionHeader": []map[string]any{
+				{"containerVersionId": "2"}, {"containerVersionId": "10"}, {"containerVersionId": "11", "deleted": true},
+			}})
+		case strings.Contains(r.URL.Path, "/versions/"):
+			fetched = r.URL.Path[strings.LastIndex(r.URL.Path, "/")+1:]
+			json.NewEncoder(w).Encode(map[string]any{
+				"containerVersionId": fetched,
+				"tag": []map[string]any{
+					{"name": "Big HTML", "type": "html", "parameter": []map[string]any{{"type": "template", "key": "html", "value": strings.Repeat("x", 170*1024)}}},
+					{"name": "Small", "type": "img"},
+				},
+				"variable": []map[string]any{{"name": "Page", "type": "u"}},
+			})
+		default:
+			http.NotFound(w, r)
+		}
+	})
+	ctx := context.Background()
+
+	report, err := client.GetContainerLimits(ctx, "1", "2", "", false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if fetched != "10" || report.VersionID != "10" {
+		t.Errorf("fetched version %q, report version %q, want 10", fetched, report.VersionID)
+	}
+	limits := map[string]ContainerLimit{}
+	for _, l := range report.Limits {
+		limits[l.Resource] = l
+	}
+	if l := limits["workspaces"]; l.Count != 3 || l.Status != LimitReached {
+		t.Errorf("workspaces = %+v", l)
+	}
+	if l := limits["containerSizeBytes"]; l.Status != LimitApproaching || l.Percent < 80 {
+		t.Errorf("size = %+v", l)
+	}
+	if l := limits["tags"]; l.Count != 2 || l.Status != LimitNone {
+		t.Errorf("tags = %+v", l)
+	}
+	if len(report.Largest) != 3 || report.Largest[0].Name != "Big HTML" {
+		t.Errorf("largest = %+v", report.Largest)
+	}
+
+	report, err = client.GetContainerLimits(ctx, "1", "2", "2", true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if fetched != "2" || report.Limits[0].Status != LimitNone {
+		t.Errorf("fetched %q, workspaces = %+v", fetched, report.Limits[0])
+	}
+}
+
+func TestNewContainerLimit(t *testing.T) {
+	for _, tt := range []struct {
+		count, limit int
+		want         string
+	}{
+		{1, 3, LimitOK},
+		{3, 3, LimitReached},
+		{4, 3, LimitReached},
+		{80, 100, LimitApproaching},
+		{79, 100, LimitOK},
+		{5, 0, LimitNone},
+	} {
+		if got := newContainerLimit("x", tt.count, tt.limit).Status; got != tt.want {
+			t.Errorf("newContainerLimit(%d, %d) = %s, want %s", tt.count, tt.limit, got, tt.want)
+		}
+	}
+}
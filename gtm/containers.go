@@ -13,6 +13,7 @@ type Container struct {
 	Name         string   `json:"name"`
 	PublicID     string   `json:"publicId"`
 	UsageContext []string `json:"usageContext"`
+	TagIDs       []string `json:"tagIds,omitempty"`
 	Path         string   `json:"path"`
 }
 
@@ -20,11 +21,11 @@ type Container struct {
 func (c *Client) ListContainers(ctx context.Context, accountID string) ([]Container, error) {
 	parent := fmt.Sprintf("accounts/%s", accountID)
 
-	resp, err := retryWithBackoff(ctx, 3, func() (*tagmanager.ListContainersResponse, error) {
+	resp, err := RetryWithBackoff(ctx, func() (*tagmanager.ListContainersResponse, error) {
 		return c.Service.Accounts.Containers.List(parent).Context(ctx).Do()
 	})
 	if err != nil {
-		return nil, mapGoogleError(err)
+		return nil, MapGoogleError(err)
 	}
 
 	return toContainers(resp.Container), nil
@@ -33,17 +34,85 @@ func (c *Client) ListContainers(ctx context.Context, accountID string) ([]Contai
 func toContainers(containers []*tagmanager.Container) []Container {
 	result := make([]Container, 0, len(containers))
 	for _, c := range containers {
-		result = append(result, Container{
-			ContainerID:  c.ContainerId,
-			Name:         c.Name,
-			PublicID:     c.PublicId,
-			UsageContext: c.UsageContext,
-			Path:         c.Path,
-		})
+		result = append(result, toContainer(c))
 	}
 	return result
 }
 
+func toContainer(c *tagmanager.Container) Container {
+	return Container{
+		ContainerID:  c.ContainerId,
+		Name:         c.Name,
+		PublicID:     c.PublicId,
+		UsageContext: c.UsageContext,
+		TagIDs:       c.TagIds,
+		Path:         c.Path,
+	}
+}
+
+// GetContainer returns a single container by path.
+func (c *Client) GetContainer(ctx context.Context, path string) (*Container, error) {
+	resp, err := RetryWithBackoff(ctx, func() (*tagmanager.Container, error) {
+		return c.Service.Accounts.Containers.Get(path).Context(ctx).Do()
+	})
+	if err != nil {
+		return nil, MapGoogleError(err)
+	}
+
+	container := toContainer(resp)
+	return &container, nil
+}
+
+// MoveTagIDOptions controls how a tag ID is moved out of a container.
+type MoveTagIDOptions struct {
+	TagID                            string
+	TagName                          string
+	CopySettings                     bool
+	CopyUsers                        bool
+	CopyTermsOfService               bool
+	AllowUserPermissionFeatureUpdate bool
+}
+
+// MoveTagID moves a Google tag ID out of a container into a newly created Google tag.
+func (c *Client) MoveTagID(ctx context.Context, path string, opts MoveTagIDOptions) (*Container, error) {
+	call := c.Service.Accounts.Containers.MoveTagId(path).
+		TagId(opts.TagID).
+		CopySettings(opts.CopySettings).
+		CopyUsers(opts.CopyUsers).
+		CopyTermsOfService(opts.CopyTermsOfService).
+		AllowUserPermissionFeatureUpdate(opts.AllowUserPermissionFeatureUpdate)
+	if opts.TagName != "" {
+		call = call.TagName(opts.TagName)
+	}
+
+	resp, err := call.Context(ctx).Do()
+	if err != nil {
+		return nil, MapGoogleError(err)
+	}
+
+	container := toContainer(resp)
+	return &container, nil
+}
+
+// CombineContainers merges otherContainerID into the container at path.
+// settingSource is "current" or "other" and selects whose config settings are kept.
+func (c *Client) CombineContainers(ctx context.Context, path, otherContainerID, settingSource string, allowUserPermissionFeatureUpdate bool) (*Container, error) {
+	call := c.Service.Accounts.Containers.Combine(path).
+		ContainerId(otherContainerID).
+		AllowUserPermissionFeatureUpdate(allowUserPermissionFeatureUpdate)
+	if settingSource != "" {
+		call = call.SettingSource(settingSource)
+	}
+
+	resp, err := call.Context(ctx).Do()
+	if err != nil {
+		return nil, MapGoogleError(err)
+	}
+
+	container := toContainer(resp)
+	return &container, nil
+}
+
 // DeleteContainer deletes a container by path.
 func (c *Client) DeleteContainer(ctx context.Context, path string) error {
 	return c.Service.Accounts.Containers.Delete(path).Context(ctx).Do()
@@ -0,0 +1,57 @@
+package gtm
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"testing"
+)
+
+func TestMoveTagID(t *testing.T) {
+	var got *http.Request
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		got = r
+		io.WriteString(w, `{"path":"accounts/1/containers/2","containerId":"2","publicId":"GTM-AAA","tagIds":["AW-2"]}`)
+	})
+
+	container, err := client.MoveTagID(context.Background(), "accounts/1/containers/2", MoveTagIDOptions{TagID: "G-1", CopyUsers: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(container.TagIDs) != 1 || container.TagIDs[0] != "AW-2" {
+		t.Errorf("TagIDs = %v, want [AW-2]", container.TagIDs)
+	}
+	if got.Method != http.MethodPost || got.URL.Path != "/tagmanager/v2/accounts/1/containers/2:move_tag_id" {
+		t.Errorf("request = %s %s", got.Method, got.URL.Path)
+	}
+	q := got.URL.Query()
+	if q.Get("tagId") != "G-1" || q.Get("copyUsers") != "true" || q.Get("copySettings") != "false" {
+		t.Errorf("query = %v", q)
+	}
+	if q.Has("tagName") {
+		t.Errorf("empty tagName was sent: %v", q)
+	}
+}
+
+func TestCombineContainers(t *testing.T) {
+	var got *http.Request
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		got = r
+		io.WriteString(w, `{"path":"accounts/1/containers/2","containerId":"2","publicId":"GTM-AAA","tagIds":["G-1","G-3"]}`)
+	})
+
+	container, err := client.CombineContainers(context.Background(), "accounts/1/containers/2", "3", "other", true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(container.TagIDs) != 2 {
+		t.Errorf("TagIDs = %v, want both containers' tag IDs", container.TagIDs)
+	}
+	if got.Method != http.MethodPost || got.URL.Path != "/tagmanager/v2/accounts/1/containers/2:combine" {
+		t.Errorf("request = %s %s", got.Method, got.URL.Path)
+	}
+	q := got.URL.Query()
+	if q.Get("containerId") != "3" || q.Get("settingSource") != "other" || q.Get("allowUserPermissionFeatureUpdate") != "true" {
+		t.Errorf("query = %v", q)
+	}
+}
@@ -0,0 +1,291 @@
+package gtm
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+
+	tagmanager "google.golang.org/api/tagmanager/v2"
+)
+
+// EntitySelection names entities by ID or name.
+type EntitySelection struct {
+	Tags      []string
+	Triggers  []string
+	Variables []string
+}
+
+// Empty reports whether nothing is selected.
+func (s EntitySelection) Empty() bool {
+	return len(s.Tags) == 0 && len(s.Triggers) == 0 && len(s.Variables) == 0
+}
+
+// variableRefRe matches {{Variable Name}} references.
+var variableRefRe = regexp.MustCompile(`\{\{([^{}]+)\}\}`)
+
+// variableRefs returns the names of variables referenced by an entity.
+func variableRefs(entity any) []string {
+	data, err := json.Marshal(entity)
+	if err != nil {
+		return nil
+	}
+	// Work on the decoded JSON text so escaped characters in names match.
+	var text strings.Builder
+	var walk func(v any)
+	walk = func(v any) {
+		switch t := v.(type) {
+		case string:
+			text.WriteString(t)
+			text.WriteByte('\n')
+		case []any:
+			for _, e := range t {
+				walk(e)
+			}
+		case map[string]any:
+			for _, e := range t {
+				walk(e)
+			}
+		}
+	}
+	var decoded any
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return nil
+	}
+	walk(decoded)
+
+	var names []string
+	for _, m := range variableRefRe.FindAllStringSubmatch(text.String(), -1) {
+		names = append(names, m[1])
+	}
+	return names
+}
+
+// copySelection collects selected entities and their dependencies from a source version.
+type copySelection struct {
+	src *tagmanager.ContainerVersion
+
+	tags      map[string]bool // by ID
+	triggers  map[string]bool
+	variables map[string]bool
+	templates map[string]bool
+	builtIns  map[string]bool // by type
+	reuse     map[string]bool
+}
+
+// SelectForCopy builds a partial version containing the selected entities and
+// everything they depend on: triggers, referenced variables, built-in variables,
+// setup/teardown tags and custom templates. Dependencies are returned as reuse
+// keys so that existing target entities with the same name are used as-is.
+func SelectForCopy(src *tagmanager.ContainerVersion, sel EntitySelection) (*tagmanager.ContainerVersion, map[string]bool, error) {
+	cs := &copySelection{
+		src:       src,
+		tags:      make(map[string]bool),
+		triggers:  make(map[string]bool),
+		variables: make(map[string]bool),
+		templates: make(map[string]bool),
+		builtIns:  make(map[string]bool),
+		reuse:     make(map[string]bool),
+	}
+
+	for _, ref := range sel.Tags {
+		tag := findTag(src.Tag, ref)
+		if tag == nil {
+			return nil, nil, fmt.Errorf("%w: tag %q not found in source workspace", ErrNotFound, ref)
+		}
+		cs.addTag(tag, false)
+	}
+	for _, ref := range sel.Triggers {
+		trigger := findTrigger(src.Trigger, ref)
+		if trigger == nil {
+			return nil, nil, fmt.Errorf("%w: trigger %q not found in source workspace", ErrNotFound, ref)
+		}
+		cs.addTrigger(trigger, false)
+	}
+	for _, ref := range sel.Variables {
+		variable := findVariable(src.Variable, ref)
+		if variable == nil {
+			return nil, nil, fmt.Errorf("%w: variable %q not found in source workspace", ErrNotFound, ref)
+		}
+		cs.addVariable(variable, false)
+	}
+
+	out := &tagmanager.ContainerVersion{
+		AccountId:   src.AccountId,
+		ContainerId: src.ContainerId,
+	}
+	// Folders are not copied, so entities land at the top level of the target.
+	for _, t := range src.Tag {
+		if cs.tags[t.TagId] {
+			t.ParentFolderId = ""
+			out.Tag = append(out.Tag, t)
+		}
+	}
+	for _, t := range src.Trigger {
+		if cs.triggers[t.TriggerId] {
+			t.ParentFolderId = ""
+			out.Trigger = append(out.Trigger, t)
+		}
+	}
+	for _, v := range src.Variable {
+		if cs.variables[v.VariableId] {
+			v.ParentFolderId = ""
+			out.Variable = append(out.Variable, v)
+		}
+	}
+	for _, t := range src.CustomTemplate {
+		if cs.templates[t.TemplateId] {
+			out.CustomTemplate = append(out.CustomTemplate, t)
+		}
+	}
+	for _, b := range src.BuiltInVariable {
+		if cs.builtIns[b.Type] {
+			out.BuiltInVariable = append(out.BuiltInVariable, b)
+		}
+	}
+	return out, cs.reuse, nil
+}
+
+func (cs *copySelection) addTag(tag *tagmanager.Tag, dependency bool) {
+	if cs.tags[tag.TagId] {
+		if !dependency {
+			delete(cs.reuse, EntityKey("tag", tag.TagId))
+		}
+		return
+	}
+	cs.tags[tag.TagId] = true
+	if dependency {
+		cs.reuse[EntityKey("tag", tag.TagId)] = true
+	}
+
+	for _, id := range append(append([]string{}, tag.FiringTriggerId...), tag.BlockingTriggerId...) {
+		if trigger := findTrigger(cs.src.Trigger, id); trigger != nil {
+			cs.addTrigger(trigger, true)
+		}
+	}
+	for _, s := range tag.SetupTag {
+		if dep := findTag(cs.src.Tag, s.TagName); dep != nil {
+			cs.addTag(dep, true)
+		}
+	}
+	for _, t := range tag.TeardownTag {
+		if dep := findTag(cs.src.Tag, t.TagName); dep != nil {
+			cs.addTag(dep, true)
+		}
+	}
+	cs.addTemplate(tag.Type)
+	cs.addRefs(tag)
+}
+
+func (cs *copySelection) addTrigger(trigger *tagmanager.Trigger, dependency bool) {
+	if cs.triggers[trigger.TriggerId] {
+		if !dependency {
+			delete(cs.reuse, EntityKey("trigger", trigger.TriggerId))
+		}
+		return
+	}
+	cs.triggers[trigger.TriggerId] = true
+	if dependency {
+		cs.reuse[EntityKey("trigger", trigger.TriggerId)] = true
+	}
+
+	if trigger.Type == "triggerGroup" {
+		for _, p := range trigger.Parameter {
+			if p.Key != "triggerIds" {
+				continue
+			}
+			for _, member := range p.List {
+				if dep := findTrigger(cs.src.Trigger, member.Value); dep != nil {
+					cs.addTrigger(dep, true)
+				}
+			}
+		}
+	}
+	cs.addRefs(trigger)
+}
+
+func (cs *copySelection) addVariable(variable *tagmanager.Variable, dependency bool) {
+	if cs.variables[variable.VariableId] {
+		if !dependency {
+			delete(cs.reuse, EntityKey("variable", variable.VariableId))
+		}
+		return
+	}
+	cs.variables[variable.VariableId] = true
+	if dependency {
+		cs.reuse[EntityKey("variable", variable.VariableId)] = true
+	}
+
+	cs.addTemplate(variable.Type)
+	cs.addRefs(variable)
+}
+
+// addTemplate includes the custom template used by a tag or variable type.
+func (cs *copySelection) addTemplate(entityType string) {
+	for _, t := range cs.src.CustomTemplate {
+		if TemplateType(cs.src.ContainerId, t.TemplateId) == entityType && !cs.templates[t.TemplateId] {
+			cs.templates[t.TemplateId] = true
+			cs.reuse[EntityKey("template", t.TemplateId)] = true
+		}
+	}
+}
+
+// addRefs includes the variables and built-in variables an entity references.
+func (cs *copySelection) addRefs(entity any) {
+	for _, name := range variableRefs(entity) {
+		if v := findVariable(cs.src.Variable, name); v != nil {
+			cs.addVariable(v, true)
+			continue
+		}
+		for _, b := range cs.src.BuiltInVariable {
+			if b.Name == name {
+				cs.builtIns[b.Type] = true
+			}
+		}
+	}
+}
+
+// findTag returns the tag with the given ID or name.
+func findTag(tags []*tagmanager.Tag, ref string) *tagmanager.Tag {
+	for _, t := range tags {
+		if t.TagId == ref {
+			return t
+		}
+	}
+	for _, t := range tags {
+		if t.Name == ref {
+			return t
+		}
+	}
+	return nil
+}
+
+// findTrigger returns the trigger with the given ID or name.
+func findTrigger(triggers []*tagmanager.Trigger, ref string) *tagmanager.Trigger {
+	for _, t := range triggers {
+		if t.TriggerId == ref {
+			return t
+		}
+	}
+	for _, t := range triggers {
+		if t.Name == ref {
+			return t
+		}
+	}
+	return nil
+}
+
+// findVariable returns the variable with the given ID or name.
+func findVariable(variables []*tagmanager.Variable, ref string) *tagmanager.Variable {
+	for _, v := range variables {
+		if v.VariableId == ref {
+			return v
+		}
+	}
+	for _, v := range variables {
+		if v.Name == ref {
+			return v
+		}
+	}
+	return nil
+}
@@ -0,0 +1,102 @@
+package gtm
+
+import (
+	"sort"
+	"testing"
+
+	tagmanager "google.golang.org/api/tagmanager/v2"
+)
+
+func testCopySource() *tagmanager.ContainerVersion {
+	return &tagmanager.ContainerVersion{
+		ContainerId: "100",
+		Tag: []*tagmanager.Tag{
+			{TagId: "1", Name: "GA4 Purchase", Type: "gaawe", FiringTriggerId: []string{"10"}, ParentFolderId: "7",
+				Parameter: []*tagmanager.Parameter{{Type: "template", Key: "value", Value: "{{DLV - value}}"}}},
+			{TagId: "2", Name: "Unrelated", Type: "html"},
+			{TagId: "3", Name: "Custom", Type: "cvt_100_5", FiringTriggerId: []string{"2147479553"}},
+		},
+		Trigger: []*tagmanager.Trigger{
+			{TriggerId: "10", Name: "Purchase", Type: "customEvent",
+				Filter: []*tagmanager.Condition{{Type: "equals", Parameter: []*tagmanager.Parameter{{Key: "arg0", Value: "{{Page Path}}"}}}}},
+			{TriggerId: "11", Name: "Other", Type: "pageview"},
+		},
+		Variable: []*tagmanager.Variable{
+			{VariableId: "20", Name: "DLV - value", Type: "jsm", Parameter: []*tagmanager.Parameter{{Key: "javascript", Value: "function(){return {{DLV - raw}};}"}}},
+			{VariableId: "21", Name: "DLV - raw", Type: "v"},
+			{VariableId: "22", Name: "Unused", Type: "v"},
+		},
+		BuiltInVariable: []*tagmanager.BuiltInVariable{{Name: "Page Path", Type: "pagePath"}},
+		CustomTemplate:  []*tagmanager.CustomTemplate{{TemplateId: "5", Name: "My Template"}},
+	}
+}
+
+func TestSelectForCopy_Dependencies(t *testing.T) {
+	out, reuse, err := SelectForCopy(testCopySource(), EntitySelection{Tags: []string{"GA4 Purchase"}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(out.Tag) != 1 || out.Tag[0].TagId != "1" {
+		t.Fatalf("expected only the selected tag, got %+v", out.Tag)
+	}
+	if out.Tag[0].ParentFolderId != "" {
+		t.Error("expected folder to be cleared")
+	}
+	if len(out.Trigger) != 1 || out.Trigger[0].TriggerId != "10" {
+		t.Errorf("expected firing trigger dependency, got %+v", out.Trigger)
+	}
+
+	var vars []string
+	for _, v := range out.Variable {
+		vars = append(vars, v.Name)
+	}
+	sort.Strings(vars)
+	if len(vars) != 2 || vars[0] != "DLV - raw" || vars[1] != "DLV - value" {
+		t.Errorf("expected transitive variable dependencies, got %v", vars)
+	}
+	if len(out.BuiltInVariable) != 1 || out.BuiltInVariable[0].Type != "pagePath" {
+		t.Errorf("expected built-in variable dependency, got %+v", out.BuiltInVariable)
+	}
+
+	if reuse[EntityKey("tag", "1")] {
+		t.Error("selected tag must not be marked for reuse")
+	}
+	for _, key := range []string{"trigger:10", "variable:20", "variable:21"} {
+		if !reuse[key] {
+			t.Errorf("expected dependency %s to be marked for reuse", key)
+		}
+	}
+}
+
+func TestSelectForCopy_CustomTemplate(t *testing.T) {
+	out, reuse, err := SelectForCopy(testCopySource(), EntitySelection{Tags: []string{"3"}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(out.CustomTemplate) != 1 || !reuse["template:5"] {
+		t.Errorf("expected custom template dependency, got %+v", out.CustomTemplate)
+	}
+	if len(out.Trigger) != 0 {
+		t.Errorf("built-in triggers must not be copied, got %+v", out.Trigger)
+	}
+}
+
+func TestSelectForCopy_ExplicitDependency(t *testing.T) {
+	_, reuse, err := SelectForCopy(testCopySource(), EntitySelection{
+		Tags:     []string{"1"},
+		Triggers: []string{"Purchase"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if reuse["trigger:10"] {
+		t.Error("explicitly selected trigger must not be marked for reuse")
+	}
+}
+
+func TestSelectForCopy_NotFound(t *testing.T) {
+	if _, _, err := SelectForCopy(testCopySource(), EntitySelection{Variables: []string{"missing"}}); err == nil {
+		t.Error("expected error for unknown variable")
+	}
+}
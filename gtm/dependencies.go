@@ -0,0 +1,173 @@
+package gtm
+
+import (
+	"fmt"
+
+	tagmanager "google.golang.org/api/tagmanager/v2"
+)
+
+// BuiltInTriggerNames names the built-in triggers tags can fire on. They are
+// not returned by the triggers API, so they are not graph nodes.
+var BuiltInTriggerNames = map[string]string{
+	"2147479553": "All Pages",
+	"2147479572": "Initialization - All Pages",
+	"2147479573": "Consent Initialization - All Pages",
+}
+
+// DependencyRef is an entity related to the one being inspected.
+type DependencyRef struct {
+	GraphNode
+	Relation string `json:"relation"`
+}
+
+// EntityDependencies lists what an entity references and what references it.
+type EntityDependencies struct {
+	Entity     GraphNode       `json:"entity"`
+	DependsOn  []DependencyRef `json:"dependsOn"`
+	UsedBy     []DependencyRef `json:"usedBy"`
+	Unresolved []string        `json:"unresolved,omitempty"`
+}
+
+// FindEntity returns the ID of the tag, trigger or variable in v with the
+// given ID or name.
+func FindEntity(v *tagmanager.ContainerVersion, kind, id, name string) (string, error) {
+	var entities []NamedEntity
+	switch kind {
+	case EntityTypeTag:
+		for _, t := range v.Tag {
+			entities = append(entities, NamedEntity{t.TagId, t.Name})
+		}
+	case EntityTypeTrigger:
+		for _, t := range v.Trigger {
+			entities = append(entities, NamedEntity{t.TriggerId, t.Name})
+		}
+	case EntityTypeVariable:
+		for _, vr := range v.Variable {
+			entities = append(entities, NamedEntity{vr.VariableId, vr.Name})
+		}
+	default:
+		return "", fmt.Errorf("invalid entity type %q (valid values: tag, trigger, variable)", kind)
+	}
+
+	if id == "" {
+		return MatchEntityName(kind, name, entities)
+	}
+	for _, e := range entities {
+		if e.ID == id {
+			return id, nil
+		}
+	}
+	return "", fmt.Errorf("%w: %s %s not found in this workspace", ErrNotFound, kind, id)
+}
+
+// DependenciesOf collects the incoming and outgoing dependency edges of
+// one entity, plus references that do not resolve to anything in v.
+func DependenciesOf(v *tagmanager.ContainerVersion, kind, id string) *EntityDependencies {
+	g := BuildDependencyGraph(v)
+	nodes := make(map[string]GraphNode, len(g.Nodes))
+	for _, n := range g.Nodes {
+		nodes[n.ID] = n
+	}
+
+	self := GraphNodeID(kind, id)
+	deps := &EntityDependencies{Entity: nodes[self], DependsOn: []DependencyRef{}, UsedBy: []DependencyRef{}}
+	for _, e := range g.Edges {
+		switch self {
+		case e.To:
+			deps.DependsOn = append(deps.DependsOn, DependencyRef{GraphNode: nodes[e.From], Relation: e.Relation})
+		case e.From:
+			deps.UsedBy = append(deps.UsedBy, DependencyRef{GraphNode: nodes[e.To], Relation: e.Relation})
+		}
+	}
+
+	// The graph drops edges to entities it does not know about; report them
+	var entity any
+	switch kind {
+	case EntityTypeTag:
+		for _, t := range v.Tag {
+			if t.TagId != id {
+				continue
+			}
+			entity = t
+			addTriggerRefs(deps, nodes, t.FiringTriggerId, RelationFires)
+			addTriggerRefs(deps, nodes, t.BlockingTriggerId, RelationBlocks)
+			for _, s := range t.SetupTag {
+				if !hasTagNamed(v, s.TagName) {
+					deps.Unresolved = append(deps.Unresolved, "setup tag "+s.TagName)
+				}
+			}
+			for _, s := range t.TeardownTag {
+				if !hasTagNamed(v, s.TagName) {
+					deps.Unresolved = append(deps.Unresolved, "teardown tag "+s.TagName)
+				}
+			}
+		}
+	case EntityTypeTrigger:
+		for _, t := range v.Trigger {
+			if t.TriggerId == id {
+				entity = t
+			}
+		}
+	case EntityTypeVariable:
+		for _, vr := range v.Variable {
+			if vr.VariableId == id {
+				entity = vr
+			}
+		}
+	}
+	if entity != nil {
+		seen := make(map[string]bool)
+		for _, name := range variableRefs(entity) {
+			if !seen[name] && !referencesKnownVariable(deps.DependsOn, name) {
+				seen[name] = true
+				deps.Unresolved = append(deps.Unresolved, "{{"+name+"}}")
+			}
+		}
+	}
+	return deps
+}
+
+// addTriggerRefs adds built-in triggers as dependencies and records trigger
+// IDs that are neither workspace nor built-in triggers as unresolved.
+func addTriggerRefs(deps *EntityDependencies, nodes map[string]GraphNode, ids []string, relation string) {
+	for _, id := range ids {
+		if _, ok := nodes[GraphNodeID("trigger", id)]; ok {
+			continue
+		}
+		if !isBuiltInTriggerID(id) {
+			deps.Unresolved = append(deps.Unresolved, "trigger "+id)
+			continue
+		}
+		name := BuiltInTriggerNames[id]
+		if name == "" {
+			name = "Built-in trigger " + id
+		}
+		deps.DependsOn = append(deps.DependsOn, DependencyRef{
+			GraphNode: GraphNode{ID: GraphNodeID("builtInTrigger", id), Kind: "builtInTrigger", EntityID: id, Name: name},
+			Relation:  relation,
+		})
+	}
+}
+
+func hasTagNamed(v *tagmanager.ContainerVersion, name string) bool {
+	for _, t := range v.Tag {
+		if t.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// referencesKnownVariable reports whether name resolved to a variable or
+// built-in variable dependency.
+func referencesKnownVariable(deps []DependencyRef, name string) bool {
+	for _, d := range deps {
+		if d.Relation != RelationReferences {
+			continue
+		}
+		if d.Name == name || (name == "_event" && d.Kind == "builtInVariable" && d.Type == "event") {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,78 @@
+package gtm
+
+import (
+	"errors"
+	"slices"
+	"testing"
+
+	tagmanager "google.golang.org/api/tagmanager/v2"
+)
+
+func refSummary(refs []DependencyRef) []string {
+	var out []string
+	for _, r := range refs {
+		out = append(out, r.Relation+" "+r.ID)
+	}
+	slices.Sort(out)
+	return out
+}
+
+func TestEntityDependencies(t *testing.T) {
+	v := testGraphVersion()
+	v.Tag[0].FiringTriggerId = append(v.Tag[0].FiringTriggerId, "2147479553", "99")
+	v.Tag[0].Parameter = append(v.Tag[0].Parameter, &tagmanager.Parameter{Type: "template", Key: "userId", Value: "{{Missing Var}}"})
+
+	tests := []struct {
+		kind, id       string
+		dependsOn      []string
+		usedBy         []string
+		wantUnresolved []string
+	}{
+		{
+			kind: EntityTypeTag, id: "1",
+			dependsOn:      []string{"blocks trigger:11", "fires builtInTrigger:2147479553", "fires trigger:10", "references variable:20", "setup tag:2"},
+			wantUnresolved: []string{"trigger 99", "{{Missing Var}}"},
+		},
+		{
+			kind: EntityTypeTrigger, id: "10",
+			dependsOn: []string{"references builtInVariable:event"},
+			usedBy:    []string{"fires tag:1", "fires tag:2", "member trigger:12"},
+		},
+		{
+			kind: EntityTypeVariable, id: "21",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.kind+":"+tt.id, func(t *testing.T) {
+			deps := DependenciesOf(v, tt.kind, tt.id)
+			if deps.Entity.EntityID != tt.id {
+				t.Errorf("entity = %+v", deps.Entity)
+			}
+			if got := refSummary(deps.DependsOn); !slices.Equal(got, tt.dependsOn) {
+				t.Errorf("dependsOn = %v, want %v", got, tt.dependsOn)
+			}
+			if got := refSummary(deps.UsedBy); !slices.Equal(got, tt.usedBy) {
+				t.Errorf("usedBy = %v, want %v", got, tt.usedBy)
+			}
+			if !slices.Equal(deps.Unresolved, tt.wantUnresolved) {
+				t.Errorf("unresolved = %v, want %v", deps.Unresolved, tt.wantUnresolved)
+			}
+		})
+	}
+}
+
+func TestFindEntity(t *testing.T) {
+	v := testGraphVersion()
+	if id, err := FindEntity(v, EntityTypeTrigger, "", "purchase event"); err != nil || id != "10" {
+		t.Errorf("by name = %q, %v", id, err)
+	}
+	if id, err := FindEntity(v, EntityTypeVariable, "20", ""); err != nil || id != "20" {
+		t.Errorf("by ID = %q, %v", id, err)
+	}
+	if _, err := FindEntity(v, EntityTypeTag, "404", ""); !errors.Is(err, ErrNotFound) {
+		t.Errorf("missing ID error = %v", err)
+	}
+	if _, err := FindEntity(v, "folder", "1", ""); err == nil {
+		t.Error("expected error for invalid kind")
+	}
+}
@@ -0,0 +1,296 @@
+package gtm
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+
+	tagmanager "google.golang.org/api/tagmanager/v2"
+)
+
+// Entity diff statuses.
+const (
+	DiffAdded    = "added"
+	DiffRemoved  = "removed"
+	DiffModified = "modified"
+)
+
+// FieldChange is a single changed field of a modified entity. Field is a
+// dotted path; parameters are addressed by key, e.g. parameter[eventName].value.
+type FieldChange struct {
+	Field  string `json:"field"`
+	Before any    `json:"before,omitempty"`
+	After  any    `json:"after,omitempty"`
+}
+
+// EntityDiff describes how one entity differs between two container states.
+type EntityDiff struct {
+	EntityType string        `json:"entityType"`
+	EntityID   string        `json:"entityId"`
+	Name       string        `json:"name"`
+	Status     string        `json:"status"`
+	Changes    []FieldChange `json:"changes,omitempty"`
+}
+
+// ContainerDiff is the structured difference between a base and a head container state.
+type ContainerDiff struct {
+	Added    int          `json:"added"`
+	Removed  int          `json:"removed"`
+	Modified int          `json:"modified"`
+	Entities []EntityDiff `json:"entities"`
+}
+
+// WorkspaceDiff is a workspace compared against a container version.
+type WorkspaceDiff struct {
+	BaseVersionID string // "none" if the container was never published
+	Base          *tagmanager.ContainerVersion
+	Head          *tagmanager.ContainerVersion
+	Diff          *ContainerDiff
+}
+
+// DiffWorkspace compares a workspace against a container version. versionID
+// "live" compares against the published version; a container that was never
+// published compares against an empty one.
+func (c *Client) DiffWorkspace(ctx context.Context, accountID, containerID, workspaceID, versionID string) (*WorkspaceDiff, error) {
+	base, err := c.GetVersion(ctx, accountID, containerID, versionID)
+	switch {
+	case err == nil:
+	case versionID == "live" && errors.Is(err, ErrNotFound):
+		// Never published: every workspace entity is an addition
+		base = &tagmanager.ContainerVersion{}
+	default:
+		return nil, err
+	}
+
+	head, err := c.SnapshotWorkspace(ctx, accountID, containerID, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+
+	baseID := base.ContainerVersionId
+	if baseID == "" {
+		baseID = "none"
+	}
+	return &WorkspaceDiff{BaseVersionID: baseID, Base: base, Head: head, Diff: DiffVersions(base, head)}, nil
+}
+
+// DiffVersionsRedacted is DiffVersions with sensitive parameter values
+// redacted as in get and export output. A field whose only change is a
+// secret is still reported, with both values redacted. base and head are
+// not modified.
+func DiffVersionsRedacted(base, head *tagmanager.ContainerVersion) *ContainerDiff {
+	d := DiffVersions(base, head)
+	redacted := DiffVersions(redactedCopy(base), redactedCopy(head))
+	shown := make(map[string]FieldChange)
+	for _, e := range redacted.Entities {
+		for _, c := range e.Changes {
+			shown[e.EntityType+"/"+e.EntityID+"/"+c.Field] = c
+		}
+	}
+	for i := range d.Entities {
+		e := &d.Entities[i]
+		for j, c := range e.Changes {
+			if rc, ok := shown[e.EntityType+"/"+e.EntityID+"/"+c.Field]; ok {
+				e.Changes[j] = rc
+				continue
+			}
+			e.Changes[j] = FieldChange{Field: c.Field, Before: redactedIfSet(c.Before), After: redactedIfSet(c.After)}
+		}
+	}
+	return d
+}
+
+// redactedCopy returns a deep copy of cv with sensitive values redacted.
+func redactedCopy(cv *tagmanager.ContainerVersion) *tagmanager.ContainerVersion {
+	cp := &tagmanager.ContainerVersion{}
+	if data, err := json.Marshal(cv); err == nil {
+		_ = json.Unmarshal(data, cp)
+	}
+	redaction.version(cp)
+	return cp
+}
+
+func redactedIfSet(v any) any {
+	if v == nil {
+		return nil
+	}
+	return RedactedValue
+}
+
+// diffIgnoredFields are location and concurrency fields that differ between
+// a workspace and a version without being a change.
+var diffIgnoredFields = []string{"accountId", "containerId", "workspaceId", "path", "fingerprint", "tagManagerUrl"}
+
+// DiffVersions compares every entity kind in two container states.
+func DiffVersions(base, head *tagmanager.ContainerVersion) *ContainerDiff {
+	d := &ContainerDiff{Entities: []EntityDiff{}}
+	diffEntities(d, "tag", base.Tag, head.Tag,
+		func(e *tagmanager.Tag) string { return e.TagId }, func(e *tagmanager.Tag) string { return e.Name })
+	diffEntities(d, "trigger", base.Trigger, head.Trigger,
+		func(e *tagmanager.Trigger) string { return e.TriggerId }, func(e *tagmanager.Trigger) string { return e.Name })
+	diffEntities(d, "variable", base.Variable, head.Variable,
+		func(e *tagmanager.Variable) string { return e.VariableId }, func(e *tagmanager.Variable) string { return e.Name })
+	diffEntities(d, "builtInVariable", base.BuiltInVariable, head.BuiltInVariable,
+		func(e *tagmanager.BuiltInVariable) string { return e.Type }, func(e *tagmanager.BuiltInVariable) string { return e.Name })
+	diffEntities(d, "folder", base.Folder, head.Folder,
+		func(e *tagmanager.Folder) string { return e.FolderId }, func(e *tagmanager.Folder) string { return e.Name })
+	diffEntities(d, "template", base.CustomTemplate, head.CustomTemplate,
+		func(e *tagmanager.CustomTemplate) string { return e.TemplateId }, func(e *tagmanager.CustomTemplate) string { return e.Name })
+	diffEntities(d, "client", base.Client, head.Client,
+		func(e *tagmanager.Client) string { return e.ClientId }, func(e *tagmanager.Client) string { return e.Name })
+	diffEntities(d, "transformation", base.Transformation, head.Transformation,
+		func(e *tagmanager.Transformation) string { return e.TransformationId }, func(e *tagmanager.Transformation) string { return e.Name })
+	diffEntities(d, "zone", base.Zone, head.Zone,
+		func(e *tagmanager.Zone) string { return e.ZoneId }, func(e *tagmanager.Zone) string { return e.Name })
+	return d
+}
+
+// diffEntities matches entities of one kind by ID and records additions,
+// removals and field-level modifications.
+func diffEntities[T any](d *ContainerDiff, kind string, base, head []*T, id, name func(*T) string) {
+	baseByID := make(map[string]*T, len(base))
+	for _, e := range base {
+		baseByID[id(e)] = e
+	}
+	headIDs := make(map[string]bool, len(head))
+
+	for _, e := range head {
+		headIDs[id(e)] = true
+		old, ok := baseByID[id(e)]
+		if !ok {
+			d.Added++
+			d.Entities = append(d.Entities, EntityDiff{EntityType: kind, EntityID: id(e), Name: name(e), Status: DiffAdded})
+			continue
+		}
+		var changes []FieldChange
+		diffValues("", normalizeForDiff(old), normalizeForDiff(e), &changes)
+		if len(changes) > 0 {
+			d.Modified++
+			d.Entities = append(d.Entities, EntityDiff{EntityType: kind, EntityID: id(e), Name: name(e), Status: DiffModified, Changes: changes})
+		}
+	}
+
+	for _, e := range base {
+		if !headIDs[id(e)] {
+			d.Removed++
+			d.Entities = append(d.Entities, EntityDiff{EntityType: kind, EntityID: id(e), Name: name(e), Status: DiffRemoved})
+		}
+	}
+}
+
+// normalizeForDiff converts an API entity into generic JSON values without
+// location and fingerprint fields.
+func normalizeForDiff(entity any) map[string]any {
+	data, err := json.Marshal(entity)
+	if err != nil {
+		return nil
+	}
+	var m map[string]any
+	if err := json.Unmarshal(data, &m); err != nil {
+		return nil
+	}
+	for _, f := range diffIgnoredFields {
+		delete(m, f)
+	}
+	return m
+}
+
+// diffValues appends the differences between two JSON values. Objects are
+// compared field by field, lists of parameters by key and other lists by index.
+func diffValues(field string, before, after any, out *[]FieldChange) {
+	if reflect.DeepEqual(before, after) {
+		return
+	}
+
+	bm, bok := before.(map[string]any)
+	am, aok := after.(map[string]any)
+	if bok && aok {
+		keys := make(map[string]bool)
+		for k := range bm {
+			keys[k] = true
+		}
+		for k := range am {
+			keys[k] = true
+		}
+		sorted := make([]string, 0, len(keys))
+		for k := range keys {
+			sorted = append(sorted, k)
+		}
+		sort.Strings(sorted)
+		for _, k := range sorted {
+			diffValues(joinField(field, k), bm[k], am[k], out)
+		}
+		return
+	}
+
+	bl, bok := before.([]any)
+	al, aok := after.([]any)
+	if bok && aok {
+		if bk, ok := keyedParams(bl); ok {
+			if ak, ok := keyedParams(al); ok {
+				diffKeyed(field, bk, ak, out)
+				return
+			}
+		}
+		if len(bl) == len(al) {
+			for i := range bl {
+				diffValues(fmt.Sprintf("%s[%d]", field, i), bl[i], al[i], out)
+			}
+			return
+		}
+	}
+
+	*out = append(*out, FieldChange{Field: field, Before: before, After: after})
+}
+
+// keyedParams indexes a list of parameter objects by their "key" field. It
+// fails if any element is not an object with a unique string key.
+func keyedParams(list []any) (map[string]any, bool) {
+	if len(list) == 0 {
+		return nil, false
+	}
+	byKey := make(map[string]any, len(list))
+	for _, e := range list {
+		m, ok := e.(map[string]any)
+		if !ok {
+			return nil, false
+		}
+		key, ok := m["key"].(string)
+		if !ok || key == "" {
+			return nil, false
+		}
+		if _, dup := byKey[key]; dup {
+			return nil, false
+		}
+		byKey[key] = m
+	}
+	return byKey, true
+}
+
+func diffKeyed(field string, before, after map[string]any, out *[]FieldChange) {
+	keys := make(map[string]bool)
+	for k := range before {
+		keys[k] = true
+	}
+	for k := range after {
+		keys[k] = true
+	}
+	sorted := make([]string, 0, len(keys))
+	for k := range keys {
+		sorted = append(sorted, k)
+	}
+	sort.Strings(sorted)
+	for _, k := range sorted {
+		diffValues(fmt.Sprintf("%s[%s]", field, k), before[k], after[k], out)
+	}
+}
+
+func joinField(prefix, name string) string {
+	if prefix == "" {
+		return name
+	}
+	return prefix + "." + name
+}
@@ -0,0 +1,144 @@
+package gtm
+
+import (
+	"reflect"
+	"testing"
+
+	tagmanager "google.golang.org/api/tagmanager/v2"
+)
+
+func TestDiffVersions(t *testing.T) {
+	base := &tagmanager.ContainerVersion{
+		Tag: []*tagmanager.Tag{
+			{TagId: "1", Name: "GA4 Event", Type: "gaawe", Path: "accounts/1/containers/2/versions/5/tags/1", Fingerprint: "a",
+				Parameter: []*tagmanager.Parameter{
+					{Type: "template", Key: "eventName", Value: "click"},
+					{Type: "template", Key: "measurementIdOverride", Value: "G-1"},
+				}},
+			{TagId: "2", Name: "Old Tag", Type: "html"},
+		},
+		Trigger: []*tagmanager.Trigger{{TriggerId: "10", Name: "All Pages", Type: "pageview"}},
+	}
+	head := &tagmanager.ContainerVersion{
+		Tag: []*tagmanager.Tag{
+			{TagId: "1", Name: "GA4 Event", Type: "gaawe", Path: "accounts/1/containers/2/workspaces/3/tags/1", Fingerprint: "b",
+				Parameter: []*tagmanager.Parameter{
+					{Type: "template", Key: "measurementIdOverride", Value: "G-1"},
+					{Type: "template", Key: "eventName", Value: "cta_click"},
+				}},
+			{TagId: "3", Name: "New Tag", Type: "html"},
+		},
+		Trigger: []*tagmanager.Trigger{{TriggerId: "10", Name: "All Pages", Type: "pageview", WorkspaceId: "3"}},
+	}
+
+	d := DiffVersions(base, head)
+
+	if d.Added != 1 || d.Removed != 1 || d.Modified != 1 {
+		t.Fatalf("expected 1 added, 1 removed, 1 modified, got %+v", d)
+	}
+
+	want := []EntityDiff{
+		{EntityType: "tag", EntityID: "1", Name: "GA4 Event", Status: DiffModified, Changes: []FieldChange{
+			{Field: "parameter[eventName].value", Before: "click", After: "cta_click"},
+		}},
+		{EntityType: "tag", EntityID: "3", Name: "New Tag", Status: DiffAdded},
+		{EntityType: "tag", EntityID: "2", Name: "Old Tag", Status: DiffRemoved},
+	}
+	if !reflect.DeepEqual(d.Entities, want) {
+		t.Errorf("unexpected entities:\n got %+v\nwant %+v", d.Entities, want)
+	}
+}
+
+func TestDiffVersionsRedacted(t *testing.T) {
+	base := &tagmanager.ContainerVersion{
+		Tag: []*tagmanager.Tag{{TagId: "1", Name: "Webhook", Type: "html", Parameter: []*tagmanager.Parameter{
+			{Type: "template", Key: "apiKey", Value: "old-secret"},
+			{Type: "template", Key: "endpoint", Value: "https://a.example"},
+		}}},
+		Variable: []*tagmanager.Variable{{VariableId: "2", Name: "Stripe Secret", Type: "c", Parameter: []*tagmanager.Parameter{
+			{Type: "template", Key: "value", Value: "sk_live_1"},
+		}}},
+	}
+	head := &tagmanager.ContainerVersion{
+		Tag: []*tagmanager.Tag{{TagId: "1", Name: "Webhook", Type: "html", Parameter: []*tagmanager.Parameter{
+			{Type: "template", Key: "apiKey", Value: "new-secret"},
+			{Type: "template", Key: "endpoint", Value: "https://b.example"},
+			{Type: "template", Key: "authToken", Value: "tok"},
+		}}},
+		Variable: []*tagmanager.Variable{{VariableId: "2", Name: "Stripe Secret", Type: "c", Parameter: []*tagmanager.Parameter{
+			{Type: "template", Key: "value", Value: "sk_live_2"},
+		}}},
+	}
+
+	d := DiffVersionsRedacted(base, head)
+
+	// Changed secrets are still reported, without their values
+	want := []EntityDiff{
+		{EntityType: "tag", EntityID: "1", Name: "Webhook", Status: DiffModified, Changes: []FieldChange{
+			{Field: "parameter[apiKey].value", Before: RedactedValue, After: RedactedValue},
+			{Field: "parameter[authToken]", After: map[string]any{"key": "authToken", "type": "template", "value": RedactedValue}},
+			{Field: "parameter[endpoint].value", Before: "https://a.example", After: "https://b.example"},
+		}},
+		{EntityType: "variable", EntityID: "2", Name: "Stripe Secret", Status: DiffModified, Changes: []FieldChange{
+			{Field: "parameter[value].value", Before: RedactedValue, After: RedactedValue},
+		}},
+	}
+	if d.Modified != 2 || !reflect.DeepEqual(d.Entities, want) {
+		t.Errorf("unexpected entities:\n got %+v\nwant %+v", d.Entities, want)
+	}
+
+	// The versions themselves are not modified
+	if base.Tag[0].Parameter[0].Value != "old-secret" || head.Variable[0].Parameter[0].Value != "sk_live_2" {
+		t.Error("DiffVersionsRedacted modified its arguments")
+	}
+}
+
+func TestDiffValues(t *testing.T) {
+	tests := []struct {
+		name   string
+		before any
+		after  any
+		want   []FieldChange
+	}{
+		{
+			name:   "scalar field",
+			before: map[string]any{"name": "a"},
+			after:  map[string]any{"name": "b"},
+			want:   []FieldChange{{Field: "name", Before: "a", After: "b"}},
+		},
+		{
+			name:   "added field",
+			before: map[string]any{},
+			after:  map[string]any{"paused": true},
+			want:   []FieldChange{{Field: "paused", After: true}},
+		},
+		{
+			name:   "unkeyed list by index",
+			before: map[string]any{"firingTriggerId": []any{"1", "2"}},
+			after:  map[string]any{"firingTriggerId": []any{"1", "3"}},
+			want:   []FieldChange{{Field: "firingTriggerId[1]", Before: "2", After: "3"}},
+		},
+		{
+			name:   "list length change",
+			before: map[string]any{"firingTriggerId": []any{"1"}},
+			after:  map[string]any{"firingTriggerId": []any{"1", "2"}},
+			want:   []FieldChange{{Field: "firingTriggerId", Before: []any{"1"}, After: []any{"1", "2"}}},
+		},
+		{
+			name:   "removed parameter",
+			before: map[string]any{"parameter": []any{map[string]any{"key": "a", "value": "1"}, map[string]any{"key": "b", "value": "2"}}},
+			after:  map[string]any{"parameter": []any{map[string]any{"key": "a", "value": "1"}}},
+			want:   []FieldChange{{Field: "parameter[b]", Before: map[string]any{"key": "b", "value": "2"}}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []FieldChange
+			diffValues("", tt.before, tt.after, &got)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
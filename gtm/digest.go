@@ -0,0 +1,261 @@
+package gtm
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"golang.org/x/oauth2"
+	"gopkg.in/yaml.v3"
+)
+
+// minDigestInterval bounds how often scheduled digests may run.
+const minDigestInterval = 15 * time.Minute
+
+// DigestConfig configures scheduled audit and backup runs.
+//
+//	interval: 24h
+//	api_key: nightly-audit        # credential from API_KEYS_FILE
+//	backup_dir: /var/lib/gtm/backups
+//	containers:
+//	  - account_id: "123456"
+//	    container_id: "7890123"
+type DigestConfig struct {
+	Interval   time.Duration  `yaml:"interval"`
+	APIKey     string         `yaml:"api_key"`
+	BackupDir  string         `yaml:"backup_dir"`
+	Containers []DigestTarget `yaml:"containers"`
+}
+
+// DigestTarget is a container included in the scheduled digest.
+type DigestTarget struct {
+	AccountID   string `yaml:"account_id"`
+	ContainerID string `yaml:"container_id"`
+}
+
+// LoadDigestConfig reads and validates a digest configuration file.
+func LoadDigestConfig(path string) (*DigestConfig, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read digest file: %w", err)
+	}
+	cfg, err := parseDigestConfig(data)
+	if err != nil {
+		return nil, fmt.Errorf("invalid digest file %s: %w", path, err)
+	}
+	return cfg, nil
+}
+
+func parseDigestConfig(data []byte) (*DigestConfig, error) {
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+
+	var cfg DigestConfig
+	if err := dec.Decode(&cfg); err != nil && !errors.Is(err, io.EOF) {
+		return nil, err
+	}
+
+	if cfg.Interval == 0 {
+		cfg.Interval = 24 * time.Hour
+	}
+	if cfg.Interval < minDigestInterval {
+		return nil, fmt.Errorf("interval must be at least %s", minDigestInterval)
+	}
+	if cfg.APIKey == "" {
+		return nil, fmt.Errorf("api_key is required")
+	}
+	if len(cfg.Containers) == 0 {
+		return nil, fmt.Errorf("at least one container is required")
+	}
+	for i, t := range cfg.Containers {
+		if err := ValidateContainerPath(t.AccountID, t.ContainerID); err != nil {
+			return nil, fmt.Errorf("container %d: %w", i, err)
+		}
+	}
+	return &cfg, nil
+}
+
+// Digest is the result of a scheduled audit and backup run.
+type Digest struct {
+	GeneratedAt time.Time         `json:"generatedAt"`
+	Duration    string            `json:"duration"`
+	Containers  []ContainerDigest `json:"containers"`
+	Errors      int               `json:"errors"`
+	Warnings    int               `json:"warnings"`
+}
+
+// ContainerDigest summarizes one container in a digest.
+type ContainerDigest struct {
+	AccountID   string            `json:"accountId"`
+	ContainerID string            `json:"containerId"`
+	Name        string            `json:"name,omitempty"`
+	PublicID    string            `json:"publicId,omitempty"`
+	LiveVersion *LiveVersion      `json:"liveVersion,omitempty"`
+	LiveCounts  *ExportCounts     `json:"liveCounts,omitempty"`
+	BackupFile  string            `json:"backupFile,omitempty"`
+	Workspaces  []WorkspaceDigest `json:"workspaces"`
+	Error       string            `json:"error,omitempty"`
+}
+
+// WorkspaceDigest lists the validation issues found in a workspace.
+type WorkspaceDigest struct {
+	WorkspaceID string            `json:"workspaceId"`
+	Name        string            `json:"name"`
+	Counts      ExportCounts      `json:"counts"`
+	Issues      []ValidationIssue `json:"issues"`
+	Error       string            `json:"error,omitempty"`
+}
+
+// digestStore holds the most recent digest.
+type digestStore struct {
+	mu     sync.RWMutex
+	latest *Digest
+}
+
+func (s *digestStore) set(d *Digest) {
+	s.mu.Lock()
+	s.latest = d
+	s.mu.Unlock()
+}
+
+func (s *digestStore) Get() *Digest {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.latest
+}
+
+// LatestDigest is the process-wide digest served by gtm://digest/latest.
+var LatestDigest = &digestStore{}
+
+// DigestScheduler periodically audits and backs up the configured containers.
+type DigestScheduler struct {
+	cfg         *DigestConfig
+	tokenSource oauth2.TokenSource
+	logger      *slog.Logger
+	now         func() time.Time
+}
+
+// NewDigestScheduler creates a scheduler acting with the given Google credential.
+func NewDigestScheduler(cfg *DigestConfig, tokenSource oauth2.TokenSource, logger *slog.Logger) *DigestScheduler {
+	return &DigestScheduler{cfg: cfg, tokenSource: tokenSource, logger: logger, now: time.Now}
+}
+
+// Run produces a digest immediately and then at every interval until ctx is done.
+func (s *DigestScheduler) Run(ctx context.Context) {
+	ticker := time.NewTicker(s.cfg.Interval)
+	defer ticker.Stop()
+	for {
+		s.RunOnce(ctx)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// RunOnce audits every configured container and stores the result as the latest digest.
+func (s *DigestScheduler) RunOnce(ctx context.Context) *Digest {
+	start := s.now()
+	digest := &Digest{GeneratedAt: start, Containers: []ContainerDigest{}}
+
+	client, err := NewClient(ctx, s.tokenSource)
+	for _, t := range s.cfg.Containers {
+		cd := ContainerDigest{AccountID: t.AccountID, ContainerID: t.ContainerID, Workspaces: []WorkspaceDigest{}}
+		if err != nil {
+			cd.Error = err.Error()
+		} else {
+			s.auditContainer(ctx, client, &cd)
+		}
+		digest.Containers = append(digest.Containers, cd)
+	}
+
+	for _, cd := range digest.Containers {
+		if cd.Error != "" {
+			digest.Errors++
+		}
+		for _, w := range cd.Workspaces {
+			for _, issue := range w.Issues {
+				if issue.Severity == SeverityError {
+					digest.Errors++
+				} else {
+					digest.Warnings++
+				}
+			}
+		}
+	}
+	digest.Duration = s.now().Sub(start).Round(time.Millisecond).String()
+
+	LatestDigest.set(digest)
+	s.logger.Info("digest generated", "containers", len(digest.Containers), "errors", digest.Errors, "warnings", digest.Warnings, "duration", digest.Duration)
+	return digest
+}
+
+// auditContainer backs up a container and validates each of its workspaces.
+func (s *DigestScheduler) auditContainer(ctx context.Context, client *Client, cd *ContainerDigest) {
+	backup, err := client.BackupContainer(ctx, cd.AccountID, cd.ContainerID)
+	if err != nil {
+		cd.Error = err.Error()
+		return
+	}
+	cd.Name = backup.Name
+	cd.PublicID = backup.PublicID
+	backup.CreatedAt = s.now()
+
+	if backup.Live != nil {
+		counts := backup.Live.Counts()
+		cd.LiveCounts = &counts
+		if cd.LiveVersion, err = client.GetLiveVersion(ctx, cd.AccountID, cd.ContainerID); err != nil {
+			s.logger.Warn("digest: failed to read live version", "container", cd.ContainerID, "error", err)
+		}
+	}
+
+	if s.cfg.BackupDir != "" {
+		file, err := writeDigestBackup(s.cfg.BackupDir, backup)
+		if err != nil {
+			cd.Error = err.Error()
+		}
+		cd.BackupFile = file
+	}
+
+	for _, w := range backup.Workspaces {
+		wd := WorkspaceDigest{WorkspaceID: w.WorkspaceID, Name: w.Name, Counts: w.Export.Counts(), Issues: []ValidationIssue{}}
+		issues, err := client.ValidateWorkspace(ctx, cd.AccountID, cd.ContainerID, w.WorkspaceID)
+		if err != nil {
+			wd.Error = err.Error()
+		} else {
+			wd.Issues = issues
+		}
+		cd.Workspaces = append(cd.Workspaces, wd)
+	}
+}
+
+// writeDigestBackup writes the live version (or first workspace) export of a
+// backup to dir, in GTM UI export format, and returns the file path.
+func writeDigestBackup(dir string, backup *ContainerBackup) (string, error) {
+	export := backup.RestoreExport()
+	if export == nil {
+		return "", nil
+	}
+	data, err := json.MarshalIndent(export, "", "    ")
+	if err != nil {
+		return "", fmt.Errorf("failed to encode backup: %w", err)
+	}
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return "", fmt.Errorf("failed to create backup directory: %w", err)
+	}
+	name := fmt.Sprintf("%s-%s-%s.json", backup.AccountID, backup.ContainerID, backup.CreatedAt.UTC().Format("20060102T150405Z"))
+	path := filepath.Join(dir, name)
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		return "", fmt.Errorf("failed to write backup: %w", err)
+	}
+	return path, nil
+}
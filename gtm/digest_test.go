@@ -0,0 +1,136 @@
+package gtm
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	tagmanager "google.golang.org/api/tagmanager/v2"
+)
+
+func TestParseDigestConfig(t *testing.T) {
+	tests := []struct {
+		name    string
+		yaml    string
+		wantErr string
+	}{
+		{
+			name: "valid",
+			yaml: "interval: 6h\napi_key: audit\ncontainers:\n  - account_id: \"1\"\n    container_id: \"2\"\n",
+		},
+		{
+			name:    "interval too short",
+			yaml:    "interval: 1m\napi_key: audit\ncontainers:\n  - account_id: \"1\"\n    container_id: \"2\"\n",
+			wantErr: "interval must be at least",
+		},
+		{
+			name:    "missing api key",
+			yaml:    "containers:\n  - account_id: \"1\"\n    container_id: \"2\"\n",
+			wantErr: "api_key is required",
+		},
+		{
+			name:    "no containers",
+			yaml:    "api_key: audit\n",
+			wantErr: "at least one container",
+		},
+		{
+			name:    "invalid container",
+			yaml:    "api_key: audit\ncontainers:\n  - account_id: \"1\"\n",
+			wantErr: "container 0",
+		},
+		{
+			name:    "unknown field",
+			yaml:    "api_key: audit\nschedule: daily\ncontainers:\n  - account_id: \"1\"\n    container_id: \"2\"\n",
+			wantErr: "schedule",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg, err := parseDigestConfig([]byte(tt.yaml))
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("error = %v, want containing %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if cfg.Interval != 6*time.Hour || len(cfg.Containers) != 1 {
+				t.Errorf("unexpected config: %+v", cfg)
+			}
+		})
+	}
+
+	cfg, err := parseDigestConfig([]byte("api_key: audit\ncontainers:\n  - account_id: \"1\"\n    container_id: \"2\"\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.Interval != 24*time.Hour {
+		t.Errorf("default interval = %s, want 24h", cfg.Interval)
+	}
+}
+
+func TestDigestScheduler_RunOnceRecordsErrors(t *testing.T) {
+	cfg := &DigestConfig{Interval: time.Hour, APIKey: "audit", Containers: []DigestTarget{
+		{AccountID: "1", ContainerID: "2"},
+		{AccountID: "1", ContainerID: "3"},
+	}}
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+	// Without a token source the client cannot be built; every container
+	// reports the error and the digest is still published.
+	digest := NewDigestScheduler(cfg, nil, logger).RunOnce(context.Background())
+	if len(digest.Containers) != 2 || digest.Errors != 2 {
+		t.Fatalf("digest = %+v, want 2 containers with errors", digest)
+	}
+	for _, cd := range digest.Containers {
+		if cd.Error == "" {
+			t.Errorf("container %s has no error", cd.ContainerID)
+		}
+	}
+	if LatestDigest.Get() != digest {
+		t.Error("RunOnce did not store the latest digest")
+	}
+}
+
+func TestWriteDigestBackup(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "backups")
+	backup := &ContainerBackup{
+		AccountID:   "1",
+		ContainerID: "2",
+		CreatedAt:   time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC),
+		Live: &ContainerExport{ExportFormatVersion: 2, ContainerVersion: &tagmanager.ContainerVersion{
+			Tag: []*tagmanager.Tag{{Name: "GA4"}},
+		}},
+	}
+
+	path, err := writeDigestBackup(dir, backup)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if filepath.Base(path) != "1-2-20260102T030405Z.json" {
+		t.Errorf("path = %s", path)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var export ContainerExport
+	if err := json.Unmarshal(data, &export); err != nil {
+		t.Fatal(err)
+	}
+	if len(export.ContainerVersion.Tag) != 1 {
+		t.Errorf("backup tags = %d, want 1", len(export.ContainerVersion.Tag))
+	}
+
+	if path, err := writeDigestBackup(dir, &ContainerBackup{}); err != nil || path != "" {
+		t.Errorf("empty backup = %q, %v; want no file", path, err)
+	}
+}
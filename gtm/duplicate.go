@@ -0,0 +1,143 @@
+package gtm
+
+import (
+	"context"
+	"strings"
+
+	tagmanager "google.golang.org/api/tagmanager/v2"
+)
+
+// overrideParams replaces the top-level parameters whose key matches an
+// override and appends overrides for keys the entity does not have yet.
+func overrideParams(params []*tagmanager.Parameter, overrides []Parameter) []*tagmanager.Parameter {
+	for _, o := range overrides {
+		replaced := false
+		for i, p := range params {
+			if p != nil && p.Key == o.Key {
+				params[i] = toAPIParam(&o)
+				replaced = true
+			}
+		}
+		if !replaced {
+			params = append(params, toAPIParam(&o))
+		}
+	}
+	return params
+}
+
+// checkDuplicateName validates the name of a duplicate against the original's.
+func checkDuplicateName(name, original string) error {
+	if strings.TrimSpace(name) == "" {
+		return InvalidField("name", "name is required")
+	}
+	if name == original {
+		return InvalidField("name", "name must differ from the original's (%q)", original)
+	}
+	return nil
+}
+
+// DuplicateTag creates a copy of a tag in the same workspace under name,
+// with every field of the original, including ones TagInput does not cover
+// such as setup and teardown tags or monitoring metadata. Overrides replace
+// top-level parameters by key.
+func (c *Client) DuplicateTag(ctx context.Context, accountID, containerID, workspaceID, tagID, name string, overrides []Parameter) (*CreatedTag, error) {
+	ws := c.Service.Accounts.Containers.Workspaces
+	tag, err := RetryWithBackoff(ctx, func() (*tagmanager.Tag, error) {
+		return ws.Tags.Get(BuildTagPath(accountID, containerID, workspaceID, tagID)).Context(ctx).Do()
+	})
+	if err != nil {
+		return nil, MapGoogleError(err)
+	}
+	if err := checkDuplicateName(name, tag.Name); err != nil {
+		return nil, err
+	}
+
+	parent := BuildWorkspacePath(accountID, containerID, workspaceID)
+	tag.TagId, tag.Path, tag.Fingerprint, tag.TagManagerUrl = "", "", "", ""
+	tag.Name = name
+	tag.Parameter = overrideParams(tag.Parameter, overrides)
+
+	result, err := ws.Tags.Create(parent, tag).Context(ctx).Do()
+	if err != nil {
+		return nil, MapGoogleError(err)
+	}
+	RecentMutations.Mark(ctx, result.Path)
+
+	return &CreatedTag{
+		TagID:       result.TagId,
+		Name:        result.Name,
+		Type:        result.Type,
+		Path:        result.Path,
+		Fingerprint: result.Fingerprint,
+	}, nil
+}
+
+// DuplicateTrigger creates a copy of a trigger in the same workspace under
+// name, keeping its filters, event settings and every other field.
+// Overrides replace top-level parameters by key.
+func (c *Client) DuplicateTrigger(ctx context.Context, accountID, containerID, workspaceID, triggerID, name string, overrides []Parameter) (*CreatedTrigger, error) {
+	ws := c.Service.Accounts.Containers.Workspaces
+	trigger, err := RetryWithBackoff(ctx, func() (*tagmanager.Trigger, error) {
+		return ws.Triggers.Get(BuildTriggerPath(accountID, containerID, workspaceID, triggerID)).Context(ctx).Do()
+	})
+	if err != nil {
+		return nil, MapGoogleError(err)
+	}
+	if err := checkDuplicateName(name, trigger.Name); err != nil {
+		return nil, err
+	}
+
+	parent := BuildWorkspacePath(accountID, containerID, workspaceID)
+	trigger.TriggerId, trigger.Path, trigger.Fingerprint, trigger.TagManagerUrl = "", "", "", ""
+	trigger.Name = name
+	trigger.Parameter = overrideParams(trigger.Parameter, overrides)
+
+	result, err := ws.Triggers.Create(parent, trigger).Context(ctx).Do()
+	if err != nil {
+		return nil, MapGoogleError(err)
+	}
+	RecentMutations.Mark(ctx, result.Path)
+
+	return &CreatedTrigger{
+		TriggerID:   result.TriggerId,
+		Name:        result.Name,
+		Type:        result.Type,
+		Path:        result.Path,
+		Fingerprint: result.Fingerprint,
+	}, nil
+}
+
+// DuplicateVariable creates a copy of a variable in the same workspace
+// under name, keeping its format value, enabling and disabling triggers and
+// every other field. Overrides replace top-level parameters by key.
+func (c *Client) DuplicateVariable(ctx context.Context, accountID, containerID, workspaceID, variableID, name string, overrides []Parameter) (*CreatedVariable, error) {
+	ws := c.Service.Accounts.Containers.Workspaces
+	variable, err := RetryWithBackoff(ctx, func() (*tagmanager.Variable, error) {
+		return ws.Variables.Get(BuildVariablePath(accountID, containerID, workspaceID, variableID)).Context(ctx).Do()
+	})
+	if err != nil {
+		return nil, MapGoogleError(err)
+	}
+	if err := checkDuplicateName(name, variable.Name); err != nil {
+		return nil, err
+	}
+
+	parent := BuildWorkspacePath(accountID, containerID, workspaceID)
+	variable.VariableId, variable.Path, variable.Fingerprint, variable.TagManagerUrl = "", "", "", ""
+	variable.Name = name
+	variable.Parameter = overrideParams(variable.Parameter, overrides)
+
+	result, err := ws.Variables.Create(parent, variable).Context(ctx).Do()
+	if err != nil {
+		return nil, MapGoogleError(err)
+	}
+	RecentMutations.Mark(ctx, result.Path)
+
+	return &CreatedVariable{
+		VariableID:  result.VariableId,
+		Name:        result.Name,
+		Type:        result.Type,
+		Path:        result.Path,
+		Fingerprint: result.Fingerprint,
+	}, nil
+}
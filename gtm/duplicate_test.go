@@ -0,0 +1,76 @@
+package gtm
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"testing"
+
+	tagmanager "google.golang.org/api/tagmanager/v2"
+)
+
+func TestDuplicateTag(t *testing.T) {
+	var created *tagmanager.Tag
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPost {
+			created = &tagmanager.Tag{}
+			if err := json.NewDecoder(r.Body).Decode(created); err != nil {
+				t.Error(err)
+			}
+			io.WriteString(w, `{"tagId":"9","name":"GA4 - Sign Up","type":"gaawe","path":"accounts/1/containers/2/workspaces/3/tags/9"}`)
+			return
+		}
+		io.WriteString(w, `{"tagId":"5","name":"GA4 - Login","type":"gaawe","fingerprint":"123","path":"accounts/1/containers/2/workspaces/3/tags/5",
+			"firingTriggerId":["7"],"setupTag":[{"tagName":"Consent"}],"monitoringMetadataTagNameKey":"tag",
+			"parameter":[{"type":"template","key":"eventName","value":"login"},{"type":"template","key":"measurementIdOverride","value":"{{GA4 ID}}"}]}`)
+	})
+	ctx := context.Background()
+
+	tag, err := client.DuplicateTag(ctx, "1", "2", "3", "5", "GA4 - Sign Up", []Parameter{
+		TemplateParam("eventName", "sign_up"),
+		BooleanParam("sendEcommerceData", false),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tag.TagID != "9" {
+		t.Errorf("tag = %+v", tag)
+	}
+	if created.TagId != "" || created.Fingerprint != "" || created.Path != "" || created.Name != "GA4 - Sign Up" {
+		t.Errorf("created = %+v", created)
+	}
+	if len(created.SetupTag) != 1 || created.MonitoringMetadataTagNameKey != "tag" || len(created.FiringTriggerId) != 1 {
+		t.Errorf("fields not kept: %+v", created)
+	}
+	if len(created.Parameter) != 3 || created.Parameter[0].Value != "sign_up" || created.Parameter[1].Value != "{{GA4 ID}}" || created.Parameter[2].Key != "sendEcommerceData" {
+		t.Errorf("parameters = %+v", created.Parameter)
+	}
+
+	created = nil
+	if _, err := client.DuplicateTag(ctx, "1", "2", "3", "5", "GA4 - Login", nil); !errors.Is(err, ErrInvalidRequest) || created != nil {
+		t.Errorf("same name: err = %v, created = %+v", err, created)
+	}
+}
+
+func TestDuplicateVariable(t *testing.T) {
+	var created *tagmanager.Variable
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPost {
+			created = &tagmanager.Variable{}
+			json.NewDecoder(r.Body).Decode(created)
+			io.WriteString(w, `{"variableId":"8","name":"Const - Staging ID","type":"c"}`)
+			return
+		}
+		io.WriteString(w, `{"variableId":"4","name":"Const - GA4 ID","type":"c","parentFolderId":"12",
+			"formatValue":{"caseConversionType":"lowercase"},"parameter":[{"type":"template","key":"value","value":"G-PROD"}]}`)
+	})
+
+	if _, err := client.DuplicateVariable(context.Background(), "1", "2", "3", "4", "Const - Staging ID", []Parameter{TemplateParam("value", "G-STAGING")}); err != nil {
+		t.Fatal(err)
+	}
+	if created.VariableId != "" || created.ParentFolderId != "12" || created.FormatValue == nil || created.Parameter[0].Value != "G-STAGING" {
+		t.Errorf("created = %+v", created)
+	}
+}
@@ -0,0 +1,109 @@
+package gtm
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	tagmanager "google.golang.org/api/tagmanager/v2"
+)
+
+// DefaultCanaryEnvironment is the environment name used for canary releases.
+const DefaultCanaryEnvironment = "canary"
+
+// CanaryEnvironment describes a user environment serving a canary version.
+type CanaryEnvironment struct {
+	EnvironmentID      string `json:"environmentId"`
+	Name               string `json:"name"`
+	ContainerVersionID string `json:"containerVersionId"`
+	Created            bool   `json:"created,omitempty"`
+	// Snippet replaces the standard GTM web snippet and loads the canary
+	// environment for a sticky percentage of visitors.
+	Snippet string `json:"snippet,omitempty"`
+	// QueryParams are appended to the gtm.js URL to load the environment directly.
+	QueryParams string `json:"queryParams"`
+}
+
+// FindEnvironment returns the user environment with the given name, or nil if none exists.
+func (c *Client) FindEnvironment(ctx context.Context, accountID, containerID, name string) (*tagmanager.Environment, error) {
+	parent := BuildContainerPath(accountID, containerID)
+	resp, err := RetryWithBackoff(ctx, func() (*tagmanager.ListEnvironmentsResponse, error) {
+		return c.Service.Accounts.Containers.Environments.List(parent).Context(ctx).Do()
+	})
+	if err != nil {
+		return nil, MapGoogleError(err)
+	}
+	for _, env := range resp.Environment {
+		if env.Type == "user" && strings.EqualFold(env.Name, name) {
+			return env, nil
+		}
+	}
+	return nil, nil
+}
+
+// DeployToEnvironment points the named user environment at a container
+// version, creating the environment if needed.
+func (c *Client) DeployToEnvironment(ctx context.Context, accountID, containerID, name, versionID string) (*tagmanager.Environment, bool, error) {
+	env, err := c.FindEnvironment(ctx, accountID, containerID, name)
+	if err != nil {
+		return nil, false, err
+	}
+
+	if env == nil {
+		created, err := c.Service.Accounts.Containers.Environments.Create(BuildContainerPath(accountID, containerID), &tagmanager.Environment{
+			Name:               name,
+			Description:        "Canary releases managed by gtm-mcp-server",
+			ContainerVersionId: versionID,
+		}).Context(ctx).Do()
+		if err != nil {
+			return nil, false, MapGoogleError(err)
+		}
+		return created, true, nil
+	}
+
+	env.ContainerVersionId = versionID
+	updated, err := c.Service.Accounts.Containers.Environments.Update(env.Path, env).Fingerprint(env.Fingerprint).Context(ctx).Do()
+	if err != nil {
+		return nil, false, MapGoogleError(err)
+	}
+	return updated, false, nil
+}
+
+// environmentQueryParams returns the gtm.js query parameters selecting an environment.
+func environmentQueryParams(env *tagmanager.Environment) string {
+	return fmt.Sprintf("&gtm_auth=%s&gtm_preview=env-%s&gtm_cookies_win=x", env.AuthorizationCode, env.EnvironmentId)
+}
+
+// canarySnippet returns a GTM web snippet that assigns each visitor to the
+// canary bucket with the given percentage (sticky via localStorage) and loads
+// the canary environment for that bucket and the live container otherwise.
+func canarySnippet(publicID string, env *tagmanager.Environment, percentage int) string {
+	return fmt.Sprintf(`<!-- Google Tag Manager (canary %d%%) -->
+<script>
+(function(w,d,l,i,pct,env){
+  var b='0';
+  try{b=w.localStorage.getItem('gtm_canary');if(b===null){b=Math.random()*100<pct?'1':'0';w.localStorage.setItem('gtm_canary',b);}}catch(e){b='0';}
+  w[l]=w[l]||[];w[l].push({'gtm.start':new Date().getTime(),event:'gtm.js',gtm_canary:b==='1'});
+  var f=d.getElementsByTagName('script')[0],j=d.createElement('script');
+  j.async=true;j.src='https://www.googletagmanager.com/gtm.js?id='+i+(l!='dataLayer'?'&l='+l:'')+(b==='1'?env:'');
+  f.parentNode.insertBefore(j,f);
+})(window,document,'dataLayer','%s',%d,'%s');
+</script>
+<!-- End Google Tag Manager (canary) -->`, percentage, publicID, percentage, environmentQueryParams(env))
+}
+
+// ToCanaryEnvironment builds the canary description, including the snippet for web containers.
+func ToCanaryEnvironment(env *tagmanager.Environment, created bool, container *Container, percentage int) *CanaryEnvironment {
+	out := &CanaryEnvironment{
+		EnvironmentID:      env.EnvironmentId,
+		Name:               env.Name,
+		ContainerVersionID: env.ContainerVersionId,
+		Created:            created,
+		QueryParams:        environmentQueryParams(env),
+	}
+	if container != nil && slices.Contains(container.UsageContext, "web") {
+		out.Snippet = canarySnippet(container.PublicID, env, percentage)
+	}
+	return out
+}
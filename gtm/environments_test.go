@@ -0,0 +1,50 @@
+package gtm
+
+import (
+	"strings"
+	"testing"
+
+	tagmanager "google.golang.org/api/tagmanager/v2"
+)
+
+func TestEnvironmentQueryParams(t *testing.T) {
+	env := &tagmanager.Environment{EnvironmentId: "7", AuthorizationCode: "abc123"}
+	want := "&gtm_auth=abc123&gtm_preview=env-7&gtm_cookies_win=x"
+	if got := environmentQueryParams(env); got != want {
+		t.Errorf("environmentQueryParams() = %q, want %q", got, want)
+	}
+}
+
+func TestToCanaryEnvironment(t *testing.T) {
+	env := &tagmanager.Environment{EnvironmentId: "7", Name: "canary", ContainerVersionId: "42", AuthorizationCode: "abc123"}
+
+	tests := []struct {
+		name        string
+		container   *Container
+		wantSnippet bool
+	}{
+		{name: "web container gets snippet", container: &Container{PublicID: "GTM-ABC", UsageContext: []string{"web"}}, wantSnippet: true},
+		{name: "server container has no snippet", container: &Container{PublicID: "GTM-SRV", UsageContext: []string{"server"}}},
+		{name: "unknown container", container: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ToCanaryEnvironment(env, true, tt.container, 10)
+			if got.EnvironmentID != "7" || got.ContainerVersionID != "42" || !got.Created {
+				t.Errorf("unexpected canary: %+v", got)
+			}
+			if (got.Snippet != "") != tt.wantSnippet {
+				t.Fatalf("snippet present = %v, want %v", got.Snippet != "", tt.wantSnippet)
+			}
+			if !tt.wantSnippet {
+				return
+			}
+			for _, want := range []string{"'GTM-ABC',10,", "gtm_preview=env-7", "localStorage"} {
+				if !strings.Contains(got.Snippet, want) {
+					t.Errorf("snippet missing %q:\n%s", want, got.Snippet)
+				}
+			}
+		})
+	}
+}
@@ -4,6 +4,9 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"math/rand/v2"
+	"strings"
+	"sync"
 	"time"
 
 	"google.golang.org/api/googleapi"
@@ -15,15 +18,67 @@ var (
 	ErrRateLimit      = errors.New("rate limit exceeded")
 	ErrPermission     = errors.New("insufficient permissions")
 	ErrInvalidRequest = errors.New("invalid request")
+	ErrReadOnly       = errors.New("read-only session")
+	ErrMissingScope   = errors.New("missing Google scope")
+	ErrServerError    = errors.New("Google backend error")
 )
 
-// retryWithBackoff executes fn with exponential backoff for rate limits.
-// Returns the result or final error after maxRetries attempts.
-func retryWithBackoff[T any](ctx context.Context, maxRetries int, fn func() (T, error)) (T, error) {
+// FieldError is an invalid input, naming the field at fault. It
+// matches ErrInvalidRequest.
+type FieldError struct {
+	Field string
+	Err   error
+}
+
+func (e *FieldError) Error() string   { return e.Err.Error() }
+func (e *FieldError) Unwrap() []error { return []error{e.Err, ErrInvalidRequest} }
+
+// InvalidField returns a FieldError for an input field.
+func InvalidField(field, format string, args ...any) error {
+	return &FieldError{Field: field, Err: fmt.Errorf(format, args...)}
+}
+
+// RetryPolicy controls how GTM API calls are retried after rate limit and
+// transient server errors.
+type RetryPolicy struct {
+	MaxRetries int           // retries after the first attempt
+	BaseDelay  time.Duration // backoff ceiling of the first retry, doubled per retry
+	MaxDelay   time.Duration // longest wait before a retry
+}
+
+// DefaultRetryPolicy retries three times within 1s, 2s and 4s.
+var DefaultRetryPolicy = RetryPolicy{MaxRetries: 3, BaseDelay: time.Second, MaxDelay: 32 * time.Second}
+
+var (
+	retryMu     sync.Mutex
+	retryPolicy = DefaultRetryPolicy
+)
+
+// SetRetryPolicy sets how GTM API calls are retried.
+func SetRetryPolicy(p RetryPolicy) {
+	retryMu.Lock()
+	defer retryMu.Unlock()
+	retryPolicy = p
+}
+
+// RetryWithBackoff executes fn, retrying rate limit and transient server
+// errors under the configured RetryPolicy.
+func RetryWithBackoff[T any](ctx context.Context, fn func() (T, error)) (T, error) {
+	retryMu.Lock()
+	p := retryPolicy
+	retryMu.Unlock()
+	return retryWithPolicy(ctx, p, fn)
+}
+
+// retryWithPolicy executes fn with exponential backoff and full jitter for
+// rate limits and 5xx responses: each retry waits a random time up to BaseDelay doubled per
+// attempt, or as long as a Retry-After header asks. Returns the result or
+// final error after MaxRetries retries.
+func retryWithPolicy[T any](ctx context.Context, p RetryPolicy, fn func() (T, error)) (T, error) {
 	var zero T
 	var lastErr error
 
-	for attempt := 0; attempt <= maxRetries; attempt++ {
+	for attempt := 0; attempt <= p.MaxRetries; attempt++ {
 		// Check context before executing
 		select {
 		case <-ctx.Done():
@@ -36,54 +91,144 @@ func retryWithBackoff[T any](ctx context.Context, maxRetries int, fn func() (T,
 			return result, nil
 		}
 
-		// Check if it's a rate limit error
 		var apiErr *googleapi.Error
-		if errors.As(err, &apiErr) {
-			if apiErr.Code == 403 || apiErr.Code == 429 {
-				if attempt < maxRetries {
-					waitTime := time.Duration(1<<uint(attempt)) * time.Second
-					if waitTime > 32*time.Second {
-						waitTime = 32 * time.Second
-					}
-
-					select {
-					case <-time.After(waitTime):
-						lastErr = err
-						continue
-					case <-ctx.Done():
-						return zero, ctx.Err()
-					}
-				}
+		if !errors.As(err, &apiErr) || !isRetryable(apiErr) {
+			return zero, err
+		}
+		if attempt == p.MaxRetries {
+			return zero, &retriedError{error: err, retries: attempt}
+		}
+
+		waitTime := backoffDelay(p, attempt)
+		if after := retryAfter(apiErr.Header); after > 0 {
+			// Retrying sooner than Google asks would fail again
+			if after > p.MaxDelay {
+				return zero, err
 			}
+			waitTime = after
 		}
 
-		return zero, err
+		select {
+		case <-time.After(waitTime):
+			lastErr = err
+		case <-ctx.Done():
+			return zero, ctx.Err()
+		}
 	}
 
 	return zero, fmt.Errorf("max retries exceeded: %w", lastErr)
 }
 
-// mapGoogleError converts Google API errors to our error types.
-func mapGoogleError(err error) error {
+// backoffDelay returns a random delay up to BaseDelay * 2^attempt, capped
+// at MaxDelay.
+func backoffDelay(p RetryPolicy, attempt int) time.Duration {
+	ceiling := p.MaxDelay
+	if attempt < 32 && p.BaseDelay<<attempt > 0 && p.BaseDelay<<attempt < ceiling {
+		ceiling = p.BaseDelay << attempt
+	}
+	if ceiling <= 0 {
+		return 0
+	}
+	return rand.N(ceiling + 1)
+}
+
+// isRetryable reports whether an API error may succeed on a later attempt.
+func isRetryable(apiErr *googleapi.Error) bool {
+	return isRateLimitError(apiErr) || isServerError(apiErr)
+}
+
+// isServerError reports whether an API error is a transient backend failure.
+func isServerError(apiErr *googleapi.Error) bool {
+	switch apiErr.Code {
+	case 500, 502, 503, 504:
+		return true
+	}
+	return false
+}
+
+// retriedError is the last error of a call that was retried before giving up.
+type retriedError struct {
+	error
+	retries int
+}
+
+func (e *retriedError) Unwrap() error { return e.error }
+
+// isRateLimitError reports whether an API error is worth retrying after a
+// delay: 429s and 403s whose reason is a rate limit, not a permission.
+func isRateLimitError(apiErr *googleapi.Error) bool {
+	switch apiErr.Code {
+	case 429:
+		return true
+	case 403:
+		for _, item := range apiErr.Errors {
+			if item.Reason == "rateLimitExceeded" || item.Reason == "userRateLimitExceeded" {
+				return true
+			}
+		}
+		return strings.Contains(apiErr.Body, "RATE_LIMIT_EXCEEDED")
+	}
+	return false
+}
+
+// MapGoogleError converts Google API errors to our error types. The
+// original *googleapi.Error stays in the chain for its reason and status.
+func MapGoogleError(err error) error {
 	if err == nil {
 		return nil
 	}
 
 	var apiErr *googleapi.Error
 	if errors.As(err, &apiErr) {
+		var mapped error
 		switch apiErr.Code {
 		case 404:
-			return fmt.Errorf("%w: %s", ErrNotFound, apiErr.Message)
+			mapped = fmt.Errorf("%w: %s", ErrNotFound, apiErr.Message)
 		case 409:
-			return fmt.Errorf("%w: %s", ErrConflict, apiErr.Message)
+			mapped = fmt.Errorf("%w: %s", ErrConflict, apiErr.Message)
 		case 403:
-			return fmt.Errorf("%w: %s", ErrPermission, apiErr.Message)
+			if isRateLimitError(apiErr) {
+				mapped = fmt.Errorf("%w: %s", ErrRateLimit, apiErr.Message)
+			} else if isScopeError(apiErr) {
+				mapped = fmt.Errorf("%w: the Google token was granted without the scope this operation needs (%s). Sign in again and allow every requested permission, or ask the server administrator to add the scope to GOOGLE_SCOPES", ErrMissingScope, apiErr.Message)
+			} else {
+				mapped = fmt.Errorf("%w: %s", ErrPermission, apiErr.Message)
+			}
 		case 429:
-			return fmt.Errorf("%w: %s", ErrRateLimit, apiErr.Message)
+			if after := retryAfter(apiErr.Header); after > 0 {
+				mapped = fmt.Errorf("%w: %s (Google asked to retry after %s)", ErrRateLimit, apiErr.Message, after)
+			} else {
+				mapped = fmt.Errorf("%w: %s", ErrRateLimit, apiErr.Message)
+			}
 		case 400:
-			return fmt.Errorf("%w: %s", ErrInvalidRequest, apiErr.Message)
+			mapped = fmt.Errorf("%w: %s", ErrInvalidRequest, apiErr.Message)
+		case 500, 502, 503, 504:
+			mapped = fmt.Errorf("%w (HTTP %d): %s", ErrServerError, apiErr.Code, apiErr.Message)
+			var retried *retriedError
+			if errors.As(err, &retried) && retried.retries > 0 {
+				mapped = fmt.Errorf("%w, retried %d times", mapped, retried.retries)
+			}
+		}
+		if mapped != nil {
+			return &googleError{error: mapped, api: apiErr}
 		}
 	}
 
 	return err
 }
+
+// googleError is a mapped error that keeps the Google API error behind it.
+type googleError struct {
+	error
+	api *googleapi.Error
+}
+
+func (e *googleError) Unwrap() []error { return []error{e.error, e.api} }
+
+// isScopeError reports whether a 403 was caused by the OAuth token lacking a
+// scope rather than by the user's GTM permissions.
+func isScopeError(apiErr *googleapi.Error) bool {
+	return apiErr.Code == 403 &&
+		(strings.Contains(apiErr.Message, "insufficient authentication scopes") ||
+			strings.Contains(apiErr.Body, "ACCESS_TOKEN_SCOPE_INSUFFICIENT"))
+}
@@ -3,6 +3,7 @@ package gtm
 import (
 	"context"
 	"errors"
+	"net/http"
 	"testing"
 	"time"
 
@@ -10,7 +11,7 @@ import (
 )
 
 func TestMapGoogleError_NilError(t *testing.T) {
-	err := mapGoogleError(nil)
+	err := MapGoogleError(nil)
 	if err != nil {
 		t.Errorf("expected nil error, got %v", err)
 	}
@@ -22,7 +23,7 @@ func TestMapGoogleError_NotFound(t *testing.T) {
 		Message: "Resource not found",
 	}
 
-	err := mapGoogleError(apiErr)
+	err := MapGoogleError(apiErr)
 	if err == nil {
 		t.Fatal("expected error, got nil")
 	}
@@ -43,7 +44,7 @@ func TestMapGoogleError_Conflict(t *testing.T) {
 		Message: "Fingerprint mismatch",
 	}
 
-	err := mapGoogleError(apiErr)
+	err := MapGoogleError(apiErr)
 	if err == nil {
 		t.Fatal("expected error, got nil")
 	}
@@ -64,7 +65,7 @@ func TestMapGoogleError_Permission(t *testing.T) {
 		Message: "Permission denied",
 	}
 
-	err := mapGoogleError(apiErr)
+	err := MapGoogleError(apiErr)
 	if err == nil {
 		t.Fatal("expected error, got nil")
 	}
@@ -85,7 +86,7 @@ func TestMapGoogleError_RateLimit(t *testing.T) {
 		Message: "Rate limit exceeded",
 	}
 
-	err := mapGoogleError(apiErr)
+	err := MapGoogleError(apiErr)
 	if err == nil {
 		t.Fatal("expected error, got nil")
 	}
@@ -106,7 +107,7 @@ func TestMapGoogleError_InvalidRequest(t *testing.T) {
 		Message: "Invalid request parameters",
 	}
 
-	err := mapGoogleError(apiErr)
+	err := MapGoogleError(apiErr)
 	if err == nil {
 		t.Fatal("expected error, got nil")
 	}
@@ -127,7 +128,7 @@ func TestMapGoogleError_UnknownStatusCode(t *testing.T) {
 		Message: "Internal server error",
 	}
 
-	err := mapGoogleError(apiErr)
+	err := MapGoogleError(apiErr)
 	if err == nil {
 		t.Fatal("expected error, got nil")
 	}
@@ -141,7 +142,7 @@ func TestMapGoogleError_UnknownStatusCode(t *testing.T) {
 func TestMapGoogleError_NonGoogleAPIError(t *testing.T) {
 	originalErr := errors.New("some other error")
 
-	err := mapGoogleError(originalErr)
+	err := MapGoogleError(originalErr)
 	if err == nil {
 		t.Fatal("expected error, got nil")
 	}
@@ -197,7 +198,7 @@ func TestMapGoogleError_AllStatusCodes(t *testing.T) {
 				Message: tt.message,
 			}
 
-			err := mapGoogleError(apiErr)
+			err := MapGoogleError(apiErr)
 			if err == nil {
 				t.Fatal("expected error, got nil")
 			}
@@ -214,11 +215,24 @@ func TestMapGoogleError_AllStatusCodes(t *testing.T) {
 	}
 }
 
+func TestMapGoogleError_RetryAfter(t *testing.T) {
+	apiErr := &googleapi.Error{
+		Code:    429,
+		Message: "Quota exceeded",
+		Header:  http.Header{"Retry-After": {"20"}},
+	}
+
+	err := MapGoogleError(apiErr)
+	if !errors.Is(err, ErrRateLimit) || !contains(err.Error(), "retry after 20s") {
+		t.Errorf("expected rate limit error with retry hint, got %v", err)
+	}
+}
+
 func TestRetryWithBackoff_SuccessFirstTry(t *testing.T) {
 	ctx := context.Background()
 	callCount := 0
 
-	result, err := retryWithBackoff(ctx, 3, func() (string, error) {
+	result, err := retryWithPolicy(ctx, fastRetries(3), func() (string, error) {
 		callCount++
 		return "success", nil
 	})
@@ -245,7 +259,7 @@ func TestRetryWithBackoff_NonRetryableError(t *testing.T) {
 		Message: "Bad request",
 	}
 
-	result, err := retryWithBackoff(ctx, 3, func() (string, error) {
+	result, err := retryWithPolicy(ctx, fastRetries(3), func() (string, error) {
 		callCount++
 		return "", nonRetryableErr
 	})
@@ -275,10 +289,11 @@ func TestRetryWithBackoff_RateLimitError403(t *testing.T) {
 	rateLimitErr := &googleapi.Error{
 		Code:    403,
 		Message: "Rate limit exceeded",
+		Errors:  []googleapi.ErrorItem{{Reason: "rateLimitExceeded"}},
 	}
 
 	// Fail twice with rate limit, then succeed
-	result, err := retryWithBackoff(ctx, 3, func() (string, error) {
+	result, err := retryWithPolicy(ctx, fastRetries(3), func() (string, error) {
 		callCount++
 		if callCount <= 2 {
 			return "", rateLimitErr
@@ -310,7 +325,7 @@ func TestRetryWithBackoff_RateLimitError429(t *testing.T) {
 	}
 
 	// Fail once with rate limit, then succeed
-	result, err := retryWithBackoff(ctx, 3, func() (string, error) {
+	result, err := retryWithPolicy(ctx, fastRetries(3), func() (string, error) {
 		callCount++
 		if callCount == 1 {
 			return "", rateLimitErr
@@ -341,7 +356,7 @@ func TestRetryWithBackoff_MaxRetriesExceeded(t *testing.T) {
 	}
 
 	// Always fail with rate limit error
-	result, err := retryWithBackoff(ctx, 2, func() (string, error) {
+	result, err := retryWithPolicy(ctx, fastRetries(2), func() (string, error) {
 		callCount++
 		return "", rateLimitErr
 	})
@@ -380,7 +395,7 @@ func TestRetryWithBackoff_ContextCancellation(t *testing.T) {
 		cancel()
 	}()
 
-	result, err := retryWithBackoff(ctx, 5, func() (string, error) {
+	result, err := retryWithPolicy(ctx, fastRetries(5), func() (string, error) {
 		callCount++
 		if callCount == 1 {
 			// First call fails, which should trigger a retry attempt
@@ -416,7 +431,7 @@ func TestRetryWithBackoff_ContextCancelledBeforeCall(t *testing.T) {
 
 	callCount := 0
 
-	result, err := retryWithBackoff(ctx, 3, func() (string, error) {
+	result, err := retryWithPolicy(ctx, fastRetries(3), func() (string, error) {
 		callCount++
 		return "should not reach here", nil
 	})
@@ -448,7 +463,8 @@ func TestRetryWithBackoff_ExponentialBackoff(t *testing.T) {
 	}
 
 	// Always fail to test backoff timing
-	_, _ = retryWithBackoff(ctx, 3, func() (string, error) {
+	policy := RetryPolicy{MaxRetries: 3, BaseDelay: 50 * time.Millisecond, MaxDelay: time.Second}
+	_, _ = retryWithPolicy(ctx, policy, func() (string, error) {
 		callTimes = append(callTimes, time.Now())
 		return "", rateLimitErr
 	})
@@ -457,40 +473,53 @@ func TestRetryWithBackoff_ExponentialBackoff(t *testing.T) {
 		t.Fatalf("expected 4 calls, got %d", len(callTimes))
 	}
 
-	// Check exponential backoff (1s, 2s, 4s)
-	// Allow some tolerance for timing
-	tolerance := 100 * time.Millisecond
+	// Full jitter: each retry waits at most 50ms, 100ms and 200ms
+	tolerance := 50 * time.Millisecond
+	for i, ceiling := range []time.Duration{50 * time.Millisecond, 100 * time.Millisecond, 200 * time.Millisecond} {
+		if wait := callTimes[i+1].Sub(callTimes[i]); wait > ceiling+tolerance {
+			t.Errorf("retry %d waited %v, want at most %v", i+1, wait, ceiling)
+		}
+	}
+}
 
-	// First retry should be ~1s after initial call
-	firstBackoff := callTimes[1].Sub(callTimes[0])
-	if firstBackoff < 1*time.Second-tolerance || firstBackoff > 1*time.Second+tolerance {
-		t.Errorf("expected first backoff ~1s, got %v", firstBackoff)
+func TestBackoffDelay(t *testing.T) {
+	p := RetryPolicy{BaseDelay: time.Second, MaxDelay: 32 * time.Second}
+	for attempt := 0; attempt <= 40; attempt++ {
+		ceiling := min(time.Duration(1<<min(attempt, 10))*time.Second, 32*time.Second)
+		for i := 0; i < 20; i++ {
+			if d := backoffDelay(p, attempt); d < 0 || d > ceiling {
+				t.Fatalf("attempt %d: delay %v outside [0, %v]", attempt, d, ceiling)
+			}
+		}
 	}
+}
 
-	// Second retry should be ~2s after first retry
-	secondBackoff := callTimes[2].Sub(callTimes[1])
-	if secondBackoff < 2*time.Second-tolerance || secondBackoff > 2*time.Second+tolerance {
-		t.Errorf("expected second backoff ~2s, got %v", secondBackoff)
+func TestRetryWithBackoff_PermissionDenied403NotRetried(t *testing.T) {
+	callCount := 0
+	_, err := retryWithPolicy(context.Background(), fastRetries(3), func() (string, error) {
+		callCount++
+		return "", &googleapi.Error{Code: 403, Message: "The caller does not have permission", Errors: []googleapi.ErrorItem{{Reason: "forbidden"}}}
+	})
+	if callCount != 1 || !errors.Is(MapGoogleError(err), ErrPermission) {
+		t.Errorf("got %d calls, error %v", callCount, err)
 	}
+}
 
-	// Third retry should be ~4s after second retry
-	thirdBackoff := callTimes[3].Sub(callTimes[2])
-	if thirdBackoff < 4*time.Second-tolerance || thirdBackoff > 4*time.Second+tolerance {
-		t.Errorf("expected third backoff ~4s, got %v", thirdBackoff)
+func TestRetryWithBackoff_RetryAfterBeyondMaxDelay(t *testing.T) {
+	callCount := 0
+	_, err := retryWithPolicy(context.Background(), fastRetries(3), func() (string, error) {
+		callCount++
+		return "", &googleapi.Error{Code: 429, Header: http.Header{"Retry-After": {"120"}}}
+	})
+	if callCount != 1 || err == nil {
+		t.Errorf("expected no retry when Retry-After exceeds MaxDelay, got %d calls", callCount)
 	}
 }
 
-func TestRetryWithBackoff_MaxBackoffCap(t *testing.T) {
-	// Verify the backoff cap logic: 2^attempt should be capped at 32 seconds.
-	// Instead of waiting 63+ seconds, we verify the calculation directly.
-	for attempt := uint(0); attempt <= 10; attempt++ {
-		waitTime := time.Duration(1<<attempt) * time.Second
-		if waitTime > 32*time.Second {
-			waitTime = 32 * time.Second
-		}
-		if attempt >= 5 && waitTime != 32*time.Second {
-			t.Errorf("attempt %d: expected cap at 32s, got %v", attempt, waitTime)
-		}
+func TestMapGoogleError_RateLimit403(t *testing.T) {
+	err := MapGoogleError(&googleapi.Error{Code: 403, Message: "Quota exceeded", Errors: []googleapi.ErrorItem{{Reason: "userRateLimitExceeded"}}})
+	if !errors.Is(err, ErrRateLimit) {
+		t.Errorf("expected ErrRateLimit, got %v", err)
 	}
 }
 
@@ -499,7 +528,7 @@ func TestRetryWithBackoff_IntegerResult(t *testing.T) {
 	ctx := context.Background()
 	callCount := 0
 
-	result, err := retryWithBackoff(ctx, 2, func() (int, error) {
+	result, err := retryWithPolicy(ctx, fastRetries(2), func() (int, error) {
 		callCount++
 		if callCount == 1 {
 			return 0, &googleapi.Error{Code: 429}
@@ -524,7 +553,7 @@ func TestRetryWithBackoff_StructResult(t *testing.T) {
 
 	ctx := context.Background()
 
-	result, err := retryWithBackoff(ctx, 2, func() (*Result, error) {
+	result, err := retryWithPolicy(ctx, fastRetries(2), func() (*Result, error) {
 		return &Result{Value: "test"}, nil
 	})
 
@@ -549,7 +578,7 @@ func TestRetryWithBackoff_ContextCancelledDuringWait(t *testing.T) {
 	}
 
 	start := time.Now()
-	result, err := retryWithBackoff(ctx, 5, func() (string, error) {
+	result, err := retryWithPolicy(ctx, RetryPolicy{MaxRetries: 5, BaseDelay: 10 * time.Second, MaxDelay: 32 * time.Second}, func() (string, error) {
 		callCount++
 		return "", rateLimitErr
 	})
@@ -568,7 +597,7 @@ func TestRetryWithBackoff_ContextCancelledDuringWait(t *testing.T) {
 	}
 
 	// Should have been cancelled before completing all retries
-	// First call happens immediately, second after 1s, but timeout is 500ms
+	// First call happens immediately, the retries wait up to 10s, 20s, ...
 	// so we should only get 1-2 calls
 	if callCount > 2 {
 		t.Errorf("expected at most 2 calls before timeout, got %d", callCount)
@@ -580,6 +609,11 @@ func TestRetryWithBackoff_ContextCancelledDuringWait(t *testing.T) {
 	}
 }
 
+// fastRetries returns a retry policy with short delays for tests.
+func fastRetries(maxRetries int) RetryPolicy {
+	return RetryPolicy{MaxRetries: maxRetries, BaseDelay: 10 * time.Millisecond, MaxDelay: time.Second}
+}
+
 // Helper function
 func contains(s, substr string) bool {
 	return len(s) >= len(substr) && (s == substr || len(substr) == 0 ||
@@ -594,3 +628,43 @@ func findSubstring(s, substr string) bool {
 	}
 	return false
 }
+
+func TestMapGoogleError_MissingScope(t *testing.T) {
+	apiErr := &googleapi.Error{
+		Code:    403,
+		Message: "Request had insufficient authentication scopes.",
+	}
+
+	err := MapGoogleError(apiErr)
+	if !errors.Is(err, ErrMissingScope) {
+		t.Errorf("expected ErrMissingScope, got %v", err)
+	}
+}
+
+func TestRetryWithBackoff_ServerError(t *testing.T) {
+	callCount := 0
+	result, err := retryWithPolicy(context.Background(), fastRetries(3), func() (string, error) {
+		callCount++
+		if callCount == 1 {
+			return "", &googleapi.Error{Code: 503, Message: "Backend unavailable"}
+		}
+		return "ok", nil
+	})
+	if err != nil || result != "ok" || callCount != 2 {
+		t.Errorf("got %q, %v after %d calls", result, err, callCount)
+	}
+}
+
+func TestMapGoogleError_ServerErrorRetried(t *testing.T) {
+	_, err := retryWithPolicy(context.Background(), fastRetries(2), func() (string, error) {
+		return "", &googleapi.Error{Code: 500, Message: "Internal error"}
+	})
+	err = MapGoogleError(err)
+
+	if !errors.Is(err, ErrServerError) {
+		t.Fatalf("expected ErrServerError, got %v", err)
+	}
+	if want := "Google backend error (HTTP 500): Internal error, retried 2 times"; err.Error() != want {
+		t.Errorf("message = %q, want %q", err.Error(), want)
+	}
+}
@@ -0,0 +1,232 @@
+package gtm
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"golang.org/x/sync/errgroup"
+	tagmanager "google.golang.org/api/tagmanager/v2"
+)
+
+// exportFormatVersion is the format version written by the GTM UI "Export Container" feature.
+const exportFormatVersion = 2
+
+// ContainerExport is a container snapshot in the GTM UI export format. It can
+// be imported through the GTM UI or the import_container tool.
+type ContainerExport struct {
+	ExportFormatVersion int                          `json:"exportFormatVersion"`
+	ExportTime          string                       `json:"exportTime"`
+	ContainerVersion    *tagmanager.ContainerVersion `json:"containerVersion"`
+}
+
+// ExportCounts summarizes the number of entities in an export.
+type ExportCounts struct {
+	Tags             int `json:"tags"`
+	Triggers         int `json:"triggers"`
+	Variables        int `json:"variables"`
+	BuiltInVariables int `json:"builtInVariables"`
+	Folders          int `json:"folders"`
+	Templates        int `json:"templates"`
+	Clients          int `json:"clients,omitempty"`
+	Transformations  int `json:"transformations,omitempty"`
+	Zones            int `json:"zones,omitempty"`
+}
+
+// Counts returns the number of entities of each type in the export.
+func (e *ContainerExport) Counts() ExportCounts {
+	v := e.ContainerVersion
+	if v == nil {
+		return ExportCounts{}
+	}
+	return ExportCounts{
+		Tags:             len(v.Tag),
+		Triggers:         len(v.Trigger),
+		Variables:        len(v.Variable),
+		BuiltInVariables: len(v.BuiltInVariable),
+		Folders:          len(v.Folder),
+		Templates:        len(v.CustomTemplate),
+		Clients:          len(v.Client),
+		Transformations:  len(v.Transformation),
+		Zones:            len(v.Zone),
+	}
+}
+
+func NewContainerExport(version *tagmanager.ContainerVersion) *ContainerExport {
+	return &ContainerExport{
+		ExportFormatVersion: exportFormatVersion,
+		ExportTime:          time.Now().UTC().Format("2006-01-02 15:04:05"),
+		ContainerVersion:    version,
+	}
+}
+
+// ExportVersion exports a container version. versionID "live" exports the published version.
+func (c *Client) ExportVersion(ctx context.Context, accountID, containerID, versionID string) (*ContainerExport, error) {
+	version, err := c.GetVersion(ctx, accountID, containerID, versionID)
+	if err != nil {
+		return nil, err
+	}
+	return NewContainerExport(version), nil
+}
+
+// GetVersion returns a full container version. versionID "live" returns the published version.
+func (c *Client) GetVersion(ctx context.Context, accountID, containerID, versionID string) (*tagmanager.ContainerVersion, error) {
+	version, err := RetryWithBackoff(ctx, func() (*tagmanager.ContainerVersion, error) {
+		if versionID == "live" {
+			return c.Service.Accounts.Containers.Versions.Live(BuildContainerPath(accountID, containerID)).Context(ctx).Do()
+		}
+		path := fmt.Sprintf("accounts/%s/containers/%s/versions/%s", accountID, containerID, versionID)
+		return c.Service.Accounts.Containers.Versions.Get(path).Context(ctx).Do()
+	})
+	if err != nil {
+		return nil, MapGoogleError(err)
+	}
+	return version, nil
+}
+
+// ExportWorkspace exports the current state of a workspace, including
+// unpublished changes.
+func (c *Client) ExportWorkspace(ctx context.Context, accountID, containerID, workspaceID string) (*ContainerExport, error) {
+	version, err := c.SnapshotWorkspace(ctx, accountID, containerID, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+	return NewContainerExport(version), nil
+}
+
+// SnapshotWorkspace reads every entity in a workspace into a ContainerVersion
+// structure without creating a version.
+func (c *Client) SnapshotWorkspace(ctx context.Context, accountID, containerID, workspaceID string) (*tagmanager.ContainerVersion, error) {
+	containerPath := BuildContainerPath(accountID, containerID)
+	parent := BuildWorkspacePath(accountID, containerID, workspaceID)
+	ws := c.Service.Accounts.Containers.Workspaces
+
+	container, err := RetryWithBackoff(ctx, func() (*tagmanager.Container, error) {
+		return c.Service.Accounts.Containers.Get(containerPath).Context(ctx).Do()
+	})
+	if err != nil {
+		return nil, MapGoogleError(err)
+	}
+
+	version := &tagmanager.ContainerVersion{
+		AccountId:          accountID,
+		ContainerId:        containerID,
+		ContainerVersionId: "0",
+		Container:          container,
+		Path:               containerPath + "/versions/0",
+	}
+
+	// The entity lists are independent, so fetch them concurrently; the
+	// first failure cancels the rest
+	g, ctx := errgroup.WithContext(ctx)
+	fetch := func(kind string, f func() error) {
+		g.Go(func() error {
+			if err := f(); err != nil {
+				return err
+			}
+			StepProgress(ctx, "Read %s", kind)
+			return nil
+		})
+	}
+	// Six entity lists plus the server-only or web-only ones
+	if slices.Contains(container.UsageContext, "server") {
+		ExpectProgress(ctx, 8)
+	} else {
+		ExpectProgress(ctx, 7)
+	}
+	fetch("tags", func() error {
+		tags, err := listAllTags(ctx, ws, parent)
+		if err != nil {
+			return MapGoogleError(err)
+		}
+		version.Tag = tags
+		return nil
+	})
+	fetch("triggers", func() error {
+		triggers, err := listAllTriggers(ctx, ws, parent)
+		if err != nil {
+			return MapGoogleError(err)
+		}
+		version.Trigger = triggers
+		return nil
+	})
+	fetch("variables", func() error {
+		variables, err := listAllVariables(ctx, ws, parent)
+		if err != nil {
+			return MapGoogleError(err)
+		}
+		version.Variable = variables
+		return nil
+	})
+	fetch("built-in variables", func() error {
+		resp, err := RetryWithBackoff(ctx, func() (*tagmanager.ListEnabledBuiltInVariablesResponse, error) {
+			return ws.BuiltInVariables.List(parent).Context(ctx).Do()
+		})
+		if err != nil {
+			return MapGoogleError(err)
+		}
+		version.BuiltInVariable = resp.BuiltInVariable
+		return nil
+	})
+	fetch("folders", func() error {
+		resp, err := RetryWithBackoff(ctx, func() (*tagmanager.ListFoldersResponse, error) {
+			return ws.Folders.List(parent).Context(ctx).Do()
+		})
+		if err != nil {
+			return MapGoogleError(err)
+		}
+		version.Folder = resp.Folder
+		return nil
+	})
+	fetch("templates", func() error {
+		resp, err := RetryWithBackoff(ctx, func() (*tagmanager.ListTemplatesResponse, error) {
+			return ws.Templates.List(parent).Context(ctx).Do()
+		})
+		if err != nil {
+			return MapGoogleError(err)
+		}
+		version.CustomTemplate = resp.Template
+		return nil
+	})
+
+	// Clients and transformations only exist in server containers, zones only in web containers.
+	if slices.Contains(container.UsageContext, "server") {
+		fetch("clients", func() error {
+			resp, err := RetryWithBackoff(ctx, func() (*tagmanager.ListClientsResponse, error) {
+				return ws.Clients.List(parent).Context(ctx).Do()
+			})
+			if err != nil {
+				return MapGoogleError(err)
+			}
+			version.Client = resp.Client
+			return nil
+		})
+		fetch("transformations", func() error {
+			resp, err := RetryWithBackoff(ctx, func() (*tagmanager.ListTransformationsResponse, error) {
+				return ws.Transformations.List(parent).Context(ctx).Do()
+			})
+			if err != nil {
+				return MapGoogleError(err)
+			}
+			version.Transformation = resp.Transformation
+			return nil
+		})
+	} else {
+		fetch("zones", func() error {
+			resp, err := RetryWithBackoff(ctx, func() (*tagmanager.ListZonesResponse, error) {
+				return ws.Zones.List(parent).Context(ctx).Do()
+			})
+			if err != nil {
+				return MapGoogleError(err)
+			}
+			version.Zone = resp.Zone
+			return nil
+		})
+	}
+
+	if err := g.Wait(); err != nil {
+		return nil, err
+	}
+	return version, nil
+}
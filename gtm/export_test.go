@@ -0,0 +1,42 @@
+package gtm
+
+import (
+	"encoding/json"
+	"testing"
+
+	tagmanager "google.golang.org/api/tagmanager/v2"
+)
+
+func TestContainerExport_Format(t *testing.T) {
+	export := NewContainerExport(&tagmanager.ContainerVersion{
+		AccountId:   "1",
+		ContainerId: "2",
+		Tag:         []*tagmanager.Tag{{TagId: "1", Name: "GA4"}},
+		Trigger:     []*tagmanager.Trigger{{TriggerId: "3"}, {TriggerId: "4"}},
+	})
+
+	counts := export.Counts()
+	if counts.Tags != 1 || counts.Triggers != 2 || counts.Variables != 0 {
+		t.Errorf("unexpected counts: %+v", counts)
+	}
+
+	data, err := json.Marshal(export)
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+
+	var decoded map[string]any
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	if decoded["exportFormatVersion"] != float64(2) {
+		t.Errorf("expected exportFormatVersion 2, got %v", decoded["exportFormatVersion"])
+	}
+	version, ok := decoded["containerVersion"].(map[string]any)
+	if !ok {
+		t.Fatal("expected containerVersion object")
+	}
+	if tags, _ := version["tag"].([]any); len(tags) != 1 {
+		t.Errorf("expected 1 tag in export, got %v", version["tag"])
+	}
+}
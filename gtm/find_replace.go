@@ -0,0 +1,206 @@
+package gtm
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	tagmanager "google.golang.org/api/tagmanager/v2"
+)
+
+// FindReplaceOptions configures a find-and-replace across workspace entities.
+type FindReplaceOptions struct {
+	Find    string
+	Replace string
+	// Regex treats Find as a regular expression; Replace may use $1 etc.
+	Regex bool
+	// EntityTypes limits the search (tag, trigger, variable). Empty means all three.
+	EntityTypes []string
+}
+
+// FindReplaceMatch is an entity whose parameter values contain the search text.
+type FindReplaceMatch struct {
+	EntityType string        `json:"entityType"`
+	EntityID   string        `json:"entityId"`
+	Name       string        `json:"name"`
+	Changes    []FieldChange `json:"changes"`
+	Error      string        `json:"error,omitempty"`
+
+	apply func(ctx context.Context) error
+}
+
+// replacer rewrites parameter values.
+type replacer struct {
+	re      *regexp.Regexp
+	find    string
+	replace string
+}
+
+func newReplacer(opts FindReplaceOptions) (*replacer, error) {
+	if opts.Find == "" {
+		return nil, fmt.Errorf("find is required")
+	}
+	r := &replacer{find: opts.Find, replace: opts.Replace}
+	if opts.Regex {
+		re, err := regexp.Compile(opts.Find)
+		if err != nil {
+			return nil, fmt.Errorf("invalid regex: %w", err)
+		}
+		r.re = re
+	}
+	return r, nil
+}
+
+func (r *replacer) apply(s string) string {
+	if r.re != nil {
+		return r.re.ReplaceAllString(s, r.replace)
+	}
+	return strings.ReplaceAll(s, r.find, r.replace)
+}
+
+// replaceParams rewrites values in a parameter tree in place and records each
+// change. Tag and trigger reference parameters are left untouched.
+func (r *replacer) replaceParams(field string, params []*tagmanager.Parameter, changes *[]FieldChange) {
+	for i, p := range params {
+		if p == nil {
+			continue
+		}
+		name := fmt.Sprintf("%s[%d]", field, i)
+		if p.Key != "" {
+			name = fmt.Sprintf("%s[%s]", field, p.Key)
+		}
+		if p.Value != "" && p.Type != "tagReference" && p.Type != "triggerReference" {
+			if replaced := r.apply(p.Value); replaced != p.Value {
+				*changes = append(*changes, FieldChange{Field: name + ".value", Before: p.Value, After: replaced})
+				p.Value = replaced
+			}
+		}
+		r.replaceParams(name+".list", p.List, changes)
+		r.replaceParams(name+".map", p.Map, changes)
+	}
+}
+
+func (r *replacer) replaceConditions(field string, conditions []*tagmanager.Condition, changes *[]FieldChange) {
+	for i, c := range conditions {
+		r.replaceParams(fmt.Sprintf("%s[%d].parameter", field, i), c.Parameter, changes)
+	}
+}
+
+// replaceTrigger rewrites trigger parameters and filter conditions.
+func (r *replacer) replaceTrigger(t *tagmanager.Trigger) []FieldChange {
+	var changes []FieldChange
+	r.replaceParams("parameter", t.Parameter, &changes)
+	r.replaceConditions("filter", t.Filter, &changes)
+	r.replaceConditions("autoEventFilter", t.AutoEventFilter, &changes)
+	r.replaceConditions("customEventFilter", t.CustomEventFilter, &changes)
+	if t.EventName != nil {
+		r.replaceParams("eventName", []*tagmanager.Parameter{t.EventName}, &changes)
+	}
+	return changes
+}
+
+// FindReplace searches the parameter values of tags, triggers and variables
+// in a workspace and returns the entities that would change. With apply set,
+// the changed entities are written back and per-entity errors are recorded.
+func (c *Client) FindReplace(ctx context.Context, accountID, containerID, workspaceID string, opts FindReplaceOptions, apply bool) ([]FindReplaceMatch, error) {
+	r, err := newReplacer(opts)
+	if err != nil {
+		return nil, err
+	}
+	kinds, err := EntityKinds(opts.EntityTypes)
+	if err != nil {
+		return nil, err
+	}
+
+	parent := BuildWorkspacePath(accountID, containerID, workspaceID)
+	ws := c.Service.Accounts.Containers.Workspaces
+	matches := []FindReplaceMatch{}
+
+	if kinds[EntityTypeTag] {
+		tags, err := listAllTags(ctx, ws, parent)
+		if err != nil {
+			return nil, MapGoogleError(err)
+		}
+		for _, t := range tags {
+			var changes []FieldChange
+			r.replaceParams("parameter", t.Parameter, &changes)
+			if len(changes) == 0 {
+				continue
+			}
+			matches = append(matches, FindReplaceMatch{EntityType: EntityTypeTag, EntityID: t.TagId, Name: t.Name, Changes: changes,
+				apply: func(ctx context.Context) error {
+					_, err := ws.Tags.Update(t.Path, t).Fingerprint(t.Fingerprint).Context(ctx).Do()
+					return err
+				}})
+		}
+	}
+
+	if kinds[EntityTypeTrigger] {
+		triggers, err := listAllTriggers(ctx, ws, parent)
+		if err != nil {
+			return nil, MapGoogleError(err)
+		}
+		for _, t := range triggers {
+			changes := r.replaceTrigger(t)
+			if len(changes) == 0 {
+				continue
+			}
+			matches = append(matches, FindReplaceMatch{EntityType: EntityTypeTrigger, EntityID: t.TriggerId, Name: t.Name, Changes: changes,
+				apply: func(ctx context.Context) error {
+					_, err := ws.Triggers.Update(t.Path, t).Fingerprint(t.Fingerprint).Context(ctx).Do()
+					return err
+				}})
+		}
+	}
+
+	if kinds[EntityTypeVariable] {
+		variables, err := listAllVariables(ctx, ws, parent)
+		if err != nil {
+			return nil, MapGoogleError(err)
+		}
+		for _, v := range variables {
+			var changes []FieldChange
+			r.replaceParams("parameter", v.Parameter, &changes)
+			if len(changes) == 0 {
+				continue
+			}
+			matches = append(matches, FindReplaceMatch{EntityType: EntityTypeVariable, EntityID: v.VariableId, Name: v.Name, Changes: changes,
+				apply: func(ctx context.Context) error {
+					_, err := ws.Variables.Update(v.Path, v).Fingerprint(v.Fingerprint).Context(ctx).Do()
+					return err
+				}})
+		}
+	}
+
+	if apply {
+		ExpectProgress(ctx, len(matches))
+		for i := range matches {
+			if err := matches[i].apply(ctx); err != nil {
+				matches[i].Error = MapGoogleError(err).Error()
+				StepProgress(ctx, "Failed to update %s %q", matches[i].EntityType, matches[i].Name)
+				continue
+			}
+			StepProgress(ctx, "Updated %s %q", matches[i].EntityType, matches[i].Name)
+		}
+		RecentMutations.Mark(ctx, parent)
+	}
+	return matches, nil
+}
+
+// EntityKinds validates a list of entity types; empty selects tags, triggers and variables.
+func EntityKinds(types []string) (map[string]bool, error) {
+	if len(types) == 0 {
+		types = []string{EntityTypeTag, EntityTypeTrigger, EntityTypeVariable}
+	}
+	kinds := make(map[string]bool)
+	for _, k := range types {
+		switch k {
+		case EntityTypeTag, EntityTypeTrigger, EntityTypeVariable:
+			kinds[k] = true
+		default:
+			return nil, fmt.Errorf("invalid entity type '%s' (valid values: tag, trigger, variable)", k)
+		}
+	}
+	return kinds, nil
+}
@@ -0,0 +1,101 @@
+package gtm
+
+import (
+	"reflect"
+	"testing"
+
+	tagmanager "google.golang.org/api/tagmanager/v2"
+)
+
+func TestReplacer_ReplaceParams(t *testing.T) {
+	tests := []struct {
+		name    string
+		opts    FindReplaceOptions
+		params  []*tagmanager.Parameter
+		want    []FieldChange
+		wantErr bool
+	}{
+		{
+			name: "literal replace in nested map",
+			opts: FindReplaceOptions{Find: "G-OLD", Replace: "G-NEW"},
+			params: []*tagmanager.Parameter{
+				{Type: "template", Key: "measurementIdOverride", Value: "G-OLD"},
+				{Type: "list", Key: "eventParameters", List: []*tagmanager.Parameter{
+					{Type: "map", Map: []*tagmanager.Parameter{
+						{Type: "template", Key: "name", Value: "id"},
+						{Type: "template", Key: "value", Value: "prefix-G-OLD"},
+					}},
+				}},
+			},
+			want: []FieldChange{
+				{Field: "parameter[measurementIdOverride].value", Before: "G-OLD", After: "G-NEW"},
+				{Field: "parameter[eventParameters].list[0].map[value].value", Before: "prefix-G-OLD", After: "prefix-G-NEW"},
+			},
+		},
+		{
+			name: "regex with capture group",
+			opts: FindReplaceOptions{Find: `UA-(\d+)-1`, Replace: "UA-$1-2", Regex: true},
+			params: []*tagmanager.Parameter{
+				{Type: "template", Key: "trackingId", Value: "UA-12345-1"},
+			},
+			want: []FieldChange{
+				{Field: "parameter[trackingId].value", Before: "UA-12345-1", After: "UA-12345-2"},
+			},
+		},
+		{
+			name: "references are not touched",
+			opts: FindReplaceOptions{Find: "10", Replace: "20"},
+			params: []*tagmanager.Parameter{
+				{Type: "list", Key: "triggerIds", List: []*tagmanager.Parameter{{Type: "triggerReference", Value: "10"}}},
+			},
+		},
+		{
+			name:    "empty find",
+			opts:    FindReplaceOptions{Replace: "x"},
+			wantErr: true,
+		},
+		{
+			name:    "invalid regex",
+			opts:    FindReplaceOptions{Find: "(", Regex: true},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, err := newReplacer(tt.opts)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("newReplacer() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			var got []FieldChange
+			r.replaceParams("parameter", tt.params, &got)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestReplacer_ReplaceTrigger(t *testing.T) {
+	r, err := newReplacer(FindReplaceOptions{Find: "/old-checkout", Replace: "/checkout"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	trigger := &tagmanager.Trigger{
+		Filter: []*tagmanager.Condition{{Type: "contains", Parameter: []*tagmanager.Parameter{
+			{Type: "template", Key: "arg0", Value: "{{Page URL}}"},
+			{Type: "template", Key: "arg1", Value: "/old-checkout"},
+		}}},
+	}
+
+	changes := r.replaceTrigger(trigger)
+	if len(changes) != 1 || changes[0].Field != "filter[0].parameter[arg1].value" {
+		t.Fatalf("unexpected changes: %+v", changes)
+	}
+	if got := trigger.Filter[0].Parameter[1].Value; got != "/checkout" {
+		t.Errorf("expected filter value to be replaced in place, got %q", got)
+	}
+}
@@ -26,11 +26,11 @@ type FolderEntities struct {
 func (c *Client) ListFolders(ctx context.Context, accountID, containerID, workspaceID string) ([]Folder, error) {
 	parent := fmt.Sprintf("accounts/%s/containers/%s/workspaces/%s", accountID, containerID, workspaceID)
 
-	resp, err := retryWithBackoff(ctx, 3, func() (*tagmanager.ListFoldersResponse, error) {
+	resp, err := RetryWithBackoff(ctx, func() (*tagmanager.ListFoldersResponse, error) {
 		return c.Service.Accounts.Containers.Workspaces.Folders.List(parent).Context(ctx).Do()
 	})
 	if err != nil {
-		return nil, mapGoogleError(err)
+		return nil, MapGoogleError(err)
 	}
 
 	return toFolders(resp.Folder), nil
@@ -41,11 +41,11 @@ func (c *Client) GetFolderEntities(ctx context.Context, accountID, containerID,
 	path := fmt.Sprintf("accounts/%s/containers/%s/workspaces/%s/folders/%s",
 		accountID, containerID, workspaceID, folderID)
 
-	resp, err := retryWithBackoff(ctx, 3, func() (*tagmanager.FolderEntities, error) {
+	resp, err := RetryWithBackoff(ctx, func() (*tagmanager.FolderEntities, error) {
 		return c.Service.Accounts.Containers.Workspaces.Folders.Entities(path).Context(ctx).Do()
 	})
 	if err != nil {
-		return nil, mapGoogleError(err)
+		return nil, MapGoogleError(err)
 	}
 
 	entities := &FolderEntities{}
@@ -0,0 +1,83 @@
+package gtm
+
+import (
+	"fmt"
+
+	tagmanager "google.golang.org/api/tagmanager/v2"
+)
+
+// Variable case conversion types.
+const (
+	CaseConversionNone      = "none"
+	CaseConversionLowercase = "lowercase"
+	CaseConversionUppercase = "uppercase"
+)
+
+// FormatValue is the output formatting of a variable: case conversion and
+// replacement values for null, undefined, true and false. Nil convert fields
+// leave that value unchanged; an empty string converts it to "".
+type FormatValue struct {
+	CaseConversionType      string  `json:"caseConversionType,omitempty" jsonschema:"description:Case conversion: none, lowercase or uppercase (optional)"`
+	ConvertNullToValue      *string `json:"convertNullToValue,omitempty" jsonschema:"description:Value to output instead of null (optional)"`
+	ConvertUndefinedToValue *string `json:"convertUndefinedToValue,omitempty" jsonschema:"description:Value to output instead of undefined, e.g. 0 (optional)"`
+	ConvertTrueToValue      *string `json:"convertTrueToValue,omitempty" jsonschema:"description:Value to output instead of true (optional)"`
+	ConvertFalseToValue     *string `json:"convertFalseToValue,omitempty" jsonschema:"description:Value to output instead of false (optional)"`
+}
+
+// ValidateFormatValue checks the case conversion type of a format value.
+func ValidateFormatValue(f *FormatValue) error {
+	if f == nil {
+		return nil
+	}
+	switch f.CaseConversionType {
+	case "", CaseConversionNone, CaseConversionLowercase, CaseConversionUppercase:
+		return nil
+	default:
+		return fmt.Errorf("invalid caseConversionType '%s' (valid values: none, lowercase, uppercase)", f.CaseConversionType)
+	}
+}
+
+func toAPIFormatValue(f *FormatValue) *tagmanager.VariableFormatValue {
+	if f == nil {
+		return nil
+	}
+	return &tagmanager.VariableFormatValue{
+		CaseConversionType:      f.CaseConversionType,
+		ConvertNullToValue:      formatValueParam(f.ConvertNullToValue),
+		ConvertUndefinedToValue: formatValueParam(f.ConvertUndefinedToValue),
+		ConvertTrueToValue:      formatValueParam(f.ConvertTrueToValue),
+		ConvertFalseToValue:     formatValueParam(f.ConvertFalseToValue),
+	}
+}
+
+func formatValueParam(value *string) *tagmanager.Parameter {
+	if value == nil {
+		return nil
+	}
+	return &tagmanager.Parameter{Type: ParamTemplate, Value: *value}
+}
+
+func toFormatValue(f *tagmanager.VariableFormatValue) *FormatValue {
+	if f == nil {
+		return nil
+	}
+	result := &FormatValue{
+		CaseConversionType:      f.CaseConversionType,
+		ConvertNullToValue:      formatValueString(f.ConvertNullToValue),
+		ConvertUndefinedToValue: formatValueString(f.ConvertUndefinedToValue),
+		ConvertTrueToValue:      formatValueString(f.ConvertTrueToValue),
+		ConvertFalseToValue:     formatValueString(f.ConvertFalseToValue),
+	}
+	if *result == (FormatValue{}) {
+		return nil
+	}
+	return result
+}
+
+func formatValueString(p *tagmanager.Parameter) *string {
+	if p == nil {
+		return nil
+	}
+	value := p.Value
+	return &value
+}
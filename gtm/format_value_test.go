@@ -0,0 +1,70 @@
+package gtm
+
+import (
+	"testing"
+
+	tagmanager "google.golang.org/api/tagmanager/v2"
+)
+
+func TestFormatValueRoundTrip(t *testing.T) {
+	zero, empty := "0", ""
+	in := &FormatValue{CaseConversionType: CaseConversionLowercase, ConvertUndefinedToValue: &zero, ConvertNullToValue: &empty}
+
+	api := toAPIFormatValue(in)
+	if api.ConvertUndefinedToValue == nil || api.ConvertUndefinedToValue.Type != ParamTemplate || api.ConvertUndefinedToValue.Value != "0" {
+		t.Errorf("convertUndefinedToValue = %+v, want template 0", api.ConvertUndefinedToValue)
+	}
+	if api.ConvertTrueToValue != nil || api.ConvertFalseToValue != nil {
+		t.Errorf("unset conversions should stay nil: %+v", api)
+	}
+
+	out := toFormatValue(api)
+	if out.CaseConversionType != CaseConversionLowercase || *out.ConvertUndefinedToValue != "0" || out.ConvertNullToValue == nil || *out.ConvertNullToValue != "" {
+		t.Errorf("round trip = %+v", out)
+	}
+	if toFormatValue(&tagmanager.VariableFormatValue{}) != nil {
+		t.Error("empty format value should read as nil")
+	}
+}
+
+func TestValidateFormatValue(t *testing.T) {
+	if err := ValidateFormatValue(nil); err != nil {
+		t.Errorf("nil: %v", err)
+	}
+	if err := ValidateFormatValue(&FormatValue{CaseConversionType: CaseConversionUppercase}); err != nil {
+		t.Errorf("uppercase: %v", err)
+	}
+	if err := ValidateFormatValue(&FormatValue{CaseConversionType: "titlecase"}); err == nil {
+		t.Error("expected error for invalid caseConversionType")
+	}
+}
+
+func TestApplyVariablePatch_FormatValue(t *testing.T) {
+	zero := "0"
+	v := &tagmanager.Variable{Name: "DLV - Value", Type: "v", FormatValue: &tagmanager.VariableFormatValue{CaseConversionType: CaseConversionUppercase}}
+
+	name := "DLV - Order Value"
+	if err := applyVariablePatch(v, &VariablePatch{Name: &name}); err != nil {
+		t.Fatal(err)
+	}
+	if v.FormatValue == nil || v.FormatValue.CaseConversionType != CaseConversionUppercase {
+		t.Errorf("format value dropped on rename: %+v", v.FormatValue)
+	}
+
+	if err := applyVariablePatch(v, &VariablePatch{FormatValue: &FormatValue{ConvertUndefinedToValue: &zero}}); err != nil {
+		t.Fatal(err)
+	}
+	if v.FormatValue.CaseConversionType != "" || v.FormatValue.ConvertUndefinedToValue.Value != "0" {
+		t.Errorf("format value not replaced: %+v", v.FormatValue)
+	}
+
+	if err := applyVariablePatch(v, &VariablePatch{FormatValue: &FormatValue{CaseConversionType: "bogus"}}); err == nil {
+		t.Error("expected error for invalid caseConversionType")
+	}
+	if err := applyVariablePatch(v, &VariablePatch{Clear: []string{VariableFieldFormatValue}}); err != nil {
+		t.Fatal(err)
+	}
+	if v.FormatValue != nil {
+		t.Errorf("format value not cleared: %+v", v.FormatValue)
+	}
+}
@@ -0,0 +1,130 @@
+package gtm
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	analyticsadmin "google.golang.org/api/analyticsadmin/v1beta"
+)
+
+// GA4Property is a Google Analytics 4 property the user can access.
+type GA4Property struct {
+	PropertyID   string `json:"propertyId"`
+	DisplayName  string `json:"displayName"`
+	PropertyType string `json:"propertyType,omitempty"` // ordinary, subproperty or rollup
+	AccountID    string `json:"accountId"`
+	AccountName  string `json:"accountName"`
+}
+
+// GA4DataStream is a data stream of a GA4 property. Web streams carry the
+// measurement ID that GA4 tags send to.
+type GA4DataStream struct {
+	StreamID      string `json:"streamId"`
+	DisplayName   string `json:"displayName"`
+	Type          string `json:"type"` // web, android or ios
+	MeasurementID string `json:"measurementId,omitempty"`
+	DefaultURI    string `json:"defaultUri,omitempty"`
+}
+
+// ListGA4Properties returns the GA4 properties the user can access. A
+// non-empty query keeps the properties whose name or account name contains
+// it, ignoring case.
+func (c *Client) ListGA4Properties(ctx context.Context, query string) ([]GA4Property, error) {
+	summaries, err := listAllPages(ctx, func(pageToken string) (*analyticsadmin.GoogleAnalyticsAdminV1betaListAccountSummariesResponse, error) {
+		return c.Analytics.AccountSummaries.List().PageSize(200).PageToken(pageToken).Context(ctx).Do()
+	}, func(resp *analyticsadmin.GoogleAnalyticsAdminV1betaListAccountSummariesResponse) ([]*analyticsadmin.GoogleAnalyticsAdminV1betaAccountSummary, string) {
+		return resp.AccountSummaries, resp.NextPageToken
+	})
+	if err != nil {
+		return nil, MapGoogleError(err)
+	}
+
+	query = strings.ToLower(strings.TrimSpace(query))
+	properties := []GA4Property{}
+	for _, a := range summaries {
+		for _, p := range a.PropertySummaries {
+			if query != "" && !strings.Contains(strings.ToLower(p.DisplayName), query) && !strings.Contains(strings.ToLower(a.DisplayName), query) {
+				continue
+			}
+			properties = append(properties, GA4Property{
+				PropertyID:   strings.TrimPrefix(p.Property, "properties/"),
+				DisplayName:  p.DisplayName,
+				PropertyType: strings.ToLower(strings.TrimPrefix(p.PropertyType, "PROPERTY_TYPE_")),
+				AccountID:    strings.TrimPrefix(a.Account, "accounts/"),
+				AccountName:  a.DisplayName,
+			})
+		}
+	}
+	return properties, nil
+}
+
+// ResolveGA4PropertyID returns the ID of the property with the given name.
+// An exact match wins; otherwise a single case-insensitive match is accepted.
+func (c *Client) ResolveGA4PropertyID(ctx context.Context, name string) (string, error) {
+	properties, err := c.ListGA4Properties(ctx, "")
+	if err != nil {
+		return "", err
+	}
+	var exact, folded []GA4Property
+	for _, p := range properties {
+		switch {
+		case p.DisplayName == name:
+			exact = append(exact, p)
+		case strings.EqualFold(strings.TrimSpace(p.DisplayName), strings.TrimSpace(name)):
+			folded = append(folded, p)
+		}
+	}
+	matches := exact
+	if len(matches) == 0 {
+		matches = folded
+	}
+	switch len(matches) {
+	case 0:
+		return "", fmt.Errorf("%w: no GA4 property named %q is accessible; list_ga4_properties shows the available ones", ErrNotFound, name)
+	case 1:
+		return matches[0].PropertyID, nil
+	}
+	ids := make([]string, len(matches))
+	for i, m := range matches {
+		ids[i] = fmt.Sprintf("%s (account %s)", m.PropertyID, m.AccountName)
+	}
+	return "", InvalidField("propertyName", "%d GA4 properties are named %q: %s; use propertyId instead", len(matches), name, strings.Join(ids, ", "))
+}
+
+// ListGA4DataStreams returns the data streams of a GA4 property.
+func (c *Client) ListGA4DataStreams(ctx context.Context, propertyID string) ([]GA4DataStream, error) {
+	parent := "properties/" + strings.TrimPrefix(propertyID, "properties/")
+	streams, err := listAllPages(ctx, func(pageToken string) (*analyticsadmin.GoogleAnalyticsAdminV1betaListDataStreamsResponse, error) {
+		return c.Analytics.Properties.DataStreams.List(parent).PageSize(200).PageToken(pageToken).Context(ctx).Do()
+	}, func(resp *analyticsadmin.GoogleAnalyticsAdminV1betaListDataStreamsResponse) ([]*analyticsadmin.GoogleAnalyticsAdminV1betaDataStream, string) {
+		return resp.DataStreams, resp.NextPageToken
+	})
+	if err != nil {
+		return nil, MapGoogleError(err)
+	}
+
+	out := make([]GA4DataStream, 0, len(streams))
+	for _, s := range streams {
+		d := GA4DataStream{
+			StreamID:    s.Name[strings.LastIndex(s.Name, "/")+1:],
+			DisplayName: s.DisplayName,
+			Type:        ga4StreamTypes[s.Type],
+		}
+		if d.Type == "" {
+			d.Type = strings.ToLower(s.Type)
+		}
+		if w := s.WebStreamData; w != nil {
+			d.MeasurementID = w.MeasurementId
+			d.DefaultURI = w.DefaultUri
+		}
+		out = append(out, d)
+	}
+	return out, nil
+}
+
+var ga4StreamTypes = map[string]string{
+	"WEB_DATA_STREAM":         "web",
+	"ANDROID_APP_DATA_STREAM": "android",
+	"IOS_APP_DATA_STREAM":     "ios",
+}
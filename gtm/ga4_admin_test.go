@@ -0,0 +1,76 @@
+package gtm
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	analyticsadmin "google.golang.org/api/analyticsadmin/v1beta"
+	"google.golang.org/api/option"
+)
+
+func newTestAnalyticsClient(t *testing.T, handler http.HandlerFunc) *Client {
+	t.Helper()
+	api := httptest.NewServer(handler)
+	t.Cleanup(api.Close)
+
+	service, err := analyticsadmin.NewService(context.Background(), option.WithEndpoint(api.URL), option.WithHTTPClient(api.Client()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &Client{Analytics: service, user: t.Name()}
+}
+
+func TestGA4Admin(t *testing.T) {
+	client := newTestAnalyticsClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v1beta/accountSummaries":
+			io.WriteString(w, `{"accountSummaries":[
+				{"account":"accounts/10","displayName":"Acme","propertySummaries":[
+					{"property":"properties/100","displayName":"Acme Shop","propertyType":"PROPERTY_TYPE_ORDINARY"},
+					{"property":"properties/101","displayName":"Acme Blog","propertyType":"PROPERTY_TYPE_ORDINARY"}]},
+				{"account":"accounts/20","displayName":"Agency","propertySummaries":[
+					{"property":"properties/200","displayName":"acme shop","propertyType":"PROPERTY_TYPE_ROLLUP"}]}]}`)
+		case "/v1beta/properties/100/dataStreams":
+			io.WriteString(w, `{"dataStreams":[
+				{"name":"properties/100/dataStreams/5","displayName":"Web","type":"WEB_DATA_STREAM","webStreamData":{"measurementId":"G-ABC123","defaultUri":"https://shop.acme.com"}},
+				{"name":"properties/100/dataStreams/6","displayName":"App","type":"IOS_APP_DATA_STREAM"}]}`)
+		default:
+			http.NotFound(w, r)
+		}
+	})
+	ctx := context.Background()
+
+	properties, err := client.ListGA4Properties(ctx, "SHOP")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(properties) != 2 || properties[0].PropertyID != "100" || properties[0].AccountName != "Acme" || properties[1].PropertyType != "rollup" {
+		t.Errorf("properties = %+v", properties)
+	}
+
+	if id, err := client.ResolveGA4PropertyID(ctx, "Acme Shop"); err != nil || id != "100" {
+		t.Errorf("exact name: id = %q, err = %v", id, err)
+	}
+	if _, err := client.ResolveGA4PropertyID(ctx, "ACME SHOP"); !errors.Is(err, ErrInvalidRequest) {
+		t.Errorf("ambiguous name: err = %v, want ErrInvalidRequest", err)
+	}
+	if _, err := client.ResolveGA4PropertyID(ctx, "Missing"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("unknown name: err = %v, want ErrNotFound", err)
+	}
+
+	streams, err := client.ListGA4DataStreams(ctx, "properties/100")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []GA4DataStream{
+		{StreamID: "5", DisplayName: "Web", Type: "web", MeasurementID: "G-ABC123", DefaultURI: "https://shop.acme.com"},
+		{StreamID: "6", DisplayName: "App", Type: "ios"},
+	}
+	if len(streams) != 2 || streams[0] != want[0] || streams[1] != want[1] {
+		t.Errorf("streams = %+v", streams)
+	}
+}
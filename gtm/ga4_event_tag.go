@@ -0,0 +1,105 @@
+package gtm
+
+import (
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// ga4NamePattern matches GA4 event, parameter and user property names.
+var ga4NamePattern = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9_]*$`)
+
+// GA4 name length limits.
+const (
+	maxGA4EventNameLength    = 40
+	maxGA4ParameterLength    = 40
+	maxGA4UserPropertyLength = 24
+)
+
+// GA4EventTag describes a GA4 event tag (type gaawe) by its settings. The
+// tag sends to either a measurement ID or the ID of a Google tag in the
+// workspace.
+type GA4EventTag struct {
+	MeasurementID     string            `json:"measurementId,omitempty" jsonschema:"description:GA4 measurement ID (G-XXXXXXX) or a variable holding it, e.g. {{GA4 Measurement ID}}. Provide this or configTag"`
+	ConfigTag         string            `json:"configTag,omitempty" jsonschema:"description:Name of the Google tag (googtag or gaawc) in the workspace to send through, instead of measurementId"`
+	EventName         string            `json:"eventName" jsonschema:"description:GA4 event name, e.g. sign_up or {{Event}}"`
+	EventParameters   map[string]string `json:"eventParameters,omitempty" jsonschema:"description:Event parameters as name: value, e.g. {\"method\": \"{{DL - Method}}\"} (optional)"`
+	UserProperties    map[string]string `json:"userProperties,omitempty" jsonschema:"description:User properties as name: value (optional)"`
+	SendEcommerceData bool              `json:"sendEcommerceData,omitempty" jsonschema:"description:Send the ecommerce object from the data layer, for ecommerce events such as purchase (optional)"`
+}
+
+// Parameters validates the settings and returns the gaawe parameters. A
+// measurement ID goes in measurementIdOverride next to an empty
+// measurementId tag reference, as Tag Manager expects; a config tag goes in
+// the tag reference itself.
+func (e GA4EventTag) Parameters() ([]Parameter, error) {
+	switch {
+	case e.MeasurementID != "" && e.ConfigTag != "":
+		return nil, InvalidField("measurementId", "provide either measurementId or configTag, not both")
+	case e.MeasurementID == "" && e.ConfigTag == "":
+		return nil, InvalidField("measurementId", "measurementId or configTag is required")
+	case e.MeasurementID != "" && !IsVariableReference(e.MeasurementID) && !MeasurementIDPattern.MatchString(e.MeasurementID):
+		return nil, InvalidField("measurementId", "measurementId %q is not a GA4 measurement ID (G-XXXXXXX) or a {{variable}} reference", e.MeasurementID)
+	}
+	if err := validateGA4Name("eventName", "eventName", e.EventName, maxGA4EventNameLength); err != nil {
+		return nil, err
+	}
+
+	params := []Parameter{{Type: ParamTagReference, Key: "measurementId", Value: e.ConfigTag}}
+	if e.MeasurementID != "" {
+		params = append(params, TemplateParam("measurementIdOverride", e.MeasurementID))
+	}
+	params = append(params, TemplateParam("eventName", e.EventName))
+	if e.SendEcommerceData {
+		params = append(params, BooleanParam("sendEcommerceData", true), TemplateParam("getEcommerceDataFrom", "dataLayer"))
+	}
+	if len(e.EventParameters) > 0 {
+		rows, err := ga4NameValueRows("eventParameters", e.EventParameters, maxGA4ParameterLength)
+		if err != nil {
+			return nil, err
+		}
+		params = append(params, ListParam("eventParameters", rows...))
+	}
+	if len(e.UserProperties) > 0 {
+		rows, err := ga4NameValueRows("userProperties", e.UserProperties, maxGA4UserPropertyLength)
+		if err != nil {
+			return nil, err
+		}
+		params = append(params, ListParam("userProperties", rows...))
+	}
+	return params, nil
+}
+
+// ga4NameValueRows returns name/value maps for values, sorted by name.
+func ga4NameValueRows(field string, values map[string]string, maxLength int) ([]Parameter, error) {
+	names := make([]string, 0, len(values))
+	for name := range values {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+
+	rows := make([]Parameter, len(names))
+	for i, name := range names {
+		if err := validateGA4Name(field, field+" name", name, maxLength); err != nil {
+			return nil, err
+		}
+		rows[i] = MapParam("", TemplateParam("name", name), TemplateParam("value", values[name]))
+	}
+	return rows, nil
+}
+
+// validateGA4Name checks a GA4 name against the naming rules. Variable
+// references are resolved at runtime and only checked for presence.
+func validateGA4Name(field, label, name string, maxLength int) error {
+	switch {
+	case strings.TrimSpace(name) == "":
+		return InvalidField(field, "%s is required", label)
+	case strings.Contains(name, "{{"):
+		return nil
+	case len(name) > maxLength:
+		return InvalidField(field, "%s %q is longer than %d characters", label, name, maxLength)
+	case !ga4NamePattern.MatchString(name):
+		return InvalidField(field, "%s %q must start with a letter and contain only letters, digits and underscores", label, name)
+	}
+	return nil
+}
@@ -0,0 +1,73 @@
+package gtm
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestGA4EventTagParameters(t *testing.T) {
+	params, err := GA4EventTag{
+		MeasurementID:     "{{GA4 Measurement ID}}",
+		EventName:         "purchase",
+		EventParameters:   map[string]string{"transaction_id": "{{DL - Transaction ID}}", "coupon": "{{DL - Coupon}}"},
+		UserProperties:    map[string]string{"plan": "pro"},
+		SendEcommerceData: true,
+	}.Parameters()
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []Parameter{
+		{Type: ParamTagReference, Key: "measurementId"},
+		TemplateParam("measurementIdOverride", "{{GA4 Measurement ID}}"),
+		TemplateParam("eventName", "purchase"),
+		BooleanParam("sendEcommerceData", true),
+		TemplateParam("getEcommerceDataFrom", "dataLayer"),
+		ListParam("eventParameters",
+			MapParam("", TemplateParam("name", "coupon"), TemplateParam("value", "{{DL - Coupon}}")),
+			MapParam("", TemplateParam("name", "transaction_id"), TemplateParam("value", "{{DL - Transaction ID}}")),
+		),
+		ListParam("userProperties", MapParam("", TemplateParam("name", "plan"), TemplateParam("value", "pro"))),
+	}
+	if !reflect.DeepEqual(params, want) {
+		t.Errorf("params = %+v\nwant %+v", params, want)
+	}
+	if err := ValidateParameters(params); err != nil {
+		t.Errorf("invalid parameters: %v", err)
+	}
+	if violations := checkTagPayload(TagPayload{Name: "GA4 - purchase", Type: "gaawe", FiringTriggerIDs: []string{"2147479553"}, Parameter: params}, nil, nil); len(violations) > 0 {
+		t.Errorf("violations = %+v", violations)
+	}
+
+	params, err = GA4EventTag{ConfigTag: "GA4 - Config", EventName: "sign_up"}.Parameters()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(params) != 2 || params[0].Value != "GA4 - Config" || params[1].Value != "sign_up" {
+		t.Errorf("config tag params = %+v", params)
+	}
+}
+
+func TestGA4EventTagParameters_Errors(t *testing.T) {
+	for _, tt := range []struct {
+		name  string
+		tag   GA4EventTag
+		field string
+	}{
+		{name: "no destination", tag: GA4EventTag{EventName: "a"}, field: "measurementId"},
+		{name: "both destinations", tag: GA4EventTag{MeasurementID: "G-ABC123", ConfigTag: "GA4", EventName: "a"}, field: "measurementId"},
+		{name: "invalid measurement ID", tag: GA4EventTag{MeasurementID: "UA-1234-1", EventName: "a"}, field: "measurementId"},
+		{name: "no event name", tag: GA4EventTag{MeasurementID: "G-ABC123"}, field: "eventName"},
+		{name: "invalid event name", tag: GA4EventTag{MeasurementID: "G-ABC123", EventName: "sign up"}, field: "eventName"},
+		{name: "long parameter name", tag: GA4EventTag{MeasurementID: "G-ABC123", EventName: "a", EventParameters: map[string]string{"a_parameter_name_longer_than_forty_characters": "x"}}, field: "eventParameters"},
+		{name: "long user property", tag: GA4EventTag{MeasurementID: "G-ABC123", EventName: "a", UserProperties: map[string]string{"customer_lifetime_value_x": "x"}}, field: "userProperties"},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := tt.tag.Parameters()
+			var fe *FieldError
+			if !errors.As(err, &fe) || fe.Field != tt.field {
+				t.Errorf("err = %v, want error on %s", err, tt.field)
+			}
+		})
+	}
+}
@@ -0,0 +1,136 @@
+package gtm
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+
+	tagmanager "google.golang.org/api/tagmanager/v2"
+)
+
+// MeasurementIDPattern matches a GA4 measurement ID.
+var MeasurementIDPattern = regexp.MustCompile(`^G-[A-Z0-9]+$`)
+
+// standardBuiltInVariables are the built-in variables GTM enables in a new
+// web container.
+var standardBuiltInVariables = []string{"pageUrl", "pageHostname", "pagePath", "referrer", "event"}
+
+// GA4SetupResult reports what SetupGA4Basic created and what it found
+// already in place.
+type GA4SetupResult struct {
+	Created                 []ChangesetStep     `json:"created"`
+	Existing                []BlueprintExisting `json:"existing,omitempty"`
+	EnabledBuiltInVariables []string            `json:"enabledBuiltInVariables"`
+	FiringTrigger           string              `json:"firingTrigger"`
+}
+
+// apiParamValue returns the value of the top-level parameter key.
+func apiParamValue(params []*tagmanager.Parameter, key string) string {
+	for _, p := range params {
+		if p.Key == key {
+			return p.Value
+		}
+	}
+	return ""
+}
+
+// SetupGA4Basic provisions the ga4-base blueprint for measurementID and
+// enables the standard built-in variables. It is idempotent: a Google tag
+// already sending to measurementID, entities of the blueprint already in
+// the workspace and enabled built-in variables are left alone. Running it
+// again with a different ID fails instead of reusing a measurement ID
+// variable that holds another one.
+func (c *Client) SetupGA4Basic(ctx context.Context, accountID, containerID, workspaceID, measurementID string) (*GA4SetupResult, error) {
+	existing, err := c.ListWorkspaceEntities(ctx, accountID, containerID, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+	ops, reused, err := BuiltInBlueprints()["ga4-base"].Changeset(map[string]string{"MEASUREMENT_ID": measurementID}, existing)
+	if err != nil {
+		return nil, err
+	}
+	result := &GA4SetupResult{
+		Created:                 []ChangesetStep{},
+		Existing:                reused,
+		EnabledBuiltInVariables: []string{},
+		FiringTrigger:           BuiltInTriggerNames["2147479572"],
+	}
+
+	ws := c.Service.Accounts.Containers.Workspaces
+	for _, e := range reused {
+		if e.EntityType != "variable" {
+			continue
+		}
+		v, err := RetryWithBackoff(ctx, func() (*tagmanager.Variable, error) {
+			return ws.Variables.Get(BuildVariablePath(accountID, containerID, workspaceID, e.EntityID)).Context(ctx).Do()
+		})
+		if err != nil {
+			return nil, MapGoogleError(err)
+		}
+		if value := apiParamValue(v.Parameter, "value"); v.Type == "c" && value != measurementID {
+			return nil, InvalidField("measurementId", "variable %q already holds measurement ID %s; rename or update it to set up %s", e.Name, value, measurementID)
+		}
+	}
+
+	// A Google tag sending to the same ID under another name counts as the
+	// blueprint's tag
+	for _, t := range existing.Tags {
+		if t.Type != "googtag" && t.Type != "gaawc" {
+			continue
+		}
+		tag, err := RetryWithBackoff(ctx, func() (*tagmanager.Tag, error) {
+			return ws.Tags.Get(BuildTagPath(accountID, containerID, workspaceID, t.TagID)).Context(ctx).Do()
+		})
+		if err != nil {
+			return nil, MapGoogleError(err)
+		}
+		if apiParamValue(tag.Parameter, "tagId") != measurementID && apiParamValue(tag.Parameter, "measurementId") != measurementID {
+			continue
+		}
+		for i, op := range ops {
+			if op.EntityType == "tag" {
+				ops = append(ops[:i], ops[i+1:]...)
+				result.Existing = append(result.Existing, BlueprintExisting{EntityType: "tag", EntityID: t.TagID, Name: t.Name})
+				break
+			}
+		}
+		break
+	}
+
+	if len(ops) > 0 {
+		applied, err := c.ApplyChangeset(ctx, accountID, containerID, workspaceID, ops)
+		if err != nil {
+			if applied != nil {
+				for _, step := range applied.RolledBack {
+					if step.Error != "" {
+						return nil, fmt.Errorf("%w; %s %q could not be deleted again: %s", err, step.EntityType, step.Name, step.Error)
+					}
+				}
+			}
+			return nil, err
+		}
+		result.Created = applied.Applied
+	}
+
+	enabled, err := c.ListBuiltInVariables(ctx, accountID, containerID, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+	isEnabled := make(map[string]bool, len(enabled))
+	for _, v := range enabled {
+		isEnabled[v.Type] = true
+	}
+	var missing []string
+	for _, t := range standardBuiltInVariables {
+		if !isEnabled[t] {
+			missing = append(missing, t)
+		}
+	}
+	if len(missing) > 0 {
+		if _, err := c.EnableBuiltInVariables(ctx, accountID, containerID, workspaceID, missing); err != nil {
+			return nil, fmt.Errorf("created %d entities but failed to enable built-in variables: %w", len(result.Created), err)
+		}
+		result.EnabledBuiltInVariables = missing
+	}
+	return result, nil
+}
@@ -0,0 +1,112 @@
+package gtm
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"sort"
+	"strings"
+	"testing"
+)
+
+// serveGA4Workspace extends fake with entity lists and built-in variables.
+func serveGA4Workspace(fake *fakeWorkspace, builtIns map[string]bool) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		path := strings.TrimPrefix(r.URL.Path, "/tagmanager/v2/")
+		kind := path[strings.LastIndex(path, "/")+1:]
+		switch {
+		case kind == "built_in_variables":
+			fake.mu.Lock()
+			defer fake.mu.Unlock()
+			if r.Method == http.MethodPost {
+				fake.calls = append(fake.calls, "enable "+strings.Join(r.URL.Query()["type"], ","))
+				for _, t := range r.URL.Query()["type"] {
+					builtIns[t] = true
+				}
+			}
+			vars := []map[string]any{}
+			for t := range builtIns {
+				vars = append(vars, map[string]any{"type": t})
+			}
+			json.NewEncoder(w).Encode(map[string]any{"builtInVariable": vars})
+		case r.Method == http.MethodGet && (kind == "tags" || kind == "triggers" || kind == "variables"):
+			fake.mu.Lock()
+			defer fake.mu.Unlock()
+			list := []map[string]any{}
+			for p, e := range fake.entities {
+				if strings.HasPrefix(p, path+"/") {
+					list = append(list, e)
+				}
+			}
+			json.NewEncoder(w).Encode(map[string]any{strings.TrimSuffix(kind, "s"): list})
+		default:
+			fake.serve(w, r)
+		}
+	}
+}
+
+func TestSetupGA4Basic_Idempotent(t *testing.T) {
+	fake := &fakeWorkspace{entities: map[string]map[string]any{}}
+	builtIns := map[string]bool{"pageUrl": true}
+	client := newTestClient(t, serveGA4Workspace(fake, builtIns))
+
+	result, err := client.SetupGA4Basic(context.Background(), "1", "2", "3", "G-TEST123")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(result.Created) != 2 || len(result.Existing) != 0 {
+		t.Errorf("created %+v, existing %+v", result.Created, result.Existing)
+	}
+	sort.Strings(result.EnabledBuiltInVariables)
+	if got := strings.Join(result.EnabledBuiltInVariables, ","); got != "event,pageHostname,pagePath,referrer" {
+		t.Errorf("enabled %s", got)
+	}
+	for _, e := range fake.entities {
+		if e["type"] == "googtag" && e["firingTriggerId"].([]any)[0] != "2147479572" {
+			t.Errorf("Google tag fires on %v", e["firingTriggerId"])
+		}
+	}
+
+	// The production transport drops cached lists after every write
+	listCaches.invalidate("accounts/1/containers/2/workspaces/3")
+	result, err = client.SetupGA4Basic(context.Background(), "1", "2", "3", "G-TEST123")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(result.Created) != 0 || len(result.Existing) != 2 || len(result.EnabledBuiltInVariables) != 0 {
+		t.Errorf("second run: %+v", result)
+	}
+	if len(fake.entities) != 2 {
+		t.Errorf("entities after second run = %v", fake.entities)
+	}
+
+	if _, err := client.SetupGA4Basic(context.Background(), "1", "2", "3", "G-OTHER"); !errors.Is(err, ErrInvalidRequest) {
+		t.Errorf("other measurement ID: err = %v, want ErrInvalidRequest", err)
+	}
+}
+
+func TestSetupGA4Basic_ReusesGoogleTag(t *testing.T) {
+	ws := "accounts/1/containers/2/workspaces/3"
+	fake := &fakeWorkspace{
+		entities: map[string]map[string]any{
+			ws + "/tags/5": {
+				"tagId": "5", "name": "Google Tag", "type": "googtag", "path": ws + "/tags/5",
+				"parameter": []any{map[string]any{"type": "template", "key": "tagId", "value": "G-TEST123"}},
+			},
+		},
+		nextID: 10,
+	}
+	client := newTestClient(t, serveGA4Workspace(fake, map[string]bool{}))
+
+	result, err := client.SetupGA4Basic(context.Background(), "1", "2", "3", "G-TEST123")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(result.Created) != 1 || result.Created[0].EntityType != "variable" {
+		t.Errorf("created %+v, want only the variable", result.Created)
+	}
+	if len(result.Existing) != 1 || result.Existing[0].EntityID != "5" {
+		t.Errorf("existing %+v, want the Google tag", result.Existing)
+	}
+}
@@ -0,0 +1,276 @@
+package gtm
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	// DefaultGallerySearchLimit and MaxGallerySearchLimit bound the
+	// templates search_gallery_templates returns.
+	DefaultGallerySearchLimit = 10
+	MaxGallerySearchLimit     = 25
+
+	// galleryCandidates is how many repositories a search checks for a
+	// gallery template.
+	galleryCandidates = 40
+	// galleryFetchers bounds the concurrent template.tpl downloads.
+	galleryFetchers = 8
+	// galleryRequestTimeout bounds each request to GitHub.
+	galleryRequestTimeout = 10 * time.Second
+	// maxTemplateDataBytes bounds a downloaded template.tpl or metadata.yaml.
+	maxTemplateDataBytes = 1 << 20
+)
+
+// GalleryIndex searches the Community Template Gallery. Gallery templates
+// are GitHub repositories with a template.tpl at their root, so the index
+// is GitHub repository search, with every candidate confirmed by reading
+// its template.tpl.
+type GalleryIndex struct {
+	client *http.Client
+	apiURL string // GitHub REST API
+	rawURL string // raw file host, owner/repo/ref/path below it
+	token  string // optional GitHub token, raising the search rate limit
+}
+
+// NewGalleryIndex returns an index backed by github.com. The token is
+// optional; without it GitHub allows 10 searches per minute per server IP.
+func NewGalleryIndex(token string) *GalleryIndex {
+	return &GalleryIndex{
+		client: &http.Client{Timeout: galleryRequestTimeout},
+		apiURL: "https://api.github.com",
+		rawURL: "https://raw.githubusercontent.com",
+		token:  token,
+	}
+}
+
+// GalleryTemplate is a search result, ready for import_gallery_template.
+type GalleryTemplate struct {
+	Owner             string   `json:"galleryOwner"`
+	Repository        string   `json:"galleryRepository"`
+	DisplayName       string   `json:"displayName"`
+	Description       string   `json:"description,omitempty"`
+	Kind              string   `json:"kind"`              // tag, variable or client
+	ContainerContexts []string `json:"containerContexts"` // web, server
+	Brand             string   `json:"brand,omitempty"`
+	Stars             int      `json:"stars"`
+	URL               string   `json:"url"`
+}
+
+// GallerySearchOptions filter gallery search results.
+type GallerySearchOptions struct {
+	Kind    string // tag, variable or client; empty for any
+	Context string // web or server; empty for any
+	Limit   int
+}
+
+type githubRepository struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Stars       int    `json:"stargazers_count"`
+	HTMLURL     string `json:"html_url"`
+	Fork        bool   `json:"fork"`
+	Owner       struct {
+		Login string `json:"login"`
+	} `json:"owner"`
+}
+
+// Search finds gallery templates matching the keywords, best matches first.
+func (g *GalleryIndex) Search(ctx context.Context, query string, opts GallerySearchOptions) ([]GalleryTemplate, error) {
+	query = strings.TrimSpace(query)
+	if query == "" {
+		return nil, InvalidField("query", "query is required")
+	}
+	switch opts.Kind {
+	case "", "tag", "variable", "client":
+	default:
+		return nil, InvalidField("kind", "kind must be tag, variable or client")
+	}
+	switch opts.Context {
+	case "", "web", "server":
+	default:
+		return nil, InvalidField("context", "context must be web or server")
+	}
+	if opts.Limit <= 0 {
+		opts.Limit = DefaultGallerySearchLimit
+	}
+	opts.Limit = min(opts.Limit, MaxGallerySearchLimit)
+
+	repos, err := g.searchRepositories(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	// Confirm candidates concurrently, keeping the search order
+	results := make([]*GalleryTemplate, len(repos))
+	sem := make(chan struct{}, galleryFetchers)
+	var wg sync.WaitGroup
+	for i, repo := range repos {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+			results[i] = g.galleryTemplate(ctx, repo)
+		}()
+	}
+	wg.Wait()
+
+	templates := []GalleryTemplate{}
+	for _, t := range results {
+		if t == nil || (opts.Kind != "" && t.Kind != opts.Kind) ||
+			(opts.Context != "" && !slices.Contains(t.ContainerContexts, opts.Context)) {
+			continue
+		}
+		templates = append(templates, *t)
+		if len(templates) == opts.Limit {
+			break
+		}
+	}
+	return templates, nil
+}
+
+// searchRepositories returns the repositories GitHub finds for the
+// keywords in names, descriptions and topics, without forks.
+func (g *GalleryIndex) searchRepositories(ctx context.Context, query string) ([]githubRepository, error) {
+	params := url.Values{
+		"q":        {query + " gtm in:name,description,topics fork:false"},
+		"per_page": {fmt.Sprint(galleryCandidates)},
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, g.apiURL+"/search/repositories?"+params.Encode(), nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/vnd.github+json")
+	if g.token != "" {
+		req.Header.Set("Authorization", "Bearer "+g.token)
+	}
+	resp, err := g.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search the template gallery: %w", err)
+	}
+	defer resp.Body.Close()
+	switch {
+	case resp.StatusCode == http.StatusForbidden || resp.StatusCode == http.StatusTooManyRequests:
+		return nil, fmt.Errorf("%w: GitHub search limit reached; try again in a minute", ErrRateLimit)
+	case resp.StatusCode != http.StatusOK:
+		return nil, fmt.Errorf("template gallery search failed: GitHub returned %s", resp.Status)
+	}
+	var result struct {
+		Items []githubRepository `json:"items"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("invalid GitHub search response: %w", err)
+	}
+	repos := result.Items[:0]
+	for _, repo := range result.Items {
+		if !repo.Fork {
+			repos = append(repos, repo)
+		}
+	}
+	return repos, nil
+}
+
+// GalleryVersion is a release of a gallery template, from the versions
+// list of its metadata.yaml.
+type GalleryVersion struct {
+	SHA         string `yaml:"sha" json:"sha"`
+	ChangeNotes string `yaml:"changeNotes" json:"changeNotes,omitempty"`
+}
+
+// TemplateData downloads the template.tpl of a gallery repository at ref,
+// a commit SHA, branch or tag; an empty ref reads the default branch.
+func (g *GalleryIndex) TemplateData(ctx context.Context, owner, repository, ref string) (string, error) {
+	data, err := g.rawFile(ctx, owner, repository, ref, "template.tpl")
+	return string(data), err
+}
+
+// Versions returns the released versions of a gallery template, newest
+// first. The gallery serves the versions listed in the repository's
+// metadata.yaml, not every commit.
+func (g *GalleryIndex) Versions(ctx context.Context, owner, repository string) ([]GalleryVersion, error) {
+	data, err := g.rawFile(ctx, owner, repository, "", "metadata.yaml")
+	if err != nil {
+		return nil, err
+	}
+	var metadata struct {
+		Versions []GalleryVersion `yaml:"versions"`
+	}
+	if err := yaml.Unmarshal(data, &metadata); err != nil {
+		return nil, fmt.Errorf("invalid metadata.yaml in %s/%s: %w", owner, repository, err)
+	}
+	if len(metadata.Versions) == 0 {
+		return nil, fmt.Errorf("%w: metadata.yaml in %s/%s lists no versions", ErrNotFound, owner, repository)
+	}
+	return metadata.Versions, nil
+}
+
+// rawFile downloads a file of a repository at ref (default branch if empty).
+func (g *GalleryIndex) rawFile(ctx context.Context, owner, repository, ref, name string) ([]byte, error) {
+	if ref == "" {
+		ref = "HEAD"
+	}
+	fileURL := fmt.Sprintf("%s/%s/%s/%s/%s", g.rawURL, url.PathEscape(owner), url.PathEscape(repository), url.PathEscape(ref), name)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, fileURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := g.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to download %s/%s: %w", owner, repository, err)
+	}
+	defer resp.Body.Close()
+	switch {
+	case resp.StatusCode == http.StatusNotFound:
+		return nil, fmt.Errorf("%w: %s/%s has no %s at %s", ErrNotFound, owner, repository, name, ref)
+	case resp.StatusCode != http.StatusOK:
+		return nil, fmt.Errorf("failed to download %s/%s: GitHub returned %s", owner, repository, resp.Status)
+	}
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxTemplateDataBytes))
+	if err != nil {
+		return nil, fmt.Errorf("failed to download %s/%s: %w", owner, repository, err)
+	}
+	return data, nil
+}
+
+// galleryTemplate reads the repository's template.tpl and describes the
+// template, or returns nil if the repository holds no template.
+func (g *GalleryIndex) galleryTemplate(ctx context.Context, repo githubRepository) *GalleryTemplate {
+	data, err := g.TemplateData(ctx, repo.Owner.Login, repo.Name, "")
+	if err != nil {
+		return nil
+	}
+	info, err := parseTemplateInfo(data)
+	if err != nil {
+		return nil
+	}
+
+	t := &GalleryTemplate{
+		Owner:             repo.Owner.Login,
+		Repository:        repo.Name,
+		DisplayName:       info.DisplayName,
+		Description:       info.Description,
+		Kind:              templateKind(info.Type),
+		ContainerContexts: []string{},
+		Brand:             info.Brand.DisplayName,
+		Stars:             repo.Stars,
+		URL:               repo.HTMLURL,
+	}
+	for _, c := range info.ContainerContexts {
+		t.ContainerContexts = append(t.ContainerContexts, strings.ToLower(c))
+	}
+	if t.Description == "" {
+		t.Description = repo.Description
+	}
+	return t
+}
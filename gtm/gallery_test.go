@@ -0,0 +1,132 @@
+package gtm
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+const testTemplateData = `___TERMS_OF_SERVICE___
+
+By creating or modifying this file you agree to Google Tag Manager's Community
+Template Gallery Developer Terms of Service.
+
+___INFO___
+
+{
+  "type": "%s",
+  "id": "cvt_temp_public_id",
+  "version": 1,
+  "displayName": "%s",
+  "brand": {"id": "brand_dummy", "displayName": "Acme"},
+  "description": "",
+  "containerContexts": ["%s"]
+}
+
+___TEMPLATE_PARAMETERS___
+
+[]
+`
+
+func TestTemplateSections(t *testing.T) {
+	sections := templateSections(fmt.Sprintf(testTemplateData, "TAG", "Acme Pixel", "WEB"))
+	if sections["TEMPLATE_PARAMETERS"] != "[]" || !strings.HasPrefix(sections["TERMS_OF_SERVICE"], "By creating") {
+		t.Errorf("sections = %q", sections)
+	}
+	if _, err := parseTemplateInfo("no sections"); err == nil {
+		t.Error("template data without ___INFO___ accepted")
+	}
+}
+
+func TestGalleryIndexSearch(t *testing.T) {
+	templates := map[string][3]string{
+		"/acme/acme-pixel/HEAD/template.tpl":   {"TAG", "Acme Pixel", "WEB"},
+		"/acme/acme-sgtm/HEAD/template.tpl":    {"TAG", "Acme Conversions API", "SERVER"},
+		"/other/acme-vars/HEAD/template.tpl":   {"MACRO", "Acme Cookie", "WEB"},
+		"/someone/acme-docs/HEAD/template.tpl": {},
+	}
+	var query, auth string
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/search/repositories" {
+			query, auth = r.URL.Query().Get("q"), r.Header.Get("Authorization")
+			io.WriteString(w, `{"items":[
+				{"name":"acme-pixel","owner":{"login":"acme"},"description":"Acme pixel for GTM","stargazers_count":12},
+				{"name":"acme-docs","owner":{"login":"someone"},"description":"Notes about Acme and GTM"},
+				{"name":"acme-sgtm","owner":{"login":"acme"},"stargazers_count":3},
+				{"name":"acme-pixel","owner":{"login":"fork"},"fork":true},
+				{"name":"acme-vars","owner":{"login":"other"}}
+			]}`)
+			return
+		}
+		info, ok := templates[r.URL.Path]
+		if !ok || info[0] == "" {
+			http.NotFound(w, r)
+			return
+		}
+		fmt.Fprintf(w, testTemplateData, info[0], info[1], info[2])
+	}))
+	t.Cleanup(api.Close)
+	g := &GalleryIndex{client: api.Client(), apiURL: api.URL, rawURL: api.URL, token: "tok"}
+	ctx := context.Background()
+
+	got, err := g.Search(ctx, "acme", GallerySearchOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(query, "acme ") || auth != "Bearer tok" {
+		t.Errorf("query = %q, auth = %q", query, auth)
+	}
+	var names []string
+	for _, tmpl := range got {
+		names = append(names, tmpl.Owner+"/"+tmpl.Repository)
+	}
+	if strings.Join(names, ",") != "acme/acme-pixel,acme/acme-sgtm,other/acme-vars" {
+		t.Fatalf("templates = %v", names)
+	}
+	pixel := got[0]
+	if pixel.DisplayName != "Acme Pixel" || pixel.Kind != "tag" || pixel.ContainerContexts[0] != "web" ||
+		pixel.Description != "Acme pixel for GTM" || pixel.Brand != "Acme" || pixel.Stars != 12 {
+		t.Errorf("pixel = %+v", pixel)
+	}
+
+	got, err = g.Search(ctx, "acme", GallerySearchOptions{Context: "server"})
+	if err != nil || len(got) != 1 || got[0].Repository != "acme-sgtm" {
+		t.Errorf("server templates = %+v, %v", got, err)
+	}
+	got, err = g.Search(ctx, "acme", GallerySearchOptions{Kind: "variable"})
+	if err != nil || len(got) != 1 || got[0].Kind != "variable" {
+		t.Errorf("variable templates = %+v, %v", got, err)
+	}
+	got, err = g.Search(ctx, "acme", GallerySearchOptions{Limit: 1})
+	if err != nil || len(got) != 1 {
+		t.Errorf("limited templates = %+v, %v", got, err)
+	}
+
+	for name, opts := range map[string]GallerySearchOptions{
+		"kind":    {Kind: "trigger"},
+		"context": {Context: "mobile"},
+	} {
+		if _, err := g.Search(ctx, "acme", opts); !errors.Is(err, ErrInvalidRequest) {
+			t.Errorf("%s: err = %v, want ErrInvalidRequest", name, err)
+		}
+	}
+	if _, err := g.Search(ctx, " ", GallerySearchOptions{}); !errors.Is(err, ErrInvalidRequest) {
+		t.Errorf("empty query: err = %v, want ErrInvalidRequest", err)
+	}
+}
+
+func TestGalleryIndexSearch_RateLimited(t *testing.T) {
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+	}))
+	t.Cleanup(api.Close)
+	g := &GalleryIndex{client: api.Client(), apiURL: api.URL, rawURL: api.URL}
+	if _, err := g.Search(context.Background(), "acme", GallerySearchOptions{}); !errors.Is(err, ErrRateLimit) {
+		t.Errorf("err = %v, want ErrRateLimit", err)
+	}
+}
@@ -0,0 +1,252 @@
+package gtm
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+	"sync"
+	"time"
+
+	tagmanager "google.golang.org/api/tagmanager/v2"
+)
+
+const (
+	// githubBackupTimeout bounds each commit of a container export.
+	githubBackupTimeout = 30 * time.Second
+	// defaultGitHubBackupDir is the repository directory exports go to.
+	defaultGitHubBackupDir = "containers"
+)
+
+// Version events that trigger a backup commit.
+const (
+	backupEventCreate  = "Create"
+	backupEventPublish = "Publish"
+)
+
+// GitHubBackupOptions configure committing container exports to GitHub.
+type GitHubBackupOptions struct {
+	Repository string // owner/name
+	Branch     string // empty for the repository's default branch
+	Dir        string // directory of the exports; defaults to containers
+	Token      string // token with write access to the repository contents
+	// Containers limits the backup to these container IDs or public IDs
+	// (GTM-XXXX). Empty backs up every container a user of the server
+	// versions, whoever owns it.
+	Containers []string
+}
+
+// GitHubBackup commits the export of every version created or published
+// through the server to a GitHub repository, one file per container at
+// <dir>/<accountId>/<containerId>.json, so the repository history is the
+// version history of each container. Sensitive parameter values are
+// redacted from the exports.
+type GitHubBackup struct {
+	client     *http.Client
+	apiURL     string
+	repo       string
+	branch     string
+	dir        string
+	token      string
+	containers map[string]bool // nil backs up every container
+	logger     *slog.Logger
+
+	mu sync.Mutex     // serializes commits, which update the same files
+	wg sync.WaitGroup // pending commits
+}
+
+var versionBackup struct {
+	mu     sync.Mutex
+	github *GitHubBackup
+}
+
+// SetGitHubBackup sets the backup run after versions are created and
+// published. nil disables it.
+func SetGitHubBackup(b *GitHubBackup) {
+	versionBackup.mu.Lock()
+	defer versionBackup.mu.Unlock()
+	versionBackup.github = b
+}
+
+// NewGitHubBackup checks the options and returns a backup to github.com.
+func NewGitHubBackup(opts GitHubBackupOptions, logger *slog.Logger) (*GitHubBackup, error) {
+	owner, name, ok := strings.Cut(opts.Repository, "/")
+	if !ok || owner == "" || name == "" || strings.Contains(name, "/") {
+		return nil, fmt.Errorf("invalid GitHub backup repository %q: use owner/name", opts.Repository)
+	}
+	if opts.Token == "" {
+		return nil, fmt.Errorf("a GitHub token with write access to %s is required", opts.Repository)
+	}
+	dir := strings.Trim(opts.Dir, "/")
+	if dir == "" {
+		dir = defaultGitHubBackupDir
+	}
+	var containers map[string]bool
+	if len(opts.Containers) > 0 {
+		containers = make(map[string]bool, len(opts.Containers))
+		for _, id := range opts.Containers {
+			containers[strings.ToUpper(strings.TrimSpace(id))] = true
+		}
+	}
+	return &GitHubBackup{
+		client:     &http.Client{Timeout: githubBackupTimeout},
+		apiURL:     "https://api.github.com",
+		repo:       opts.Repository,
+		branch:     opts.Branch,
+		dir:        dir,
+		token:      opts.Token,
+		containers: containers,
+		logger:     logger,
+	}, nil
+}
+
+// includes reports whether versions of a container are backed up.
+func (b *GitHubBackup) includes(containerID string, version *tagmanager.ContainerVersion) bool {
+	if b.containers == nil || b.containers[containerID] {
+		return true
+	}
+	return version.Container != nil && b.containers[strings.ToUpper(version.Container.PublicId)]
+}
+
+// backupVersion commits a created or published version to the configured
+// backup, if any. The commit runs in the background: a failed backup is
+// logged rather than failing a version change that already happened.
+func backupVersion(accountID, containerID, event string, version *tagmanager.ContainerVersion) {
+	versionBackup.mu.Lock()
+	b := versionBackup.github
+	versionBackup.mu.Unlock()
+	if b == nil || version == nil || !b.includes(containerID, version) {
+		return
+	}
+
+	b.wg.Add(1)
+	go func() {
+		defer b.wg.Done()
+		ctx, cancel := context.WithTimeout(context.Background(), githubBackupTimeout)
+		defer cancel()
+		if err := b.Commit(ctx, accountID, containerID, event, version); err != nil {
+			b.logger.Error("failed to back up container version to GitHub",
+				"account_id", accountID, "container_id", containerID,
+				"version_id", version.ContainerVersionId, "error", err)
+		}
+	}()
+}
+
+// wait blocks until pending commits finish.
+func (b *GitHubBackup) wait() {
+	b.wg.Wait()
+}
+
+// exportPath returns the repository path of a container's export.
+func (b *GitHubBackup) exportPath(accountID, containerID string) string {
+	return path.Join(b.dir, accountID, containerID+".json")
+}
+
+// Commit writes the export of version, with sensitive values redacted, to
+// the container's file, with the version name and notes in the commit
+// message.
+func (b *GitHubBackup) Commit(ctx context.Context, accountID, containerID, event string, version *tagmanager.ContainerVersion) error {
+	data, err := json.MarshalIndent(NewContainerExport(redactedCopy(version)), "", "  ")
+	if err != nil {
+		return err
+	}
+	file := b.exportPath(accountID, containerID)
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	sha, err := b.fileSHA(ctx, file)
+	if err != nil {
+		return err
+	}
+	body := map[string]string{
+		"message": backupCommitMessage(containerID, event, version),
+		"content": base64.StdEncoding.EncodeToString(append(data, '\n')),
+	}
+	if sha != "" {
+		body["sha"] = sha
+	}
+	if b.branch != "" {
+		body["branch"] = b.branch
+	}
+	resp, err := b.do(ctx, http.MethodPut, file, body)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusCreated {
+		return fmt.Errorf("GitHub rejected the commit of %s: %s", file, resp.Status)
+	}
+	return nil
+}
+
+// fileSHA returns the blob SHA of a repository file, or "" if it does not
+// exist yet.
+func (b *GitHubBackup) fileSHA(ctx context.Context, file string) (string, error) {
+	resp, err := b.do(ctx, http.MethodGet, file, nil)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusNotFound:
+		return "", nil
+	default:
+		return "", fmt.Errorf("failed to read %s from GitHub: %s", file, resp.Status)
+	}
+	var meta struct {
+		SHA string `json:"sha"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&meta); err != nil {
+		return "", fmt.Errorf("invalid GitHub response for %s: %w", file, err)
+	}
+	return meta.SHA, nil
+}
+
+// do calls the contents API for a repository file.
+func (b *GitHubBackup) do(ctx context.Context, method, file string, body any) (*http.Response, error) {
+	u := fmt.Sprintf("%s/repos/%s/contents/%s", b.apiURL, b.repo, file)
+	if method == http.MethodGet && b.branch != "" {
+		u += "?ref=" + url.QueryEscape(b.branch)
+	}
+	var payload bytes.Buffer
+	if body != nil {
+		if err := json.NewEncoder(&payload).Encode(body); err != nil {
+			return nil, err
+		}
+	}
+	req, err := http.NewRequestWithContext(ctx, method, u, &payload)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/vnd.github+json")
+	req.Header.Set("Authorization", "Bearer "+b.token)
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	return b.client.Do(req)
+}
+
+// backupCommitMessage summarizes the version event, followed by the
+// version notes.
+func backupCommitMessage(containerID, event string, version *tagmanager.ContainerVersion) string {
+	container := containerID
+	if version.Container != nil && version.Container.PublicId != "" {
+		container = version.Container.PublicId
+	}
+	msg := fmt.Sprintf("%s %s version %s", event, container, version.ContainerVersionId)
+	if version.Name != "" {
+		msg += ": " + version.Name
+	}
+	if notes := strings.TrimSpace(version.Description); notes != "" {
+		msg += "\n\n" + notes
+	}
+	return msg
+}
@@ -0,0 +1,151 @@
+package gtm
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	tagmanager "google.golang.org/api/tagmanager/v2"
+)
+
+// fakeGitHubContents serves the contents API of one repository from memory.
+type fakeGitHubContents struct {
+	mu      sync.Mutex
+	files   map[string]string // path -> content
+	commits []map[string]string
+}
+
+func (f *fakeGitHubContents) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	file, ok := strings.CutPrefix(r.URL.Path, "/repos/acme/gtm-backup/contents/")
+	if !ok || r.Header.Get("Authorization") != "Bearer secret" {
+		http.Error(w, "unexpected request", http.StatusBadRequest)
+		return
+	}
+	switch r.Method {
+	case http.MethodGet:
+		if _, ok := f.files[file]; !ok {
+			http.NotFound(w, r)
+			return
+		}
+		json.NewEncoder(w).Encode(map[string]string{"sha": "sha-" + file})
+	case http.MethodPut:
+		var body map[string]string
+		json.NewDecoder(r.Body).Decode(&body)
+		_, exists := f.files[file]
+		if exists != (body["sha"] == "sha-"+file) {
+			http.Error(w, "sha mismatch", http.StatusConflict)
+			return
+		}
+		content, _ := base64.StdEncoding.DecodeString(body["content"])
+		f.files[file] = string(content)
+		f.commits = append(f.commits, body)
+		w.WriteHeader(http.StatusCreated)
+	}
+}
+
+func TestGitHubBackup(t *testing.T) {
+	gh := &fakeGitHubContents{files: map[string]string{}}
+	srv := httptest.NewServer(gh)
+	t.Cleanup(srv.Close)
+
+	b, err := NewGitHubBackup(GitHubBackupOptions{Repository: "acme/gtm-backup", Branch: "main", Token: "secret"}, slog.New(slog.NewTextHandler(io.Discard, nil)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	b.apiURL = srv.URL
+	SetGitHubBackup(b)
+	t.Cleanup(func() { SetGitHubBackup(nil) })
+
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]any{"containerVersion": map[string]any{
+			"containerVersionId": "7",
+			"name":               "Purchases",
+			"description":        "Adds the purchase tag.",
+			"container":          map[string]any{"publicId": "GTM-ABC123"},
+			"tag": []map[string]any{{"tagId": "1", "name": "GA4 Purchase", "parameter": []map[string]any{
+				{"type": "template", "key": "apiSecret", "value": "s3cr3t"},
+			}}},
+		}})
+	})
+	ctx := context.Background()
+
+	if _, err := client.CreateVersion(ctx, "1", "2", "3", &VersionInput{Name: "Purchases"}); err != nil {
+		t.Fatal(err)
+	}
+	b.wait()
+	if _, err := client.PublishVersion(ctx, "1", "2", "7"); err != nil {
+		t.Fatal(err)
+	}
+	b.wait()
+
+	if len(gh.commits) != 2 {
+		t.Fatalf("commits = %v", gh.commits)
+	}
+	if msg := gh.commits[0]["message"]; msg != "Create GTM-ABC123 version 7: Purchases\n\nAdds the purchase tag." {
+		t.Errorf("message = %q", msg)
+	}
+	if c := gh.commits[1]; !strings.HasPrefix(c["message"], "Publish GTM-ABC123 version 7") || c["branch"] != "main" {
+		t.Errorf("publish commit = %v", c)
+	}
+
+	var export ContainerExport
+	if err := json.Unmarshal([]byte(gh.files["containers/1/2.json"]), &export); err != nil {
+		t.Fatal(err)
+	}
+	if v := export.ContainerVersion; v == nil || len(v.Tag) != 1 || v.Tag[0].Name != "GA4 Purchase" {
+		t.Errorf("export = %+v", export)
+	}
+	if v := export.ContainerVersion; v != nil && v.Tag[0].Parameter[0].Value != RedactedValue {
+		t.Errorf("committed secret = %q, want it redacted", v.Tag[0].Parameter[0].Value)
+	}
+}
+
+func TestGitHubBackup_Containers(t *testing.T) {
+	gh := &fakeGitHubContents{files: map[string]string{}}
+	srv := httptest.NewServer(gh)
+	t.Cleanup(srv.Close)
+
+	b, err := NewGitHubBackup(GitHubBackupOptions{Repository: "acme/gtm-backup", Token: "secret", Containers: []string{"2", "gtm-xyz"}}, slog.New(slog.NewTextHandler(io.Discard, nil)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	b.apiURL = srv.URL
+	SetGitHubBackup(b)
+	t.Cleanup(func() { SetGitHubBackup(nil) })
+
+	backupVersion("1", "2", backupEventPublish, &tagmanager.ContainerVersion{ContainerVersionId: "1"})
+	backupVersion("1", "3", backupEventPublish, &tagmanager.ContainerVersion{ContainerVersionId: "1", Container: &tagmanager.Container{PublicId: "GTM-XYZ"}})
+	backupVersion("9", "4", backupEventPublish, &tagmanager.ContainerVersion{ContainerVersionId: "1", Container: &tagmanager.Container{PublicId: "GTM-OTHER"}})
+	b.wait()
+
+	if len(gh.files) != 2 || gh.files["containers/1/2.json"] == "" || gh.files["containers/1/3.json"] == "" {
+		t.Errorf("backed up %v, want only the listed containers", gh.files)
+	}
+}
+
+func TestNewGitHubBackup(t *testing.T) {
+	for _, repo := range []string{"", "acme", "acme/", "acme/a/b"} {
+		if _, err := NewGitHubBackup(GitHubBackupOptions{Repository: repo, Token: "x"}, slog.Default()); err == nil {
+			t.Errorf("repository %q accepted", repo)
+		}
+	}
+	if _, err := NewGitHubBackup(GitHubBackupOptions{Repository: "acme/backup"}, slog.Default()); err == nil {
+		t.Error("missing token accepted")
+	}
+}
+
+func TestBackupCommitMessage(t *testing.T) {
+	msg := backupCommitMessage("2", backupEventPublish, &tagmanager.ContainerVersion{ContainerVersionId: "3"})
+	if msg != "Publish 2 version 3" {
+		t.Errorf("message = %q", msg)
+	}
+}
@@ -0,0 +1,272 @@
+package gtm
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+
+	tagmanager "google.golang.org/api/tagmanager/v2"
+)
+
+// Dependency edge relations.
+const (
+	RelationFires      = "fires"      // trigger fires tag
+	RelationBlocks     = "blocks"     // trigger blocks tag
+	RelationReferences = "references" // variable is referenced by an entity
+	RelationSetup      = "setup"      // setup tag runs before tag
+	RelationTeardown   = "teardown"   // teardown tag runs after tag
+	RelationMember     = "member"     // trigger is a member of a trigger group
+)
+
+// GraphNode is an entity in the dependency graph. ID is "<kind>:<entityId>";
+// built-in variables use their type as entity ID.
+type GraphNode struct {
+	ID       string `json:"id"`
+	Kind     string `json:"kind"`
+	EntityID string `json:"entityId"`
+	Name     string `json:"name"`
+	Type     string `json:"type,omitempty"`
+}
+
+// GraphEdge points from a dependency to the entity that uses it.
+type GraphEdge struct {
+	From     string `json:"from"`
+	To       string `json:"to"`
+	Relation string `json:"relation"`
+}
+
+// DependencyGraph is the tag, trigger and variable dependency graph of a container.
+type DependencyGraph struct {
+	Nodes []GraphNode `json:"nodes"`
+	Edges []GraphEdge `json:"edges"`
+}
+
+func GraphNodeID(kind, id string) string {
+	return kind + ":" + id
+}
+
+// BuildDependencyGraph links triggers to the tags they fire or block, setup
+// and teardown tags to their tags, trigger group members to their group, and
+// variables (including built-ins) to every entity that references them.
+func BuildDependencyGraph(v *tagmanager.ContainerVersion) *DependencyGraph {
+	g := &DependencyGraph{Nodes: []GraphNode{}, Edges: []GraphEdge{}}
+	seen := make(map[string]bool)
+	addNode := func(n GraphNode) {
+		if !seen[n.ID] {
+			seen[n.ID] = true
+			g.Nodes = append(g.Nodes, n)
+		}
+	}
+	addEdge := func(from, to, relation string) {
+		if seen[from] && seen[to] {
+			g.Edges = append(g.Edges, GraphEdge{From: from, To: to, Relation: relation})
+		}
+	}
+
+	for _, t := range v.Tag {
+		addNode(GraphNode{ID: GraphNodeID("tag", t.TagId), Kind: "tag", EntityID: t.TagId, Name: t.Name, Type: t.Type})
+	}
+	for _, t := range v.Trigger {
+		addNode(GraphNode{ID: GraphNodeID("trigger", t.TriggerId), Kind: "trigger", EntityID: t.TriggerId, Name: t.Name, Type: t.Type})
+	}
+	variablesByName := make(map[string]string)
+	for _, vr := range v.Variable {
+		id := GraphNodeID("variable", vr.VariableId)
+		addNode(GraphNode{ID: id, Kind: "variable", EntityID: vr.VariableId, Name: vr.Name, Type: vr.Type})
+		variablesByName[vr.Name] = id
+	}
+	builtInsByName := make(map[string]*tagmanager.BuiltInVariable)
+	for _, b := range v.BuiltInVariable {
+		builtInsByName[b.Name] = b
+		// Custom event conditions reference the Event built-in as {{_event}}
+		if b.Type == "event" {
+			builtInsByName["_event"] = b
+		}
+	}
+	tagsByName := make(map[string]string)
+	for _, t := range v.Tag {
+		tagsByName[t.Name] = GraphNodeID("tag", t.TagId)
+	}
+
+	// linkRefs adds reference edges from every variable an entity uses. Built-in
+	// variables only become nodes once referenced.
+	linkRefs := func(entity any, to string) {
+		done := make(map[string]bool)
+		for _, name := range variableRefs(entity) {
+			from, ok := variablesByName[name]
+			if !ok {
+				b, ok := builtInsByName[name]
+				if !ok {
+					continue
+				}
+				from = GraphNodeID("builtInVariable", b.Type)
+				addNode(GraphNode{ID: from, Kind: "builtInVariable", EntityID: b.Type, Name: b.Name, Type: b.Type})
+			}
+			if !done[from] {
+				done[from] = true
+				addEdge(from, to, RelationReferences)
+			}
+		}
+	}
+
+	for _, t := range v.Tag {
+		id := GraphNodeID("tag", t.TagId)
+		for _, tr := range t.FiringTriggerId {
+			addEdge(GraphNodeID("trigger", tr), id, RelationFires)
+		}
+		for _, tr := range t.BlockingTriggerId {
+			addEdge(GraphNodeID("trigger", tr), id, RelationBlocks)
+		}
+		for _, s := range t.SetupTag {
+			if from, ok := tagsByName[s.TagName]; ok {
+				addEdge(from, id, RelationSetup)
+			}
+		}
+		for _, s := range t.TeardownTag {
+			if from, ok := tagsByName[s.TagName]; ok {
+				addEdge(from, id, RelationTeardown)
+			}
+		}
+		linkRefs(t, id)
+	}
+	for _, t := range v.Trigger {
+		id := GraphNodeID("trigger", t.TriggerId)
+		if t.Type == "triggerGroup" {
+			for _, p := range t.Parameter {
+				if p.Key != "triggerIds" {
+					continue
+				}
+				for _, member := range p.List {
+					addEdge(GraphNodeID("trigger", member.Value), id, RelationMember)
+				}
+			}
+		}
+		linkRefs(t, id)
+	}
+	for _, vr := range v.Variable {
+		linkRefs(vr, GraphNodeID("variable", vr.VariableId))
+	}
+
+	return g
+}
+
+// Neighborhood returns the subgraph of nodes at most depth edges away from
+// root, following edges in both directions, with the edges between them.
+func (g *DependencyGraph) Neighborhood(root string, depth int) *DependencyGraph {
+	dist := map[string]int{root: 0}
+	frontier := []string{root}
+	for d := 1; d <= depth && len(frontier) > 0; d++ {
+		var next []string
+		for _, e := range g.Edges {
+			for _, pair := range [][2]string{{e.From, e.To}, {e.To, e.From}} {
+				if _, done := dist[pair[1]]; !done && slices.Contains(frontier, pair[0]) {
+					dist[pair[1]] = d
+					next = append(next, pair[1])
+				}
+			}
+		}
+		frontier = next
+	}
+
+	sub := &DependencyGraph{Nodes: []GraphNode{}, Edges: []GraphEdge{}}
+	for _, n := range g.Nodes {
+		if _, ok := dist[n.ID]; ok {
+			sub.Nodes = append(sub.Nodes, n)
+		}
+	}
+	for _, e := range g.Edges {
+		_, from := dist[e.From]
+		_, to := dist[e.To]
+		if from && to {
+			sub.Edges = append(sub.Edges, e)
+		}
+	}
+	return sub
+}
+
+// mermaidIDRe matches characters not allowed in Mermaid node IDs.
+var mermaidIDRe = regexp.MustCompile(`[^A-Za-z0-9_]`)
+
+func mermaidID(id string) string {
+	return mermaidIDRe.ReplaceAllString(id, "_")
+}
+
+func mermaidLabel(n GraphNode) string {
+	label := strings.NewReplacer(`"`, "#quot;", "\n", " ").Replace(n.Name)
+	if n.Type != "" && n.Kind != "builtInVariable" {
+		label += "<br/><small>" + n.Type + "</small>"
+	}
+	return `"` + label + `"`
+}
+
+// Mermaid renders the graph as a Mermaid flowchart with one subgraph per entity kind.
+func (g *DependencyGraph) Mermaid() string {
+	var b strings.Builder
+	b.WriteString("flowchart LR\n")
+
+	groups := []struct{ kind, title, open, close string }{
+		{"trigger", "Triggers", "([", "])"},
+		{"tag", "Tags", "[", "]"},
+		{"variable", "Variables", "{{", "}}"},
+		{"builtInVariable", "Built-in Variables", "{{", "}}"},
+	}
+	for _, grp := range groups {
+		var nodes []GraphNode
+		for _, n := range g.Nodes {
+			if n.Kind == grp.kind {
+				nodes = append(nodes, n)
+			}
+		}
+		if len(nodes) == 0 {
+			continue
+		}
+		fmt.Fprintf(&b, "  subgraph %s[\"%s\"]\n", grp.kind, grp.title)
+		for _, n := range nodes {
+			fmt.Fprintf(&b, "    %s%s%s%s\n", mermaidID(n.ID), grp.open, mermaidLabel(n), grp.close)
+		}
+		b.WriteString("  end\n")
+	}
+
+	for _, e := range g.Edges {
+		arrow := "-->"
+		if e.Relation == RelationBlocks || e.Relation == RelationReferences {
+			arrow = "-.->"
+		}
+		fmt.Fprintf(&b, "  %s %s|%s| %s\n", mermaidID(e.From), arrow, e.Relation, mermaidID(e.To))
+	}
+	return b.String()
+}
+
+// DOT renders the graph in Graphviz DOT format.
+func (g *DependencyGraph) DOT() string {
+	shapes := map[string]string{
+		"tag":             "box",
+		"trigger":         "ellipse",
+		"variable":        "hexagon",
+		"builtInVariable": "hexagon",
+	}
+
+	var b strings.Builder
+	b.WriteString("digraph container {\n  rankdir=LR;\n")
+
+	nodes := append([]GraphNode(nil), g.Nodes...)
+	sort.SliceStable(nodes, func(i, j int) bool { return nodes[i].Kind < nodes[j].Kind })
+	for _, n := range nodes {
+		label := n.Name
+		if n.Type != "" && n.Kind != "builtInVariable" {
+			label += "\n" + n.Type
+		}
+		fmt.Fprintf(&b, "  %q [label=%q, shape=%s];\n", n.ID, label, shapes[n.Kind])
+	}
+	for _, e := range g.Edges {
+		style := ""
+		if e.Relation == RelationBlocks || e.Relation == RelationReferences {
+			style = ", style=dashed"
+		}
+		fmt.Fprintf(&b, "  %q -> %q [label=%q%s];\n", e.From, e.To, e.Relation, style)
+	}
+	b.WriteString("}\n")
+	return b.String()
+}
@@ -0,0 +1,129 @@
+package gtm
+
+import (
+	"strings"
+	"testing"
+
+	tagmanager "google.golang.org/api/tagmanager/v2"
+)
+
+func testGraphVersion() *tagmanager.ContainerVersion {
+	return &tagmanager.ContainerVersion{
+		Tag: []*tagmanager.Tag{
+			{TagId: "1", Name: "GA4 \"Purchase\"", Type: "gaawe", FiringTriggerId: []string{"10"}, BlockingTriggerId: []string{"11"},
+				Parameter: []*tagmanager.Parameter{{Type: "template", Key: "measurementIdOverride", Value: "{{GA4 ID}}"}},
+				SetupTag:  []*tagmanager.SetupTag{{TagName: "Consent Init"}}},
+			{TagId: "2", Name: "Consent Init", Type: "html", FiringTriggerId: []string{"10"}},
+		},
+		Trigger: []*tagmanager.Trigger{
+			{TriggerId: "10", Name: "Purchase Event", Type: "customEvent",
+				CustomEventFilter: []*tagmanager.Condition{{Type: "equals", Parameter: []*tagmanager.Parameter{
+					{Type: "template", Key: "arg0", Value: "{{_event}}"},
+					{Type: "template", Key: "arg1", Value: "purchase"},
+				}}}},
+			{TriggerId: "11", Name: "Internal Traffic", Type: "pageview"},
+			{TriggerId: "12", Name: "Group", Type: "triggerGroup", Parameter: []*tagmanager.Parameter{
+				{Type: "list", Key: "triggerIds", List: []*tagmanager.Parameter{{Type: "triggerReference", Value: "10"}}},
+			}},
+		},
+		Variable: []*tagmanager.Variable{
+			{VariableId: "20", Name: "GA4 ID", Type: "c"},
+			{VariableId: "21", Name: "Unused", Type: "c"},
+		},
+		BuiltInVariable: []*tagmanager.BuiltInVariable{
+			{Name: "Event", Type: "event"},
+			{Name: "Page URL", Type: "pageUrl"},
+		},
+	}
+}
+
+func TestBuildDependencyGraph(t *testing.T) {
+	g := BuildDependencyGraph(testGraphVersion())
+
+	edges := make(map[string]bool)
+	for _, e := range g.Edges {
+		edges[e.From+" "+e.Relation+" "+e.To] = true
+	}
+	for _, want := range []string{
+		"trigger:10 fires tag:1",
+		"trigger:11 blocks tag:1",
+		"tag:2 setup tag:1",
+		"variable:20 references tag:1",
+		"builtInVariable:event references trigger:10",
+		"trigger:10 member trigger:12",
+	} {
+		if !edges[want] {
+			t.Errorf("missing edge %q", want)
+		}
+	}
+	if len(g.Edges) != 7 {
+		t.Errorf("expected 7 edges, got %d: %v", len(g.Edges), g.Edges)
+	}
+
+	// Unreferenced built-ins are left out; unused variables are kept.
+	nodes := make(map[string]bool)
+	for _, n := range g.Nodes {
+		nodes[n.ID] = true
+	}
+	if nodes["builtInVariable:pageUrl"] {
+		t.Error("unreferenced built-in variable should not be a node")
+	}
+	if !nodes["variable:21"] {
+		t.Error("unused variable should still be a node")
+	}
+}
+
+func TestDependencyGraph_Render(t *testing.T) {
+	g := BuildDependencyGraph(testGraphVersion())
+
+	mermaid := g.Mermaid()
+	for _, want := range []string{
+		"flowchart LR\n",
+		`tag_1["GA4 #quot;Purchase#quot;<br/><small>gaawe</small>"]`,
+		`trigger_10(["Purchase Event<br/><small>customEvent</small>"])`,
+		"trigger_10 -->|fires| tag_1",
+		"trigger_11 -.->|blocks| tag_1",
+	} {
+		if !strings.Contains(mermaid, want) {
+			t.Errorf("mermaid output missing %q:\n%s", want, mermaid)
+		}
+	}
+
+	dot := g.DOT()
+	for _, want := range []string{
+		"digraph container {",
+		`"tag:1" [label="GA4 \"Purchase\"\ngaawe", shape=box];`,
+		`"trigger:11" -> "tag:1" [label="blocks", style=dashed];`,
+	} {
+		if !strings.Contains(dot, want) {
+			t.Errorf("dot output missing %q:\n%s", want, dot)
+		}
+	}
+}
+
+func TestDependencyGraph_Neighborhood(t *testing.T) {
+	g := BuildDependencyGraph(testGraphVersion())
+
+	sub := g.Neighborhood("variable:20", 1)
+	var ids []string
+	for _, n := range sub.Nodes {
+		ids = append(ids, n.ID)
+	}
+	if strings.Join(ids, ",") != "tag:1,variable:20" || len(sub.Edges) != 1 {
+		t.Errorf("depth 1 = %v, edges %v", ids, sub.Edges)
+	}
+
+	sub = g.Neighborhood("variable:20", 2)
+	nodes := make(map[string]bool)
+	for _, n := range sub.Nodes {
+		nodes[n.ID] = true
+	}
+	for _, want := range []string{"tag:1", "tag:2", "trigger:10", "trigger:11"} {
+		if !nodes[want] {
+			t.Errorf("depth 2 missing %s: %v", want, sub.Nodes)
+		}
+	}
+	if nodes["trigger:12"] || nodes["variable:21"] {
+		t.Errorf("depth 2 includes distant nodes: %v", sub.Nodes)
+	}
+}
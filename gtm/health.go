@@ -0,0 +1,67 @@
+package gtm
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// discoveryURL is the Tag Manager API discovery document, which needs no
+// credentials or quota and answers whenever the API is being served.
+const discoveryURL = "https://tagmanager.googleapis.com/$discovery/rest?version=v2"
+
+const (
+	// apiProbeTTL is how long a probe result is reused, so frequent
+	// readiness checks do not each call Google.
+	apiProbeTTL = 30 * time.Second
+	// apiProbeTimeout bounds one probe request.
+	apiProbeTimeout = 5 * time.Second
+)
+
+// APIProbe checks that the Tag Manager API is reachable from this instance.
+type APIProbe struct {
+	url    string
+	client *http.Client
+
+	mu      sync.Mutex
+	checked time.Time
+	err     error
+}
+
+// NewAPIProbe returns a probe fetching the Tag Manager discovery document.
+func NewAPIProbe() *APIProbe {
+	return &APIProbe{url: discoveryURL, client: &http.Client{Timeout: apiProbeTimeout}}
+}
+
+// Check returns nil when the Tag Manager API answered its discovery request
+// within the last apiProbeTTL, and the reason it did not otherwise.
+func (p *APIProbe) Check(ctx context.Context) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if !p.checked.IsZero() && time.Since(p.checked) < apiProbeTTL {
+		return p.err
+	}
+	p.err = p.fetch(ctx)
+	p.checked = time.Now()
+	return p.err
+}
+
+func (p *APIProbe) fetch(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, apiProbeTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, p.url, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("tag manager API unreachable: %w", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("tag manager API discovery returned %s", resp.Status)
+	}
+	return nil
+}
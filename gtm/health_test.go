@@ -0,0 +1,41 @@
+package gtm
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestAPIProbe(t *testing.T) {
+	status, calls := http.StatusOK, 0
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.WriteHeader(status)
+	}))
+	defer api.Close()
+
+	probe := NewAPIProbe()
+	probe.url = api.URL
+	for range 2 {
+		if err := probe.Check(context.Background()); err != nil {
+			t.Fatalf("Check() = %v", err)
+		}
+	}
+	if calls != 1 {
+		t.Errorf("calls = %d, want 1 (cached)", calls)
+	}
+
+	// Expired results are checked again
+	status = http.StatusServiceUnavailable
+	probe.checked = probe.checked.Add(-apiProbeTTL)
+	if err := probe.Check(context.Background()); err == nil || calls != 2 {
+		t.Errorf("Check() = %v, calls = %d", err, calls)
+	}
+
+	api.Close()
+	probe.checked = probe.checked.Add(-apiProbeTTL)
+	if err := probe.Check(context.Background()); err == nil {
+		t.Error("Check() on closed server = nil")
+	}
+}
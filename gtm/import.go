@@ -0,0 +1,596 @@
+package gtm
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	tagmanager "google.golang.org/api/tagmanager/v2"
+)
+
+// Import modes, matching the options of the GTM UI "Import Container" feature.
+const (
+	ImportModeOverwrite      = "overwrite"       // replace the workspace contents with the import
+	ImportModeMergeOverwrite = "merge_overwrite" // merge, overwriting entities with the same name
+	ImportModeMergeRename    = "merge_rename"    // merge, renaming imported entities whose name is taken
+)
+
+// ImportChange describes one planned or applied import action.
+type ImportChange struct {
+	EntityType string `json:"entityType"`
+	Name       string `json:"name"`
+	Action     string `json:"action"`               // "create", "update", "rename", "reuse", "delete" or "enable"
+	SourceName string `json:"sourceName,omitempty"` // original name when renamed
+}
+
+// ImportResult summarizes a container import.
+type ImportResult struct {
+	Changes []ImportChange `json:"changes"`
+	Created int            `json:"created"`
+	Updated int            `json:"updated"`
+	Renamed int            `json:"renamed"`
+	Reused  int            `json:"reused,omitempty"`
+	Deleted int            `json:"deleted"`
+}
+
+func (r *ImportResult) add(entityType, name, action, sourceName string) {
+	r.Changes = append(r.Changes, ImportChange{EntityType: entityType, Name: name, Action: action, SourceName: sourceName})
+	switch action {
+	case "create", "enable":
+		r.Created++
+	case "update":
+		r.Updated++
+	case "rename":
+		r.Renamed++
+		r.Created++
+	case "reuse":
+		r.Reused++
+	case "delete":
+		r.Deleted++
+	}
+}
+
+// ParseContainerExport decodes container JSON in the GTM UI export format.
+func ParseContainerExport(data []byte) (*ContainerExport, error) {
+	var export ContainerExport
+	if err := json.Unmarshal(data, &export); err != nil {
+		return nil, fmt.Errorf("%w: invalid container export JSON: %v", ErrInvalidRequest, err)
+	}
+	if export.ContainerVersion == nil {
+		return nil, fmt.Errorf("%w: container export has no containerVersion", ErrInvalidRequest)
+	}
+	return &export, nil
+}
+
+// ValidateImportMode checks that mode is a supported import mode.
+func ValidateImportMode(mode string) error {
+	switch mode {
+	case ImportModeOverwrite, ImportModeMergeOverwrite, ImportModeMergeRename:
+		return nil
+	}
+	return fmt.Errorf("invalid import mode '%s' (valid values: %s, %s, %s)",
+		mode, ImportModeOverwrite, ImportModeMergeOverwrite, ImportModeMergeRename)
+}
+
+// entityOps adapts one GTM entity type to the generic import steps.
+type entityOps[T any] struct {
+	kind   string
+	name   func(*T) *string
+	id     func(*T) string
+	path   func(*T) string
+	reset  func(*T) // clears IDs, paths and fingerprints of a source entity
+	create func(ctx context.Context, e *T) (*T, error)
+	update func(ctx context.Context, path string, e *T) (*T, error)
+	remove func(ctx context.Context, path string) error
+}
+
+// ImportOptions controls how ImportContainer applies an export.
+type ImportOptions struct {
+	Mode string
+	// Apply writes the changes. When false only the plan is computed.
+	Apply bool
+	// Reuse lists source entities, keyed by EntityKey, that map to an existing
+	// target entity with the same name instead of updating or renaming it.
+	Reuse map[string]bool
+}
+
+// EntityKey identifies a source entity in ImportOptions.Reuse, e.g. "trigger:12".
+func EntityKey(kind, id string) string {
+	return kind + ":" + id
+}
+
+// importMatch pairs an imported entity with the target entity it replaces.
+type importMatch[T any] struct {
+	item     *T
+	existing *T   // nil when the entity is created
+	reuse    bool // map to existing without writing
+}
+
+// importPlan holds the matched entities of one type.
+type importPlan[T any] struct {
+	ops     entityOps[T]
+	matches []importMatch[T]
+	deletes []*T
+}
+
+// planEntities matches imported entities to existing ones by name. In
+// merge_rename mode conflicting items are renamed in place and recorded in renames.
+func planEntities[T any](ops entityOps[T], items, existing []*T, opts ImportOptions, result *ImportResult, renames map[string]string) *importPlan[T] {
+	mode := opts.Mode
+	plan := &importPlan[T]{ops: ops}
+
+	byName := make(map[string]*T, len(existing))
+	taken := make(map[string]bool, len(existing)+len(items))
+	for _, e := range existing {
+		byName[*ops.name(e)] = e
+		taken[*ops.name(e)] = true
+	}
+
+	matched := make(map[*T]bool)
+	for _, item := range items {
+		name := *ops.name(item)
+		ex, conflict := byName[name]
+		switch {
+		case !conflict:
+			plan.matches = append(plan.matches, importMatch[T]{item: item})
+			result.add(ops.kind, name, "create", "")
+		case opts.Reuse[EntityKey(ops.kind, ops.id(item))]:
+			matched[ex] = true
+			plan.matches = append(plan.matches, importMatch[T]{item: item, existing: ex, reuse: true})
+			result.add(ops.kind, name, "reuse", "")
+		case mode == ImportModeMergeRename:
+			newName := uniqueImportName(name, taken)
+			*ops.name(item) = newName
+			if renames != nil {
+				renames[name] = newName
+			}
+			plan.matches = append(plan.matches, importMatch[T]{item: item})
+			result.add(ops.kind, newName, "rename", name)
+		default:
+			matched[ex] = true
+			plan.matches = append(plan.matches, importMatch[T]{item: item, existing: ex})
+			result.add(ops.kind, name, "update", "")
+		}
+		taken[*ops.name(item)] = true
+	}
+
+	if mode == ImportModeOverwrite {
+		for _, e := range existing {
+			if !matched[e] {
+				plan.deletes = append(plan.deletes, e)
+				result.add(ops.kind, *ops.name(e), "delete", "")
+			}
+		}
+	}
+	return plan
+}
+
+// uniqueImportName returns name with an "(imported)" suffix that is not in taken.
+func uniqueImportName(name string, taken map[string]bool) string {
+	candidate := name + " (imported)"
+	for i := 2; taken[candidate]; i++ {
+		candidate = fmt.Sprintf("%s (imported %d)", name, i)
+	}
+	return candidate
+}
+
+// apply creates or updates the matched entities and returns a map from source
+// entity ID to target entity ID.
+func (p *importPlan[T]) apply(ctx context.Context, matches []importMatch[T]) (map[string]string, error) {
+	ids := make(map[string]string, len(matches))
+	for _, m := range matches {
+		srcID := p.ops.id(m.item)
+		if m.reuse {
+			ids[srcID] = p.ops.id(m.existing)
+			continue
+		}
+		p.ops.reset(m.item)
+
+		var res *T
+		var err error
+		if m.existing != nil {
+			res, err = p.ops.update(ctx, p.ops.path(m.existing), m.item)
+		} else {
+			res, err = p.ops.create(ctx, m.item)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to import %s %q: %w", p.ops.kind, *p.ops.name(m.item), MapGoogleError(err))
+		}
+		ids[srcID] = p.ops.id(res)
+		StepProgress(ctx, "Imported %s %q", p.ops.kind, *p.ops.name(m.item))
+	}
+	return ids, nil
+}
+
+// removeUnmatched deletes existing entities that the import does not replace.
+func (p *importPlan[T]) removeUnmatched(ctx context.Context) error {
+	for _, e := range p.deletes {
+		if err := p.ops.remove(ctx, p.ops.path(e)); err != nil {
+			return fmt.Errorf("failed to delete %s %q: %w", p.ops.kind, *p.ops.name(e), MapGoogleError(err))
+		}
+		StepProgress(ctx, "Deleted %s %q", p.ops.kind, *p.ops.name(e))
+	}
+	return nil
+}
+
+// writes returns how many entities applying the plan creates, updates or
+// deletes.
+func (p *importPlan[T]) writes(mode string) int {
+	n := 0
+	for _, m := range p.matches {
+		if !m.reuse {
+			n++
+		}
+	}
+	if mode == ImportModeOverwrite {
+		n += len(p.deletes)
+	}
+	return n
+}
+
+// rewriteVariableRefs replaces {{Old Name}} references in every entity after
+// variables were renamed.
+func rewriteVariableRefs[T any](items []*T, renames map[string]string) error {
+	if len(renames) == 0 {
+		return nil
+	}
+
+	pairs := make([]string, 0, len(renames)*2)
+	for oldName, newName := range renames {
+		pairs = append(pairs, jsonEscape("{{"+oldName+"}}"), jsonEscape("{{"+newName+"}}"))
+	}
+	replacer := strings.NewReplacer(pairs...)
+
+	for _, item := range items {
+		data, err := json.Marshal(item)
+		if err != nil {
+			return err
+		}
+		var rewritten T
+		if err := json.Unmarshal([]byte(replacer.Replace(string(data))), &rewritten); err != nil {
+			return err
+		}
+		*item = rewritten // keep the pointer so plans still reference the item
+	}
+	return nil
+}
+
+// jsonEscape returns s as it appears inside a JSON string literal.
+func jsonEscape(s string) string {
+	data, _ := json.Marshal(s)
+	return string(data[1 : len(data)-1])
+}
+
+// remapIDs replaces source IDs with target IDs, keeping IDs that are not in the map
+// (e.g. built-in triggers).
+func remapIDs(ids []string, mapping map[string]string) []string {
+	result := make([]string, len(ids))
+	for i, id := range ids {
+		if mapped, ok := mapping[id]; ok {
+			id = mapped
+		}
+		result[i] = id
+	}
+	return result
+}
+
+// remapTriggerGroup rewrites the member trigger IDs of a trigger group.
+func remapTriggerGroup(trigger *tagmanager.Trigger, mapping map[string]string) {
+	for _, p := range trigger.Parameter {
+		if p.Key != "triggerIds" {
+			continue
+		}
+		for _, member := range p.List {
+			if mapped, ok := mapping[member.Value]; ok {
+				member.Value = mapped
+			}
+		}
+	}
+}
+
+// TemplateType returns the tag/variable type that references a custom template.
+func TemplateType(containerID, templateID string) string {
+	return fmt.Sprintf("cvt_%s_%s", containerID, templateID)
+}
+
+// ImportContainer imports a container export into a workspace.
+func (c *Client) ImportContainer(ctx context.Context, accountID, containerID, workspaceID string, export *ContainerExport, opts ImportOptions) (*ImportResult, error) {
+	mode := opts.Mode
+	if err := ValidateImportMode(mode); err != nil {
+		return nil, err
+	}
+
+	target, err := c.SnapshotWorkspace(ctx, accountID, containerID, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+
+	src := export.ContainerVersion
+	parent := BuildWorkspacePath(accountID, containerID, workspaceID)
+	if opts.Apply {
+		defer RecentMutations.Mark(ctx, parent)
+	}
+	ws := c.Service.Accounts.Containers.Workspaces
+	result := &ImportResult{Changes: []ImportChange{}}
+
+	folderOps := entityOps[tagmanager.Folder]{
+		kind: "folder",
+		name: func(e *tagmanager.Folder) *string { return &e.Name },
+		id:   func(e *tagmanager.Folder) string { return e.FolderId },
+		path: func(e *tagmanager.Folder) string { return e.Path },
+		reset: func(e *tagmanager.Folder) {
+			e.AccountId, e.ContainerId, e.WorkspaceId, e.FolderId, e.Path, e.Fingerprint, e.TagManagerUrl = "", "", "", "", "", "", ""
+		},
+		create: func(ctx context.Context, e *tagmanager.Folder) (*tagmanager.Folder, error) {
+			return ws.Folders.Create(parent, e).Context(ctx).Do()
+		},
+		update: func(ctx context.Context, path string, e *tagmanager.Folder) (*tagmanager.Folder, error) {
+			return ws.Folders.Update(path, e).Context(ctx).Do()
+		},
+		remove: func(ctx context.Context, path string) error { return ws.Folders.Delete(path).Context(ctx).Do() },
+	}
+	templateOps := entityOps[tagmanager.CustomTemplate]{
+		kind: "template",
+		name: func(e *tagmanager.CustomTemplate) *string { return &e.Name },
+		id:   func(e *tagmanager.CustomTemplate) string { return e.TemplateId },
+		path: func(e *tagmanager.CustomTemplate) string { return e.Path },
+		reset: func(e *tagmanager.CustomTemplate) {
+			e.AccountId, e.ContainerId, e.WorkspaceId, e.TemplateId, e.Path, e.Fingerprint, e.TagManagerUrl = "", "", "", "", "", "", ""
+		},
+		create: func(ctx context.Context, e *tagmanager.CustomTemplate) (*tagmanager.CustomTemplate, error) {
+			return ws.Templates.Create(parent, e).Context(ctx).Do()
+		},
+		update: func(ctx context.Context, path string, e *tagmanager.CustomTemplate) (*tagmanager.CustomTemplate, error) {
+			return ws.Templates.Update(path, e).Context(ctx).Do()
+		},
+		remove: func(ctx context.Context, path string) error { return ws.Templates.Delete(path).Context(ctx).Do() },
+	}
+	variableOps := entityOps[tagmanager.Variable]{
+		kind: "variable",
+		name: func(e *tagmanager.Variable) *string { return &e.Name },
+		id:   func(e *tagmanager.Variable) string { return e.VariableId },
+		path: func(e *tagmanager.Variable) string { return e.Path },
+		reset: func(e *tagmanager.Variable) {
+			e.AccountId, e.ContainerId, e.WorkspaceId, e.VariableId, e.Path, e.Fingerprint, e.TagManagerUrl = "", "", "", "", "", "", ""
+		},
+		create: func(ctx context.Context, e *tagmanager.Variable) (*tagmanager.Variable, error) {
+			return ws.Variables.Create(parent, e).Context(ctx).Do()
+		},
+		update: func(ctx context.Context, path string, e *tagmanager.Variable) (*tagmanager.Variable, error) {
+			return ws.Variables.Update(path, e).Context(ctx).Do()
+		},
+		remove: func(ctx context.Context, path string) error { return ws.Variables.Delete(path).Context(ctx).Do() },
+	}
+	triggerOps := entityOps[tagmanager.Trigger]{
+		kind: "trigger",
+		name: func(e *tagmanager.Trigger) *string { return &e.Name },
+		id:   func(e *tagmanager.Trigger) string { return e.TriggerId },
+		path: func(e *tagmanager.Trigger) string { return e.Path },
+		reset: func(e *tagmanager.Trigger) {
+			e.AccountId, e.ContainerId, e.WorkspaceId, e.TriggerId, e.Path, e.Fingerprint, e.TagManagerUrl = "", "", "", "", "", "", ""
+		},
+		create: func(ctx context.Context, e *tagmanager.Trigger) (*tagmanager.Trigger, error) {
+			return ws.Triggers.Create(parent, e).Context(ctx).Do()
+		},
+		update: func(ctx context.Context, path string, e *tagmanager.Trigger) (*tagmanager.Trigger, error) {
+			return ws.Triggers.Update(path, e).Context(ctx).Do()
+		},
+		remove: func(ctx context.Context, path string) error { return ws.Triggers.Delete(path).Context(ctx).Do() },
+	}
+	tagOps := entityOps[tagmanager.Tag]{
+		kind: "tag",
+		name: func(e *tagmanager.Tag) *string { return &e.Name },
+		id:   func(e *tagmanager.Tag) string { return e.TagId },
+		path: func(e *tagmanager.Tag) string { return e.Path },
+		reset: func(e *tagmanager.Tag) {
+			e.AccountId, e.ContainerId, e.WorkspaceId, e.TagId, e.Path, e.Fingerprint, e.TagManagerUrl = "", "", "", "", "", "", ""
+		},
+		create: func(ctx context.Context, e *tagmanager.Tag) (*tagmanager.Tag, error) {
+			return ws.Tags.Create(parent, e).Context(ctx).Do()
+		},
+		update: func(ctx context.Context, path string, e *tagmanager.Tag) (*tagmanager.Tag, error) {
+			return ws.Tags.Update(path, e).Context(ctx).Do()
+		},
+		remove: func(ctx context.Context, path string) error { return ws.Tags.Delete(path).Context(ctx).Do() },
+	}
+	clientOps := entityOps[tagmanager.Client]{
+		kind: "client",
+		name: func(e *tagmanager.Client) *string { return &e.Name },
+		id:   func(e *tagmanager.Client) string { return e.ClientId },
+		path: func(e *tagmanager.Client) string { return e.Path },
+		reset: func(e *tagmanager.Client) {
+			e.AccountId, e.ContainerId, e.WorkspaceId, e.ClientId, e.Path, e.Fingerprint, e.TagManagerUrl = "", "", "", "", "", "", ""
+		},
+		create: func(ctx context.Context, e *tagmanager.Client) (*tagmanager.Client, error) {
+			return ws.Clients.Create(parent, e).Context(ctx).Do()
+		},
+		update: func(ctx context.Context, path string, e *tagmanager.Client) (*tagmanager.Client, error) {
+			return ws.Clients.Update(path, e).Context(ctx).Do()
+		},
+		remove: func(ctx context.Context, path string) error { return ws.Clients.Delete(path).Context(ctx).Do() },
+	}
+	transformationOps := entityOps[tagmanager.Transformation]{
+		kind: "transformation",
+		name: func(e *tagmanager.Transformation) *string { return &e.Name },
+		id:   func(e *tagmanager.Transformation) string { return e.TransformationId },
+		path: func(e *tagmanager.Transformation) string { return e.Path },
+		reset: func(e *tagmanager.Transformation) {
+			e.AccountId, e.ContainerId, e.WorkspaceId, e.TransformationId, e.Path, e.Fingerprint, e.TagManagerUrl = "", "", "", "", "", "", ""
+		},
+		create: func(ctx context.Context, e *tagmanager.Transformation) (*tagmanager.Transformation, error) {
+			return ws.Transformations.Create(parent, e).Context(ctx).Do()
+		},
+		update: func(ctx context.Context, path string, e *tagmanager.Transformation) (*tagmanager.Transformation, error) {
+			return ws.Transformations.Update(path, e).Context(ctx).Do()
+		},
+		remove: func(ctx context.Context, path string) error { return ws.Transformations.Delete(path).Context(ctx).Do() },
+	}
+
+	// Plan every entity type. Variables and tags are referenced by name, so
+	// renames are tracked and rewritten before anything is written.
+	variableRenames := make(map[string]string)
+	tagRenames := make(map[string]string)
+
+	folders := planEntities(folderOps, src.Folder, target.Folder, opts, result, nil)
+	templates := planEntities(templateOps, src.CustomTemplate, target.CustomTemplate, opts, result, nil)
+	variables := planEntities(variableOps, src.Variable, target.Variable, opts, result, variableRenames)
+	triggers := planEntities(triggerOps, src.Trigger, target.Trigger, opts, result, nil)
+	tags := planEntities(tagOps, src.Tag, target.Tag, opts, result, tagRenames)
+	clients := planEntities(clientOps, src.Client, target.Client, opts, result, nil)
+	transformations := planEntities(transformationOps, src.Transformation, target.Transformation, opts, result, nil)
+
+	enabled := make(map[string]bool, len(target.BuiltInVariable))
+	for _, b := range target.BuiltInVariable {
+		enabled[b.Type] = true
+	}
+	var builtIns []string
+	for _, b := range src.BuiltInVariable {
+		if !enabled[b.Type] {
+			builtIns = append(builtIns, b.Type)
+			result.add("builtInVariable", b.Name, "enable", "")
+		}
+	}
+
+	if !opts.Apply {
+		return result, nil
+	}
+	ExpectProgress(ctx, folders.writes(mode)+templates.writes(mode)+variables.writes(mode)+triggers.writes(mode)+
+		tags.writes(mode)+clients.writes(mode)+transformations.writes(mode))
+
+	if err := rewriteVariableRefs(src.Variable, variableRenames); err != nil {
+		return nil, err
+	}
+	if err := rewriteVariableRefs(src.Trigger, variableRenames); err != nil {
+		return nil, err
+	}
+	if err := rewriteVariableRefs(src.Tag, variableRenames); err != nil {
+		return nil, err
+	}
+	if err := rewriteVariableRefs(src.Client, variableRenames); err != nil {
+		return nil, err
+	}
+	if err := rewriteVariableRefs(src.Transformation, variableRenames); err != nil {
+		return nil, err
+	}
+	for _, tag := range src.Tag {
+		for _, s := range tag.SetupTag {
+			if renamed, ok := tagRenames[s.TagName]; ok {
+				s.TagName = renamed
+			}
+		}
+		for _, t := range tag.TeardownTag {
+			if renamed, ok := tagRenames[t.TagName]; ok {
+				t.TagName = renamed
+			}
+		}
+	}
+	folderIDs, err := folders.apply(ctx, folders.matches)
+	if err != nil {
+		return nil, err
+	}
+
+	templateIDs, err := templates.apply(ctx, templates.matches)
+	if err != nil {
+		return nil, err
+	}
+	templateTypes := make(map[string]string, len(templateIDs))
+	for srcID, targetID := range templateIDs {
+		templateTypes[TemplateType(src.ContainerId, srcID)] = TemplateType(containerID, targetID)
+	}
+	remapType := func(t string) string {
+		if mapped, ok := templateTypes[t]; ok {
+			return mapped
+		}
+		return t
+	}
+	remapFolder := func(id string) string {
+		if mapped, ok := folderIDs[id]; ok {
+			return mapped
+		}
+		return id
+	}
+
+	if len(builtIns) > 0 {
+		if _, err := c.EnableBuiltInVariables(ctx, accountID, containerID, workspaceID, builtIns); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, v := range src.Variable {
+		v.Type = remapType(v.Type)
+		v.ParentFolderId = remapFolder(v.ParentFolderId)
+	}
+	if _, err := variables.apply(ctx, variables.matches); err != nil {
+		return nil, err
+	}
+
+	// Trigger groups reference other triggers, so create them last.
+	var plain, groups []importMatch[tagmanager.Trigger]
+	for _, m := range triggers.matches {
+		m.item.ParentFolderId = remapFolder(m.item.ParentFolderId)
+		if m.item.Type == "triggerGroup" {
+			groups = append(groups, m)
+		} else {
+			plain = append(plain, m)
+		}
+	}
+	triggerIDs, err := triggers.apply(ctx, plain)
+	if err != nil {
+		return nil, err
+	}
+	for _, m := range groups {
+		remapTriggerGroup(m.item, triggerIDs)
+	}
+	groupIDs, err := triggers.apply(ctx, groups)
+	if err != nil {
+		return nil, err
+	}
+	for srcID, targetID := range groupIDs {
+		triggerIDs[srcID] = targetID
+	}
+
+	for _, tag := range src.Tag {
+		tag.Type = remapType(tag.Type)
+		tag.ParentFolderId = remapFolder(tag.ParentFolderId)
+		tag.FiringTriggerId = remapIDs(tag.FiringTriggerId, triggerIDs)
+		tag.BlockingTriggerId = remapIDs(tag.BlockingTriggerId, triggerIDs)
+	}
+	if _, err := tags.apply(ctx, tags.matches); err != nil {
+		return nil, err
+	}
+
+	for _, cl := range src.Client {
+		cl.Type = remapType(cl.Type)
+		cl.ParentFolderId = remapFolder(cl.ParentFolderId)
+	}
+	if _, err := clients.apply(ctx, clients.matches); err != nil {
+		return nil, err
+	}
+	for _, t := range src.Transformation {
+		t.Type = remapType(t.Type)
+		t.ParentFolderId = remapFolder(t.ParentFolderId)
+	}
+	if _, err := transformations.apply(ctx, transformations.matches); err != nil {
+		return nil, err
+	}
+
+	// Overwrite mode: remove what the import did not replace, dependents first.
+	if mode == ImportModeOverwrite {
+		for _, remove := range []func(context.Context) error{
+			tags.removeUnmatched,
+			triggers.removeUnmatched,
+			variables.removeUnmatched,
+			clients.removeUnmatched,
+			transformations.removeUnmatched,
+			templates.removeUnmatched,
+			folders.removeUnmatched,
+		} {
+			if err := remove(ctx); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return result, nil
+}
@@ -0,0 +1,110 @@
+package gtm
+
+import (
+	"testing"
+
+	tagmanager "google.golang.org/api/tagmanager/v2"
+)
+
+var testVariableOps = entityOps[tagmanager.Variable]{
+	kind: "variable",
+	name: func(e *tagmanager.Variable) *string { return &e.Name },
+	id:   func(e *tagmanager.Variable) string { return e.VariableId },
+	path: func(e *tagmanager.Variable) string { return e.Path },
+}
+
+func TestPlanEntities(t *testing.T) {
+	tests := []struct {
+		mode                                  string
+		wantCreated, wantUpdated, wantRenamed int
+		wantDeleted                           int
+	}{
+		{ImportModeOverwrite, 1, 1, 0, 1},
+		{ImportModeMergeOverwrite, 1, 1, 0, 0},
+		{ImportModeMergeRename, 2, 0, 1, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.mode, func(t *testing.T) {
+			items := []*tagmanager.Variable{{VariableId: "1", Name: "Page Type"}, {VariableId: "2", Name: "New Var"}}
+			existing := []*tagmanager.Variable{{VariableId: "10", Name: "Page Type"}, {VariableId: "11", Name: "Old Var"}}
+
+			result := &ImportResult{}
+			renames := map[string]string{}
+			plan := planEntities(testVariableOps, items, existing, ImportOptions{Mode: tt.mode}, result, renames)
+
+			if result.Created != tt.wantCreated || result.Updated != tt.wantUpdated ||
+				result.Renamed != tt.wantRenamed || result.Deleted != tt.wantDeleted {
+				t.Errorf("got %+v", result)
+			}
+			if len(plan.deletes) != tt.wantDeleted {
+				t.Errorf("expected %d deletes, got %d", tt.wantDeleted, len(plan.deletes))
+			}
+			if tt.mode == ImportModeMergeRename {
+				if renames["Page Type"] != "Page Type (imported)" || items[0].Name != "Page Type (imported)" {
+					t.Errorf("expected rename, got %v / %q", renames, items[0].Name)
+				}
+			}
+		})
+	}
+}
+
+func TestUniqueImportName(t *testing.T) {
+	taken := map[string]bool{"A (imported)": true, "A (imported 2)": true}
+	if got := uniqueImportName("A", taken); got != "A (imported 3)" {
+		t.Errorf("got %q", got)
+	}
+}
+
+func TestRewriteVariableRefs(t *testing.T) {
+	tags := []*tagmanager.Tag{{
+		Name: "GA4 Event",
+		Parameter: []*tagmanager.Parameter{
+			{Type: "template", Key: "eventName", Value: "{{Event \"Name\"}}"},
+			{Type: "list", Key: "params", List: []*tagmanager.Parameter{
+				{Type: "template", Key: "value", Value: "prefix-{{Page Type}}"},
+			}},
+		},
+	}}
+	original := tags[0]
+
+	err := rewriteVariableRefs(tags, map[string]string{
+		"Page Type":    "Page Type (imported)",
+		`Event "Name"`: `Event "Name" (imported)`,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if tags[0] != original {
+		t.Error("expected item pointer to be preserved")
+	}
+	if got := tags[0].Parameter[0].Value; got != `{{Event "Name" (imported)}}` {
+		t.Errorf("unexpected value %q", got)
+	}
+	if got := tags[0].Parameter[1].List[0].Value; got != "prefix-{{Page Type (imported)}}" {
+		t.Errorf("unexpected nested value %q", got)
+	}
+}
+
+func TestRemapIDs(t *testing.T) {
+	got := remapIDs([]string{"5", "2147479553"}, map[string]string{"5": "42"})
+	if got[0] != "42" || got[1] != "2147479553" {
+		t.Errorf("got %v", got)
+	}
+}
+
+func TestParseContainerExport(t *testing.T) {
+	if _, err := ParseContainerExport([]byte(`{"exportFormatVersion": 2}`)); err == nil {
+		t.Error("expected error for export without containerVersion")
+	}
+	if _, err := ParseContainerExport([]byte(`not json`)); err == nil {
+		t.Error("expected error for invalid JSON")
+	}
+	export, err := ParseContainerExport([]byte(`{"exportFormatVersion": 2, "containerVersion": {"tag": [{"name": "A"}]}}`))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if export.Counts().Tags != 1 {
+		t.Errorf("expected 1 tag, got %d", export.Counts().Tags)
+	}
+}
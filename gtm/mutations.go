@@ -3,6 +3,7 @@ package gtm
 import (
 	"context"
 	"fmt"
+	"strconv"
 
 	tagmanager "google.golang.org/api/tagmanager/v2"
 )
@@ -20,12 +21,18 @@ func (c *Client) CreateTag(ctx context.Context, accountID, containerID, workspac
 		Notes:             input.Notes,
 		Paused:            input.Paused,
 		TagFiringOption:   input.TagFiringOption,
+		ScheduleStartMs:   input.ScheduleStartMs,
+		ScheduleEndMs:     input.ScheduleEndMs,
+		ConsentSettings:   toAPIConsentSettings(input.ConsentSettings),
+		Priority:          toAPIPriority(input.Priority),
+		ParentFolderId:    input.ParentFolderId,
 	}
 
 	result, err := c.Service.Accounts.Containers.Workspaces.Tags.Create(parent, tag).Context(ctx).Do()
 	if err != nil {
-		return nil, mapGoogleError(err)
+		return nil, MapGoogleError(err)
 	}
+	RecentMutations.Mark(ctx, result.Path)
 
 	return &CreatedTag{
 		TagID:       result.TagId,
@@ -36,31 +43,26 @@ func (c *Client) CreateTag(ctx context.Context, accountID, containerID, workspac
 	}, nil
 }
 
-// UpdateTag updates an existing tag. It fetches the current tag first to get the fingerprint.
-func (c *Client) UpdateTag(ctx context.Context, path string, input *TagInput) (*CreatedTag, error) {
-	// Get current tag for fingerprint
-	current, err := c.Service.Accounts.Containers.Workspaces.Tags.Get(path).Context(ctx).Do()
+// UpdateTag applies a partial update to an existing tag. It fetches the current
+// tag first, so the fingerprint and any fields not in the patch are kept.
+func (c *Client) UpdateTag(ctx context.Context, path string, patch *TagPatch) (*CreatedTag, error) {
+	// Get the current tag so fields not in the patch are preserved
+	tag, err := readAfterMutation(ctx, path, func() (*tagmanager.Tag, error) {
+		return c.Service.Accounts.Containers.Workspaces.Tags.Get(path).Context(ctx).Do()
+	})
 	if err != nil {
-		return nil, mapGoogleError(err)
+		return nil, MapGoogleError(err)
 	}
 
-	// Build updated tag with fingerprint
-	tag := &tagmanager.Tag{
-		Name:              input.Name,
-		Type:              input.Type,
-		FiringTriggerId:   input.FiringTriggerId,
-		BlockingTriggerId: input.BlockingTriggerId,
-		Parameter:         toAPIParams(input.Parameter),
-		Notes:             input.Notes,
-		Paused:            input.Paused,
-		TagFiringOption:   input.TagFiringOption,
-		Fingerprint:       current.Fingerprint,
+	if err := applyTagPatch(tag, patch); err != nil {
+		return nil, err
 	}
 
 	result, err := c.Service.Accounts.Containers.Workspaces.Tags.Update(path, tag).Context(ctx).Do()
 	if err != nil {
-		return nil, mapGoogleError(err)
+		return nil, MapGoogleError(err)
 	}
+	RecentMutations.Mark(ctx, result.Path)
 
 	return &CreatedTag{
 		TagID:       result.TagId,
@@ -74,7 +76,7 @@ func (c *Client) UpdateTag(ctx context.Context, path string, input *TagInput) (*
 // DeleteTag deletes a tag from the workspace.
 func (c *Client) DeleteTag(ctx context.Context, path string) error {
 	err := c.Service.Accounts.Containers.Workspaces.Tags.Delete(path).Context(ctx).Do()
-	return mapGoogleError(err)
+	return MapGoogleError(err)
 }
 
 // CreateTrigger creates a new trigger in the workspace.
@@ -89,11 +91,13 @@ func (c *Client) CreateTrigger(ctx context.Context, accountID, containerID, work
 		CustomEventFilter: toAPIConditions(input.CustomEventFilter),
 		Parameter:         toAPIParams(input.Parameter),
 		Notes:             input.Notes,
+		ParentFolderId:    input.ParentFolderId,
 	}
 
 	if input.EventName != nil {
 		trigger.EventName = toAPIParam(input.EventName)
 	}
+	applyTriggerFields(trigger, input)
 
 	// For click/form triggers with autoEventFilter, set required companion fields
 	if len(input.AutoEventFilter) > 0 && (input.Type == "linkClick" || input.Type == "formSubmission" || input.Type == "click") {
@@ -104,8 +108,9 @@ func (c *Client) CreateTrigger(ctx context.Context, accountID, containerID, work
 
 	result, err := c.Service.Accounts.Containers.Workspaces.Triggers.Create(parent, trigger).Context(ctx).Do()
 	if err != nil {
-		return nil, mapGoogleError(err)
+		return nil, MapGoogleError(err)
 	}
+	RecentMutations.Mark(ctx, result.Path)
 
 	return &CreatedTrigger{
 		TriggerID:   result.TriggerId,
@@ -119,18 +124,41 @@ func (c *Client) CreateTrigger(ctx context.Context, accountID, containerID, work
 // DeleteTrigger deletes a trigger from the workspace.
 func (c *Client) DeleteTrigger(ctx context.Context, path string) error {
 	err := c.Service.Accounts.Containers.Workspaces.Triggers.Delete(path).Context(ctx).Do()
-	return mapGoogleError(err)
+	return MapGoogleError(err)
 }
 
 // UpdateTrigger updates an existing trigger. It fetches the current trigger first to get the fingerprint.
 // Fields not provided in input are preserved from the current trigger.
 func (c *Client) UpdateTrigger(ctx context.Context, path string, input *TriggerInput) (*CreatedTrigger, error) {
 	// Get current trigger for fingerprint and to preserve unset fields
-	current, err := c.Service.Accounts.Containers.Workspaces.Triggers.Get(path).Context(ctx).Do()
+	current, err := readAfterMutation(ctx, path, func() (*tagmanager.Trigger, error) {
+		return c.Service.Accounts.Containers.Workspaces.Triggers.Get(path).Context(ctx).Do()
+	})
+	if err != nil {
+		return nil, MapGoogleError(err)
+	}
+
+	trigger := mergeTriggerUpdate(current, input)
+
+	result, err := c.Service.Accounts.Containers.Workspaces.Triggers.Update(path, trigger).Fingerprint(current.Fingerprint).Context(ctx).Do()
 	if err != nil {
-		return nil, mapGoogleError(err)
+		return nil, MapGoogleError(err)
 	}
+	RecentMutations.Mark(ctx, result.Path)
 
+	return &CreatedTrigger{
+		TriggerID:   result.TriggerId,
+		Name:        result.Name,
+		Type:        result.Type,
+		Path:        result.Path,
+		Fingerprint: result.Fingerprint,
+	}, nil
+}
+
+// mergeTriggerUpdate builds the trigger an update sends: the fields of input,
+// with filters, parameters, folder, event name and type-specific settings
+// kept from current where input leaves them out.
+func mergeTriggerUpdate(current *tagmanager.Trigger, input *TriggerInput) *tagmanager.Trigger {
 	// Preserve existing fields when not provided in input
 	filter := toAPIConditions(input.Filter)
 	if filter == nil {
@@ -148,6 +176,10 @@ func (c *Client) UpdateTrigger(ctx context.Context, path string, input *TriggerI
 	if params == nil {
 		params = current.Parameter
 	}
+	parentFolderID := input.ParentFolderId
+	if parentFolderID == "" {
+		parentFolderID = current.ParentFolderId
+	}
 
 	trigger := &tagmanager.Trigger{
 		Name:              input.Name,
@@ -157,6 +189,7 @@ func (c *Client) UpdateTrigger(ctx context.Context, path string, input *TriggerI
 		CustomEventFilter: customEventFilter,
 		Parameter:         params,
 		Notes:             input.Notes,
+		ParentFolderId:    parentFolderID,
 		// Preserve trigger-specific fields from current trigger (exclude auto-generated ones)
 		CheckValidation:                current.CheckValidation,
 		WaitForTags:                    current.WaitForTags,
@@ -182,6 +215,7 @@ func (c *Client) UpdateTrigger(ctx context.Context, path string, input *TriggerI
 	} else {
 		trigger.EventName = current.EventName
 	}
+	applyTriggerFields(trigger, input)
 
 	// For click/form triggers with autoEventFilter, ensure companion fields have proper boolean values
 	// (not empty template params which indicate "All Clicks" mode)
@@ -196,19 +230,7 @@ func (c *Client) UpdateTrigger(ctx context.Context, path string, input *TriggerI
 			trigger.CheckValidation = &tagmanager.Parameter{Type: "boolean", Value: "false"}
 		}
 	}
-
-	result, err := c.Service.Accounts.Containers.Workspaces.Triggers.Update(path, trigger).Fingerprint(current.Fingerprint).Context(ctx).Do()
-	if err != nil {
-		return nil, mapGoogleError(err)
-	}
-
-	return &CreatedTrigger{
-		TriggerID:   result.TriggerId,
-		Name:        result.Name,
-		Type:        result.Type,
-		Path:        result.Path,
-		Fingerprint: result.Fingerprint,
-	}, nil
+	return trigger
 }
 
 // CreateVariable creates a new variable in the workspace.
@@ -216,16 +238,19 @@ func (c *Client) CreateVariable(ctx context.Context, accountID, containerID, wor
 	parent := BuildWorkspacePath(accountID, containerID, workspaceID)
 
 	variable := &tagmanager.Variable{
-		Name:      input.Name,
-		Type:      input.Type,
-		Parameter: toAPIParams(input.Parameter),
-		Notes:     input.Notes,
+		Name:           input.Name,
+		Type:           input.Type,
+		Parameter:      toAPIParams(input.Parameter),
+		Notes:          input.Notes,
+		ParentFolderId: input.ParentFolderId,
+		FormatValue:    toAPIFormatValue(input.FormatValue),
 	}
 
 	result, err := c.Service.Accounts.Containers.Workspaces.Variables.Create(parent, variable).Context(ctx).Do()
 	if err != nil {
-		return nil, mapGoogleError(err)
+		return nil, MapGoogleError(err)
 	}
+	RecentMutations.Mark(ctx, result.Path)
 
 	return &CreatedVariable{
 		VariableID:  result.VariableId,
@@ -236,26 +261,27 @@ func (c *Client) CreateVariable(ctx context.Context, accountID, containerID, wor
 	}, nil
 }
 
-// UpdateVariable updates an existing variable. It fetches the current variable first to get the fingerprint.
-func (c *Client) UpdateVariable(ctx context.Context, path string, input *VariableInput) (*CreatedVariable, error) {
-	// Get current variable for fingerprint
-	current, err := c.Service.Accounts.Containers.Workspaces.Variables.Get(path).Context(ctx).Do()
+// UpdateVariable applies a partial update to an existing variable. It fetches
+// the current variable first, so the fingerprint and any fields not in the
+// patch are kept.
+func (c *Client) UpdateVariable(ctx context.Context, path string, patch *VariablePatch) (*CreatedVariable, error) {
+	// Get the current variable so fields not in the patch are preserved
+	variable, err := readAfterMutation(ctx, path, func() (*tagmanager.Variable, error) {
+		return c.Service.Accounts.Containers.Workspaces.Variables.Get(path).Context(ctx).Do()
+	})
 	if err != nil {
-		return nil, mapGoogleError(err)
+		return nil, MapGoogleError(err)
 	}
 
-	variable := &tagmanager.Variable{
-		Name:        input.Name,
-		Type:        input.Type,
-		Parameter:   toAPIParams(input.Parameter),
-		Notes:       input.Notes,
-		Fingerprint: current.Fingerprint,
+	if err := applyVariablePatch(variable, patch); err != nil {
+		return nil, err
 	}
 
 	result, err := c.Service.Accounts.Containers.Workspaces.Variables.Update(path, variable).Context(ctx).Do()
 	if err != nil {
-		return nil, mapGoogleError(err)
+		return nil, MapGoogleError(err)
 	}
+	RecentMutations.Mark(ctx, result.Path)
 
 	return &CreatedVariable{
 		VariableID:  result.VariableId,
@@ -269,37 +295,27 @@ func (c *Client) UpdateVariable(ctx context.Context, path string, input *Variabl
 // DeleteVariable deletes a variable from the workspace.
 func (c *Client) DeleteVariable(ctx context.Context, path string) error {
 	err := c.Service.Accounts.Containers.Workspaces.Variables.Delete(path).Context(ctx).Do()
-	return mapGoogleError(err)
+	return MapGoogleError(err)
 }
 
-func toAPIParams(params []Parameter) []*tagmanager.Parameter {
-	if len(params) == 0 {
+// toAPIPriority converts a tag firing priority to the integer parameter GTM expects.
+func toAPIPriority(priority *int) *tagmanager.Parameter {
+	if priority == nil {
 		return nil
 	}
-	result := make([]*tagmanager.Parameter, len(params))
-	for i, p := range params {
-		result[i] = toAPIParam(&p)
-	}
-	return result
+	return &tagmanager.Parameter{Type: ParamInteger, Value: strconv.Itoa(*priority)}
 }
 
-func toAPIParam(p *Parameter) *tagmanager.Parameter {
+// tagPriority returns the firing priority of a tag, or nil if it has none.
+func tagPriority(p *tagmanager.Parameter) *int {
 	if p == nil {
 		return nil
 	}
-	param := &tagmanager.Parameter{
-		Type:            p.Type,
-		Key:             p.Key,
-		Value:           p.Value,
-		ForceSendFields: []string{"Type", "Key", "Value"},
-	}
-	if len(p.List) > 0 {
-		param.List = toAPIParams(p.List)
-	}
-	if len(p.Map) > 0 {
-		param.Map = toAPIParams(p.Map)
+	priority, err := strconv.Atoi(p.Value)
+	if err != nil {
+		return nil
 	}
-	return param
+	return &priority
 }
 
 func toAPIConditions(conditions []Condition) []*tagmanager.Condition {
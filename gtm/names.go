@@ -0,0 +1,42 @@
+package gtm
+
+import (
+	"fmt"
+	"strings"
+)
+
+// NamedEntity is the ID and display name of a workspace entity.
+type NamedEntity struct {
+	ID   string
+	Name string
+}
+
+// MatchEntityName returns the ID of the entity with the given name. An exact
+// match wins; otherwise a single case-insensitive match is accepted.
+func MatchEntityName(kind, name string, entities []NamedEntity) (string, error) {
+	var exact, folded []NamedEntity
+	for _, e := range entities {
+		switch {
+		case e.Name == name:
+			exact = append(exact, e)
+		case strings.EqualFold(strings.TrimSpace(e.Name), strings.TrimSpace(name)):
+			folded = append(folded, e)
+		}
+	}
+
+	matches := exact
+	if len(matches) == 0 {
+		matches = folded
+	}
+	switch len(matches) {
+	case 0:
+		return "", fmt.Errorf("%w: no %s named %q in this workspace", ErrNotFound, kind, name)
+	case 1:
+		return matches[0].ID, nil
+	}
+	ids := make([]string, len(matches))
+	for i, m := range matches {
+		ids[i] = m.ID
+	}
+	return "", fmt.Errorf("%w: %d %ss match name %q (IDs %s); use the ID instead", ErrInvalidRequest, len(matches), kind, name, strings.Join(ids, ", "))
+}
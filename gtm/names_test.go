@@ -0,0 +1,39 @@
+package gtm
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestMatchEntityName(t *testing.T) {
+	entities := []NamedEntity{
+		{"1", "GA4 - Page View"},
+		{"2", "ga4 - page view"},
+		{"3", "CE - Purchase"},
+		{"4", "DLV - Value"},
+		{"5", "dlv - value"},
+	}
+	tests := []struct {
+		name    string
+		query   string
+		want    string
+		wantErr error
+	}{
+		{"exact match wins over case-insensitive", "GA4 - Page View", "1", nil},
+		{"unique case-insensitive match", "ce - purchase", "3", nil},
+		{"surrounding whitespace ignored", " CE - Purchase ", "3", nil},
+		{"ambiguous case-insensitive match", "Dlv - Value", "", ErrInvalidRequest},
+		{"no match", "Missing", "", ErrNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := MatchEntityName(EntityTypeTag, tt.query, entities)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("error = %v, want %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
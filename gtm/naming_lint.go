@@ -0,0 +1,172 @@
+package gtm
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// NamingRule is the naming convention of one entity type. Prefixes map GTM
+// types (e.g. gaawe, customEvent, v) to the prefix names of that type
+// start with; they are used to suggest compliant names.
+type NamingRule struct {
+	Pattern     string            `yaml:"pattern" json:"pattern" jsonschema:"description:Regular expression every name must match"`
+	Prefixes    map[string]string `yaml:"prefixes" json:"prefixes,omitempty" jsonschema:"description:Name prefix per GTM type (e.g. gaawe: 'GA4 - '), used to suggest compliant names"`
+	Description string            `yaml:"description" json:"description,omitempty" jsonschema:"description:The convention in words, shown with each violation"`
+}
+
+// NamingRules are the naming conventions keyed by entity type: tag,
+// trigger or variable. A type without a rule is not checked.
+type NamingRules map[string]NamingRule
+
+// DefaultNamingRules is the common "Kind - Detail" convention, used when
+// neither the server nor the tool call supplies rules.
+var DefaultNamingRules = NamingRules{
+	"tag": {
+		Pattern:     `^[A-Z][A-Za-z0-9]* - \S.*$`,
+		Description: "Platform - Detail, e.g. GA4 - Event - purchase",
+		Prefixes: map[string]string{
+			"googtag": "GA4 - ", "gaawc": "GA4 - ", "gaawe": "GA4 - Event - ",
+			"awct": "Ads - Conversion - ", "sp": "Ads - Remarketing - ", "gclidw": "Ads - ",
+			"flc": "Floodlight - ", "fls": "Floodlight - ", "html": "HTML - ", "img": "IMG - ",
+		},
+	},
+	"trigger": {
+		Pattern:     `^[A-Z][A-Za-z0-9]* - \S.*$`,
+		Description: "Kind - Detail, e.g. CE - purchase",
+		Prefixes: map[string]string{
+			"customEvent": "CE - ", "pageview": "PV - ", "domReady": "DOM - ", "windowLoaded": "WL - ",
+			"click": "Click - ", "linkClick": "Link - ", "formSubmission": "Form - ", "timer": "Timer - ",
+			"historyChange": "History - ", "scrollDepth": "Scroll - ", "elementVisibility": "Visibility - ",
+			"youTubeVideo": "YouTube - ", "triggerGroup": "Group - ", "jsError": "JS Error - ",
+		},
+	},
+	"variable": {
+		Pattern:     `^[A-Z][A-Za-z0-9]* - \S.*$`,
+		Description: "Kind - Detail, e.g. DLV - ecommerce.value",
+		Prefixes: map[string]string{
+			"v": "DLV - ", "c": "C - ", "jsm": "CJS - ", "j": "JS - ", "u": "URL - ", "k": "Cookie - ",
+			"d": "DOM - ", "smm": "LT - ", "remm": "RT - ", "gtes": "GTES - ", "gtcs": "GTCS - ",
+		},
+	},
+}
+
+// namePrefixPattern matches a leading "Kind - " segment, which is replaced
+// by the suggested prefix when Kind is one the rule uses.
+var namePrefixPattern = regexp.MustCompile(`^([A-Za-z0-9]+)(\s+-\s+|\s*[|:]\s*)`)
+
+// NamingViolation is an entity whose name breaks its naming rule.
+type NamingViolation struct {
+	EntityType string `json:"entityType"`
+	EntityID   string `json:"entityId"`
+	Name       string `json:"name"`
+	Type       string `json:"type"`
+	Path       string `json:"path"`
+	Rule       string `json:"rule"`
+	Suggestion string `json:"suggestion,omitempty"`
+}
+
+// LoadNamingRules reads a YAML naming rules file keyed by entity type.
+func LoadNamingRules(path string) (NamingRules, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read naming rules: %w", err)
+	}
+	var rules NamingRules
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+	if err := dec.Decode(&rules); err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("invalid naming rules: %w", err)
+	}
+	if _, err := rules.compile(); err != nil {
+		return nil, fmt.Errorf("invalid naming rules: %w", err)
+	}
+	return rules, nil
+}
+
+// compile checks the rules and compiles their patterns.
+func (rules NamingRules) compile() (map[string]*regexp.Regexp, error) {
+	patterns := make(map[string]*regexp.Regexp, len(rules))
+	for entityType, rule := range rules {
+		switch entityType {
+		case "tag", "trigger", "variable":
+		default:
+			return nil, InvalidField("rules", "unknown entity type %q (use tag, trigger or variable)", entityType)
+		}
+		re, err := regexp.Compile(rule.Pattern)
+		if err != nil || rule.Pattern == "" {
+			return nil, InvalidField("rules."+entityType+".pattern", "invalid pattern %q", rule.Pattern)
+		}
+		patterns[entityType] = re
+	}
+	return patterns, nil
+}
+
+// LintNames checks the names of entities against rules. A suggestion is
+// the name with the rule's prefix for the entity's GTM type, replacing a
+// leading "Kind - " segment whose Kind starts one of the rule's prefixes.
+// It is offered only when it complies and is not taken by another entity
+// of the same type.
+func LintNames(rules NamingRules, entities *WorkspaceEntities) ([]NamingViolation, error) {
+	patterns, err := rules.compile()
+	if err != nil {
+		return nil, err
+	}
+	type entity struct{ id, name, typ, path string }
+	byType := map[string][]entity{}
+	for _, t := range entities.Tags {
+		byType["tag"] = append(byType["tag"], entity{t.TagID, t.Name, t.Type, t.Path})
+	}
+	for _, t := range entities.Triggers {
+		byType["trigger"] = append(byType["trigger"], entity{t.TriggerID, t.Name, t.Type, t.Path})
+	}
+	for _, v := range entities.Variables {
+		byType["variable"] = append(byType["variable"], entity{v.VariableID, v.Name, v.Type, v.Path})
+	}
+
+	violations := []NamingViolation{}
+	for _, entityType := range []string{"tag", "trigger", "variable"} {
+		re, ok := patterns[entityType]
+		if !ok {
+			continue
+		}
+		rule := rules[entityType]
+		kinds := make(map[string]bool, len(rule.Prefixes))
+		for _, prefix := range rule.Prefixes {
+			if fields := strings.Fields(prefix); len(fields) > 0 {
+				kinds[strings.ToLower(fields[0])] = true
+			}
+		}
+		taken := make(map[string]bool, len(byType[entityType]))
+		for _, e := range byType[entityType] {
+			taken[e.name] = true
+		}
+		for _, e := range byType[entityType] {
+			if re.MatchString(e.name) {
+				continue
+			}
+			v := NamingViolation{EntityType: entityType, EntityID: e.id, Name: e.name, Type: e.typ, Path: e.path, Rule: rule.Pattern}
+			if rule.Description != "" {
+				v.Rule = rule.Description + " (" + rule.Pattern + ")"
+			}
+			if prefix, ok := rule.Prefixes[e.typ]; ok {
+				base := strings.TrimSpace(e.name)
+				if m := namePrefixPattern.FindStringSubmatch(base); m != nil && kinds[strings.ToLower(m[1])] && len(m[0]) < len(base) {
+					base = base[len(m[0]):]
+				}
+				if suggestion := prefix + base; re.MatchString(suggestion) && !taken[suggestion] {
+					v.Suggestion = suggestion
+					taken[suggestion] = true
+				}
+			}
+			violations = append(violations, v)
+		}
+	}
+	return violations, nil
+}
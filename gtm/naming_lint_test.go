@@ -0,0 +1,95 @@
+package gtm
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestLintNames_DefaultRules(t *testing.T) {
+	entities := &WorkspaceEntities{
+		Tags: []Tag{
+			{TagID: "1", Name: "GA4 - Event - purchase", Type: "gaawe"},
+			{TagID: "2", Name: "purchase event", Type: "gaawe"},
+			{TagID: "3", Name: "html: chat widget", Type: "html"},
+			{TagID: "4", Name: "checkout: step 1", Type: "html"},
+		},
+		Triggers: []Trigger{
+			{TriggerID: "5", Name: "purchase", Type: "customEvent"},
+			{TriggerID: "6", Name: "CE - purchase", Type: "customEvent"},
+		},
+		Variables: []Variable{
+			{VariableID: "7", Name: "ecommerce.value", Type: "v"},
+			{VariableID: "8", Name: "my var", Type: "aev"},
+		},
+	}
+
+	violations, err := LintNames(DefaultNamingRules, entities)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]string{
+		"2": "GA4 - Event - purchase event",
+		"3": "HTML - chat widget",
+		"4": "HTML - checkout: step 1",
+		"5": "", // CE - purchase is taken
+		"7": "DLV - ecommerce.value",
+		"8": "", // no prefix for the type
+	}
+	if len(violations) != len(want) {
+		t.Fatalf("violations = %+v", violations)
+	}
+	for _, v := range violations {
+		suggestion, ok := want[v.EntityID]
+		if !ok || v.Suggestion != suggestion {
+			t.Errorf("%s %q: suggestion %q, want %q", v.EntityType, v.Name, v.Suggestion, suggestion)
+		}
+	}
+}
+
+func TestLintNames_CustomRules(t *testing.T) {
+	rules := NamingRules{"variable": {Pattern: `^[a-z_]+$`}}
+	entities := &WorkspaceEntities{
+		Tags:      []Tag{{TagID: "1", Name: "anything goes"}},
+		Variables: []Variable{{VariableID: "2", Name: "page_type"}, {VariableID: "3", Name: "Page Type"}},
+	}
+	violations, err := LintNames(rules, entities)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(violations) != 1 || violations[0].EntityID != "3" {
+		t.Errorf("violations = %+v", violations)
+	}
+
+	for name, bad := range map[string]NamingRules{
+		"unknown type":  {"folder": {Pattern: ".*"}},
+		"bad pattern":   {"tag": {Pattern: "("}},
+		"empty pattern": {"tag": {}},
+	} {
+		if _, err := LintNames(bad, entities); !errors.Is(err, ErrInvalidRequest) {
+			t.Errorf("%s: err = %v, want ErrInvalidRequest", name, err)
+		}
+	}
+}
+
+func TestLoadNamingRules(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "naming.yaml")
+	if err := os.WriteFile(path, []byte("tag:\n  pattern: ^GA4 - .+$\n  prefixes: {gaawe: 'GA4 - '}\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	rules, err := LoadNamingRules(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rules["tag"].Prefixes["gaawe"] != "GA4 - " {
+		t.Errorf("rules = %+v", rules)
+	}
+
+	if err := os.WriteFile(path, []byte("tag:\n  regex: x\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadNamingRules(path); err == nil {
+		t.Error("rules with an unknown field accepted")
+	}
+}
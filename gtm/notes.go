@@ -0,0 +1,120 @@
+package gtm
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+
+	tagmanager "google.golang.org/api/tagmanager/v2"
+)
+
+// EntityNote is a generated or approved note for an entity without notes.
+type EntityNote struct {
+	EntityType string `json:"entityType"`
+	EntityID   string `json:"entityId"`
+	Name       string `json:"name,omitempty"`
+	Notes      string `json:"notes"`
+	Error      string `json:"error,omitempty"`
+}
+
+// NoteCandidate is a workspace entity whose notes are empty.
+type NoteCandidate struct {
+	EntityNote
+	Summary NoteContext
+	Apply   func(ctx context.Context, notes string) error
+}
+
+// NoteContext is what the model sees of an entity when drafting its notes.
+// Field names match the API so any entity kind decodes into it.
+type NoteContext struct {
+	Name              string                  `json:"name"`
+	Type              string                  `json:"type"`
+	Parameter         []*tagmanager.Parameter `json:"parameter,omitempty"`
+	FiringTriggerID   []string                `json:"firingTriggerId,omitempty"`
+	Filter            any                     `json:"filter,omitempty"`
+	AutoEventFilter   any                     `json:"autoEventFilter,omitempty"`
+	CustomEventFilter any                     `json:"customEventFilter,omitempty"`
+}
+
+// NewNoteContext copies an entity into a NoteContext with sensitive
+// parameter values redacted. The entity itself is not modified.
+func NewNoteContext(entity any) NoteContext {
+	var nc NoteContext
+	if data, err := json.Marshal(entity); err == nil {
+		_ = json.Unmarshal(data, &nc)
+	}
+	redaction.params(nc.Parameter, redaction.sensitive(nc.Name))
+	return nc
+}
+
+// EntitiesWithoutNotes returns the tags, triggers and variables of a
+// workspace with empty notes, limited to kinds.
+func (c *Client) EntitiesWithoutNotes(ctx context.Context, accountID, containerID, workspaceID string, kinds map[string]bool) ([]NoteCandidate, error) {
+	parent := BuildWorkspacePath(accountID, containerID, workspaceID)
+	ws := c.Service.Accounts.Containers.Workspaces
+	var candidates []NoteCandidate
+
+	if kinds[EntityTypeTag] {
+		tags, err := listAllTags(ctx, ws, parent)
+		if err != nil {
+			return nil, MapGoogleError(err)
+		}
+		for _, t := range tags {
+			if strings.TrimSpace(t.Notes) != "" {
+				continue
+			}
+			candidates = append(candidates, NoteCandidate{
+				EntityNote: EntityNote{EntityType: EntityTypeTag, EntityID: t.TagId, Name: t.Name},
+				Summary:    NewNoteContext(t),
+				Apply: func(ctx context.Context, notes string) error {
+					t.Notes = notes
+					_, err := ws.Tags.Update(t.Path, t).Fingerprint(t.Fingerprint).Context(ctx).Do()
+					return err
+				},
+			})
+		}
+	}
+
+	if kinds[EntityTypeTrigger] {
+		triggers, err := listAllTriggers(ctx, ws, parent)
+		if err != nil {
+			return nil, MapGoogleError(err)
+		}
+		for _, t := range triggers {
+			if strings.TrimSpace(t.Notes) != "" {
+				continue
+			}
+			candidates = append(candidates, NoteCandidate{
+				EntityNote: EntityNote{EntityType: EntityTypeTrigger, EntityID: t.TriggerId, Name: t.Name},
+				Summary:    NewNoteContext(t),
+				Apply: func(ctx context.Context, notes string) error {
+					t.Notes = notes
+					_, err := ws.Triggers.Update(t.Path, t).Fingerprint(t.Fingerprint).Context(ctx).Do()
+					return err
+				},
+			})
+		}
+	}
+
+	if kinds[EntityTypeVariable] {
+		variables, err := listAllVariables(ctx, ws, parent)
+		if err != nil {
+			return nil, MapGoogleError(err)
+		}
+		for _, v := range variables {
+			if strings.TrimSpace(v.Notes) != "" {
+				continue
+			}
+			candidates = append(candidates, NoteCandidate{
+				EntityNote: EntityNote{EntityType: EntityTypeVariable, EntityID: v.VariableId, Name: v.Name},
+				Summary:    NewNoteContext(v),
+				Apply: func(ctx context.Context, notes string) error {
+					v.Notes = notes
+					_, err := ws.Variables.Update(v.Path, v).Fingerprint(v.Fingerprint).Context(ctx).Do()
+					return err
+				},
+			})
+		}
+	}
+	return candidates, nil
+}
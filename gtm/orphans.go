@@ -0,0 +1,102 @@
+package gtm
+
+import (
+	"strconv"
+	"time"
+
+	tagmanager "google.golang.org/api/tagmanager/v2"
+)
+
+// DefaultPausedTagDays is how long a tag must have been paused (unmodified)
+// before find_orphans reports it.
+const DefaultPausedTagDays = 30
+
+// OrphanEntity is an entity that nothing uses.
+type OrphanEntity struct {
+	EntityID     string    `json:"entityId"`
+	Name         string    `json:"name"`
+	Type         string    `json:"type"`
+	Path         string    `json:"path"`
+	LastModified time.Time `json:"lastModified,omitzero"`
+	AgeDays      int       `json:"ageDays,omitempty"`
+}
+
+// Orphans groups the unused entities of a workspace.
+type Orphans struct {
+	Triggers   []OrphanEntity `json:"triggers"`
+	Variables  []OrphanEntity `json:"variables"`
+	PausedTags []OrphanEntity `json:"pausedTags"`
+}
+
+// Total returns the number of orphaned entities.
+func (o *Orphans) Total() int {
+	return len(o.Triggers) + len(o.Variables) + len(o.PausedTags)
+}
+
+// fingerprintTime returns the modification time encoded in an entity
+// fingerprint (epoch milliseconds), or the zero time.
+func fingerprintTime(fingerprint string) time.Time {
+	ms, err := strconv.ParseInt(fingerprint, 10, 64)
+	if err != nil {
+		return time.Time{}
+	}
+	return time.UnixMilli(ms).UTC()
+}
+
+// FindOrphans reports triggers that fire, block or group nothing, variables
+// no entity references, and tags paused and unmodified for at least
+// pausedDays.
+func FindOrphans(v *tagmanager.ContainerVersion, now time.Time, pausedDays int) *Orphans {
+	g := BuildDependencyGraph(v)
+	used := make(map[string]bool)
+	for _, e := range g.Edges {
+		used[e.From] = true
+	}
+	// Clients, transformations and zones can reference variables too
+	referenced := make(map[string]bool)
+	var others []any
+	for _, c := range v.Client {
+		others = append(others, c)
+	}
+	for _, t := range v.Transformation {
+		others = append(others, t)
+	}
+	for _, z := range v.Zone {
+		others = append(others, z)
+	}
+	for _, entity := range others {
+		for _, name := range variableRefs(entity) {
+			referenced[name] = true
+		}
+	}
+
+	o := &Orphans{Triggers: []OrphanEntity{}, Variables: []OrphanEntity{}, PausedTags: []OrphanEntity{}}
+	for _, t := range v.Trigger {
+		if !used[GraphNodeID("trigger", t.TriggerId)] {
+			o.Triggers = append(o.Triggers, orphan(t.TriggerId, t.Name, t.Type, t.Path, t.Fingerprint, now))
+		}
+	}
+	for _, vr := range v.Variable {
+		if !used[GraphNodeID("variable", vr.VariableId)] && !referenced[vr.Name] {
+			o.Variables = append(o.Variables, orphan(vr.VariableId, vr.Name, vr.Type, vr.Path, vr.Fingerprint, now))
+		}
+	}
+	for _, t := range v.Tag {
+		if !t.Paused {
+			continue
+		}
+		entity := orphan(t.TagId, t.Name, t.Type, t.Path, t.Fingerprint, now)
+		if !entity.LastModified.IsZero() && entity.AgeDays >= pausedDays {
+			o.PausedTags = append(o.PausedTags, entity)
+		}
+	}
+	return o
+}
+
+func orphan(id, name, typ, path, fingerprint string, now time.Time) OrphanEntity {
+	e := OrphanEntity{EntityID: id, Name: name, Type: typ, Path: path, LastModified: fingerprintTime(fingerprint)}
+	if !e.LastModified.IsZero() {
+		e.AgeDays = int(now.Sub(e.LastModified).Hours() / 24)
+	}
+	return e
+}
@@ -0,0 +1,58 @@
+package gtm
+
+import (
+	"strconv"
+	"testing"
+	"time"
+
+	tagmanager "google.golang.org/api/tagmanager/v2"
+)
+
+func TestFindOrphans(t *testing.T) {
+	now := time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC)
+	fp := func(daysAgo int) string {
+		return strconv.FormatInt(now.AddDate(0, 0, -daysAgo).UnixMilli(), 10)
+	}
+
+	v := testGraphVersion()
+	v.Trigger = append(v.Trigger, &tagmanager.Trigger{TriggerId: "13", Name: "Unused Click", Type: "click", Path: "accounts/1/containers/2/workspaces/3/triggers/13"})
+	v.Variable = append(v.Variable, &tagmanager.Variable{VariableId: "22", Name: "Client Var", Type: "c"})
+	v.Client = []*tagmanager.Client{{Name: "GA4", Parameter: []*tagmanager.Parameter{{Type: "template", Key: "x", Value: "{{Client Var}}"}}}}
+	v.Tag = append(v.Tag,
+		&tagmanager.Tag{TagId: "3", Name: "Old Paused", Type: "html", Paused: true, Fingerprint: fp(45)},
+		&tagmanager.Tag{TagId: "4", Name: "New Paused", Type: "html", Paused: true, Fingerprint: fp(3)},
+	)
+
+	o := FindOrphans(v, now, 30)
+
+	// Trigger 12 is a group no tag uses; 10 is used, 11 blocks a tag.
+	if got := orphanIDs(o.Triggers); got != "12,13" {
+		t.Errorf("orphan triggers = %s, want 12,13", got)
+	}
+	if o.Triggers[1].Path == "" {
+		t.Error("orphan trigger path missing")
+	}
+	if got := orphanIDs(o.Variables); got != "21" {
+		t.Errorf("orphan variables = %s, want 21", got)
+	}
+	if got := orphanIDs(o.PausedTags); got != "3" {
+		t.Errorf("paused tags = %s, want 3", got)
+	}
+	if o.PausedTags[0].AgeDays != 45 {
+		t.Errorf("paused tag age = %d, want 45", o.PausedTags[0].AgeDays)
+	}
+	if o.Total() != 4 {
+		t.Errorf("total = %d, want 4", o.Total())
+	}
+}
+
+func orphanIDs(entities []OrphanEntity) string {
+	var s string
+	for i, e := range entities {
+		if i > 0 {
+			s += ","
+		}
+		s += e.EntityID
+	}
+	return s
+}
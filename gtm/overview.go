@@ -0,0 +1,186 @@
+package gtm
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"golang.org/x/sync/errgroup"
+)
+
+// WorkspaceOverview is a compact snapshot of a workspace for orientation:
+// counts, entity names and types, and which tags each trigger fires.
+type WorkspaceOverview struct {
+	Path             string                  `json:"path"`
+	Counts           OverviewCounts          `json:"counts"`
+	Changes          *WorkspaceChangeSummary `json:"changes"`
+	Tags             []OverviewTag           `json:"tags"`
+	Triggers         []OverviewTrigger       `json:"triggers"`
+	Variables        []CompactEntity         `json:"variables"`
+	BuiltInVariables []string                `json:"builtInVariables"`
+	Folders          []CompactEntity         `json:"folders"`
+}
+
+// OverviewCounts counts the entities of a workspace.
+type OverviewCounts struct {
+	Tags             int            `json:"tags"`
+	PausedTags       int            `json:"pausedTags,omitempty"`
+	Triggers         int            `json:"triggers"`
+	UnusedTriggers   int            `json:"unusedTriggers,omitempty"`
+	Variables        int            `json:"variables"`
+	BuiltInVariables int            `json:"builtInVariables"`
+	Folders          int            `json:"folders"`
+	TagTypes         map[string]int `json:"tagTypes,omitempty"`
+}
+
+// OverviewTag is a tag with the names of its firing and blocking triggers.
+type OverviewTag struct {
+	CompactEntity
+	Paused    bool     `json:"paused,omitempty"`
+	FiresOn   []string `json:"firesOn,omitempty"`
+	BlockedBy []string `json:"blockedBy,omitempty"`
+}
+
+// OverviewTrigger is a trigger with the names of the tags it fires.
+type OverviewTrigger struct {
+	CompactEntity
+	Fires []string `json:"fires,omitempty"`
+}
+
+// GetWorkspaceOverview fetches the entities, built-in variables, folders and
+// status of a workspace concurrently and summarizes them.
+func (c *Client) GetWorkspaceOverview(ctx context.Context, accountID, containerID, workspaceID string) (*WorkspaceOverview, error) {
+	var (
+		entities *WorkspaceEntities
+		builtIns []BuiltInVariable
+		folders  []Folder
+		status   *WorkspaceStatus
+	)
+	g, ctx := errgroup.WithContext(ctx)
+
+	g.Go(func() error {
+		var err error
+		entities, err = c.ListWorkspaceEntities(ctx, accountID, containerID, workspaceID)
+		return err
+	})
+	g.Go(func() error {
+		var err error
+		if builtIns, err = c.ListBuiltInVariables(ctx, accountID, containerID, workspaceID); err != nil {
+			return fmt.Errorf("failed to list built-in variables: %w", err)
+		}
+		return nil
+	})
+	g.Go(func() error {
+		var err error
+		if folders, err = c.ListFolders(ctx, accountID, containerID, workspaceID); err != nil {
+			return fmt.Errorf("failed to list folders: %w", err)
+		}
+		return nil
+	})
+	g.Go(func() error {
+		var err error
+		if status, err = c.GetWorkspaceStatus(ctx, accountID, containerID, workspaceID); err != nil {
+			return fmt.Errorf("failed to get workspace status: %w", err)
+		}
+		return nil
+	})
+
+	if err := g.Wait(); err != nil {
+		return nil, err
+	}
+
+	overview := buildWorkspaceOverview(entities, builtIns, folders, status)
+	overview.Path = BuildWorkspacePath(accountID, containerID, workspaceID)
+	return overview, nil
+}
+
+// buildWorkspaceOverview summarizes fetched workspace data.
+func buildWorkspaceOverview(entities *WorkspaceEntities, builtIns []BuiltInVariable, folders []Folder, status *WorkspaceStatus) *WorkspaceOverview {
+	triggerNames := make(map[string]string, len(entities.Triggers)+len(BuiltInTriggerNames))
+	for id, name := range BuiltInTriggerNames {
+		triggerNames[id] = name
+	}
+	for _, t := range entities.Triggers {
+		triggerNames[t.TriggerID] = t.Name
+	}
+	names := func(ids []string) []string {
+		result := make([]string, 0, len(ids))
+		for _, id := range ids {
+			if name, ok := triggerNames[id]; ok {
+				result = append(result, name)
+			} else {
+				result = append(result, "trigger "+id)
+			}
+		}
+		return result
+	}
+
+	o := &WorkspaceOverview{
+		Changes:          SummarizeChanges(status),
+		Tags:             make([]OverviewTag, 0, len(entities.Tags)),
+		Triggers:         make([]OverviewTrigger, 0, len(entities.Triggers)),
+		Variables:        make([]CompactEntity, 0, len(entities.Variables)),
+		BuiltInVariables: make([]string, 0, len(builtIns)),
+		Folders:          make([]CompactEntity, 0, len(folders)),
+		Counts: OverviewCounts{
+			Tags:             len(entities.Tags),
+			Triggers:         len(entities.Triggers),
+			Variables:        len(entities.Variables),
+			BuiltInVariables: len(builtIns),
+			Folders:          len(folders),
+			TagTypes:         make(map[string]int),
+		},
+	}
+
+	fires := make(map[string][]string) // trigger ID -> tag names
+	for _, t := range entities.Tags {
+		o.Tags = append(o.Tags, OverviewTag{
+			CompactEntity: CompactEntity{ID: t.TagID, Name: t.Name, Type: t.Type},
+			Paused:        t.Paused,
+			FiresOn:       names(t.FiringTriggerID),
+			BlockedBy:     names(t.BlockingTriggerID),
+		})
+		o.Counts.TagTypes[t.Type]++
+		if t.Paused {
+			o.Counts.PausedTags++
+		}
+		for _, id := range t.FiringTriggerID {
+			fires[id] = append(fires[id], t.Name)
+		}
+	}
+
+	for _, t := range entities.Triggers {
+		o.Triggers = append(o.Triggers, OverviewTrigger{
+			CompactEntity: CompactEntity{ID: t.TriggerID, Name: t.Name, Type: t.Type},
+			Fires:         fires[t.TriggerID],
+		})
+		if len(fires[t.TriggerID]) == 0 {
+			o.Counts.UnusedTriggers++
+		}
+	}
+	// Built-in triggers are not listed by the API but still fire tags
+	var builtInIDs []string
+	for id := range fires {
+		if _, ok := BuiltInTriggerNames[id]; ok {
+			builtInIDs = append(builtInIDs, id)
+		}
+	}
+	sort.Strings(builtInIDs)
+	for _, id := range builtInIDs {
+		o.Triggers = append(o.Triggers, OverviewTrigger{
+			CompactEntity: CompactEntity{ID: id, Name: BuiltInTriggerNames[id], Type: "builtIn"},
+			Fires:         fires[id],
+		})
+	}
+
+	for _, v := range entities.Variables {
+		o.Variables = append(o.Variables, CompactEntity{ID: v.VariableID, Name: v.Name, Type: v.Type})
+	}
+	for _, b := range builtIns {
+		o.BuiltInVariables = append(o.BuiltInVariables, b.Type)
+	}
+	for _, f := range folders {
+		o.Folders = append(o.Folders, CompactEntity{ID: f.FolderID, Name: f.Name})
+	}
+	return o
+}
@@ -0,0 +1,52 @@
+package gtm
+
+import (
+	"reflect"
+	"slices"
+	"testing"
+)
+
+func TestBuildWorkspaceOverview(t *testing.T) {
+	entities := &WorkspaceEntities{
+		Tags: []Tag{
+			{TagID: "1", Name: "GA4 Config", Type: "googtag", FiringTriggerID: []string{"2147479553"}},
+			{TagID: "2", Name: "GA4 Purchase", Type: "gaawe", FiringTriggerID: []string{"10"}, BlockingTriggerID: []string{"11"}},
+			{TagID: "3", Name: "Old Pixel", Type: "html", Paused: true, FiringTriggerID: []string{"10"}},
+		},
+		Triggers: []Trigger{
+			{TriggerID: "10", Name: "Purchase", Type: "customEvent"},
+			{TriggerID: "11", Name: "Internal Traffic", Type: "pageview"},
+		},
+		Variables: []Variable{{VariableID: "20", Name: "Measurement ID", Type: "c"}},
+	}
+	builtIns := []BuiltInVariable{{Name: "Page URL", Type: "pageUrl"}}
+	folders := []Folder{{FolderID: "30", Name: "GA4"}}
+	status := &WorkspaceStatus{ChangeCount: 1, Changes: []WorkspaceChange{{EntityType: "tag"}}}
+
+	o := buildWorkspaceOverview(entities, builtIns, folders, status)
+
+	want := OverviewCounts{
+		Tags: 3, PausedTags: 1, Triggers: 2, UnusedTriggers: 1, Variables: 1, BuiltInVariables: 1, Folders: 1,
+		TagTypes: map[string]int{"googtag": 1, "gaawe": 1, "html": 1},
+	}
+	if !reflect.DeepEqual(o.Counts, want) {
+		t.Errorf("counts = %+v, want %+v", o.Counts, want)
+	}
+	if o.Changes == nil || o.Changes.Tags != 1 {
+		t.Errorf("changes = %+v", o.Changes)
+	}
+
+	if tag := o.Tags[1]; !slices.Equal(tag.FiresOn, []string{"Purchase"}) || !slices.Equal(tag.BlockedBy, []string{"Internal Traffic"}) {
+		t.Errorf("tag = %+v", tag)
+	}
+	if trigger := o.Triggers[0]; !slices.Equal(trigger.Fires, []string{"GA4 Purchase", "Old Pixel"}) {
+		t.Errorf("trigger = %+v", trigger)
+	}
+	// The built-in All Pages trigger is listed because a tag fires on it
+	if len(o.Triggers) != 3 || o.Triggers[2].Name != "All Pages" || !slices.Equal(o.Triggers[2].Fires, []string{"GA4 Config"}) {
+		t.Errorf("triggers = %+v", o.Triggers)
+	}
+	if !slices.Equal(o.BuiltInVariables, []string{"pageUrl"}) || o.Folders[0].Name != "GA4" {
+		t.Errorf("built-ins = %v, folders = %+v", o.BuiltInVariables, o.Folders)
+	}
+}
@@ -0,0 +1,103 @@
+package gtm
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	tagmanager "google.golang.org/api/tagmanager/v2"
+)
+
+const (
+	// maxListPages caps how many API pages a single list follows. The API
+	// returns up to 200 entities per page, far beyond GTM's container limits.
+	maxListPages = 50
+
+	// DefaultPageSize is how many entities list tools return per page.
+	DefaultPageSize = 100
+	// MaxPageSize is the largest pageSize list tools accept.
+	MaxPageSize = 500
+	// MaxListAll caps how many entities list tools return with all=true.
+	MaxListAll = 2000
+)
+
+// listAllPages fetches every page of a GTM list call, following
+// nextPageToken. page extracts a response's entities and next page token.
+func listAllPages[R, T any](ctx context.Context, fetch func(pageToken string) (R, error), page func(R) ([]T, string)) ([]T, error) {
+	var all []T
+	token := ""
+	for i := 0; i < maxListPages; i++ {
+		resp, err := RetryWithBackoff(ctx, func() (R, error) {
+			return fetch(token)
+		})
+		if err != nil {
+			return nil, err
+		}
+		items, next := page(resp)
+		all = append(all, items...)
+		if next == "" {
+			return all, nil
+		}
+		token = next
+	}
+	return nil, fmt.Errorf("list has more than %d pages", maxListPages)
+}
+
+// listAllTags returns every tag in the workspace at parent.
+func listAllTags(ctx context.Context, ws *tagmanager.AccountsContainersWorkspacesService, parent string) ([]*tagmanager.Tag, error) {
+	return listAllPages(ctx, func(pageToken string) (*tagmanager.ListTagsResponse, error) {
+		return ws.Tags.List(parent).PageToken(pageToken).Context(ctx).Do()
+	}, func(resp *tagmanager.ListTagsResponse) ([]*tagmanager.Tag, string) {
+		return resp.Tag, resp.NextPageToken
+	})
+}
+
+// listAllTriggers returns every trigger in the workspace at parent.
+func listAllTriggers(ctx context.Context, ws *tagmanager.AccountsContainersWorkspacesService, parent string) ([]*tagmanager.Trigger, error) {
+	return listAllPages(ctx, func(pageToken string) (*tagmanager.ListTriggersResponse, error) {
+		return ws.Triggers.List(parent).PageToken(pageToken).Context(ctx).Do()
+	}, func(resp *tagmanager.ListTriggersResponse) ([]*tagmanager.Trigger, string) {
+		return resp.Trigger, resp.NextPageToken
+	})
+}
+
+// listAllVariables returns every variable in the workspace at parent.
+func listAllVariables(ctx context.Context, ws *tagmanager.AccountsContainersWorkspacesService, parent string) ([]*tagmanager.Variable, error) {
+	return listAllPages(ctx, func(pageToken string) (*tagmanager.ListVariablesResponse, error) {
+		return ws.Variables.List(parent).PageToken(pageToken).Context(ctx).Do()
+	}, func(resp *tagmanager.ListVariablesResponse) ([]*tagmanager.Variable, string) {
+		return resp.Variable, resp.NextPageToken
+	})
+}
+
+// Paginate returns one page of items for a list tool and the token of the
+// next page, or "" on the last page. Tokens are offsets into the full list,
+// so a page may skip or repeat entities if the workspace changes between
+// calls. all returns up to MaxListAll items and ignores pageSize.
+func Paginate[T any](items []T, pageToken string, pageSize int, all bool) ([]T, string, error) {
+	offset := 0
+	if pageToken != "" {
+		var err error
+		if offset, err = strconv.Atoi(pageToken); err != nil || offset < 0 {
+			return nil, "", InvalidField("pageToken", "invalid pageToken %q: pass the nextPageToken of a previous response", pageToken)
+		}
+	}
+	switch {
+	case all:
+		pageSize = MaxListAll
+	case pageSize < 0 || pageSize > MaxPageSize:
+		return nil, "", InvalidField("pageSize", "pageSize must be between 1 and %d", MaxPageSize)
+	case pageSize == 0:
+		pageSize = DefaultPageSize
+	}
+
+	if offset >= len(items) {
+		return []T{}, "", nil
+	}
+	end := min(offset+pageSize, len(items))
+	next := ""
+	if end < len(items) {
+		next = strconv.Itoa(end)
+	}
+	return items[offset:end], next, nil
+}
@@ -0,0 +1,105 @@
+package gtm
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"slices"
+	"testing"
+)
+
+func TestListTags_FollowsPages(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Query().Get("pageToken") {
+		case "":
+			fmt.Fprint(w, `{"tag":[{"tagId":"1"},{"tagId":"2"}],"nextPageToken":"p2"}`)
+		case "p2":
+			fmt.Fprint(w, `{"tag":[{"tagId":"3"}]}`)
+		default:
+			t.Errorf("unexpected pageToken %q", r.URL.Query().Get("pageToken"))
+		}
+	})
+
+	tags, err := client.ListTags(context.Background(), "1", "2", "3")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(tags) != 3 || tags[2].TagID != "3" {
+		t.Errorf("tags = %+v", tags)
+	}
+}
+
+func TestListAllPages_Cap(t *testing.T) {
+	calls := 0
+	_, err := listAllPages(context.Background(), func(pageToken string) (string, error) {
+		calls++
+		return "next", nil
+	}, func(resp string) ([]string, string) {
+		return []string{resp}, resp
+	})
+	if err == nil || calls != maxListPages {
+		t.Errorf("err = %v after %d calls, want an error after %d", err, calls, maxListPages)
+	}
+}
+
+func TestPaginate(t *testing.T) {
+	items := make([]int, 250)
+	for i := range items {
+		items[i] = i
+	}
+
+	tests := []struct {
+		name      string
+		pageToken string
+		pageSize  int
+		all       bool
+		wantFirst int
+		wantLen   int
+		wantNext  string
+	}{
+		{name: "default page size", wantFirst: 0, wantLen: 100, wantNext: "100"},
+		{name: "next page", pageToken: "100", pageSize: 100, wantFirst: 100, wantLen: 100, wantNext: "200"},
+		{name: "last page", pageToken: "200", pageSize: 100, wantFirst: 200, wantLen: 50},
+		{name: "all", pageSize: 10, all: true, wantFirst: 0, wantLen: 250},
+		{name: "past the end", pageToken: "300", wantLen: 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			page, next, err := Paginate(items, tt.pageToken, tt.pageSize, tt.all)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(page) != tt.wantLen || next != tt.wantNext {
+				t.Errorf("got %d items, next %q; want %d items, next %q", len(page), next, tt.wantLen, tt.wantNext)
+			}
+			if len(page) > 0 && page[0] != tt.wantFirst {
+				t.Errorf("first item = %d, want %d", page[0], tt.wantFirst)
+			}
+		})
+	}
+}
+
+func TestPaginate_AllCap(t *testing.T) {
+	items := make([]int, MaxListAll+1)
+	page, next, err := Paginate(items, "", 0, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(page) != MaxListAll || next != fmt.Sprint(MaxListAll) {
+		t.Errorf("got %d items, next %q", len(page), next)
+	}
+	if page, _, _ := Paginate(items, next, 0, true); !slices.Equal(page, items[MaxListAll:]) {
+		t.Errorf("second page = %d items", len(page))
+	}
+}
+
+func TestPaginate_Invalid(t *testing.T) {
+	for _, tt := range []struct {
+		pageToken string
+		pageSize  int
+	}{{"abc", 0}, {"-1", 0}, {"", MaxPageSize + 1}, {"", -1}} {
+		if _, _, err := Paginate([]int{1}, tt.pageToken, tt.pageSize, false); err == nil {
+			t.Errorf("Paginate(%q, %d) succeeded", tt.pageToken, tt.pageSize)
+		}
+	}
+}
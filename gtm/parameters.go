@@ -0,0 +1,277 @@
+package gtm
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	tagmanager "google.golang.org/api/tagmanager/v2"
+)
+
+// Parameter types accepted by the Tag Manager API.
+const (
+	ParamTemplate         = "template"
+	ParamBoolean          = "boolean"
+	ParamInteger          = "integer"
+	ParamList             = "list"
+	ParamMap              = "map"
+	ParamTagReference     = "tagReference"
+	ParamTriggerReference = "triggerReference"
+)
+
+var paramTypes = []string{ParamTemplate, ParamBoolean, ParamInteger, ParamList, ParamMap, ParamTagReference, ParamTriggerReference}
+
+// TemplateParam returns a template (string) parameter.
+func TemplateParam(key, value string) Parameter {
+	return Parameter{Type: ParamTemplate, Key: key, Value: value}
+}
+
+// BooleanParam returns a boolean parameter.
+func BooleanParam(key string, value bool) Parameter {
+	return Parameter{Type: ParamBoolean, Key: key, Value: strconv.FormatBool(value)}
+}
+
+// IntegerParam returns an integer parameter.
+func IntegerParam(key string, value int64) Parameter {
+	return Parameter{Type: ParamInteger, Key: key, Value: strconv.FormatInt(value, 10)}
+}
+
+// ListParam returns a list parameter. Items must not have keys.
+func ListParam(key string, items ...Parameter) Parameter {
+	return Parameter{Type: ParamList, Key: key, List: items}
+}
+
+// MapParam returns a map parameter. Entries must have unique keys.
+func MapParam(key string, entries ...Parameter) Parameter {
+	return Parameter{Type: ParamMap, Key: key, Map: entries}
+}
+
+// ParseParametersJSON decodes a JSON array of parameters and validates it.
+// Type names are matched case-insensitively and normalized. Unknown fields
+// and non-string values are rejected with the offending location.
+func ParseParametersJSON(data string) ([]Parameter, error) {
+	var params []Parameter
+	if err := decodeStrict(data, &params); err != nil {
+		return nil, err
+	}
+	normalizeParams(params)
+	if err := ValidateParameters(params); err != nil {
+		return nil, err
+	}
+	return params, nil
+}
+
+// ParametersInput returns the parameters of a tool input, given either as
+// the typed field or, for backward compatibility, as a JSON string in
+// jsonField. Neither returns nil.
+func ParametersInput(field string, typed []Parameter, jsonField, data string) ([]Parameter, error) {
+	if typed != nil && data != "" {
+		return nil, InvalidField(field, "provide either %s or %s, not both", field, jsonField)
+	}
+	if data != "" {
+		params, err := ParseParametersJSON(data)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s: %w", jsonField, err)
+		}
+		return params, nil
+	}
+	if typed == nil {
+		return nil, nil
+	}
+	normalizeParams(typed)
+	if err := validateParams(field, typed, true); err != nil {
+		return nil, InvalidField(field, "%v", err)
+	}
+	return typed, nil
+}
+
+// ParseParameterJSON decodes and validates a single keyless parameter such
+// as a trigger eventName.
+func ParseParameterJSON(data string) (*Parameter, error) {
+	var p Parameter
+	if err := decodeStrict(data, &p); err != nil {
+		return nil, err
+	}
+	normalizeParam(&p)
+	if err := validateParam("parameter", &p); err != nil {
+		return nil, err
+	}
+	return &p, nil
+}
+
+// ParseConditionsJSON decodes a JSON array of trigger conditions and
+// validates their parameters. Each condition is either raw GTM
+// {type, parameter} or the simplified {variable, op, value, negate} form,
+// which is compiled to raw GTM.
+func ParseConditionsJSON(data string) ([]Condition, error) {
+	var raws []json.RawMessage
+	if err := decodeStrict(data, &raws); err != nil {
+		return nil, err
+	}
+	conditions := make([]Condition, len(raws))
+	for i, raw := range raws {
+		if isSimpleCondition(raw) {
+			var simple SimpleCondition
+			if err := decodeStrict(string(raw), &simple); err != nil {
+				return nil, fmt.Errorf("condition[%d]: %w", i, err)
+			}
+			c, err := simple.Compile()
+			if err != nil {
+				return nil, fmt.Errorf("condition[%d]: %w", i, err)
+			}
+			conditions[i] = c
+			continue
+		}
+		if err := decodeStrict(string(raw), &conditions[i]); err != nil {
+			return nil, err
+		}
+		normalizeParams(conditions[i].Parameter)
+		if err := validateParams(fmt.Sprintf("condition[%d].parameter", i), conditions[i].Parameter, true); err != nil {
+			return nil, err
+		}
+	}
+	return conditions, nil
+}
+
+// decodeStrict unmarshals JSON rejecting unknown fields and trailing data.
+func decodeStrict(data string, v any) error {
+	dec := json.NewDecoder(strings.NewReader(data))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(v); err != nil {
+		var typeErr *json.UnmarshalTypeError
+		if errors.As(err, &typeErr) && typeErr.Field != "" {
+			return fmt.Errorf("invalid JSON: %s must be a %s, got %s (wrap numbers and booleans in quotes)", typeErr.Field, typeErr.Type, typeErr.Value)
+		}
+		return fmt.Errorf("invalid JSON: %w", err)
+	}
+	if dec.More() {
+		return fmt.Errorf("invalid JSON: unexpected data after value")
+	}
+	return nil
+}
+
+func normalizeParams(params []Parameter) {
+	for i := range params {
+		normalizeParam(&params[i])
+	}
+}
+
+// normalizeParam rewrites type names to their canonical casing.
+func normalizeParam(p *Parameter) {
+	for _, t := range paramTypes {
+		if strings.EqualFold(p.Type, t) {
+			p.Type = t
+			break
+		}
+	}
+	normalizeParams(p.List)
+	normalizeParams(p.Map)
+}
+
+// ValidateParameters checks a top-level parameter list against GTM's
+// structural rules: every parameter has a known type and a unique key, list
+// items have no keys, map entries have unique keys, scalar values are
+// well-formed and only list and map parameters have children.
+func ValidateParameters(params []Parameter) error {
+	return validateParams("parameter", params, true)
+}
+
+func validateParams(field string, params []Parameter, keyed bool) error {
+	seen := make(map[string]bool)
+	for i := range params {
+		p := &params[i]
+		name := fmt.Sprintf("%s[%d]", field, i)
+		if p.Key != "" {
+			name = fmt.Sprintf("%s[%s]", field, p.Key)
+		}
+		if keyed {
+			if p.Key == "" {
+				return fmt.Errorf("%s: key is required", name)
+			}
+			if seen[p.Key] {
+				return fmt.Errorf("%s: duplicate key", name)
+			}
+			seen[p.Key] = true
+		} else if p.Key != "" {
+			return fmt.Errorf("%s: list items must not have a key", name)
+		}
+		if err := validateParam(name, p); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// validateParam checks a single parameter and its children.
+func validateParam(name string, p *Parameter) error {
+	switch p.Type {
+	case "":
+		return fmt.Errorf("%s: type is required", name)
+	case ParamList, ParamMap:
+		if p.Value != "" {
+			return fmt.Errorf("%s: %s parameters cannot have a value", name, p.Type)
+		}
+		if p.Type == ParamList && len(p.Map) > 0 {
+			return fmt.Errorf("%s: list parameters hold items in list, not map", name)
+		}
+		if p.Type == ParamMap && len(p.List) > 0 {
+			return fmt.Errorf("%s: map parameters hold entries in map, not list", name)
+		}
+		if err := validateParams(name+".list", p.List, false); err != nil {
+			return err
+		}
+		return validateParams(name+".map", p.Map, true)
+	case ParamTemplate, ParamTagReference, ParamTriggerReference:
+	case ParamBoolean:
+		if p.Value != "true" && p.Value != "false" {
+			return fmt.Errorf("%s: boolean value must be \"true\" or \"false\", got %q", name, p.Value)
+		}
+	case ParamInteger:
+		if _, err := strconv.ParseInt(p.Value, 10, 64); err != nil && !IsVariableReference(p.Value) {
+			return fmt.Errorf("%s: integer value must be a whole number or {{variable}}, got %q", name, p.Value)
+		}
+	default:
+		return fmt.Errorf("%s: unknown type %q (valid: %s)", name, p.Type, strings.Join(paramTypes, ", "))
+	}
+	if len(p.List) > 0 || len(p.Map) > 0 {
+		return fmt.Errorf("%s: %s parameters cannot have list or map children", name, p.Type)
+	}
+	return nil
+}
+
+// IsVariableReference reports whether s is exactly one {{variable}} reference.
+func IsVariableReference(s string) bool {
+	return strings.HasPrefix(s, "{{") && strings.HasSuffix(s, "}}") && strings.Count(s, "{{") == 1
+}
+
+func toAPIParams(params []Parameter) []*tagmanager.Parameter {
+	if len(params) == 0 {
+		return nil
+	}
+	result := make([]*tagmanager.Parameter, len(params))
+	for i, p := range params {
+		result[i] = toAPIParam(&p)
+	}
+	return result
+}
+
+func toAPIParam(p *Parameter) *tagmanager.Parameter {
+	if p == nil {
+		return nil
+	}
+	param := &tagmanager.Parameter{
+		Type:            p.Type,
+		Key:             p.Key,
+		Value:           p.Value,
+		ForceSendFields: []string{"Type", "Key", "Value"},
+	}
+	if len(p.List) > 0 {
+		param.List = toAPIParams(p.List)
+	}
+	if len(p.Map) > 0 {
+		param.Map = toAPIParams(p.Map)
+	}
+	return param
+}
@@ -0,0 +1,253 @@
+package gtm
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	tagmanager "google.golang.org/api/tagmanager/v2"
+)
+
+func TestValidateParameters(t *testing.T) {
+	tests := []struct {
+		name    string
+		params  []Parameter
+		wantErr string
+	}{
+		{
+			name: "valid nested structure",
+			params: []Parameter{
+				TemplateParam("eventName", "purchase"),
+				BooleanParam("sendEcommerceData", true),
+				IntegerParam("timeout", 2000),
+				ListParam("eventParameters", MapParam("",
+					TemplateParam("name", "currency"),
+					TemplateParam("value", "{{DLV - Currency}}"),
+				)),
+				{Type: ParamTagReference, Key: "measurementId"},
+			},
+		},
+		{name: "integer variable reference", params: []Parameter{{Type: ParamInteger, Key: "n", Value: "{{Limit}}"}}},
+		{name: "empty list", params: []Parameter{ListParam("affectedTags")}},
+		{name: "missing key", params: []Parameter{{Type: ParamTemplate, Value: "x"}}, wantErr: "parameter[0]: key is required"},
+		{name: "duplicate key", params: []Parameter{TemplateParam("a", "1"), TemplateParam("a", "2")}, wantErr: "parameter[a]: duplicate key"},
+		{name: "missing type", params: []Parameter{{Key: "a", Value: "1"}}, wantErr: "type is required"},
+		{name: "unknown type", params: []Parameter{{Type: "string", Key: "a"}}, wantErr: `unknown type "string"`},
+		{
+			name:    "list item with key",
+			params:  []Parameter{ListParam("rows", TemplateParam("row", "x"))},
+			wantErr: "parameter[rows].list[row]: list items must not have a key",
+		},
+		{
+			name:    "map entry without key",
+			params:  []Parameter{ListParam("rows", MapParam("", TemplateParam("", "x")))},
+			wantErr: "parameter[rows].list[0].map[0]: key is required",
+		},
+		{
+			name:    "duplicate map key",
+			params:  []Parameter{MapParam("m", TemplateParam("k", "1"), TemplateParam("k", "2"))},
+			wantErr: "parameter[m].map[k]: duplicate key",
+		},
+		{name: "list with value", params: []Parameter{{Type: ParamList, Key: "l", Value: "x"}}, wantErr: "list parameters cannot have a value"},
+		{name: "list with map entries", params: []Parameter{{Type: ParamList, Key: "l", Map: []Parameter{TemplateParam("k", "v")}}}, wantErr: "hold items in list"},
+		{name: "map with list items", params: []Parameter{{Type: ParamMap, Key: "m", List: []Parameter{TemplateParam("", "v")}}}, wantErr: "hold entries in map"},
+		{name: "template with children", params: []Parameter{{Type: ParamTemplate, Key: "t", List: []Parameter{TemplateParam("", "v")}}}, wantErr: "cannot have list or map children"},
+		{name: "bad boolean", params: []Parameter{{Type: ParamBoolean, Key: "b", Value: "yes"}}, wantErr: "boolean value must be"},
+		{name: "bad integer", params: []Parameter{{Type: ParamInteger, Key: "i", Value: "1.5"}}, wantErr: "integer value must be"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateParameters(tt.params)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("error = %v, want containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestParseParametersJSON(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    []Parameter
+		wantErr string
+	}{
+		{
+			name:  "normalizes type casing",
+			input: `[{"type":"Template","key":"a","value":"x"},{"type":"LIST","key":"l","list":[{"type":"Map","map":[{"type":"BOOLEAN","key":"b","value":"true"}]}]}]`,
+			want: []Parameter{
+				TemplateParam("a", "x"),
+				ListParam("l", MapParam("", BooleanParam("b", true))),
+			},
+		},
+		{name: "numeric value", input: `[{"type":"integer","key":"a","value":5}]`, wantErr: "value must be a string"},
+		{name: "unknown field", input: `[{"type":"template","key":"a","values":"x"}]`, wantErr: `unknown field "values"`},
+		{name: "trailing data", input: `[] []`, wantErr: "unexpected data"},
+		{name: "object instead of array", input: `{"type":"template"}`, wantErr: "invalid JSON"},
+		{name: "structural error", input: `[{"type":"template","value":"x"}]`, wantErr: "key is required"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseParametersJSON(tt.input)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("error = %v, want containing %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseConditionsJSON(t *testing.T) {
+	conditions, err := ParseConditionsJSON(`[{"type":"equals","negate":true,"parameter":[{"type":"template","key":"arg0","value":"{{_event}}"},{"type":"template","key":"arg1","value":"purchase"}]}]`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(conditions) != 1 || !conditions[0].Negate || len(conditions[0].Parameter) != 2 {
+		t.Errorf("unexpected conditions: %+v", conditions)
+	}
+
+	_, err = ParseConditionsJSON(`[{"type":"equals","parameter":[{"type":"template","value":"{{_event}}"}]}]`)
+	if err == nil || !strings.Contains(err.Error(), "condition[0].parameter[0]: key is required") {
+		t.Errorf("error = %v, want missing key error", err)
+	}
+}
+
+// TestTemplatesAreValid keeps the documented parameter examples in line with
+// the validator.
+func TestTemplatesAreValid(t *testing.T) {
+	for _, tmpl := range GetTagTemplates() {
+		if _, err := ParseParametersJSON(tmpl.Parameters); err != nil {
+			t.Errorf("tag template %q: %v", tmpl.Name, err)
+		}
+	}
+	for _, tmpl := range GetVariableTemplates() {
+		if _, err := ParseParametersJSON(tmpl.Parameters); err != nil {
+			t.Errorf("variable template %q: %v", tmpl.Name, err)
+		}
+	}
+}
+
+// roundTrip converts parameters to the API structs, serializes them as the
+// API client would and decodes the result back into tool parameters.
+func roundTrip(t *testing.T, params []Parameter) []Parameter {
+	t.Helper()
+	data, err := json.Marshal(toAPIParams(params))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var api []*tagmanager.Parameter
+	if err := json.Unmarshal(data, &api); err != nil {
+		t.Fatal(err)
+	}
+	data, err = json.Marshal(api)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []Parameter
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatal(err)
+	}
+	return got
+}
+
+// sameParams compares parameters treating nil and empty children as equal,
+// as the API does.
+func sameParams(a, b []Parameter) bool {
+	ja, _ := json.Marshal(a)
+	jb, _ := json.Marshal(b)
+	return string(ja) == string(jb)
+}
+
+func TestParameters_RoundTrip(t *testing.T) {
+	params := []Parameter{
+		TemplateParam("eventName", "purchase"),
+		BooleanParam("sendEcommerceData", false),
+		IntegerParam("timeout", 0),
+		{Type: ParamTagReference, Key: "measurementId"},
+		ListParam("triggerIds", Parameter{Type: ParamTriggerReference, Value: "12"}),
+		ListParam("eventParameters",
+			MapParam("", TemplateParam("name", "currency"), TemplateParam("value", "EUR")),
+			MapParam("", TemplateParam("name", "value"), TemplateParam("value", "")),
+		),
+	}
+	if got := roundTrip(t, params); !sameParams(got, params) {
+		t.Errorf("round trip mismatch:\n got %+v\nwant %+v", got, params)
+	}
+}
+
+func FuzzParseParametersJSON(f *testing.F) {
+	f.Add(`[{"type":"template","key":"a","value":"x"}]`)
+	f.Add(`[{"type":"list","key":"l","list":[{"type":"map","map":[{"type":"template","key":"k","value":"v"}]}]}]`)
+	f.Add(`[{"type":"boolean","key":"b","value":"true"},{"type":"integer","key":"i","value":"{{N}}"}]`)
+	f.Add(`[{"type":"map","key":"m","map":[{"type":"list","key":"l","list":[]}]}]`)
+	f.Add(`[{"type":"template","key":"a","value":5}]`)
+	for _, tmpl := range GetTagTemplates() {
+		f.Add(tmpl.Parameters)
+	}
+
+	f.Fuzz(func(t *testing.T, input string) {
+		params, err := ParseParametersJSON(input)
+		if err != nil {
+			return
+		}
+		// Anything accepted must survive conversion to the API structs
+		// unchanged and still be valid afterwards.
+		got := roundTrip(t, params)
+		if len(params) == 0 {
+			return
+		}
+		if !sameParams(got, params) {
+			t.Fatalf("round trip mismatch:\n got %+v\nwant %+v", got, params)
+		}
+		if err := ValidateParameters(got); err != nil {
+			t.Fatalf("round-tripped parameters invalid: %v", err)
+		}
+	})
+}
+
+func TestParametersInput(t *testing.T) {
+	params, err := ParametersInput("parameters", []Parameter{{Type: "Template", Key: "html", Value: "<p>"}}, "parametersJson", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(params, []Parameter{TemplateParam("html", "<p>")}) {
+		t.Errorf("params = %+v", params)
+	}
+
+	params, err = ParametersInput("parameters", nil, "parametersJson", `[{"type":"boolean","key":"b","value":"true"}]`)
+	if err != nil || !reflect.DeepEqual(params, []Parameter{BooleanParam("b", true)}) {
+		t.Errorf("JSON params = %+v, err = %v", params, err)
+	}
+	if params, err := ParametersInput("parameters", nil, "parametersJson", ""); err != nil || params != nil {
+		t.Errorf("no params = %+v, err = %v", params, err)
+	}
+
+	if _, err := ParametersInput("parameters", []Parameter{}, "parametersJson", "[]"); !errors.Is(err, ErrInvalidRequest) {
+		t.Errorf("both forms: err = %v, want ErrInvalidRequest", err)
+	}
+	if _, err := ParametersInput("parameters", nil, "parametersJson", `[{"type":"template"}]`); err == nil || !strings.Contains(err.Error(), "invalid parametersJson") {
+		t.Errorf("invalid JSON: err = %v", err)
+	}
+	_, err = ParametersInput("parameters", []Parameter{{Type: "list", Key: "l", List: []Parameter{{Type: "template", Key: "k", Value: "x"}}}}, "parametersJson", "")
+	if !errors.Is(err, ErrInvalidRequest) || !strings.Contains(err.Error(), "parameters[l].list[k]: list items must not have a key") {
+		t.Errorf("invalid typed params: err = %v", err)
+	}
+}
@@ -0,0 +1,242 @@
+package gtm
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	tagmanager "google.golang.org/api/tagmanager/v2"
+)
+
+// Tag fields that can be removed with TagPatch.Clear.
+const (
+	TagFieldNotes              = "notes"
+	TagFieldFiringTriggerIDs   = "firingTriggerIds"
+	TagFieldBlockingTriggerIDs = "blockingTriggerIds"
+	TagFieldParameters         = "parameters"
+	TagFieldScheduleStart      = "scheduleStart"
+	TagFieldScheduleEnd        = "scheduleEnd"
+	TagFieldConsentSettings    = "consentSettings"
+	TagFieldPriority           = "priority"
+	TagFieldParentFolderID     = "parentFolderId"
+)
+
+var clearableTagFields = []string{
+	TagFieldNotes,
+	TagFieldFiringTriggerIDs,
+	TagFieldBlockingTriggerIDs,
+	TagFieldParameters,
+	TagFieldScheduleStart,
+	TagFieldScheduleEnd,
+	TagFieldConsentSettings,
+	TagFieldPriority,
+	TagFieldParentFolderID,
+}
+
+// TagPatch is a partial tag update. Nil fields keep their current value and
+// fields named in Clear are removed; everything else on the tag (sequencing,
+// monitoring metadata, ...) is left untouched. Clearing the parent folder
+// moves the tag back to the workspace root.
+type TagPatch struct {
+	Name              *string
+	Type              *string
+	FiringTriggerId   []string
+	BlockingTriggerId []string
+	Parameter         []Parameter
+	Notes             *string
+	Paused            *bool
+	ScheduleStartMs   *int64
+	ScheduleEndMs     *int64
+	ConsentSettings   *ConsentSettings
+	Priority          *int
+	ParentFolderId    *string
+	Clear             []string
+}
+
+// applyTagPatch merges a patch into the current tag and validates the result.
+func applyTagPatch(tag *tagmanager.Tag, patch *TagPatch) error {
+	supplied := map[string]bool{
+		TagFieldNotes:              patch.Notes != nil,
+		TagFieldFiringTriggerIDs:   patch.FiringTriggerId != nil,
+		TagFieldBlockingTriggerIDs: patch.BlockingTriggerId != nil,
+		TagFieldParameters:         patch.Parameter != nil,
+		TagFieldScheduleStart:      patch.ScheduleStartMs != nil,
+		TagFieldScheduleEnd:        patch.ScheduleEndMs != nil,
+		TagFieldConsentSettings:    patch.ConsentSettings != nil,
+		TagFieldPriority:           patch.Priority != nil,
+		TagFieldParentFolderID:     patch.ParentFolderId != nil,
+	}
+	if err := checkClearFields(patch.Clear, clearableTagFields, supplied); err != nil {
+		return err
+	}
+
+	if patch.Name != nil {
+		tag.Name = *patch.Name
+	}
+	if patch.Type != nil {
+		tag.Type = *patch.Type
+	}
+	if patch.FiringTriggerId != nil {
+		tag.FiringTriggerId = patch.FiringTriggerId
+	}
+	if patch.BlockingTriggerId != nil {
+		tag.BlockingTriggerId = patch.BlockingTriggerId
+	}
+	if patch.Parameter != nil {
+		tag.Parameter = toAPIParams(patch.Parameter)
+	}
+	if patch.Notes != nil {
+		tag.Notes = *patch.Notes
+	}
+	if patch.Paused != nil {
+		tag.Paused = *patch.Paused
+	}
+	if patch.ScheduleStartMs != nil {
+		tag.ScheduleStartMs = *patch.ScheduleStartMs
+	}
+	if patch.ScheduleEndMs != nil {
+		tag.ScheduleEndMs = *patch.ScheduleEndMs
+	}
+	if patch.ConsentSettings != nil {
+		tag.ConsentSettings = toAPIConsentSettings(patch.ConsentSettings)
+	}
+	if patch.Priority != nil {
+		tag.Priority = toAPIPriority(patch.Priority)
+	}
+	if patch.ParentFolderId != nil {
+		tag.ParentFolderId = *patch.ParentFolderId
+	}
+
+	for _, field := range patch.Clear {
+		switch field {
+		case TagFieldNotes:
+			tag.Notes = ""
+		case TagFieldFiringTriggerIDs:
+			tag.FiringTriggerId = nil
+		case TagFieldBlockingTriggerIDs:
+			tag.BlockingTriggerId = nil
+		case TagFieldParameters:
+			tag.Parameter = nil
+		case TagFieldScheduleStart:
+			tag.ScheduleStartMs = 0
+		case TagFieldScheduleEnd:
+			tag.ScheduleEndMs = 0
+		case TagFieldConsentSettings:
+			tag.ConsentSettings = nil
+		case TagFieldPriority:
+			tag.Priority = nil
+		case TagFieldParentFolderID:
+			tag.ParentFolderId = ""
+		}
+	}
+
+	// Tags without firing triggers (e.g. setup tags used only in sequencing)
+	// are valid, so triggers are only checked when they are being replaced.
+	if patch.FiringTriggerId != nil {
+		if err := ValidateTagInput(tag.Name, tag.Type, tag.FiringTriggerId); err != nil {
+			return err
+		}
+	} else if err := validateTagNameAndType(tag.Name, tag.Type); err != nil {
+		return err
+	}
+	if tag.ScheduleStartMs != 0 && tag.ScheduleEndMs != 0 && tag.ScheduleEndMs <= tag.ScheduleStartMs {
+		return fmt.Errorf("scheduleEnd must be after scheduleStart")
+	}
+	return nil
+}
+
+// checkClearFields rejects unknown field names and fields that are both
+// supplied and cleared.
+func checkClearFields(clear, clearable []string, supplied map[string]bool) error {
+	for _, field := range clear {
+		if !slices.Contains(clearable, field) {
+			return fmt.Errorf("cannot clear %q: clearable fields are %s", field, strings.Join(clearable, ", "))
+		}
+		if supplied[field] {
+			return fmt.Errorf("%s is both supplied and listed in clear", field)
+		}
+	}
+	return nil
+}
+
+// Variable fields that can be removed with VariablePatch.Clear.
+const (
+	VariableFieldNotes          = "notes"
+	VariableFieldParameters     = "parameters"
+	VariableFieldParentFolderID = "parentFolderId"
+	VariableFieldFormatValue    = "formatValue"
+)
+
+var clearableVariableFields = []string{VariableFieldNotes, VariableFieldParameters, VariableFieldParentFolderID, VariableFieldFormatValue}
+
+// VariablePatch is a partial variable update. Nil fields keep their current
+// value and fields named in Clear are removed; scheduling fields are left
+// untouched. A supplied FormatValue replaces the whole format value block.
+type VariablePatch struct {
+	Name           *string
+	Type           *string
+	Parameter      []Parameter
+	Notes          *string
+	ParentFolderId *string
+	FormatValue    *FormatValue
+	Clear          []string
+}
+
+// applyVariablePatch merges a patch into the current variable and validates the result.
+func applyVariablePatch(v *tagmanager.Variable, patch *VariablePatch) error {
+	supplied := map[string]bool{
+		VariableFieldNotes:          patch.Notes != nil,
+		VariableFieldParameters:     patch.Parameter != nil,
+		VariableFieldParentFolderID: patch.ParentFolderId != nil,
+		VariableFieldFormatValue:    patch.FormatValue != nil,
+	}
+	if err := checkClearFields(patch.Clear, clearableVariableFields, supplied); err != nil {
+		return err
+	}
+
+	if patch.Name != nil {
+		v.Name = *patch.Name
+	}
+	if patch.Type != nil {
+		v.Type = *patch.Type
+	}
+	if patch.Parameter != nil {
+		if err := ValidateVariableParameters(v.Type, patch.Parameter); err != nil {
+			return err
+		}
+		v.Parameter = toAPIParams(patch.Parameter)
+	}
+	if patch.Notes != nil {
+		v.Notes = *patch.Notes
+	}
+	if patch.ParentFolderId != nil {
+		v.ParentFolderId = *patch.ParentFolderId
+	}
+	if patch.FormatValue != nil {
+		if err := ValidateFormatValue(patch.FormatValue); err != nil {
+			return err
+		}
+		v.FormatValue = toAPIFormatValue(patch.FormatValue)
+	}
+
+	for _, field := range patch.Clear {
+		switch field {
+		case VariableFieldNotes:
+			v.Notes = ""
+		case VariableFieldParameters:
+			v.Parameter = nil
+		case VariableFieldParentFolderID:
+			v.ParentFolderId = ""
+		case VariableFieldFormatValue:
+			v.FormatValue = nil
+		}
+	}
+
+	if strings.TrimSpace(v.Name) == "" {
+		return fmt.Errorf("name is required")
+	}
+	if strings.TrimSpace(v.Type) == "" {
+		return fmt.Errorf("type is required")
+	}
+	return nil
+}
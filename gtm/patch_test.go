@@ -0,0 +1,222 @@
+package gtm
+
+import (
+	"strings"
+	"testing"
+
+	tagmanager "google.golang.org/api/tagmanager/v2"
+)
+
+func patchTestTag() *tagmanager.Tag {
+	return &tagmanager.Tag{
+		Name:              "GA4 - Purchase",
+		Type:              "gaawe",
+		FiringTriggerId:   []string{"10"},
+		BlockingTriggerId: []string{"20"},
+		Parameter:         []*tagmanager.Parameter{{Type: "template", Key: "eventName", Value: "purchase"}},
+		Notes:             "old notes",
+		ParentFolderId:    "5",
+		ScheduleStartMs:   1000,
+		ScheduleEndMs:     2000,
+		ConsentSettings:   &tagmanager.TagConsentSetting{ConsentStatus: "needed"},
+		Fingerprint:       "abc",
+	}
+}
+
+func TestApplyTagPatch_KeepsUnsuppliedFields(t *testing.T) {
+	tag := patchTestTag()
+	name := "GA4 - Purchase v2"
+	paused := true
+
+	if err := applyTagPatch(tag, &TagPatch{Name: &name, Paused: &paused}); err != nil {
+		t.Fatal(err)
+	}
+	if tag.Name != name || !tag.Paused {
+		t.Errorf("patched fields not applied: name=%q paused=%v", tag.Name, tag.Paused)
+	}
+	if tag.Notes != "old notes" || tag.ParentFolderId != "5" || tag.ConsentSettings == nil ||
+		tag.ScheduleStartMs != 1000 || len(tag.BlockingTriggerId) != 1 || len(tag.Parameter) != 1 || tag.Fingerprint != "abc" {
+		t.Errorf("unsupplied fields changed: %+v", tag)
+	}
+}
+
+func TestApplyTagPatch_Clear(t *testing.T) {
+	tag := patchTestTag()
+	err := applyTagPatch(tag, &TagPatch{Clear: []string{TagFieldNotes, TagFieldBlockingTriggerIDs, TagFieldScheduleStart, TagFieldScheduleEnd}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tag.Notes != "" || tag.BlockingTriggerId != nil || tag.ScheduleStartMs != 0 || tag.ScheduleEndMs != 0 {
+		t.Errorf("fields not cleared: %+v", tag)
+	}
+	if len(tag.FiringTriggerId) != 1 {
+		t.Errorf("firing triggers changed: %v", tag.FiringTriggerId)
+	}
+}
+
+func TestApplyTagPatch_Errors(t *testing.T) {
+	empty := ""
+	end := int64(500)
+	tests := []struct {
+		name    string
+		patch   TagPatch
+		wantErr string
+	}{
+		{"unknown clear field", TagPatch{Clear: []string{"name"}}, "cannot clear"},
+		{"supplied and cleared", TagPatch{Notes: &empty, Clear: []string{TagFieldNotes}}, "both supplied"},
+		{"empty name", TagPatch{Name: &empty}, "tag name is required"},
+		{"empty firing triggers", TagPatch{FiringTriggerId: []string{}}, "firing trigger"},
+		{"end before start", TagPatch{ScheduleEndMs: &end}, "scheduleEnd must be after"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := applyTagPatch(patchTestTag(), &tt.patch)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("error = %v, want containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestApplyTagPatch_TagWithoutTriggers(t *testing.T) {
+	tag := patchTestTag()
+	tag.FiringTriggerId = nil
+	notes := "setup tag"
+	if err := applyTagPatch(tag, &TagPatch{Notes: &notes}); err != nil {
+		t.Errorf("updating a tag without firing triggers: %v", err)
+	}
+}
+
+func TestApplyVariablePatch(t *testing.T) {
+	current := func() *tagmanager.Variable {
+		return &tagmanager.Variable{
+			Name:           "DLV - Value",
+			Type:           "v",
+			Parameter:      []*tagmanager.Parameter{{Type: "template", Key: "name", Value: "ecommerce.value"}},
+			Notes:          "notes",
+			ParentFolderId: "7",
+			FormatValue:    &tagmanager.VariableFormatValue{ConvertNullToValue: &tagmanager.Parameter{Type: "template", Value: "0"}},
+			ScheduleEndMs:  5000,
+			Fingerprint:    "abc",
+		}
+	}
+
+	v := current()
+	name := "DLV - Order Value"
+	if err := applyVariablePatch(v, &VariablePatch{Name: &name}); err != nil {
+		t.Fatal(err)
+	}
+	if v.Name != name || v.Type != "v" || len(v.Parameter) != 1 || v.Notes != "notes" ||
+		v.ParentFolderId != "7" || v.FormatValue == nil || v.ScheduleEndMs != 5000 || v.Fingerprint != "abc" {
+		t.Errorf("rename changed other fields: %+v", v)
+	}
+
+	v = current()
+	params := []Parameter{{Type: "template", Key: "name", Value: "ecommerce.revenue"}}
+	if err := applyVariablePatch(v, &VariablePatch{Parameter: params, Clear: []string{VariableFieldNotes}}); err != nil {
+		t.Fatal(err)
+	}
+	if v.Parameter[0].Value != "ecommerce.revenue" || v.Notes != "" || v.Name != "DLV - Value" {
+		t.Errorf("parameter patch not applied: %+v", v)
+	}
+
+	empty := ""
+	if err := applyVariablePatch(current(), &VariablePatch{Name: &empty}); err == nil {
+		t.Error("expected error for empty name")
+	}
+	if err := applyVariablePatch(current(), &VariablePatch{Clear: []string{"scheduleEnd"}}); err == nil {
+		t.Error("expected error for unclearable field")
+	}
+	gtes := "gtes"
+	if err := applyVariablePatch(current(), &VariablePatch{Type: &gtes, Parameter: []Parameter{{Type: "template", Key: "bogus"}}}); err == nil {
+		t.Error("expected settings variable parameters to be validated against the new type")
+	}
+}
+
+func TestApplyTagPatch_ConsentSettings(t *testing.T) {
+	tag := patchTestTag()
+	consent := &ConsentSettings{ConsentStatus: ConsentNeeded, ConsentType: []string{"ad_user_data"}}
+	if err := applyTagPatch(tag, &TagPatch{ConsentSettings: consent}); err != nil {
+		t.Fatal(err)
+	}
+	if tag.ConsentSettings.ConsentStatus != ConsentNeeded || tag.ConsentSettings.ConsentType.List[0].Value != "ad_user_data" {
+		t.Errorf("consent not applied: %+v", tag.ConsentSettings)
+	}
+
+	if err := applyTagPatch(tag, &TagPatch{Clear: []string{TagFieldConsentSettings}}); err != nil {
+		t.Fatal(err)
+	}
+	if tag.ConsentSettings != nil {
+		t.Errorf("consent not cleared: %+v", tag.ConsentSettings)
+	}
+}
+
+func TestApplyTagPatch_Priority(t *testing.T) {
+	tag := patchTestTag()
+	priority := -5
+	if err := applyTagPatch(tag, &TagPatch{Priority: &priority}); err != nil {
+		t.Fatal(err)
+	}
+	if got := tagPriority(tag.Priority); got == nil || *got != -5 {
+		t.Errorf("priority = %v, want -5", got)
+	}
+	if tag.Priority.Type != ParamInteger {
+		t.Errorf("priority type = %q, want integer", tag.Priority.Type)
+	}
+
+	if err := applyTagPatch(tag, &TagPatch{Clear: []string{TagFieldPriority}}); err != nil {
+		t.Fatal(err)
+	}
+	if tag.Priority != nil {
+		t.Errorf("priority not cleared: %+v", tag.Priority)
+	}
+}
+
+func TestPatch_ParentFolder(t *testing.T) {
+	tag := patchTestTag()
+	folder := "9"
+	if err := applyTagPatch(tag, &TagPatch{ParentFolderId: &folder}); err != nil {
+		t.Fatal(err)
+	}
+	if tag.ParentFolderId != "9" {
+		t.Errorf("tag folder = %q, want 9", tag.ParentFolderId)
+	}
+	if err := applyTagPatch(tag, &TagPatch{Clear: []string{TagFieldParentFolderID}}); err != nil {
+		t.Fatal(err)
+	}
+	if tag.ParentFolderId != "" {
+		t.Errorf("tag folder not cleared: %q", tag.ParentFolderId)
+	}
+	if err := applyTagPatch(tag, &TagPatch{ParentFolderId: &folder, Clear: []string{TagFieldParentFolderID}}); err == nil {
+		t.Error("expected error when parentFolderId is both supplied and cleared")
+	}
+
+	v := &tagmanager.Variable{Name: "DLV - Value", Type: "v", ParentFolderId: "7"}
+	if err := applyVariablePatch(v, &VariablePatch{ParentFolderId: &folder}); err != nil {
+		t.Fatal(err)
+	}
+	if v.ParentFolderId != "9" {
+		t.Errorf("variable folder = %q, want 9", v.ParentFolderId)
+	}
+	if err := applyVariablePatch(v, &VariablePatch{Clear: []string{VariableFieldParentFolderID}}); err != nil {
+		t.Fatal(err)
+	}
+	if v.ParentFolderId != "" {
+		t.Errorf("variable folder not cleared: %q", v.ParentFolderId)
+	}
+}
+
+func TestToTag_ScheduleAndPriority(t *testing.T) {
+	tag := patchTestTag()
+	tag.Priority = &tagmanager.Parameter{Type: "integer", Value: "10"}
+	got := toTag(tag)
+	if got.ScheduleStartMs != 1000 || got.ScheduleEndMs != 2000 {
+		t.Errorf("schedule = %d-%d, want 1000-2000", got.ScheduleStartMs, got.ScheduleEndMs)
+	}
+	if got.Priority == nil || *got.Priority != 10 {
+		t.Errorf("priority = %v, want 10", got.Priority)
+	}
+	if got.ConsentSettings == nil || got.ConsentSettings.ConsentStatus != "needed" {
+		t.Errorf("consent = %+v", got.ConsentSettings)
+	}
+}
@@ -0,0 +1,196 @@
+package gtm
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// ValidationIssue is a problem found while validating a workspace.
+type ValidationIssue struct {
+	Severity   string `json:"severity"` // "error" or "warning"
+	EntityType string `json:"entityType"`
+	EntityID   string `json:"entityId,omitempty"`
+	Name       string `json:"name,omitempty"`
+	Message    string `json:"message"`
+}
+
+// Validation issue severities.
+const (
+	SeverityError   = "error"
+	SeverityWarning = "warning"
+	SeverityInfo    = "info" // worth knowing, no action needed
+)
+
+// isBuiltInTriggerID reports whether id refers to a GTM built-in trigger such
+// as All Pages (2147479553) or Initialization (2147479572). Built-in triggers
+// are not returned by the triggers API.
+func isBuiltInTriggerID(id string) bool {
+	return len(id) == 10 && strings.HasPrefix(id, "21474")
+}
+
+// ValidateWorkspace runs pre-publish checks on a workspace: merge conflicts,
+// tags referencing missing triggers and active tags without firing triggers.
+func (c *Client) ValidateWorkspace(ctx context.Context, accountID, containerID, workspaceID string) ([]ValidationIssue, error) {
+	status, err := c.GetWorkspaceStatus(ctx, accountID, containerID, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+
+	tags, err := c.ListTags(ctx, accountID, containerID, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+
+	triggers, err := c.ListTriggers(ctx, accountID, containerID, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+
+	issues := validateTagTriggers(tags, triggers)
+	if status.HasConflicts {
+		issues = append([]ValidationIssue{{
+			Severity:   SeverityError,
+			EntityType: "workspace",
+			EntityID:   workspaceID,
+			Message:    fmt.Sprintf("workspace has %d merge conflicts", status.ConflictCount),
+		}}, issues...)
+	}
+	return issues, nil
+}
+
+// validateTagTriggers checks that every trigger a tag references exists.
+func validateTagTriggers(tags []Tag, triggers []Trigger) []ValidationIssue {
+	known := make(map[string]bool, len(triggers))
+	for _, t := range triggers {
+		known[t.TriggerID] = true
+	}
+
+	var issues []ValidationIssue
+	for _, tag := range tags {
+		if len(tag.FiringTriggerID) == 0 && !tag.Paused {
+			issues = append(issues, ValidationIssue{
+				Severity:   SeverityWarning,
+				EntityType: "tag",
+				EntityID:   tag.TagID,
+				Name:       tag.Name,
+				Message:    "tag has no firing triggers and will only fire via tag sequencing",
+			})
+		}
+
+		refs := append(append([]string{}, tag.FiringTriggerID...), tag.BlockingTriggerID...)
+		for _, id := range refs {
+			if known[id] || isBuiltInTriggerID(id) {
+				continue
+			}
+			issues = append(issues, ValidationIssue{
+				Severity:   SeverityError,
+				EntityType: "tag",
+				EntityID:   tag.TagID,
+				Name:       tag.Name,
+				Message:    fmt.Sprintf("tag references missing trigger %s", id),
+			})
+		}
+	}
+	return issues
+}
+
+// HasValidationErrors reports whether any issue has error severity.
+func HasValidationErrors(issues []ValidationIssue) bool {
+	for _, issue := range issues {
+		if issue.Severity == SeverityError {
+			return true
+		}
+	}
+	return false
+}
+
+// GenerateReleaseNotes builds version notes listing the workspace changes,
+// prefixed by optional user-supplied notes.
+func GenerateReleaseNotes(notes string, changes []WorkspaceChange) string {
+	var b strings.Builder
+	if notes = strings.TrimSpace(notes); notes != "" {
+		b.WriteString(notes)
+		b.WriteString("\n\n")
+	}
+
+	fmt.Fprintf(&b, "Changes (%d):", len(changes))
+	for _, ch := range changes {
+		name := ch.Name
+		if name == "" {
+			name = ch.EntityID
+		}
+		fmt.Fprintf(&b, "\n- %s %s: %s", ch.ChangeStatus, ch.EntityType, name)
+	}
+	return b.String()
+}
+
+// ReleaseRisk is a change in a workspace diff that deserves a closer look
+// before publishing.
+type ReleaseRisk struct {
+	EntityType string `json:"entityType"`
+	EntityID   string `json:"entityId"`
+	Name       string `json:"name"`
+	Risk       string `json:"risk"`
+}
+
+// ReleaseRisks flags new or changed custom HTML, changed firing conditions
+// and removed tags and triggers in a workspace diff.
+func ReleaseRisks(wd *WorkspaceDiff) []ReleaseRisk {
+	tagTypes := make(map[string]string, len(wd.Head.Tag))
+	for _, t := range wd.Head.Tag {
+		tagTypes[t.TagId] = t.Type
+	}
+
+	risks := []ReleaseRisk{}
+	add := func(e EntityDiff, risk string) {
+		risks = append(risks, ReleaseRisk{EntityType: e.EntityType, EntityID: e.EntityID, Name: e.Name, Risk: risk})
+	}
+	for _, e := range wd.Diff.Entities {
+		switch {
+		case e.EntityType == "tag" && e.Status == DiffAdded && tagTypes[e.EntityID] == "html":
+			add(e, "new Custom HTML tag")
+		case (e.EntityType == "tag" || e.EntityType == "trigger") && e.Status == DiffRemoved:
+			add(e, e.EntityType+" removed")
+		case e.Status == DiffModified:
+			for _, risk := range modifiedRisks(e) {
+				add(e, risk)
+			}
+		}
+	}
+	return risks
+}
+
+// modifiedRisks returns the risky kinds of change in a modified entity, each
+// at most once.
+func modifiedRisks(e EntityDiff) []string {
+	var risks []string
+	seen := make(map[string]bool)
+	for _, ch := range e.Changes {
+		var risk string
+		switch e.EntityType {
+		case "tag":
+			switch {
+			case strings.HasPrefix(ch.Field, "parameter[html]"):
+				risk = "Custom HTML code changed"
+			case strings.HasPrefix(ch.Field, "firingTriggerId"), strings.HasPrefix(ch.Field, "blockingTriggerId"):
+				risk = "firing conditions changed"
+			}
+		case "trigger":
+			for _, f := range []string{"type", "filter", "autoEventFilter", "customEventFilter"} {
+				if ch.Field == f || strings.HasPrefix(ch.Field, f+"[") || strings.HasPrefix(ch.Field, f+".") {
+					risk = "trigger conditions changed"
+				}
+			}
+		case "template":
+			if ch.Field == "templateData" {
+				risk = "custom template code changed"
+			}
+		}
+		if risk != "" && !seen[risk] {
+			seen[risk] = true
+			risks = append(risks, risk)
+		}
+	}
+	return risks
+}
@@ -0,0 +1,98 @@
+package gtm
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	tagmanager "google.golang.org/api/tagmanager/v2"
+)
+
+func TestValidateTagTriggers(t *testing.T) {
+	triggers := []Trigger{{TriggerID: "10", Name: "Checkout"}}
+
+	tests := []struct {
+		name       string
+		tag        Tag
+		wantErrors int
+		wantWarns  int
+	}{
+		{"existing trigger", Tag{TagID: "1", FiringTriggerID: []string{"10"}}, 0, 0},
+		{"built-in all pages", Tag{TagID: "2", FiringTriggerID: []string{"2147479553"}}, 0, 0},
+		{"missing firing trigger", Tag{TagID: "3", FiringTriggerID: []string{"99"}}, 1, 0},
+		{"missing blocking trigger", Tag{TagID: "4", FiringTriggerID: []string{"10"}, BlockingTriggerID: []string{"98"}}, 1, 0},
+		{"no firing triggers", Tag{TagID: "5"}, 0, 1},
+		{"paused without triggers", Tag{TagID: "6", Paused: true}, 0, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			issues := validateTagTriggers([]Tag{tt.tag}, triggers)
+			var errs, warns int
+			for _, issue := range issues {
+				switch issue.Severity {
+				case SeverityError:
+					errs++
+				case SeverityWarning:
+					warns++
+				}
+			}
+			if errs != tt.wantErrors || warns != tt.wantWarns {
+				t.Errorf("got %d errors and %d warnings, want %d and %d: %+v", errs, warns, tt.wantErrors, tt.wantWarns, issues)
+			}
+			if HasValidationErrors(issues) != (tt.wantErrors > 0) {
+				t.Errorf("HasValidationErrors mismatch for %+v", issues)
+			}
+		})
+	}
+}
+
+func TestGenerateReleaseNotes(t *testing.T) {
+	notes := GenerateReleaseNotes("  Add checkout tracking ", []WorkspaceChange{
+		{EntityType: "tag", EntityID: "1", Name: "GA4 - Purchase", ChangeStatus: "added"},
+		{EntityType: "trigger", EntityID: "7", ChangeStatus: "deleted"},
+	})
+
+	want := "Add checkout tracking\n\nChanges (2):\n- added tag: GA4 - Purchase\n- deleted trigger: 7"
+	if notes != want {
+		t.Errorf("got %q, want %q", notes, want)
+	}
+
+	if notes := GenerateReleaseNotes("", nil); !strings.HasPrefix(notes, "Changes (0):") {
+		t.Errorf("unexpected notes without user text: %q", notes)
+	}
+}
+
+func TestReleaseRisks(t *testing.T) {
+	base := &tagmanager.ContainerVersion{
+		Tag: []*tagmanager.Tag{
+			{TagId: "1", Name: "GA4 Event", Type: "gaawe", FiringTriggerId: []string{"10"}},
+			{TagId: "2", Name: "Old Pixel", Type: "html"},
+			{TagId: "4", Name: "Chat Widget", Type: "html", Parameter: []*tagmanager.Parameter{{Type: "template", Key: "html", Value: "<script>a()</script>"}}},
+		},
+		Trigger: []*tagmanager.Trigger{{TriggerId: "10", Name: "Click", Type: "click"}},
+	}
+	head := &tagmanager.ContainerVersion{
+		Tag: []*tagmanager.Tag{
+			{TagId: "1", Name: "GA4 Event", Type: "gaawe", FiringTriggerId: []string{"11"}},
+			{TagId: "3", Name: "New Pixel", Type: "html"},
+			{TagId: "4", Name: "Chat Widget", Type: "html", Parameter: []*tagmanager.Parameter{{Type: "template", Key: "html", Value: "<script>b()</script>"}}},
+		},
+		Trigger: []*tagmanager.Trigger{
+			{TriggerId: "10", Name: "Click", Type: "click", Filter: []*tagmanager.Condition{{Type: "contains"}}},
+		},
+	}
+
+	got := ReleaseRisks(&WorkspaceDiff{Base: base, Head: head, Diff: DiffVersions(base, head)})
+
+	want := []ReleaseRisk{
+		{EntityType: "tag", EntityID: "1", Name: "GA4 Event", Risk: "firing conditions changed"},
+		{EntityType: "tag", EntityID: "3", Name: "New Pixel", Risk: "new Custom HTML tag"},
+		{EntityType: "tag", EntityID: "4", Name: "Chat Widget", Risk: "Custom HTML code changed"},
+		{EntityType: "tag", EntityID: "2", Name: "Old Pixel", Risk: "tag removed"},
+		{EntityType: "trigger", EntityID: "10", Name: "Click", Risk: "trigger conditions changed"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ReleaseRisks:\n got %+v\nwant %+v", got, want)
+	}
+}
@@ -0,0 +1,107 @@
+package gtm
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+
+	tagmanager "google.golang.org/api/tagmanager/v2"
+)
+
+// tagAssistantURL is where Tag Manager's Preview button opens debug mode.
+const tagAssistantURL = "https://tagassistant.google.com/#/"
+
+// PreviewLink opens a workspace or environment in Tag Assistant debug mode.
+type PreviewLink struct {
+	TagAssistantURL string `json:"tagAssistantUrl"`
+	SiteURL         string `json:"siteUrl"`
+	PublicID        string `json:"publicId"`
+	EnvironmentID   string `json:"environmentId"`
+	EnvironmentName string `json:"environmentName"`
+	EnvironmentType string `json:"environmentType"`
+	WorkspaceID     string `json:"workspaceId,omitempty"`
+	// QueryParams are appended to the gtm.js URL to load the previewed
+	// container without Tag Assistant, e.g. in automated QA.
+	QueryParams string `json:"queryParams,omitempty"`
+}
+
+// PreviewEnvironment returns the environment debug mode loads: the named
+// environment (user, live or latest) when name is set, otherwise the
+// workspace's own preview environment. It returns nil if none exists; a
+// workspace environment appears once preview has been started for the
+// workspace in Tag Manager.
+func (c *Client) PreviewEnvironment(ctx context.Context, accountID, containerID, workspaceID, name string) (*tagmanager.Environment, error) {
+	parent := BuildContainerPath(accountID, containerID)
+	resp, err := RetryWithBackoff(ctx, func() (*tagmanager.ListEnvironmentsResponse, error) {
+		return c.Service.Accounts.Containers.Environments.List(parent).Context(ctx).Do()
+	})
+	if err != nil {
+		return nil, MapGoogleError(err)
+	}
+	for _, env := range resp.Environment {
+		if name != "" {
+			if env.Type != "workspace" && strings.EqualFold(env.Name, name) {
+				return env, nil
+			}
+		} else if env.Type == "workspace" && env.WorkspaceId == workspaceID {
+			return env, nil
+		}
+	}
+	return nil, nil
+}
+
+// previewLink builds the Tag Assistant URL for env of the container with
+// the given public ID, opening siteURL. The live environment needs no
+// authorization, so it is previewed without environment parameters.
+func previewLink(publicID string, env *tagmanager.Environment, siteURL string) (*PreviewLink, error) {
+	site, err := url.Parse(siteURL)
+	if err != nil || (site.Scheme != "http" && site.Scheme != "https") || site.Host == "" {
+		return nil, InvalidField("url", "url %q is not an http or https URL", siteURL)
+	}
+
+	params := []string{"source=TAG_MANAGER", "id=" + url.QueryEscape(publicID)}
+	link := &PreviewLink{
+		SiteURL:         site.String(),
+		PublicID:        publicID,
+		EnvironmentID:   env.EnvironmentId,
+		EnvironmentName: env.Name,
+		EnvironmentType: env.Type,
+		WorkspaceID:     env.WorkspaceId,
+	}
+	if env.Type != "live" {
+		params = append(params, "gtm_auth="+url.QueryEscape(env.AuthorizationCode), "gtm_preview=env-"+url.QueryEscape(env.EnvironmentId))
+		link.QueryParams = environmentQueryParams(env)
+	}
+	params = append(params, "url="+url.QueryEscape(link.SiteURL))
+	link.TagAssistantURL = tagAssistantURL + "?" + strings.Join(params, "&")
+	return link, nil
+}
+
+// GetPreviewLink builds the Tag Assistant link previewing a workspace, or
+// the named environment when environment is set, on siteURL. An empty
+// siteURL falls back to the environment's URL.
+func (c *Client) GetPreviewLink(ctx context.Context, accountID, containerID, workspaceID, environment, siteURL string) (*PreviewLink, error) {
+	env, err := c.PreviewEnvironment(ctx, accountID, containerID, workspaceID, environment)
+	if err != nil {
+		return nil, err
+	}
+	if env == nil {
+		if environment != "" {
+			return nil, fmt.Errorf("%w: environment %q does not exist in container %s", ErrNotFound, environment, containerID)
+		}
+		return nil, fmt.Errorf("%w: workspace %s has no preview environment yet; click Preview once for this workspace in Tag Manager, then ask again", ErrNotFound, workspaceID)
+	}
+	if siteURL == "" {
+		siteURL = env.Url
+	}
+	if siteURL == "" {
+		return nil, InvalidField("url", "url is required because environment %q has no default URL", env.Name)
+	}
+
+	container, err := c.GetContainer(ctx, BuildContainerPath(accountID, containerID))
+	if err != nil {
+		return nil, err
+	}
+	return previewLink(container.PublicID, env, siteURL)
+}
@@ -0,0 +1,79 @@
+package gtm
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	tagmanager "google.golang.org/api/tagmanager/v2"
+)
+
+func TestPreviewLink(t *testing.T) {
+	env := &tagmanager.Environment{EnvironmentId: "5", Name: "Default Workspace", Type: "workspace", WorkspaceId: "3", AuthorizationCode: "a b"}
+	link, err := previewLink("GTM-ABC", env, "https://example.com/shop?x=1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "https://tagassistant.google.com/#/?source=TAG_MANAGER&id=GTM-ABC&gtm_auth=a+b&gtm_preview=env-5&url=https%3A%2F%2Fexample.com%2Fshop%3Fx%3D1"
+	if link.TagAssistantURL != want {
+		t.Errorf("TagAssistantURL = %q, want %q", link.TagAssistantURL, want)
+	}
+	if link.QueryParams == "" || link.WorkspaceID != "3" {
+		t.Errorf("link = %+v", link)
+	}
+
+	live, err := previewLink("GTM-ABC", &tagmanager.Environment{EnvironmentId: "1", Name: "Live", Type: "live", AuthorizationCode: "x"}, "http://example.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(live.TagAssistantURL, "gtm_auth") || live.QueryParams != "" {
+		t.Errorf("live link carries environment parameters: %+v", live)
+	}
+
+	for _, bad := range []string{"example.com", "javascript:alert(1)", "ftp://example.com", "https://"} {
+		if _, err := previewLink("GTM-ABC", env, bad); !errors.Is(err, ErrInvalidRequest) {
+			t.Errorf("%q: err = %v, want ErrInvalidRequest", bad, err)
+		}
+	}
+}
+
+func TestGetPreviewLink(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, "/environments") {
+			io.WriteString(w, `{"environment":[
+				{"environmentId":"1","name":"Live","type":"live"},
+				{"environmentId":"4","name":"Staging","type":"user","authorizationCode":"stg","url":"https://staging.example.com"},
+				{"environmentId":"5","name":"Default Workspace","type":"workspace","workspaceId":"3","authorizationCode":"ws"}
+			]}`)
+			return
+		}
+		io.WriteString(w, `{"containerId":"2","publicId":"GTM-ABC","usageContext":["web"]}`)
+	})
+	ctx := context.Background()
+
+	link, err := client.GetPreviewLink(ctx, "1", "2", "3", "", "https://example.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if link.EnvironmentID != "5" || !strings.Contains(link.TagAssistantURL, "id=GTM-ABC&gtm_auth=ws&gtm_preview=env-5") {
+		t.Errorf("workspace link = %+v", link)
+	}
+
+	link, err = client.GetPreviewLink(ctx, "1", "2", "", "staging", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if link.EnvironmentID != "4" || link.SiteURL != "https://staging.example.com" {
+		t.Errorf("environment link = %+v", link)
+	}
+
+	if _, err := client.GetPreviewLink(ctx, "1", "2", "9", "", "https://example.com"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("workspace without preview environment: err = %v, want ErrNotFound", err)
+	}
+	if _, err := client.GetPreviewLink(ctx, "1", "2", "3", "", ""); !errors.Is(err, ErrInvalidRequest) {
+		t.Errorf("missing url: err = %v, want ErrInvalidRequest", err)
+	}
+}
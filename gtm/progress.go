@@ -0,0 +1,55 @@
+package gtm
+
+import (
+	"context"
+	"fmt"
+	"sync"
+)
+
+type progressContextKey struct{}
+
+// ProgressFunc receives the progress of a long-running call: the items
+// processed so far, the items expected in total and what was just done,
+// e.g. "Deleted tag "GA4 Config"".
+type ProgressFunc func(ctx context.Context, done, total int, message string)
+
+// progressTracker counts the items a long-running call has processed and
+// reports them. Several phases of one call add to the same count, so
+// progress only increases.
+type progressTracker struct {
+	report ProgressFunc
+
+	mu    sync.Mutex
+	done  int
+	total int
+}
+
+// WithProgress returns a copy of ctx whose long-running calls (bulk
+// deletes, changesets, find and replace, imports and exports) report each
+// processed item to report.
+func WithProgress(ctx context.Context, report ProgressFunc) context.Context {
+	return context.WithValue(ctx, progressContextKey{}, &progressTracker{report: report})
+}
+
+// ExpectProgress announces n more items the tool call in ctx will process.
+func ExpectProgress(ctx context.Context, n int) {
+	if p, ok := ctx.Value(progressContextKey{}).(*progressTracker); ok {
+		p.mu.Lock()
+		p.total += n
+		p.mu.Unlock()
+	}
+}
+
+// StepProgress marks one item processed and reports it.
+func StepProgress(ctx context.Context, format string, args ...any) {
+	p, ok := ctx.Value(progressContextKey{}).(*progressTracker)
+	if !ok {
+		return
+	}
+	// Send under the lock so concurrent steps arrive in order
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.done++
+	total := max(p.total, p.done)
+	p.report(ctx, p.done, total, fmt.Sprintf(format, args...))
+}
@@ -0,0 +1,53 @@
+package gtm
+
+import (
+	"context"
+	"net/http"
+	"testing"
+)
+
+func TestExecuteBulkDelete_ReportsProgress(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/tagmanager/v2/accounts/1/containers/2/workspaces/3/tags/2" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	type progress struct {
+		done, total int
+		message     string
+	}
+	var got []progress
+	ctx := WithProgress(context.Background(), func(ctx context.Context, done, total int, message string) {
+		got = append(got, progress{done, total, message})
+	})
+
+	plan := &BulkDeletePlan{Items: []BulkDeleteItem{
+		{EntityType: EntityTypeTag, Name: "A", Path: "accounts/1/containers/2/workspaces/3/tags/1"},
+		{EntityType: EntityTypeTag, Name: "B", Path: "accounts/1/containers/2/workspaces/3/tags/2"},
+		{EntityType: EntityTypeTag, Name: "C", Path: "accounts/1/containers/2/workspaces/3/tags/3"},
+	}}
+	if deleted := client.ExecuteBulkDelete(ctx, plan); deleted != 2 {
+		t.Errorf("deleted = %d, want 2", deleted)
+	}
+
+	if len(got) != 3 {
+		t.Fatalf("got %d reports, want 3", len(got))
+	}
+	for i, p := range got {
+		if p.done != i+1 || p.total != 3 {
+			t.Errorf("report %d = %+v", i, p)
+		}
+	}
+	if want := `Failed to delete tag "B"`; got[1].message != want {
+		t.Errorf("message = %q, want %q", got[1].message, want)
+	}
+}
+
+func TestStepProgress_WithoutToken(t *testing.T) {
+	// Tools call the progress helpers whether or not the client asked
+	ExpectProgress(context.Background(), 3)
+	StepProgress(context.Background(), "Deleted %s", "x")
+}
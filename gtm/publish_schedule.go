@@ -0,0 +1,296 @@
+package gtm
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"golang.org/x/oauth2"
+)
+
+const (
+	// publishCheckInterval is how often the scheduler looks for due publishes.
+	publishCheckInterval = 30 * time.Second
+	// maxPublishDelay is how late a scheduled publish may run, e.g. after the
+	// server was down at its time. Later publishes are marked missed instead.
+	maxPublishDelay = time.Hour
+	// maxScheduleAhead bounds how far ahead a publish can be scheduled.
+	maxScheduleAhead = 90 * 24 * time.Hour
+	// scheduleRetention is how long finished publishes stay listed.
+	scheduleRetention = 30 * 24 * time.Hour
+)
+
+// Statuses of a scheduled publish.
+const (
+	PublishPending  = "pending"
+	PublishDone     = "published"
+	PublishFailed   = "failed"
+	PublishMissed   = "missed"
+	PublishCanceled = "canceled"
+)
+
+// ScheduledPublish is a container version to publish at a given time.
+type ScheduledPublish struct {
+	ID          string    `json:"id"`
+	AccountID   string    `json:"accountId"`
+	ContainerID string    `json:"containerId"`
+	VersionID   string    `json:"versionId"`
+	VersionName string    `json:"versionName,omitempty"`
+	PublishAt   time.Time `json:"publishAt"`
+	Status      string    `json:"status"`
+	RequestedBy string    `json:"requestedBy,omitempty"`
+	Note        string    `json:"note,omitempty"`
+	CreatedAt   time.Time `json:"createdAt"`
+	FinishedAt  time.Time `json:"finishedAt,omitzero"`
+	Error       string    `json:"error,omitempty"`
+}
+
+// scheduleFile is the on-disk layout of the schedule file.
+type scheduleFile struct {
+	Publishes []*ScheduledPublish `json:"publishes"`
+}
+
+// PublishScheduler publishes container versions at scheduled times. The
+// schedule is kept in a JSON file so it survives restarts, and publishes run
+// with the credential passed to Run rather than the credential of the user
+// who scheduled them.
+type PublishScheduler struct {
+	mu        sync.Mutex
+	file      string
+	publishes map[string]*ScheduledPublish // keyed by ID
+	logger    *slog.Logger
+	now       func() time.Time
+
+	// publish is replaced in tests
+	publish func(ctx context.Context, client *Client, sp *ScheduledPublish) error
+}
+
+// NewPublishScheduler loads the schedule kept in file, creating it if needed.
+func NewPublishScheduler(file string, logger *slog.Logger) (*PublishScheduler, error) {
+	s := &PublishScheduler{
+		file:      file,
+		publishes: make(map[string]*ScheduledPublish),
+		logger:    logger,
+		now:       time.Now,
+		publish: func(ctx context.Context, client *Client, sp *ScheduledPublish) error {
+			_, err := client.PublishVersion(ctx, sp.AccountID, sp.ContainerID, sp.VersionID)
+			return err
+		},
+	}
+
+	data, err := os.ReadFile(file)
+	switch {
+	case errors.Is(err, os.ErrNotExist):
+		if err := s.save(); err != nil {
+			return nil, err
+		}
+	case err != nil:
+		return nil, fmt.Errorf("failed to read schedule file: %w", err)
+	default:
+		var f scheduleFile
+		if err := json.Unmarshal(data, &f); err != nil {
+			return nil, fmt.Errorf("invalid schedule file %s: %w", file, err)
+		}
+		for _, sp := range f.Publishes {
+			s.publishes[sp.ID] = sp
+		}
+	}
+	return s, nil
+}
+
+// save writes the schedule file, replacing it atomically. The caller must
+// hold s.mu or be the only user of s.
+func (s *PublishScheduler) save() error {
+	f := scheduleFile{Publishes: s.sorted()}
+	data, err := json.MarshalIndent(f, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode schedule: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(s.file), 0o700); err != nil {
+		return fmt.Errorf("failed to create schedule directory: %w", err)
+	}
+	tmp := s.file + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o600); err != nil {
+		return fmt.Errorf("failed to write schedule file: %w", err)
+	}
+	if err := os.Rename(tmp, s.file); err != nil {
+		return fmt.Errorf("failed to write schedule file: %w", err)
+	}
+	return nil
+}
+
+// sorted returns every publish ordered by time. The caller must hold s.mu.
+func (s *PublishScheduler) sorted() []*ScheduledPublish {
+	all := make([]*ScheduledPublish, 0, len(s.publishes))
+	for _, sp := range s.publishes {
+		all = append(all, sp)
+	}
+	sort.Slice(all, func(i, j int) bool {
+		if !all[i].PublishAt.Equal(all[j].PublishAt) {
+			return all[i].PublishAt.Before(all[j].PublishAt)
+		}
+		return all[i].ID < all[j].ID
+	})
+	return all
+}
+
+// Schedule adds a pending publish. PublishAt must be in the future and at
+// most maxScheduleAhead away.
+func (s *PublishScheduler) Schedule(sp ScheduledPublish) (*ScheduledPublish, error) {
+	now := s.now()
+	if !sp.PublishAt.After(now) {
+		return nil, InvalidField("publishAt", "publishAt must be in the future")
+	}
+	if sp.PublishAt.After(now.Add(maxScheduleAhead)) {
+		return nil, InvalidField("publishAt", "publishAt must be within %d days", int(maxScheduleAhead.Hours()/24))
+	}
+	id, err := generateToken(12)
+	if err != nil {
+		return nil, err
+	}
+	sp.ID = id
+	sp.Status = PublishPending
+	sp.CreatedAt = now
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.publishes[id] = &sp
+	if err := s.save(); err != nil {
+		delete(s.publishes, id)
+		return nil, err
+	}
+	copied := sp
+	return &copied, nil
+}
+
+// List returns the publishes of a container ordered by time. Finished
+// publishes are included only with all.
+func (s *PublishScheduler) List(accountID, containerID string, all bool) []ScheduledPublish {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	list := []ScheduledPublish{}
+	for _, sp := range s.sorted() {
+		if sp.AccountID != accountID || sp.ContainerID != containerID {
+			continue
+		}
+		if all || sp.Status == PublishPending {
+			list = append(list, *sp)
+		}
+	}
+	return list
+}
+
+// Cancel cancels a pending publish of a container. Canceling a canceled
+// publish again has no effect.
+func (s *PublishScheduler) Cancel(accountID, containerID, id string) (*ScheduledPublish, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	sp, ok := s.publishes[id]
+	if !ok || sp.AccountID != accountID || sp.ContainerID != containerID {
+		return nil, fmt.Errorf("%w: no scheduled publish %s in this container", ErrNotFound, id)
+	}
+	switch sp.Status {
+	case PublishCanceled:
+	case PublishPending:
+		sp.Status = PublishCanceled
+		sp.FinishedAt = s.now()
+		if err := s.save(); err != nil {
+			sp.Status, sp.FinishedAt = PublishPending, time.Time{}
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("scheduled publish %s is already %s", id, sp.Status)
+	}
+	copied := *sp
+	return &copied, nil
+}
+
+// Run publishes due versions with the Google credential tokenSource until
+// ctx is done.
+func (s *PublishScheduler) Run(ctx context.Context, tokenSource oauth2.TokenSource) {
+	ticker := time.NewTicker(publishCheckInterval)
+	defer ticker.Stop()
+	for {
+		s.RunDue(ctx, tokenSource)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// RunDue publishes every pending version whose time has come, marks those
+// more than maxPublishDelay late as missed and forgets finished publishes
+// older than scheduleRetention.
+func (s *PublishScheduler) RunDue(ctx context.Context, tokenSource oauth2.TokenSource) {
+	s.mu.Lock()
+	now := s.now()
+	var due []*ScheduledPublish
+	changed := false
+	for id, sp := range s.publishes {
+		switch {
+		case sp.Status != PublishPending:
+			if now.Sub(sp.FinishedAt) > scheduleRetention {
+				delete(s.publishes, id)
+				changed = true
+			}
+		case now.Sub(sp.PublishAt) > maxPublishDelay:
+			sp.Status = PublishMissed
+			sp.FinishedAt = now
+			sp.Error = fmt.Sprintf("the server was not running within %s of the scheduled time", maxPublishDelay)
+			s.logger.Warn("scheduled publish missed", "id", sp.ID, "container", sp.ContainerID, "version", sp.VersionID)
+			changed = true
+		case !sp.PublishAt.After(now):
+			due = append(due, sp)
+		}
+	}
+	if changed {
+		if err := s.save(); err != nil {
+			s.logger.Error("failed to save schedule", "error", err)
+		}
+	}
+	s.mu.Unlock()
+	if len(due) == 0 {
+		return
+	}
+
+	client, clientErr := NewClient(ctx, tokenSource)
+	sort.Slice(due, func(i, j int) bool { return due[i].PublishAt.Before(due[j].PublishAt) })
+	for _, sp := range due {
+		// A publish canceled since the scan is skipped
+		s.mu.Lock()
+		pending := sp.Status == PublishPending
+		s.mu.Unlock()
+		if !pending {
+			continue
+		}
+
+		err := clientErr
+		if err == nil {
+			err = s.publish(ctx, client, sp)
+		}
+
+		s.mu.Lock()
+		sp.FinishedAt = s.now()
+		if err != nil {
+			sp.Status = PublishFailed
+			sp.Error = err.Error()
+			s.logger.Error("scheduled publish failed", "id", sp.ID, "container", sp.ContainerID, "version", sp.VersionID, "error", err)
+		} else {
+			sp.Status = PublishDone
+			s.logger.Info("scheduled publish done", "id", sp.ID, "container", sp.ContainerID, "version", sp.VersionID)
+		}
+		if err := s.save(); err != nil {
+			s.logger.Error("failed to save schedule", "error", err)
+		}
+		s.mu.Unlock()
+	}
+}
@@ -0,0 +1,101 @@
+package gtm
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"golang.org/x/oauth2"
+)
+
+func newTestScheduler(t *testing.T, file string, now *time.Time) *PublishScheduler {
+	t.Helper()
+	s, err := NewPublishScheduler(file, slog.New(slog.NewTextHandler(io.Discard, nil)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	s.now = func() time.Time { return *now }
+	return s
+}
+
+func TestPublishScheduler_PublishesDue(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "schedule.json")
+	now := time.Date(2026, 3, 1, 8, 0, 0, 0, time.UTC)
+	s := newTestScheduler(t, file, &now)
+
+	launch, err := s.Schedule(ScheduledPublish{AccountID: "1", ContainerID: "2", VersionID: "7", PublishAt: now.Add(time.Hour)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	late, _ := s.Schedule(ScheduledPublish{AccountID: "1", ContainerID: "2", VersionID: "8", PublishAt: now.Add(2 * time.Hour)})
+	canceled, _ := s.Schedule(ScheduledPublish{AccountID: "1", ContainerID: "2", VersionID: "9", PublishAt: now.Add(time.Hour)})
+	if _, err := s.Cancel("1", "2", canceled.ID); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.Schedule(ScheduledPublish{AccountID: "1", ContainerID: "2", VersionID: "7", PublishAt: now}); err == nil {
+		t.Error("scheduled a publish in the past")
+	}
+
+	// The schedule survives a restart
+	s = newTestScheduler(t, file, &now)
+	var published []string
+	s.publish = func(ctx context.Context, client *Client, sp *ScheduledPublish) error {
+		published = append(published, sp.VersionID)
+		if sp.VersionID == "8" {
+			return errors.New("boom")
+		}
+		return nil
+	}
+	if got := s.List("1", "2", false); len(got) != 2 {
+		t.Fatalf("pending = %+v, want versions 7 and 8", got)
+	}
+
+	ts := oauth2.StaticTokenSource(&oauth2.Token{AccessToken: "x"})
+	s.RunDue(context.Background(), ts)
+	if len(published) != 0 {
+		t.Fatalf("published %v before their time", published)
+	}
+
+	now = now.Add(2 * time.Hour)
+	s.RunDue(context.Background(), ts)
+	if len(published) != 2 || published[0] != "7" {
+		t.Errorf("published %v, want 7 then 8", published)
+	}
+
+	status := map[string]string{}
+	for _, sp := range s.List("1", "2", true) {
+		status[sp.ID] = sp.Status
+	}
+	if status[launch.ID] != PublishDone || status[late.ID] != PublishFailed || status[canceled.ID] != PublishCanceled {
+		t.Errorf("statuses = %v", status)
+	}
+	if len(s.List("1", "2", false)) != 0 {
+		t.Error("finished publishes are listed as pending")
+	}
+}
+
+func TestPublishScheduler_Missed(t *testing.T) {
+	now := time.Date(2026, 3, 1, 8, 0, 0, 0, time.UTC)
+	s := newTestScheduler(t, filepath.Join(t.TempDir(), "schedule.json"), &now)
+	s.publish = func(ctx context.Context, client *Client, sp *ScheduledPublish) error {
+		t.Error("published a missed version")
+		return nil
+	}
+	sp, _ := s.Schedule(ScheduledPublish{AccountID: "1", ContainerID: "2", VersionID: "7", PublishAt: now.Add(time.Minute)})
+
+	now = now.Add(time.Minute + maxPublishDelay + time.Second)
+	s.RunDue(context.Background(), oauth2.StaticTokenSource(&oauth2.Token{AccessToken: "x"}))
+	if got := s.List("1", "2", true); len(got) != 1 || got[0].Status != PublishMissed {
+		t.Errorf("publishes = %+v", got)
+	}
+	if _, err := s.Cancel("1", "2", sp.ID); err == nil {
+		t.Error("canceled a missed publish")
+	}
+	if _, err := s.Cancel("1", "3", sp.ID); !errors.Is(err, ErrNotFound) {
+		t.Errorf("cancel in another container: err = %v, want ErrNotFound", err)
+	}
+}
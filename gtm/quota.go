@@ -0,0 +1,209 @@
+package gtm
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+const (
+	// quotaCooldown is how long a user's calls are held after a 429 without
+	// a Retry-After header.
+	quotaCooldown = 10 * time.Second
+	// quotaIdleTTL is how long an idle user's quota state is kept.
+	quotaIdleTTL = time.Hour
+)
+
+// QuotaOptions limits the Google Tag Manager API calls made for each user.
+type QuotaOptions struct {
+	// PerMinute is the number of API requests per minute per user. GTM's
+	// default per-user quota is 15. 0 disables the rate limit.
+	PerMinute int
+
+	// MaxConcurrent bounds the API requests in flight per user. 0 disables
+	// the limit.
+	MaxConcurrent int
+
+	// MaxWait is the longest a request is queued for quota before failing
+	// with ErrRateLimit.
+	MaxWait time.Duration
+}
+
+// DefaultQuota matches the default per-user quota of the GTM API.
+var DefaultQuota = QuotaOptions{PerMinute: 15, MaxConcurrent: 4, MaxWait: 30 * time.Second}
+
+// quotas is the quota tracker shared by every GTM client.
+var quotas = newQuotaTracker(DefaultQuota)
+
+// SetQuota replaces the per-user GTM API quota. Tracked users start over.
+func SetQuota(opts QuotaOptions) {
+	quotas.mu.Lock()
+	defer quotas.mu.Unlock()
+	quotas.opts = opts
+	quotas.users = make(map[string]*userQuota)
+}
+
+// quotaTracker keeps the API quota state of each user.
+type quotaTracker struct {
+	mu    sync.Mutex
+	opts  QuotaOptions
+	users map[string]*userQuota
+	now   func() time.Time
+}
+
+func newQuotaTracker(opts QuotaOptions) *quotaTracker {
+	return &quotaTracker{opts: opts, users: make(map[string]*userQuota), now: time.Now}
+}
+
+// userQuota throttles the API calls of a single user.
+type userQuota struct {
+	mu           sync.Mutex
+	opts         QuotaOptions
+	limiter      *rate.Limiter // nil without a rate limit
+	slots        chan struct{} // nil without a concurrency limit
+	blockedUntil time.Time     // set from Retry-After after a 429
+	queued       int
+	lastUsed     time.Time
+	now          func() time.Time
+}
+
+// forUser returns the quota of the user key, pruning idle users.
+func (t *quotaTracker) forUser(key string) *userQuota {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	now := t.now()
+	for k, q := range t.users {
+		q.mu.Lock()
+		idle := q.queued == 0 && now.Sub(q.lastUsed) > quotaIdleTTL
+		q.mu.Unlock()
+		if idle {
+			delete(t.users, k)
+		}
+	}
+
+	q, ok := t.users[key]
+	if !ok {
+		q = &userQuota{opts: t.opts, now: t.now, lastUsed: now}
+		if t.opts.PerMinute > 0 {
+			q.limiter = rate.NewLimiter(rate.Limit(float64(t.opts.PerMinute)/60), t.opts.PerMinute)
+		}
+		if t.opts.MaxConcurrent > 0 {
+			q.slots = make(chan struct{}, t.opts.MaxConcurrent)
+		}
+		t.users[key] = q
+	}
+	return q
+}
+
+// acquire waits until the user may make another request and returns a
+// function releasing its concurrency slot. Requests that would wait longer
+// than MaxWait fail immediately with ErrRateLimit.
+func (q *userQuota) acquire(ctx context.Context) (func(), error) {
+	q.mu.Lock()
+	now := q.now()
+	q.lastUsed = now
+	wait := max(q.blockedUntil.Sub(now), 0)
+	var reservation *rate.Reservation
+	if q.limiter != nil {
+		reservation = q.limiter.ReserveN(now, 1)
+		wait = max(wait, reservation.DelayFrom(now))
+	}
+	if wait > q.opts.MaxWait {
+		if reservation != nil {
+			reservation.CancelAt(now)
+		}
+		err := q.pressureError(wait)
+		q.mu.Unlock()
+		return nil, err
+	}
+	q.queued++
+	q.mu.Unlock()
+
+	defer func() {
+		q.mu.Lock()
+		q.queued--
+		q.mu.Unlock()
+	}()
+
+	if wait > 0 {
+		timer := time.NewTimer(wait)
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			if reservation != nil {
+				reservation.Cancel()
+			}
+			return nil, ctx.Err()
+		}
+	}
+
+	if q.slots == nil {
+		return func() {}, nil
+	}
+	select {
+	case q.slots <- struct{}{}:
+		return func() { <-q.slots }, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// pressureError describes the user's quota state. Callers hold q.mu.
+func (q *userQuota) pressureError(wait time.Duration) error {
+	return fmt.Errorf("%w: Google Tag Manager API quota for this user is exhausted (%d requests queued, %d in flight, limit %d/min); the next request slot opens in %s. Wait before retrying and avoid parallel tool calls",
+		ErrRateLimit, q.queued, len(q.slots), q.opts.PerMinute, wait.Round(time.Second))
+}
+
+// throttle holds the user's requests after Google returned a 429.
+func (q *userQuota) throttle(retryAfter time.Duration) {
+	if retryAfter <= 0 {
+		retryAfter = quotaCooldown
+	}
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if until := q.now().Add(retryAfter); until.After(q.blockedUntil) {
+		q.blockedUntil = until
+	}
+}
+
+// quotaTransport applies a user's quota to every GTM API request.
+type quotaTransport struct {
+	wrapped http.RoundTripper
+	quota   *userQuota
+}
+
+func (t *quotaTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	release, err := t.quota.acquire(req.Context())
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	resp, err := t.wrapped.RoundTrip(req)
+	if err == nil && resp.StatusCode == http.StatusTooManyRequests {
+		t.quota.throttle(retryAfter(resp.Header))
+	}
+	return resp, err
+}
+
+// retryAfter parses a Retry-After header given in seconds or as an HTTP date.
+func retryAfter(h http.Header) time.Duration {
+	value := h.Get("Retry-After")
+	if value == "" {
+		return 0
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		return time.Duration(seconds) * time.Second
+	}
+	if at, err := http.ParseTime(value); err == nil {
+		return max(time.Until(at), 0)
+	}
+	return 0
+}
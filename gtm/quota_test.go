@@ -0,0 +1,116 @@
+package gtm
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestUserQuota_RateLimit(t *testing.T) {
+	q := newQuotaTracker(QuotaOptions{PerMinute: 2}).forUser("u")
+
+	for i := 0; i < 2; i++ {
+		release, err := q.acquire(context.Background())
+		if err != nil {
+			t.Fatalf("request %d: %v", i, err)
+		}
+		release()
+	}
+
+	_, err := q.acquire(context.Background())
+	if !errors.Is(err, ErrRateLimit) || !strings.Contains(err.Error(), "limit 2/min") {
+		t.Errorf("expected quota pressure error, got %v", err)
+	}
+}
+
+func TestUserQuota_QueuesWithinMaxWait(t *testing.T) {
+	q := newQuotaTracker(QuotaOptions{PerMinute: 600, MaxWait: time.Second}).forUser("u")
+	q.limiter.SetBurst(1)
+
+	start := time.Now()
+	for i := 0; i < 3; i++ {
+		release, err := q.acquire(context.Background())
+		if err != nil {
+			t.Fatalf("request %d: %v", i, err)
+		}
+		release()
+	}
+	// 10 requests per second: the 2nd and 3rd requests wait ~100ms each
+	if elapsed := time.Since(start); elapsed < 150*time.Millisecond {
+		t.Errorf("requests were not delayed: %s", elapsed)
+	}
+}
+
+func TestUserQuota_Concurrency(t *testing.T) {
+	q := newQuotaTracker(QuotaOptions{MaxConcurrent: 1}).forUser("u")
+
+	release, err := q.acquire(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	if _, err := q.acquire(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("second request while one is in flight: %v", err)
+	}
+
+	release()
+	if release, err := q.acquire(context.Background()); err != nil {
+		t.Errorf("request after release: %v", err)
+	} else {
+		release()
+	}
+}
+
+func TestQuotaTransport_HonorsRetryAfter(t *testing.T) {
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Retry-After", "120")
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer api.Close()
+
+	q := newQuotaTracker(QuotaOptions{MaxWait: 30 * time.Second}).forUser("u")
+	client := &http.Client{Transport: &quotaTransport{wrapped: http.DefaultTransport, quota: q}}
+
+	resp, err := client.Get(api.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+
+	// The next request would wait two minutes, longer than MaxWait
+	_, err = client.Get(api.URL)
+	if !errors.Is(err, ErrRateLimit) {
+		t.Errorf("expected ErrRateLimit after Retry-After, got %v", err)
+	}
+}
+
+func TestRetryAfter(t *testing.T) {
+	tests := []struct {
+		value string
+		want  time.Duration
+	}{
+		{"", 0},
+		{"30", 30 * time.Second},
+		{"soon", 0},
+	}
+	for _, tt := range tests {
+		h := http.Header{}
+		if tt.value != "" {
+			h.Set("Retry-After", tt.value)
+		}
+		if got := retryAfter(h); got != tt.want {
+			t.Errorf("retryAfter(%q) = %s, want %s", tt.value, got, tt.want)
+		}
+	}
+
+	h := http.Header{"Retry-After": {time.Now().Add(time.Minute).UTC().Format(http.TimeFormat)}}
+	if got := retryAfter(h); got < 50*time.Second || got > time.Minute {
+		t.Errorf("retryAfter(HTTP date) = %s", got)
+	}
+}
@@ -0,0 +1,225 @@
+package gtm
+
+import (
+	"strings"
+	"sync"
+
+	tagmanager "google.golang.org/api/tagmanager/v2"
+)
+
+// RedactedValue replaces sensitive parameter values in tool output.
+const RedactedValue = "[REDACTED]"
+
+// DefaultSensitiveKeys are the parameter key fragments treated as sensitive
+// unless overridden with SetSensitiveKeys.
+var DefaultSensitiveKeys = []string{"apikey", "secret", "token", "password", "passwd", "credential", "privatekey", "accesskey"}
+
+// mapNameKeys are map entry keys whose value names the row, as in
+// name/value tables such as eventParameters or fieldsToSet.
+var mapNameKeys = map[string]bool{"name": true, "key": true, "parameter": true, "fieldName": true}
+
+// redactor replaces the values of parameters whose key (or, for name/value
+// table rows, whose name) matches a sensitive key fragment.
+type redactor struct {
+	mu        sync.RWMutex
+	fragments []string
+}
+
+// redaction is the redactor applied to get, list and export output.
+var redaction = newRedactor(DefaultSensitiveKeys)
+
+func newRedactor(keys []string) *redactor {
+	r := &redactor{}
+	r.setKeys(keys)
+	return r
+}
+
+// SetSensitiveKeys replaces the key fragments used to detect sensitive
+// parameters. Matching ignores case, '_' and '-'. An empty list restores
+// DefaultSensitiveKeys.
+func SetSensitiveKeys(keys []string) {
+	redaction.setKeys(keys)
+}
+
+// IsSensitiveKey reports whether a parameter key or name matches a
+// sensitive key fragment.
+func IsSensitiveKey(s string) bool {
+	return redaction.sensitive(s)
+}
+
+// IsRowNameKey reports whether a map entry key names its row, as in
+// name/value tables such as eventParameters.
+func IsRowNameKey(key string) bool {
+	return mapNameKeys[key]
+}
+
+// RedactAPIParameters redacts a parameter list stored in an untyped field
+// and returns how many values were replaced.
+func RedactAPIParameters(v any) int {
+	return redaction.apiParams(v)
+}
+
+// RedactTriggers redacts trigger parameters in place.
+func RedactTriggers(triggers []Trigger) int {
+	return redaction.triggers(triggers)
+}
+
+// RedactClients redacts client parameters in place.
+func RedactClients(clients []ClientInfo) int {
+	return redaction.clients(clients)
+}
+
+// RedactTransformations redacts transformation parameters in place.
+func RedactTransformations(transformations []TransformationInfo) int {
+	return redaction.transformations(transformations)
+}
+
+// RedactVersion redacts the parameters of every entity in a container
+// version.
+func RedactVersion(cv *tagmanager.ContainerVersion) int {
+	return redaction.version(cv)
+}
+
+func (r *redactor) setKeys(keys []string) {
+	if len(keys) == 0 {
+		keys = DefaultSensitiveKeys
+	}
+	fragments := make([]string, 0, len(keys))
+	for _, k := range keys {
+		if k = normalizeSensitiveKey(k); k != "" {
+			fragments = append(fragments, k)
+		}
+	}
+	r.mu.Lock()
+	r.fragments = fragments
+	r.mu.Unlock()
+}
+
+func normalizeSensitiveKey(s string) string {
+	return strings.NewReplacer("_", "", "-", "", " ", "").Replace(strings.ToLower(strings.TrimSpace(s)))
+}
+
+// sensitive reports whether a key or name contains a sensitive fragment.
+func (r *redactor) sensitive(s string) bool {
+	s = normalizeSensitiveKey(s)
+	if s == "" {
+		return false
+	}
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	for _, f := range r.fragments {
+		if strings.Contains(s, f) {
+			return true
+		}
+	}
+	return false
+}
+
+// params redacts sensitive values in place and returns how many were
+// replaced. With all set every redactable value is replaced.
+func (r *redactor) params(params []*tagmanager.Parameter, all bool) int {
+	n := 0
+	for _, p := range params {
+		if p == nil {
+			continue
+		}
+		hide := all || r.sensitive(p.Key)
+		switch p.Type {
+		case ParamList:
+			n += r.params(p.List, hide)
+		case ParamMap:
+			n += r.params(p.Map, hide || r.sensitiveRow(p.Map))
+		default:
+			if hide && redactable(p) {
+				p.Value = RedactedValue
+				n++
+			}
+		}
+	}
+	return n
+}
+
+// sensitiveRow reports whether a map is a name/value row naming a secret,
+// e.g. {name: "api_secret", value: "..."}. The name entry itself is kept.
+func (r *redactor) sensitiveRow(entries []*tagmanager.Parameter) bool {
+	for _, e := range entries {
+		if e != nil && mapNameKeys[e.Key] && r.sensitive(e.Value) {
+			return true
+		}
+	}
+	return false
+}
+
+// redactable reports whether a parameter holds a literal value worth hiding.
+// Booleans, numbers, references and plain {{variable}} references are kept.
+func redactable(p *tagmanager.Parameter) bool {
+	if p.Value == "" || p.Value == RedactedValue || IsVariableReference(p.Value) {
+		return false
+	}
+	if mapNameKeys[p.Key] {
+		return false
+	}
+	return p.Type == ParamTemplate
+}
+
+// apiParams redacts a parameter list stored in an untyped output field.
+func (r *redactor) apiParams(v any) int {
+	params, ok := v.([]*tagmanager.Parameter)
+	if !ok {
+		return 0
+	}
+	return r.params(params, false)
+}
+
+// triggers redacts trigger parameters in place.
+func (r *redactor) triggers(triggers []Trigger) int {
+	n := 0
+	for i := range triggers {
+		n += r.apiParams(triggers[i].Parameter)
+	}
+	return n
+}
+
+// clients redacts client parameters in place.
+func (r *redactor) clients(clients []ClientInfo) int {
+	n := 0
+	for i := range clients {
+		n += r.apiParams(clients[i].Parameter)
+	}
+	return n
+}
+
+// transformations redacts transformation parameters in place.
+func (r *redactor) transformations(transformations []TransformationInfo) int {
+	n := 0
+	for i := range transformations {
+		n += r.apiParams(transformations[i].Parameter)
+	}
+	return n
+}
+
+// version redacts the parameters of every entity in a container version.
+// Variables whose name looks sensitive (e.g. a constant named "Stripe API
+// Key") have all their values redacted.
+func (r *redactor) version(cv *tagmanager.ContainerVersion) int {
+	if cv == nil {
+		return 0
+	}
+	n := 0
+	for _, t := range cv.Tag {
+		n += r.params(t.Parameter, false)
+	}
+	for _, t := range cv.Trigger {
+		n += r.params(t.Parameter, false)
+	}
+	for _, v := range cv.Variable {
+		n += r.params(v.Parameter, r.sensitive(v.Name))
+	}
+	for _, c := range cv.Client {
+		n += r.params(c.Parameter, false)
+	}
+	for _, t := range cv.Transformation {
+		n += r.params(t.Parameter, false)
+	}
+	return n
+}
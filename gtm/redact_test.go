@@ -0,0 +1,108 @@
+package gtm
+
+import (
+	"testing"
+
+	tagmanager "google.golang.org/api/tagmanager/v2"
+)
+
+func TestRedactor_Sensitive(t *testing.T) {
+	r := newRedactor(nil)
+	tests := []struct {
+		key  string
+		want bool
+	}{
+		{"apiKey", true},
+		{"api_key", true},
+		{"API-Key", true},
+		{"clientSecret", true},
+		{"authToken", true},
+		{"password", true},
+		{"measurementId", false},
+		{"eventName", false},
+		{"", false},
+	}
+	for _, tt := range tests {
+		if got := r.sensitive(tt.key); got != tt.want {
+			t.Errorf("sensitive(%q) = %v, want %v", tt.key, got, tt.want)
+		}
+	}
+
+	custom := newRedactor([]string{"pixel_id"})
+	if !custom.sensitive("pixelId") || custom.sensitive("apiKey") {
+		t.Error("custom keys should replace the defaults")
+	}
+}
+
+func TestRedactor_Params(t *testing.T) {
+	params := []*tagmanager.Parameter{
+		{Type: "template", Key: "apiKey", Value: "sk_live_123"},
+		{Type: "template", Key: "accessToken", Value: "{{Access Token}}"},
+		{Type: "template", Key: "measurementId", Value: "G-ABC"},
+		{Type: "boolean", Key: "sendToken", Value: "true"},
+		{Type: "list", Key: "eventParameters", List: []*tagmanager.Parameter{
+			{Type: "map", Map: []*tagmanager.Parameter{
+				{Type: "template", Key: "name", Value: "api_secret"},
+				{Type: "template", Key: "value", Value: "s3cr3t"},
+			}},
+			{Type: "map", Map: []*tagmanager.Parameter{
+				{Type: "template", Key: "name", Value: "currency"},
+				{Type: "template", Key: "value", Value: "EUR"},
+			}},
+		}},
+		{Type: "list", Key: "tokens", List: []*tagmanager.Parameter{
+			{Type: "template", Value: "t1"},
+		}},
+	}
+
+	if n := newRedactor(nil).params(params, false); n != 3 {
+		t.Errorf("redacted %d values, want 3", n)
+	}
+
+	want := map[string]string{
+		"apiKey":        RedactedValue,
+		"accessToken":   "{{Access Token}}",
+		"measurementId": "G-ABC",
+		"sendToken":     "true",
+	}
+	for _, p := range params[:4] {
+		if p.Value != want[p.Key] {
+			t.Errorf("%s = %q, want %q", p.Key, p.Value, want[p.Key])
+		}
+	}
+	secretRow := params[4].List[0].Map
+	if secretRow[0].Value != "api_secret" || secretRow[1].Value != RedactedValue {
+		t.Errorf("secret row = %q/%q, want name kept and value redacted", secretRow[0].Value, secretRow[1].Value)
+	}
+	if v := params[4].List[1].Map[1].Value; v != "EUR" {
+		t.Errorf("non-sensitive row value = %q, want EUR", v)
+	}
+	if v := params[5].List[0].Value; v != RedactedValue {
+		t.Errorf("list under sensitive key = %q, want redacted", v)
+	}
+}
+
+func TestRedactor_Version(t *testing.T) {
+	cv := &tagmanager.ContainerVersion{
+		Variable: []*tagmanager.Variable{
+			{Name: "Stripe API Key", Type: "c", Parameter: []*tagmanager.Parameter{{Type: "template", Key: "value", Value: "pk_live_1"}}},
+			{Name: "Currency", Type: "c", Parameter: []*tagmanager.Parameter{{Type: "template", Key: "value", Value: "EUR"}}},
+		},
+		Tag: []*tagmanager.Tag{
+			{Name: "Pixel", Parameter: []*tagmanager.Parameter{{Type: "template", Key: "token", Value: "abc"}}},
+		},
+	}
+
+	if n := newRedactor(nil).version(cv); n != 2 {
+		t.Errorf("redacted %d values, want 2", n)
+	}
+	if v := cv.Variable[0].Parameter[0].Value; v != RedactedValue {
+		t.Errorf("sensitive variable value = %q, want redacted", v)
+	}
+	if v := cv.Variable[1].Parameter[0].Value; v != "EUR" {
+		t.Errorf("plain variable value = %q, want EUR", v)
+	}
+	if v := cv.Tag[0].Parameter[0].Value; v != RedactedValue {
+		t.Errorf("tag token = %q, want redacted", v)
+	}
+}
@@ -0,0 +1,61 @@
+package gtm
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// scheduleLayouts are the accepted ISO 8601 layouts without a UTC offset.
+// Such times are interpreted in the schedule time zone.
+var scheduleLayouts = []string{
+	"2006-01-02T15:04:05",
+	"2006-01-02T15:04",
+	"2006-01-02 15:04:05",
+	"2006-01-02 15:04",
+	"2006-01-02",
+}
+
+// ParseScheduleTime converts an ISO 8601 time to epoch milliseconds. Times
+// with a UTC offset (RFC 3339) are used as-is; other times are interpreted in
+// the IANA time zone tz (UTC when empty). An empty value returns 0.
+func ParseScheduleTime(value, tz string) (int64, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0, nil
+	}
+
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t.UnixMilli(), nil
+	}
+
+	loc := time.UTC
+	if tz != "" {
+		var err error
+		if loc, err = time.LoadLocation(tz); err != nil {
+			return 0, fmt.Errorf("invalid time zone %q: use an IANA name like Europe/Rome", tz)
+		}
+	}
+
+	for _, layout := range scheduleLayouts {
+		if t, err := time.ParseInLocation(layout, value, loc); err == nil {
+			return t.UnixMilli(), nil
+		}
+	}
+	return 0, fmt.Errorf("invalid time %q: use ISO 8601, e.g. 2026-03-01T09:00 or 2026-03-01T09:00:00+01:00", value)
+}
+
+// ParseSchedule converts ISO 8601 start and end times in a time zone to the
+// scheduleStartMs/scheduleEndMs values GTM expects, and checks that end is after start.
+func ParseSchedule(start, end, tz string) (startMs, endMs int64, err error) {
+	if startMs, err = ParseScheduleTime(start, tz); err != nil {
+		return 0, 0, fmt.Errorf("scheduleStart: %w", err)
+	}
+	if endMs, err = ParseScheduleTime(end, tz); err != nil {
+		return 0, 0, fmt.Errorf("scheduleEnd: %w", err)
+	}
+	if startMs != 0 && endMs != 0 && endMs <= startMs {
+		return 0, 0, fmt.Errorf("scheduleEnd must be after scheduleStart")
+	}
+	return startMs, endMs, nil
+}
@@ -0,0 +1,50 @@
+package gtm
+
+import "testing"
+
+func TestParseScheduleTime(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		tz      string
+		want    int64
+		wantErr bool
+	}{
+		{"empty", "", "", 0, false},
+		{"rfc3339 with offset ignores tz", "2026-03-01T09:00:00+01:00", "America/New_York", 1772352000000, false},
+		{"utc default", "2026-03-01T08:00", "", 1772352000000, false},
+		{"time zone", "2026-03-01T09:00:00", "Europe/Rome", 1772352000000, false},
+		{"date only", "2026-03-01", "UTC", 1772323200000, false},
+		{"bad time zone", "2026-03-01", "Mars/Base", 0, true},
+		{"bad format", "03/01/2026", "", 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseScheduleTime(tt.value, tt.tz)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("got %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseSchedule(t *testing.T) {
+	if _, _, err := ParseSchedule("2026-03-02", "2026-03-01", "UTC"); err == nil {
+		t.Error("expected error when end is before start")
+	}
+	if _, _, err := ParseSchedule("2026-03-01", "2026-03-01", "UTC"); err == nil {
+		t.Error("expected error when end equals start")
+	}
+
+	start, end, err := ParseSchedule("", "2026-03-01", "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if start != 0 || end != 1772323200000 {
+		t.Errorf("got start=%d end=%d", start, end)
+	}
+}
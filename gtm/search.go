@@ -0,0 +1,226 @@
+package gtm
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+
+	tagmanager "google.golang.org/api/tagmanager/v2"
+)
+
+// searchExcerptRadius is the number of characters kept on each side of a
+// match when long values such as Custom HTML are shortened.
+const searchExcerptRadius = 40
+
+// SearchOptions configures a full text search across workspace entities.
+type SearchOptions struct {
+	Query string
+	// Regex treats Query as a regular expression.
+	Regex bool
+	// CaseSensitive disables the default case-insensitive matching.
+	CaseSensitive bool
+	// EntityTypes limits the search (tag, trigger, variable). Empty means all three.
+	EntityTypes []string
+	// IncludeSensitive matches and returns parameter values that look like
+	// secrets. By default they are redacted before matching, so a query
+	// cannot probe them either.
+	IncludeSensitive bool
+}
+
+// SearchMatch is a single field of an entity that matched the query.
+type SearchMatch struct {
+	Field string `json:"field"`
+	Value string `json:"value"`
+}
+
+// SearchHit is an entity with at least one matching field.
+type SearchHit struct {
+	EntityID string        `json:"entityId"`
+	Name     string        `json:"name"`
+	Type     string        `json:"type"`
+	Matches  []SearchMatch `json:"matches"`
+}
+
+// SearchResults groups search hits by entity type.
+type SearchResults struct {
+	Tags      []SearchHit `json:"tags"`
+	Triggers  []SearchHit `json:"triggers"`
+	Variables []SearchHit `json:"variables"`
+	Total     int         `json:"total"`
+}
+
+// searcher matches entity fields against a query.
+type searcher struct {
+	re *regexp.Regexp
+}
+
+func newSearcher(opts SearchOptions) (*searcher, error) {
+	if opts.Query == "" {
+		return nil, fmt.Errorf("query is required")
+	}
+	pattern := opts.Query
+	if !opts.Regex {
+		pattern = regexp.QuoteMeta(pattern)
+	}
+	if !opts.CaseSensitive {
+		pattern = "(?i)" + pattern
+	}
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, fmt.Errorf("invalid regex: %w", err)
+	}
+	return &searcher{re: re}, nil
+}
+
+// matchField records field when value matches, shortening long values to an
+// excerpt around the first match.
+func (s *searcher) matchField(field, value string, matches *[]SearchMatch) {
+	loc := s.re.FindStringIndex(value)
+	if loc == nil {
+		return
+	}
+	*matches = append(*matches, SearchMatch{Field: field, Value: excerpt(value, loc[0], loc[1])})
+}
+
+func (s *searcher) matchParams(field string, params []*tagmanager.Parameter, matches *[]SearchMatch) {
+	for i, p := range params {
+		if p == nil {
+			continue
+		}
+		name := fmt.Sprintf("%s[%d]", field, i)
+		if p.Key != "" {
+			name = fmt.Sprintf("%s[%s]", field, p.Key)
+		}
+		s.matchField(name+".value", p.Value, matches)
+		s.matchParams(name+".list", p.List, matches)
+		s.matchParams(name+".map", p.Map, matches)
+	}
+}
+
+func (s *searcher) matchConditions(field string, conditions []*tagmanager.Condition, matches *[]SearchMatch) {
+	for i, c := range conditions {
+		s.matchParams(fmt.Sprintf("%s[%d].parameter", field, i), c.Parameter, matches)
+	}
+}
+
+// matchCommon checks the fields shared by all entity types.
+func (s *searcher) matchCommon(name, entityType, notes string) []SearchMatch {
+	var matches []SearchMatch
+	s.matchField("name", name, &matches)
+	s.matchField("type", entityType, &matches)
+	s.matchField("notes", notes, &matches)
+	return matches
+}
+
+func (s *searcher) searchTag(t *tagmanager.Tag) []SearchMatch {
+	matches := s.matchCommon(t.Name, t.Type, t.Notes)
+	s.matchParams("parameter", t.Parameter, &matches)
+	return matches
+}
+
+func (s *searcher) searchTrigger(t *tagmanager.Trigger) []SearchMatch {
+	matches := s.matchCommon(t.Name, t.Type, t.Notes)
+	s.matchParams("parameter", t.Parameter, &matches)
+	s.matchConditions("filter", t.Filter, &matches)
+	s.matchConditions("autoEventFilter", t.AutoEventFilter, &matches)
+	s.matchConditions("customEventFilter", t.CustomEventFilter, &matches)
+	if t.EventName != nil {
+		s.matchParams("eventName", []*tagmanager.Parameter{t.EventName}, &matches)
+	}
+	return matches
+}
+
+func (s *searcher) searchVariable(v *tagmanager.Variable) []SearchMatch {
+	matches := s.matchCommon(v.Name, v.Type, v.Notes)
+	s.matchParams("parameter", v.Parameter, &matches)
+	return matches
+}
+
+// excerpt shortens value to the match at [start, end) plus some context.
+func excerpt(value string, start, end int) string {
+	from, to := start-searchExcerptRadius, end+searchExcerptRadius
+	prefix, suffix := "…", "…"
+	if from <= 0 {
+		from, prefix = 0, ""
+	}
+	if to >= len(value) {
+		to, suffix = len(value), ""
+	}
+	// Avoid cutting multi-byte characters in half.
+	for from > 0 && !isRuneStart(value[from]) {
+		from--
+	}
+	for to < len(value) && !isRuneStart(value[to]) {
+		to++
+	}
+	return prefix + value[from:to] + suffix
+}
+
+func isRuneStart(b byte) bool {
+	return b&0xC0 != 0x80
+}
+
+// SearchWorkspace searches names, notes, types and parameter values of the
+// tags, triggers and variables in a workspace.
+func (c *Client) SearchWorkspace(ctx context.Context, accountID, containerID, workspaceID string, opts SearchOptions) (*SearchResults, error) {
+	s, err := newSearcher(opts)
+	if err != nil {
+		return nil, err
+	}
+	kinds, err := EntityKinds(opts.EntityTypes)
+	if err != nil {
+		return nil, err
+	}
+
+	parent := BuildWorkspacePath(accountID, containerID, workspaceID)
+	ws := c.Service.Accounts.Containers.Workspaces
+	results := &SearchResults{Tags: []SearchHit{}, Triggers: []SearchHit{}, Variables: []SearchHit{}}
+
+	if kinds[EntityTypeTag] {
+		tags, err := listAllTags(ctx, ws, parent)
+		if err != nil {
+			return nil, MapGoogleError(err)
+		}
+		for _, t := range tags {
+			if !opts.IncludeSensitive {
+				redaction.params(t.Parameter, false)
+			}
+			if matches := s.searchTag(t); len(matches) > 0 {
+				results.Tags = append(results.Tags, SearchHit{EntityID: t.TagId, Name: t.Name, Type: t.Type, Matches: matches})
+			}
+		}
+	}
+
+	if kinds[EntityTypeTrigger] {
+		triggers, err := listAllTriggers(ctx, ws, parent)
+		if err != nil {
+			return nil, MapGoogleError(err)
+		}
+		for _, t := range triggers {
+			if !opts.IncludeSensitive {
+				redaction.params(t.Parameter, false)
+			}
+			if matches := s.searchTrigger(t); len(matches) > 0 {
+				results.Triggers = append(results.Triggers, SearchHit{EntityID: t.TriggerId, Name: t.Name, Type: t.Type, Matches: matches})
+			}
+		}
+	}
+
+	if kinds[EntityTypeVariable] {
+		variables, err := listAllVariables(ctx, ws, parent)
+		if err != nil {
+			return nil, MapGoogleError(err)
+		}
+		for _, v := range variables {
+			if !opts.IncludeSensitive {
+				redaction.params(v.Parameter, redaction.sensitive(v.Name))
+			}
+			if matches := s.searchVariable(v); len(matches) > 0 {
+				results.Variables = append(results.Variables, SearchHit{EntityID: v.VariableId, Name: v.Name, Type: v.Type, Matches: matches})
+			}
+		}
+	}
+
+	results.Total = len(results.Tags) + len(results.Triggers) + len(results.Variables)
+	return results, nil
+}
@@ -0,0 +1,172 @@
+package gtm
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+
+	tagmanager "google.golang.org/api/tagmanager/v2"
+)
+
+func TestSearcher_SearchTag(t *testing.T) {
+	tag := &tagmanager.Tag{
+		Name:  "GA4 - Purchase",
+		Type:  "gaawe",
+		Notes: "Owned by the analytics team",
+		Parameter: []*tagmanager.Parameter{
+			{Type: "template", Key: "eventName", Value: "purchase"},
+			{Type: "template", Key: "measurementIdOverride", Value: "G-ABC123"},
+			{Type: "list", Key: "eventSettingsTable", List: []*tagmanager.Parameter{
+				{Type: "map", Map: []*tagmanager.Parameter{
+					{Type: "template", Key: "parameter", Value: "currency"},
+					{Type: "template", Key: "parameterValue", Value: "{{DLV - Currency}}"},
+				}},
+			}},
+		},
+	}
+
+	tests := []struct {
+		name    string
+		opts    SearchOptions
+		want    []SearchMatch
+		wantErr bool
+	}{
+		{
+			name: "case-insensitive name and parameter",
+			opts: SearchOptions{Query: "PURCHASE"},
+			want: []SearchMatch{
+				{Field: "name", Value: "GA4 - Purchase"},
+				{Field: "parameter[eventName].value", Value: "purchase"},
+			},
+		},
+		{
+			name: "case-sensitive",
+			opts: SearchOptions{Query: "Purchase", CaseSensitive: true},
+			want: []SearchMatch{{Field: "name", Value: "GA4 - Purchase"}},
+		},
+		{
+			name: "nested map values",
+			opts: SearchOptions{Query: "curr"},
+			want: []SearchMatch{
+				{Field: "parameter[eventSettingsTable].list[0].map[parameter].value", Value: "currency"},
+				{Field: "parameter[eventSettingsTable].list[0].map[parameterValue].value", Value: "{{DLV - Currency}}"},
+			},
+		},
+		{
+			name: "regex",
+			opts: SearchOptions{Query: `^G-[A-Z0-9]+$`, Regex: true},
+			want: []SearchMatch{{Field: "parameter[measurementIdOverride].value", Value: "G-ABC123"}},
+		},
+		{
+			name: "literal query is not a regex",
+			opts: SearchOptions{Query: "G-.*"},
+		},
+		{
+			name: "type",
+			opts: SearchOptions{Query: "gaawe"},
+			want: []SearchMatch{{Field: "type", Value: "gaawe"}},
+		},
+		{
+			name:    "empty query",
+			opts:    SearchOptions{},
+			wantErr: true,
+		},
+		{
+			name:    "invalid regex",
+			opts:    SearchOptions{Query: "(", Regex: true},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, err := newSearcher(tt.opts)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("newSearcher() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if got := s.searchTag(tag); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSearcher_SearchTrigger(t *testing.T) {
+	s, err := newSearcher(SearchOptions{Query: "checkout"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	trigger := &tagmanager.Trigger{
+		Name: "Page View - Cart",
+		Type: "pageview",
+		Filter: []*tagmanager.Condition{{Type: "contains", Parameter: []*tagmanager.Parameter{
+			{Type: "template", Key: "arg0", Value: "{{Page Path}}"},
+			{Type: "template", Key: "arg1", Value: "/checkout"},
+		}}},
+	}
+
+	got := s.searchTrigger(trigger)
+	want := []SearchMatch{{Field: "filter[0].parameter[arg1].value", Value: "/checkout"}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %+v, want %+v", got, want)
+	}
+}
+
+func TestExcerpt(t *testing.T) {
+	long := strings.Repeat("a", 100) + "needle" + strings.Repeat("b", 100)
+	got := excerpt(long, 100, 106)
+	want := "…" + strings.Repeat("a", searchExcerptRadius) + "needle" + strings.Repeat("b", searchExcerptRadius) + "…"
+	if got != want {
+		t.Errorf("excerpt() = %q, want %q", got, want)
+	}
+	if got := excerpt("short needle", 6, 12); got != "short needle" {
+		t.Errorf("excerpt() = %q, want unchanged value", got)
+	}
+}
+
+func TestSearchWorkspace_RedactsSensitiveValues(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/tags"):
+			io.WriteString(w, `{"tag":[{"tagId":"1","name":"Webhook","type":"html","parameter":[
+				{"type":"template","key":"apiKey","value":"sk_live_1234"},
+				{"type":"template","key":"html","value":"<script>send('sk_live_1234')</script>"}]}]}`)
+		case strings.HasSuffix(r.URL.Path, "/triggers"):
+			io.WriteString(w, `{"trigger":[]}`)
+		default:
+			io.WriteString(w, `{"variable":[{"variableId":"2","name":"Stripe Secret","type":"c","parameter":[{"type":"template","key":"value","value":"sk_live_5678"}]}]}`)
+		}
+	})
+
+	results, err := client.SearchWorkspace(context.Background(), "1", "2", "3", SearchOptions{Query: "sk_live"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Only the Custom HTML, which is not a sensitive parameter, can match
+	want := []SearchMatch{{Field: "parameter[html].value", Value: "<script>send('sk_live_1234')</script>"}}
+	if len(results.Tags) != 1 || !reflect.DeepEqual(results.Tags[0].Matches, want) || len(results.Variables) != 0 {
+		t.Errorf("results = %+v", results)
+	}
+
+	results, err = client.SearchWorkspace(context.Background(), "1", "2", "3", SearchOptions{Query: "Stripe"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(results.Variables) != 1 || results.Variables[0].Matches[0].Field != "name" {
+		t.Errorf("variables = %+v", results.Variables)
+	}
+
+	results, err = client.SearchWorkspace(context.Background(), "1", "2", "3", SearchOptions{Query: "sk_live", IncludeSensitive: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if results.Total != 2 || len(results.Tags[0].Matches) != 2 || results.Variables[0].Matches[0].Value != "sk_live_5678" {
+		t.Errorf("results with includeSensitive = %+v", results)
+	}
+}
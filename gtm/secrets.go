@@ -0,0 +1,221 @@
+package gtm
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+
+	tagmanager "google.golang.org/api/tagmanager/v2"
+)
+
+// Secret finding kinds.
+const (
+	SecretKindAWSKey        = "aws_access_key"
+	SecretKindGoogleAPIKey  = "google_api_key"
+	SecretKindStripeKey     = "stripe_secret_key"
+	SecretKindGitHubToken   = "github_token"
+	SecretKindSlackToken    = "slack_token"
+	SecretKindSlackWebhook  = "slack_webhook"
+	SecretKindMetaToken     = "meta_access_token"
+	SecretKindPrivateKey    = "private_key"
+	SecretKindJWT           = "jwt"
+	SecretKindBearerToken   = "bearer_token"
+	SecretKindEmail         = "email"
+	SecretKindSensitiveName = "sensitive_parameter"
+)
+
+// secretPattern is a value pattern that looks like a credential or PII.
+type secretPattern struct {
+	kind     string
+	severity string
+	re       *regexp.Regexp
+}
+
+// secretPatterns are checked against every literal value. Google API keys
+// are only a warning: browser keys for Maps and similar APIs are meant to be
+// public, restricted by referrer.
+var secretPatterns = []secretPattern{
+	{SecretKindAWSKey, SeverityError, regexp.MustCompile(`\b(?:AKIA|ASIA)[0-9A-Z]{16}\b`)},
+	{SecretKindGoogleAPIKey, SeverityWarning, regexp.MustCompile(`\bAIza[0-9A-Za-z_\-]{35}`)},
+	{SecretKindStripeKey, SeverityError, regexp.MustCompile(`\b[rs]k_live_[0-9A-Za-z]{16,}`)},
+	{SecretKindGitHubToken, SeverityError, regexp.MustCompile(`\b(?:gh[pousr]_[0-9A-Za-z]{36,}|github_pat_[0-9A-Za-z_]{40,})`)},
+	{SecretKindSlackToken, SeverityError, regexp.MustCompile(`\bxox[abprs]-[0-9A-Za-z\-]{10,}`)},
+	{SecretKindSlackWebhook, SeverityError, regexp.MustCompile(`https://hooks\.slack\.com/services/[0-9A-Za-z/]+`)},
+	{SecretKindMetaToken, SeverityError, regexp.MustCompile(`\bEAA[0-9A-Za-z]{60,}`)},
+	{SecretKindPrivateKey, SeverityError, regexp.MustCompile(`-----BEGIN [A-Z ]*PRIVATE KEY-----`)},
+	{SecretKindJWT, SeverityError, regexp.MustCompile(`\beyJ[0-9A-Za-z_\-]{10,}\.eyJ[0-9A-Za-z_\-]{10,}\.[0-9A-Za-z_\-]{10,}`)},
+	{SecretKindBearerToken, SeverityError, regexp.MustCompile(`(?i)\bbearer\s+[0-9A-Za-z\-._~+/]{20,}=*`)},
+	{SecretKindEmail, SeverityWarning, regexp.MustCompile(`\b[0-9A-Za-z._%+\-]+@[0-9A-Za-z.\-]+\.[A-Za-z]{2,}\b`)},
+}
+
+// retinaImagePattern matches image names such as logo@2x.png, which look
+// like email addresses.
+var retinaImagePattern = regexp.MustCompile(`(?i)@\d(?:\.\d)?x\.(?:png|jpe?g|gif|webp|svg)$`)
+
+// SecretFinding is a value in a workspace entity that looks like a
+// credential or personal data. Match is masked so the secret itself is not
+// repeated in tool output.
+type SecretFinding struct {
+	Severity   string `json:"severity"`
+	Kind       string `json:"kind"`
+	EntityType string `json:"entityType"`
+	EntityID   string `json:"entityId"`
+	Name       string `json:"name"`
+	Field      string `json:"field"`
+	Match      string `json:"match"`
+}
+
+// secretScanner collects the findings of one entity.
+type secretScanner struct {
+	findings []SecretFinding
+	seen     map[string]bool
+}
+
+func (s *secretScanner) add(severity, kind, field, match string) {
+	key := kind + "\x00" + field + "\x00" + match
+	if s.seen[key] {
+		return
+	}
+	if s.seen == nil {
+		s.seen = make(map[string]bool)
+	}
+	s.seen[key] = true
+	s.findings = append(s.findings, SecretFinding{Severity: severity, Kind: kind, Field: field, Match: maskSecret(match)})
+}
+
+// scanValue checks a value against the secret patterns.
+func (s *secretScanner) scanValue(field, value string) {
+	if value == "" || IsVariableReference(value) {
+		return
+	}
+	for _, p := range secretPatterns {
+		for _, m := range p.re.FindAllString(value, -1) {
+			if p.kind == SecretKindEmail && retinaImagePattern.MatchString(m) {
+				continue
+			}
+			s.add(p.severity, p.kind, field, m)
+		}
+	}
+}
+
+// scanParams checks parameter values, and flags literal values under
+// sensitive keys or in name/value rows naming a secret, as the output
+// redaction does.
+func (s *secretScanner) scanParams(field string, params []*tagmanager.Parameter, sensitive bool) {
+	for i, p := range params {
+		if p == nil {
+			continue
+		}
+		name := fmt.Sprintf("%s[%d]", field, i)
+		if p.Key != "" {
+			name = fmt.Sprintf("%s[%s]", field, p.Key)
+		}
+		hide := sensitive || redaction.sensitive(p.Key)
+		switch p.Type {
+		case ParamList:
+			s.scanParams(name+".list", p.List, hide)
+		case ParamMap:
+			s.scanParams(name+".map", p.Map, hide || redaction.sensitiveRow(p.Map))
+		default:
+			s.scanValue(name+".value", p.Value)
+			if hide && redactable(p) {
+				s.add(SeverityWarning, SecretKindSensitiveName, name+".value", p.Value)
+			}
+		}
+	}
+}
+
+func (s *secretScanner) scanConditions(field string, conditions []*tagmanager.Condition) {
+	for i, c := range conditions {
+		s.scanParams(fmt.Sprintf("%s[%d].parameter", field, i), c.Parameter, false)
+	}
+}
+
+// maskSecret keeps the first four characters of a matched value and its
+// length, enough to locate it without disclosing it.
+func maskSecret(s string) string {
+	runes := []rune(s)
+	if len(runes) <= 8 {
+		return fmt.Sprintf("[%d chars]", len(runes))
+	}
+	return fmt.Sprintf("%s…[%d chars]", string(runes[:4]), len(runes))
+}
+
+func scanTagSecrets(t *tagmanager.Tag) []SecretFinding {
+	var s secretScanner
+	s.scanValue("notes", t.Notes)
+	s.scanParams("parameter", t.Parameter, false)
+	return s.findings
+}
+
+func scanTriggerSecrets(t *tagmanager.Trigger) []SecretFinding {
+	var s secretScanner
+	s.scanValue("notes", t.Notes)
+	s.scanParams("parameter", t.Parameter, false)
+	s.scanConditions("filter", t.Filter)
+	s.scanConditions("autoEventFilter", t.AutoEventFilter)
+	s.scanConditions("customEventFilter", t.CustomEventFilter)
+	return s.findings
+}
+
+func scanVariableSecrets(v *tagmanager.Variable) []SecretFinding {
+	var s secretScanner
+	s.scanValue("notes", v.Notes)
+	s.scanParams("parameter", v.Parameter, false)
+	return s.findings
+}
+
+// DetectSecrets scans the notes and parameter values of the tags, triggers
+// and variables of a workspace, including Custom HTML and constants, for
+// values that look like credentials or personal data. Findings are ordered
+// by severity.
+func (c *Client) DetectSecrets(ctx context.Context, accountID, containerID, workspaceID string, entityTypes []string) ([]SecretFinding, error) {
+	kinds, err := EntityKinds(entityTypes)
+	if err != nil {
+		return nil, err
+	}
+
+	parent := BuildWorkspacePath(accountID, containerID, workspaceID)
+	ws := c.Service.Accounts.Containers.Workspaces
+	findings := []SecretFinding{}
+	collect := func(entityType, id, name string, found []SecretFinding) {
+		for _, f := range found {
+			f.EntityType, f.EntityID, f.Name = entityType, id, name
+			findings = append(findings, f)
+		}
+	}
+
+	if kinds[EntityTypeTag] {
+		tags, err := listAllTags(ctx, ws, parent)
+		if err != nil {
+			return nil, MapGoogleError(err)
+		}
+		for _, t := range tags {
+			collect(EntityTypeTag, t.TagId, t.Name, scanTagSecrets(t))
+		}
+	}
+	if kinds[EntityTypeTrigger] {
+		triggers, err := listAllTriggers(ctx, ws, parent)
+		if err != nil {
+			return nil, MapGoogleError(err)
+		}
+		for _, t := range triggers {
+			collect(EntityTypeTrigger, t.TriggerId, t.Name, scanTriggerSecrets(t))
+		}
+	}
+	if kinds[EntityTypeVariable] {
+		variables, err := listAllVariables(ctx, ws, parent)
+		if err != nil {
+			return nil, MapGoogleError(err)
+		}
+		for _, v := range variables {
+			collect(EntityTypeVariable, v.VariableId, v.Name, scanVariableSecrets(v))
+		}
+	}
+
+	sort.SliceStable(findings, func(i, j int) bool {
+		return SeverityRank[findings[i].Severity] < SeverityRank[findings[j].Severity]
+	})
+	return findings, nil
+}
@@ -0,0 +1,105 @@
+package gtm
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Google tag settings variable types.
+const (
+	VariableTypeEventSettings  = "gtes" // Google tag: Event Settings
+	VariableTypeConfigSettings = "gtcs" // Google tag: Configuration Settings
+)
+
+// settingsTable describes one parameter group of a settings variable: a list
+// of maps, each holding a name and a value under the given keys.
+type settingsTable struct {
+	key      string
+	nameKey  string
+	valueKey string
+}
+
+// settingsTables lists the parameter groups allowed for each settings variable type.
+var settingsTables = map[string][]settingsTable{
+	VariableTypeEventSettings: {
+		{key: "eventSettingsTable", nameKey: "parameter", valueKey: "parameterValue"},
+		{key: "userProperties", nameKey: "name", valueKey: "value"},
+	},
+	VariableTypeConfigSettings: {
+		{key: "configSettingsTable", nameKey: "parameter", valueKey: "parameterValue"},
+	},
+}
+
+// ValidateVariableParameters checks type-specific parameter structure. Only
+// the Google tag settings variables (gtes, gtcs) are checked; other types pass.
+func ValidateVariableParameters(varType string, params []Parameter) error {
+	tables, ok := settingsTables[varType]
+	if !ok {
+		return nil
+	}
+
+	found := false
+	for _, p := range params {
+		var table *settingsTable
+		for i := range tables {
+			if tables[i].key == p.Key {
+				table = &tables[i]
+			}
+		}
+		if table == nil {
+			return fmt.Errorf("%s variable: unexpected parameter %q (valid keys: %s)", varType, p.Key, settingsTableKeys(tables))
+		}
+		if !strings.EqualFold(p.Type, "list") {
+			return fmt.Errorf("%s variable: %s must be a list of maps", varType, p.Key)
+		}
+		if err := validateSettingsRows(varType, table, p.List); err != nil {
+			return err
+		}
+		found = true
+	}
+	if !found {
+		return fmt.Errorf("%s variable: at least one of %s is required", varType, settingsTableKeys(tables))
+	}
+	return nil
+}
+
+// validateSettingsRows checks each row has a non-empty, unique name and a value.
+func validateSettingsRows(varType string, table *settingsTable, rows []Parameter) error {
+	seen := make(map[string]bool)
+	for i, row := range rows {
+		if !strings.EqualFold(row.Type, "map") {
+			return fmt.Errorf("%s variable: %s[%d] must be a map with %s and %s", varType, table.key, i, table.nameKey, table.valueKey)
+		}
+		var name string
+		hasValue := false
+		for _, field := range row.Map {
+			switch field.Key {
+			case table.nameKey:
+				name = strings.TrimSpace(field.Value)
+			case table.valueKey:
+				hasValue = true
+			default:
+				return fmt.Errorf("%s variable: %s[%d] has unexpected key %q (expected %s and %s)", varType, table.key, i, field.Key, table.nameKey, table.valueKey)
+			}
+		}
+		if name == "" {
+			return fmt.Errorf("%s variable: %s[%d] is missing %s", varType, table.key, i, table.nameKey)
+		}
+		if !hasValue {
+			return fmt.Errorf("%s variable: %s[%d] (%s) is missing %s", varType, table.key, i, name, table.valueKey)
+		}
+		if seen[name] {
+			return fmt.Errorf("%s variable: duplicate %s %q in %s", varType, table.nameKey, name, table.key)
+		}
+		seen[name] = true
+	}
+	return nil
+}
+
+func settingsTableKeys(tables []settingsTable) string {
+	keys := make([]string, len(tables))
+	for i, t := range tables {
+		keys[i] = t.key
+	}
+	return strings.Join(keys, ", ")
+}
@@ -0,0 +1,123 @@
+package gtm
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func settingsRow(nameKey, name, valueKey, value string) Parameter {
+	return Parameter{Type: "map", Map: []Parameter{
+		{Type: "template", Key: nameKey, Value: name},
+		{Type: "template", Key: valueKey, Value: value},
+	}}
+}
+
+func TestValidateVariableParameters(t *testing.T) {
+	tests := []struct {
+		name    string
+		varType string
+		params  []Parameter
+		wantErr string
+	}{
+		{
+			name:    "other types are not checked",
+			varType: "c",
+			params:  []Parameter{{Type: "template", Key: "anything", Value: "x"}},
+		},
+		{
+			name:    "valid event settings",
+			varType: "gtes",
+			params: []Parameter{
+				{Type: "list", Key: "eventSettingsTable", List: []Parameter{settingsRow("parameter", "page_type", "parameterValue", "{{Page Type}}")}},
+				{Type: "list", Key: "userProperties", List: []Parameter{settingsRow("name", "tier", "value", "gold")}},
+			},
+		},
+		{
+			name:    "valid config settings with uppercase types",
+			varType: "gtcs",
+			params: []Parameter{
+				{Type: "LIST", Key: "configSettingsTable", List: []Parameter{
+					{Type: "MAP", Map: []Parameter{
+						{Type: "TEMPLATE", Key: "parameter", Value: "send_page_view"},
+						{Type: "TEMPLATE", Key: "parameterValue", Value: "false"},
+					}},
+				}},
+			},
+		},
+		{
+			name:    "no parameter groups",
+			varType: "gtes",
+			wantErr: "at least one of eventSettingsTable, userProperties",
+		},
+		{
+			name:    "config table on event settings",
+			varType: "gtes",
+			params:  []Parameter{{Type: "list", Key: "configSettingsTable"}},
+			wantErr: "unexpected parameter",
+		},
+		{
+			name:    "table is not a list",
+			varType: "gtcs",
+			params:  []Parameter{{Type: "template", Key: "configSettingsTable", Value: "send_page_view"}},
+			wantErr: "must be a list of maps",
+		},
+		{
+			name:    "wrong row keys",
+			varType: "gtes",
+			params: []Parameter{
+				{Type: "list", Key: "eventSettingsTable", List: []Parameter{settingsRow("name", "page_type", "value", "home")}},
+			},
+			wantErr: "unexpected key \"name\"",
+		},
+		{
+			name:    "missing value",
+			varType: "gtcs",
+			params: []Parameter{
+				{Type: "list", Key: "configSettingsTable", List: []Parameter{
+					{Type: "map", Map: []Parameter{{Type: "template", Key: "parameter", Value: "cookie_domain"}}},
+				}},
+			},
+			wantErr: "missing parameterValue",
+		},
+		{
+			name:    "duplicate parameter",
+			varType: "gtcs",
+			params: []Parameter{
+				{Type: "list", Key: "configSettingsTable", List: []Parameter{
+					settingsRow("parameter", "cookie_domain", "parameterValue", "auto"),
+					settingsRow("parameter", "cookie_domain", "parameterValue", "none"),
+				}},
+			},
+			wantErr: "duplicate parameter",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateVariableParameters(tt.varType, tt.params)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("expected error containing %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestGetVariableTemplates_Valid(t *testing.T) {
+	for _, tmpl := range GetVariableTemplates() {
+		var params []Parameter
+		if err := json.Unmarshal([]byte(tmpl.Parameters), &params); err != nil {
+			t.Errorf("%s: invalid parameters JSON: %v", tmpl.Name, err)
+			continue
+		}
+		if err := ValidateVariableParameters(tmpl.Type, params); err != nil {
+			t.Errorf("%s: %v", tmpl.Name, err)
+		}
+	}
+}
@@ -0,0 +1,109 @@
+package gtm
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"time"
+
+	tagmanager "google.golang.org/api/tagmanager/v2"
+)
+
+// AccountSummary is an inventory of every container in an account.
+type AccountSummary struct {
+	AccountID      string             `json:"accountId"`
+	ContainerCount int                `json:"containerCount"`
+	Containers     []ContainerSummary `json:"containers"`
+}
+
+// ContainerSummary describes a container with its live version and workspace count.
+type ContainerSummary struct {
+	ContainerID    string       `json:"containerId"`
+	Name           string       `json:"name"`
+	PublicID       string       `json:"publicId"`
+	UsageContext   []string     `json:"usageContext"`
+	LiveVersion    *LiveVersion `json:"liveVersion,omitempty"`
+	WorkspaceCount int          `json:"workspaceCount"`
+	Error          string       `json:"error,omitempty"`
+}
+
+// LiveVersion describes the currently published version of a container.
+// The API has no publish time: LastModified is when the version itself was
+// last stored, which is usually when it was created, not when it went live.
+type LiveVersion struct {
+	VersionID         string    `json:"containerVersionId"`
+	Name              string    `json:"name,omitempty"`
+	LastModified      time.Time `json:"lastModified,omitzero"`
+	DaysSinceModified int       `json:"daysSinceModified"`
+}
+
+// GetLiveVersion returns the published version of a container, or nil if
+// nothing has been published yet.
+func (c *Client) GetLiveVersion(ctx context.Context, accountID, containerID string) (*LiveVersion, error) {
+	parent := BuildContainerPath(accountID, containerID)
+
+	v, err := RetryWithBackoff(ctx, func() (*tagmanager.ContainerVersion, error) {
+		return c.Service.Accounts.Containers.Versions.Live(parent).
+			Fields("containerVersionId", "name", "fingerprint").Context(ctx).Do()
+	})
+	if err != nil {
+		err = MapGoogleError(err)
+		if errors.Is(err, ErrNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return newLiveVersion(v, time.Now()), nil
+}
+
+func newLiveVersion(v *tagmanager.ContainerVersion, now time.Time) *LiveVersion {
+	live := &LiveVersion{VersionID: v.ContainerVersionId, Name: v.Name}
+	// The version fingerprint is its storage timestamp in milliseconds.
+	if ms, err := strconv.ParseInt(v.Fingerprint, 10, 64); err == nil {
+		live.LastModified = time.UnixMilli(ms).UTC()
+		live.DaysSinceModified = int(now.Sub(live.LastModified).Hours() / 24)
+	}
+	return live
+}
+
+// GetAccountSummary builds an inventory of all containers in an account.
+// Per-container lookup failures are reported on the container instead of
+// failing the whole summary.
+func (c *Client) GetAccountSummary(ctx context.Context, accountID string) (*AccountSummary, error) {
+	containers, err := c.ListContainers(ctx, accountID)
+	if err != nil {
+		return nil, err
+	}
+
+	summary := &AccountSummary{
+		AccountID:      accountID,
+		ContainerCount: len(containers),
+		Containers:     make([]ContainerSummary, 0, len(containers)),
+	}
+
+	for _, ct := range containers {
+		cs := ContainerSummary{
+			ContainerID:  ct.ContainerID,
+			Name:         ct.Name,
+			PublicID:     ct.PublicID,
+			UsageContext: ct.UsageContext,
+		}
+
+		live, err := c.GetLiveVersion(ctx, accountID, ct.ContainerID)
+		if err != nil {
+			cs.Error = err.Error()
+		}
+		cs.LiveVersion = live
+
+		workspaces, err := c.ListWorkspaces(ctx, accountID, ct.ContainerID)
+		if err != nil {
+			cs.Error = err.Error()
+		}
+		cs.WorkspaceCount = len(workspaces)
+
+		summary.Containers = append(summary.Containers, cs)
+	}
+
+	return summary, nil
+}
@@ -0,0 +1,88 @@
+package gtm
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	tagmanager "google.golang.org/api/tagmanager/v2"
+)
+
+func TestNewLiveVersion(t *testing.T) {
+	now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+	modified := now.Add(-72*time.Hour - time.Minute)
+
+	live := newLiveVersion(&tagmanager.ContainerVersion{
+		ContainerVersionId: "12",
+		Name:               "Launch",
+		Fingerprint:        fmt.Sprint(modified.UnixMilli()),
+	}, now)
+	if live.VersionID != "12" || live.Name != "Launch" {
+		t.Errorf("live = %+v", live)
+	}
+	if !live.LastModified.Equal(modified.Truncate(time.Millisecond)) || live.DaysSinceModified != 3 {
+		t.Errorf("LastModified = %v, DaysSinceModified = %d", live.LastModified, live.DaysSinceModified)
+	}
+
+	// A fingerprint that is not a timestamp leaves the time unset
+	live = newLiveVersion(&tagmanager.ContainerVersion{ContainerVersionId: "12", Fingerprint: "abc"}, now)
+	if !live.LastModified.IsZero() || live.DaysSinceModified != 0 {
+		t.Errorf("live = %+v", live)
+	}
+}
+
+func TestGetAccountSummary(t *testing.T) {
+	modified := time.Now().Add(-50 * time.Hour)
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch path := r.URL.Path; {
+		case strings.HasSuffix(path, "/accounts/1/containers"):
+			io.WriteString(w, `{"container":[
+				{"containerId":"2","name":"Web","publicId":"GTM-A","usageContext":["web"]},
+				{"containerId":"3","name":"New","publicId":"GTM-B","usageContext":["server"]}]}`)
+		case strings.HasSuffix(path, "/containers/2/versions:live"):
+			fmt.Fprintf(w, `{"containerVersionId":"7","name":"v7","fingerprint":"%d"}`, modified.UnixMilli())
+		case strings.HasSuffix(path, "/containers/2/workspaces"):
+			io.WriteString(w, `{"workspace":[{"workspaceId":"1"},{"workspaceId":"4"}]}`)
+		case strings.HasSuffix(path, "/containers/3/versions:live"):
+			// Nothing published yet
+			w.WriteHeader(http.StatusNotFound)
+			io.WriteString(w, `{"error":{"code":404,"message":"Not found"}}`)
+		case strings.HasSuffix(path, "/containers/3/workspaces"):
+			w.WriteHeader(http.StatusForbidden)
+			io.WriteString(w, `{"error":{"code":403,"message":"Denied","errors":[{"reason":"forbidden"}]}}`)
+		default:
+			t.Errorf("unexpected request %s", path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+
+	summary, err := client.GetAccountSummary(context.Background(), "1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if summary.AccountID != "1" || summary.ContainerCount != 2 || len(summary.Containers) != 2 {
+		t.Fatalf("summary = %+v", summary)
+	}
+
+	web := summary.Containers[0]
+	if web.ContainerID != "2" || web.PublicID != "GTM-A" || web.WorkspaceCount != 2 || web.Error != "" {
+		t.Errorf("web = %+v", web)
+	}
+	if web.LiveVersion == nil || web.LiveVersion.VersionID != "7" || web.LiveVersion.DaysSinceModified != 2 {
+		t.Errorf("web live version = %+v", web.LiveVersion)
+	}
+
+	// A container without a live version is not an error, but a failed
+	// lookup is reported on the container instead of failing the summary
+	unpublished := summary.Containers[1]
+	if unpublished.LiveVersion != nil {
+		t.Errorf("unpublished live version = %+v", unpublished.LiveVersion)
+	}
+	if unpublished.Error == "" || unpublished.WorkspaceCount != 0 {
+		t.Errorf("unpublished = %+v", unpublished)
+	}
+}
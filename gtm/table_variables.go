@@ -0,0 +1,78 @@
+package gtm
+
+// Table variable types.
+const (
+	VariableTypeLookupTable = "smm"  // Lookup Table
+	VariableTypeRegexTable  = "remm" // RegEx Table
+)
+
+// TableRow is one row of a lookup or regex table variable.
+type TableRow struct {
+	Key   string `json:"key" jsonschema:"description:Input value to match: an exact value for lookup tables, a regular expression for regex tables"`
+	Value string `json:"value" jsonschema:"description:Output value when the row matches; may reference variables such as {{Page Path}} and, in regex tables, capture groups such as $1"`
+}
+
+// RegexTableOptions are the advanced settings of a regex table variable.
+// The zero value matches the Tag Manager defaults: full, case-insensitive
+// matches with capture groups enabled.
+type RegexTableOptions struct {
+	PartialMatch    bool `json:"partialMatch,omitempty" jsonschema:"description:Match patterns anywhere in the input instead of the whole input (optional)"`
+	CaseSensitive   bool `json:"caseSensitive,omitempty" jsonschema:"description:Match patterns case-sensitively (optional)"`
+	NoCaptureGroups bool `json:"noCaptureGroups,omitempty" jsonschema:"description:Disable capture groups and replace functionality, so $1 in values stays literal (optional)"`
+}
+
+// LookupTableParameters returns the parameters of a lookup table variable
+// that maps the value of the input variable through rows, falling back to
+// defaultValue when set.
+func LookupTableParameters(input string, rows []TableRow, defaultValue *string) ([]Parameter, error) {
+	return tableParameters(input, rows, defaultValue)
+}
+
+// RegexTableParameters returns the parameters of a regex table variable. The
+// first row whose pattern matches the input variable wins.
+func RegexTableParameters(input string, rows []TableRow, defaultValue *string, opts RegexTableOptions) ([]Parameter, error) {
+	params, err := tableParameters(input, rows, defaultValue)
+	if err != nil {
+		return nil, err
+	}
+	return append(params,
+		BooleanParam("fullMatch", !opts.PartialMatch),
+		BooleanParam("replaceAliases", !opts.NoCaptureGroups),
+		BooleanParam("ignoreCase", !opts.CaseSensitive),
+	), nil
+}
+
+// tableParameters builds the parameters shared by both table types: the
+// input variable, the map of rows and the optional default value.
+func tableParameters(input string, rows []TableRow, defaultValue *string) ([]Parameter, error) {
+	input = variableRef(input)
+	if input == "" {
+		return nil, InvalidField("input", "input variable is required")
+	}
+	if len(rows) == 0 {
+		return nil, InvalidField("rows", "at least one row is required")
+	}
+
+	seen := make(map[string]bool, len(rows))
+	entries := make([]Parameter, len(rows))
+	for i, row := range rows {
+		if row.Key == "" {
+			return nil, InvalidField("rows", "rows[%d]: key is required", i)
+		}
+		if seen[row.Key] {
+			return nil, InvalidField("rows", "rows[%d]: duplicate key %q; only the first matching row is used", i, row.Key)
+		}
+		seen[row.Key] = true
+		entries[i] = MapParam("", TemplateParam("key", row.Key), TemplateParam("value", row.Value))
+	}
+
+	params := []Parameter{
+		BooleanParam("setDefaultValue", defaultValue != nil),
+		TemplateParam("input", input),
+		ListParam("map", entries...),
+	}
+	if defaultValue != nil {
+		params = append(params, TemplateParam("defaultValue", *defaultValue))
+	}
+	return params, nil
+}
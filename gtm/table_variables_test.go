@@ -0,0 +1,82 @@
+package gtm
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestLookupTableParameters(t *testing.T) {
+	fallback := "G-DEFAULT"
+	params, err := LookupTableParameters("Page Hostname", []TableRow{
+		{Key: "www.acme.com", Value: "G-PROD"},
+		{Key: "staging.acme.com", Value: "G-STAGING"},
+	}, &fallback)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []Parameter{
+		BooleanParam("setDefaultValue", true),
+		TemplateParam("input", "{{Page Hostname}}"),
+		ListParam("map",
+			MapParam("", TemplateParam("key", "www.acme.com"), TemplateParam("value", "G-PROD")),
+			MapParam("", TemplateParam("key", "staging.acme.com"), TemplateParam("value", "G-STAGING")),
+		),
+		TemplateParam("defaultValue", "G-DEFAULT"),
+	}
+	if !reflect.DeepEqual(params, want) {
+		t.Errorf("params = %+v\nwant %+v", params, want)
+	}
+	if err := ValidateParameters(params); err != nil {
+		t.Errorf("invalid parameters: %v", err)
+	}
+
+	params, err = LookupTableParameters("{{Page Hostname}}", []TableRow{{Key: "a", Value: "b"}}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(params) != 3 || params[0].Value != "false" || params[1].Value != "{{Page Hostname}}" {
+		t.Errorf("params without default = %+v", params)
+	}
+}
+
+func TestRegexTableParameters(t *testing.T) {
+	params, err := RegexTableParameters("{{Page Path}}", []TableRow{{Key: "^/blog/(.*)", Value: "blog-$1"}}, nil, RegexTableOptions{CaseSensitive: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := map[string]string{}
+	for _, p := range params {
+		got[p.Key] = p.Value
+	}
+	for key, want := range map[string]string{"fullMatch": "true", "replaceAliases": "true", "ignoreCase": "false", "setDefaultValue": "false"} {
+		if got[key] != want {
+			t.Errorf("%s = %q, want %q", key, got[key], want)
+		}
+	}
+	if err := ValidateParameters(params); err != nil {
+		t.Errorf("invalid parameters: %v", err)
+	}
+}
+
+func TestTableParameters_Errors(t *testing.T) {
+	for _, tt := range []struct {
+		name  string
+		input string
+		rows  []TableRow
+		field string
+	}{
+		{name: "no input", input: " ", rows: []TableRow{{Key: "a"}}, field: "input"},
+		{name: "no rows", input: "Page Path", field: "rows"},
+		{name: "empty key", input: "Page Path", rows: []TableRow{{Value: "x"}}, field: "rows"},
+		{name: "duplicate key", input: "Page Path", rows: []TableRow{{Key: "a"}, {Key: "a"}}, field: "rows"},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := LookupTableParameters(tt.input, tt.rows, nil)
+			var fe *FieldError
+			if !errors.As(err, &fe) || fe.Field != tt.field {
+				t.Errorf("err = %v, want error on %s", err, tt.field)
+			}
+		})
+	}
+}
@@ -0,0 +1,174 @@
+package gtm
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	tagmanager "google.golang.org/api/tagmanager/v2"
+)
+
+// TagViolation is a problem found in a proposed tag payload.
+type TagViolation struct {
+	Severity string `json:"severity"` // "error" or "warning"
+	Field    string `json:"field"`
+	Message  string `json:"message"`
+}
+
+// TagPayload is a proposed tag, as it would be passed to create_tag.
+type TagPayload struct {
+	Name               string
+	Type               string
+	FiringTriggerIDs   []string
+	BlockingTriggerIDs []string
+	Parameter          []Parameter
+}
+
+// builtInTagTypes are the GTM tag types that do not need a workspace template.
+var builtInTagTypes = map[string]bool{
+	"gaawc":           true, // GA4 Configuration (legacy)
+	"gaawe":           true, // GA4 Event
+	"googtag":         true, // Google tag
+	"html":            true, // Custom HTML
+	"img":             true, // Custom Image
+	"awct":            true, // Google Ads Conversion Tracking
+	"awcc":            true, // Google Ads Calls from Website Conversion
+	"awud":            true, // Google Ads User-provided Data Event
+	"sp":              true, // Google Ads Remarketing
+	"gclidw":          true, // Conversion Linker
+	"flc":             true, // Floodlight Counter
+	"fls":             true, // Floodlight Sales
+	"baut":            true, // Microsoft Advertising UET
+	"bzi":             true, // LinkedIn Insight
+	"pntr":            true, // Pinterest
+	"hjtc":            true, // Hotjar Tracking Code
+	"crto":            true, // Criteo OneTag
+	"sgtmgaaw":        true, // server-side GA4
+	"sgtmadsct":       true, // server-side Google Ads Conversion Tracking
+	"sgtmadsremarket": true, // server-side Google Ads Remarketing
+	"sgtmfls":         true, // server-side Floodlight
+	"sgtmhttp":        true, // server-side HTTP request
+}
+
+// requiredTagParams lists the parameters each known tag type needs. Each
+// entry is a set of alternative keys, at least one of which must have a value.
+var requiredTagParams = map[string][][]string{
+	"gaawe":   {{"eventName"}, {"measurementIdOverride", "measurementId"}},
+	"gaawc":   {{"measurementId"}},
+	"googtag": {{"tagId"}},
+	"html":    {{"html"}},
+	"img":     {{"url"}},
+}
+
+// ValidateTagPayload checks a proposed tag against the workspace without
+// creating it: the type must be built in or a template in the workspace, the
+// parameters required by known types must be set and every referenced
+// trigger must exist.
+func (c *Client) ValidateTagPayload(ctx context.Context, accountID, containerID, workspaceID string, payload TagPayload) ([]TagViolation, error) {
+	triggers, err := c.ListTriggers(ctx, accountID, containerID, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+	triggerIDs := make(map[string]bool, len(triggers))
+	for _, t := range triggers {
+		triggerIDs[t.TriggerID] = true
+	}
+
+	var templateTypes map[string]bool
+	if strings.HasPrefix(payload.Type, "cvt_") {
+		parent := BuildWorkspacePath(accountID, containerID, workspaceID)
+		resp, err := RetryWithBackoff(ctx, func() (*tagmanager.ListTemplatesResponse, error) {
+			return c.Service.Accounts.Containers.Workspaces.Templates.List(parent).Context(ctx).Do()
+		})
+		if err != nil {
+			return nil, MapGoogleError(err)
+		}
+		templateTypes = workspaceTemplateTypes(containerID, resp.Template)
+	}
+
+	return checkTagPayload(payload, templateTypes, triggerIDs), nil
+}
+
+// workspaceTemplateTypes returns the tag types provided by workspace
+// templates: cvt_{containerId}_{templateId}, and cvt_{galleryTemplateId} for
+// templates imported from the Community Template Gallery.
+func workspaceTemplateTypes(containerID string, templates []*tagmanager.CustomTemplate) map[string]bool {
+	types := make(map[string]bool, len(templates))
+	for _, t := range templates {
+		types[fmt.Sprintf("cvt_%s_%s", containerID, t.TemplateId)] = true
+		if t.GalleryReference != nil && t.GalleryReference.GalleryTemplateId != "" {
+			types["cvt_"+t.GalleryReference.GalleryTemplateId] = true
+		}
+	}
+	return types
+}
+
+func checkTagPayload(payload TagPayload, templateTypes, triggerIDs map[string]bool) []TagViolation {
+	violations := []TagViolation{}
+	add := func(severity, field, format string, args ...any) {
+		violations = append(violations, TagViolation{Severity: severity, Field: field, Message: fmt.Sprintf(format, args...)})
+	}
+
+	if strings.TrimSpace(payload.Name) == "" {
+		add(SeverityError, "name", "tag name is required")
+	} else if len(payload.Name) > 256 {
+		add(SeverityError, "name", "tag name must be 256 characters or less")
+	}
+
+	switch {
+	case strings.TrimSpace(payload.Type) == "":
+		add(SeverityError, "type", "tag type is required")
+	case strings.HasPrefix(payload.Type, "cvt_"):
+		if !templateTypes[payload.Type] {
+			known := make([]string, 0, len(templateTypes))
+			for t := range templateTypes {
+				known = append(known, t)
+			}
+			sort.Strings(known)
+			add(SeverityError, "type", "no template in this workspace provides type %s (available: %s); import it with import_gallery_template or create_template first", payload.Type, joinOrNone(known))
+		}
+	case !builtInTagTypes[payload.Type]:
+		add(SeverityWarning, "type", "%s is not a known built-in tag type; check the type against an existing tag of the same kind", payload.Type)
+	}
+
+	values := make(map[string]string, len(payload.Parameter))
+	for _, p := range payload.Parameter {
+		values[p.Key] = p.Value
+	}
+	for _, alternatives := range requiredTagParams[payload.Type] {
+		set := false
+		for _, key := range alternatives {
+			if strings.TrimSpace(values[key]) != "" {
+				set = true
+			}
+		}
+		if !set {
+			add(SeverityError, "parameters", "%s tags require parameter %s", payload.Type, strings.Join(alternatives, " or "))
+		}
+	}
+
+	if len(payload.FiringTriggerIDs) == 0 {
+		add(SeverityWarning, "firingTriggerIds", "tag has no firing triggers and will only fire via tag sequencing")
+	}
+	checkRefs := func(field string, ids []string) {
+		for _, id := range ids {
+			if strings.TrimSpace(id) == "" {
+				add(SeverityError, field, "trigger ID cannot be empty")
+			} else if !triggerIDs[id] && !isBuiltInTriggerID(id) {
+				add(SeverityError, field, "trigger %s does not exist in this workspace", id)
+			}
+		}
+	}
+	checkRefs("firingTriggerIds", payload.FiringTriggerIDs)
+	checkRefs("blockingTriggerIds", payload.BlockingTriggerIDs)
+
+	return violations
+}
+
+func joinOrNone(items []string) string {
+	if len(items) == 0 {
+		return "none"
+	}
+	return strings.Join(items, ", ")
+}
@@ -0,0 +1,80 @@
+package gtm
+
+import (
+	"strings"
+	"testing"
+
+	tagmanager "google.golang.org/api/tagmanager/v2"
+)
+
+func TestCheckTagPayload(t *testing.T) {
+	triggers := map[string]bool{"10": true}
+	templates := map[string]bool{"cvt_123_5": true}
+
+	tests := []struct {
+		name    string
+		payload TagPayload
+		want    []string // "severity field" of each violation, in order
+	}{
+		{
+			name: "valid GA4 event",
+			payload: TagPayload{Name: "GA4 - Purchase", Type: "gaawe", FiringTriggerIDs: []string{"10"}, Parameter: []Parameter{
+				{Type: "template", Key: "eventName", Value: "purchase"},
+				{Type: "tagReference", Key: "measurementId", Value: ""},
+				{Type: "template", Key: "measurementIdOverride", Value: "{{GA4 ID}}"},
+			}},
+		},
+		{
+			name:    "GA4 event missing parameters",
+			payload: TagPayload{Name: "GA4", Type: "gaawe", FiringTriggerIDs: []string{"10"}},
+			want:    []string{"error parameters", "error parameters"},
+		},
+		{
+			name:    "built-in trigger and missing trigger",
+			payload: TagPayload{Name: "HTML", Type: "html", FiringTriggerIDs: []string{"2147479553", "99"}, BlockingTriggerIDs: []string{""}, Parameter: []Parameter{{Type: "template", Key: "html", Value: "<b>"}}},
+			want:    []string{"error firingTriggerIds", "error blockingTriggerIds"},
+		},
+		{
+			name:    "known template type",
+			payload: TagPayload{Name: "Custom", Type: "cvt_123_5", FiringTriggerIDs: []string{"10"}},
+		},
+		{
+			name:    "missing template type",
+			payload: TagPayload{Name: "Custom", Type: "cvt_123_6", FiringTriggerIDs: []string{"10"}},
+			want:    []string{"error type"},
+		},
+		{
+			name:    "unknown built-in type and no triggers",
+			payload: TagPayload{Name: "X", Type: "mystery"},
+			want:    []string{"warning type", "warning firingTriggerIds"},
+		},
+		{
+			name:    "missing name and type",
+			payload: TagPayload{FiringTriggerIDs: []string{"10"}},
+			want:    []string{"error name", "error type"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			for _, v := range checkTagPayload(tt.payload, templates, triggers) {
+				got = append(got, v.Severity+" "+v.Field)
+			}
+			if strings.Join(got, ",") != strings.Join(tt.want, ",") {
+				t.Errorf("violations = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWorkspaceTemplateTypes(t *testing.T) {
+	types := workspaceTemplateTypes("123", []*tagmanager.CustomTemplate{
+		{TemplateId: "5"},
+		{TemplateId: "6", GalleryReference: &tagmanager.GalleryReference{GalleryTemplateId: "abc"}},
+	})
+	for _, want := range []string{"cvt_123_5", "cvt_123_6", "cvt_abc"} {
+		if !types[want] {
+			t.Errorf("missing type %s in %v", want, types)
+		}
+	}
+}
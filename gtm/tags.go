@@ -15,6 +15,10 @@ type Tag struct {
 	FiringTriggerID  []string `json:"firingTriggerId,omitempty"`
 	BlockingTriggerID []string `json:"blockingTriggerId,omitempty"`
 	Paused           bool     `json:"paused,omitempty"`
+	ConsentSettings  *ConsentSettings `json:"consentSettings,omitempty"`
+	ScheduleStartMs  int64    `json:"scheduleStartMs,omitempty"`
+	ScheduleEndMs    int64    `json:"scheduleEndMs,omitempty"`
+	Priority         *int     `json:"priority,omitempty"`
 	Path             string   `json:"path"`
 }
 
@@ -22,11 +26,12 @@ type Tag struct {
 func (c *Client) ListTags(ctx context.Context, accountID, containerID, workspaceID string) ([]Tag, error) {
 	parent := fmt.Sprintf("accounts/%s/containers/%s/workspaces/%s", accountID, containerID, workspaceID)
 
-	resp, err := retryWithBackoff(ctx, 3, func() (*tagmanager.ListTagsResponse, error) {
-		return c.Service.Accounts.Containers.Workspaces.Tags.List(parent).Context(ctx).Do()
+	resp, err := cachedList(listCaches, c.user, parent, "tags", func() (*tagmanager.ListTagsResponse, error) {
+		all, err := listAllTags(ctx, c.Service.Accounts.Containers.Workspaces, parent)
+		return &tagmanager.ListTagsResponse{Tag: all}, err
 	})
 	if err != nil {
-		return nil, mapGoogleError(err)
+		return nil, MapGoogleError(err)
 	}
 
 	return toTags(resp.Tag), nil
@@ -37,12 +42,13 @@ func (c *Client) GetTag(ctx context.Context, accountID, containerID, workspaceID
 	path := fmt.Sprintf("accounts/%s/containers/%s/workspaces/%s/tags/%s",
 		accountID, containerID, workspaceID, tagID)
 
-	tag, err := retryWithBackoff(ctx, 3, func() (*tagmanager.Tag, error) {
+	tag, err := readAfterMutation(ctx, path, func() (*tagmanager.Tag, error) {
 		return c.Service.Accounts.Containers.Workspaces.Tags.Get(path).Context(ctx).Do()
 	})
 	if err != nil {
-		return nil, mapGoogleError(err)
+		return nil, MapGoogleError(err)
 	}
+	listCaches.observe(tag.Path, tag.Fingerprint)
 
 	result := toTag(tag)
 	return &result, nil
@@ -64,6 +70,10 @@ func toTag(t *tagmanager.Tag) Tag {
 		FiringTriggerID:  t.FiringTriggerId,
 		BlockingTriggerID: t.BlockingTriggerId,
 		Paused:           t.Paused,
+		ConsentSettings:  toConsentSettings(t.ConsentSettings),
+		ScheduleStartMs:  t.ScheduleStartMs,
+		ScheduleEndMs:    t.ScheduleEndMs,
+		Priority:         tagPriority(t.Priority),
 		Path:             t.Path,
 	}
 }
@@ -0,0 +1,66 @@
+package gtm
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// templateSectionPattern matches the section headers of custom template
+// data (template.tpl), e.g. ___INFO___ or ___WEB_PERMISSIONS___.
+var templateSectionPattern = regexp.MustCompile(`(?m)^___([A-Z_]+)___\s*$`)
+
+// templateSections splits custom template data into its sections keyed by
+// name, with surrounding whitespace trimmed.
+func templateSections(data string) map[string]string {
+	sections := make(map[string]string)
+	matches := templateSectionPattern.FindAllStringSubmatchIndex(data, -1)
+	for i, m := range matches {
+		end := len(data)
+		if i+1 < len(matches) {
+			end = matches[i+1][0]
+		}
+		sections[data[m[2]:m[3]]] = strings.TrimSpace(data[m[1]:end])
+	}
+	return sections
+}
+
+// TemplateInfoSection is the ___INFO___ section of custom template data.
+type TemplateInfoSection struct {
+	Type              string   `json:"type"` // TAG, MACRO or CLIENT
+	ID                string   `json:"id"`
+	Version           int      `json:"version"`
+	DisplayName       string   `json:"displayName"`
+	Description       string   `json:"description"`
+	Categories        []string `json:"categories"`
+	ContainerContexts []string `json:"containerContexts"` // WEB or SERVER
+	Brand             struct {
+		ID          string `json:"id"`
+		DisplayName string `json:"displayName"`
+	} `json:"brand"`
+}
+
+// parseTemplateInfo reads the ___INFO___ section of custom template data.
+func parseTemplateInfo(data string) (*TemplateInfoSection, error) {
+	section, ok := templateSections(data)["INFO"]
+	if !ok {
+		return nil, fmt.Errorf("template data has no ___INFO___ section")
+	}
+	var info TemplateInfoSection
+	if err := json.Unmarshal([]byte(section), &info); err != nil {
+		return nil, fmt.Errorf("invalid ___INFO___ section: %w", err)
+	}
+	return &info, nil
+}
+
+// templateKind returns the entity type a template creates: tag, variable
+// (MACRO) or client.
+func templateKind(infoType string) string {
+	switch infoType {
+	case "MACRO":
+		return "variable"
+	default:
+		return strings.ToLower(infoType)
+	}
+}
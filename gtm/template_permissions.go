@@ -0,0 +1,301 @@
+package gtm
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+)
+
+// Permission risk levels, from most to least sensitive.
+const (
+	RiskHigh   = "high"
+	RiskMedium = "medium"
+	RiskLow    = "low"
+)
+
+var riskRank = map[string]int{RiskHigh: 0, RiskMedium: 1, RiskLow: 2}
+
+// permissionRisks is the risk of each sandboxed JavaScript permission when
+// it is restricted to specific URLs, keys or cookies. Unrestricted access
+// is always high risk; unknown permissions are medium.
+var permissionRisks = map[string]string{
+	"inject_script":           RiskHigh,
+	"inject_hidden_iframe":    RiskHigh,
+	"access_globals":          RiskHigh,
+	"send_http":               RiskHigh,
+	"use_custom_private_keys": RiskHigh,
+	"access_bigquery":         RiskHigh,
+	"access_firestore":        RiskHigh,
+	"send_pixel":              RiskMedium,
+	"get_cookies":             RiskMedium,
+	"set_cookies":             RiskMedium,
+	"access_local_storage":    RiskMedium,
+	"access_template_storage": RiskMedium,
+	"read_data_layer":         RiskMedium,
+	"get_url":                 RiskMedium,
+	"get_referrer":            RiskMedium,
+	"read_request":            RiskMedium,
+	"read_event_data":         RiskMedium,
+	"set_response_header":     RiskMedium,
+	"logging":                 RiskLow,
+	"read_title":              RiskLow,
+	"read_character_set":      RiskLow,
+	"read_container_data":     RiskLow,
+	"read_event_metadata":     RiskLow,
+	"get_user_agent":          RiskLow,
+	"access_consent":          RiskLow,
+}
+
+// TemplatePermission is one permission a custom template requests.
+type TemplatePermission struct {
+	ID       string         `json:"id"`
+	Summary  string         `json:"summary"`
+	Risk     string         `json:"risk"`
+	Settings map[string]any `json:"settings,omitempty"`
+}
+
+// TemplatePermissionReport describes what a custom template may do once
+// it runs on a site or server container.
+type TemplatePermissionReport struct {
+	DisplayName       string               `json:"displayName"`
+	Kind              string               `json:"kind"` // tag, variable or client
+	ContainerContexts []string             `json:"containerContexts"`
+	Risk              string               `json:"risk"` // highest permission risk
+	InjectsScripts    bool                 `json:"injectsScripts"`
+	Domains           []string             `json:"domains"` // hosts scripts, iframes, pixels or requests may reach
+	ReadsCookies      []string             `json:"readsCookies,omitempty"`
+	SetsCookies       []string             `json:"setsCookies,omitempty"`
+	Globals           []string             `json:"globals,omitempty"`
+	Permissions       []TemplatePermission `json:"permissions"`
+}
+
+// permissionEntry is an entry of the ___WEB_PERMISSIONS___ or
+// ___SERVER_PERMISSIONS___ section.
+type permissionEntry struct {
+	Instance struct {
+		Key struct {
+			PublicID string `json:"publicId"`
+		} `json:"key"`
+		Param []struct {
+			Key   string          `json:"key"`
+			Value permissionValue `json:"value"`
+		} `json:"param"`
+	} `json:"instance"`
+}
+
+// permissionValue is a typed permission setting: 1 string, 2 list, 3 map
+// and 8 boolean.
+type permissionValue struct {
+	Type     int               `json:"type"`
+	String   string            `json:"string"`
+	Boolean  bool              `json:"boolean"`
+	ListItem []permissionValue `json:"listItem"`
+	MapKey   []permissionValue `json:"mapKey"`
+	MapValue []permissionValue `json:"mapValue"`
+}
+
+func (v permissionValue) decode() any {
+	switch v.Type {
+	case 2:
+		items := make([]any, 0, len(v.ListItem))
+		for _, item := range v.ListItem {
+			items = append(items, item.decode())
+		}
+		return items
+	case 3:
+		m := make(map[string]any, len(v.MapKey))
+		for i, k := range v.MapKey {
+			if i < len(v.MapValue) {
+				m[k.String] = v.MapValue[i].decode()
+			}
+		}
+		return m
+	case 8:
+		return v.Boolean
+	default:
+		return v.String
+	}
+}
+
+// AnalyzeTemplatePermissions reads the permission sections of custom
+// template data and summarizes what the template may access.
+func AnalyzeTemplatePermissions(data string) (*TemplatePermissionReport, error) {
+	info, err := parseTemplateInfo(data)
+	if err != nil {
+		return nil, InvalidField("templateData", "%v", err)
+	}
+	report := &TemplatePermissionReport{
+		DisplayName:       info.DisplayName,
+		Kind:              templateKind(info.Type),
+		ContainerContexts: []string{},
+		Risk:              RiskLow,
+		Domains:           []string{},
+		Permissions:       []TemplatePermission{},
+	}
+	for _, c := range info.ContainerContexts {
+		report.ContainerContexts = append(report.ContainerContexts, strings.ToLower(c))
+	}
+
+	sections := templateSections(data)
+	for _, name := range []string{"WEB_PERMISSIONS", "SERVER_PERMISSIONS", "TEMPLATE_PERMISSIONS"} {
+		section, ok := sections[name]
+		if !ok || section == "" {
+			continue
+		}
+		var entries []permissionEntry
+		if err := json.Unmarshal([]byte(section), &entries); err != nil {
+			return nil, InvalidField("templateData", "invalid ___%s___ section: %v", name, err)
+		}
+		for _, e := range entries {
+			settings := make(map[string]any, len(e.Instance.Param))
+			for _, p := range e.Instance.Param {
+				settings[p.Key] = p.Value.decode()
+			}
+			report.add(e.Instance.Key.PublicID, settings)
+		}
+	}
+
+	sort.SliceStable(report.Permissions, func(i, j int) bool {
+		a, b := report.Permissions[i], report.Permissions[j]
+		if riskRank[a.Risk] != riskRank[b.Risk] {
+			return riskRank[a.Risk] < riskRank[b.Risk]
+		}
+		return a.ID < b.ID
+	})
+	sort.Strings(report.Domains)
+	report.Domains = slices.Compact(report.Domains)
+	return report, nil
+}
+
+// add records a permission with its summary and risk.
+func (r *TemplatePermissionReport) add(id string, settings map[string]any) {
+	risk, ok := permissionRisks[id]
+	if !ok {
+		risk = RiskMedium
+	}
+	unrestricted := false
+	urls := settingStrings(settings["urls"])
+	for _, u := range urls {
+		host := urlPatternHost(u)
+		r.Domains = append(r.Domains, host)
+		if host == "*" || host == "" {
+			unrestricted = true
+		}
+	}
+
+	var summary string
+	switch id {
+	case "inject_script":
+		r.InjectsScripts = true
+		summary = "Injects scripts from " + joinOrNone(urls)
+	case "inject_hidden_iframe":
+		summary = "Injects hidden iframes from " + joinOrNone(urls)
+	case "send_pixel", "send_http":
+		verb := map[string]string{"send_pixel": "Sends pixels", "send_http": "Sends HTTP requests"}[id]
+		if settings["allowedUrls"] == "any" {
+			unrestricted = true
+			summary = verb + " to any URL"
+		} else {
+			summary = verb + " to " + joinOrNone(urls)
+		}
+	case "get_cookies":
+		if settings["cookieAccess"] == "any" {
+			unrestricted = true
+			r.ReadsCookies = append(r.ReadsCookies, "*")
+			summary = "Reads all cookies"
+		} else {
+			names := settingStrings(settings["cookieNames"])
+			r.ReadsCookies = append(r.ReadsCookies, names...)
+			summary = "Reads cookies " + joinOrNone(names)
+		}
+	case "set_cookies":
+		var names []string
+		for _, c := range settingMaps(settings["allowedCookies"]) {
+			names = append(names, fmt.Sprint(c["name"]))
+		}
+		r.SetsCookies = append(r.SetsCookies, names...)
+		summary = "Sets cookies " + joinOrNone(names)
+	case "access_globals", "access_local_storage":
+		var keys []string
+		for _, k := range settingMaps(settings["keys"]) {
+			var modes []string
+			for _, mode := range []string{"read", "write", "execute"} {
+				if k[mode] == true {
+					modes = append(modes, mode)
+				}
+			}
+			keys = append(keys, fmt.Sprintf("%v (%s)", k["key"], strings.Join(modes, ", ")))
+			if id == "access_globals" {
+				r.Globals = append(r.Globals, fmt.Sprint(k["key"]))
+			}
+		}
+		what := map[string]string{"access_globals": "window globals", "access_local_storage": "localStorage keys"}[id]
+		summary = "Accesses " + what + " " + joinOrNone(keys)
+	case "read_data_layer":
+		if settings["allowedKeys"] == "any" {
+			unrestricted = true
+			summary = "Reads any data layer key"
+		} else {
+			summary = "Reads data layer keys " + joinOrNone(settingStrings(settings["keys"]))
+		}
+	case "get_url", "get_referrer":
+		what := map[string]string{"get_url": "the page URL", "get_referrer": "the referrer"}[id]
+		if settings["urlParts"] == "any" {
+			summary = "Reads all of " + what
+		} else {
+			summary = "Reads parts of " + what
+		}
+	case "logging":
+		summary = fmt.Sprintf("Logs to the console (%v)", settings["environments"])
+	default:
+		summary = "Uses " + strings.ReplaceAll(id, "_", " ")
+	}
+	if unrestricted {
+		risk = RiskHigh
+	}
+	if riskRank[risk] < riskRank[r.Risk] {
+		r.Risk = risk
+	}
+	if len(settings) == 0 {
+		settings = nil
+	}
+	r.Permissions = append(r.Permissions, TemplatePermission{ID: id, Summary: summary, Risk: risk, Settings: settings})
+}
+
+// urlPatternHost returns the host of a permission URL pattern such as
+// https://*.example.com/*, or "*" when any host matches.
+func urlPatternHost(pattern string) string {
+	host := pattern
+	if i := strings.Index(host, "://"); i >= 0 {
+		host = host[i+3:]
+	}
+	if i := strings.IndexAny(host, "/?#"); i >= 0 {
+		host = host[:i]
+	}
+	if strings.Trim(host, "*.") == "" {
+		return "*"
+	}
+	return host
+}
+
+func settingStrings(v any) []string {
+	items, _ := v.([]any)
+	out := make([]string, 0, len(items))
+	for _, item := range items {
+		out = append(out, fmt.Sprint(item))
+	}
+	return out
+}
+
+func settingMaps(v any) []map[string]any {
+	items, _ := v.([]any)
+	out := make([]map[string]any, 0, len(items))
+	for _, item := range items {
+		if m, ok := item.(map[string]any); ok {
+			out = append(out, m)
+		}
+	}
+	return out
+}
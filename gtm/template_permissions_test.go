@@ -0,0 +1,109 @@
+package gtm
+
+import (
+	"errors"
+	"slices"
+	"testing"
+)
+
+const testPermissionsTemplate = `___INFO___
+
+{"type": "TAG", "displayName": "Acme Pixel", "containerContexts": ["WEB"]}
+
+___WEB_PERMISSIONS___
+
+[
+  {
+    "instance": {
+      "key": {"publicId": "inject_script", "versionId": "1"},
+      "param": [{"key": "urls", "value": {"type": 2, "listItem": [{"type": 1, "string": "https://cdn.acme.com/pixel.js"}]}}]
+    },
+    "isRequired": true
+  },
+  {
+    "instance": {
+      "key": {"publicId": "send_pixel", "versionId": "1"},
+      "param": [
+        {"key": "allowedUrls", "value": {"type": 1, "string": "specific"}},
+        {"key": "urls", "value": {"type": 2, "listItem": [{"type": 1, "string": "https://*.acme.com/collect"}, {"type": 1, "string": "https://cdn.acme.com/p"}]}}
+      ]
+    }
+  },
+  {
+    "instance": {
+      "key": {"publicId": "get_cookies", "versionId": "1"},
+      "param": [{"key": "cookieAccess", "value": {"type": 1, "string": "any"}}]
+    }
+  },
+  {
+    "instance": {
+      "key": {"publicId": "access_globals", "versionId": "1"},
+      "param": [{"key": "keys", "value": {"type": 2, "listItem": [{"type": 3,
+        "mapKey": [{"type": 1, "string": "key"}, {"type": 1, "string": "read"}, {"type": 1, "string": "write"}, {"type": 1, "string": "execute"}],
+        "mapValue": [{"type": 1, "string": "acmeq"}, {"type": 8, "boolean": true}, {"type": 8, "boolean": true}, {"type": 8, "boolean": false}]}]}}]
+    }
+  },
+  {
+    "instance": {
+      "key": {"publicId": "logging", "versionId": "1"},
+      "param": [{"key": "environments", "value": {"type": 1, "string": "debug"}}]
+    }
+  }
+]
+
+___TESTS___
+
+scenarios: []
+`
+
+func TestAnalyzeTemplatePermissions(t *testing.T) {
+	report, err := AnalyzeTemplatePermissions(testPermissionsTemplate)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if report.DisplayName != "Acme Pixel" || report.Kind != "tag" || report.Risk != RiskHigh || !report.InjectsScripts {
+		t.Errorf("report = %+v", report)
+	}
+	if !slices.Equal(report.Domains, []string{"*.acme.com", "cdn.acme.com"}) {
+		t.Errorf("domains = %v", report.Domains)
+	}
+	if !slices.Equal(report.ReadsCookies, []string{"*"}) || !slices.Equal(report.Globals, []string{"acmeq"}) {
+		t.Errorf("cookies = %v, globals = %v", report.ReadsCookies, report.Globals)
+	}
+
+	got := map[string]TemplatePermission{}
+	for _, p := range report.Permissions {
+		got[p.ID] = p
+	}
+	for id, want := range map[string]TemplatePermission{
+		"inject_script":  {Risk: RiskHigh, Summary: "Injects scripts from https://cdn.acme.com/pixel.js"},
+		"send_pixel":     {Risk: RiskMedium, Summary: "Sends pixels to https://*.acme.com/collect, https://cdn.acme.com/p"},
+		"get_cookies":    {Risk: RiskHigh, Summary: "Reads all cookies"},
+		"access_globals": {Risk: RiskHigh, Summary: "Accesses window globals acmeq (read, write)"},
+		"logging":        {Risk: RiskLow, Summary: "Logs to the console (debug)"},
+	} {
+		if got[id].Risk != want.Risk || got[id].Summary != want.Summary {
+			t.Errorf("%s = %+v, want %+v", id, got[id], want)
+		}
+	}
+	if last := report.Permissions[len(report.Permissions)-1]; last.ID != "logging" {
+		t.Errorf("permissions not ordered by risk: %+v", report.Permissions)
+	}
+
+	if _, err := AnalyzeTemplatePermissions("___INFO___\n\n{}\n\n___WEB_PERMISSIONS___\n\n{not json"); !errors.Is(err, ErrInvalidRequest) {
+		t.Errorf("invalid permissions: err = %v, want ErrInvalidRequest", err)
+	}
+}
+
+func TestURLPatternHost(t *testing.T) {
+	for pattern, want := range map[string]string{
+		"https://cdn.acme.com/pixel.js": "cdn.acme.com",
+		"https://*.acme.com/":           "*.acme.com",
+		"https://*/*":                   "*",
+		"*":                             "*",
+	} {
+		if got := urlPatternHost(pattern); got != want {
+			t.Errorf("urlPatternHost(%q) = %q, want %q", pattern, got, want)
+		}
+	}
+}
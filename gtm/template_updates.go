@@ -0,0 +1,128 @@
+package gtm
+
+import (
+	"context"
+	"slices"
+
+	tagmanager "google.golang.org/api/tagmanager/v2"
+)
+
+// TemplateUpdate is the update status of a gallery template in a workspace.
+type TemplateUpdate struct {
+	TemplateID    string   `json:"templateId"`
+	Name          string   `json:"name"`
+	Owner         string   `json:"galleryOwner"`
+	Repository    string   `json:"galleryRepository"`
+	Version       string   `json:"version"`
+	LatestVersion string   `json:"latestVersion,omitempty"`
+	Outdated      bool     `json:"outdated"`
+	Modified      bool     `json:"modified,omitempty"` // edited in the workspace since import
+	ChangeNotes   []string `json:"changeNotes,omitempty"`
+	// NewPermissions are permissions the latest version requests that the
+	// workspace copy does not; review them with analyze_template_permissions.
+	NewPermissions []string `json:"newPermissions,omitempty"`
+	Updated        bool     `json:"updated,omitempty"`
+	Error          string   `json:"error,omitempty"`
+}
+
+// CheckTemplateUpdates compares the gallery templates of a workspace with
+// their latest gallery versions, limited to templateIDs when given. With
+// update set, outdated templates are imported again at the latest version;
+// templates modified in the workspace are left alone, as updating would
+// discard the changes.
+func (c *Client) CheckTemplateUpdates(ctx context.Context, accountID, containerID, workspaceID string, gallery *GalleryIndex, templateIDs []string, update bool) ([]TemplateUpdate, error) {
+	parent := BuildWorkspacePath(accountID, containerID, workspaceID)
+	resp, err := RetryWithBackoff(ctx, func() (*tagmanager.ListTemplatesResponse, error) {
+		return c.Service.Accounts.Containers.Workspaces.Templates.List(parent).Context(ctx).Do()
+	})
+	if err != nil {
+		return nil, MapGoogleError(err)
+	}
+
+	updates := []TemplateUpdate{}
+	for _, t := range resp.Template {
+		ref := t.GalleryReference
+		if ref == nil || ref.Owner == "" || ref.Repository == "" {
+			continue
+		}
+		if len(templateIDs) > 0 && !slices.Contains(templateIDs, t.TemplateId) {
+			continue
+		}
+		u := TemplateUpdate{
+			TemplateID: t.TemplateId,
+			Name:       t.Name,
+			Owner:      ref.Owner,
+			Repository: ref.Repository,
+			Version:    ref.Version,
+			Modified:   ref.IsModified,
+		}
+		versions, err := gallery.Versions(ctx, ref.Owner, ref.Repository)
+		if err != nil {
+			u.Error = err.Error()
+			updates = append(updates, u)
+			continue
+		}
+		u.LatestVersion = versions[0].SHA
+		u.Outdated = u.Version != u.LatestVersion
+		for _, v := range versions {
+			if v.SHA == u.Version {
+				break
+			}
+			if v.ChangeNotes != "" {
+				u.ChangeNotes = append(u.ChangeNotes, v.ChangeNotes)
+			}
+		}
+		if u.Outdated {
+			u.NewPermissions = newTemplatePermissions(ctx, gallery, t, u.LatestVersion)
+		}
+
+		if update && u.Outdated {
+			if u.Modified {
+				u.Error = "modified in the workspace; update it in Tag Manager to review the differences"
+			} else if err := c.importGalleryVersion(ctx, parent, ref.Owner, ref.Repository, u.LatestVersion); err != nil {
+				u.Error = err.Error()
+			} else {
+				u.Updated = true
+			}
+		}
+		updates = append(updates, u)
+	}
+	return updates, nil
+}
+
+// importGalleryVersion imports a gallery template at a version, replacing
+// the workspace's copy of it.
+func (c *Client) importGalleryVersion(ctx context.Context, parent, owner, repository, sha string) error {
+	_, err := c.Service.Accounts.Containers.Workspaces.Templates.ImportFromGallery(parent).
+		GalleryOwner(owner).
+		GalleryRepository(repository).
+		GallerySha(sha).
+		AcknowledgePermissions(true).
+		Context(ctx).Do()
+	return MapGoogleError(err)
+}
+
+// newTemplatePermissions returns the permission IDs the gallery version
+// requests beyond those of the workspace template. Failures return nil,
+// since the check is advisory.
+func newTemplatePermissions(ctx context.Context, gallery *GalleryIndex, t *tagmanager.CustomTemplate, sha string) []string {
+	data, err := gallery.TemplateData(ctx, t.GalleryReference.Owner, t.GalleryReference.Repository, sha)
+	if err != nil {
+		return nil
+	}
+	latest, err := AnalyzeTemplatePermissions(data)
+	if err != nil {
+		return nil
+	}
+	current, err := AnalyzeTemplatePermissions(t.TemplateData)
+	if err != nil {
+		return nil
+	}
+	var added []string
+	for _, p := range latest.Permissions {
+		if !slices.ContainsFunc(current.Permissions, func(q TemplatePermission) bool { return q.ID == p.ID }) {
+			added = append(added, p.ID)
+		}
+	}
+	return added
+}
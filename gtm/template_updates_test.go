@@ -0,0 +1,95 @@
+package gtm
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestCheckTemplateUpdates(t *testing.T) {
+	oldData := "___INFO___\n\n{\"type\": \"TAG\", \"displayName\": \"Acme\"}\n\n___WEB_PERMISSIONS___\n\n[]\n"
+	raw := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/acme/pixel/HEAD/metadata.yaml":
+			io.WriteString(w, "versions:\n  - sha: c3\n    changeNotes: Adds consent.\n  - sha: b2\n    changeNotes: Fixes a bug.\n  - sha: a1\n    changeNotes: Initial release.\n")
+		case "/acme/edited/HEAD/metadata.yaml":
+			io.WriteString(w, "versions:\n  - sha: e2\n  - sha: e1\n")
+		case "/acme/current/HEAD/metadata.yaml":
+			io.WriteString(w, "versions:\n  - sha: f1\n")
+		case "/acme/pixel/c3/template.tpl":
+			io.WriteString(w, testPermissionsTemplate)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(raw.Close)
+	gallery := &GalleryIndex{client: raw.Client(), apiURL: raw.URL, rawURL: raw.URL}
+
+	var imported []string
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, ":import_from_gallery") {
+			imported = append(imported, r.URL.Query().Get("galleryRepository")+"@"+r.URL.Query().Get("gallerySha"))
+			io.WriteString(w, `{"templateId":"1"}`)
+			return
+		}
+		templates := []map[string]any{
+			{"templateId": "1", "name": "Acme Pixel", "templateData": oldData,
+				"galleryReference": map[string]any{"owner": "acme", "repository": "pixel", "version": "a1"}},
+			{"templateId": "2", "name": "Acme Edited",
+				"galleryReference": map[string]any{"owner": "acme", "repository": "edited", "version": "e1", "isModified": true}},
+			{"templateId": "3", "name": "Acme Current",
+				"galleryReference": map[string]any{"owner": "acme", "repository": "current", "version": "f1"}},
+			{"templateId": "4", "name": "Own template"},
+			{"templateId": "5", "name": "Gone",
+				"galleryReference": map[string]any{"owner": "acme", "repository": "gone", "version": "x"}},
+		}
+		json.NewEncoder(w).Encode(map[string]any{"template": templates})
+	})
+	ctx := context.Background()
+
+	updates, err := client.CheckTemplateUpdates(ctx, "1", "2", "3", gallery, nil, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	byID := map[string]TemplateUpdate{}
+	for _, u := range updates {
+		byID[u.TemplateID] = u
+	}
+	if len(updates) != 4 {
+		t.Fatalf("updates = %+v", updates)
+	}
+	pixel := byID["1"]
+	if !pixel.Outdated || pixel.LatestVersion != "c3" || !slices.Equal(pixel.ChangeNotes, []string{"Adds consent.", "Fixes a bug."}) {
+		t.Errorf("pixel = %+v", pixel)
+	}
+	if !slices.Contains(pixel.NewPermissions, "inject_script") {
+		t.Errorf("new permissions = %v", pixel.NewPermissions)
+	}
+	if byID["3"].Outdated || !byID["2"].Outdated || byID["5"].Error == "" {
+		t.Errorf("updates = %+v", updates)
+	}
+	if len(imported) != 0 {
+		t.Errorf("imported without update: %v", imported)
+	}
+
+	updates, err = client.CheckTemplateUpdates(ctx, "1", "2", "3", gallery, []string{"1", "2"}, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(updates) != 2 || !slices.Equal(imported, []string{"pixel@c3"}) {
+		t.Errorf("updates = %+v, imported = %v", updates, imported)
+	}
+	for _, u := range updates {
+		if u.TemplateID == "2" && (u.Updated || u.Error == "") {
+			t.Errorf("modified template updated: %+v", u)
+		}
+		if u.TemplateID == "1" && !u.Updated {
+			t.Errorf("outdated template not updated: %+v", u)
+		}
+	}
+}
@@ -147,12 +147,9 @@ func GetTriggerTemplates() []TriggerTemplate {
 			Description: "Fires on a specific page URL",
 			Type:        "pageview",
 			FilterJSON: `[
-  {"type": "contains", "parameter": [
-    {"type": "template", "key": "arg0", "value": "{{Page URL}}"},
-    {"type": "template", "key": "arg1", "value": "/checkout"}
-  ]}
+  {"variable": "{{Page URL}}", "op": "contains", "value": "/checkout"}
 ]`,
-			Notes: "Use filterJson to match specific pages. arg0 is the variable, arg1 is the value to match.",
+			Notes: "Use filter to match specific pages. Conditions are {variable, op, value, negate}; the raw GTM form {type, parameter: [arg0, arg1]} is accepted too.",
 		},
 		{
 			Name:        "Custom Event",
@@ -164,17 +161,14 @@ func GetTriggerTemplates() []TriggerTemplate {
     {"type": "template", "key": "arg1", "value": "purchase"}
   ]}
 ]`,
-			Notes: "For customEvent triggers, use customEventFilterJson (not filterJson). The {{_event}} variable matches the dataLayer event name.",
+			Notes: "For customEvent triggers, use customEventFilter (not filter). The {{_event}} variable matches the dataLayer event name.",
 		},
 		{
 			Name:        "Click - All Elements",
 			Description: "Fires on all element clicks",
 			Type:        "linkClick",
 			AutoEventFilterJSON: `[
-  {"type": "contains", "parameter": [
-    {"type": "template", "key": "arg0", "value": "{{Click Classes}}"},
-    {"type": "template", "key": "arg1", "value": "cta-button"}
-  ]}
+  {"variable": "{{Click Classes}}", "op": "contains", "value": "cta-button"}
 ]`,
 			Notes: "Use linkClick for click triggers. Use autoEventFilterJson to filter by click element properties (Click Classes, Click ID, Click URL, etc.).",
 		},
@@ -192,3 +186,77 @@ func GetTriggerTemplates() []TriggerTemplate {
 		},
 	}
 }
+
+// VariableTemplate provides example parameter structures for creating variables.
+type VariableTemplate struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Type        string `json:"type"`
+	Parameters  string `json:"parameters"`
+	Notes       string `json:"notes"`
+}
+
+// GetVariableTemplates returns example parameter structures for common variable types,
+// including the Google tag settings variables whose parameter groups are easy to get wrong.
+func GetVariableTemplates() []VariableTemplate {
+	return []VariableTemplate{
+		{
+			Name:        "Constant",
+			Description: "Fixed value such as a GA4 measurement ID",
+			Type:        "c",
+			Parameters: `[
+  {"type": "template", "key": "value", "value": "G-XXXXXXXXXX"}
+]`,
+			Notes: "Use c type for constants. Reference it elsewhere as {{Variable Name}}.",
+		},
+		{
+			Name:        "Data Layer Variable",
+			Description: "Reads a key from the dataLayer",
+			Type:        "v",
+			Parameters: `[
+  {"type": "integer", "key": "dataLayerVersion", "value": "2"},
+  {"type": "boolean", "key": "setDefaultValue", "value": "false"},
+  {"type": "template", "key": "name", "value": "ecommerce.transaction_id"}
+]`,
+			Notes: "Use v type with dataLayerVersion 2. Nested keys use dot notation.",
+		},
+		{
+			Name:        "Google Tag: Event Settings",
+			Description: "Shared event parameters and user properties for Google tags and GA4 event tags",
+			Type:        "gtes",
+			Parameters: `[
+  {"type": "list", "key": "eventSettingsTable", "list": [
+    {"type": "map", "map": [
+      {"type": "template", "key": "parameter", "value": "page_type"},
+      {"type": "template", "key": "parameterValue", "value": "{{DL - Page Type}}"}
+    ]}
+  ]},
+  {"type": "list", "key": "userProperties", "list": [
+    {"type": "map", "map": [
+      {"type": "template", "key": "name", "value": "customer_tier"},
+      {"type": "template", "key": "value", "value": "{{DL - Customer Tier}}"}
+    ]}
+  ]}
+]`,
+			Notes: "Use gtes type. Event parameters go in eventSettingsTable as parameter/parameterValue maps; user properties go in userProperties as name/value maps. Attach it to a googtag or gaawe tag with a template parameter eventSettingsVariable set to {{Variable Name}}.",
+		},
+		{
+			Name:        "Google Tag: Configuration Settings",
+			Description: "Shared configuration parameters for Google tags",
+			Type:        "gtcs",
+			Parameters: `[
+  {"type": "list", "key": "configSettingsTable", "list": [
+    {"type": "map", "map": [
+      {"type": "template", "key": "parameter", "value": "send_page_view"},
+      {"type": "template", "key": "parameterValue", "value": "false"}
+    ]},
+    {"type": "map", "map": [
+      {"type": "template", "key": "parameter", "value": "cookie_domain"},
+      {"type": "template", "key": "parameterValue", "value": "auto"}
+    ]}
+  ]}
+]`,
+			Notes: "Use gtcs type. Configuration parameters go in configSettingsTable as parameter/parameterValue maps. Attach it to a googtag tag with a template parameter configSettingsVariable set to {{Variable Name}}.",
+		},
+	}
+}
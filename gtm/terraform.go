@@ -0,0 +1,356 @@
+package gtm
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+
+	tagmanager "google.golang.org/api/tagmanager/v2"
+)
+
+// Terraform output formats.
+const (
+	TerraformHCL  = "hcl"
+	TerraformJSON = "json"
+)
+
+// DefaultTerraformProvider is the provider source written to required_providers.
+const DefaultTerraformProvider = "mirefly/gtm"
+
+// tfExpr is a Terraform expression (e.g. a resource reference) rather than a
+// string literal.
+type tfExpr string
+
+// tfAttr is a block attribute. Value is a string, bool, tfExpr or []tfExpr.
+type tfAttr struct {
+	name  string
+	value any
+}
+
+// tfBlock is an ordered Terraform block body.
+type tfBlock struct {
+	attrs  []tfAttr
+	blocks []tfNested
+}
+
+type tfNested struct {
+	name string
+	body *tfBlock
+}
+
+func (b *tfBlock) attr(name string, value any) {
+	b.attrs = append(b.attrs, tfAttr{name, value})
+}
+
+func (b *tfBlock) block(name string, body *tfBlock) {
+	b.blocks = append(b.blocks, tfNested{name, body})
+}
+
+// tfResource is a single resource in the rendered configuration.
+type tfResource struct {
+	kind string
+	name string
+	body *tfBlock
+}
+
+// TerraformOptions configures RenderTerraform.
+type TerraformOptions struct {
+	// Format is "hcl" (default) or "json" (.tf.json syntax).
+	Format string
+	// Provider is the required_providers source (default DefaultTerraformProvider).
+	Provider string
+}
+
+// RenderTerraform renders the tags, triggers and variables of a container
+// version as gtm_tag, gtm_trigger and gtm_variable resources. Tag trigger
+// IDs become references to the generated trigger resources; built-in
+// triggers such as All Pages keep their numeric IDs.
+func RenderTerraform(cv *tagmanager.ContainerVersion, opts TerraformOptions) (string, error) {
+	format := opts.Format
+	if format == "" {
+		format = TerraformHCL
+	}
+	if format != TerraformHCL && format != TerraformJSON {
+		return "", fmt.Errorf("invalid format %q (expected hcl or json)", opts.Format)
+	}
+	provider := opts.Provider
+	if provider == "" {
+		provider = DefaultTerraformProvider
+	}
+
+	resources := terraformResources(cv)
+	if format == TerraformJSON {
+		return renderTerraformJSON(resources, provider)
+	}
+	return renderTerraformHCL(resources, provider), nil
+}
+
+func terraformResources(cv *tagmanager.ContainerVersion) []tfResource {
+	names := make(map[string]bool)
+	var resources []tfResource
+
+	triggerRefs := make(map[string]tfExpr)
+	var triggers []tfResource
+	for _, t := range cv.Trigger {
+		name := uniqueResourceName(names, "gtm_trigger", t.Name)
+		triggerRefs[t.TriggerId] = tfExpr("gtm_trigger." + name + ".id")
+		triggers = append(triggers, tfResource{kind: "gtm_trigger", name: name, body: terraformTrigger(t)})
+	}
+
+	for _, v := range cv.Variable {
+		body := &tfBlock{}
+		body.attr("name", v.Name)
+		body.attr("type", v.Type)
+		if v.Notes != "" {
+			body.attr("notes", v.Notes)
+		}
+		addParameterBlocks(body, "parameter", v.Parameter)
+		resources = append(resources, tfResource{kind: "gtm_variable", name: uniqueResourceName(names, "gtm_variable", v.Name), body: body})
+	}
+
+	resources = append(resources, triggers...)
+
+	for _, t := range cv.Tag {
+		body := &tfBlock{}
+		body.attr("name", t.Name)
+		body.attr("type", t.Type)
+		if t.Notes != "" {
+			body.attr("notes", t.Notes)
+		}
+		if t.Paused {
+			body.attr("paused", true)
+		}
+		if refs := triggerReferences(t.FiringTriggerId, triggerRefs); len(refs) > 0 {
+			body.attr("firing_trigger_id", refs)
+		}
+		if refs := triggerReferences(t.BlockingTriggerId, triggerRefs); len(refs) > 0 {
+			body.attr("blocking_trigger_id", refs)
+		}
+		addParameterBlocks(body, "parameter", t.Parameter)
+		resources = append(resources, tfResource{kind: "gtm_tag", name: uniqueResourceName(names, "gtm_tag", t.Name), body: body})
+	}
+	return resources
+}
+
+func terraformTrigger(t *tagmanager.Trigger) *tfBlock {
+	body := &tfBlock{}
+	body.attr("name", t.Name)
+	body.attr("type", t.Type)
+	if t.Notes != "" {
+		body.attr("notes", t.Notes)
+	}
+	addConditionBlocks(body, "filter", t.Filter)
+	addConditionBlocks(body, "auto_event_filter", t.AutoEventFilter)
+	addConditionBlocks(body, "custom_event_filter", t.CustomEventFilter)
+	if t.EventName != nil {
+		addParameterBlocks(body, "event_name", []*tagmanager.Parameter{t.EventName})
+	}
+	addParameterBlocks(body, "parameter", t.Parameter)
+	return body
+}
+
+// triggerReferences maps trigger IDs to resource references, keeping IDs of
+// triggers outside the export (built-in triggers) as literals.
+func triggerReferences(ids []string, refs map[string]tfExpr) []tfExpr {
+	var out []tfExpr
+	for _, id := range ids {
+		if ref, ok := refs[id]; ok {
+			out = append(out, ref)
+		} else {
+			out = append(out, tfExpr(fmt.Sprintf("%q", id)))
+		}
+	}
+	return out
+}
+
+func addConditionBlocks(body *tfBlock, name string, conditions []*tagmanager.Condition) {
+	for _, c := range conditions {
+		cond := &tfBlock{}
+		cond.attr("type", c.Type)
+		addParameterBlocks(cond, "parameter", c.Parameter)
+		body.block(name, cond)
+	}
+}
+
+func addParameterBlocks(body *tfBlock, name string, params []*tagmanager.Parameter) {
+	for _, p := range params {
+		if p == nil {
+			continue
+		}
+		param := &tfBlock{}
+		param.attr("type", p.Type)
+		if p.Key != "" {
+			param.attr("key", p.Key)
+		}
+		if p.Value != "" {
+			param.attr("value", p.Value)
+		}
+		addParameterBlocks(param, "list", p.List)
+		addParameterBlocks(param, "map", p.Map)
+		body.block(name, param)
+	}
+}
+
+var nonIdentifierChars = regexp.MustCompile(`[^a-z0-9_]+`)
+
+// uniqueResourceName derives a Terraform identifier from an entity name,
+// appending a counter when two entities of the same kind collide.
+func uniqueResourceName(used map[string]bool, kind, name string) string {
+	base := strings.Trim(nonIdentifierChars.ReplaceAllString(strings.ToLower(name), "_"), "_")
+	if base == "" {
+		base = "unnamed"
+	}
+	if base[0] >= '0' && base[0] <= '9' {
+		base = "_" + base
+	}
+	candidate := base
+	for i := 2; used[kind+"."+candidate]; i++ {
+		candidate = fmt.Sprintf("%s_%d", base, i)
+	}
+	used[kind+"."+candidate] = true
+	return candidate
+}
+
+func renderTerraformHCL(resources []tfResource, provider string) string {
+	var sb strings.Builder
+	sb.WriteString("terraform {\n  required_providers {\n    gtm = {\n")
+	fmt.Fprintf(&sb, "      source = %s\n", hclString(provider))
+	sb.WriteString("    }\n  }\n}\n")
+	for _, r := range resources {
+		fmt.Fprintf(&sb, "\nresource %q %q {\n", r.kind, r.name)
+		writeHCLBody(&sb, r.body, 1)
+		sb.WriteString("}\n")
+	}
+	return sb.String()
+}
+
+func writeHCLBody(sb *strings.Builder, b *tfBlock, depth int) {
+	indent := strings.Repeat("  ", depth)
+	width := 0
+	for _, a := range b.attrs {
+		width = max(width, len(a.name))
+	}
+	for _, a := range b.attrs {
+		fmt.Fprintf(sb, "%s%-*s = %s\n", indent, width, a.name, hclValue(a.value))
+	}
+	for _, n := range b.blocks {
+		fmt.Fprintf(sb, "%s%s {\n", indent, n.name)
+		writeHCLBody(sb, n.body, depth+1)
+		fmt.Fprintf(sb, "%s}\n", indent)
+	}
+}
+
+func hclValue(v any) string {
+	switch v := v.(type) {
+	case string:
+		return hclString(v)
+	case bool:
+		return fmt.Sprint(v)
+	case tfExpr:
+		return string(v)
+	case []tfExpr:
+		parts := make([]string, len(v))
+		for i, e := range v {
+			parts[i] = string(e)
+		}
+		return "[" + strings.Join(parts, ", ") + "]"
+	}
+	return hclString(fmt.Sprint(v))
+}
+
+// hclString quotes s as an HCL string literal, escaping template sequences
+// so GTM values such as Custom HTML are taken literally.
+func hclString(s string) string {
+	var sb strings.Builder
+	sb.WriteByte('"')
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch c {
+		case '"':
+			sb.WriteString(`\"`)
+		case '\\':
+			sb.WriteString(`\\`)
+		case '\n':
+			sb.WriteString(`\n`)
+		case '\r':
+			sb.WriteString(`\r`)
+		case '\t':
+			sb.WriteString(`\t`)
+		case '$', '%':
+			if i+1 < len(s) && s[i+1] == '{' {
+				sb.WriteByte(c)
+			}
+			sb.WriteByte(c)
+		default:
+			sb.WriteByte(c)
+		}
+	}
+	sb.WriteByte('"')
+	return sb.String()
+}
+
+func renderTerraformJSON(resources []tfResource, provider string) (string, error) {
+	byKind := make(map[string]map[string]any)
+	for _, r := range resources {
+		if byKind[r.kind] == nil {
+			byKind[r.kind] = make(map[string]any)
+		}
+		byKind[r.kind][r.name] = jsonBody(r.body)
+	}
+	doc := map[string]any{
+		"terraform": map[string]any{
+			"required_providers": map[string]any{
+				"gtm": map[string]any{"source": provider},
+			},
+		},
+		"resource": byKind,
+	}
+	data, err := json.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("failed to encode terraform JSON: %w", err)
+	}
+	return string(data) + "\n", nil
+}
+
+// jsonBody converts a block to Terraform JSON syntax, where repeated nested
+// blocks become arrays and expressions are wrapped in ${...}. Literal
+// template sequences are escaped as in HCL.
+func jsonBody(b *tfBlock) map[string]any {
+	out := make(map[string]any)
+	for _, a := range b.attrs {
+		switch v := a.value.(type) {
+		case string:
+			out[a.name] = escapeTemplate(v)
+		case tfExpr:
+			out[a.name] = jsonExpr(v)
+		case []tfExpr:
+			refs := make([]string, len(v))
+			for i, e := range v {
+				refs[i] = jsonExpr(e)
+			}
+			out[a.name] = refs
+		default:
+			out[a.name] = v
+		}
+	}
+	for _, n := range b.blocks {
+		list, _ := out[n.name].([]any)
+		out[n.name] = append(list, jsonBody(n.body))
+	}
+	return out
+}
+
+// jsonExpr renders an expression for Terraform JSON. Quoted literals are
+// unquoted; references are interpolated.
+func jsonExpr(e tfExpr) string {
+	s := string(e)
+	if strings.HasPrefix(s, `"`) {
+		return strings.Trim(s, `"`)
+	}
+	return "${" + s + "}"
+}
+
+func escapeTemplate(s string) string {
+	return strings.NewReplacer("${", "$${", "%{", "%%{").Replace(s)
+}
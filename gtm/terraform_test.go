@@ -0,0 +1,126 @@
+package gtm
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	tagmanager "google.golang.org/api/tagmanager/v2"
+)
+
+func terraformTestVersion() *tagmanager.ContainerVersion {
+	return &tagmanager.ContainerVersion{
+		Variable: []*tagmanager.Variable{
+			{Name: "GA4 ID", Type: "c", Parameter: []*tagmanager.Parameter{{Type: "template", Key: "value", Value: "G-ABC"}}},
+		},
+		Trigger: []*tagmanager.Trigger{
+			{TriggerId: "10", Name: "CE - Purchase", Type: "customEvent", CustomEventFilter: []*tagmanager.Condition{{
+				Type: "equals",
+				Parameter: []*tagmanager.Parameter{
+					{Type: "template", Key: "arg0", Value: "{{_event}}"},
+					{Type: "template", Key: "arg1", Value: "purchase"},
+				},
+			}}},
+		},
+		Tag: []*tagmanager.Tag{
+			{
+				Name:            "GA4 - Purchase",
+				Type:            "gaawe",
+				FiringTriggerId: []string{"10", "2147479553"},
+				Parameter: []*tagmanager.Parameter{
+					{Type: "template", Key: "eventName", Value: "purchase"},
+					{Type: "list", Key: "eventParameters", List: []*tagmanager.Parameter{
+						{Type: "map", Map: []*tagmanager.Parameter{
+							{Type: "template", Key: "name", Value: "currency"},
+							{Type: "template", Key: "value", Value: "EUR"},
+						}},
+					}},
+				},
+			},
+			{Name: "HTML", Type: "html", FiringTriggerId: []string{"10"}, Parameter: []*tagmanager.Parameter{
+				{Type: "template", Key: "html", Value: "<script>var s = `${x}`;\n</script>"},
+			}},
+		},
+	}
+}
+
+func TestRenderTerraform_HCL(t *testing.T) {
+	got, err := RenderTerraform(terraformTestVersion(), TerraformOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, want := range []string{
+		`source = "mirefly/gtm"`,
+		`resource "gtm_variable" "ga4_id" {`,
+		`resource "gtm_trigger" "ce_purchase" {`,
+		`resource "gtm_tag" "ga4_purchase" {`,
+		`firing_trigger_id = [gtm_trigger.ce_purchase.id, "2147479553"]`,
+		"  custom_event_filter {\n    type = \"equals\"\n    parameter {",
+		"    list {\n      type = \"map\"\n      map {",
+		`value = "<script>var s = ` + "`$${x}`" + `;\n</script>"`,
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("output missing %q:\n%s", want, got)
+		}
+	}
+}
+
+func TestRenderTerraform_JSON(t *testing.T) {
+	got, err := RenderTerraform(terraformTestVersion(), TerraformOptions{Format: TerraformJSON, Provider: "example/gtm"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var doc struct {
+		Terraform struct {
+			RequiredProviders map[string]struct {
+				Source string `json:"source"`
+			} `json:"required_providers"`
+		} `json:"terraform"`
+		Resource map[string]map[string]map[string]any `json:"resource"`
+	}
+	if err := json.Unmarshal([]byte(got), &doc); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, got)
+	}
+	if src := doc.Terraform.RequiredProviders["gtm"].Source; src != "example/gtm" {
+		t.Errorf("provider source = %q", src)
+	}
+	tag := doc.Resource["gtm_tag"]["ga4_purchase"]
+	refs, _ := tag["firing_trigger_id"].([]any)
+	if len(refs) != 2 || refs[0] != "${gtm_trigger.ce_purchase.id}" || refs[1] != "2147479553" {
+		t.Errorf("firing_trigger_id = %v", tag["firing_trigger_id"])
+	}
+	params, _ := tag["parameter"].([]any)
+	if len(params) != 2 {
+		t.Errorf("expected 2 parameter blocks, got %v", tag["parameter"])
+	}
+	html := doc.Resource["gtm_tag"]["html"]["parameter"].([]any)[0].(map[string]any)["value"]
+	if html != "<script>var s = `$${x}`;\n</script>" {
+		t.Errorf("html value not escaped: %q", html)
+	}
+}
+
+func TestRenderTerraform_InvalidFormat(t *testing.T) {
+	if _, err := RenderTerraform(&tagmanager.ContainerVersion{}, TerraformOptions{Format: "yaml"}); err == nil {
+		t.Error("expected error for unknown format")
+	}
+}
+
+func TestUniqueResourceName(t *testing.T) {
+	used := make(map[string]bool)
+	tests := []struct {
+		kind, name, want string
+	}{
+		{"gtm_tag", "GA4 - Page View", "ga4_page_view"},
+		{"gtm_tag", "GA4 | Page-View", "ga4_page_view_2"},
+		{"gtm_trigger", "GA4 - Page View", "ga4_page_view"},
+		{"gtm_tag", "404 Error", "_404_error"},
+		{"gtm_tag", "***", "unnamed"},
+	}
+	for _, tt := range tests {
+		if got := uniqueResourceName(used, tt.kind, tt.name); got != tt.want {
+			t.Errorf("uniqueResourceName(%q, %q) = %q, want %q", tt.kind, tt.name, got, tt.want)
+		}
+	}
+}
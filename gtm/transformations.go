@@ -11,11 +11,11 @@ import (
 func (c *Client) ListTransformations(ctx context.Context, accountID, containerID, workspaceID string) ([]TransformationInfo, error) {
 	parent := fmt.Sprintf("accounts/%s/containers/%s/workspaces/%s", accountID, containerID, workspaceID)
 
-	resp, err := retryWithBackoff(ctx, 3, func() (*tagmanager.ListTransformationsResponse, error) {
+	resp, err := RetryWithBackoff(ctx, func() (*tagmanager.ListTransformationsResponse, error) {
 		return c.Service.Accounts.Containers.Workspaces.Transformations.List(parent).Context(ctx).Do()
 	})
 	if err != nil {
-		return nil, mapGoogleError(err)
+		return nil, MapGoogleError(err)
 	}
 	if resp == nil {
 		return []TransformationInfo{}, nil
@@ -29,11 +29,11 @@ func (c *Client) GetTransformation(ctx context.Context, accountID, containerID,
 	path := fmt.Sprintf("accounts/%s/containers/%s/workspaces/%s/transformations/%s",
 		accountID, containerID, workspaceID, transformationID)
 
-	t, err := retryWithBackoff(ctx, 3, func() (*tagmanager.Transformation, error) {
+	t, err := readAfterMutation(ctx, path, func() (*tagmanager.Transformation, error) {
 		return c.Service.Accounts.Containers.Workspaces.Transformations.Get(path).Context(ctx).Do()
 	})
 	if err != nil {
-		return nil, mapGoogleError(err)
+		return nil, MapGoogleError(err)
 	}
 
 	result := toTransformation(t)
@@ -53,8 +53,9 @@ func (c *Client) CreateTransformation(ctx context.Context, accountID, containerI
 
 	result, err := c.Service.Accounts.Containers.Workspaces.Transformations.Create(parent, t).Context(ctx).Do()
 	if err != nil {
-		return nil, mapGoogleError(err)
+		return nil, MapGoogleError(err)
 	}
+	RecentMutations.Mark(ctx, result.Path)
 
 	return &CreatedTransformation{
 		TransformationID: result.TransformationId,
@@ -67,9 +68,11 @@ func (c *Client) CreateTransformation(ctx context.Context, accountID, containerI
 
 // UpdateTransformation updates an existing transformation. It fetches the current transformation first to get the fingerprint.
 func (c *Client) UpdateTransformation(ctx context.Context, path string, input *TransformationInput) (*CreatedTransformation, error) {
-	current, err := c.Service.Accounts.Containers.Workspaces.Transformations.Get(path).Context(ctx).Do()
+	current, err := readAfterMutation(ctx, path, func() (*tagmanager.Transformation, error) {
+		return c.Service.Accounts.Containers.Workspaces.Transformations.Get(path).Context(ctx).Do()
+	})
 	if err != nil {
-		return nil, mapGoogleError(err)
+		return nil, MapGoogleError(err)
 	}
 
 	t := &tagmanager.Transformation{
@@ -81,8 +84,9 @@ func (c *Client) UpdateTransformation(ctx context.Context, path string, input *T
 
 	result, err := c.Service.Accounts.Containers.Workspaces.Transformations.Update(path, t).Fingerprint(current.Fingerprint).Context(ctx).Do()
 	if err != nil {
-		return nil, mapGoogleError(err)
+		return nil, MapGoogleError(err)
 	}
+	RecentMutations.Mark(ctx, result.Path)
 
 	return &CreatedTransformation{
 		TransformationID: result.TransformationId,
@@ -96,7 +100,7 @@ func (c *Client) UpdateTransformation(ctx context.Context, path string, input *T
 // DeleteTransformation deletes a transformation from the workspace.
 func (c *Client) DeleteTransformation(ctx context.Context, path string) error {
 	err := c.Service.Accounts.Containers.Workspaces.Transformations.Delete(path).Context(ctx).Do()
-	return mapGoogleError(err)
+	return MapGoogleError(err)
 }
 
 func toTransformations(transformations []*tagmanager.Transformation) []TransformationInfo {
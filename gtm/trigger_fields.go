@@ -0,0 +1,95 @@
+package gtm
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	tagmanager "google.golang.org/api/tagmanager/v2"
+)
+
+// Trigger types that use the typed timer, scroll and visibility fields of
+// TriggerInput.
+var (
+	timerTriggerTypes      = []string{"timer", "ampTimer"}
+	scrollTriggerTypes     = []string{"scrollDepth", "ampScroll"}
+	visibilityTriggerTypes = []string{"elementVisibility", "ampVisibility"}
+)
+
+// ValidateTriggerFields checks the typed timer, scroll and visibility fields
+// of a trigger against its type and their value ranges.
+func ValidateTriggerFields(input *TriggerInput) error {
+	timer := input.Interval != nil || input.Limit != nil
+	scroll := input.VerticalScrollPercentages != nil || input.HorizontalScrollPercentages != nil
+	visibility := input.Selector != "" || input.VisiblePercentageMin != nil || input.VisiblePercentageMax != nil
+
+	if timer && !slices.Contains(timerTriggerTypes, input.Type) {
+		return fmt.Errorf("interval and limit are only valid for %s triggers", strings.Join(timerTriggerTypes, "/"))
+	}
+	if scroll && !slices.Contains(scrollTriggerTypes, input.Type) {
+		return fmt.Errorf("scroll percentages are only valid for %s triggers", strings.Join(scrollTriggerTypes, "/"))
+	}
+	if visibility && !slices.Contains(visibilityTriggerTypes, input.Type) {
+		return fmt.Errorf("selector and visible percentages are only valid for %s triggers", strings.Join(visibilityTriggerTypes, "/"))
+	}
+
+	if input.Interval != nil && *input.Interval <= 0 {
+		return fmt.Errorf("interval must be a positive number of milliseconds")
+	}
+	if input.Limit != nil && *input.Limit <= 0 {
+		return fmt.Errorf("limit must be positive")
+	}
+	for _, p := range append(slices.Clone(input.VerticalScrollPercentages), input.HorizontalScrollPercentages...) {
+		if p < 1 || p > 100 {
+			return fmt.Errorf("scroll percentage %d must be between 1 and 100", p)
+		}
+	}
+	for _, p := range []*int{input.VisiblePercentageMin, input.VisiblePercentageMax} {
+		if p != nil && (*p < 0 || *p > 100) {
+			return fmt.Errorf("visible percentage %d must be between 0 and 100", *p)
+		}
+	}
+	if input.VisiblePercentageMin != nil && input.VisiblePercentageMax != nil && *input.VisiblePercentageMin > *input.VisiblePercentageMax {
+		return fmt.Errorf("visiblePercentageMin must not be greater than visiblePercentageMax")
+	}
+	return nil
+}
+
+// applyTriggerFields sets the typed timer, scroll and visibility fields that
+// are present in input on t, leaving the others unchanged.
+func applyTriggerFields(t *tagmanager.Trigger, input *TriggerInput) {
+	if input.Interval != nil {
+		t.Interval = templateParam(strconv.FormatInt(*input.Interval, 10))
+	}
+	if input.Limit != nil {
+		t.Limit = templateParam(strconv.FormatInt(*input.Limit, 10))
+	}
+	if input.VerticalScrollPercentages != nil {
+		t.VerticalScrollPercentageList = percentageList(input.VerticalScrollPercentages)
+	}
+	if input.HorizontalScrollPercentages != nil {
+		t.HorizontalScrollPercentageList = percentageList(input.HorizontalScrollPercentages)
+	}
+	if input.Selector != "" {
+		t.Selector = templateParam(input.Selector)
+	}
+	if input.VisiblePercentageMin != nil {
+		t.VisiblePercentageMin = templateParam(strconv.Itoa(*input.VisiblePercentageMin))
+	}
+	if input.VisiblePercentageMax != nil {
+		t.VisiblePercentageMax = templateParam(strconv.Itoa(*input.VisiblePercentageMax))
+	}
+}
+
+func templateParam(value string) *tagmanager.Parameter {
+	return &tagmanager.Parameter{Type: ParamTemplate, Value: value}
+}
+
+func percentageList(percentages []int) *tagmanager.Parameter {
+	list := make([]*tagmanager.Parameter, len(percentages))
+	for i, p := range percentages {
+		list[i] = templateParam(strconv.Itoa(p))
+	}
+	return &tagmanager.Parameter{Type: ParamList, List: list}
+}
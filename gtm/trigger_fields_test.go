@@ -0,0 +1,66 @@
+package gtm
+
+import (
+	"testing"
+
+	tagmanager "google.golang.org/api/tagmanager/v2"
+)
+
+func TestValidateTriggerFields(t *testing.T) {
+	i64 := func(v int64) *int64 { return &v }
+	pct := func(v int) *int { return &v }
+	tests := []struct {
+		name    string
+		input   TriggerInput
+		wantErr bool
+	}{
+		{name: "no typed fields", input: TriggerInput{Type: "pageview"}},
+		{name: "timer", input: TriggerInput{Type: "timer", Interval: i64(5000), Limit: i64(3)}},
+		{name: "scroll", input: TriggerInput{Type: "scrollDepth", VerticalScrollPercentages: []int{25, 50, 75, 100}}},
+		{name: "visibility", input: TriggerInput{Type: "elementVisibility", Selector: "#cta", VisiblePercentageMin: pct(50), VisiblePercentageMax: pct(100)}},
+		{name: "interval on pageview", input: TriggerInput{Type: "pageview", Interval: i64(5000)}, wantErr: true},
+		{name: "scroll on timer", input: TriggerInput{Type: "timer", HorizontalScrollPercentages: []int{50}}, wantErr: true},
+		{name: "selector on scroll", input: TriggerInput{Type: "scrollDepth", Selector: "#cta"}, wantErr: true},
+		{name: "zero interval", input: TriggerInput{Type: "timer", Interval: i64(0)}, wantErr: true},
+		{name: "negative limit", input: TriggerInput{Type: "timer", Limit: i64(-1)}, wantErr: true},
+		{name: "percentage over 100", input: TriggerInput{Type: "scrollDepth", VerticalScrollPercentages: []int{50, 110}}, wantErr: true},
+		{name: "min above max", input: TriggerInput{Type: "elementVisibility", VisiblePercentageMin: pct(80), VisiblePercentageMax: pct(20)}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateTriggerFields(&tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestApplyTriggerFields(t *testing.T) {
+	interval := int64(10000)
+	visibleMin := 50
+	trigger := &tagmanager.Trigger{
+		Type:     "timer",
+		Limit:    &tagmanager.Parameter{Type: ParamTemplate, Value: "5"},
+		Selector: &tagmanager.Parameter{Type: ParamTemplate, Value: "#keep"},
+	}
+	applyTriggerFields(trigger, &TriggerInput{
+		Interval:                  &interval,
+		VerticalScrollPercentages: []int{25, 50},
+		VisiblePercentageMin:      &visibleMin,
+	})
+
+	if trigger.Interval == nil || trigger.Interval.Value != "10000" {
+		t.Errorf("interval = %+v, want 10000", trigger.Interval)
+	}
+	if trigger.Limit.Value != "5" || trigger.Selector.Value != "#keep" {
+		t.Errorf("unsupplied fields changed: limit=%+v selector=%+v", trigger.Limit, trigger.Selector)
+	}
+	list := trigger.VerticalScrollPercentageList
+	if list == nil || list.Type != ParamList || len(list.List) != 2 || list.List[1].Value != "50" {
+		t.Errorf("vertical scroll list = %+v", list)
+	}
+	if trigger.VisiblePercentageMin == nil || trigger.VisiblePercentageMin.Value != "50" {
+		t.Errorf("visiblePercentageMin = %+v, want 50", trigger.VisiblePercentageMin)
+	}
+}
@@ -27,11 +27,12 @@ type Trigger struct {
 func (c *Client) ListTriggers(ctx context.Context, accountID, containerID, workspaceID string) ([]Trigger, error) {
 	parent := fmt.Sprintf("accounts/%s/containers/%s/workspaces/%s", accountID, containerID, workspaceID)
 
-	resp, err := retryWithBackoff(ctx, 3, func() (*tagmanager.ListTriggersResponse, error) {
-		return c.Service.Accounts.Containers.Workspaces.Triggers.List(parent).Context(ctx).Do()
+	resp, err := cachedList(listCaches, c.user, parent, "triggers", func() (*tagmanager.ListTriggersResponse, error) {
+		all, err := listAllTriggers(ctx, c.Service.Accounts.Containers.Workspaces, parent)
+		return &tagmanager.ListTriggersResponse{Trigger: all}, err
 	})
 	if err != nil {
-		return nil, mapGoogleError(err)
+		return nil, MapGoogleError(err)
 	}
 
 	return toTriggers(resp.Trigger), nil
@@ -42,12 +43,13 @@ func (c *Client) GetTrigger(ctx context.Context, accountID, containerID, workspa
 	path := fmt.Sprintf("accounts/%s/containers/%s/workspaces/%s/triggers/%s",
 		accountID, containerID, workspaceID, triggerID)
 
-	t, err := retryWithBackoff(ctx, 3, func() (*tagmanager.Trigger, error) {
+	t, err := readAfterMutation(ctx, path, func() (*tagmanager.Trigger, error) {
 		return c.Service.Accounts.Containers.Workspaces.Triggers.Get(path).Context(ctx).Do()
 	})
 	if err != nil {
-		return nil, mapGoogleError(err)
+		return nil, MapGoogleError(err)
 	}
+	listCaches.observe(t.Path, t.Fingerprint)
 
 	triggers := toTriggers([]*tagmanager.Trigger{t})
 	return &triggers[0], nil
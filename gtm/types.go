@@ -20,6 +20,11 @@ type TagInput struct {
 	Notes              string      `json:"notes,omitempty"`
 	Paused             bool        `json:"paused,omitempty"`
 	TagFiringOption    string      `json:"tagFiringOption,omitempty"`
+	ScheduleStartMs    int64       `json:"scheduleStartMs,omitempty"`
+	ScheduleEndMs      int64       `json:"scheduleEndMs,omitempty"`
+	ConsentSettings    *ConsentSettings `json:"consentSettings,omitempty"`
+	Priority           *int        `json:"priority,omitempty"`
+	ParentFolderId     string      `json:"parentFolderId,omitempty"`
 }
 
 // TriggerInput represents input for creating/updating a trigger.
@@ -32,6 +37,17 @@ type TriggerInput struct {
 	EventName         *Parameter  `json:"eventName,omitempty"`
 	Parameter         []Parameter `json:"parameter,omitempty"` // For trigger groups: member trigger references
 	Notes             string      `json:"notes,omitempty"`
+	ParentFolderId    string      `json:"parentFolderId,omitempty"`
+	// Timer triggers
+	Interval *int64 `json:"interval,omitempty"` // milliseconds
+	Limit    *int64 `json:"limit,omitempty"`
+	// Scroll depth triggers
+	VerticalScrollPercentages   []int `json:"verticalScrollPercentages,omitempty"`
+	HorizontalScrollPercentages []int `json:"horizontalScrollPercentages,omitempty"`
+	// Element visibility triggers
+	Selector             string `json:"selector,omitempty"`
+	VisiblePercentageMin *int   `json:"visiblePercentageMin,omitempty"`
+	VisiblePercentageMax *int   `json:"visiblePercentageMax,omitempty"`
 }
 
 // Condition represents a filter condition for triggers.
@@ -43,10 +59,12 @@ type Condition struct {
 
 // VariableInput represents input for creating a variable.
 type VariableInput struct {
-	Name      string      `json:"name"`
-	Type      string      `json:"type"`
-	Parameter []Parameter `json:"parameter,omitempty"`
-	Notes     string      `json:"notes,omitempty"`
+	Name           string       `json:"name"`
+	Type           string       `json:"type"`
+	Parameter      []Parameter  `json:"parameter,omitempty"`
+	Notes          string       `json:"notes,omitempty"`
+	ParentFolderId string       `json:"parentFolderId,omitempty"`
+	FormatValue    *FormatValue `json:"formatValue,omitempty"`
 }
 
 // VersionInput represents input for creating a version.
@@ -0,0 +1,241 @@
+package gtm
+
+import (
+	"context"
+	"errors"
+
+	tagmanager "google.golang.org/api/tagmanager/v2"
+)
+
+// Upsert statuses.
+const (
+	UpsertCreated   = "created"
+	UpsertUpdated   = "updated"
+	UpsertUnchanged = "unchanged"
+)
+
+// UpsertOutcome reports what an upsert did to the entity with the given name.
+type UpsertOutcome struct {
+	Status        string   `json:"status"`
+	ChangedFields []string `json:"changedFields,omitempty"`
+}
+
+// upsertIdentityFields are fields an update body leaves out or the API
+// assigns, so they never count as a change.
+var upsertIdentityFields = []string{"tagId", "triggerId", "variableId", "uniqueTriggerId"}
+
+// changedFields lists the fields that differ between the current and the
+// updated entity, ignoring location, fingerprint and identity fields.
+func changedFields(current, updated any) []string {
+	before, after := normalizeForDiff(current), normalizeForDiff(updated)
+	for _, f := range upsertIdentityFields {
+		delete(before, f)
+		delete(after, f)
+	}
+	var changes []FieldChange
+	diffValues("", before, after, &changes)
+	fields := make([]string, len(changes))
+	for i, c := range changes {
+		fields[i] = c.Field
+	}
+	return fields
+}
+
+// UpsertTag creates the tag, or updates the tag of the same name in the
+// workspace. Names match as in the by-name lookups: exactly, or by a single
+// case-insensitive match. An update sets the supplied fields and keeps the
+// rest; a tag is only unpaused by update_tag. No update is sent when nothing
+// differs.
+func (c *Client) UpsertTag(ctx context.Context, accountID, containerID, workspaceID string, input *TagInput) (*CreatedTag, UpsertOutcome, error) {
+	tags, err := c.ListTags(ctx, accountID, containerID, workspaceID)
+	if err != nil {
+		return nil, UpsertOutcome{}, err
+	}
+	entities := make([]NamedEntity, len(tags))
+	for i, t := range tags {
+		entities[i] = NamedEntity{t.TagID, t.Name}
+	}
+	tagID, err := MatchEntityName(EntityTypeTag, input.Name, entities)
+	if errors.Is(err, ErrNotFound) {
+		created, err := c.CreateTag(ctx, accountID, containerID, workspaceID, input)
+		return created, UpsertOutcome{Status: UpsertCreated}, err
+	}
+	if err != nil {
+		return nil, UpsertOutcome{}, err
+	}
+
+	path := BuildTagPath(accountID, containerID, workspaceID, tagID)
+	tag, err := readAfterMutation(ctx, path, func() (*tagmanager.Tag, error) {
+		return c.Service.Accounts.Containers.Workspaces.Tags.Get(path).Context(ctx).Do()
+	})
+	if err != nil {
+		return nil, UpsertOutcome{}, MapGoogleError(err)
+	}
+	before := *tag
+	if err := applyTagPatch(tag, tagPatchFromInput(input)); err != nil {
+		return nil, UpsertOutcome{}, err
+	}
+
+	outcome := UpsertOutcome{Status: UpsertUnchanged, ChangedFields: changedFields(&before, tag)}
+	if len(outcome.ChangedFields) > 0 {
+		outcome.Status = UpsertUpdated
+		tag, err = c.Service.Accounts.Containers.Workspaces.Tags.Update(path, tag).Fingerprint(tag.Fingerprint).Context(ctx).Do()
+		if err != nil {
+			return nil, UpsertOutcome{}, MapGoogleError(err)
+		}
+		RecentMutations.Mark(ctx, tag.Path)
+	}
+
+	return &CreatedTag{
+		TagID:       tag.TagId,
+		Name:        tag.Name,
+		Type:        tag.Type,
+		Path:        tag.Path,
+		Fingerprint: tag.Fingerprint,
+	}, outcome, nil
+}
+
+// tagPatchFromInput is the patch an upsert applies to an existing tag: the
+// supplied fields of a create input.
+func tagPatchFromInput(input *TagInput) *TagPatch {
+	patch := &TagPatch{
+		Name:              &input.Name,
+		Type:              &input.Type,
+		FiringTriggerId:   input.FiringTriggerId,
+		BlockingTriggerId: input.BlockingTriggerId,
+		Parameter:         input.Parameter,
+		ConsentSettings:   input.ConsentSettings,
+		Priority:          input.Priority,
+	}
+	if input.Notes != "" {
+		patch.Notes = &input.Notes
+	}
+	if input.Paused {
+		patch.Paused = &input.Paused
+	}
+	if input.ScheduleStartMs != 0 {
+		patch.ScheduleStartMs = &input.ScheduleStartMs
+	}
+	if input.ScheduleEndMs != 0 {
+		patch.ScheduleEndMs = &input.ScheduleEndMs
+	}
+	if input.ParentFolderId != "" {
+		patch.ParentFolderId = &input.ParentFolderId
+	}
+	return patch
+}
+
+// UpsertTrigger creates the trigger, or updates the trigger of the same name
+// in the workspace as update_trigger does. No update is sent when nothing
+// differs.
+func (c *Client) UpsertTrigger(ctx context.Context, accountID, containerID, workspaceID string, input *TriggerInput) (*CreatedTrigger, UpsertOutcome, error) {
+	triggers, err := c.ListTriggers(ctx, accountID, containerID, workspaceID)
+	if err != nil {
+		return nil, UpsertOutcome{}, err
+	}
+	entities := make([]NamedEntity, len(triggers))
+	for i, t := range triggers {
+		entities[i] = NamedEntity{t.TriggerID, t.Name}
+	}
+	triggerID, err := MatchEntityName(EntityTypeTrigger, input.Name, entities)
+	if errors.Is(err, ErrNotFound) {
+		created, err := c.CreateTrigger(ctx, accountID, containerID, workspaceID, input)
+		return created, UpsertOutcome{Status: UpsertCreated}, err
+	}
+	if err != nil {
+		return nil, UpsertOutcome{}, err
+	}
+
+	path := BuildTriggerPath(accountID, containerID, workspaceID, triggerID)
+	current, err := readAfterMutation(ctx, path, func() (*tagmanager.Trigger, error) {
+		return c.Service.Accounts.Containers.Workspaces.Triggers.Get(path).Context(ctx).Do()
+	})
+	if err != nil {
+		return nil, UpsertOutcome{}, MapGoogleError(err)
+	}
+	trigger := mergeTriggerUpdate(current, input)
+
+	outcome := UpsertOutcome{Status: UpsertUnchanged, ChangedFields: changedFields(current, trigger)}
+	if len(outcome.ChangedFields) == 0 {
+		trigger = current
+	} else {
+		outcome.Status = UpsertUpdated
+		trigger, err = c.Service.Accounts.Containers.Workspaces.Triggers.Update(path, trigger).Fingerprint(current.Fingerprint).Context(ctx).Do()
+		if err != nil {
+			return nil, UpsertOutcome{}, MapGoogleError(err)
+		}
+		RecentMutations.Mark(ctx, trigger.Path)
+	}
+
+	return &CreatedTrigger{
+		TriggerID:   trigger.TriggerId,
+		Name:        trigger.Name,
+		Type:        trigger.Type,
+		Path:        trigger.Path,
+		Fingerprint: trigger.Fingerprint,
+	}, outcome, nil
+}
+
+// UpsertVariable creates the variable, or updates the variable of the same
+// name in the workspace, setting the supplied fields and keeping the rest. No
+// update is sent when nothing differs.
+func (c *Client) UpsertVariable(ctx context.Context, accountID, containerID, workspaceID string, input *VariableInput) (*CreatedVariable, UpsertOutcome, error) {
+	variables, err := c.ListVariables(ctx, accountID, containerID, workspaceID)
+	if err != nil {
+		return nil, UpsertOutcome{}, err
+	}
+	entities := make([]NamedEntity, len(variables))
+	for i, v := range variables {
+		entities[i] = NamedEntity{v.VariableID, v.Name}
+	}
+	variableID, err := MatchEntityName(EntityTypeVariable, input.Name, entities)
+	if errors.Is(err, ErrNotFound) {
+		created, err := c.CreateVariable(ctx, accountID, containerID, workspaceID, input)
+		return created, UpsertOutcome{Status: UpsertCreated}, err
+	}
+	if err != nil {
+		return nil, UpsertOutcome{}, err
+	}
+
+	path := BuildVariablePath(accountID, containerID, workspaceID, variableID)
+	variable, err := readAfterMutation(ctx, path, func() (*tagmanager.Variable, error) {
+		return c.Service.Accounts.Containers.Workspaces.Variables.Get(path).Context(ctx).Do()
+	})
+	if err != nil {
+		return nil, UpsertOutcome{}, MapGoogleError(err)
+	}
+	before := *variable
+	patch := &VariablePatch{
+		Name:        &input.Name,
+		Type:        &input.Type,
+		Parameter:   input.Parameter,
+		FormatValue: input.FormatValue,
+	}
+	if input.Notes != "" {
+		patch.Notes = &input.Notes
+	}
+	if input.ParentFolderId != "" {
+		patch.ParentFolderId = &input.ParentFolderId
+	}
+	if err := applyVariablePatch(variable, patch); err != nil {
+		return nil, UpsertOutcome{}, err
+	}
+
+	outcome := UpsertOutcome{Status: UpsertUnchanged, ChangedFields: changedFields(&before, variable)}
+	if len(outcome.ChangedFields) > 0 {
+		outcome.Status = UpsertUpdated
+		variable, err = c.Service.Accounts.Containers.Workspaces.Variables.Update(path, variable).Fingerprint(variable.Fingerprint).Context(ctx).Do()
+		if err != nil {
+			return nil, UpsertOutcome{}, MapGoogleError(err)
+		}
+		RecentMutations.Mark(ctx, variable.Path)
+	}
+
+	return &CreatedVariable{
+		VariableID:  variable.VariableId,
+		Name:        variable.Name,
+		Type:        variable.Type,
+		Path:        variable.Path,
+		Fingerprint: variable.Fingerprint,
+	}, outcome, nil
+}
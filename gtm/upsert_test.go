@@ -0,0 +1,130 @@
+package gtm
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"slices"
+	"strings"
+	"testing"
+
+	tagmanager "google.golang.org/api/tagmanager/v2"
+)
+
+func TestUpsertTag(t *testing.T) {
+	var created, updated *tagmanager.Tag
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodPost:
+			created = &tagmanager.Tag{}
+			json.NewDecoder(r.Body).Decode(created)
+			io.WriteString(w, `{"tagId":"9","name":"GA4 - Purchase","type":"gaawe"}`)
+		case r.Method == http.MethodPut:
+			updated = &tagmanager.Tag{}
+			json.NewDecoder(r.Body).Decode(updated)
+			io.WriteString(w, `{"tagId":"5","name":"GA4 - Config","type":"googtag","fingerprint":"124"}`)
+		case strings.HasSuffix(r.URL.Path, "/tags"):
+			io.WriteString(w, `{"tag":[{"tagId":"5","name":"GA4 - Config","type":"googtag"}]}`)
+		default:
+			io.WriteString(w, `{"tagId":"5","name":"GA4 - Config","type":"googtag","fingerprint":"123","path":"accounts/1/containers/2/workspaces/3/tags/5",
+				"firingTriggerId":["7"],"notes":"Keep","parameter":[{"type":"template","key":"tagId","value":"G-1"}]}`)
+		}
+	})
+	ctx := context.Background()
+
+	input := &TagInput{Name: "ga4 - config", Type: "googtag", FiringTriggerId: []string{"7"}, Parameter: []Parameter{TemplateParam("tagId", "G-1")}}
+	tag, outcome, err := client.UpsertTag(ctx, "1", "2", "3", input)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Only the name differs in case
+	if outcome.Status != UpsertUpdated || !slices.Equal(outcome.ChangedFields, []string{"name"}) || tag.TagID != "5" {
+		t.Errorf("tag = %+v, outcome = %+v", tag, outcome)
+	}
+	if updated.Notes != "Keep" || created != nil {
+		t.Errorf("updated = %+v, created = %+v", updated, created)
+	}
+
+	updated = nil
+	input.Name = "GA4 - Config"
+	if _, outcome, err = client.UpsertTag(ctx, "1", "2", "3", input); err != nil {
+		t.Fatal(err)
+	}
+	if outcome.Status != UpsertUnchanged || updated != nil {
+		t.Errorf("outcome = %+v, updated = %+v", outcome, updated)
+	}
+
+	input.Parameter = []Parameter{TemplateParam("tagId", "G-2")}
+	if _, outcome, err = client.UpsertTag(ctx, "1", "2", "3", input); err != nil {
+		t.Fatal(err)
+	}
+	if outcome.Status != UpsertUpdated || updated.Parameter[0].Value != "G-2" {
+		t.Errorf("outcome = %+v, updated = %+v", outcome, updated)
+	}
+
+	tag, outcome, err = client.UpsertTag(ctx, "1", "2", "3", &TagInput{Name: "GA4 - Purchase", Type: "gaawe", FiringTriggerId: []string{"8"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if outcome.Status != UpsertCreated || tag.TagID != "9" || created.Name != "GA4 - Purchase" {
+		t.Errorf("tag = %+v, outcome = %+v", tag, outcome)
+	}
+}
+
+func TestUpsertTrigger(t *testing.T) {
+	var updated *tagmanager.Trigger
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodPut:
+			updated = &tagmanager.Trigger{}
+			json.NewDecoder(r.Body).Decode(updated)
+			io.WriteString(w, `{"triggerId":"7","name":"CE - purchase","type":"customEvent"}`)
+		case strings.HasSuffix(r.URL.Path, "/triggers"):
+			io.WriteString(w, `{"trigger":[{"triggerId":"7","name":"CE - purchase","type":"customEvent"}]}`)
+		default:
+			io.WriteString(w, `{"triggerId":"7","uniqueTriggerId":{"type":"template","value":"u7"},"name":"CE - purchase","type":"customEvent","fingerprint":"1","path":"accounts/1/containers/2/workspaces/3/triggers/7",
+				"customEventFilter":[{"type":"equals","parameter":[{"type":"template","key":"arg0","value":"{{_event}}"},{"type":"template","key":"arg1","value":"purchase"}]}]}`)
+		}
+	})
+	ctx := context.Background()
+
+	input := &TriggerInput{Name: "CE - purchase", Type: "customEvent", CustomEventFilter: []Condition{{
+		Type:      "equals",
+		Parameter: []Parameter{TemplateParam("arg0", "{{_event}}"), TemplateParam("arg1", "purchase")},
+	}}}
+	_, outcome, err := client.UpsertTrigger(ctx, "1", "2", "3", input)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if outcome.Status != UpsertUnchanged || updated != nil {
+		t.Errorf("outcome = %+v, updated = %+v", outcome, updated)
+	}
+
+	input.Notes = "Purchases"
+	if _, outcome, err = client.UpsertTrigger(ctx, "1", "2", "3", input); err != nil {
+		t.Fatal(err)
+	}
+	if outcome.Status != UpsertUpdated || updated.Notes != "Purchases" || updated.UniqueTriggerId != nil {
+		t.Errorf("outcome = %+v, updated = %+v", outcome, updated)
+	}
+}
+
+func TestUpsertVariable(t *testing.T) {
+	var created bool
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodPost:
+			created = true
+			io.WriteString(w, `{"variableId":"8","name":"Const - GA4 ID","type":"c"}`)
+		case strings.HasSuffix(r.URL.Path, "/variables"):
+			io.WriteString(w, `{"variable":[{"variableId":"4","name":"Const - GA4 ID"},{"variableId":"6","name":"const - ga4 id"}]}`)
+		}
+	})
+
+	// Two case-insensitive matches and no exact one: ambiguous, nothing created
+	_, _, err := client.UpsertVariable(context.Background(), "1", "2", "3", &VariableInput{Name: "CONST - GA4 ID", Type: "c"})
+	if err == nil || created {
+		t.Errorf("err = %v, created = %v", err, created)
+	}
+}
@@ -7,14 +7,8 @@ import (
 
 // ValidateTagInput validates tag creation/update inputs.
 func ValidateTagInput(name, tagType string, firingTriggerIDs []string) error {
-	if strings.TrimSpace(name) == "" {
-		return fmt.Errorf("tag name is required")
-	}
-	if len(name) > 256 {
-		return fmt.Errorf("tag name must be 256 characters or less")
-	}
-	if strings.TrimSpace(tagType) == "" {
-		return fmt.Errorf("tag type is required")
+	if err := validateTagNameAndType(name, tagType); err != nil {
+		return err
 	}
 	if len(firingTriggerIDs) == 0 {
 		return fmt.Errorf("at least one firing trigger ID is required")
@@ -27,6 +21,19 @@ func ValidateTagInput(name, tagType string, firingTriggerIDs []string) error {
 	return nil
 }
 
+func validateTagNameAndType(name, tagType string) error {
+	if strings.TrimSpace(name) == "" {
+		return fmt.Errorf("tag name is required")
+	}
+	if len(name) > 256 {
+		return fmt.Errorf("tag name must be 256 characters or less")
+	}
+	if strings.TrimSpace(tagType) == "" {
+		return fmt.Errorf("tag type is required")
+	}
+	return nil
+}
+
 // ValidateTriggerInput validates trigger creation inputs.
 func ValidateTriggerInput(name, triggerType string) error {
 	if strings.TrimSpace(name) == "" {
@@ -58,13 +65,13 @@ func ValidateVariableInput(name, varType string) error {
 // ValidateWorkspacePath validates workspace path components.
 func ValidateWorkspacePath(accountID, containerID, workspaceID string) error {
 	if strings.TrimSpace(accountID) == "" {
-		return fmt.Errorf("account ID is required")
+		return InvalidField("accountId", "account ID is required")
 	}
 	if strings.TrimSpace(containerID) == "" {
-		return fmt.Errorf("container ID is required")
+		return InvalidField("containerId", "container ID is required")
 	}
 	if strings.TrimSpace(workspaceID) == "" {
-		return fmt.Errorf("workspace ID is required")
+		return InvalidField("workspaceId", "workspace ID is required")
 	}
 	return nil
 }
@@ -78,10 +85,10 @@ func BuildWorkspacePath(accountID, containerID, workspaceID string) string {
 // ValidateContainerPath validates container path components.
 func ValidateContainerPath(accountID, containerID string) error {
 	if strings.TrimSpace(accountID) == "" {
-		return fmt.Errorf("account ID is required")
+		return InvalidField("accountId", "account ID is required")
 	}
 	if strings.TrimSpace(containerID) == "" {
-		return fmt.Errorf("container ID is required")
+		return InvalidField("containerId", "container ID is required")
 	}
 	return nil
 }
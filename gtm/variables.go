@@ -9,21 +9,23 @@ import (
 
 // Variable is a simplified representation of a GTM variable.
 type Variable struct {
-	VariableID string `json:"variableId"`
-	Name       string `json:"name"`
-	Type       string `json:"type"`
-	Path       string `json:"path"`
+	VariableID  string       `json:"variableId"`
+	Name        string       `json:"name"`
+	Type        string       `json:"type"`
+	Path        string       `json:"path"`
+	FormatValue *FormatValue `json:"formatValue,omitempty"`
 }
 
 // ListVariables returns all variables in a workspace.
 func (c *Client) ListVariables(ctx context.Context, accountID, containerID, workspaceID string) ([]Variable, error) {
 	parent := fmt.Sprintf("accounts/%s/containers/%s/workspaces/%s", accountID, containerID, workspaceID)
 
-	resp, err := retryWithBackoff(ctx, 3, func() (*tagmanager.ListVariablesResponse, error) {
-		return c.Service.Accounts.Containers.Workspaces.Variables.List(parent).Context(ctx).Do()
+	resp, err := cachedList(listCaches, c.user, parent, "variables", func() (*tagmanager.ListVariablesResponse, error) {
+		all, err := listAllVariables(ctx, c.Service.Accounts.Containers.Workspaces, parent)
+		return &tagmanager.ListVariablesResponse{Variable: all}, err
 	})
 	if err != nil {
-		return nil, mapGoogleError(err)
+		return nil, MapGoogleError(err)
 	}
 
 	return toVariables(resp.Variable), nil
@@ -34,18 +36,20 @@ func (c *Client) GetVariable(ctx context.Context, accountID, containerID, worksp
 	path := fmt.Sprintf("accounts/%s/containers/%s/workspaces/%s/variables/%s",
 		accountID, containerID, workspaceID, variableID)
 
-	v, err := retryWithBackoff(ctx, 3, func() (*tagmanager.Variable, error) {
+	v, err := readAfterMutation(ctx, path, func() (*tagmanager.Variable, error) {
 		return c.Service.Accounts.Containers.Workspaces.Variables.Get(path).Context(ctx).Do()
 	})
 	if err != nil {
-		return nil, mapGoogleError(err)
+		return nil, MapGoogleError(err)
 	}
+	listCaches.observe(v.Path, v.Fingerprint)
 
 	result := Variable{
-		VariableID: v.VariableId,
-		Name:       v.Name,
-		Type:       v.Type,
-		Path:       v.Path,
+		VariableID:  v.VariableId,
+		Name:        v.Name,
+		Type:        v.Type,
+		Path:        v.Path,
+		FormatValue: toFormatValue(v.FormatValue),
 	}
 	return &result, nil
 }
@@ -54,10 +58,11 @@ func toVariables(variables []*tagmanager.Variable) []Variable {
 	result := make([]Variable, 0, len(variables))
 	for _, v := range variables {
 		result = append(result, Variable{
-			VariableID: v.VariableId,
-			Name:       v.Name,
-			Type:       v.Type,
-			Path:       v.Path,
+			VariableID:  v.VariableId,
+			Name:        v.Name,
+			Type:        v.Type,
+			Path:        v.Path,
+			FormatValue: toFormatValue(v.FormatValue),
 		})
 	}
 	return result
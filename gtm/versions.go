@@ -18,12 +18,13 @@ func (c *Client) CreateVersion(ctx context.Context, accountID, containerID, work
 
 	result, err := c.Service.Accounts.Containers.Workspaces.CreateVersion(parent, req).Context(ctx).Do()
 	if err != nil {
-		return nil, mapGoogleError(err)
+		return nil, MapGoogleError(err)
 	}
 
 	if result == nil || result.ContainerVersion == nil {
 		return nil, fmt.Errorf("no version created - workspace may have no changes")
 	}
+	backupVersion(accountID, containerID, backupEventCreate, result.ContainerVersion)
 
 	return &CreatedVersion{
 		VersionID: result.ContainerVersion.ContainerVersionId,
@@ -39,8 +40,9 @@ func (c *Client) PublishVersion(ctx context.Context, accountID, containerID, ver
 
 	result, err := c.Service.Accounts.Containers.Versions.Publish(path).Context(ctx).Do()
 	if err != nil {
-		return nil, mapGoogleError(err)
+		return nil, MapGoogleError(err)
 	}
+	backupVersion(accountID, containerID, backupEventPublish, result.ContainerVersion)
 
 	return &PublishedVersion{
 		VersionID: result.ContainerVersion.ContainerVersionId,
@@ -49,15 +51,66 @@ func (c *Client) PublishVersion(ctx context.Context, accountID, containerID, ver
 	}, nil
 }
 
+// AppendVersionDescription adds a paragraph to the description (the notes
+// given when it was created) of a container version.
+func (c *Client) AppendVersionDescription(ctx context.Context, accountID, containerID, versionID, text string) error {
+	path := fmt.Sprintf("accounts/%s/containers/%s/versions/%s", accountID, containerID, versionID)
+
+	version, err := RetryWithBackoff(ctx, func() (*tagmanager.ContainerVersion, error) {
+		return c.Service.Accounts.Containers.Versions.Get(path).Context(ctx).Do()
+	})
+	if err != nil {
+		return MapGoogleError(err)
+	}
+
+	if version.Description != "" {
+		version.Description += "\n\n"
+	}
+	version.Description += text
+	if _, err := c.Service.Accounts.Containers.Versions.Update(path, version).Fingerprint(version.Fingerprint).Context(ctx).Do(); err != nil {
+		return MapGoogleError(err)
+	}
+	return nil
+}
+
+// ListVersions returns the headers of every version of a container.
+func (c *Client) ListVersions(ctx context.Context, accountID, containerID string) ([]VersionInfo, error) {
+	parent := BuildContainerPath(accountID, containerID)
+
+	headers, err := listAllPages(ctx, func(pageToken string) (*tagmanager.ListContainerVersionsResponse, error) {
+		return c.Service.Accounts.Containers.VersionHeaders.List(parent).PageToken(pageToken).Context(ctx).Do()
+	}, func(resp *tagmanager.ListContainerVersionsResponse) ([]*tagmanager.ContainerVersionHeader, string) {
+		return resp.ContainerVersionHeader, resp.NextPageToken
+	})
+	if err != nil {
+		return nil, MapGoogleError(err)
+	}
+
+	versions := make([]VersionInfo, 0, len(headers))
+	for _, v := range headers {
+		versions = append(versions, VersionInfo{
+			VersionID:          v.ContainerVersionId,
+			Name:               v.Name,
+			Deleted:            v.Deleted,
+			NumTags:            v.NumTags,
+			NumTriggers:        v.NumTriggers,
+			NumVariables:       v.NumVariables,
+			NumCustomTemplates: v.NumCustomTemplates,
+			Path:               v.Path,
+		})
+	}
+	return versions, nil
+}
+
 // GetWorkspaceStatus checks if a workspace has changes to publish.
 func (c *Client) GetWorkspaceStatus(ctx context.Context, accountID, containerID, workspaceID string) (*WorkspaceStatus, error) {
 	path := BuildWorkspacePath(accountID, containerID, workspaceID)
 
-	status, err := retryWithBackoff(ctx, 3, func() (*tagmanager.GetWorkspaceStatusResponse, error) {
+	status, err := RetryWithBackoff(ctx, func() (*tagmanager.GetWorkspaceStatusResponse, error) {
 		return c.Service.Accounts.Containers.Workspaces.GetStatus(path).Context(ctx).Do()
 	})
 	if err != nil {
-		return nil, mapGoogleError(err)
+		return nil, MapGoogleError(err)
 	}
 
 	return &WorkspaceStatus{
@@ -65,9 +118,53 @@ func (c *Client) GetWorkspaceStatus(ctx context.Context, accountID, containerID,
 		HasConflicts:  len(status.MergeConflict) > 0,
 		ChangeCount:   len(status.WorkspaceChange),
 		ConflictCount: len(status.MergeConflict),
+		Changes:       toWorkspaceChanges(status.WorkspaceChange),
 	}, nil
 }
 
+func toWorkspaceChanges(entities []*tagmanager.Entity) []WorkspaceChange {
+	result := make([]WorkspaceChange, 0, len(entities))
+	for _, e := range entities {
+		change := WorkspaceChange{ChangeStatus: e.ChangeStatus}
+		switch {
+		case e.Tag != nil:
+			change.EntityType, change.EntityID, change.Name = "tag", e.Tag.TagId, e.Tag.Name
+		case e.Trigger != nil:
+			change.EntityType, change.EntityID, change.Name = "trigger", e.Trigger.TriggerId, e.Trigger.Name
+		case e.Variable != nil:
+			change.EntityType, change.EntityID, change.Name = "variable", e.Variable.VariableId, e.Variable.Name
+		case e.Folder != nil:
+			change.EntityType, change.EntityID, change.Name = "folder", e.Folder.FolderId, e.Folder.Name
+		case e.Client != nil:
+			change.EntityType, change.EntityID, change.Name = "client", e.Client.ClientId, e.Client.Name
+		case e.Transformation != nil:
+			change.EntityType, change.EntityID, change.Name = "transformation", e.Transformation.TransformationId, e.Transformation.Name
+		case e.CustomTemplate != nil:
+			change.EntityType, change.EntityID, change.Name = "template", e.CustomTemplate.TemplateId, e.CustomTemplate.Name
+		case e.BuiltInVariable != nil:
+			change.EntityType, change.Name = "builtInVariable", e.BuiltInVariable.Name
+		case e.Zone != nil:
+			change.EntityType, change.EntityID, change.Name = "zone", e.Zone.ZoneId, e.Zone.Name
+		case e.GtagConfig != nil:
+			change.EntityType, change.EntityID = "gtagConfig", e.GtagConfig.GtagConfigId
+		}
+		result = append(result, change)
+	}
+	return result
+}
+
+// VersionInfo is a simplified version header response.
+type VersionInfo struct {
+	VersionID          string `json:"versionId"`
+	Name               string `json:"name,omitempty"`
+	Deleted            bool   `json:"deleted,omitempty"`
+	NumTags            string `json:"numTags,omitempty"`
+	NumTriggers        string `json:"numTriggers,omitempty"`
+	NumVariables       string `json:"numVariables,omitempty"`
+	NumCustomTemplates string `json:"numCustomTemplates,omitempty"`
+	Path               string `json:"path"`
+}
+
 // PublishedVersion represents the result of publishing a version.
 type PublishedVersion struct {
 	VersionID string `json:"containerVersionId"`
@@ -81,4 +178,14 @@ type WorkspaceStatus struct {
 	HasConflicts  bool `json:"hasConflicts"`
 	ChangeCount   int  `json:"changeCount"`
 	ConflictCount int  `json:"conflictCount"`
+
+	Changes []WorkspaceChange `json:"changes,omitempty"`
+}
+
+// WorkspaceChange is a single entity changed in a workspace.
+type WorkspaceChange struct {
+	EntityType   string `json:"entityType"`
+	EntityID     string `json:"entityId,omitempty"`
+	Name         string `json:"name,omitempty"`
+	ChangeStatus string `json:"changeStatus"` // "added", "updated" or "deleted"
 }
@@ -0,0 +1,33 @@
+package gtm
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"testing"
+
+	tagmanager "google.golang.org/api/tagmanager/v2"
+)
+
+func TestAppendVersionDescription(t *testing.T) {
+	var updated tagmanager.ContainerVersion
+	var fingerprint string
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			io.WriteString(w, `{"containerVersionId":"41","description":"Launch","fingerprint":"f1"}`)
+		case http.MethodPut:
+			fingerprint = r.URL.Query().Get("fingerprint")
+			json.NewDecoder(r.Body).Decode(&updated)
+			io.WriteString(w, `{}`)
+		}
+	})
+
+	if err := client.AppendVersionDescription(context.Background(), "1", "2", "41", "Rolled back"); err != nil {
+		t.Fatal(err)
+	}
+	if updated.Description != "Launch\n\nRolled back" || fingerprint != "f1" {
+		t.Errorf("description = %q, fingerprint = %q", updated.Description, fingerprint)
+	}
+}
@@ -0,0 +1,186 @@
+package gtm
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	tagmanager "google.golang.org/api/tagmanager/v2"
+)
+
+// Workspace audit rules.
+const (
+	RuleDuplicateTag           = "duplicate_tag"
+	RuleUnusedTrigger          = "unused_trigger"
+	RuleUnusedVariable         = "unused_variable"
+	RulePausedTag              = "paused_tag"
+	RuleMissingNotes           = "missing_notes"
+	RuleCustomHTMLDocWrite     = "custom_html_document_write"
+	RuleCustomHTMLExternalLoad = "custom_html_external_script"
+	RuleCustomHTMLEval         = "custom_html_eval"
+)
+
+// SeverityRank orders severities from most to least severe.
+var SeverityRank = map[string]int{SeverityError: 0, SeverityWarning: 1, SeverityInfo: 2}
+
+var (
+	documentWritePattern  = regexp.MustCompile(`document\.write(ln)?\s*\(`)
+	externalScriptPattern = regexp.MustCompile(`(?i)<script[^>]+src\s*=\s*["']?(https?:)?//`)
+	evalPattern           = regexp.MustCompile(`\beval\s*\(|new\s+Function\s*\(`)
+)
+
+// WorkspaceFinding is one result of audit_workspace.
+type WorkspaceFinding struct {
+	Rule       string   `json:"rule"`
+	Severity   string   `json:"severity"`
+	EntityType string   `json:"entityType"`
+	EntityID   string   `json:"entityId"`
+	Name       string   `json:"name"`
+	Path       string   `json:"path"`
+	Message    string   `json:"message"`
+	Related    []string `json:"related,omitempty"` // paths of the other entities involved
+}
+
+// AuditWorkspace runs the deterministic workspace checks on a snapshot.
+// Findings are ordered by severity, then rule and entity name.
+func AuditWorkspace(v *tagmanager.ContainerVersion, now time.Time, pausedDays int) []WorkspaceFinding {
+	findings := []WorkspaceFinding{}
+	add := func(rule, severity, entityType, id, name, path, format string, args ...any) *WorkspaceFinding {
+		findings = append(findings, WorkspaceFinding{
+			Rule: rule, Severity: severity, EntityType: entityType,
+			EntityID: id, Name: name, Path: path, Message: fmt.Sprintf(format, args...),
+		})
+		return &findings[len(findings)-1]
+	}
+
+	// Duplicate tags: same type and the same parameters up to whitespace.
+	// Active duplicates on the same triggers fire twice, e.g. double
+	// counting every GA4 event.
+	groups := make(map[string][]*tagmanager.Tag)
+	var signatures []string
+	for _, t := range v.Tag {
+		sig := t.Type + "|" + parameterSignature(t.Parameter)
+		if groups[sig] == nil {
+			signatures = append(signatures, sig)
+		}
+		groups[sig] = append(groups[sig], t)
+	}
+	for _, sig := range signatures {
+		tags := groups[sig]
+		if len(tags) < 2 {
+			continue
+		}
+		first := tags[0]
+		severity := SeverityWarning
+		var related []string
+		for _, t := range tags[1:] {
+			related = append(related, t.Path)
+			if !first.Paused && !t.Paused && sameIDs(first.FiringTriggerId, t.FiringTriggerId) {
+				severity = SeverityError
+			}
+		}
+		message := fmt.Sprintf("%d %s tags have the same parameters", len(tags), first.Type)
+		if severity == SeverityError {
+			message += " and fire on the same triggers, so they send everything twice"
+		}
+		add(RuleDuplicateTag, severity, "tag", first.TagId, first.Name, first.Path, "%s", message).Related = related
+	}
+
+	orphans := FindOrphans(v, now, pausedDays)
+	for _, t := range orphans.Triggers {
+		add(RuleUnusedTrigger, SeverityWarning, "trigger", t.EntityID, t.Name, t.Path, "trigger is not used by any tag or trigger group")
+	}
+	for _, vr := range orphans.Variables {
+		add(RuleUnusedVariable, SeverityWarning, "variable", vr.EntityID, vr.Name, vr.Path, "variable is not referenced by any tag, trigger, variable or client")
+	}
+	longPaused := make(map[string]int, len(orphans.PausedTags))
+	for _, t := range orphans.PausedTags {
+		longPaused[t.EntityID] = t.AgeDays
+	}
+
+	for _, t := range v.Tag {
+		if t.Paused {
+			if days, ok := longPaused[t.TagId]; ok {
+				add(RulePausedTag, SeverityWarning, "tag", t.TagId, t.Name, t.Path, "tag has been paused for %d days; delete it if it is no longer needed", days)
+			} else {
+				add(RulePausedTag, SeverityInfo, "tag", t.TagId, t.Name, t.Path, "tag is paused")
+			}
+		}
+		if strings.TrimSpace(t.Notes) == "" {
+			add(RuleMissingNotes, SeverityInfo, "tag", t.TagId, t.Name, t.Path, "tag has no notes explaining its purpose")
+		}
+		if t.Type != "html" {
+			continue
+		}
+		html := apiParamValue(t.Parameter, "html")
+		if documentWritePattern.MatchString(html) || apiParamValue(t.Parameter, "supportDocumentWrite") == "true" {
+			add(RuleCustomHTMLDocWrite, SeverityWarning, "tag", t.TagId, t.Name, t.Path, "custom HTML uses document.write, which blocks rendering and breaks on asynchronously loaded pages")
+		}
+		if externalScriptPattern.MatchString(html) {
+			add(RuleCustomHTMLExternalLoad, SeverityWarning, "tag", t.TagId, t.Name, t.Path, "custom HTML loads an external script; prefer a community template with declared permissions")
+		}
+		if evalPattern.MatchString(html) {
+			add(RuleCustomHTMLEval, SeverityWarning, "tag", t.TagId, t.Name, t.Path, "custom HTML evaluates code at runtime (eval or new Function)")
+		}
+	}
+
+	sort.SliceStable(findings, func(i, j int) bool {
+		a, b := findings[i], findings[j]
+		if SeverityRank[a.Severity] != SeverityRank[b.Severity] {
+			return SeverityRank[a.Severity] < SeverityRank[b.Severity]
+		}
+		if a.Rule != b.Rule {
+			return a.Rule < b.Rule
+		}
+		return a.Name < b.Name
+	})
+	return findings
+}
+
+// parameterSignature renders parameters in a canonical form: keyed
+// parameters sorted by key and values with whitespace collapsed.
+func parameterSignature(params []*tagmanager.Parameter) string {
+	data, _ := json.Marshal(normalizedParams(params, true))
+	return string(data)
+}
+
+func normalizedParams(params []*tagmanager.Parameter, keyed bool) []any {
+	out := make([]any, 0, len(params))
+	for _, p := range params {
+		out = append(out, []any{p.Key, p.Type, strings.Join(strings.Fields(p.Value), " "),
+			normalizedParams(p.List, false), normalizedParams(p.Map, true)})
+	}
+	if keyed {
+		sort.Slice(out, func(i, j int) bool { return out[i].([]any)[0].(string) < out[j].([]any)[0].(string) })
+	}
+	return out
+}
+
+// sameIDs reports whether a and b hold the same IDs in any order.
+func sameIDs(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	x := append([]string(nil), a...)
+	y := append([]string(nil), b...)
+	sort.Strings(x)
+	sort.Strings(y)
+	for i := range x {
+		if x[i] != y[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// CountSeverities returns the number of findings per severity.
+func CountSeverities(findings []WorkspaceFinding) map[string]int {
+	counts := map[string]int{SeverityError: 0, SeverityWarning: 0, SeverityInfo: 0}
+	for _, f := range findings {
+		counts[f.Severity]++
+	}
+	return counts
+}
@@ -0,0 +1,82 @@
+package gtm
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	tagmanager "google.golang.org/api/tagmanager/v2"
+)
+
+func TestAuditWorkspace(t *testing.T) {
+	now := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	old := fmt.Sprint(now.AddDate(0, 0, -90).UnixMilli())
+	ga4 := func(id, name, value string) *tagmanager.Tag {
+		return &tagmanager.Tag{
+			TagId: id, Name: name, Type: "gaawe", Path: "tags/" + id, Notes: "GA4",
+			FiringTriggerId: []string{"2147479553"},
+			Parameter: []*tagmanager.Parameter{
+				{Type: "template", Key: "measurementIdOverride", Value: "G-1"},
+				{Type: "template", Key: "eventName", Value: value},
+			},
+		}
+	}
+	v := &tagmanager.ContainerVersion{
+		Tag: []*tagmanager.Tag{
+			ga4("1", "GA4 - page_view", "page_view"),
+			ga4("2", "GA4 - page_view copy", " page_view "),
+			ga4("3", "GA4 - signup", "sign_up"),
+			{TagId: "4", Name: "Old pixel", Type: "html", Path: "tags/4", Paused: true, Fingerprint: old,
+				FiringTriggerId: []string{"10"},
+				Parameter: []*tagmanager.Parameter{
+					{Type: "template", Key: "html", Value: `<script src="https://cdn.example.com/px.js"></script><script>document.write('x')</script>`},
+				}},
+		},
+		Trigger: []*tagmanager.Trigger{
+			{TriggerId: "10", Name: "Click", Type: "click", Path: "triggers/10"},
+			{TriggerId: "11", Name: "Unused", Type: "pageview", Path: "triggers/11"},
+		},
+	}
+
+	findings := AuditWorkspace(v, now, DefaultPausedTagDays)
+	got := map[string]WorkspaceFinding{}
+	for _, f := range findings {
+		got[f.Rule+"/"+f.EntityID] = f
+	}
+
+	dup, ok := got[RuleDuplicateTag+"/1"]
+	if !ok || dup.Severity != SeverityError || len(dup.Related) != 1 || dup.Related[0] != "tags/2" {
+		t.Errorf("duplicate finding = %+v", dup)
+	}
+	for key, severity := range map[string]string{
+		RuleUnusedTrigger + "/11":         SeverityWarning,
+		RulePausedTag + "/4":              SeverityWarning,
+		RuleMissingNotes + "/4":           SeverityInfo,
+		RuleCustomHTMLDocWrite + "/4":     SeverityWarning,
+		RuleCustomHTMLExternalLoad + "/4": SeverityWarning,
+	} {
+		if got[key].Severity != severity {
+			t.Errorf("%s: finding = %+v, want severity %s", key, got[key], severity)
+		}
+	}
+	if _, ok := got[RuleDuplicateTag+"/3"]; ok {
+		t.Error("tag with different parameters reported as duplicate")
+	}
+	if _, ok := got[RuleCustomHTMLEval+"/4"]; ok {
+		t.Error("eval reported without eval")
+	}
+	if len(findings) != 6 {
+		t.Errorf("findings = %+v", findings)
+	}
+	if findings[0].Severity != SeverityError || findings[len(findings)-1].Severity != SeverityInfo {
+		t.Errorf("findings not ordered by severity: %+v", findings)
+	}
+
+	// Duplicates on different triggers are only a warning
+	v.Tag[1].FiringTriggerId = []string{"10"}
+	for _, f := range AuditWorkspace(v, now, DefaultPausedTagDays) {
+		if f.Rule == RuleDuplicateTag && f.Severity != SeverityWarning {
+			t.Errorf("duplicate on other triggers: %+v", f)
+		}
+	}
+}
@@ -0,0 +1,98 @@
+package gtm
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"golang.org/x/sync/errgroup"
+	"google.golang.org/api/googleapi"
+	tagmanager "google.golang.org/api/tagmanager/v2"
+)
+
+// WorkspaceSyncState is how a workspace relates to its container's latest
+// version.
+type WorkspaceSyncState struct {
+	// Behind is set when the workspace has not changed since the latest
+	// container version was created, so it cannot contain that version's
+	// changes. A workspace edited after the version but never updated is
+	// not detected.
+	Behind            bool   `json:"behind"`
+	LatestVersionID   string `json:"latestVersionId,omitempty"`
+	LatestVersionName string `json:"latestVersionName,omitempty"`
+	ConflictCount     int    `json:"conflictCount"`
+}
+
+// Warnings returns the sync problems of a workspace as tool output notes.
+func (s *WorkspaceSyncState) Warnings(path string) []string {
+	var warnings []string
+	if s.ConflictCount > 0 {
+		warnings = append(warnings, fmt.Sprintf("Workspace %s has %d merge conflicts. Resolve them in the GTM UI; create_version fails until they are resolved.", path, s.ConflictCount))
+	}
+	if s.Behind {
+		version := s.LatestVersionID
+		if s.LatestVersionName != "" {
+			version += fmt.Sprintf(" (%q)", s.LatestVersionName)
+		}
+		warnings = append(warnings, fmt.Sprintf("Workspace %s has not been updated since container version %s was created, so it is behind the latest version. Update it in the GTM UI before create_version; the newer version's changes may conflict with or be overwritten by this workspace.", path, version))
+	}
+	return warnings
+}
+
+// GetWorkspaceSyncState reports merge conflicts of a workspace and whether
+// it is behind the latest container version. Fingerprints are GTM storage
+// timestamps, so a workspace fingerprint older than the latest version's
+// means the workspace has not been updated since.
+func (c *Client) GetWorkspaceSyncState(ctx context.Context, accountID, containerID, workspaceID string) (*WorkspaceSyncState, error) {
+	var state WorkspaceSyncState
+	var workspaceFingerprint, versionFingerprint string
+	g, ctx := errgroup.WithContext(ctx)
+
+	g.Go(func() error {
+		status, err := c.GetWorkspaceStatus(ctx, accountID, containerID, workspaceID)
+		if err != nil {
+			return err
+		}
+		state.ConflictCount = status.ConflictCount
+		return nil
+	})
+	g.Go(func() error {
+		path := BuildWorkspacePath(accountID, containerID, workspaceID)
+		ws, err := RetryWithBackoff(ctx, func() (*tagmanager.Workspace, error) {
+			return c.Service.Accounts.Containers.Workspaces.Get(path).Fields(googleapi.Field("fingerprint")).Context(ctx).Do()
+		})
+		if err != nil {
+			return MapGoogleError(err)
+		}
+		workspaceFingerprint = ws.Fingerprint
+		return nil
+	})
+	g.Go(func() error {
+		header, err := RetryWithBackoff(ctx, func() (*tagmanager.ContainerVersionHeader, error) {
+			return c.Service.Accounts.Containers.VersionHeaders.Latest(BuildContainerPath(accountID, containerID)).Context(ctx).Do()
+		})
+		if err != nil {
+			return MapGoogleError(err)
+		}
+		if header.ContainerVersionId == "" || header.ContainerVersionId == "0" || header.Deleted {
+			return nil
+		}
+		state.LatestVersionID, state.LatestVersionName = header.ContainerVersionId, header.Name
+		version, err := RetryWithBackoff(ctx, func() (*tagmanager.ContainerVersion, error) {
+			return c.Service.Accounts.Containers.Versions.Get(header.Path).Fields(googleapi.Field("fingerprint")).Context(ctx).Do()
+		})
+		if err != nil {
+			return MapGoogleError(err)
+		}
+		versionFingerprint = version.Fingerprint
+		return nil
+	})
+	if err := g.Wait(); err != nil {
+		return nil, err
+	}
+
+	ws, errWS := strconv.ParseInt(workspaceFingerprint, 10, 64)
+	v, errV := strconv.ParseInt(versionFingerprint, 10, 64)
+	state.Behind = errWS == nil && errV == nil && ws < v
+	return &state, nil
+}
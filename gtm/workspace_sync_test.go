@@ -0,0 +1,44 @@
+package gtm
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestGetWorkspaceSyncState(t *testing.T) {
+	workspaceFingerprint := "1700000000000"
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/tagmanager/v2/accounts/1/containers/2/workspaces/3/status":
+			io.WriteString(w, `{"mergeConflict":[{"entityInWorkspace":{}}]}`)
+		case "/tagmanager/v2/accounts/1/containers/2/workspaces/3":
+			io.WriteString(w, `{"fingerprint":"`+workspaceFingerprint+`"}`)
+		case "/tagmanager/v2/accounts/1/containers/2/version_headers:latest":
+			io.WriteString(w, `{"containerVersionId":"12","name":"Checkout fix","path":"accounts/1/containers/2/versions/12"}`)
+		case "/tagmanager/v2/accounts/1/containers/2/versions/12":
+			io.WriteString(w, `{"fingerprint":"1700000005000"}`)
+		default:
+			http.NotFound(w, r)
+		}
+	})
+
+	state, err := client.GetWorkspaceSyncState(context.Background(), "1", "2", "3")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !state.Behind || state.ConflictCount != 1 || state.LatestVersionID != "12" {
+		t.Errorf("state = %+v", state)
+	}
+	if w := state.Warnings("ws"); len(w) != 2 || !strings.Contains(w[1], `12 ("Checkout fix")`) {
+		t.Errorf("warnings = %q", w)
+	}
+
+	// A workspace changed after the latest version is not behind
+	workspaceFingerprint = "1700000009000"
+	if state, err := client.GetWorkspaceSyncState(context.Background(), "1", "2", "3"); err != nil || state.Behind {
+		t.Errorf("state = %+v, err = %v", state, err)
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 
+	"golang.org/x/sync/errgroup"
 	tagmanager "google.golang.org/api/tagmanager/v2"
 )
 
@@ -13,22 +14,71 @@ type Workspace struct {
 	Name        string `json:"name"`
 	Description string `json:"description,omitempty"`
 	Path        string `json:"path"`
+
+	// Changes is only populated by list_workspaces.
+	Changes *WorkspaceChangeSummary `json:"changes,omitempty"`
+}
+
+// WorkspaceChangeSummary counts the pending changes in a workspace by entity type.
+type WorkspaceChangeSummary struct {
+	Total     int    `json:"total"`
+	Conflicts int    `json:"conflicts"`
+	Tags      int    `json:"tags"`
+	Triggers  int    `json:"triggers"`
+	Variables int    `json:"variables"`
+	Other     int    `json:"other"`
+	Error     string `json:"error,omitempty"`
+}
+
+// SummarizeChanges aggregates a workspace status into per-type change counts.
+func SummarizeChanges(status *WorkspaceStatus) *WorkspaceChangeSummary {
+	summary := &WorkspaceChangeSummary{
+		Total:     status.ChangeCount,
+		Conflicts: status.ConflictCount,
+	}
+	for _, ch := range status.Changes {
+		switch ch.EntityType {
+		case "tag":
+			summary.Tags++
+		case "trigger":
+			summary.Triggers++
+		case "variable":
+			summary.Variables++
+		default:
+			summary.Other++
+		}
+	}
+	return summary
 }
 
 // ListWorkspaces returns all workspaces in a container.
 func (c *Client) ListWorkspaces(ctx context.Context, accountID, containerID string) ([]Workspace, error) {
 	parent := fmt.Sprintf("accounts/%s/containers/%s", accountID, containerID)
 
-	resp, err := retryWithBackoff(ctx, 3, func() (*tagmanager.ListWorkspacesResponse, error) {
+	resp, err := RetryWithBackoff(ctx, func() (*tagmanager.ListWorkspacesResponse, error) {
 		return c.Service.Accounts.Containers.Workspaces.List(parent).Context(ctx).Do()
 	})
 	if err != nil {
-		return nil, mapGoogleError(err)
+		return nil, MapGoogleError(err)
 	}
 
 	return toWorkspaces(resp.Workspace), nil
 }
 
+// CreateWorkspace creates a new workspace in a container.
+func (c *Client) CreateWorkspace(ctx context.Context, accountID, containerID, name, description string) (*tagmanager.Workspace, error) {
+	workspace := &tagmanager.Workspace{
+		Name:        name,
+		Description: description,
+	}
+
+	created, err := c.Service.Accounts.Containers.Workspaces.Create(BuildContainerPath(accountID, containerID), workspace).Context(ctx).Do()
+	if err != nil {
+		return nil, MapGoogleError(err)
+	}
+	return created, nil
+}
+
 func toWorkspaces(workspaces []*tagmanager.Workspace) []Workspace {
 	result := make([]Workspace, 0, len(workspaces))
 	for _, w := range workspaces {
@@ -41,3 +91,47 @@ func toWorkspaces(workspaces []*tagmanager.Workspace) []Workspace {
 	}
 	return result
 }
+
+// WorkspaceEntities are the tags, triggers and variables of a workspace.
+type WorkspaceEntities struct {
+	Tags      []Tag
+	Triggers  []Trigger
+	Variables []Variable
+}
+
+// ListWorkspaceEntities fetches the tags, triggers and variables of a
+// workspace concurrently. The first failure cancels the other requests.
+func (c *Client) ListWorkspaceEntities(ctx context.Context, accountID, containerID, workspaceID string) (*WorkspaceEntities, error) {
+	var entities WorkspaceEntities
+	g, ctx := errgroup.WithContext(ctx)
+
+	g.Go(func() error {
+		tags, err := c.ListTags(ctx, accountID, containerID, workspaceID)
+		if err != nil {
+			return fmt.Errorf("failed to list tags: %w", err)
+		}
+		entities.Tags = tags
+		return nil
+	})
+	g.Go(func() error {
+		triggers, err := c.ListTriggers(ctx, accountID, containerID, workspaceID)
+		if err != nil {
+			return fmt.Errorf("failed to list triggers: %w", err)
+		}
+		entities.Triggers = triggers
+		return nil
+	})
+	g.Go(func() error {
+		variables, err := c.ListVariables(ctx, accountID, containerID, workspaceID)
+		if err != nil {
+			return fmt.Errorf("failed to list variables: %w", err)
+		}
+		entities.Variables = variables
+		return nil
+	})
+
+	if err := g.Wait(); err != nil {
+		return nil, err
+	}
+	return &entities, nil
+}
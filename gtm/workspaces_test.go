@@ -0,0 +1,91 @@
+package gtm
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"google.golang.org/api/option"
+	tagmanager "google.golang.org/api/tagmanager/v2"
+)
+
+func TestSummarizeChanges(t *testing.T) {
+	summary := SummarizeChanges(&WorkspaceStatus{
+		ChangeCount:   4,
+		ConflictCount: 1,
+		Changes: []WorkspaceChange{
+			{EntityType: "tag"},
+			{EntityType: "tag"},
+			{EntityType: "variable"},
+			{EntityType: "folder"},
+		},
+	})
+
+	if summary.Total != 4 || summary.Conflicts != 1 || summary.Tags != 2 ||
+		summary.Triggers != 0 || summary.Variables != 1 || summary.Other != 1 {
+		t.Errorf("unexpected summary: %+v", summary)
+	}
+}
+
+// newTestClient returns a client whose GTM API requests go to handler.
+func newTestClient(t *testing.T, handler http.HandlerFunc) *Client {
+	t.Helper()
+	api := httptest.NewServer(handler)
+	t.Cleanup(api.Close)
+
+	service, err := tagmanager.NewService(context.Background(), option.WithEndpoint(api.URL), option.WithHTTPClient(api.Client()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &Client{Service: service, user: t.Name(), http: api.Client()}
+}
+
+func TestListWorkspaceEntities_Concurrent(t *testing.T) {
+	// Each list blocks until all three requests are in flight
+	var inFlight sync.WaitGroup
+	inFlight.Add(3)
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		inFlight.Done()
+		inFlight.Wait()
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/tags"):
+			io.WriteString(w, `{"tag":[{"tagId":"1","name":"GA4"}]}`)
+		case strings.HasSuffix(r.URL.Path, "/triggers"):
+			io.WriteString(w, `{"trigger":[{"triggerId":"2","name":"All Pages"}]}`)
+		default:
+			io.WriteString(w, `{"variable":[{"variableId":"3","name":"Measurement ID"}]}`)
+		}
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	entities, err := client.ListWorkspaceEntities(ctx, "1", "2", "3")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entities.Tags) != 1 || len(entities.Triggers) != 1 || len(entities.Variables) != 1 {
+		t.Errorf("entities = %+v", entities)
+	}
+}
+
+func TestListWorkspaceEntities_Error(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, "/triggers") {
+			w.WriteHeader(http.StatusNotFound)
+			io.WriteString(w, `{"error":{"code":404,"message":"workspace not found"}}`)
+			return
+		}
+		io.WriteString(w, `{}`)
+	})
+
+	_, err := client.ListWorkspaceEntities(context.Background(), "1", "2", "3")
+	if !errors.Is(err, ErrNotFound) || !strings.Contains(err.Error(), "failed to list triggers") {
+		t.Errorf("error = %v", err)
+	}
+}
@@ -3,18 +3,22 @@ package main
 import (
 	"context"
 	"encoding/json"
+	"errors"
+	"flag"
 	"fmt"
 	"log/slog"
 	"net/http"
 	"os"
 	"os/signal"
+	"strings"
 	"syscall"
 	"time"
 
-	"gtm-mcp-server/auth"
-	"gtm-mcp-server/config"
-	"gtm-mcp-server/gtm"
-	"gtm-mcp-server/middleware"
+	"github.com/paolobietolini/gtm-mcp-server/auth"
+	"github.com/paolobietolini/gtm-mcp-server/config"
+	"github.com/paolobietolini/gtm-mcp-server/gtm"
+	"github.com/paolobietolini/gtm-mcp-server/middleware"
+	"github.com/paolobietolini/gtm-mcp-server/tools"
 
 	"github.com/modelcontextprotocol/go-sdk/mcp"
 )
@@ -25,10 +29,20 @@ const (
 )
 
 func main() {
-	// Set up structured logging to stderr (stdout is reserved for MCP in stdio mode)
-	logger := slog.New(slog.NewJSONHandler(os.Stderr, &slog.HandlerOptions{
-		Level: slog.LevelInfo,
-	}))
+	stdio := flag.Bool("stdio", false, "serve MCP over stdin/stdout for a single local user")
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "usage: %s [--stdio]\n       %s call <tool> [--json '{...}']\n\n", os.Args[0], os.Args[0])
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+	call := flag.Arg(0) == "call"
+
+	// Set up structured logging to stderr (stdout is reserved for MCP in stdio mode),
+	// tagging lines logged during an MCP request with its request ID
+	logger := slog.New(middleware.NewRequestIDHandler(slog.NewJSONHandler(os.Stderr, &slog.HandlerOptions{
+		Level:       slog.LevelInfo,
+		ReplaceAttr: middleware.RedactAttr,
+	})))
 	slog.SetDefault(logger)
 
 	// Load configuration
@@ -38,13 +52,32 @@ func main() {
 		os.Exit(1)
 	}
 
+	// Mask credentials, code parameters and configured keys in every log line
+	middleware.SetRedactedKeys(cfg.LogRedactKeys)
+
 	// Adjust log level
 	if cfg.LogLevel == "debug" {
-		logger = slog.New(slog.NewJSONHandler(os.Stderr, &slog.HandlerOptions{
-			Level: slog.LevelDebug,
-		}))
+		logger = slog.New(middleware.NewRequestIDHandler(slog.NewJSONHandler(os.Stderr, &slog.HandlerOptions{
+			Level:       slog.LevelDebug,
+			ReplaceAttr: middleware.RedactAttr,
+		})))
 		slog.SetDefault(logger)
+	} else if call {
+		// Keep stderr to warnings and errors when a script calls a tool
+		logger = slog.New(middleware.NewRequestIDHandler(slog.NewJSONHandler(os.Stderr, &slog.HandlerOptions{
+			Level:       slog.LevelWarn,
+			ReplaceAttr: middleware.RedactAttr,
+		})))
+		slog.SetDefault(logger)
+	}
+
+	// Google scopes requested by every OAuth flow and credential
+	googleScopes, err := auth.ParseGoogleScopes(cfg.GoogleScopes)
+	if err != nil {
+		logger.Error("invalid GOOGLE_SCOPES", "error", err)
+		os.Exit(1)
 	}
+	auth.SetGoogleScopes(googleScopes)
 
 	// Create MCP server
 	server := mcp.NewServer(&mcp.Implementation{
@@ -52,22 +85,59 @@ func main() {
 		Version: serverVersion,
 	}, nil)
 
-	// Add logging middleware
-	server.AddReceivingMiddleware(middleware.NewLoggingMiddleware(logger))
+	// Assign each request an ID, then log it
+	server.AddReceivingMiddleware(middleware.NewRequestIDMiddleware(), middleware.NewLoggingMiddleware(logger))
+
+	// Optional scheduled publishing, run below with a stored credential
+	var publishScheduler *gtm.PublishScheduler
+	if cfg.PublishScheduleFile != "" {
+		publishScheduler, err = gtm.NewPublishScheduler(cfg.PublishScheduleFile, logger)
+		if err != nil {
+			logger.Error("failed to load publish schedule", "error", err)
+			os.Exit(1)
+		}
+	}
+
+	// Scripts read the whole output of a call, not continuation pages
+	if call {
+		cfg.MaxToolOutputBytes = 0
+	}
 
 	// Register tools
-	registerTools(server)
+	if err := registerTools(server, cfg, publishScheduler, logger); err != nil {
+		logger.Error("failed to register tools", "error", err)
+		os.Exit(1)
+	}
+
+	// CLI mode: invoke one tool with the local user's credentials and exit
+	if call {
+		os.Exit(runCall(server, cfg, flag.Args()[1:]))
+	}
+
+	// Local mode: MCP over stdin/stdout with the user's own Google login
+	if *stdio {
+		if err := runStdio(server, cfg, publishScheduler, logger); err != nil && err != context.Canceled {
+			logger.Error("stdio server error", "error", err)
+			os.Exit(1)
+		}
+		return
+	}
 
 	// Create HTTP handler for MCP
 	mcpHandler := mcp.NewStreamableHTTPHandler(func(r *http.Request) *mcp.Server {
 		return server
 	}, nil)
 
+	// MCP endpoint with per-token and per-client rate limits and a body size
+	// limit; the limits key on the token info added by the auth middleware
+	mcpEndpoint := rateLimitMCP(cfg, maxBytesHandler(5<<20, mcpHandler))
+
 	// Set up HTTP routes
 	mux := http.NewServeMux()
 
-	// Health check endpoint (no auth required)
-	mux.HandleFunc("GET /health", func(w http.ResponseWriter, r *http.Request) {
+	// Liveness endpoint (no auth required); /health is kept for existing
+	// health checks
+	healthHandler := func(w http.ResponseWriter, r *http.Request) {
 		w.Header().Set("Content-Type", "application/json")
 		w.WriteHeader(http.StatusOK)
 		json.NewEncoder(w).Encode(map[string]string{
@@ -75,7 +145,9 @@ func main() {
 			"service": serverName,
 			"version": serverVersion,
 		})
-	})
+	}
+	mux.HandleFunc("GET /healthz", healthHandler)
+	mux.HandleFunc("GET /health", healthHandler)
 
 	// OAuth metadata endpoints (always served, no auth required)
 	// RFC 9728: Protected Resource Metadata - tells clients where to find the authorization server
@@ -94,15 +166,82 @@ func main() {
 	oauthLimiter := middleware.NewRateLimiter(10, 20)   // 10 req/s, burst 20
 	registerLimiter := middleware.NewRateLimiter(2, 5)   // 2 req/s, burst 5
 
-	if oauthConfigured {
-		// Set up OAuth
-		tokenStore = auth.NewMemoryTokenStore()
-		googleProvider := auth.NewGoogleProvider(
+	// The Google client is also needed without the OAuth server by API keys
+	// holding refresh tokens
+	var googleProvider *auth.GoogleProvider
+	if cfg.ValidateGoogleClient() == nil {
+		googleProvider = auth.NewGoogleProvider(
 			cfg.GoogleClientID,
 			cfg.GoogleClientSecret,
 			cfg.BaseURL+"/oauth/callback",
 		)
+	}
+
+	// Optional static API keys for internal automation
+	var apiKeys *auth.APIKeyStore
+	if cfg.APIKeysFile != "" || len(cfg.MCPAPIKeys) > 0 {
+		apiKeys, err = loadAPIKeys(cfg, googleProvider)
+		if err != nil {
+			logger.Error("failed to load API keys", "error", err)
+			os.Exit(1)
+		}
+		logger.Info("API key authentication enabled", "keys", apiKeys.Len(), "header", auth.APIKeyHeader)
+	}
+
+	// Optional scheduled audits and backups served as gtm://digest/latest
+	var digestScheduler *gtm.DigestScheduler
+	if cfg.DigestFile != "" {
+		digestCfg, err := gtm.LoadDigestConfig(cfg.DigestFile)
+		if err != nil {
+			logger.Error("failed to load digest config", "error", err)
+			os.Exit(1)
+		}
+		key := apiKeys.ByName(digestCfg.APIKey)
+		if key == nil {
+			logger.Error("digest api_key not found in API_KEYS_FILE", "key_name", digestCfg.APIKey)
+			os.Exit(1)
+		}
+		digestScheduler = gtm.NewDigestScheduler(digestCfg, key.TokenSource(), logger)
+		logger.Info("scheduled digest enabled", "containers", len(digestCfg.Containers), "interval", digestCfg.Interval)
+	}
+
+	// Scheduled publishes run with an API key's credential, since the
+	// session that scheduled them may be gone by then
+	var publishKey *auth.APIKey
+	if publishScheduler != nil {
+		publishKey = apiKeys.ByName(cfg.PublishScheduleAPIKey)
+		if publishKey == nil {
+			logger.Error("PUBLISH_SCHEDULE_API_KEY must name a key in API_KEYS_FILE or MCP_API_KEYS", "key_name", cfg.PublishScheduleAPIKey)
+			os.Exit(1)
+		}
+		logger.Info("scheduled publishing enabled", "file", cfg.PublishScheduleFile, "key_name", publishKey.Name)
+	}
+
+	if oauthConfigured {
+		// Set up OAuth
+		tokenStore = auth.NewMemoryTokenStore()
+
+		// Optional stateless JWT access tokens for multi-node deployments
+		var jwtSigner *auth.JWTSigner
+		switch cfg.AccessTokenFormat {
+		case "opaque":
+		case "jwt":
+			jwtSigner, err = auth.NewJWTSigner(cfg.JWTSecret, cfg.BaseURL)
+			if err != nil {
+				logger.Error("failed to configure JWT access tokens", "error", err)
+				os.Exit(1)
+			}
+			tokenStore = auth.NewJWTTokenStore(tokenStore, jwtSigner)
+			logger.Info("JWT access tokens enabled")
+		default:
+			logger.Error("invalid ACCESS_TOKEN_FORMAT", "value", cfg.AccessTokenFormat, "valid", "opaque, jwt")
+			os.Exit(1)
+		}
+
 		authServer = auth.NewServer(cfg.BaseURL, googleProvider, tokenStore, logger)
+		if jwtSigner != nil {
+			authServer.UseJWT(jwtSigner)
+		}
 
 		// OAuth endpoints with rate limiting and body size limits
 		mux.HandleFunc("GET /authorize", oauthLimiter.MiddlewareFunc(authServer.AuthorizeHandler))
@@ -112,8 +251,8 @@ func main() {
 
 		// MCP endpoint with REQUIRED auth middleware and body size limit
 		// Returns 401 if no valid Bearer token - triggers Claude's OAuth flow
-		authMiddleware := auth.Middleware(tokenStore, googleProvider, logger, cfg.BaseURL)
-		mux.Handle("/", authMiddleware(maxBytesHandler(5<<20, mcpHandler)))
+		authMiddleware := auth.Middleware(tokenStore, googleProvider, apiKeys, logger, cfg.BaseURL)
+		mux.Handle("/", authMiddleware(mcpEndpoint))
 
 		logger.Info("OAuth configured",
 			"authorize_endpoint", cfg.BaseURL+"/authorize",
@@ -140,10 +279,19 @@ func main() {
 		mux.HandleFunc("POST /token", oauthLimiter.MiddlewareFunc(oauthNotConfiguredHandler))
 		mux.HandleFunc("POST /register", registerLimiter.MiddlewareFunc(oauthNotConfiguredHandler))
 
-		// MCP endpoint without auth (still apply body size limit)
-		mux.Handle("/", maxBytesHandler(5<<20, mcpHandler))
+		if apiKeys != nil {
+			// MCP endpoint restricted to API key clients
+			mux.Handle("/", auth.APIKeyMiddleware(apiKeys, logger, cfg.BaseURL)(mcpEndpoint))
+		} else {
+			// MCP endpoint without auth (still apply body size limit)
+			mux.Handle("/", mcpEndpoint)
+		}
 	}
 
+	// Readiness endpoint (no auth required): fails while this instance
+	// cannot serve GTM tools
+	mux.HandleFunc("GET /readyz", readinessHandler(oauthConfigured, apiKeys != nil, tokenStore, gtm.NewAPIProbe()))
+
 	// Create HTTP server
 	addr := fmt.Sprintf(":%d", cfg.Port)
 	httpServer := &http.Server{
@@ -159,6 +307,13 @@ func main() {
 	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
 	defer stop()
 
+	if digestScheduler != nil {
+		go digestScheduler.Run(ctx)
+	}
+	if publishScheduler != nil {
+		go publishScheduler.Run(ctx, publishKey.TokenSource())
+	}
+
 	// Start server
 	go func() {
 		logger.Info("starting GTM MCP server",
@@ -186,10 +341,174 @@ func main() {
 	logger.Info("server stopped")
 }
 
+// runStdio serves MCP over stdin/stdout. Google OAuth runs through a
+// loopback redirect on first use and the refresh token is kept in a local
+// credentials file, which also publishes scheduled versions.
+func runStdio(server *mcp.Server, cfg *config.Config, publishScheduler *gtm.PublishScheduler, logger *slog.Logger) error {
+	if err := cfg.ValidateGoogleClient(); err != nil {
+		return fmt.Errorf("stdio mode: %w", err)
+	}
+	tokenSource, credentialsFile, err := localTokenSource(cfg)
+	if err != nil {
+		return err
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	if publishScheduler != nil {
+		go publishScheduler.Run(ctx, tokenSource)
+	}
+
+	logger.Info("starting GTM MCP server on stdio", "file", credentialsFile)
+	return server.Run(auth.LocalContext(ctx, tokenSource), &mcp.StdioTransport{})
+}
+
+// localTokenSource returns the Google token source of the local user, kept
+// in GTM_CREDENTIALS_FILE or the default credentials file, and the file's
+// path.
+func localTokenSource(cfg *config.Config) (*auth.LocalTokenSource, string, error) {
+	credentialsFile := cfg.CredentialsFile
+	if credentialsFile == "" {
+		var err error
+		if credentialsFile, err = auth.DefaultCredentialsFile(); err != nil {
+			return nil, "", fmt.Errorf("failed to locate credentials file: %w", err)
+		}
+	}
+	return auth.NewLocalTokenSource(cfg.GoogleClientID, cfg.GoogleClientSecret, credentialsFile, slog.Default()), credentialsFile, nil
+}
+
+// loadAPIKeys combines the keys of API_KEYS_FILE and MCP_API_KEYS.
+func loadAPIKeys(cfg *config.Config, googleProvider *auth.GoogleProvider) (*auth.APIKeyStore, error) {
+	var keys []*auth.APIKey
+	if cfg.APIKeysFile != "" {
+		fileKeys, err := auth.ReadAPIKeyFile(cfg.APIKeysFile)
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, fileKeys...)
+	}
+	inlineKeys, err := auth.ParseInlineAPIKeys(cfg.MCPAPIKeys)
+	if err != nil {
+		return nil, err
+	}
+	return auth.NewAPIKeyStore(append(keys, inlineKeys...), googleProvider)
+}
+
 // registerTools adds MCP tools to the server.
-func registerTools(server *mcp.Server) {
-	registerUtilityTools(server)
-	gtm.RegisterTools(server)
+func registerTools(server *mcp.Server, cfg *config.Config, publishScheduler *gtm.PublishScheduler, logger *slog.Logger) error {
+	registerUtilityTools(server, cfg.ReadOnly)
+	gtm.SetSensitiveKeys(cfg.SensitiveParamKeys)
+	gtm.SetQuota(gtm.QuotaOptions{
+		PerMinute:     cfg.GTMQuotaPerMinute,
+		MaxConcurrent: cfg.GTMMaxConcurrent,
+		MaxWait:       time.Duration(cfg.GTMQuotaMaxWait) * time.Second,
+	})
+	gtm.SetRetryPolicy(gtm.RetryPolicy{
+		MaxRetries: cfg.GTMMaxRetries,
+		BaseDelay:  time.Duration(cfg.GTMRetryBaseDelayMs) * time.Millisecond,
+		MaxDelay:   time.Duration(cfg.GTMRetryMaxDelay) * time.Second,
+	})
+	gtm.SetListCacheTTL(time.Duration(cfg.ListCacheTTL) * time.Second)
+	// Log GTM API requests and responses when GTM_DEBUG is set, except in
+	// production
+	if os.Getenv("GTM_DEBUG") != "" {
+		if baseURL := os.Getenv("BASE_URL"); baseURL != "" && !strings.Contains(baseURL, "localhost") && !strings.Contains(baseURL, "127.0.0.1") {
+			logger.Warn("GTM_DEBUG ignored in production", "base_url", baseURL)
+		} else {
+			logger.Warn("GTM_DEBUG is enabled — HTTP bodies will be logged (credentials and sensitive parameters redacted)")
+			gtm.SetTransportWrapper(middleware.NewLoggingTransport)
+		}
+	}
+	if cfg.GitHubBackupRepo != "" {
+		token := cfg.GitHubBackupToken
+		if token == "" {
+			token = cfg.GitHubToken
+		}
+		backup, err := gtm.NewGitHubBackup(gtm.GitHubBackupOptions{
+			Repository: cfg.GitHubBackupRepo,
+			Branch:     cfg.GitHubBackupBranch,
+			Dir:        cfg.GitHubBackupDir,
+			Token:      token,
+			Containers: cfg.GitHubBackupContainers,
+		}, logger)
+		if err != nil {
+			return err
+		}
+		gtm.SetGitHubBackup(backup)
+		if len(cfg.GitHubBackupContainers) == 0 {
+			logger.Warn("GitHub version backup covers every container versioned through the server; set GITHUB_BACKUP_CONTAINERS to limit it", "repository", cfg.GitHubBackupRepo)
+		}
+		logger.Info("GitHub version backup enabled", "repository", cfg.GitHubBackupRepo, "containers", len(cfg.GitHubBackupContainers))
+	}
+
+	audit, err := tools.NewAuditLog(tools.AuditOptions{File: cfg.AuditLogFile, WebhookURL: cfg.AuditWebhookURL}, logger)
+	if err != nil {
+		return err
+	}
+
+	opts := tools.ToolOptions{
+		Profile:        cfg.ToolProfile,
+		ReservedNames:  utilityToolNames,
+		DisabledTools:  cfg.DisabledTools,
+		ReadOnly:       cfg.ReadOnly,
+		GoogleScopes:   auth.GoogleScopes,
+		Audit:          audit,
+		MaxOutputBytes: cfg.MaxToolOutputBytes,
+		Scheduler:      publishScheduler,
+	}
+	if cfg.ApprovalMode {
+		opts.Approvals = tools.NewApprovalQueue(tools.ApprovalOptions{
+			TTL:        time.Duration(cfg.ApprovalTTL) * time.Second,
+			Approvers:  cfg.ApprovalClientIDs,
+			WebhookURL: cfg.ApprovalWebhookURL,
+		}, logger)
+		logger.Info("approval mode enabled", "approvers", len(cfg.ApprovalClientIDs), "webhook", cfg.ApprovalWebhookURL != "")
+	}
+	if cfg.ToolOverridesFile != "" {
+		overrides, err := tools.LoadToolOverrides(cfg.ToolOverridesFile)
+		if err != nil {
+			return err
+		}
+		opts.Overrides = overrides
+	}
+	if cfg.BlueprintDir != "" {
+		blueprints, err := gtm.LoadBlueprints(cfg.BlueprintDir)
+		if err != nil {
+			return err
+		}
+		opts.Blueprints = blueprints
+		logger.Info("blueprints loaded", "count", len(blueprints))
+	}
+	if cfg.NamingRulesFile != "" {
+		rules, err := gtm.LoadNamingRules(cfg.NamingRulesFile)
+		if err != nil {
+			return err
+		}
+		opts.NamingRules = rules
+	}
+	if cfg.ContextFile != "" {
+		contexts, err := tools.LoadWorkspaceContexts(cfg.ContextFile)
+		if err != nil {
+			return err
+		}
+		opts.WorkspaceContexts = contexts
+		logger.Info("workspace contexts loaded", "aliases", len(contexts.Aliases), "default", contexts.Default)
+	}
+	opts.Gallery = gtm.NewGalleryIndex(cfg.GitHubToken)
+	return tools.RegisterTools(server, opts)
+}
+
+// rateLimitMCP wraps the MCP handler with the configured per-token and
+// per-client rate limits. A limit of 0 disables it.
+func rateLimitMCP(cfg *config.Config, next http.Handler) http.Handler {
+	if cfg.TokenRateLimit > 0 {
+		next = middleware.NewKeyedRateLimiter(cfg.TokenRateLimit, cfg.TokenRateBurst, middleware.TokenKey).Middleware(next)
+	}
+	if cfg.ClientRateLimit > 0 {
+		next = middleware.NewKeyedRateLimiter(cfg.ClientRateLimit, cfg.ClientRateBurst, middleware.ClientKey).Middleware(next)
+	}
+	return next
 }
 
 // maxBytesHandler wraps an http.Handler with a request body size limit.
@@ -202,8 +521,66 @@ func maxBytesHandler(maxBytes int64, next http.Handler) http.Handler {
 	})
 }
 
-// registerUtilityTools adds ping and auth_status tools.
-func registerUtilityTools(server *mcp.Server) {
+// readinessHandler reports whether this instance can serve GTM tools: the
+// token store answers and the Tag Manager API is reachable. It responds 503
+// with the failing checks otherwise. Running without OAuth or API keys is a
+// deliberate choice, e.g. behind an authenticating proxy, and stays ready.
+func readinessHandler(oauthConfigured, apiKeysConfigured bool, tokenStore auth.TokenStore, gtmAPI *gtm.APIProbe) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		checks := map[string]string{}
+		ready := true
+		check := func(name string, err error) {
+			if err != nil {
+				checks[name] = err.Error()
+				ready = false
+				return
+			}
+			checks[name] = "ok"
+		}
+
+		switch {
+		case oauthConfigured:
+			check("auth", nil)
+		case apiKeysConfigured:
+			checks["auth"] = "ok (API keys only)"
+		default:
+			checks["auth"] = "ok (unauthenticated)"
+		}
+
+		if tokenStore != nil {
+			// An unknown client is the store's normal answer to the probe
+			_, err := tokenStore.GetClient("readyz-probe")
+			if errors.Is(err, auth.ErrClientNotFound) {
+				err = nil
+			}
+			check("token_store", err)
+		} else {
+			checks["token_store"] = "not used"
+		}
+
+		check("gtm_api", gtmAPI.Check(r.Context()))
+
+		status, code := "ready", http.StatusOK
+		if !ready {
+			status, code = "not ready", http.StatusServiceUnavailable
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(code)
+		json.NewEncoder(w).Encode(map[string]any{
+			"status":  status,
+			"service": serverName,
+			"version": serverVersion,
+			"checks":  checks,
+		})
+	}
+}
+
+// utilityToolNames are the tools registerUtilityTools adds, which tool
+// override aliases may not reuse.
+var utilityToolNames = []string{"ping", "auth_status", "disconnect"}
+
+// registerUtilityTools adds ping, auth_status and disconnect tools.
+func registerUtilityTools(server *mcp.Server, readOnly bool) {
 	// Ping tool for testing connectivity
 	type PingInput struct {
 		Message string `json:"message,omitempty" jsonschema:"Optional message to echo back"`
@@ -228,6 +605,7 @@ func registerUtilityTools(server *mcp.Server) {
 	type AuthStatusInput struct{}
 	type AuthStatusOutput struct {
 		Authenticated bool   `json:"authenticated"`
+		ReadOnly      bool   `json:"readOnly"`
 		Message       string `json:"message"`
 	}
 
@@ -236,12 +614,34 @@ func registerUtilityTools(server *mcp.Server) {
 		Description: "Check authentication status with Google Tag Manager",
 	}, func(ctx context.Context, req *mcp.CallToolRequest, input AuthStatusInput) (*mcp.CallToolResult, AuthStatusOutput, error) {
 		tokenInfo := auth.GetTokenInfo(ctx)
-		output := AuthStatusOutput{Authenticated: tokenInfo != nil}
-		if tokenInfo != nil {
+		output := AuthStatusOutput{Authenticated: tokenInfo != nil, ReadOnly: readOnly || auth.IsReadOnly(ctx)}
+		if tokenInfo != nil && output.ReadOnly {
+			output.Message = "You are authenticated with read-only access: list, get and search tools work, tools that change containers do not"
+		} else if tokenInfo != nil {
 			output.Message = "You are authenticated and can access GTM data"
 		} else {
 			output.Message = "Not authenticated. GTM tools will require authentication."
 		}
 		return nil, output, nil
 	})
+
+	// Disconnect tool to end the session from the chat
+	type DisconnectInput struct{}
+	type DisconnectOutput struct {
+		Success bool   `json:"success"`
+		Message string `json:"message"`
+	}
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "disconnect",
+		Description: "End the current session: deletes this connection's access token and revokes the server's access to your Google Tag Manager account. GTM tools fail afterwards until you reconnect and sign in again.",
+	}, func(ctx context.Context, req *mcp.CallToolRequest, input DisconnectInput) (*mcp.CallToolResult, DisconnectOutput, error) {
+		if err := auth.Disconnect(ctx); err != nil {
+			return nil, DisconnectOutput{}, err
+		}
+		return nil, DisconnectOutput{
+			Success: true,
+			Message: "Disconnected. Google access has been revoked; reconnect to sign in again.",
+		}, nil
+	})
 }
@@ -2,8 +2,10 @@ package middleware
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"log/slog"
+	"strings"
 	"time"
 
 	"github.com/modelcontextprotocol/go-sdk/mcp"
@@ -11,7 +13,11 @@ import (
 
 // NewLoggingMiddleware creates MCP-level logging middleware that logs
 // all incoming requests and their results. For tools/call requests,
-// it extracts and logs the tool name for audit purposes.
+// it extracts and logs the tool name for audit purposes, and the error
+// of calls that fail. Lines are logged with the request context, so they
+// carry the request ID set by NewRequestIDMiddleware. At debug level the
+// arguments of tool calls are logged too, with sensitive values redacted
+// (see RedactValue).
 func NewLoggingMiddleware(logger *slog.Logger) mcp.Middleware {
 	return func(next mcp.MethodHandler) mcp.MethodHandler {
 		return func(ctx context.Context, method string, req mcp.Request) (mcp.Result, error) {
@@ -35,7 +41,10 @@ func NewLoggingMiddleware(logger *slog.Logger) mcp.Middleware {
 				attrs = append(attrs, "tool", toolName)
 			}
 
-			logger.Info("mcp request", attrs...)
+			logger.InfoContext(ctx, "mcp request", attrs...)
+			if ctr, ok := req.(*mcp.CallToolRequest); ok && len(ctr.Params.Arguments) > 0 && logger.Enabled(ctx, slog.LevelDebug) {
+				logger.DebugContext(ctx, "tool call arguments", append(attrs, "arguments", json.RawMessage(RedactJSON(ctr.Params.Arguments)))...)
+			}
 
 			result, err := next(ctx, method, req)
 
@@ -45,12 +54,14 @@ func NewLoggingMiddleware(logger *slog.Logger) mcp.Middleware {
 			if err != nil {
 				// Context cancellation is not an error - don't log when client disconnects
 				if !errors.Is(err, context.Canceled) && !errors.Is(err, context.DeadlineExceeded) {
-					logger.Error("mcp request failed",
+					logger.ErrorContext(ctx, "mcp request failed",
 						append(attrs, "error", err.Error())...,
 					)
 				}
+			} else if res, ok := result.(*mcp.CallToolResult); ok && res.IsError {
+				logger.WarnContext(ctx, "tool call failed", append(attrs, "error", toolErrorText(res))...)
 			} else {
-				logger.Info("mcp request completed", attrs...)
+				logger.InfoContext(ctx, "mcp request completed", attrs...)
 			}
 
 			return result, err
@@ -65,3 +76,14 @@ func extractToolName(req mcp.Request) string {
 	}
 	return ""
 }
+
+// toolErrorText returns the text content of a failed tool call.
+func toolErrorText(res *mcp.CallToolResult) string {
+	var parts []string
+	for _, c := range res.Content {
+		if text, ok := c.(*mcp.TextContent); ok {
+			parts = append(parts, text.Text)
+		}
+	}
+	return strings.Join(parts, "\n")
+}
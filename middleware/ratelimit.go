@@ -6,17 +6,24 @@ import (
 	"sync"
 	"time"
 
+	"github.com/paolobietolini/gtm-mcp-server/auth"
+
 	"golang.org/x/time/rate"
 )
 
 const maxVisitors = 10000
 
-// RateLimiter provides per-IP rate limiting for HTTP endpoints.
+// KeyFunc returns the key a request is rate limited by.
+type KeyFunc func(r *http.Request) string
+
+// RateLimiter provides per-key rate limiting for HTTP endpoints. Requests are
+// keyed by client IP unless another KeyFunc is given.
 type RateLimiter struct {
 	mu       sync.Mutex
 	visitors map[string]*visitor
 	rate     rate.Limit
 	burst    int
+	key      KeyFunc
 }
 
 type visitor struct {
@@ -24,28 +31,34 @@ type visitor struct {
 	lastSeen time.Time
 }
 
-// NewRateLimiter creates a rate limiter with the given requests per second and burst.
+// NewRateLimiter creates a per-IP rate limiter with the given requests per second and burst.
 func NewRateLimiter(rps float64, burst int) *RateLimiter {
+	return NewKeyedRateLimiter(rps, burst, extractClientIP)
+}
+
+// NewKeyedRateLimiter creates a rate limiter that keys requests with key.
+func NewKeyedRateLimiter(rps float64, burst int, key KeyFunc) *RateLimiter {
 	rl := &RateLimiter{
 		visitors: make(map[string]*visitor),
 		rate:     rate.Limit(rps),
 		burst:    burst,
+		key:      key,
 	}
 	go rl.cleanup()
 	return rl
 }
 
-func (rl *RateLimiter) getVisitor(ip string) (*rate.Limiter, bool) {
+func (rl *RateLimiter) getVisitor(key string) (*rate.Limiter, bool) {
 	rl.mu.Lock()
 	defer rl.mu.Unlock()
 
-	v, exists := rl.visitors[ip]
+	v, exists := rl.visitors[key]
 	if !exists {
 		if len(rl.visitors) >= maxVisitors {
 			return nil, false
 		}
 		limiter := rate.NewLimiter(rl.rate, rl.burst)
-		rl.visitors[ip] = &visitor{limiter: limiter, lastSeen: time.Now()}
+		rl.visitors[key] = &visitor{limiter: limiter, lastSeen: time.Now()}
 		return limiter, true
 	}
 	v.lastSeen = time.Now()
@@ -59,9 +72,9 @@ func (rl *RateLimiter) cleanup() {
 
 	for range ticker.C {
 		rl.mu.Lock()
-		for ip, v := range rl.visitors {
+		for key, v := range rl.visitors {
 			if time.Since(v.lastSeen) > 3*time.Minute {
-				delete(rl.visitors, ip)
+				delete(rl.visitors, key)
 			}
 		}
 		rl.mu.Unlock()
@@ -77,6 +90,31 @@ func extractClientIP(r *http.Request) string {
 	return r.RemoteAddr
 }
 
+// TokenKey keys authenticated requests by access token, or by client ID for
+// API keys and local sessions, which have no access token. Unauthenticated
+// requests fall back to the client IP.
+func TokenKey(r *http.Request) string {
+	info := auth.GetTokenInfo(r.Context())
+	switch {
+	case info == nil:
+		return "ip:" + extractClientIP(r)
+	case info.AccessToken != "":
+		return "token:" + info.AccessToken
+	default:
+		return "client:" + info.ClientID
+	}
+}
+
+// ClientKey keys authenticated requests by OAuth client ID, so every session
+// of one client shares a limit. Unauthenticated requests fall back to the
+// client IP.
+func ClientKey(r *http.Request) string {
+	if info := auth.GetTokenInfo(r.Context()); info != nil {
+		return "client:" + info.ClientID
+	}
+	return "ip:" + extractClientIP(r)
+}
+
 func rateLimitReject(w http.ResponseWriter) {
 	w.Header().Set("Content-Type", "application/json")
 	w.Header().Set("Retry-After", "1")
@@ -84,12 +122,10 @@ func rateLimitReject(w http.ResponseWriter) {
 	w.Write([]byte(`{"error":"rate_limit_exceeded","error_description":"Too many requests. Please retry later."}`))
 }
 
-// Middleware returns an HTTP middleware that rate limits by client IP.
+// Middleware returns an HTTP middleware that rate limits by the limiter's key.
 func (rl *RateLimiter) Middleware(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		ip := extractClientIP(r)
-
-		limiter, ok := rl.getVisitor(ip)
+		limiter, ok := rl.getVisitor(rl.key(r))
 		if !ok || !limiter.Allow() {
 			rateLimitReject(w)
 			return
@@ -102,9 +138,7 @@ func (rl *RateLimiter) Middleware(next http.Handler) http.Handler {
 // MiddlewareFunc wraps an http.HandlerFunc with rate limiting.
 func (rl *RateLimiter) MiddlewareFunc(next http.HandlerFunc) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
-		ip := extractClientIP(r)
-
-		limiter, ok := rl.getVisitor(ip)
+		limiter, ok := rl.getVisitor(rl.key(r))
 		if !ok || !limiter.Allow() {
 			rateLimitReject(w)
 			return
@@ -1,6 +1,7 @@
 package middleware
 
 import (
+	"context"
 	"io"
 	"net/http"
 	"net/http/httptest"
@@ -8,6 +9,8 @@ import (
 	"sync"
 	"testing"
 	"time"
+
+	"github.com/paolobietolini/gtm-mcp-server/auth"
 )
 
 func TestNewRateLimiter(t *testing.T) {
@@ -477,3 +480,56 @@ func TestRateLimiter_DifferentIPsIndependent(t *testing.T) {
 		}
 	}
 }
+
+func TestKeyedRateLimiter_PerToken(t *testing.T) {
+	// 1 request per second, burst of 1: a second request with the same key is rejected
+	rl := NewKeyedRateLimiter(1, 1, TokenKey)
+	handler := rl.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	request := func(info *auth.TokenInfo) int {
+		req := httptest.NewRequest(http.MethodPost, "/", nil)
+		req.RemoteAddr = "10.0.0.1:1234" // every request shares one egress IP
+		if info != nil {
+			req = req.WithContext(context.WithValue(req.Context(), auth.TokenInfoKey, info))
+		}
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, req)
+		return w.Code
+	}
+
+	alice := &auth.TokenInfo{AccessToken: "alice", ClientID: "claude"}
+	bob := &auth.TokenInfo{AccessToken: "bob", ClientID: "claude"}
+
+	if code := request(alice); code != http.StatusOK {
+		t.Errorf("alice first request: got %d", code)
+	}
+	if code := request(alice); code != http.StatusTooManyRequests {
+		t.Errorf("alice second request: expected 429, got %d", code)
+	}
+	if code := request(bob); code != http.StatusOK {
+		t.Errorf("bob should have his own limit, got %d", code)
+	}
+	if code := request(nil); code != http.StatusOK {
+		t.Errorf("unauthenticated request should be keyed by IP, got %d", code)
+	}
+}
+
+func TestRateLimitKeys(t *testing.T) {
+	req := httptest.NewRequest(http.MethodPost, "/", nil)
+	req.RemoteAddr = "10.0.0.1:1234"
+	if got := TokenKey(req); got != "ip:10.0.0.1:1234" {
+		t.Errorf("TokenKey without auth = %q", got)
+	}
+
+	apiKey := req.WithContext(context.WithValue(req.Context(), auth.TokenInfoKey, &auth.TokenInfo{ClientID: "api-key:ci"}))
+	if got := TokenKey(apiKey); got != "client:api-key:ci" {
+		t.Errorf("TokenKey for API key = %q", got)
+	}
+
+	oauth := req.WithContext(context.WithValue(req.Context(), auth.TokenInfoKey, &auth.TokenInfo{AccessToken: "tok", ClientID: "claude"}))
+	if got := ClientKey(oauth); got != "client:claude" {
+		t.Errorf("ClientKey = %q", got)
+	}
+}
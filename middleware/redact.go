@@ -0,0 +1,224 @@
+package middleware
+
+import (
+	"encoding/json"
+	"log/slog"
+	"regexp"
+	"strings"
+	"sync"
+)
+
+// redactedLogValue replaces sensitive values in log lines.
+const redactedLogValue = "[REDACTED]"
+
+// DefaultRedactedKeys are the key fragments whose values are masked in logs:
+// credentials, and GTM parameters carrying code that often embeds secrets
+// (Custom HTML, Custom JavaScript variables and custom template code).
+var DefaultRedactedKeys = []string{
+	"authorization", "token", "secret", "password", "passwd", "credential", "apikey", "privatekey", "accesskey", "cookie",
+	"html", "javascript", "templatedata",
+}
+
+// paramKeyFields are the object fields that name a GTM parameter, whose
+// sibling value field is masked when the name is sensitive.
+var paramKeyFields = []string{"key", "name"}
+
+// rowNameKeys are the keys of map entries naming a name/value table row,
+// as in GA4 event parameters {name: "api_secret", value: "..."}.
+var rowNameKeys = map[string]bool{"name": true, "key": true, "parameter": true, "fieldName": true}
+
+// bearerRe matches bearer credentials inside free text.
+var bearerRe = regexp.MustCompile(`(?i)(Bearer\s+)[A-Za-z0-9\-._~+/]+=*`)
+
+var logRedaction = struct {
+	mu        sync.RWMutex
+	fragments []string
+}{fragments: normalizeRedactedKeys(DefaultRedactedKeys)}
+
+// SetRedactedKeys adds key fragments to DefaultRedactedKeys. Matching
+// ignores case, '_' and '-'.
+func SetRedactedKeys(keys []string) {
+	fragments := normalizeRedactedKeys(append(append([]string{}, DefaultRedactedKeys...), keys...))
+	logRedaction.mu.Lock()
+	logRedaction.fragments = fragments
+	logRedaction.mu.Unlock()
+}
+
+func normalizeRedactedKeys(keys []string) []string {
+	var fragments []string
+	for _, k := range keys {
+		if k = normalizeLogKey(k); k != "" {
+			fragments = append(fragments, k)
+		}
+	}
+	return fragments
+}
+
+func normalizeLogKey(s string) string {
+	return strings.NewReplacer("_", "", "-", "", " ", "").Replace(strings.ToLower(strings.TrimSpace(s)))
+}
+
+// sensitiveLogKey reports whether a key contains a redacted fragment.
+func sensitiveLogKey(key string) bool {
+	key = normalizeLogKey(key)
+	if key == "" {
+		return false
+	}
+	logRedaction.mu.RLock()
+	defer logRedaction.mu.RUnlock()
+	for _, f := range logRedaction.fragments {
+		if strings.Contains(key, f) {
+			return true
+		}
+	}
+	return false
+}
+
+// RedactValue returns a copy of a decoded JSON value with the values of
+// sensitive keys masked. In GTM parameters such as {key: "html", value:
+// "..."} the value is masked when the parameter key is sensitive, and
+// strings holding JSON are redacted as JSON.
+func RedactValue(v any) any {
+	switch v := v.(type) {
+	case map[string]any:
+		hideValue := false
+		for _, f := range paramKeyFields {
+			if name, ok := v[f].(string); ok && sensitiveLogKey(name) {
+				hideValue = true
+			}
+		}
+		out := make(map[string]any, len(v))
+		for k, x := range v {
+			switch {
+			case sensitiveLogKey(k), hideValue && k == "value":
+				out[k] = redactedLogValue
+			case k == "map" && sensitiveRow(x):
+				out[k] = redactRow(x.([]any))
+			default:
+				out[k] = RedactValue(x)
+			}
+		}
+		return out
+	case []any:
+		out := make([]any, len(v))
+		for i, x := range v {
+			out[i] = RedactValue(x)
+		}
+		return out
+	case string:
+		// Legacy *Json arguments carry parameters as JSON text
+		if s := strings.TrimSpace(v); strings.HasPrefix(s, "[") || strings.HasPrefix(s, "{") {
+			var parsed any
+			if json.Unmarshal([]byte(s), &parsed) == nil {
+				data, _ := json.Marshal(RedactValue(parsed))
+				return string(data)
+			}
+		}
+		return bearerRe.ReplaceAllString(v, "${1}"+redactedLogValue)
+	}
+	return v
+}
+
+// sensitiveRow reports whether the entries of a GTM map parameter form a
+// name/value row naming a secret.
+func sensitiveRow(entries any) bool {
+	list, ok := entries.([]any)
+	if !ok {
+		return false
+	}
+	for _, e := range list {
+		m, _ := e.(map[string]any)
+		key, _ := m["key"].(string)
+		value, _ := m["value"].(string)
+		if rowNameKeys[key] && sensitiveLogKey(value) {
+			return true
+		}
+	}
+	return false
+}
+
+// redactRow masks every value of a sensitive row except its name.
+func redactRow(entries []any) []any {
+	out := make([]any, len(entries))
+	for i, e := range entries {
+		m, ok := e.(map[string]any)
+		key, _ := m["key"].(string)
+		if !ok || rowNameKeys[key] {
+			out[i] = RedactValue(e)
+			continue
+		}
+		entry := RedactValue(m).(map[string]any)
+		if _, ok := entry["value"]; ok {
+			entry["value"] = redactedLogValue
+		}
+		out[i] = entry
+	}
+	return out
+}
+
+// RedactJSON masks sensitive values in a JSON document. Text that is not
+// JSON only has bearer credentials masked.
+func RedactJSON(data []byte) []byte {
+	var v any
+	if err := json.Unmarshal(data, &v); err != nil {
+		return bearerRe.ReplaceAll(data, []byte("${1}"+redactedLogValue))
+	}
+	out, err := json.Marshal(RedactValue(v))
+	if err != nil {
+		return nil
+	}
+	return out
+}
+
+// RedactHTTPDump masks the Authorization header and sensitive JSON body
+// values of an HTTP request or response dump.
+func RedactHTTPDump(dump []byte) []byte {
+	head, body, ok := strings.Cut(string(dump), "\r\n\r\n")
+	var b strings.Builder
+	for i, line := range strings.Split(head, "\r\n") {
+		if i > 0 {
+			b.WriteString("\r\n")
+		}
+		if name, _, found := strings.Cut(line, ":"); found && i > 0 && sensitiveLogKey(name) {
+			line = name + ": " + redactedLogValue
+		}
+		b.WriteString(line)
+	}
+	if ok {
+		b.WriteString("\r\n\r\n")
+		b.WriteString(redactBody(body))
+	}
+	return []byte(b.String())
+}
+
+// redactBody redacts the JSON document in an HTTP body, which a chunked
+// dump surrounds with chunk sizes.
+func redactBody(body string) string {
+	start := strings.IndexAny(body, "{[")
+	end := strings.LastIndexAny(body, "}]")
+	if start < 0 || end < start {
+		return string(RedactJSON([]byte(body)))
+	}
+	return body[:start] + string(RedactJSON([]byte(body[start:end+1]))) + body[end+1:]
+}
+
+// RedactAttr is a slog.HandlerOptions.ReplaceAttr function masking the
+// values of attributes with sensitive keys, and bearer credentials in
+// string values.
+func RedactAttr(groups []string, a slog.Attr) slog.Attr {
+	if a.Value.Kind() == slog.KindGroup {
+		return a
+	}
+	if sensitiveLogKey(a.Key) {
+		return slog.String(a.Key, redactedLogValue)
+	}
+	switch a.Value.Kind() {
+	case slog.KindString:
+		a.Value = slog.StringValue(bearerRe.ReplaceAllString(a.Value.String(), "${1}"+redactedLogValue))
+	case slog.KindAny:
+		if raw, ok := a.Value.Any().(json.RawMessage); ok {
+			a.Value = slog.AnyValue(json.RawMessage(RedactJSON(raw)))
+		}
+	}
+	return a
+}
@@ -0,0 +1,67 @@
+package middleware
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"strings"
+	"testing"
+)
+
+func TestRedactJSON(t *testing.T) {
+	in := `{"accountId":"1","name":"Custom HTML - Chat","pageToken":"abc","parameters":[
+		{"type":"template","key":"html","value":"<script>key='s3cr3t'</script>"},
+		{"type":"template","key":"eventName","value":"purchase"},
+		{"type":"map","map":[{"type":"template","key":"name","value":"api_secret"},{"type":"template","key":"value","value":"xyz"}]}
+	],"notes":"Bearer ya29.abc"}`
+	out := string(RedactJSON([]byte(in)))
+
+	for _, secret := range []string{"s3cr3t", "xyz", "ya29", `"abc"`} {
+		if strings.Contains(out, secret) {
+			t.Errorf("%s not redacted: %s", secret, out)
+		}
+	}
+	for _, kept := range []string{"purchase", "Custom HTML - Chat", "api_secret"} {
+		if !strings.Contains(out, kept) {
+			t.Errorf("%s redacted: %s", kept, out)
+		}
+	}
+}
+
+func TestSetRedactedKeys(t *testing.T) {
+	t.Cleanup(func() { SetRedactedKeys(nil) })
+	SetRedactedKeys([]string{"user_email"})
+
+	out := string(RedactJSON([]byte(`{"userEmail":"a@b.c","authorization":"x"}`)))
+	if strings.Contains(out, "a@b.c") || strings.Contains(out, `"x"`) {
+		t.Errorf("out = %s", out)
+	}
+}
+
+func TestRedactHTTPDump(t *testing.T) {
+	dump := "POST /tagmanager/v2/tags HTTP/1.1\r\nHost: x\r\nAuthorization: Bearer ya29.abc\r\n\r\n5a\r\n" +
+		`{"name":"Chat","parameter":[{"key":"html","value":"<script>s3cr3t</script>"}]}`
+	out := string(RedactHTTPDump([]byte(dump)))
+	if strings.Contains(out, "ya29") || strings.Contains(out, "s3cr3t") || !strings.Contains(out, "Host: x") || !strings.Contains(out, "Chat") {
+		t.Errorf("out = %q", out)
+	}
+}
+
+func TestRedactAttr(t *testing.T) {
+	var logs bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&logs, &slog.HandlerOptions{ReplaceAttr: RedactAttr}))
+	logger.Info("call", "access_token", "ya29.abc", "error", "401: Bearer ya29.def rejected",
+		"arguments", json.RawMessage(`{"html":"<b>s3cr3t</b>"}`), "tool", "create_tag")
+
+	out := logs.String()
+	if strings.Contains(out, "ya29") || strings.Contains(out, "s3cr3t") || !strings.Contains(out, "create_tag") {
+		t.Errorf("log = %s", out)
+	}
+}
+
+func TestRedactJSON_EmbeddedJSON(t *testing.T) {
+	in := `{"parametersJson":"[{\"type\":\"template\",\"key\":\"html\",\"value\":\"<script>s3cr3t</script>\"}]"}`
+	if out := string(RedactJSON([]byte(in))); strings.Contains(out, "s3cr3t") {
+		t.Errorf("out = %s", out)
+	}
+}
@@ -0,0 +1,47 @@
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+func TestRequestIDMiddleware(t *testing.T) {
+	var logs bytes.Buffer
+	logger := slog.New(NewRequestIDHandler(slog.NewJSONHandler(&logs, nil))).With("component", "test")
+
+	var ids []string
+	handler := NewRequestIDMiddleware()(func(ctx context.Context, method string, req mcp.Request) (mcp.Result, error) {
+		ids = append(ids, RequestIDFromContext(ctx))
+		logger.InfoContext(ctx, "handled")
+		return nil, nil
+	})
+	for range 2 {
+		handler(context.Background(), "tools/call", &mcp.CallToolRequest{})
+	}
+	if len(ids) != 2 || len(ids[0]) != 16 || ids[0] == ids[1] {
+		t.Fatalf("ids = %v, want two distinct IDs", ids)
+	}
+
+	dec := json.NewDecoder(&logs)
+	for _, id := range ids {
+		var line map[string]any
+		if err := dec.Decode(&line); err != nil {
+			t.Fatal(err)
+		}
+		if line["request_id"] != id || line["component"] != "test" {
+			t.Errorf("log line = %v, want request_id %s", line, id)
+		}
+	}
+
+	// Lines logged outside a request have no request ID
+	logs.Reset()
+	logger.Info("startup")
+	if bytes.Contains(logs.Bytes(), []byte("request_id")) {
+		t.Errorf("log line = %s", logs.String())
+	}
+}
@@ -0,0 +1,36 @@
+package middleware
+
+import (
+	"log"
+	"net/http"
+	"net/http/httputil"
+)
+
+// loggingTransport wraps an http.RoundTripper and logs request/response bodies
+// with sensitive headers and parameter values redacted.
+type loggingTransport struct {
+	wrapped http.RoundTripper
+}
+
+// NewLoggingTransport returns a transport logging every request and response
+// sent through next, redacted with RedactHTTPDump and tagged with the MCP
+// request ID of the request context.
+func NewLoggingTransport(next http.RoundTripper) http.RoundTripper {
+	return &loggingTransport{wrapped: next}
+}
+
+func (t *loggingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	requestID := RequestIDFromContext(req.Context())
+	dump, _ := httputil.DumpRequestOut(req, true)
+	log.Printf("[HTTP REQUEST] request_id=%s %s", requestID, RedactHTTPDump(dump))
+
+	resp, err := t.wrapped.RoundTrip(req)
+	if err != nil {
+		return resp, err
+	}
+
+	respDump, _ := httputil.DumpResponse(resp, true)
+	log.Printf("[HTTP RESPONSE] request_id=%s %s", requestID, RedactHTTPDump(respDump))
+
+	return resp, nil
+}
@@ -0,0 +1,63 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/paolobietolini/gtm-mcp-server/gtm"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+const (
+	// DefaultAnnotateEntities is how many entities annotate_workspace
+	// documents per call by default.
+	DefaultAnnotateEntities = 20
+	// MaxAnnotateEntities caps the entities documented per call, since each
+	// one costs a sampling request.
+	MaxAnnotateEntities = 100
+
+	// maxNoteLength caps a generated note, in runes.
+	maxNoteLength = 500
+)
+
+// sampleFunc asks the client's model for a completion, as
+// mcp.ServerSession.CreateMessage does.
+type sampleFunc func(context.Context, *mcp.CreateMessageParams) (*mcp.CreateMessageResult, error)
+
+// draftNote asks the client's model for a short note describing the
+// purpose of an entity.
+func draftNote(ctx context.Context, sample sampleFunc, c gtm.NoteCandidate) (string, error) {
+	data, err := json.MarshalIndent(c.Summary, "", "  ")
+	if err != nil {
+		return "", err
+	}
+
+	res, err := sample(ctx, &mcp.CreateMessageParams{
+		SystemPrompt: "You document Google Tag Manager configurations. Reply with the note text only: one or two plain sentences, no Markdown, no preamble.",
+		Messages: []*mcp.SamplingMessage{{
+			Role: "user",
+			Content: &mcp.TextContent{Text: fmt.Sprintf(
+				"Write the Notes field for this GTM %s, describing its purpose as inferred from its type and parameters:\n\n%s", c.EntityType, data)},
+		}},
+		MaxTokens: 200,
+	})
+	if err != nil {
+		return "", fmt.Errorf("sampling failed: %w", err)
+	}
+
+	text, ok := res.Content.(*mcp.TextContent)
+	if !ok {
+		return "", fmt.Errorf("sampling returned %T content, want text", res.Content)
+	}
+	notes := strings.TrimSpace(text.Text)
+	if notes == "" {
+		return "", fmt.Errorf("sampling returned an empty note")
+	}
+	if r := []rune(notes); len(r) > maxNoteLength {
+		notes = string(r[:maxNoteLength])
+	}
+	return notes, nil
+}
@@ -0,0 +1,62 @@
+package tools
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/paolobietolini/gtm-mcp-server/gtm"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	tagmanager "google.golang.org/api/tagmanager/v2"
+)
+
+func TestNewNoteContext_Redacts(t *testing.T) {
+	tag := &tagmanager.Tag{Name: "Stripe", Type: "html", Parameter: []*tagmanager.Parameter{
+		{Type: "template", Key: "apiKey", Value: "sk_live_123"},
+	}}
+
+	nc := gtm.NewNoteContext(tag)
+
+	if nc.Parameter[0].Value == "sk_live_123" {
+		t.Error("sensitive value sent for sampling")
+	}
+	if tag.Parameter[0].Value != "sk_live_123" {
+		t.Error("the entity itself was redacted")
+	}
+}
+
+func TestAnnotateEntities(t *testing.T) {
+	sample := func(ctx context.Context, p *mcp.CreateMessageParams) (*mcp.CreateMessageResult, error) {
+		text := p.Messages[0].Content.(*mcp.TextContent).Text
+		if strings.Contains(text, "Broken") {
+			return nil, errors.New("client refused")
+		}
+		return &mcp.CreateMessageResult{Content: &mcp.TextContent{Text: "  Sends GA4 page views.\n"}}, nil
+	}
+
+	var written []string
+	candidate := func(name string) gtm.NoteCandidate {
+		return gtm.NoteCandidate{
+			EntityNote: gtm.EntityNote{EntityType: gtm.EntityTypeTag, EntityID: name, Name: name},
+			Summary:    gtm.NoteContext{Name: name, Type: "gaawe"},
+			Apply: func(ctx context.Context, notes string) error {
+				written = append(written, name+": "+notes)
+				return nil
+			},
+		}
+	}
+	candidates := []gtm.NoteCandidate{candidate("GA4"), candidate("Broken")}
+
+	// A dry run drafts notes without writing them
+	updated, notes := annotateEntities(context.Background(), sample, candidates, false)
+	if updated != 0 || len(written) != 0 || notes[0].Notes != "Sends GA4 page views." || notes[1].Error == "" {
+		t.Errorf("dry run: updated %d, written %v, notes %+v", updated, written, notes)
+	}
+
+	updated, notes = annotateEntities(context.Background(), sample, candidates, true)
+	if updated != 1 || len(written) != 1 || written[0] != "GA4: Sends GA4 page views." {
+		t.Errorf("apply: updated %d, written %v, notes %+v", updated, written, notes)
+	}
+}
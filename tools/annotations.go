@@ -0,0 +1,72 @@
+package tools
+
+import "github.com/modelcontextprotocol/go-sdk/mcp"
+
+// additiveTools are mutating tools that only add entities and never change
+// or remove existing ones. Every other mutating tool is marked destructive.
+var additiveTools = map[string]bool{
+	"create_workspace":             true,
+	"clone_workspace":              true,
+	"create_tag":                   true,
+	"create_ga4_event_tag":         true,
+	"create_trigger":               true,
+	"create_variable":              true,
+	"create_lookup_table_variable": true,
+	"create_regex_table_variable":  true,
+	"create_client":                true,
+	"create_transformation":        true,
+	"duplicate_tag":                true,
+	"duplicate_trigger":            true,
+	"duplicate_variable":           true,
+	"create_template":              true,
+	"create_container":             true,
+	"create_version":               true,
+	"copy_entities":                true,
+	"apply_blueprint":              true,
+	"setup_ga4_basic":              true,
+	"import_gallery_template":      true,
+	"enable_built_in_variables":    true,
+	"annotate_workspace":           true, // only fills empty notes
+}
+
+// idempotentTools are mutating tools that have no further effect when
+// called again with the same arguments.
+var idempotentTools = map[string]bool{
+	"update_tag":                 true,
+	"update_trigger":             true,
+	"update_variable":            true,
+	"upsert_tag":                 true,
+	"upsert_trigger":             true,
+	"upsert_variable":            true,
+	"update_client":              true,
+	"update_transformation":      true,
+	"update_template":            true,
+	"check_template_updates":     true,
+	"delete_tag":                 true,
+	"delete_trigger":             true,
+	"delete_variable":            true,
+	"delete_client":              true,
+	"delete_transformation":      true,
+	"delete_template":            true,
+	"delete_container":           true,
+	"bulk_delete_entities":       true,
+	"enable_built_in_variables":  true,
+	"disable_built_in_variables": true,
+	"publish_version":            true,
+	"cancel_scheduled_publish":   true,
+	"setup_ga4_basic":            true,
+	"setup_consent_mode":         true,
+}
+
+// toolAnnotations returns the MCP hints for a tool, so clients can decide
+// which calls to confirm with the user.
+func toolAnnotations(name string) *mcp.ToolAnnotations {
+	if readTools[name] {
+		return &mcp.ToolAnnotations{ReadOnlyHint: true}
+	}
+	destructive := !additiveTools[name]
+	return &mcp.ToolAnnotations{
+		DestructiveHint: &destructive,
+		IdempotentHint:  idempotentTools[name],
+	}
+}
@@ -0,0 +1,51 @@
+package tools
+
+import "testing"
+
+func TestToolAnnotations(t *testing.T) {
+	tools := registeredTools(t, ToolOptions{})
+
+	for name, tool := range tools {
+		a := tool.Annotations
+		if a == nil {
+			t.Errorf("%s has no annotations", name)
+			continue
+		}
+		if a.ReadOnlyHint != readTools[name] {
+			t.Errorf("%s: readOnlyHint = %v", name, a.ReadOnlyHint)
+		}
+		if !a.ReadOnlyHint && a.DestructiveHint == nil {
+			t.Errorf("%s: mutating tool without destructiveHint", name)
+		}
+	}
+
+	for _, tt := range []struct {
+		name        string
+		destructive bool
+	}{
+		{"delete_tag", true},
+		{"publish_version", true},
+		{"update_variable", true},
+		{"gtm_api_request", true},
+		{"create_tag", false},
+		{"create_version", false},
+	} {
+		if got := *tools[tt.name].Annotations.DestructiveHint; got != tt.destructive {
+			t.Errorf("%s: destructiveHint = %v, want %v", tt.name, got, tt.destructive)
+		}
+	}
+}
+
+func TestToolAnnotations_ReferenceRegisteredTools(t *testing.T) {
+	all := registeredToolNames(t, ToolOptions{})
+	for _, set := range []map[string]bool{additiveTools, idempotentTools} {
+		for name := range set {
+			if !all[name] {
+				t.Errorf("annotation set references unknown tool %q", name)
+			}
+			if readTools[name] {
+				t.Errorf("read tool %q listed as mutating", name)
+			}
+		}
+	}
+}
@@ -0,0 +1,329 @@
+package tools
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/paolobietolini/gtm-mcp-server/auth"
+	"github.com/paolobietolini/gtm-mcp-server/gtm"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+const (
+	// DefaultApprovalTTL is how long a change waits for approval by default.
+	DefaultApprovalTTL = 24 * time.Hour
+	// maxPendingChanges bounds the number of changes awaiting approval.
+	maxPendingChanges = 200
+	// approvalWebhookTimeout bounds each approval webhook call.
+	approvalWebhookTimeout = 10 * time.Second
+)
+
+// approvalTools decide on pending changes and are never held for approval.
+var approvalTools = map[string]bool{
+	"approve_change": true,
+	"reject_change":  true,
+}
+
+// ApprovalOptions configures the approval mode of mutating tools.
+type ApprovalOptions struct {
+	// TTL is how long a change waits for a decision. 0 uses DefaultApprovalTTL.
+	TTL time.Duration
+
+	// Approvers are the client IDs (or api-key:<name>) allowed to approve
+	// and reject changes. Empty allows every client. Nobody may approve
+	// their own change.
+	Approvers []string
+
+	// WebhookURL receives each new change as a JSON POST. A 200 response
+	// of {"approved": true|false, "reason": "..."} decides the change at
+	// once; any other response leaves it pending.
+	WebhookURL string
+}
+
+// PendingChange is a mutating tool call held until it is approved.
+type PendingChange struct {
+	ApprovalID  string         `json:"approvalId"`
+	Tool        string         `json:"tool"`
+	ClientID    string         `json:"clientId,omitempty"`
+	SessionID   string         `json:"sessionId,omitempty"`
+	AccountID   string         `json:"accountId,omitempty"`
+	ContainerID string         `json:"containerId,omitempty"`
+	WorkspaceID string         `json:"workspaceId,omitempty"`
+	Input       map[string]any `json:"input,omitempty"` // redacted summary of the call's input
+	CreatedAt   time.Time      `json:"createdAt"`
+	ExpiresAt   time.Time      `json:"expiresAt"`
+
+	user    string // userKey of the requester
+	execute func(ctx context.Context, req *mcp.CallToolRequest) (any, error)
+}
+
+// webhookDecision is the body of a deciding approval webhook response.
+type webhookDecision struct {
+	Approved bool   `json:"approved"`
+	Reason   string `json:"reason"`
+}
+
+// ApprovalQueue holds mutating tool calls until an approver decides on them.
+// Pending changes are kept in memory and lost when the server restarts.
+type ApprovalQueue struct {
+	ttl       time.Duration
+	approvers []string
+	hook      string
+	client    *http.Client
+	logger    *slog.Logger
+
+	mu      sync.Mutex
+	pending map[string]*PendingChange // keyed by approval ID
+	now     func() time.Time
+}
+
+// NewApprovalQueue creates an approval queue with the given options.
+func NewApprovalQueue(opts ApprovalOptions, logger *slog.Logger) *ApprovalQueue {
+	ttl := opts.TTL
+	if ttl <= 0 {
+		ttl = DefaultApprovalTTL
+	}
+	return &ApprovalQueue{
+		ttl:       ttl,
+		approvers: opts.Approvers,
+		hook:      opts.WebhookURL,
+		client:    &http.Client{Timeout: approvalWebhookTimeout},
+		logger:    logger,
+		pending:   make(map[string]*PendingChange),
+		now:       time.Now,
+	}
+}
+
+// prune removes expired changes and the oldest ones beyond
+// maxPendingChanges. The caller must hold q.mu.
+func (q *ApprovalQueue) prune() {
+	now := q.now()
+	for id, c := range q.pending {
+		if now.After(c.ExpiresAt) {
+			delete(q.pending, id)
+		}
+	}
+	for len(q.pending) > maxPendingChanges {
+		var oldest *PendingChange
+		for _, c := range q.pending {
+			if oldest == nil || c.CreatedAt.Before(oldest.CreatedAt) {
+				oldest = c
+			}
+		}
+		delete(q.pending, oldest.ApprovalID)
+	}
+}
+
+// add stores a change and returns it with its approval ID set.
+func (q *ApprovalQueue) add(c *PendingChange) (*PendingChange, error) {
+	id, err := auth.GenerateToken(12)
+	if err != nil {
+		return nil, err
+	}
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	c.ApprovalID = id
+	c.CreatedAt = q.now()
+	c.ExpiresAt = c.CreatedAt.Add(q.ttl)
+	q.pending[id] = c
+	q.prune()
+	return c, nil
+}
+
+// List returns the pending changes, oldest first, optionally of one container.
+func (q *ApprovalQueue) List(accountID, containerID string) []PendingChange {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.prune()
+	list := []PendingChange{}
+	for _, c := range q.pending {
+		if (accountID == "" || c.AccountID == accountID) && (containerID == "" || c.ContainerID == containerID) {
+			list = append(list, *c)
+		}
+	}
+	slices.SortFunc(list, func(a, b PendingChange) int { return a.CreatedAt.Compare(b.CreatedAt) })
+	return list
+}
+
+// Visible returns the pending changes the caller may see, oldest first,
+// optionally of one container: every change for approvers, otherwise only
+// the caller's own.
+func (q *ApprovalQueue) Visible(ctx context.Context, accountID, containerID string) []PendingChange {
+	list := q.List(accountID, containerID)
+	if q.checkApprover(ctx) == nil {
+		return list
+	}
+	user := userKey(ctx)
+	return slices.DeleteFunc(list, func(c PendingChange) bool { return c.user != user })
+}
+
+// take removes and returns a pending change, so it is decided only once.
+// A change that fails check, if set, stays pending.
+func (q *ApprovalQueue) take(id string, check func(*PendingChange) error) (*PendingChange, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.prune()
+	c, ok := q.pending[id]
+	if !ok {
+		return nil, fmt.Errorf("%w: no pending change %q; it may have expired or been decided", gtm.ErrNotFound, id)
+	}
+	if check != nil {
+		if err := check(c); err != nil {
+			return nil, err
+		}
+	}
+	delete(q.pending, id)
+	return c, nil
+}
+
+// checkApprover fails unless the caller may decide on changes.
+func (q *ApprovalQueue) checkApprover(ctx context.Context) error {
+	if len(q.approvers) == 0 {
+		return nil
+	}
+	clientID := ""
+	if tokenInfo := auth.GetTokenInfo(ctx); tokenInfo != nil {
+		clientID = tokenInfo.ClientID
+	}
+	if !slices.Contains(q.approvers, clientID) {
+		return fmt.Errorf("%w: client %q may not approve or reject changes", gtm.ErrPermission, clientID)
+	}
+	return nil
+}
+
+// notRequester returns a check failing for changes requested by the user or
+// session of ctx, so every change needs a second person to approve it.
+func notRequester(ctx context.Context) func(*PendingChange) error {
+	user, session := userKey(ctx), gtm.SessionFromContext(ctx)
+	return func(c *PendingChange) error {
+		if c.user == user || (session != "" && c.SessionID == session) {
+			return fmt.Errorf("%w: a change must be approved by someone other than its requester", gtm.ErrPermission)
+		}
+		return nil
+	}
+}
+
+// Approve runs a pending change with the requester's credentials and
+// returns the change and the tool's output. Requesters may not approve
+// their own changes.
+func (q *ApprovalQueue) Approve(ctx context.Context, req *mcp.CallToolRequest, id string) (*PendingChange, any, error) {
+	if err := q.checkApprover(ctx); err != nil {
+		return nil, nil, err
+	}
+	c, err := q.take(id, notRequester(ctx))
+	if err != nil {
+		return nil, nil, err
+	}
+	out, err := c.execute(ctx, req)
+	return c, out, err
+}
+
+// Reject discards a pending change.
+func (q *ApprovalQueue) Reject(ctx context.Context, id string) (*PendingChange, error) {
+	if err := q.checkApprover(ctx); err != nil {
+		return nil, err
+	}
+	return q.take(id, nil)
+}
+
+// askWebhook posts a new change to the approval webhook and returns its
+// decision, or nil when the webhook leaves the change pending.
+func (q *ApprovalQueue) askWebhook(ctx context.Context, c *PendingChange) *webhookDecision {
+	body, err := json.Marshal(c)
+	if err != nil {
+		return nil
+	}
+	ctx, cancel := context.WithTimeout(ctx, approvalWebhookTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, q.hook, bytes.NewReader(body))
+	if err != nil {
+		q.logger.ErrorContext(ctx, "invalid approval webhook", "error", err)
+		return nil
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := q.client.Do(req)
+	if err != nil {
+		q.logger.ErrorContext(ctx, "failed to call approval webhook", "approval_id", c.ApprovalID, "error", err)
+		return nil
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil
+	}
+	var d webhookDecision
+	if err := json.NewDecoder(resp.Body).Decode(&d); err != nil {
+		q.logger.ErrorContext(ctx, "invalid approval webhook response", "approval_id", c.ApprovalID, "error", err)
+		return nil
+	}
+	return &d
+}
+
+// withApproval holds calls of a mutating tool until they are approved. The
+// call returns an APPROVAL_REQUIRED error carrying the approval ID, unless
+// the approval webhook decides it at once. An approved call runs as its
+// requester, so approving never lends the approver's Google access, and is
+// recorded in audit under the tool's name and the requester.
+func withApproval[In, Out any](q *ApprovalQueue, audit *AuditLog, name string, handler mcp.ToolHandlerFor[In, Out]) mcp.ToolHandlerFor[In, Out] {
+	if q == nil || approvalTools[name] {
+		return handler
+	}
+	approved := withAudit(audit, name, handler)
+	return func(ctx context.Context, req *mcp.CallToolRequest, input In) (*mcp.CallToolResult, Out, error) {
+		in := toJSONMap(input)
+		requester := ctx
+		change := &PendingChange{
+			Tool:        name,
+			SessionID:   gtm.SessionFromContext(ctx),
+			AccountID:   stringField(in, "accountId"),
+			ContainerID: stringField(in, "containerId"),
+			WorkspaceID: stringField(in, "workspaceId"),
+			Input:       summarizeInput(in),
+			user:        userKey(ctx),
+			execute: func(ctx context.Context, req *mcp.CallToolRequest) (any, error) {
+				_, out, err := approved(auth.WithIdentity(ctx, requester), req, input)
+				return out, err
+			},
+		}
+		if tokenInfo := auth.GetTokenInfo(ctx); tokenInfo != nil {
+			change.ClientID = tokenInfo.ClientID
+		}
+
+		var zero Out
+		change, err := q.add(change)
+		if err != nil {
+			return nil, zero, err
+		}
+
+		if q.hook != "" {
+			if d := q.askWebhook(ctx, change); d != nil {
+				if _, err := q.take(change.ApprovalID, nil); err != nil {
+					return nil, zero, err
+				}
+				if !d.Approved {
+					return nil, zero, &ToolError{
+						Code:       ErrorCodeRejected,
+						Message:    fmt.Sprintf("%s was rejected by the approval webhook: %s", name, d.Reason),
+						ApprovalID: change.ApprovalID,
+						Suggestion: errorSuggestions[ErrorCodeRejected],
+					}
+				}
+				return handler(ctx, req, input)
+			}
+		}
+
+		return nil, zero, &ToolError{
+			Code:       ErrorCodeApprovalRequired,
+			Message:    fmt.Sprintf("%s was not run: it is waiting for approval until %s", name, change.ExpiresAt.UTC().Format(time.RFC3339)),
+			ApprovalID: change.ApprovalID,
+			Suggestion: errorSuggestions[ErrorCodeApprovalRequired],
+		}
+	}
+}
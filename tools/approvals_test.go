@@ -0,0 +1,214 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/paolobietolini/gtm-mcp-server/auth"
+	"github.com/paolobietolini/gtm-mcp-server/gtm"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+type approvalTestInput struct {
+	AccountID string `json:"accountId"`
+	Name      string `json:"name"`
+}
+
+func clientContext(clientID string) context.Context {
+	return context.WithValue(context.Background(), auth.TokenInfoKey, &auth.TokenInfo{ClientID: clientID})
+}
+
+func TestWithApproval_HoldsUntilApproved(t *testing.T) {
+	q := NewApprovalQueue(ApprovalOptions{Approvers: []string{"reviewer"}}, slog.New(slog.NewTextHandler(io.Discard, nil)))
+	var ranBy []string
+	handler := withApproval(q, nil, "create_tag", func(ctx context.Context, req *mcp.CallToolRequest, input approvalTestInput) (*mcp.CallToolResult, string, error) {
+		ranBy = append(ranBy, auth.GetTokenInfo(ctx).ClientID)
+		return nil, "created " + input.Name, nil
+	})
+
+	_, _, err := handler(clientContext("claude"), nil, approvalTestInput{AccountID: "1", Name: "GA4"})
+	var te *ToolError
+	if !errors.As(err, &te) || te.Code != ErrorCodeApprovalRequired || te.ApprovalID == "" {
+		t.Fatalf("err = %v, want an APPROVAL_REQUIRED error", err)
+	}
+	if len(ranBy) != 0 {
+		t.Fatal("held call ran before approval")
+	}
+	pending := q.List("1", "")
+	if len(pending) != 1 || pending[0].Tool != "create_tag" || pending[0].ClientID != "claude" {
+		t.Fatalf("pending = %+v", pending)
+	}
+
+	if _, _, err := q.Approve(clientContext("claude"), nil, te.ApprovalID); !errors.Is(err, gtm.ErrPermission) {
+		t.Errorf("approve by the requester: err = %v, want ErrPermission", err)
+	}
+	// The approved call runs with the requester's credentials
+	_, out, err := q.Approve(clientContext("reviewer"), nil, te.ApprovalID)
+	if err != nil || out != "created GA4" || len(ranBy) != 1 || ranBy[0] != "claude" {
+		t.Errorf("approve: out = %v, ran by %v, err = %v", out, ranBy, err)
+	}
+	if _, _, err := q.Approve(clientContext("reviewer"), nil, te.ApprovalID); !errors.Is(err, gtm.ErrNotFound) {
+		t.Errorf("second approve: err = %v, want ErrNotFound", err)
+	}
+}
+
+func TestWithApproval_RejectsSelfApproval(t *testing.T) {
+	q := NewApprovalQueue(ApprovalOptions{}, slog.New(slog.NewTextHandler(io.Discard, nil)))
+	handler := withApproval(q, nil, "create_tag", func(ctx context.Context, req *mcp.CallToolRequest, input approvalTestInput) (*mcp.CallToolResult, string, error) {
+		return nil, "created " + input.Name, nil
+	})
+
+	requester := gtm.WithSession(clientContext("claude"), "session-1")
+	_, _, err := handler(requester, nil, approvalTestInput{Name: "GA4"})
+	var te *ToolError
+	if !errors.As(err, &te) || te.Code != ErrorCodeApprovalRequired {
+		t.Fatalf("err = %v, want an APPROVAL_REQUIRED error", err)
+	}
+
+	// Without APPROVAL_CLIENT_IDS anyone may approve, except the requester
+	if _, _, err := q.Approve(clientContext("claude"), nil, te.ApprovalID); !errors.Is(err, gtm.ErrPermission) {
+		t.Errorf("approve by the requester: err = %v, want ErrPermission", err)
+	}
+	if _, _, err := q.Approve(gtm.WithSession(clientContext("other"), "session-1"), nil, te.ApprovalID); !errors.Is(err, gtm.ErrPermission) {
+		t.Errorf("approve from the requester's session: err = %v, want ErrPermission", err)
+	}
+	if _, out, err := q.Approve(clientContext("reviewer"), nil, te.ApprovalID); err != nil || out != "created GA4" {
+		t.Errorf("approve by another client: out = %v, err = %v", out, err)
+	}
+}
+
+func TestRegisterTools_ApprovalChecksReadOnlyBeforeQueuing(t *testing.T) {
+	q := NewApprovalQueue(ApprovalOptions{}, slog.New(slog.NewTextHandler(io.Discard, nil)))
+	server := mcp.NewServer(&mcp.Implementation{Name: "test", Version: "0.0.0"}, nil)
+	if err := RegisterTools(server, ToolOptions{Approvals: q}); err != nil {
+		t.Fatalf("RegisterTools: %v", err)
+	}
+
+	ctx := context.Background()
+	readOnly := context.WithValue(ctx, auth.TokenInfoKey, &auth.TokenInfo{ClientID: "claude", Scope: auth.ScopeRead})
+	serverTransport, clientTransport := mcp.NewInMemoryTransports()
+	serverSession, err := server.Connect(readOnly, serverTransport, nil)
+	if err != nil {
+		t.Fatalf("server connect: %v", err)
+	}
+	defer serverSession.Close()
+	client := mcp.NewClient(&mcp.Implementation{Name: "test-client", Version: "0.0.0"}, nil)
+	session, err := client.Connect(ctx, clientTransport, nil)
+	if err != nil {
+		t.Fatalf("client connect: %v", err)
+	}
+	defer session.Close()
+
+	res, err := session.CallTool(ctx, &mcp.CallToolParams{Name: "create_workspace", Arguments: map[string]any{"accountId": "1", "containerId": "2", "name": "ws"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var envelope struct{ Error ToolError }
+	if err := json.Unmarshal([]byte(res.Content[0].(*mcp.TextContent).Text), &envelope); err != nil || envelope.Error.Code != ErrorCodeReadOnly {
+		t.Errorf("create_workspace with a gtm:read token returned %+v", res.Content[0])
+	}
+	if pending := q.List("", ""); len(pending) != 0 {
+		t.Errorf("read-only call was queued: %+v", pending)
+	}
+}
+
+func TestWithApproval_Webhook(t *testing.T) {
+	hook := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var change PendingChange
+		if err := json.NewDecoder(r.Body).Decode(&change); err != nil {
+			t.Error(err)
+		}
+		switch change.Input["name"] {
+		case "ok":
+			fmt.Fprint(w, `{"approved":true}`)
+		case "no":
+			fmt.Fprint(w, `{"approved":false,"reason":"production freeze"}`)
+		default:
+			w.WriteHeader(http.StatusAccepted)
+		}
+	}))
+	defer hook.Close()
+
+	q := NewApprovalQueue(ApprovalOptions{WebhookURL: hook.URL}, slog.New(slog.NewTextHandler(io.Discard, nil)))
+	handler := withApproval(q, nil, "create_tag", func(ctx context.Context, req *mcp.CallToolRequest, input approvalTestInput) (*mcp.CallToolResult, string, error) {
+		return nil, "created " + input.Name, nil
+	})
+
+	if _, out, err := handler(context.Background(), nil, approvalTestInput{Name: "ok"}); err != nil || out != "created ok" {
+		t.Errorf("approved: out = %q, err = %v", out, err)
+	}
+	var te *ToolError
+	if _, _, err := handler(context.Background(), nil, approvalTestInput{Name: "no"}); !errors.As(err, &te) || te.Code != ErrorCodeRejected {
+		t.Errorf("rejected: err = %v", err)
+	}
+	if _, _, err := handler(context.Background(), nil, approvalTestInput{Name: "later"}); !errors.As(err, &te) || te.Code != ErrorCodeApprovalRequired {
+		t.Errorf("undecided: err = %v", err)
+	}
+	if pending := q.List("", ""); len(pending) != 1 || pending[0].Input["name"] != "later" {
+		t.Errorf("pending = %+v, want only the undecided change", pending)
+	}
+}
+
+func TestApprovalQueue_VisibleToRequesterOrApprover(t *testing.T) {
+	q := NewApprovalQueue(ApprovalOptions{Approvers: []string{"reviewer"}}, slog.New(slog.NewTextHandler(io.Discard, nil)))
+	handler := withApproval(q, nil, "create_tag", func(ctx context.Context, req *mcp.CallToolRequest, input approvalTestInput) (*mcp.CallToolResult, string, error) {
+		return nil, "created " + input.Name, nil
+	})
+	handler(clientContext("claude"), nil, approvalTestInput{AccountID: "1", Name: "GA4"})
+	handler(clientContext("cursor"), nil, approvalTestInput{AccountID: "1", Name: "Ads"})
+
+	if got := q.Visible(clientContext("claude"), "", ""); len(got) != 1 || got[0].ClientID != "claude" {
+		t.Errorf("requester sees %+v, want only their own change", got)
+	}
+	if got := q.Visible(clientContext("other"), "", ""); len(got) != 0 {
+		t.Errorf("unrelated client sees %+v", got)
+	}
+	if got := q.Visible(clientContext("reviewer"), "1", ""); len(got) != 2 {
+		t.Errorf("approver sees %+v, want both changes", got)
+	}
+
+	// Without APPROVAL_CLIENT_IDS every client is an approver
+	open := NewApprovalQueue(ApprovalOptions{}, slog.New(slog.NewTextHandler(io.Discard, nil)))
+	withApproval(open, nil, "create_tag", func(ctx context.Context, req *mcp.CallToolRequest, input approvalTestInput) (*mcp.CallToolResult, string, error) {
+		return nil, "", nil
+	})(clientContext("claude"), nil, approvalTestInput{Name: "GA4"})
+	if got := open.Visible(clientContext("other"), "", ""); len(got) != 1 {
+		t.Errorf("without approvers configured: sees %+v, want every change", got)
+	}
+}
+
+func TestWithApproval_AuditsApprovedCallAsRequester(t *testing.T) {
+	audit, err := NewAuditLog(AuditOptions{}, slog.New(slog.NewTextHandler(io.Discard, nil)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	q := NewApprovalQueue(ApprovalOptions{}, slog.New(slog.NewTextHandler(io.Discard, nil)))
+	handler := withApproval(q, audit, "create_tag", func(ctx context.Context, req *mcp.CallToolRequest, input approvalTestInput) (*mcp.CallToolResult, string, error) {
+		return nil, "created " + input.Name, nil
+	})
+
+	_, _, err = handler(clientContext("claude"), nil, approvalTestInput{AccountID: "1", Name: "GA4"})
+	var te *ToolError
+	if !errors.As(err, &te) {
+		t.Fatalf("err = %v, want an APPROVAL_REQUIRED error", err)
+	}
+	if _, _, err := q.Approve(clientContext("reviewer"), nil, te.ApprovalID); err != nil {
+		t.Fatal(err)
+	}
+
+	events, err := audit.List(AuditFilter{}, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(events) != 1 || events[0].Tool != "create_tag" || events[0].User != "client:claude" || events[0].ClientID != "claude" || events[0].Result != AuditSuccess {
+		t.Errorf("events = %+v, want the approved create_tag recorded for its requester", events)
+	}
+}
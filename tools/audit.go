@@ -0,0 +1,352 @@
+package tools
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"os"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/paolobietolini/gtm-mcp-server/auth"
+	"github.com/paolobietolini/gtm-mcp-server/gtm"
+	"github.com/paolobietolini/gtm-mcp-server/middleware"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+const (
+	// maxAuditEvents bounds the number of audit events kept in memory.
+	maxAuditEvents = 1000
+	// maxAuditString truncates long input strings (e.g. imported container JSON).
+	maxAuditString = 200
+	// auditWebhookTimeout bounds each webhook delivery.
+	auditWebhookTimeout = 10 * time.Second
+)
+
+// AuditEvent records one call to a mutating tool.
+type AuditEvent struct {
+	Time        time.Time      `json:"time"`
+	Tool        string         `json:"tool"`
+	ClientID    string         `json:"clientId,omitempty"`
+	User        string         `json:"user,omitempty"` // caller's Google grant or client, see userKey
+	SessionID   string         `json:"sessionId,omitempty"`
+	RequestID   string         `json:"requestId,omitempty"`
+	AccountID   string         `json:"accountId,omitempty"`
+	ContainerID string         `json:"containerId,omitempty"`
+	WorkspaceID string         `json:"workspaceId,omitempty"`
+	Path        string         `json:"path,omitempty"`
+	Input       map[string]any `json:"input,omitempty"`
+	Result      string         `json:"result"`
+	Error       string         `json:"error,omitempty"`
+	DurationMs  int64          `json:"durationMs"`
+}
+
+// Audit event results.
+const (
+	AuditSuccess = "success"
+	AuditError   = "error"
+)
+
+// AuditOptions configures the sinks of an AuditLog.
+type AuditOptions struct {
+	// File is an append-only JSON Lines file. Empty keeps events in memory only.
+	File string
+
+	// WebhookURL receives each event as a JSON POST. Empty disables the webhook.
+	WebhookURL string
+}
+
+// AuditFilter selects audit events. Empty fields match every event.
+type AuditFilter struct {
+	AccountID   string
+	ContainerID string
+	WorkspaceID string
+	Tool        string
+	ClientID    string
+	User        string
+	Since       time.Time
+}
+
+func (f AuditFilter) match(ev AuditEvent) bool {
+	return (f.AccountID == "" || ev.AccountID == f.AccountID) &&
+		(f.ContainerID == "" || ev.ContainerID == f.ContainerID) &&
+		(f.WorkspaceID == "" || ev.WorkspaceID == f.WorkspaceID) &&
+		(f.Tool == "" || ev.Tool == f.Tool) &&
+		(f.ClientID == "" || ev.ClientID == f.ClientID) &&
+		(f.User == "" || ev.User == f.User) &&
+		(f.Since.IsZero() || !ev.Time.Before(f.Since))
+}
+
+// AuditLog records mutating tool calls. Recent events are kept in memory;
+// with a file configured every event is also appended to it, and with a
+// webhook configured every event is posted to it.
+type AuditLog struct {
+	mu     sync.Mutex
+	events []AuditEvent // oldest first, at most maxAuditEvents
+	file   string
+	hook   string
+	client *http.Client
+	logger *slog.Logger
+}
+
+// NewAuditLog creates an audit log with the given sinks. The file is opened
+// once to check that it is writable.
+func NewAuditLog(opts AuditOptions, logger *slog.Logger) (*AuditLog, error) {
+	if opts.File != "" {
+		f, err := os.OpenFile(opts.File, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+		if err != nil {
+			return nil, fmt.Errorf("failed to open audit log file: %w", err)
+		}
+		f.Close()
+	}
+	return &AuditLog{
+		file:   opts.File,
+		hook:   opts.WebhookURL,
+		client: &http.Client{Timeout: auditWebhookTimeout},
+		logger: logger,
+	}, nil
+}
+
+// Record stores an event in every configured sink. Sink failures are logged
+// rather than failing the tool call that has already run.
+func (a *AuditLog) Record(ev AuditEvent) {
+	line, err := json.Marshal(ev)
+	if err != nil {
+		a.logger.Error("failed to encode audit event", "tool", ev.Tool, "error", err)
+		return
+	}
+
+	a.mu.Lock()
+	a.events = append(a.events, ev)
+	if len(a.events) > maxAuditEvents {
+		a.events = a.events[len(a.events)-maxAuditEvents:]
+	}
+	if a.file != "" {
+		if err := appendLine(a.file, line); err != nil {
+			a.logger.Error("failed to write audit event", "tool", ev.Tool, "error", err)
+		}
+	}
+	a.mu.Unlock()
+
+	if a.hook != "" {
+		go a.post(line)
+	}
+}
+
+func appendLine(path string, line []byte) error {
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(append(line, '\n')); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+func (a *AuditLog) post(body []byte) {
+	resp, err := a.client.Post(a.hook, "application/json", bytes.NewReader(body))
+	if err != nil {
+		a.logger.Error("failed to deliver audit event", "error", err)
+		return
+	}
+	resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		a.logger.Error("audit webhook rejected event", "status", resp.StatusCode)
+	}
+}
+
+// List returns up to limit events matching filter, newest first. With a file
+// configured the file is read, so events from before a restart are included.
+func (a *AuditLog) List(filter AuditFilter, limit int) ([]AuditEvent, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	events := a.events
+	if a.file != "" {
+		var err error
+		if events, err = readAuditFile(a.file); err != nil {
+			return nil, err
+		}
+	}
+
+	matched := make([]AuditEvent, 0)
+	for i := len(events) - 1; i >= 0 && len(matched) < limit; i-- {
+		if filter.match(events[i]) {
+			matched = append(matched, events[i])
+		}
+	}
+	return matched, nil
+}
+
+func readAuditFile(path string) ([]AuditEvent, error) {
+	f, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read audit log file: %w", err)
+	}
+	defer f.Close()
+
+	var events []AuditEvent
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1<<20)
+	for scanner.Scan() {
+		var ev AuditEvent
+		if json.Unmarshal(scanner.Bytes(), &ev) == nil {
+			events = append(events, ev)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read audit log file: %w", err)
+	}
+	return events, nil
+}
+
+// withAudit records every call of a mutating tool, including calls rejected
+// by the read-only and scope guards.
+func withAudit[In, Out any](audit *AuditLog, name string, handler mcp.ToolHandlerFor[In, Out]) mcp.ToolHandlerFor[In, Out] {
+	if audit == nil {
+		return handler
+	}
+	return func(ctx context.Context, req *mcp.CallToolRequest, input In) (*mcp.CallToolResult, Out, error) {
+		start := time.Now()
+		result, output, err := handler(ctx, req, input)
+
+		in := toJSONMap(input)
+		ev := AuditEvent{
+			Time:        start.UTC(),
+			Tool:        name,
+			User:        userKey(ctx),
+			SessionID:   gtm.SessionFromContext(ctx),
+			RequestID:   middleware.RequestIDFromContext(ctx),
+			AccountID:   stringField(in, "accountId"),
+			ContainerID: stringField(in, "containerId"),
+			WorkspaceID: stringField(in, "workspaceId"),
+			Path:        auditPath(in, toJSONMap(output)),
+			Input:       summarizeInput(in),
+			Result:      AuditSuccess,
+			DurationMs:  time.Since(start).Milliseconds(),
+		}
+		if tokenInfo := auth.GetTokenInfo(ctx); tokenInfo != nil {
+			ev.ClientID = tokenInfo.ClientID
+		}
+		if err != nil {
+			ev.Result = AuditError
+			ev.Error = err.Error()
+		} else if result != nil && result.IsError {
+			ev.Result = AuditError
+		}
+		audit.Record(ev)
+
+		return result, output, err
+	}
+}
+
+// toJSONMap converts a tool input or output to its JSON object form.
+func toJSONMap(v any) map[string]any {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil
+	}
+	var m map[string]any
+	json.Unmarshal(data, &m)
+	return m
+}
+
+func stringField(m map[string]any, key string) string {
+	s, _ := m[key].(string)
+	return s
+}
+
+// auditPath returns the path of the changed entity: the output's path, the
+// path of an entity nested in the output, the input's path, or the path
+// built from the input IDs.
+func auditPath(input, output map[string]any) string {
+	if p := stringField(output, "path"); p != "" {
+		return p
+	}
+	keys := make([]string, 0, len(output))
+	for k := range output {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		if nested, ok := output[k].(map[string]any); ok {
+			if p := stringField(nested, "path"); p != "" {
+				return p
+			}
+		}
+	}
+	if p := stringField(input, "path"); p != "" {
+		return p
+	}
+
+	path := ""
+	for _, seg := range []struct{ name, key string }{
+		{"accounts", "accountId"},
+		{"containers", "containerId"},
+		{"workspaces", "workspaceId"},
+	} {
+		id := stringField(input, seg.key)
+		if id == "" {
+			break
+		}
+		if path != "" {
+			path += "/"
+		}
+		path += seg.name + "/" + id
+	}
+	return path
+}
+
+// summarizeInput copies a tool input with sensitive values redacted and
+// long strings truncated.
+func summarizeInput(input map[string]any) map[string]any {
+	if len(input) == 0 {
+		return nil
+	}
+	return summarizeValue(input, false).(map[string]any)
+}
+
+func summarizeValue(v any, hide bool) any {
+	switch v := v.(type) {
+	case map[string]any:
+		row := false
+		for k, name := range v {
+			if s, ok := name.(string); ok && gtm.IsRowNameKey(k) && gtm.IsSensitiveKey(s) {
+				row = true
+			}
+		}
+		out := make(map[string]any, len(v))
+		for k, val := range v {
+			out[k] = summarizeValue(val, hide || gtm.IsSensitiveKey(k) || (row && k == "value"))
+		}
+		return out
+	case []any:
+		out := make([]any, len(v))
+		for i, val := range v {
+			out[i] = summarizeValue(val, hide)
+		}
+		return out
+	case string:
+		if hide && v != "" && !gtm.IsVariableReference(v) {
+			return gtm.RedactedValue
+		}
+		if r := []rune(v); len(r) > maxAuditString {
+			return string(r[:maxAuditString]) + "…"
+		}
+		return v
+	default:
+		return v
+	}
+}
@@ -0,0 +1,267 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/paolobietolini/gtm-mcp-server/auth"
+	"github.com/paolobietolini/gtm-mcp-server/gtm"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+func newTestAuditLog(t *testing.T, opts AuditOptions) *AuditLog {
+	t.Helper()
+	audit, err := NewAuditLog(opts, slog.New(slog.NewTextHandler(io.Discard, nil)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return audit
+}
+
+func TestWithAudit_RecordsCall(t *testing.T) {
+	audit := newTestAuditLog(t, AuditOptions{})
+
+	type input struct {
+		AccountID    string           `json:"accountId"`
+		ContainerID  string           `json:"containerId"`
+		WorkspaceID  string           `json:"workspaceId"`
+		Name         string           `json:"name"`
+		Parameters   []map[string]any `json:"parameters"`
+		ConfirmToken string           `json:"confirmToken"`
+	}
+	type output struct {
+		Tag struct {
+			Path string `json:"path"`
+		} `json:"tag"`
+	}
+
+	handler := withAudit(audit, "create_tag", func(ctx context.Context, req *mcp.CallToolRequest, in input) (*mcp.CallToolResult, output, error) {
+		var out output
+		out.Tag.Path = "accounts/1/containers/2/workspaces/3/tags/4"
+		return nil, out, nil
+	})
+
+	ctx := context.WithValue(context.Background(), auth.TokenInfoKey, &auth.TokenInfo{ClientID: "client-1"})
+	ctx = gtm.WithSession(ctx, "session-1")
+	_, _, err := handler(ctx, nil, input{
+		AccountID:   "1",
+		ContainerID: "2",
+		WorkspaceID: "3",
+		Name:        strings.Repeat("x", maxAuditString+10),
+		Parameters: []map[string]any{
+			{"type": "template", "key": "measurementId", "value": "G-123"},
+			{"type": "template", "key": "apiSecret", "value": "s3cr3t"},
+		},
+		ConfirmToken: "tok",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	events, err := audit.List(AuditFilter{}, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(events) != 1 {
+		t.Fatalf("got %d events, want 1", len(events))
+	}
+	ev := events[0]
+	if ev.Tool != "create_tag" || ev.ClientID != "client-1" || ev.SessionID != "session-1" || ev.Result != AuditSuccess {
+		t.Errorf("event = %+v", ev)
+	}
+	if ev.AccountID != "1" || ev.ContainerID != "2" || ev.WorkspaceID != "3" || ev.Path != "accounts/1/containers/2/workspaces/3/tags/4" {
+		t.Errorf("event location = %+v", ev)
+	}
+
+	data, _ := json.Marshal(ev.Input)
+	if strings.Contains(string(data), "s3cr3t") || strings.Contains(string(data), `"tok"`) {
+		t.Errorf("sensitive input was not redacted: %s", data)
+	}
+	if !strings.Contains(string(data), "G-123") {
+		t.Errorf("non-sensitive input was redacted: %s", data)
+	}
+	if name := ev.Input["name"].(string); len([]rune(name)) != maxAuditString+1 {
+		t.Errorf("long input was not truncated: %d runes", len([]rune(name)))
+	}
+}
+
+func TestWithAudit_RecordsError(t *testing.T) {
+	audit := newTestAuditLog(t, AuditOptions{})
+	handler := withAudit(audit, "delete_tag", func(ctx context.Context, req *mcp.CallToolRequest, in map[string]string) (*mcp.CallToolResult, struct{}, error) {
+		return nil, struct{}{}, gtm.ErrReadOnly
+	})
+
+	_, _, err := handler(context.Background(), nil, map[string]string{"accountId": "1", "containerId": "2"})
+	if !errors.Is(err, gtm.ErrReadOnly) {
+		t.Fatalf("error = %v", err)
+	}
+
+	events, _ := audit.List(AuditFilter{}, 10)
+	if len(events) != 1 || events[0].Result != AuditError || events[0].Error == "" || events[0].Path != "accounts/1/containers/2" {
+		t.Errorf("events = %+v", events)
+	}
+}
+
+func TestAuditLog_FileAndFilter(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.jsonl")
+	audit := newTestAuditLog(t, AuditOptions{File: path})
+
+	start := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+	audit.Record(AuditEvent{Time: start, Tool: "create_tag", AccountID: "1", Result: AuditSuccess})
+	audit.Record(AuditEvent{Time: start.Add(time.Hour), Tool: "publish_version", AccountID: "1", Result: AuditSuccess})
+	audit.Record(AuditEvent{Time: start.Add(2 * time.Hour), Tool: "create_tag", AccountID: "2", Result: AuditSuccess})
+
+	// A new log over the same file sees the earlier events
+	reopened := newTestAuditLog(t, AuditOptions{File: path})
+
+	tests := []struct {
+		name   string
+		filter AuditFilter
+		limit  int
+		want   []string
+	}{
+		{name: "all newest first", limit: 10, want: []string{"create_tag", "publish_version", "create_tag"}},
+		{name: "limit", limit: 1, want: []string{"create_tag"}},
+		{name: "tool", filter: AuditFilter{Tool: "publish_version"}, limit: 10, want: []string{"publish_version"}},
+		{name: "account", filter: AuditFilter{AccountID: "1"}, limit: 10, want: []string{"publish_version", "create_tag"}},
+		{name: "since", filter: AuditFilter{Since: start.Add(time.Hour)}, limit: 10, want: []string{"create_tag", "publish_version"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			events, err := reopened.List(tt.filter, tt.limit)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var got []string
+			for _, ev := range events {
+				got = append(got, ev.Tool)
+			}
+			if strings.Join(got, ",") != strings.Join(tt.want, ",") {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAuditLog_Webhook(t *testing.T) {
+	received := make(chan AuditEvent, 1)
+	hook := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var ev AuditEvent
+		json.NewDecoder(r.Body).Decode(&ev)
+		received <- ev
+	}))
+	defer hook.Close()
+
+	audit := newTestAuditLog(t, AuditOptions{WebhookURL: hook.URL})
+	audit.Record(AuditEvent{Time: time.Now(), Tool: "publish_version", Result: AuditSuccess})
+
+	select {
+	case ev := <-received:
+		if ev.Tool != "publish_version" {
+			t.Errorf("webhook event = %+v", ev)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("webhook was not called")
+	}
+}
+
+func TestRegisterTools_AuditsMutatingToolsOnly(t *testing.T) {
+	audit := newTestAuditLog(t, AuditOptions{})
+	ctx := context.Background()
+	server := mcp.NewServer(&mcp.Implementation{Name: "test", Version: "0.0.0"}, nil)
+	if err := RegisterTools(server, ToolOptions{ReadOnly: true, Audit: audit}); err != nil {
+		t.Fatalf("RegisterTools: %v", err)
+	}
+	serverTransport, clientTransport := mcp.NewInMemoryTransports()
+	serverSession, err := server.Connect(ctx, serverTransport, nil)
+	if err != nil {
+		t.Fatalf("server connect: %v", err)
+	}
+	defer serverSession.Close()
+	client := mcp.NewClient(&mcp.Implementation{Name: "test-client", Version: "0.0.0"}, nil)
+	session, err := client.Connect(ctx, clientTransport, nil)
+	if err != nil {
+		t.Fatalf("client connect: %v", err)
+	}
+	defer session.Close()
+
+	session.CallTool(ctx, &mcp.CallToolParams{Name: "create_workspace", Arguments: map[string]any{"accountId": "1", "containerId": "2", "name": "ws"}})
+	session.CallTool(ctx, &mcp.CallToolParams{Name: "list_audit_events", Arguments: map[string]any{}})
+
+	res, err := session.CallTool(ctx, &mcp.CallToolParams{Name: "list_audit_events", Arguments: map[string]any{"tool": "create_workspace"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var out ListAuditEventsOutput
+	data, _ := json.Marshal(res.StructuredContent)
+	if err := json.Unmarshal(data, &out); err != nil {
+		t.Fatal(err)
+	}
+	if out.Count != 1 || out.Events[0].Result != AuditError {
+		t.Errorf("output = %+v", out)
+	}
+
+	all, _ := audit.List(AuditFilter{}, 10)
+	if len(all) != 1 {
+		t.Errorf("read tools must not be audited, got %d events", len(all))
+	}
+}
+
+func TestListAuditEvents_OnlyCallersEvents(t *testing.T) {
+	audit := newTestAuditLog(t, AuditOptions{})
+	record := withAudit(audit, "create_tag", func(ctx context.Context, req *mcp.CallToolRequest, in struct{}) (*mcp.CallToolResult, struct{}, error) {
+		return nil, struct{}{}, nil
+	})
+	record(clientContext("alice"), nil, struct{}{})
+	record(clientContext("bob"), nil, struct{}{})
+	record(clientContext("bob"), nil, struct{}{})
+
+	server := mcp.NewServer(&mcp.Implementation{Name: "test", Version: "0.0.0"}, nil)
+	if err := RegisterTools(server, ToolOptions{Audit: audit}); err != nil {
+		t.Fatalf("RegisterTools: %v", err)
+	}
+	ctx := context.Background()
+	serverTransport, clientTransport := mcp.NewInMemoryTransports()
+	serverSession, err := server.Connect(clientContext("alice"), serverTransport, nil)
+	if err != nil {
+		t.Fatalf("server connect: %v", err)
+	}
+	defer serverSession.Close()
+	client := mcp.NewClient(&mcp.Implementation{Name: "test-client", Version: "0.0.0"}, nil)
+	session, err := client.Connect(ctx, clientTransport, nil)
+	if err != nil {
+		t.Fatalf("client connect: %v", err)
+	}
+	defer session.Close()
+
+	list := func(args map[string]any) ListAuditEventsOutput {
+		res, err := session.CallTool(ctx, &mcp.CallToolParams{Name: "list_audit_events", Arguments: args})
+		if err != nil {
+			t.Fatal(err)
+		}
+		var out ListAuditEventsOutput
+		data, _ := json.Marshal(res.StructuredContent)
+		if err := json.Unmarshal(data, &out); err != nil {
+			t.Fatal(err)
+		}
+		return out
+	}
+
+	if out := list(map[string]any{}); out.Count != 1 || out.Events[0].ClientID != "alice" {
+		t.Errorf("alice sees %+v", out.Events)
+	}
+	// Filtering by another client does not reveal its events
+	if out := list(map[string]any{"clientId": "bob"}); out.Count != 0 {
+		t.Errorf("alice sees bob's events: %+v", out.Events)
+	}
+}
@@ -0,0 +1,68 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/paolobietolini/gtm-mcp-server/auth"
+	"github.com/paolobietolini/gtm-mcp-server/gtm"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// toolGoogleScopes lists the Google scopes of mutating tools that need more
+// than tagmanager.edit.containers, and of tools calling other Google APIs.
+// Other read tools work with any Tag Manager scope.
+var toolGoogleScopes = map[string][]string{
+	"create_version":        {auth.GoogleScopeEditVersions},
+	"publish_version":       {auth.GoogleScopePublish},
+	"release_workspace":     {auth.GoogleScopeEditVersions, auth.GoogleScopePublish},
+	"create_and_publish":    {auth.GoogleScopeEditVersions, auth.GoogleScopePublish},
+	"promote_canary":        {auth.GoogleScopePublish},
+	"rollback_to_version":   {auth.GoogleScopeEditVersions, auth.GoogleScopePublish},
+	"schedule_publish":      {auth.GoogleScopePublish},
+	"delete_container":      {auth.GoogleScopeDeleteContainers},
+	"list_ga4_properties":   {auth.GoogleScopeAnalyticsReadonly},
+	"list_ga4_data_streams": {auth.GoogleScopeAnalyticsReadonly},
+}
+
+// requiredGoogleScopes returns the Google scopes a tool needs.
+func requiredGoogleScopes(name string) []string {
+	if scopes, ok := toolGoogleScopes[name]; ok {
+		return scopes
+	}
+	if readTools[name] {
+		return nil
+	}
+	return []string{auth.GoogleScopeEditContainers}
+}
+
+// missingGoogleScope returns the first scope the tool needs that is not in
+// granted, or "" when granted is nil (every scope) or covers the tool.
+func missingGoogleScope(name string, granted []string) string {
+	if granted == nil {
+		return ""
+	}
+	for _, scope := range requiredGoogleScopes(name) {
+		if !slices.Contains(granted, scope) {
+			return scope
+		}
+	}
+	return ""
+}
+
+// missingScopeHandler replaces the handler of a tool whose Google scope the
+// server does not request, so calls fail with an actionable error instead of
+// a bare 403 from the API.
+func missingScopeHandler[In, Out any](name, scope string) mcp.ToolHandlerFor[In, Out] {
+	short := auth.GoogleScopeShortNames([]string{scope})[0]
+	scopeName, instead := "tagmanager."+short, "make this change in the Tag Manager UI"
+	if scope == auth.GoogleScopeAnalyticsReadonly {
+		scopeName, instead = short, "look the measurement ID up in the Google Analytics admin"
+	}
+	return func(ctx context.Context, req *mcp.CallToolRequest, input In) (*mcp.CallToolResult, Out, error) {
+		var zero Out
+		return nil, zero, fmt.Errorf("%w: %s needs the Google scope %s, which this server does not request; ask the server administrator to add %s to GOOGLE_SCOPES, or %s", gtm.ErrMissingScope, name, scopeName, short, instead)
+	}
+}
@@ -1,13 +1,14 @@
-package gtm
+package tools
 
 import (
 	"context"
-	"fmt"
+
+	"github.com/paolobietolini/gtm-mcp-server/gtm"
 )
 
 // WorkspaceContext holds a validated workspace path and an authenticated GTM client.
 type WorkspaceContext struct {
-	Client      *Client
+	Client      *gtm.Client
 	AccountID   string
 	ContainerID string
 	WorkspaceID string
@@ -15,7 +16,7 @@ type WorkspaceContext struct {
 
 // ContainerContext holds a validated container path and an authenticated GTM client.
 type ContainerContext struct {
-	Client      *Client
+	Client      *gtm.Client
 	AccountID   string
 	ContainerID string
 }
@@ -23,7 +24,7 @@ type ContainerContext struct {
 // resolveWorkspace validates the workspace path IDs and creates an authenticated GTM client.
 // Use this in any tool handler that operates at the workspace level.
 func resolveWorkspace(ctx context.Context, accountID, containerID, workspaceID string) (*WorkspaceContext, error) {
-	if err := ValidateWorkspacePath(accountID, containerID, workspaceID); err != nil {
+	if err := gtm.ValidateWorkspacePath(accountID, containerID, workspaceID); err != nil {
 		return nil, err
 	}
 
@@ -43,7 +44,7 @@ func resolveWorkspace(ctx context.Context, accountID, containerID, workspaceID s
 // resolveContainer validates the container path IDs and creates an authenticated GTM client.
 // Use this in any tool handler that operates at the container level.
 func resolveContainer(ctx context.Context, accountID, containerID string) (*ContainerContext, error) {
-	if err := ValidateContainerPath(accountID, containerID); err != nil {
+	if err := gtm.ValidateContainerPath(accountID, containerID); err != nil {
 		return nil, err
 	}
 
@@ -61,9 +62,9 @@ func resolveContainer(ctx context.Context, accountID, containerID string) (*Cont
 
 // resolveAccount validates the account ID and creates an authenticated GTM client.
 // Use this in any tool handler that operates at the account level.
-func resolveAccount(ctx context.Context, accountID string) (*Client, error) {
+func resolveAccount(ctx context.Context, accountID string) (*gtm.Client, error) {
 	if accountID == "" {
-		return nil, fmt.Errorf("account ID is required")
+		return nil, gtm.InvalidField("accountId", "account ID is required")
 	}
 
 	return getClient(ctx)
@@ -71,10 +72,10 @@ func resolveAccount(ctx context.Context, accountID string) (*Client, error) {
 
 // WorkspacePath returns the formatted workspace path string.
 func (wc *WorkspaceContext) WorkspacePath() string {
-	return BuildWorkspacePath(wc.AccountID, wc.ContainerID, wc.WorkspaceID)
+	return gtm.BuildWorkspacePath(wc.AccountID, wc.ContainerID, wc.WorkspaceID)
 }
 
 // ContainerPath returns the formatted container path string.
 func (cc *ContainerContext) ContainerPath() string {
-	return BuildContainerPath(cc.AccountID, cc.ContainerID)
+	return gtm.BuildContainerPath(cc.AccountID, cc.ContainerID)
 }
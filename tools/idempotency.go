@@ -0,0 +1,192 @@
+package tools
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"sync"
+	"time"
+
+	"github.com/paolobietolini/gtm-mcp-server/gtm"
+
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+const (
+	// idempotencyTTL is how long the result of a call with an idempotency
+	// key is returned to replays of that call.
+	idempotencyTTL = time.Hour
+	// maxIdempotencyKeys bounds how many results are kept in memory.
+	maxIdempotencyKeys = 1000
+	// maxIdempotencyKeyLength bounds the length of a client's key.
+	maxIdempotencyKeyLength = 255
+)
+
+// idempotencyKeySchema describes the idempotencyKey argument every mutating
+// tool accepts.
+var idempotencyKeySchema = &jsonschema.Schema{
+	Type:        "string",
+	MaxLength:   jsonschema.Ptr(maxIdempotencyKeyLength),
+	Description: "Unique key for this change, e.g. a UUID (optional). A call repeated with the same key and arguments within an hour returns the first call's result instead of running again, so retries never create duplicates.",
+}
+
+// idempotencyStore remembers the results of mutating tool calls made with an
+// idempotency key, per user, tool and key.
+type idempotencyStore struct {
+	mu      sync.Mutex
+	results map[string]*idempotentCall
+	now     func() time.Time
+}
+
+// idempotentCall is a call in flight or its successful result.
+type idempotentCall struct {
+	inputHash string
+	done      chan struct{} // closed when the call finishes
+	ok        bool          // the call succeeded and result and output are set
+	result    *mcp.CallToolResult
+	output    any
+	expiresAt time.Time
+}
+
+func newIdempotencyStore() *idempotencyStore {
+	return &idempotencyStore{results: make(map[string]*idempotentCall), now: time.Now}
+}
+
+// withIdempotency returns the stored result when a call repeats the
+// idempotency key of an earlier successful call with the same arguments. A
+// repeat that arrives while the first call is running waits for it. Failed
+// calls are not stored, so they can be retried with the same key.
+func withIdempotency[In, Out any](store *idempotencyStore, name string, handler mcp.ToolHandlerFor[In, Out]) mcp.ToolHandlerFor[In, Out] {
+	if store == nil {
+		return handler
+	}
+	return func(ctx context.Context, req *mcp.CallToolRequest, input In) (*mcp.CallToolResult, Out, error) {
+		var zero Out
+		key, err := idempotencyKey(req)
+		if err != nil {
+			return nil, zero, err
+		}
+		if key == "" {
+			return handler(ctx, req, input)
+		}
+
+		key = userKey(ctx) + "\x00" + name + "\x00" + key
+		call, owner, err := store.begin(ctx, key, inputHash(input))
+		if err != nil {
+			return nil, zero, err
+		}
+		if !owner {
+			out, _ := call.output.(Out)
+			return call.result, out, nil
+		}
+
+		result, out, err := handler(ctx, req, input)
+		store.finish(key, call, result, out, err == nil && (result == nil || !result.IsError))
+		return result, out, err
+	}
+}
+
+// idempotencyKey returns the idempotencyKey argument of a tool call, if any.
+func idempotencyKey(req *mcp.CallToolRequest) (string, error) {
+	if req == nil || req.Params == nil || len(req.Params.Arguments) == 0 {
+		return "", nil
+	}
+	var args struct {
+		IdempotencyKey string `json:"idempotencyKey"`
+	}
+	if err := json.Unmarshal(req.Params.Arguments, &args); err != nil {
+		return "", nil
+	}
+	if len(args.IdempotencyKey) > maxIdempotencyKeyLength {
+		return "", gtm.InvalidField("idempotencyKey", "idempotencyKey is longer than %d characters", maxIdempotencyKeyLength)
+	}
+	return args.IdempotencyKey, nil
+}
+
+// inputHash identifies the arguments of a call, so a key reused for a
+// different change is rejected rather than answered with the wrong result.
+func inputHash(input any) string {
+	data, _ := json.Marshal(toJSONMap(input))
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// begin returns the call stored under key. owner is true if there was none
+// and the caller must run the call and finish it; otherwise the returned
+// call holds a successful result.
+func (s *idempotencyStore) begin(ctx context.Context, key, hash string) (call *idempotentCall, owner bool, err error) {
+	for {
+		s.mu.Lock()
+		s.evict()
+		call, ok := s.results[key]
+		if !ok {
+			call = &idempotentCall{inputHash: hash, done: make(chan struct{})}
+			s.results[key] = call
+			s.mu.Unlock()
+			return call, true, nil
+		}
+		s.mu.Unlock()
+
+		if call.inputHash != hash {
+			return nil, false, gtm.InvalidField("idempotencyKey", "this idempotencyKey was already used with different arguments; use a new key for a different change")
+		}
+		select {
+		case <-call.done:
+		case <-ctx.Done():
+			return nil, false, ctx.Err()
+		}
+		if call.ok {
+			return call, false, nil
+		}
+		// The first call failed and was dropped: run this one instead
+	}
+}
+
+// finish stores the result of a successful call, or drops a failed one.
+func (s *idempotencyStore) finish(key string, call *idempotentCall, result *mcp.CallToolResult, output any, ok bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if ok {
+		call.ok = true
+		call.result = result
+		call.output = output
+		call.expiresAt = s.now().Add(idempotencyTTL)
+	} else if s.results[key] == call {
+		delete(s.results, key)
+	}
+	close(call.done)
+}
+
+// evict drops expired results, and the oldest result when the store is
+// full. Calls in flight are kept. It must be called with s.mu held.
+func (s *idempotencyStore) evict() {
+	now := s.now()
+	var oldest string
+	for k, c := range s.results {
+		if c.expiresAt.IsZero() {
+			continue
+		}
+		if now.After(c.expiresAt) {
+			delete(s.results, k)
+		} else if oldest == "" || c.expiresAt.Before(s.results[oldest].expiresAt) {
+			oldest = k
+		}
+	}
+	if len(s.results) >= maxIdempotencyKeys && oldest != "" {
+		delete(s.results, oldest)
+	}
+}
+
+// addIdempotencyKey adds the idempotencyKey argument to a tool's input schema.
+func addIdempotencyKey(tool *mcp.Tool) {
+	schema, ok := tool.InputSchema.(*jsonschema.Schema)
+	if !ok || schema.Properties["idempotencyKey"] != nil {
+		return
+	}
+	if schema.Properties == nil {
+		schema.Properties = make(map[string]*jsonschema.Schema)
+	}
+	schema.Properties["idempotencyKey"] = idempotencyKeySchema
+}
@@ -0,0 +1,110 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/paolobietolini/gtm-mcp-server/gtm"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+func idempotentRequest(t *testing.T, args map[string]any) *mcp.CallToolRequest {
+	t.Helper()
+	data, err := json.Marshal(args)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &mcp.CallToolRequest{Params: &mcp.CallToolParamsRaw{Arguments: data}}
+}
+
+func TestWithIdempotency_Replays(t *testing.T) {
+	runs := 0
+	fail := false
+	handler := withIdempotency(newIdempotencyStore(), "create_tag", func(ctx context.Context, req *mcp.CallToolRequest, input approvalTestInput) (*mcp.CallToolResult, string, error) {
+		runs++
+		if fail {
+			return nil, "", errors.New("boom")
+		}
+		return nil, "created " + input.Name, nil
+	})
+	ctx := clientContext("claude")
+	input := approvalTestInput{AccountID: "1", Name: "GA4"}
+	req := idempotentRequest(t, map[string]any{"accountId": "1", "name": "GA4", "idempotencyKey": "k1"})
+
+	for range 2 {
+		if _, out, err := handler(ctx, req, input); err != nil || out != "created GA4" {
+			t.Fatalf("out = %q, err = %v", out, err)
+		}
+	}
+	if runs != 1 {
+		t.Errorf("runs = %d, want 1", runs)
+	}
+
+	// The same key with different arguments is rejected
+	if _, _, err := handler(ctx, req, approvalTestInput{AccountID: "1", Name: "Other"}); !errors.Is(err, gtm.ErrInvalidRequest) {
+		t.Errorf("reused key: err = %v", err)
+	}
+	// Other users and calls without a key run
+	handler(clientContext("other"), req, input)
+	handler(ctx, idempotentRequest(t, map[string]any{"name": "GA4"}), input)
+	if runs != 3 {
+		t.Errorf("runs = %d, want 3", runs)
+	}
+
+	// Failed calls are not remembered
+	fail = true
+	req = idempotentRequest(t, map[string]any{"idempotencyKey": "k2"})
+	handler(ctx, req, input)
+	fail = false
+	if _, out, err := handler(ctx, req, input); err != nil || out != "created GA4" || runs != 5 {
+		t.Errorf("retry after failure: out = %q, err = %v, runs = %d", out, err, runs)
+	}
+}
+
+func TestWithIdempotency_WaitsForCallInFlight(t *testing.T) {
+	release := make(chan struct{})
+	var mu sync.Mutex
+	runs := 0
+	handler := withIdempotency(newIdempotencyStore(), "create_tag", func(ctx context.Context, req *mcp.CallToolRequest, input approvalTestInput) (*mcp.CallToolResult, string, error) {
+		mu.Lock()
+		runs++
+		mu.Unlock()
+		<-release
+		return nil, "created", nil
+	})
+	req := idempotentRequest(t, map[string]any{"idempotencyKey": "k"})
+
+	var wg sync.WaitGroup
+	outs := make([]string, 3)
+	for i := range outs {
+		wg.Go(func() {
+			_, outs[i], _ = handler(clientContext("claude"), req, approvalTestInput{Name: "GA4"})
+		})
+	}
+	close(release)
+	wg.Wait()
+	if runs != 1 || outs[0] != "created" || outs[1] != "created" || outs[2] != "created" {
+		t.Errorf("runs = %d, outs = %v", runs, outs)
+	}
+}
+
+func TestRegisterTools_IdempotencyKeyOnMutatingTools(t *testing.T) {
+	tools := registeredTools(t, ToolOptions{})
+	hasKey := func(name string) bool {
+		schema, _ := tools[name].InputSchema.(map[string]any)
+		props, _ := schema["properties"].(map[string]any)
+		return props["idempotencyKey"] != nil
+	}
+	for _, name := range []string{"create_tag", "update_trigger", "delete_variable", "publish_version"} {
+		if !hasKey(name) {
+			t.Errorf("%s has no idempotencyKey", name)
+		}
+	}
+	if hasKey("list_tags") {
+		t.Error("list_tags has an idempotencyKey")
+	}
+}
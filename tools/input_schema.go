@@ -0,0 +1,51 @@
+package tools
+
+import (
+	"reflect"
+
+	"github.com/paolobietolini/gtm-mcp-server/gtm"
+
+	"github.com/google/jsonschema-go/jsonschema"
+)
+
+// parameterSchemaDepth is how many levels of list and map nesting the
+// Parameter input schema spells out. GTM parameters rarely nest deeper
+// than a list of maps; deeper items are accepted as any object.
+const parameterSchemaDepth = 4
+
+// inputTypeSchemas are the schemas of tool input types that inference
+// cannot derive. Parameter is recursive, which inference rejects.
+var inputTypeSchemas = map[reflect.Type]*jsonschema.Schema{
+	reflect.TypeFor[gtm.Parameter](): parameterSchema(parameterSchemaDepth),
+}
+
+// parameterSchema describes a GTM parameter with depth levels of nested
+// list items and map entries.
+func parameterSchema(depth int) *jsonschema.Schema {
+	children := &jsonschema.Schema{Type: "object"}
+	if depth > 0 {
+		children = parameterSchema(depth - 1)
+	}
+	return &jsonschema.Schema{
+		Type:     "object",
+		Required: []string{"type"},
+		Properties: map[string]*jsonschema.Schema{
+			"type":  {Type: "string", Description: "Parameter type: template, boolean, integer, list, map, tagReference or triggerReference"},
+			"key":   {Type: "string", Description: "Parameter key; required except for list items"},
+			"value": {Type: "string", Description: "Value of template, boolean (\"true\"/\"false\"), integer and reference parameters, always as a string"},
+			"list":  {Type: "array", Items: children, Description: "Items of a list parameter (no keys)"},
+			"map":   {Type: "array", Items: children, Description: "Entries of a map parameter (unique keys)"},
+		},
+		AdditionalProperties: &jsonschema.Schema{Not: &jsonschema.Schema{}},
+	}
+}
+
+// inputSchema infers the input schema of a tool from its input type, as
+// mcp.AddTool does, with inputTypeSchemas filled in.
+func inputSchema[In any]() (*jsonschema.Schema, error) {
+	rt := reflect.TypeFor[In]()
+	if rt.Kind() == reflect.Pointer {
+		rt = rt.Elem()
+	}
+	return jsonschema.ForType(rt, &jsonschema.ForOptions{TypeSchemas: inputTypeSchemas})
+}
@@ -0,0 +1,60 @@
+package tools
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestInputSchema_Parameters(t *testing.T) {
+	schema, err := inputSchema[CreateTagInput]()
+	if err != nil {
+		t.Fatal(err)
+	}
+	resolved, err := schema.Resolve(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, tt := range []struct {
+		input string
+		valid bool
+	}{
+		{`{"accountId":"1","containerId":"2","workspaceId":"3","name":"t","type":"html","firingTriggerIds":["4"],
+			"parameters":[{"type":"template","key":"html","value":"<p>"},{"type":"list","key":"l","list":[{"type":"map","map":[{"type":"template","key":"k","value":"v"}]}]}]}`, true},
+		{`{"accountId":"1","containerId":"2","workspaceId":"3","name":"t","type":"html","firingTriggerIds":["4"],
+			"parameters":[{"type":"template","key":"html","values":"<p>"}]}`, false},
+		{`{"accountId":"1","containerId":"2","workspaceId":"3","name":"t","type":"html","firingTriggerIds":["4"],
+			"parameters":[{"key":"html","value":"<p>"}]}`, false},
+	} {
+		var instance map[string]any
+		if err := json.Unmarshal([]byte(tt.input), &instance); err != nil {
+			t.Fatal(err)
+		}
+		if err := resolved.Validate(instance); (err == nil) != tt.valid {
+			t.Errorf("Validate(%s) = %v, want valid %v", tt.input, err, tt.valid)
+		}
+	}
+}
+
+func TestInputSchema_RegisteredTools(t *testing.T) {
+	tools := registeredTools(t, ToolOptions{})
+	for _, name := range []string{"create_tag", "update_tag", "create_trigger", "update_trigger", "create_variable"} {
+		data, err := json.Marshal(tools[name].InputSchema)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var schema struct {
+			Properties map[string]json.RawMessage `json:"properties"`
+		}
+		if err := json.Unmarshal(data, &schema); err != nil {
+			t.Fatal(err)
+		}
+		typed := "parameters"
+		if name == "create_trigger" {
+			typed = "filter"
+		}
+		if schema.Properties[typed] == nil {
+			t.Errorf("%s: no %s property", name, typed)
+		}
+	}
+}
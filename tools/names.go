@@ -0,0 +1,67 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/paolobietolini/gtm-mcp-server/gtm"
+)
+
+// resolveEntityID returns id when given, otherwise looks the entity up by
+// name. Exactly one of id and name must be set.
+func (wc *WorkspaceContext) resolveEntityID(ctx context.Context, kind, id, name string) (string, error) {
+	switch {
+	case id != "" && name != "":
+		return "", gtm.InvalidField(kind+"Name", "provide either %sId or %sName, not both", kind, kind)
+	case id != "":
+		return id, nil
+	case name == "":
+		return "", gtm.InvalidField(kind+"Id", "%sId or %sName is required", kind, kind)
+	}
+
+	var entities []gtm.NamedEntity
+	switch kind {
+	case gtm.EntityTypeTag:
+		tags, err := wc.Client.ListTags(ctx, wc.AccountID, wc.ContainerID, wc.WorkspaceID)
+		if err != nil {
+			return "", err
+		}
+		for _, t := range tags {
+			entities = append(entities, gtm.NamedEntity{ID: t.TagID, Name: t.Name})
+		}
+	case gtm.EntityTypeTrigger:
+		triggers, err := wc.Client.ListTriggers(ctx, wc.AccountID, wc.ContainerID, wc.WorkspaceID)
+		if err != nil {
+			return "", err
+		}
+		for _, t := range triggers {
+			entities = append(entities, gtm.NamedEntity{ID: t.TriggerID, Name: t.Name})
+		}
+	case gtm.EntityTypeVariable:
+		variables, err := wc.Client.ListVariables(ctx, wc.AccountID, wc.ContainerID, wc.WorkspaceID)
+		if err != nil {
+			return "", err
+		}
+		for _, v := range variables {
+			entities = append(entities, gtm.NamedEntity{ID: v.VariableID, Name: v.Name})
+		}
+	default:
+		return "", fmt.Errorf("unsupported entity type %q", kind)
+	}
+	return gtm.MatchEntityName(kind, name, entities)
+}
+
+// resolveTagID returns the tag ID, looking the tag up by name if no ID is given.
+func (wc *WorkspaceContext) resolveTagID(ctx context.Context, id, name string) (string, error) {
+	return wc.resolveEntityID(ctx, gtm.EntityTypeTag, id, name)
+}
+
+// resolveTriggerID returns the trigger ID, looking the trigger up by name if no ID is given.
+func (wc *WorkspaceContext) resolveTriggerID(ctx context.Context, id, name string) (string, error) {
+	return wc.resolveEntityID(ctx, gtm.EntityTypeTrigger, id, name)
+}
+
+// resolveVariableID returns the variable ID, looking the variable up by name if no ID is given.
+func (wc *WorkspaceContext) resolveVariableID(ctx context.Context, id, name string) (string, error) {
+	return wc.resolveEntityID(ctx, gtm.EntityTypeVariable, id, name)
+}
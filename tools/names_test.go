@@ -0,0 +1,18 @@
+package tools
+
+import (
+	"testing"
+)
+
+func TestResolveEntityID_Arguments(t *testing.T) {
+	wc := &WorkspaceContext{}
+	if id, err := wc.resolveTagID(t.Context(), "7", ""); err != nil || id != "7" {
+		t.Errorf("resolveTagID with ID = %q, %v", id, err)
+	}
+	if _, err := wc.resolveTagID(t.Context(), "7", "GA4"); err == nil {
+		t.Error("expected error when both ID and name are given")
+	}
+	if _, err := wc.resolveVariableID(t.Context(), "", ""); err == nil || err.Error() != "variableId or variableName is required" {
+		t.Errorf("missing ID and name: %v", err)
+	}
+}
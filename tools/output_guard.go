@@ -0,0 +1,247 @@
+package tools
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+const (
+	// DefaultMaxOutputBytes is the default limit on a tool result's
+	// serialized size, about 25k tokens.
+	DefaultMaxOutputBytes = 100_000
+
+	// continuationTTL is how long the full output of a truncated result
+	// can be fetched with get_continuation.
+	continuationTTL = 15 * time.Minute
+	// maxContinuations bounds how many full outputs are kept in memory.
+	maxContinuations = 100
+)
+
+// outputGuard truncates tool results larger than limit and keeps the full
+// output so the client can fetch the remainder in chunks.
+type outputGuard struct {
+	limit int
+
+	mu      sync.Mutex
+	outputs map[string]*continuation // keyed by continuation ID
+	now     func() time.Time
+}
+
+type continuation struct {
+	user      string
+	data      []byte
+	expiresAt time.Time
+}
+
+func newOutputGuard(limit int) *outputGuard {
+	return &outputGuard{limit: limit, outputs: make(map[string]*continuation), now: time.Now}
+}
+
+// withOutputGuard truncates the output of a tool call that serializes to
+// more than the guard's limit. The structured result keeps its schema with
+// trailing array items dropped, and the text content carries the first
+// chunk of the full JSON with a continuation token for the rest.
+func withOutputGuard[In, Out any](guard *outputGuard, handler mcp.ToolHandlerFor[In, Out]) mcp.ToolHandlerFor[In, Out] {
+	if guard == nil {
+		return handler
+	}
+	return func(ctx context.Context, req *mcp.CallToolRequest, input In) (*mcp.CallToolResult, Out, error) {
+		res, out, err := handler(ctx, req, input)
+		if err != nil || (res != nil && res.Content != nil) {
+			return res, out, err
+		}
+		data, err := json.Marshal(out)
+		if err != nil || len(data) <= guard.limit {
+			return res, out, nil
+		}
+
+		var shrunk Out
+		if err := json.Unmarshal(shrinkJSON(data, guard.limit), &shrunk); err != nil {
+			return res, out, nil
+		}
+		token := guard.store(userKey(ctx), data)
+		chunk, next := guard.chunk(data, token, 0)
+		return &mcp.CallToolResult{Content: []mcp.Content{&mcp.TextContent{
+			Text: chunk + "\n\n" + continuationNote(len(data), next),
+		}}}, shrunk, nil
+	}
+}
+
+// continuationNote tells the model how to fetch the rest of an output.
+func continuationNote(total int, next string) string {
+	if next == "" {
+		return fmt.Sprintf("[End of output, %d bytes in total.]", total)
+	}
+	return fmt.Sprintf("[Output truncated: it is %d bytes in total. Call get_continuation with token %q for the next chunk, or narrow the request with filters, compact mode or a smaller page size.]", total, next)
+}
+
+// store keeps the full output for user and returns its continuation ID.
+func (g *outputGuard) store(user string, data []byte) string {
+	var b [12]byte
+	rand.Read(b[:])
+	id := hex.EncodeToString(b[:])
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	now := g.now()
+	var oldest string
+	for k, c := range g.outputs {
+		if now.After(c.expiresAt) {
+			delete(g.outputs, k)
+		} else if oldest == "" || c.expiresAt.Before(g.outputs[oldest].expiresAt) {
+			oldest = k
+		}
+	}
+	if len(g.outputs) >= maxContinuations {
+		delete(g.outputs, oldest)
+	}
+	g.outputs[id] = &continuation{user: user, data: data, expiresAt: now.Add(continuationTTL)}
+	return id
+}
+
+// chunk returns up to limit bytes of data starting at offset, and the token
+// of the following chunk, or "" for the last one.
+func (g *outputGuard) chunk(data []byte, id string, offset int) (string, string) {
+	end := min(offset+g.limit, len(data))
+	// Don't split a multi-byte character
+	for end < len(data) && end > offset && !utf8.RuneStart(data[end]) {
+		end--
+	}
+	next := ""
+	if end < len(data) {
+		next = id + "." + strconv.Itoa(end)
+	}
+	return string(data[offset:end]), next
+}
+
+// next returns the chunk of a stored output named by token.
+func (g *outputGuard) next(user, token string) (string, string, int, error) {
+	id, offsetStr, _ := strings.Cut(token, ".")
+	offset, err := strconv.Atoi(offsetStr)
+
+	g.mu.Lock()
+	c, ok := g.outputs[id]
+	if ok && g.now().After(c.expiresAt) {
+		delete(g.outputs, id)
+		ok = false
+	}
+	g.mu.Unlock()
+
+	if !ok || c.user != user {
+		return "", "", 0, fmt.Errorf("continuation %q is unknown or expired; run the original tool again", token)
+	}
+	if err != nil || offset < 0 || offset >= len(c.data) {
+		return "", "", 0, fmt.Errorf("invalid continuation token %q", token)
+	}
+	chunk, next := g.chunk(c.data, id, offset)
+	return chunk, next, len(c.data), nil
+}
+
+// shrinkJSON drops trailing items from the largest arrays in data, and
+// shortens the longest strings if that is not enough, until it serializes
+// to at most limit bytes. The result keeps the shape of data.
+func shrinkJSON(data []byte, limit int) []byte {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	var v any
+	if err := dec.Decode(&v); err != nil {
+		return data
+	}
+
+	for {
+		out, err := json.Marshal(v)
+		if err != nil || len(out) <= limit {
+			return out
+		}
+		excess := len(out) - limit
+
+		if n := longest(v, arrayLen); n > 0 {
+			// Drop items in proportion to the excess, at least one
+			drop := min(n, n*excess/len(out)+1)
+			v, _ = replaceFirst(v, func(x any) bool { return arrayLen(x) == n }, func(x any) any {
+				return x.([]any)[:n-drop]
+			})
+			continue
+		}
+		if n := longest(v, stringLen); n > 0 {
+			v, _ = replaceFirst(v, func(x any) bool { return stringLen(x) == n }, func(x any) any {
+				r := []rune(x.(string))
+				return string(r[:max(0, len(r)-excess-1)])
+			})
+			continue
+		}
+		return out
+	}
+}
+
+func arrayLen(v any) int {
+	if arr, ok := v.([]any); ok {
+		return len(arr)
+	}
+	return 0
+}
+
+func stringLen(v any) int {
+	if s, ok := v.(string); ok {
+		return len(s)
+	}
+	return 0
+}
+
+// longest returns the largest size of any value in a decoded JSON value.
+func longest(v any, size func(any) int) int {
+	n := size(v)
+	switch x := v.(type) {
+	case map[string]any:
+		for _, item := range x {
+			n = max(n, longest(item, size))
+		}
+	case []any:
+		for _, item := range x {
+			n = max(n, longest(item, size))
+		}
+	}
+	return n
+}
+
+// replaceFirst replaces the first value in a decoded JSON value that
+// matches, visiting object keys in sorted order.
+func replaceFirst(v any, match func(any) bool, replace func(any) any) (any, bool) {
+	if match(v) {
+		return replace(v), true
+	}
+	switch x := v.(type) {
+	case map[string]any:
+		keys := make([]string, 0, len(x))
+		for k := range x {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			if item, ok := replaceFirst(x[k], match, replace); ok {
+				x[k] = item
+				return x, true
+			}
+		}
+	case []any:
+		for i := range x {
+			if item, ok := replaceFirst(x[i], match, replace); ok {
+				x[i] = item
+				return x, true
+			}
+		}
+	}
+	return v, false
+}
@@ -0,0 +1,105 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/paolobietolini/gtm-mcp-server/auth"
+	"github.com/paolobietolini/gtm-mcp-server/gtm"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+func bigTagList(n int) ListTagsOutput {
+	out := ListTagsOutput{Total: n}
+	for i := 0; i < n; i++ {
+		out.Tags = append(out.Tags, gtm.Tag{TagID: fmt.Sprint(i), Name: fmt.Sprintf("GA4 Event %d", i), Type: "gaawe"})
+	}
+	return out
+}
+
+func TestWithOutputGuard_Truncates(t *testing.T) {
+	guard := newOutputGuard(2000)
+	full := bigTagList(100)
+	handler := withOutputGuard(guard, func(ctx context.Context, req *mcp.CallToolRequest, input struct{}) (*mcp.CallToolResult, ListTagsOutput, error) {
+		return nil, full, nil
+	})
+
+	ctx := context.WithValue(context.Background(), auth.TokenInfoKey, &auth.TokenInfo{ClientID: "a"})
+	res, out, err := handler(ctx, nil, struct{}{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The structured output keeps its shape and fits the limit
+	data, _ := json.Marshal(out)
+	if len(data) > 2000 || len(out.Tags) == 0 || len(out.Tags) >= 100 || out.Total != 100 {
+		t.Errorf("shrunk output: %d bytes, %d tags, total %d", len(data), len(out.Tags), out.Total)
+	}
+	if out.Tags[0].TagID != "0" {
+		t.Errorf("first tag = %+v, want the original first tag", out.Tags[0])
+	}
+
+	// The text holds the first chunk and a token for the rest
+	text := res.Content[0].(*mcp.TextContent).Text
+	token := text[strings.Index(text, `token "`)+7:]
+	token = token[:strings.Index(token, `"`)]
+	assembled := text[:strings.Index(text, "\n\n[Output truncated")]
+
+	for token != "" {
+		chunk, next, _, err := guard.next(userKey(ctx), token)
+		if err != nil {
+			t.Fatal(err)
+		}
+		assembled += chunk
+		token = next
+	}
+	want, _ := json.Marshal(full)
+	if assembled != string(want) {
+		t.Errorf("assembled output differs from the full output")
+	}
+}
+
+func TestWithOutputGuard_SmallOutputUnchanged(t *testing.T) {
+	handler := withOutputGuard(newOutputGuard(2000), func(ctx context.Context, req *mcp.CallToolRequest, input struct{}) (*mcp.CallToolResult, ListTagsOutput, error) {
+		return nil, bigTagList(3), nil
+	})
+	res, out, err := handler(context.Background(), nil, struct{}{})
+	if err != nil || res != nil || len(out.Tags) != 3 {
+		t.Errorf("res = %v, %d tags, err = %v", res, len(out.Tags), err)
+	}
+}
+
+func TestOutputGuard_NextChecksUser(t *testing.T) {
+	guard := newOutputGuard(10)
+	id := guard.store("alice", []byte(strings.Repeat("x", 25)))
+
+	if _, _, _, err := guard.next("bob", id+".10"); err == nil {
+		t.Error("another user read the continuation")
+	}
+	if _, _, _, err := guard.next("alice", id+".abc"); err == nil {
+		t.Error("invalid offset accepted")
+	}
+	chunk, next, total, err := guard.next("alice", id+".20")
+	if err != nil || chunk != "xxxxx" || next != "" || total != 25 {
+		t.Errorf("last chunk = %q, next %q, total %d, err %v", chunk, next, total, err)
+	}
+}
+
+func TestShrinkJSON_Strings(t *testing.T) {
+	data := []byte(`{"notes":"` + strings.Repeat("é", 500) + `","id":"1"}`)
+	shrunk := shrinkJSON(data, 100)
+	var v struct {
+		Notes string `json:"notes"`
+		ID    string `json:"id"`
+	}
+	if err := json.Unmarshal(shrunk, &v); err != nil {
+		t.Fatalf("shrunk JSON is invalid: %v", err)
+	}
+	if len(shrunk) > 100 || v.ID != "1" {
+		t.Errorf("shrunk = %s", shrunk)
+	}
+}
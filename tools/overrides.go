@@ -0,0 +1,104 @@
+package tools
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"gopkg.in/yaml.v3"
+)
+
+// ToolOverride customizes how a single tool is presented to MCP clients.
+type ToolOverride struct {
+	// Description replaces the built-in tool description.
+	Description string `yaml:"description"`
+	// AppendDescription is added after the (possibly replaced) description,
+	// e.g. for company naming conventions or forbidden actions.
+	AppendDescription string `yaml:"append_description"`
+	// Hidden prevents the tool from being registered.
+	Hidden bool `yaml:"hidden"`
+	// Aliases registers the same tool under additional names.
+	Aliases []string `yaml:"aliases"`
+}
+
+// toolOverridesFile is the on-disk layout of a tool override file:
+//
+//	tools:
+//	  delete_tag:
+//	    hidden: true
+//	  create_tag:
+//	    append_description: "Tag names must follow 'GA4 - Event - <name>'."
+//	    aliases: [add_tag]
+type toolOverridesFile struct {
+	Tools map[string]ToolOverride `yaml:"tools"`
+}
+
+// LoadToolOverrides reads a YAML tool override file keyed by tool name.
+func LoadToolOverrides(path string) (map[string]ToolOverride, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read tool overrides: %w", err)
+	}
+	return parseToolOverrides(data)
+}
+
+func parseToolOverrides(data []byte) (map[string]ToolOverride, error) {
+	var file toolOverridesFile
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+	if err := dec.Decode(&file); err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("invalid tool overrides: %w", err)
+	}
+	return file.Tools, nil
+}
+
+// apply returns the tool to register with the override applied.
+func (o ToolOverride) apply(tool *mcp.Tool) *mcp.Tool {
+	t := *tool
+	if o.Description != "" {
+		t.Description = o.Description
+	}
+	if o.AppendDescription != "" {
+		t.Description = strings.TrimSpace(t.Description + "\n\n" + o.AppendDescription)
+	}
+	return &t
+}
+
+// checkOverrides reports override entries that don't match any GTM tool,
+// aliases that collide with an existing tool name and aliases given to more
+// than one tool. The SDK would otherwise silently replace one tool with the
+// other.
+func (r *toolRegistry) checkOverrides() error {
+	names := make([]string, 0, len(r.overrides))
+	for name := range r.overrides {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var unknown []string
+	aliasOf := make(map[string]string)
+	for _, name := range names {
+		if !r.known[name] {
+			unknown = append(unknown, name)
+			continue
+		}
+		for _, alias := range r.overrides[name].Aliases {
+			if r.known[alias] || r.reserved[alias] {
+				return fmt.Errorf("tool alias %q for %q conflicts with an existing tool", alias, name)
+			}
+			if other, ok := aliasOf[alias]; ok {
+				return fmt.Errorf("tool alias %q is given to both %q and %q", alias, other, name)
+			}
+			aliasOf[alias] = name
+		}
+	}
+	if len(unknown) > 0 {
+		return fmt.Errorf("tool overrides reference unknown tools: %s", strings.Join(unknown, ", "))
+	}
+	return nil
+}
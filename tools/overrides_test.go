@@ -0,0 +1,150 @@
+package tools
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+func TestParseToolOverrides(t *testing.T) {
+	data := []byte(`
+tools:
+  create_tag:
+    append_description: "Use the naming convention."
+    aliases: [add_tag]
+  delete_container:
+    hidden: true
+`)
+	overrides, err := parseToolOverrides(data)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !overrides["delete_container"].Hidden {
+		t.Error("expected delete_container to be hidden")
+	}
+	if got := overrides["create_tag"].Aliases; len(got) != 1 || got[0] != "add_tag" {
+		t.Errorf("unexpected aliases: %v", got)
+	}
+}
+
+func TestParseToolOverrides_Invalid(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+	}{
+		{"unknown field", "tools:\n  create_tag:\n    hide: true\n"},
+		{"malformed", "tools: [\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := parseToolOverrides([]byte(tt.data)); err == nil {
+				t.Error("expected error")
+			}
+		})
+	}
+}
+
+func TestParseToolOverrides_Empty(t *testing.T) {
+	overrides, err := parseToolOverrides(nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(overrides) != 0 {
+		t.Errorf("expected no overrides, got %d", len(overrides))
+	}
+}
+
+func TestToolOverride_Apply(t *testing.T) {
+	tool := &mcp.Tool{Name: "create_tag", Description: "Create a tag."}
+
+	got := ToolOverride{AppendDescription: "No custom HTML."}.apply(tool)
+	if got.Description != "Create a tag.\n\nNo custom HTML." {
+		t.Errorf("unexpected description: %q", got.Description)
+	}
+	if tool.Description != "Create a tag." {
+		t.Error("apply must not modify the original tool")
+	}
+
+	got = ToolOverride{Description: "Replaced."}.apply(tool)
+	if got.Description != "Replaced." {
+		t.Errorf("unexpected description: %q", got.Description)
+	}
+}
+
+func TestRegisterTools_Overrides(t *testing.T) {
+	names := registeredToolNames(t, ToolOptions{Overrides: map[string]ToolOverride{
+		"delete_container": {Hidden: true},
+		"create_tag":       {Aliases: []string{"add_tag"}},
+	}})
+
+	if names["delete_container"] {
+		t.Error("expected delete_container to be hidden")
+	}
+	if !names["create_tag"] || !names["add_tag"] {
+		t.Error("expected create_tag and its add_tag alias to be registered")
+	}
+}
+
+func TestRegisterTools_OverridesUnknownTool(t *testing.T) {
+	server := mcp.NewServer(&mcp.Implementation{Name: "test", Version: "0.0.0"}, nil)
+	err := RegisterTools(server, ToolOptions{Overrides: map[string]ToolOverride{
+		"no_such_tool": {Hidden: true},
+	}})
+	if err == nil || !strings.Contains(err.Error(), "no_such_tool") {
+		t.Errorf("expected unknown tool error, got %v", err)
+	}
+}
+
+func TestRegisterTools_OverridesAliasConflict(t *testing.T) {
+	server := mcp.NewServer(&mcp.Implementation{Name: "test", Version: "0.0.0"}, nil)
+	err := RegisterTools(server, ToolOptions{Overrides: map[string]ToolOverride{
+		"create_tag": {Aliases: []string{"get_tag"}},
+	}})
+	if err == nil {
+		t.Error("expected alias conflict error")
+	}
+}
+
+func TestRegisterTools_OverridesDuplicateAlias(t *testing.T) {
+	tests := []struct {
+		name      string
+		overrides map[string]ToolOverride
+		reserved  []string
+		want      string
+	}{
+		{
+			name: "alias of two tools",
+			overrides: map[string]ToolOverride{
+				"create_tag":     {Aliases: []string{"add"}},
+				"create_trigger": {Aliases: []string{"add"}},
+			},
+			want: `"add" is given to both "create_tag" and "create_trigger"`,
+		},
+		{
+			name:      "alias repeated for one tool",
+			overrides: map[string]ToolOverride{"create_tag": {Aliases: []string{"add_tag", "add_tag"}}},
+			want:      `"add_tag" is given to both "create_tag" and "create_tag"`,
+		},
+		{
+			name:      "alias of the tool itself",
+			overrides: map[string]ToolOverride{"create_tag": {Aliases: []string{"create_tag"}}},
+			want:      "conflicts with an existing tool",
+		},
+		{
+			name:      "reserved utility tool",
+			overrides: map[string]ToolOverride{"create_tag": {Aliases: []string{"ping"}}},
+			reserved:  []string{"ping", "auth_status", "disconnect"},
+			want:      `"ping" for "create_tag" conflicts with an existing tool`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := mcp.NewServer(&mcp.Implementation{Name: "test", Version: "0.0.0"}, nil)
+			err := RegisterTools(server, ToolOptions{Overrides: tt.overrides, ReservedNames: tt.reserved})
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("err = %v, want %q", err, tt.want)
+			}
+		})
+	}
+}
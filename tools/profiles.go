@@ -0,0 +1,251 @@
+package tools
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/paolobietolini/gtm-mcp-server/gtm"
+)
+
+// Tool profile names. Each profile registers a curated subset of the GTM tools
+// so deployments can expose only what their users need.
+const (
+	ProfileAll        = ""            // every tool (default)
+	ProfileAnalyst    = "analyst"     // read-only browsing
+	ProfileCore       = "core"        // web container editing and publishing
+	ProfileServerSide = "server-side" // core plus server-side clients, transformations and custom templates
+	ProfileAdmin      = "admin"       // every tool, including container creation and deletion
+)
+
+// ToolOptions controls which GTM tools RegisterTools exposes.
+type ToolOptions struct {
+	// Profile selects a named tool profile. Empty registers every tool.
+	Profile string
+
+	// Overrides customizes tool descriptions, hides tools or adds aliases,
+	// keyed by tool name. See LoadToolOverrides.
+	Overrides map[string]ToolOverride
+
+	// ReservedNames are tool names registered on the server outside
+	// RegisterTools, such as ping. Override aliases may not use them.
+	ReservedNames []string
+
+	// ReadOnly makes every mutating tool fail with ErrReadOnly. Tokens
+	// with only the gtm:read scope get the same treatment per session.
+	ReadOnly bool
+
+	// GoogleScopes are the Google scopes the server requests. Tools that
+	// need a scope outside this set fail with ErrMissingScope. Nil assumes
+	// every scope.
+	GoogleScopes []string
+
+	// DisabledTools are tool names never registered, applied after the
+	// profile. Unknown names fail RegisterTools.
+	DisabledTools []string
+
+	// Audit records every call to a mutating tool and backs the
+	// list_audit_events tool. Nil disables auditing.
+	Audit *AuditLog
+
+	// MaxOutputBytes truncates tool results that serialize to more bytes;
+	// the rest is fetched with get_continuation. 0 disables the limit.
+	MaxOutputBytes int
+
+	// Scheduler backs the scheduled publish tools. Nil disables them.
+	Scheduler *gtm.PublishScheduler
+
+	// Approvals holds every mutating tool call until it is approved with
+	// approve_change. Nil runs mutating tools directly.
+	Approvals *ApprovalQueue
+
+	// Blueprints are the blueprints apply_blueprint provisions, keyed by
+	// name. Nil uses the built-in blueprints. See LoadBlueprints.
+	Blueprints map[string]*gtm.Blueprint
+
+	// NamingRules are the naming conventions lint_naming checks. Nil uses
+	// DefaultNamingRules. See LoadNamingRules.
+	NamingRules gtm.NamingRules
+
+	// Gallery backs search_gallery_templates. Nil searches GitHub without
+	// a token.
+	Gallery *gtm.GalleryIndex
+
+	// WorkspaceContexts are the aliases set_context accepts and the
+	// workspace sessions start in. Nil only allows set_context with IDs.
+	// See LoadWorkspaceContexts.
+	WorkspaceContexts *WorkspaceContexts
+}
+
+// analystTools are read-only tools that never modify a container.
+var analystTools = []string{
+	"set_context",
+	"list_accounts",
+	"list_containers",
+	"list_workspaces",
+	"get_container_limits",
+	"get_workspace_status",
+	"get_workspace_overview",
+	"diff_workspace",
+	"generate_container_map",
+	"search_workspace",
+	"get_dependencies",
+	"find_orphans",
+	"audit_workspace",
+	"detect_secrets",
+	"lint_naming",
+	"get_preview_link",
+	"list_tags",
+	"get_tag",
+	"get_tags",
+	"list_triggers",
+	"get_trigger",
+	"get_triggers",
+	"list_variables",
+	"get_variable",
+	"get_variables",
+	"list_built_in_variables",
+	"list_folders",
+	"get_folder_entities",
+	"list_templates",
+	"get_template",
+	"list_versions",
+	"list_ga4_properties",
+	"list_ga4_data_streams",
+	"generate_changelog",
+	"list_audit_events",
+	"list_scheduled_publishes",
+	"list_pending_changes",
+	"get_continuation",
+	"get_tag_templates",
+	"get_trigger_templates",
+	"get_variable_templates",
+	"list_blueprints",
+	"search_gallery_templates",
+	"analyze_template_permissions",
+	"export_container",
+	"export_terraform",
+}
+
+// coreTools extend the analyst profile with day-to-day web container editing.
+var coreTools = []string{
+	"create_workspace",
+	"clone_workspace",
+	"validate_tag",
+	"create_tag",
+	"create_ga4_event_tag",
+	"update_tag",
+	"upsert_tag",
+	"delete_tag",
+	"create_trigger",
+	"update_trigger",
+	"upsert_trigger",
+	"delete_trigger",
+	"create_variable",
+	"create_lookup_table_variable",
+	"create_regex_table_variable",
+	"update_variable",
+	"upsert_variable",
+	"delete_variable",
+	"duplicate_tag",
+	"duplicate_trigger",
+	"duplicate_variable",
+	"bulk_delete_entities",
+	"apply_changeset",
+	"apply_blueprint",
+	"setup_ga4_basic",
+	"setup_consent_mode",
+	"find_replace",
+	"annotate_workspace",
+	"enable_built_in_variables",
+	"disable_built_in_variables",
+	"import_gallery_template",
+	"check_template_updates",
+	"import_container",
+	"copy_entities",
+	"create_version",
+	"publish_version",
+	"release_workspace",
+	"create_and_publish",
+	"canary_release",
+	"promote_canary",
+	"rollback_to_version",
+	"schedule_publish",
+	"cancel_scheduled_publish",
+	"approve_change",
+	"reject_change",
+}
+
+// serverSideTools extend the core profile with server-side container entities.
+var serverSideTools = []string{
+	"list_clients",
+	"get_client",
+	"create_client",
+	"update_client",
+	"delete_client",
+	"list_transformations",
+	"get_transformation",
+	"create_transformation",
+	"update_transformation",
+	"delete_transformation",
+	"create_template",
+	"update_template",
+	"delete_template",
+}
+
+// readTools are the tools that never modify a container: the analyst
+// profile plus the read tools of the larger profiles. Read-only sessions
+// can only call these.
+var readTools = func() map[string]bool {
+	tools := map[string]bool{
+		"validate_tag":         true,
+		"list_clients":         true,
+		"get_client":           true,
+		"list_transformations": true,
+		"get_transformation":   true,
+	}
+	for _, name := range analystTools {
+		tools[name] = true
+	}
+	return tools
+}()
+
+// profiles maps each restricted profile to the tool lists it includes.
+// ProfileAll and ProfileAdmin are not listed because they allow every tool.
+var profiles = map[string][][]string{
+	ProfileAnalyst:    {analystTools},
+	ProfileCore:       {analystTools, coreTools},
+	ProfileServerSide: {analystTools, coreTools, serverSideTools},
+}
+
+// profileTools returns the set of tool names allowed by a profile.
+// A nil set means every tool is allowed.
+func profileTools(profile string) (map[string]bool, error) {
+	profile = strings.ToLower(strings.TrimSpace(profile))
+	if profile == ProfileAll || profile == ProfileAdmin {
+		return nil, nil
+	}
+
+	lists, ok := profiles[profile]
+	if !ok {
+		return nil, fmt.Errorf("unknown tool profile %q (valid values: %s)", profile, strings.Join(ProfileNames(), ", "))
+	}
+
+	allowed := make(map[string]bool)
+	for _, list := range lists {
+		for _, name := range list {
+			allowed[name] = true
+		}
+	}
+	return allowed, nil
+}
+
+// ProfileNames returns the names of all selectable tool profiles.
+func ProfileNames() []string {
+	names := []string{ProfileAdmin}
+	for name := range profiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
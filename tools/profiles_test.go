@@ -0,0 +1,249 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/paolobietolini/gtm-mcp-server/auth"
+	"github.com/paolobietolini/gtm-mcp-server/gtm"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// registeredToolNames registers the GTM tools with opts and returns the tool
+// names a connected client sees.
+func registeredToolNames(t *testing.T, opts ToolOptions) map[string]bool {
+	t.Helper()
+
+	names := make(map[string]bool)
+	for name := range registeredTools(t, opts) {
+		names[name] = true
+	}
+	return names
+}
+
+// registeredTools registers the GTM tools with opts and returns the tools a
+// connected client sees, keyed by name.
+func registeredTools(t *testing.T, opts ToolOptions) map[string]*mcp.Tool {
+	t.Helper()
+
+	ctx := context.Background()
+	server := mcp.NewServer(&mcp.Implementation{Name: "test", Version: "0.0.0"}, nil)
+	if err := RegisterTools(server, opts); err != nil {
+		t.Fatalf("RegisterTools: %v", err)
+	}
+
+	serverTransport, clientTransport := mcp.NewInMemoryTransports()
+	serverSession, err := server.Connect(ctx, serverTransport, nil)
+	if err != nil {
+		t.Fatalf("server connect: %v", err)
+	}
+	defer serverSession.Close()
+
+	client := mcp.NewClient(&mcp.Implementation{Name: "test-client", Version: "0.0.0"}, nil)
+	session, err := client.Connect(ctx, clientTransport, nil)
+	if err != nil {
+		t.Fatalf("client connect: %v", err)
+	}
+	defer session.Close()
+
+	tools := make(map[string]*mcp.Tool)
+	for tool, err := range session.Tools(ctx, nil) {
+		if err != nil {
+			t.Fatalf("list tools: %v", err)
+		}
+		tools[tool.Name] = tool
+	}
+	return tools
+}
+
+func TestProfileTools_AllProfilesReferenceRegisteredTools(t *testing.T) {
+	all := registeredToolNames(t, ToolOptions{})
+
+	for profile, lists := range profiles {
+		for _, list := range lists {
+			for _, name := range list {
+				if !all[name] {
+					t.Errorf("profile %q references unknown tool %q", profile, name)
+				}
+			}
+		}
+	}
+}
+
+func TestRegisterTools_AnalystProfileIsSubset(t *testing.T) {
+	all := registeredToolNames(t, ToolOptions{})
+	analyst := registeredToolNames(t, ToolOptions{Profile: ProfileAnalyst})
+
+	if len(analyst) != len(analystTools) {
+		t.Errorf("expected %d analyst tools, got %d", len(analystTools), len(analyst))
+	}
+	if len(analyst) >= len(all) {
+		t.Errorf("analyst profile should register fewer tools than the full set (%d >= %d)", len(analyst), len(all))
+	}
+	for _, name := range []string{"create_tag", "publish_version", "delete_container"} {
+		if analyst[name] {
+			t.Errorf("analyst profile should not register %q", name)
+		}
+	}
+}
+
+func TestRegisterTools_AdminProfileRegistersEverything(t *testing.T) {
+	all := registeredToolNames(t, ToolOptions{})
+	admin := registeredToolNames(t, ToolOptions{Profile: ProfileAdmin})
+
+	if len(admin) != len(all) {
+		t.Errorf("expected admin profile to register %d tools, got %d", len(all), len(admin))
+	}
+}
+
+func TestRegisterTools_CoreProfileIncludesCreateAndPublish(t *testing.T) {
+	core := registeredToolNames(t, ToolOptions{Profile: ProfileCore})
+	if !core["create_and_publish"] || !core["release_workspace"] {
+		t.Error("core profile should register create_and_publish alongside release_workspace")
+	}
+}
+
+func TestRegisterTools_DisabledTools(t *testing.T) {
+	names := registeredToolNames(t, ToolOptions{Profile: ProfileCore, DisabledTools: []string{"publish_version", "delete_container"}})
+	if names["publish_version"] {
+		t.Error("disabled tool publish_version should not be registered")
+	}
+	if !names["create_version"] {
+		t.Error("create_version should still be registered")
+	}
+
+	server := mcp.NewServer(&mcp.Implementation{Name: "test", Version: "0.0.0"}, nil)
+	err := RegisterTools(server, ToolOptions{DisabledTools: []string{"publish_versoin"}})
+	if err == nil || !strings.Contains(err.Error(), "publish_versoin") {
+		t.Errorf("expected error naming the unknown tool, got %v", err)
+	}
+}
+
+func TestProfileTools_UnknownProfile(t *testing.T) {
+	if _, err := profileTools("nonexistent"); err == nil {
+		t.Error("expected error for unknown profile")
+	}
+}
+
+func TestProfileTools_CaseInsensitive(t *testing.T) {
+	allowed, err := profileTools("  Server-Side ")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !allowed["create_client"] {
+		t.Error("expected server-side profile to include create_client")
+	}
+}
+
+func TestReadTools_ReferenceRegisteredTools(t *testing.T) {
+	all := registeredToolNames(t, ToolOptions{})
+	for name := range readTools {
+		if !all[name] {
+			t.Errorf("readTools references unknown tool %q", name)
+		}
+	}
+}
+
+func TestRegisterTools_ReadOnlyRejectsMutatingTools(t *testing.T) {
+	ctx := context.Background()
+	server := mcp.NewServer(&mcp.Implementation{Name: "test", Version: "0.0.0"}, nil)
+	if err := RegisterTools(server, ToolOptions{ReadOnly: true}); err != nil {
+		t.Fatalf("RegisterTools: %v", err)
+	}
+	serverTransport, clientTransport := mcp.NewInMemoryTransports()
+	serverSession, err := server.Connect(ctx, serverTransport, nil)
+	if err != nil {
+		t.Fatalf("server connect: %v", err)
+	}
+	defer serverSession.Close()
+	client := mcp.NewClient(&mcp.Implementation{Name: "test-client", Version: "0.0.0"}, nil)
+	session, err := client.Connect(ctx, clientTransport, nil)
+	if err != nil {
+		t.Fatalf("client connect: %v", err)
+	}
+	defer session.Close()
+
+	call := func(name string, args map[string]any) string {
+		res, err := session.CallTool(ctx, &mcp.CallToolParams{Name: name, Arguments: args})
+		if err != nil {
+			t.Fatalf("call %s: %v", name, err)
+		}
+		if len(res.Content) == 0 {
+			return ""
+		}
+		return res.Content[0].(*mcp.TextContent).Text
+	}
+
+	got := call("create_workspace", map[string]any{"accountId": "1", "containerId": "2", "name": "ws"})
+	var envelope struct{ Error ToolError }
+	if err := json.Unmarshal([]byte(got), &envelope); err != nil || envelope.Error.Code != ErrorCodeReadOnly || !strings.Contains(envelope.Error.Message, "read-only session") {
+		t.Errorf("create_workspace in read-only mode returned %q", got)
+	}
+	if got := call("list_tags", map[string]any{"accountId": "1", "containerId": "2", "workspaceId": "3"}); strings.Contains(got, "read-only session") {
+		t.Errorf("list_tags should not be blocked in read-only mode: %q", got)
+	}
+}
+
+func TestWithReadOnlyGuard_TokenScope(t *testing.T) {
+	called := false
+	handler := withReadOnlyGuard("create_tag", false, func(ctx context.Context, req *mcp.CallToolRequest, input struct{}) (*mcp.CallToolResult, struct{}, error) {
+		called = true
+		return nil, struct{}{}, nil
+	})
+
+	readCtx := context.WithValue(context.Background(), auth.TokenInfoKey, &auth.TokenInfo{Scope: auth.ScopeRead})
+	if _, _, err := handler(readCtx, nil, struct{}{}); !errors.Is(err, gtm.ErrReadOnly) || called {
+		t.Errorf("gtm:read token: err = %v, called = %v", err, called)
+	}
+
+	writeCtx := context.WithValue(context.Background(), auth.TokenInfoKey, &auth.TokenInfo{Scope: "gtm:read gtm:write"})
+	if _, _, err := handler(writeCtx, nil, struct{}{}); err != nil || !called {
+		t.Errorf("gtm:write token: err = %v, called = %v", err, called)
+	}
+}
+
+func TestMissingGoogleScope(t *testing.T) {
+	edit := []string{auth.GoogleScopeEditContainers, auth.GoogleScopeEditVersions}
+	tests := []struct {
+		tool    string
+		granted []string
+		want    string
+	}{
+		{tool: "publish_version", granted: nil, want: ""},
+		{tool: "publish_version", granted: edit, want: auth.GoogleScopePublish},
+		{tool: "release_workspace", granted: []string{auth.GoogleScopeEditContainers, auth.GoogleScopePublish}, want: auth.GoogleScopeEditVersions},
+		{tool: "create_and_publish", granted: []string{auth.GoogleScopeEditContainers, auth.GoogleScopePublish}, want: auth.GoogleScopeEditVersions},
+		{tool: "delete_container", granted: edit, want: auth.GoogleScopeDeleteContainers},
+		{tool: "create_tag", granted: edit, want: ""},
+		{tool: "create_tag", granted: []string{auth.GoogleScopeReadonly}, want: auth.GoogleScopeEditContainers},
+		{tool: "list_tags", granted: []string{auth.GoogleScopeReadonly}, want: ""},
+		{tool: "list_ga4_properties", granted: []string{auth.GoogleScopeReadonly}, want: auth.GoogleScopeAnalyticsReadonly},
+		{tool: "list_ga4_data_streams", granted: []string{auth.GoogleScopeReadonly, auth.GoogleScopeAnalyticsReadonly}, want: ""},
+	}
+	for _, tt := range tests {
+		if got := missingGoogleScope(tt.tool, tt.granted); got != tt.want {
+			t.Errorf("missingGoogleScope(%s, %v) = %q, want %q", tt.tool, tt.granted, got, tt.want)
+		}
+	}
+}
+
+func TestToolGoogleScopes_ReferenceRegisteredTools(t *testing.T) {
+	all := registeredToolNames(t, ToolOptions{})
+	for name := range toolGoogleScopes {
+		if !all[name] {
+			t.Errorf("toolGoogleScopes references unknown tool %q", name)
+		}
+	}
+}
+
+func TestMissingScopeHandler(t *testing.T) {
+	handler := missingScopeHandler[struct{}, struct{}]("publish_version", auth.GoogleScopePublish)
+	_, _, err := handler(context.Background(), nil, struct{}{})
+	if !errors.Is(err, gtm.ErrMissingScope) || !strings.Contains(err.Error(), "tagmanager.publish") {
+		t.Errorf("err = %v", err)
+	}
+}
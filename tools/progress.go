@@ -0,0 +1,32 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/paolobietolini/gtm-mcp-server/gtm"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// withProgress reports the progress of a long-running tool call to the
+// client as MCP progress notifications, e.g. "Deleted tag "GA4 Config" (3 of
+// 12)", when the client asked for them with a progress token.
+func withProgress[In, Out any](handler mcp.ToolHandlerFor[In, Out]) mcp.ToolHandlerFor[In, Out] {
+	return func(ctx context.Context, req *mcp.CallToolRequest, input In) (*mcp.CallToolResult, Out, error) {
+		if req != nil && req.Session != nil && req.Params != nil {
+			if token := req.Params.GetProgressToken(); token != nil {
+				ctx = gtm.WithProgress(ctx, func(ctx context.Context, done, total int, message string) {
+					// A client that went away only loses the notification
+					_ = req.Session.NotifyProgress(ctx, &mcp.ProgressNotificationParams{
+						ProgressToken: token,
+						Progress:      float64(done),
+						Total:         float64(total),
+						Message:       fmt.Sprintf("%s (%d of %d)", message, done, total),
+					})
+				})
+			}
+		}
+		return handler(ctx, req, input)
+	}
+}
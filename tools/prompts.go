@@ -0,0 +1,596 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/paolobietolini/gtm-mcp-server/gtm"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	tagmanager "google.golang.org/api/tagmanager/v2"
+)
+
+// RegisterPrompts adds all GTM prompts to the MCP server.
+func RegisterPrompts(server *mcp.Server) {
+	// Audit container prompt - analyzes workspace for issues
+	server.AddPrompt(&mcp.Prompt{
+		Name:        "audit_container",
+		Description: "Analyze a GTM workspace for potential issues, duplicates, naming inconsistencies, and best practice violations",
+		Arguments: []*mcp.PromptArgument{
+			{Name: "accountId", Description: "The GTM account ID", Required: true},
+			{Name: "containerId", Description: "The GTM container ID", Required: true},
+			{Name: "workspaceId", Description: "The GTM workspace ID", Required: true},
+		},
+	}, handleAuditContainerPrompt)
+
+	// Generate tracking plan prompt - creates markdown documentation
+	server.AddPrompt(&mcp.Prompt{
+		Name:        "generate_tracking_plan",
+		Description: "Generate a Markdown tracking plan document from existing tags, triggers, and variables in a workspace",
+		Arguments: []*mcp.PromptArgument{
+			{Name: "accountId", Description: "The GTM account ID", Required: true},
+			{Name: "containerId", Description: "The GTM container ID", Required: true},
+			{Name: "workspaceId", Description: "The GTM workspace ID", Required: true},
+		},
+	}, handleGenerateTrackingPlanPrompt)
+
+	// Suggest GA4 setup prompt - recommends tag structure
+	server.AddPrompt(&mcp.Prompt{
+		Name:        "suggest_ga4_setup",
+		Description: "Recommend a GA4 tag structure based on tracking goals and requirements",
+		Arguments: []*mcp.PromptArgument{
+			{Name: "goals", Description: "Description of tracking goals (e.g., 'ecommerce purchase tracking, form submissions, button clicks')", Required: true},
+		},
+	}, handleSuggestGA4SetupPrompt)
+
+	// Consent mode audit prompt - reviews tag consent configuration
+	server.AddPrompt(&mcp.Prompt{
+		Name:        "consent_mode_audit",
+		Description: "Review the consent settings of every tag in a workspace for Consent Mode v2 compliance on EEA traffic, with remediation steps per tag",
+		Arguments: []*mcp.PromptArgument{
+			{Name: "accountId", Description: "The GTM account ID", Required: true},
+			{Name: "containerId", Description: "The GTM container ID", Required: true},
+			{Name: "workspaceId", Description: "The GTM workspace ID", Required: true},
+		},
+	}, handleConsentModeAuditPrompt)
+
+	// Normalize naming prompt - plans renames to a naming convention
+	server.AddPrompt(&mcp.Prompt{
+		Name:        "normalize_naming",
+		Description: "Plan renames of tags, triggers and variables in a workspace to follow a naming convention, as a list of update calls to approve",
+		Arguments: []*mcp.PromptArgument{
+			{Name: "accountId", Description: "The GTM account ID", Required: true},
+			{Name: "containerId", Description: "The GTM container ID", Required: true},
+			{Name: "workspaceId", Description: "The GTM workspace ID", Required: true},
+			{Name: "convention", Description: "The naming convention, e.g. 'GA4 - Event - {name}' for tags, 'CE - {event}' for custom event triggers, 'DLV - {key}' for data layer variables", Required: true},
+		},
+	}, handleNormalizeNamingPrompt)
+
+	// Pre-publish review prompt - release summary of a workspace diff
+	server.AddPrompt(&mcp.Prompt{
+		Name:        "pre_publish_review",
+		Description: "Summarize what publishing a workspace would change compared to the live version, with risk callouts and suggested version notes",
+		Arguments: []*mcp.PromptArgument{
+			{Name: "accountId", Description: "The GTM account ID", Required: true},
+			{Name: "containerId", Description: "The GTM container ID", Required: true},
+			{Name: "workspaceId", Description: "The GTM workspace ID", Required: true},
+		},
+	}, handlePrePublishReviewPrompt)
+}
+
+func handleAuditContainerPrompt(ctx context.Context, req *mcp.GetPromptRequest) (*mcp.GetPromptResult, error) {
+	accountID := req.Params.Arguments["accountId"]
+	containerID := req.Params.Arguments["containerId"]
+	workspaceID := req.Params.Arguments["workspaceId"]
+
+	if accountID == "" || containerID == "" || workspaceID == "" {
+		return nil, fmt.Errorf("accountId, containerId, and workspaceId are required")
+	}
+
+	client, err := getClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// Fetch all workspace data
+	entities, err := client.ListWorkspaceEntities(ctx, accountID, containerID, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+	tags, triggers, variables := entities.Tags, entities.Triggers, entities.Variables
+
+	// Build the workspace data JSON
+	workspaceData := map[string]any{
+		"tags":      tags,
+		"triggers":  triggers,
+		"variables": variables,
+		"summary": map[string]int{
+			"totalTags":      len(tags),
+			"totalTriggers":  len(triggers),
+			"totalVariables": len(variables),
+		},
+	}
+
+	dataJSON, err := json.MarshalIndent(workspaceData, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+
+	return &mcp.GetPromptResult{
+		Description: "Container audit analysis request",
+		Messages: []*mcp.PromptMessage{
+			{
+				Role: "user",
+				Content: &mcp.TextContent{
+					Text: fmt.Sprintf(`Please audit this GTM workspace for potential issues. Here is the current configuration:
+
+%s
+
+Please analyze and report on:
+
+1. **Naming Consistency**
+   - Are tag, trigger, and variable names following a consistent pattern?
+   - Are there any names that are unclear or non-descriptive?
+
+2. **Duplicate Detection**
+   - Are there any tags that appear to be duplicates (same type and similar configuration)?
+   - Are there triggers that fire on the same conditions?
+
+3. **Orphaned Items**
+   - Are there any triggers that are not used by any tags?
+   - Are there any variables that don't appear to be referenced?
+
+4. **Best Practices**
+   - Are tags properly organized with appropriate triggers?
+   - Are there any paused tags that might be forgotten?
+   - Are there missing triggers for common use cases?
+
+5. **GA4 Configuration** (if applicable)
+   - Is there a GA4 configuration tag?
+   - Are event tags properly linked to the configuration?
+   - Are ecommerce events configured correctly?
+
+6. **Security Concerns**
+   - Are there any custom HTML tags that might pose security risks?
+   - Are there any tags loading external scripts?
+
+Please provide specific recommendations for improvements.`, string(dataJSON)),
+				},
+			},
+		},
+	}, nil
+}
+
+func handleGenerateTrackingPlanPrompt(ctx context.Context, req *mcp.GetPromptRequest) (*mcp.GetPromptResult, error) {
+	accountID := req.Params.Arguments["accountId"]
+	containerID := req.Params.Arguments["containerId"]
+	workspaceID := req.Params.Arguments["workspaceId"]
+
+	if accountID == "" || containerID == "" || workspaceID == "" {
+		return nil, fmt.Errorf("accountId, containerId, and workspaceId are required")
+	}
+
+	client, err := getClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// Fetch all workspace data
+	entities, err := client.ListWorkspaceEntities(ctx, accountID, containerID, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+	tags, triggers, variables := entities.Tags, entities.Triggers, entities.Variables
+
+	// Create trigger lookup map
+	triggerMap := make(map[string]string)
+	for _, t := range triggers {
+		triggerMap[t.TriggerID] = t.Name
+	}
+
+	// Build the workspace data JSON
+	workspaceData := map[string]any{
+		"tags":       tags,
+		"triggers":   triggers,
+		"variables":  variables,
+		"triggerMap": triggerMap,
+	}
+
+	dataJSON, err := json.MarshalIndent(workspaceData, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+
+	return &mcp.GetPromptResult{
+		Description: "Generate tracking plan documentation",
+		Messages: []*mcp.PromptMessage{
+			{
+				Role: "user",
+				Content: &mcp.TextContent{
+					Text: fmt.Sprintf(`Please generate a comprehensive Markdown tracking plan document from this GTM workspace configuration:
+
+%s
+
+Generate a document with the following structure:
+
+# Tracking Plan
+
+## Overview
+- Summary of the tracking implementation
+- Total counts (tags, triggers, variables)
+
+## Events
+
+For each tag, create a section:
+
+### [Event Name]
+- **Tag Name:** [name]
+- **Tag Type:** [type]
+- **Trigger(s):** [list of trigger names]
+- **Description:** [inferred purpose]
+- **Parameters:** [if applicable]
+
+## Triggers
+
+For each trigger:
+
+### [Trigger Name]
+- **Type:** [type]
+- **Conditions:** [filter conditions if any]
+- **Used by:** [list of tags using this trigger]
+
+## Variables
+
+For each variable:
+
+### [Variable Name]
+- **Type:** [type]
+- **Purpose:** [inferred purpose]
+
+## Data Layer Requirements
+
+List all dataLayer events and variables that need to be pushed from the website.
+
+## Implementation Notes
+
+Any observations about the implementation, dependencies, or recommendations.
+
+Format the output as clean, professional Markdown.`, string(dataJSON)),
+				},
+			},
+		},
+	}, nil
+}
+
+func handleSuggestGA4SetupPrompt(ctx context.Context, req *mcp.GetPromptRequest) (*mcp.GetPromptResult, error) {
+	goals := req.Params.Arguments["goals"]
+
+	if goals == "" {
+		return nil, fmt.Errorf("goals description is required")
+	}
+
+	// Get the available tag and trigger templates
+	tagTemplates := gtm.GetTagTemplates()
+	triggerTemplates := gtm.GetTriggerTemplates()
+
+	templatesData := map[string]any{
+		"tagTemplates":     tagTemplates,
+		"triggerTemplates": triggerTemplates,
+	}
+
+	templatesJSON, err := json.MarshalIndent(templatesData, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+
+	return &mcp.GetPromptResult{
+		Description: "GA4 setup recommendations",
+		Messages: []*mcp.PromptMessage{
+			{
+				Role: "user",
+				Content: &mcp.TextContent{
+					Text: fmt.Sprintf(`I need help setting up GA4 tracking in Google Tag Manager for the following goals:
+
+**Tracking Goals:**
+%s
+
+Here are the available tag and trigger templates that can be used:
+
+%s
+
+Please provide:
+
+1. **Recommended Tags**
+   - List each tag needed with:
+     - Tag name (following naming convention: "[Category] - [Action]")
+     - Tag type
+     - Configuration details
+     - Which trigger to use
+
+2. **Recommended Triggers**
+   - List each trigger needed with:
+     - Trigger name
+     - Trigger type
+     - Filter conditions (if any)
+
+3. **Required Variables**
+   - List any Data Layer variables needed
+   - List any built-in variables to enable
+
+4. **Data Layer Requirements**
+   - Specify what dataLayer pushes the website needs to implement
+   - Provide example code snippets for each event
+
+5. **Implementation Order**
+   - Step-by-step order to create the tags, triggers, and variables
+
+6. **Testing Checklist**
+   - Key scenarios to test
+   - Expected GA4 events and parameters
+
+Please be specific about the GTM configuration - use the exact parameter formats shown in the templates.`, goals, string(templatesJSON)),
+				},
+			},
+		},
+	}, nil
+}
+
+func handleNormalizeNamingPrompt(ctx context.Context, req *mcp.GetPromptRequest) (*mcp.GetPromptResult, error) {
+	accountID := req.Params.Arguments["accountId"]
+	containerID := req.Params.Arguments["containerId"]
+	workspaceID := req.Params.Arguments["workspaceId"]
+	convention := req.Params.Arguments["convention"]
+
+	if accountID == "" || containerID == "" || workspaceID == "" || convention == "" {
+		return nil, fmt.Errorf("accountId, containerId, workspaceId, and convention are required")
+	}
+
+	client, err := getClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	entities, err := client.ListWorkspaceEntities(ctx, accountID, containerID, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+
+	dataJSON, err := json.MarshalIndent(map[string]any{
+		"tags":      gtm.CompactTags(entities.Tags),
+		"triggers":  gtm.CompactTriggers(entities.Triggers),
+		"variables": gtm.CompactVariables(entities.Variables),
+	}, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+
+	return &mcp.GetPromptResult{
+		Description: "Naming convention rename plan request",
+		Messages: []*mcp.PromptMessage{
+			{
+				Role: "user",
+				Content: &mcp.TextContent{
+					Text: fmt.Sprintf(`Please plan renames so the entities of this GTM workspace (account %s, container %s, workspace %s) follow this naming convention:
+
+%s
+
+Here are the current tags, triggers and variables:
+
+%s
+
+Please produce:
+
+1. **Rename Plan**
+   - A table per entity kind with ID, current name and new name, only for entities that don't already follow the convention.
+   - Derive placeholders such as {name} or {event} from the entity's type and current name; mark guesses with "(check)".
+   - Keep new names unique within each kind.
+
+2. **Entities Left Alone**
+   - Entities the convention doesn't cover or whose purpose is unclear, with the reason.
+
+3. **Update Calls**
+   - The exact calls to execute after I approve, in order: update_tag (tagId, name), update_trigger (triggerId, name) and update_variable (variableId, name).
+   - Variable renames break {{Variable Name}} references, so for every renamed variable also list a find_replace call replacing "{{Old Name}}" with "{{New Name}}", run after the renames.
+
+Do not call any update tool yet; wait for my approval of the plan.`, accountID, containerID, workspaceID, convention, string(dataJSON)),
+				},
+			},
+		},
+	}, nil
+}
+
+func handlePrePublishReviewPrompt(ctx context.Context, req *mcp.GetPromptRequest) (*mcp.GetPromptResult, error) {
+	accountID := req.Params.Arguments["accountId"]
+	containerID := req.Params.Arguments["containerId"]
+	workspaceID := req.Params.Arguments["workspaceId"]
+
+	if accountID == "" || containerID == "" || workspaceID == "" {
+		return nil, fmt.Errorf("accountId, containerId, and workspaceId are required")
+	}
+
+	client, err := getClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	wd, err := client.DiffWorkspace(ctx, accountID, containerID, workspaceID, "live")
+	if err != nil {
+		return nil, err
+	}
+	issues, err := client.ValidateWorkspace(ctx, accountID, containerID, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+
+	dataJSON, err := json.MarshalIndent(map[string]any{
+		"liveVersionId":    wd.BaseVersionID,
+		"diff":             gtm.DiffVersionsRedacted(wd.Base, wd.Head),
+		"riskCallouts":     gtm.ReleaseRisks(wd),
+		"validationIssues": issues,
+	}, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+
+	return &mcp.GetPromptResult{
+		Description: "Pre-publish review request",
+		Messages: []*mcp.PromptMessage{
+			{
+				Role: "user",
+				Content: &mcp.TextContent{
+					Text: fmt.Sprintf(`Please review what publishing this GTM workspace would change compared to the live version. Here is the diff with field-level changes, the risk callouts found automatically and the pre-publish validation issues:
+
+%s
+
+Please produce:
+
+1. **Release Summary**
+   - A short, human-readable summary of the changes for a marketer or analyst, grouped by purpose (e.g. "New GA4 purchase tracking") rather than by entity.
+
+2. **Risk Callouts**
+   - Explain each risk callout and what could break: new or changed Custom HTML, changed firing conditions, removed tags or triggers.
+   - Point out anything else in the diff that looks risky, such as changed measurement IDs, paused tags or consent settings removed.
+   - List validation errors first; they block publishing.
+
+3. **Testing Checklist**
+   - What to check in GTM preview mode before publishing, per risky change.
+
+4. **Suggested Version Notes**
+   - A version name (under 60 characters) and notes ready to pass to create_version.
+
+Do not create or publish a version; wait for my go-ahead.`, string(dataJSON)),
+				},
+			},
+		},
+	}, nil
+}
+
+// consentAuditTag is a tag as presented to the consent_mode_audit prompt.
+type consentAuditTag struct {
+	TagID                 string               `json:"tagId"`
+	Name                  string               `json:"name"`
+	Type                  string               `json:"type"`
+	Template              string               `json:"template,omitempty"` // custom template name, for cvt_ types
+	Paused                bool                 `json:"paused,omitempty"`
+	ConsentSettings       *gtm.ConsentSettings `json:"consentSettings"`
+	FiringTriggers        []string             `json:"firingTriggers,omitempty"`
+	ConsentInitialization bool                 `json:"consentInitialization,omitempty"` // fires on a consent initialization trigger
+}
+
+func handleConsentModeAuditPrompt(ctx context.Context, req *mcp.GetPromptRequest) (*mcp.GetPromptResult, error) {
+	accountID := req.Params.Arguments["accountId"]
+	containerID := req.Params.Arguments["containerId"]
+	workspaceID := req.Params.Arguments["workspaceId"]
+
+	if accountID == "" || containerID == "" || workspaceID == "" {
+		return nil, fmt.Errorf("accountId, containerId, and workspaceId are required")
+	}
+
+	client, err := getClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	entities, err := client.ListWorkspaceEntities(ctx, accountID, containerID, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Custom template names help identify CMP tags such as Cookiebot or iubenda
+	parent := gtm.BuildWorkspacePath(accountID, containerID, workspaceID)
+	resp, err := gtm.RetryWithBackoff(ctx, func() (*tagmanager.ListTemplatesResponse, error) {
+		return client.Service.Accounts.Containers.Workspaces.Templates.List(parent).Context(ctx).Do()
+	})
+	if err != nil {
+		return nil, gtm.MapGoogleError(err)
+	}
+	templateNames := make(map[string]string)
+	for _, t := range resp.Template {
+		templateNames[gtm.TemplateType(containerID, t.TemplateId)] = t.Name
+		if t.GalleryReference != nil && t.GalleryReference.GalleryTemplateId != "" {
+			templateNames["cvt_"+t.GalleryReference.GalleryTemplateId] = t.Name
+		}
+	}
+
+	triggerNames := make(map[string]string)
+	consentInitTriggers := map[string]bool{gtm.ConsentInitTriggerID: true}
+	for id, name := range gtm.BuiltInTriggerNames {
+		triggerNames[id] = name
+	}
+	for _, t := range entities.Triggers {
+		triggerNames[t.TriggerID] = t.Name
+		if t.Type == "consentInit" {
+			consentInitTriggers[t.TriggerID] = true
+		}
+	}
+
+	tags := make([]consentAuditTag, 0, len(entities.Tags))
+	for _, t := range entities.Tags {
+		tag := consentAuditTag{
+			TagID:           t.TagID,
+			Name:            t.Name,
+			Type:            t.Type,
+			Template:        templateNames[t.Type],
+			Paused:          t.Paused,
+			ConsentSettings: t.ConsentSettings,
+		}
+		if tag.ConsentSettings == nil {
+			tag.ConsentSettings = &gtm.ConsentSettings{ConsentStatus: gtm.ConsentNotSet}
+		}
+		for _, id := range t.FiringTriggerID {
+			name := triggerNames[id]
+			if name == "" {
+				name = id
+			}
+			tag.FiringTriggers = append(tag.FiringTriggers, name)
+			if consentInitTriggers[id] {
+				tag.ConsentInitialization = true
+			}
+		}
+		tags = append(tags, tag)
+	}
+
+	dataJSON, err := json.MarshalIndent(map[string]any{"tags": tags}, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+
+	return &mcp.GetPromptResult{
+		Description: "Consent Mode v2 compliance review request",
+		Messages: []*mcp.PromptMessage{
+			{
+				Role: "user",
+				Content: &mcp.TextContent{
+					Text: fmt.Sprintf(`Please review this GTM workspace for Consent Mode v2 compliance on EEA traffic. Here are its tags with their consent settings; tags with consentInitialization fire on a Consent Initialization trigger:
+
+%s
+
+Background:
+- Consent Mode v2 requires four consent types: ad_storage, analytics_storage, ad_user_data and ad_personalization. Default states must be set before any tag fires, from a CMP or consent tag on the Consent Initialization - All Pages trigger.
+- Google tags (googtag, gaawe, awct, sp, flc, fls and similar) have built-in consent checks, so consentStatus notSet is normal for them.
+- Every other tag (Custom HTML, third-party and cvt_ template tags) needs consentStatus "needed" with the consent types matching what it does, e.g. ad_storage and ad_user_data for advertising pixels, analytics_storage for analytics.
+
+Please report:
+
+1. **Consent Initialization**
+   - Which tag sets the consent defaults, and does it fire on a Consent Initialization trigger?
+   - Is a CMP present (check tag names, types and template names)? If not, say so first.
+
+2. **Tags Missing Consent Configuration**
+   - List every non-Google tag with consentStatus notSet or notNeeded, and every tag whose consent types don't cover what it does.
+   - Flag Custom HTML tags separately: their behaviour can't be verified from the configuration.
+
+3. **Remediation per Tag**
+   - For each flagged tag give the tag name and ID, the consentStatus and consentType values to set, and the update_tag call (consentStatus, consentTypes) that applies them.
+   - Note tags that should fire on a different trigger to respect consent, e.g. consent update events.
+
+4. **Summary**
+   - How many tags are compliant, need changes or need manual review.
+
+Paused tags can be listed last, since they do not fire.`, string(dataJSON)),
+				},
+			},
+		},
+	}, nil
+}
@@ -0,0 +1,649 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/paolobietolini/gtm-mcp-server/gtm"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/yosida95/uritemplate/v3"
+	tagmanager "google.golang.org/api/tagmanager/v2"
+)
+
+// URI template patterns for GTM resources
+const (
+	uriAccounts    = "gtm://accounts"
+	uriContainers  = "gtm://accounts/{accountId}/containers"
+	uriSummary     = "gtm://accounts/{accountId}/summary"
+	uriWorkspaces  = "gtm://accounts/{accountId}/containers/{containerId}/workspaces"
+	uriTags        = "gtm://accounts/{accountId}/containers/{containerId}/workspaces/{workspaceId}/tags"
+	uriTriggers    = "gtm://accounts/{accountId}/containers/{containerId}/workspaces/{workspaceId}/triggers"
+	uriVariables   = "gtm://accounts/{accountId}/containers/{containerId}/workspaces/{workspaceId}/variables"
+	uriTag         = "gtm://accounts/{accountId}/containers/{containerId}/workspaces/{workspaceId}/tags/{tagId}"
+	uriTrigger     = "gtm://accounts/{accountId}/containers/{containerId}/workspaces/{workspaceId}/triggers/{triggerId}"
+	uriVariable    = "gtm://accounts/{accountId}/containers/{containerId}/workspaces/{workspaceId}/variables/{variableId}"
+	uriTemplate    = "gtm://accounts/{accountId}/containers/{containerId}/workspaces/{workspaceId}/templates/{templateId}"
+	uriVersions    = "gtm://accounts/{accountId}/containers/{containerId}/versions"
+	uriVersion     = "gtm://accounts/{accountId}/containers/{containerId}/versions/{versionId}"
+	uriLiveVersion = "gtm://accounts/{accountId}/containers/{containerId}/versions/live"
+	uriDigest      = "gtm://digest/latest"
+)
+
+// Compiled URI templates for extracting parameters
+var (
+	tmplContainers = uritemplate.MustNew(uriContainers)
+	tmplSummary    = uritemplate.MustNew(uriSummary)
+	tmplWorkspaces = uritemplate.MustNew(uriWorkspaces)
+	tmplTags       = uritemplate.MustNew(uriTags)
+	tmplTriggers   = uritemplate.MustNew(uriTriggers)
+	tmplVariables  = uritemplate.MustNew(uriVariables)
+	tmplTag        = uritemplate.MustNew(uriTag)
+	tmplTrigger    = uritemplate.MustNew(uriTrigger)
+	tmplVariable   = uritemplate.MustNew(uriVariable)
+	tmplTemplate   = uritemplate.MustNew(uriTemplate)
+	tmplVersions   = uritemplate.MustNew(uriVersions)
+	tmplVersion    = uritemplate.MustNew(uriVersion)
+)
+
+// RegisterResources adds all GTM resource templates to the MCP server.
+func RegisterResources(server *mcp.Server) {
+	// gtm://accounts - list all accounts
+	server.AddResource(&mcp.Resource{
+		Name:        "GTM Accounts",
+		Description: "List of all Google Tag Manager accounts accessible to the authenticated user",
+		MIMEType:    "application/json",
+		URI:         uriAccounts,
+	}, handleAccountsResource)
+
+	// gtm://accounts/{accountId}/containers - list containers in account
+	server.AddResourceTemplate(&mcp.ResourceTemplate{
+		Name:        "GTM Containers",
+		Description: "List of containers in a GTM account",
+		MIMEType:    "application/json",
+		URITemplate: uriContainers,
+	}, handleContainersResource)
+
+	// gtm://accounts/{accountId}/summary - container inventory for an account
+	server.AddResourceTemplate(&mcp.ResourceTemplate{
+		Name:        "GTM Account Summary",
+		Description: "Inventory of all containers in a GTM account with type, public ID, live version (with the days since it was last modified) and workspace count",
+		MIMEType:    "application/json",
+		URITemplate: uriSummary,
+	}, handleSummaryResource)
+
+	// gtm://accounts/{accountId}/containers/{containerId}/workspaces
+	server.AddResourceTemplate(&mcp.ResourceTemplate{
+		Name:        "GTM Workspaces",
+		Description: "List of workspaces in a GTM container",
+		MIMEType:    "application/json",
+		URITemplate: uriWorkspaces,
+	}, handleWorkspacesResource)
+
+	// gtm://accounts/{accountId}/containers/{containerId}/workspaces/{workspaceId}/tags
+	server.AddResourceTemplate(&mcp.ResourceTemplate{
+		Name:        "GTM Tags",
+		Description: "List of all tags in a GTM workspace",
+		MIMEType:    "application/json",
+		URITemplate: uriTags,
+	}, handleTagsResource)
+
+	// gtm://accounts/{accountId}/containers/{containerId}/workspaces/{workspaceId}/triggers
+	server.AddResourceTemplate(&mcp.ResourceTemplate{
+		Name:        "GTM Triggers",
+		Description: "List of all triggers in a GTM workspace",
+		MIMEType:    "application/json",
+		URITemplate: uriTriggers,
+	}, handleTriggersResource)
+
+	// gtm://accounts/{accountId}/containers/{containerId}/workspaces/{workspaceId}/variables
+	server.AddResourceTemplate(&mcp.ResourceTemplate{
+		Name:        "GTM Variables",
+		Description: "List of all variables in a GTM workspace",
+		MIMEType:    "application/json",
+		URITemplate: uriVariables,
+	}, handleVariablesResource)
+
+	// gtm://accounts/{accountId}/containers/{containerId}/workspaces/{workspaceId}/tags/{tagId}
+	server.AddResourceTemplate(&mcp.ResourceTemplate{
+		Name:        "GTM Tag",
+		Description: "A single tag in a GTM workspace, with its parameters and firing triggers",
+		MIMEType:    "application/json",
+		URITemplate: uriTag,
+	}, handleTagResource)
+
+	// gtm://accounts/{accountId}/containers/{containerId}/workspaces/{workspaceId}/triggers/{triggerId}
+	server.AddResourceTemplate(&mcp.ResourceTemplate{
+		Name:        "GTM Trigger",
+		Description: "A single trigger in a GTM workspace, with its filters and parameters",
+		MIMEType:    "application/json",
+		URITemplate: uriTrigger,
+	}, handleTriggerResource)
+
+	// gtm://accounts/{accountId}/containers/{containerId}/workspaces/{workspaceId}/variables/{variableId}
+	server.AddResourceTemplate(&mcp.ResourceTemplate{
+		Name:        "GTM Variable",
+		Description: "A single variable in a GTM workspace, with its parameters",
+		MIMEType:    "application/json",
+		URITemplate: uriVariable,
+	}, handleVariableResource)
+
+	// gtm://accounts/{accountId}/containers/{containerId}/workspaces/{workspaceId}/templates/{templateId}
+	server.AddResourceTemplate(&mcp.ResourceTemplate{
+		Name:        "GTM Custom Template",
+		Description: "A single custom template in a GTM workspace, including its template code",
+		MIMEType:    "application/json",
+		URITemplate: uriTemplate,
+	}, handleTemplateResource)
+
+	// gtm://accounts/{accountId}/containers/{containerId}/versions
+	server.AddResourceTemplate(&mcp.ResourceTemplate{
+		Name:        "GTM Versions",
+		Description: "List of version headers of a GTM container with entity counts",
+		MIMEType:    "application/json",
+		URITemplate: uriVersions,
+	}, handleVersionsResource)
+
+	// gtm://accounts/{accountId}/containers/{containerId}/versions/live -
+	// served by the version handler, listed separately for discoverability
+	server.AddResourceTemplate(&mcp.ResourceTemplate{
+		Name:        "GTM Live Version",
+		Description: "The published version of a GTM container with all its tags, triggers, variables and templates",
+		MIMEType:    "application/json",
+		URITemplate: uriLiveVersion,
+	}, handleVersionResource)
+
+	// gtm://accounts/{accountId}/containers/{containerId}/versions/{versionId}
+	server.AddResourceTemplate(&mcp.ResourceTemplate{
+		Name:        "GTM Version",
+		Description: "A GTM container version with all its tags, triggers, variables and templates",
+		MIMEType:    "application/json",
+		URITemplate: uriVersion,
+	}, handleVersionResource)
+
+	// gtm://digest/latest - most recent scheduled audit and backup run
+	server.AddResource(&mcp.Resource{
+		Name:        "GTM Governance Digest",
+		Description: "Results of the most recent scheduled audit and backup run across the containers configured in DIGEST_FILE",
+		MIMEType:    "application/json",
+		URI:         uriDigest,
+	}, handleDigestResource)
+}
+
+func handleAccountsResource(ctx context.Context, req *mcp.ReadResourceRequest) (*mcp.ReadResourceResult, error) {
+	client, err := getClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	accounts, err := client.ListAccounts(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := json.MarshalIndent(map[string]any{"accounts": accounts}, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+
+	return &mcp.ReadResourceResult{
+		Contents: []*mcp.ResourceContents{
+			{
+				URI:      req.Params.URI,
+				MIMEType: "application/json",
+				Text:     string(data),
+			},
+		},
+	}, nil
+}
+
+func handleContainersResource(ctx context.Context, req *mcp.ReadResourceRequest) (*mcp.ReadResourceResult, error) {
+	// Extract accountId from URI using regex
+	match := tmplContainers.Regexp().FindStringSubmatch(req.Params.URI)
+	if len(match) < 2 {
+		return nil, fmt.Errorf("invalid URI: could not extract accountId")
+	}
+	accountID := match[1]
+
+	client, err := getClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	containers, err := client.ListContainers(ctx, accountID)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := json.MarshalIndent(map[string]any{"containers": containers}, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+
+	return &mcp.ReadResourceResult{
+		Contents: []*mcp.ResourceContents{
+			{
+				URI:      req.Params.URI,
+				MIMEType: "application/json",
+				Text:     string(data),
+			},
+		},
+	}, nil
+}
+
+func handleSummaryResource(ctx context.Context, req *mcp.ReadResourceRequest) (*mcp.ReadResourceResult, error) {
+	match := tmplSummary.Regexp().FindStringSubmatch(req.Params.URI)
+	if len(match) < 2 {
+		return nil, fmt.Errorf("invalid URI: could not extract accountId")
+	}
+	accountID := match[1]
+
+	client, err := getClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	summary, err := client.GetAccountSummary(ctx, accountID)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := json.MarshalIndent(summary, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+
+	return &mcp.ReadResourceResult{
+		Contents: []*mcp.ResourceContents{
+			{
+				URI:      req.Params.URI,
+				MIMEType: "application/json",
+				Text:     string(data),
+			},
+		},
+	}, nil
+}
+
+func handleWorkspacesResource(ctx context.Context, req *mcp.ReadResourceRequest) (*mcp.ReadResourceResult, error) {
+	match := tmplWorkspaces.Regexp().FindStringSubmatch(req.Params.URI)
+	if len(match) < 3 {
+		return nil, fmt.Errorf("invalid URI: could not extract accountId and containerId")
+	}
+	accountID := match[1]
+	containerID := match[2]
+
+	client, err := getClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	workspaces, err := client.ListWorkspaces(ctx, accountID, containerID)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := json.MarshalIndent(map[string]any{"workspaces": workspaces}, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+
+	return &mcp.ReadResourceResult{
+		Contents: []*mcp.ResourceContents{
+			{
+				URI:      req.Params.URI,
+				MIMEType: "application/json",
+				Text:     string(data),
+			},
+		},
+	}, nil
+}
+
+func handleTagsResource(ctx context.Context, req *mcp.ReadResourceRequest) (*mcp.ReadResourceResult, error) {
+	match := tmplTags.Regexp().FindStringSubmatch(req.Params.URI)
+	if len(match) < 4 {
+		return nil, fmt.Errorf("invalid URI: could not extract accountId, containerId, and workspaceId")
+	}
+	accountID := match[1]
+	containerID := match[2]
+	workspaceID := match[3]
+
+	client, err := getClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	tags, err := client.ListTags(ctx, accountID, containerID, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := json.MarshalIndent(map[string]any{"tags": tags}, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+
+	return &mcp.ReadResourceResult{
+		Contents: []*mcp.ResourceContents{
+			{
+				URI:      req.Params.URI,
+				MIMEType: "application/json",
+				Text:     string(data),
+			},
+		},
+	}, nil
+}
+
+func handleTriggersResource(ctx context.Context, req *mcp.ReadResourceRequest) (*mcp.ReadResourceResult, error) {
+	match := tmplTriggers.Regexp().FindStringSubmatch(req.Params.URI)
+	if len(match) < 4 {
+		return nil, fmt.Errorf("invalid URI: could not extract accountId, containerId, and workspaceId")
+	}
+	accountID := match[1]
+	containerID := match[2]
+	workspaceID := match[3]
+
+	client, err := getClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	triggers, err := client.ListTriggers(ctx, accountID, containerID, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+	gtm.RedactTriggers(triggers)
+
+	data, err := json.MarshalIndent(map[string]any{"triggers": triggers}, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+
+	return &mcp.ReadResourceResult{
+		Contents: []*mcp.ResourceContents{
+			{
+				URI:      req.Params.URI,
+				MIMEType: "application/json",
+				Text:     string(data),
+			},
+		},
+	}, nil
+}
+
+func handleVariablesResource(ctx context.Context, req *mcp.ReadResourceRequest) (*mcp.ReadResourceResult, error) {
+	match := tmplVariables.Regexp().FindStringSubmatch(req.Params.URI)
+	if len(match) < 4 {
+		return nil, fmt.Errorf("invalid URI: could not extract accountId, containerId, and workspaceId")
+	}
+	accountID := match[1]
+	containerID := match[2]
+	workspaceID := match[3]
+
+	client, err := getClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	variables, err := client.ListVariables(ctx, accountID, containerID, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := json.MarshalIndent(map[string]any{"variables": variables}, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+
+	return &mcp.ReadResourceResult{
+		Contents: []*mcp.ResourceContents{
+			{
+				URI:      req.Params.URI,
+				MIMEType: "application/json",
+				Text:     string(data),
+			},
+		},
+	}, nil
+}
+
+// matchEntityURI extracts the account, container, workspace and entity IDs
+// from a single-entity resource URI.
+func matchEntityURI(tmpl *uritemplate.Template, uri string) (accountID, containerID, workspaceID, entityID string, err error) {
+	match := tmpl.Regexp().FindStringSubmatch(uri)
+	if len(match) < 5 {
+		return "", "", "", "", fmt.Errorf("invalid URI: could not extract accountId, containerId, workspaceId, and entity ID")
+	}
+	return match[1], match[2], match[3], match[4], nil
+}
+
+func handleTagResource(ctx context.Context, req *mcp.ReadResourceRequest) (*mcp.ReadResourceResult, error) {
+	accountID, containerID, workspaceID, tagID, err := matchEntityURI(tmplTag, req.Params.URI)
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := getClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	tag, err := client.GetTag(ctx, accountID, containerID, workspaceID, tagID)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := json.MarshalIndent(tag, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+
+	return &mcp.ReadResourceResult{
+		Contents: []*mcp.ResourceContents{
+			{
+				URI:      req.Params.URI,
+				MIMEType: "application/json",
+				Text:     string(data),
+			},
+		},
+	}, nil
+}
+
+func handleTriggerResource(ctx context.Context, req *mcp.ReadResourceRequest) (*mcp.ReadResourceResult, error) {
+	accountID, containerID, workspaceID, triggerID, err := matchEntityURI(tmplTrigger, req.Params.URI)
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := getClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	trigger, err := client.GetTrigger(ctx, accountID, containerID, workspaceID, triggerID)
+	if err != nil {
+		return nil, err
+	}
+	gtm.RedactAPIParameters(trigger.Parameter)
+
+	data, err := json.MarshalIndent(trigger, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+
+	return &mcp.ReadResourceResult{
+		Contents: []*mcp.ResourceContents{
+			{
+				URI:      req.Params.URI,
+				MIMEType: "application/json",
+				Text:     string(data),
+			},
+		},
+	}, nil
+}
+
+func handleVariableResource(ctx context.Context, req *mcp.ReadResourceRequest) (*mcp.ReadResourceResult, error) {
+	accountID, containerID, workspaceID, variableID, err := matchEntityURI(tmplVariable, req.Params.URI)
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := getClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	variable, err := client.GetVariable(ctx, accountID, containerID, workspaceID, variableID)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := json.MarshalIndent(variable, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+
+	return &mcp.ReadResourceResult{
+		Contents: []*mcp.ResourceContents{
+			{
+				URI:      req.Params.URI,
+				MIMEType: "application/json",
+				Text:     string(data),
+			},
+		},
+	}, nil
+}
+
+func handleTemplateResource(ctx context.Context, req *mcp.ReadResourceRequest) (*mcp.ReadResourceResult, error) {
+	accountID, containerID, workspaceID, templateID, err := matchEntityURI(tmplTemplate, req.Params.URI)
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := getClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	path := fmt.Sprintf("accounts/%s/containers/%s/workspaces/%s/templates/%s",
+		accountID, containerID, workspaceID, templateID)
+	template, err := gtm.RetryWithBackoff(ctx, func() (*tagmanager.CustomTemplate, error) {
+		return client.Service.Accounts.Containers.Workspaces.Templates.Get(path).Context(ctx).Do()
+	})
+	if err != nil {
+		return nil, gtm.MapGoogleError(err)
+	}
+
+	data, err := json.MarshalIndent(template, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+
+	return &mcp.ReadResourceResult{
+		Contents: []*mcp.ResourceContents{
+			{
+				URI:      req.Params.URI,
+				MIMEType: "application/json",
+				Text:     string(data),
+			},
+		},
+	}, nil
+}
+
+func handleVersionsResource(ctx context.Context, req *mcp.ReadResourceRequest) (*mcp.ReadResourceResult, error) {
+	match := tmplVersions.Regexp().FindStringSubmatch(req.Params.URI)
+	if len(match) < 3 {
+		return nil, fmt.Errorf("invalid URI: could not extract accountId and containerId")
+	}
+	accountID := match[1]
+	containerID := match[2]
+
+	client, err := getClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	versions, err := client.ListVersions(ctx, accountID, containerID)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := json.MarshalIndent(map[string]any{"versions": versions}, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+
+	return &mcp.ReadResourceResult{
+		Contents: []*mcp.ResourceContents{
+			{
+				URI:      req.Params.URI,
+				MIMEType: "application/json",
+				Text:     string(data),
+			},
+		},
+	}, nil
+}
+
+// handleVersionResource serves both a numbered version and versions/live,
+// which GetVersion resolves to the published version.
+func handleVersionResource(ctx context.Context, req *mcp.ReadResourceRequest) (*mcp.ReadResourceResult, error) {
+	match := tmplVersion.Regexp().FindStringSubmatch(req.Params.URI)
+	if len(match) < 4 {
+		return nil, fmt.Errorf("invalid URI: could not extract accountId, containerId, and versionId")
+	}
+	accountID := match[1]
+	containerID := match[2]
+	versionID := match[3]
+
+	client, err := getClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	version, err := client.GetVersion(ctx, accountID, containerID, versionID)
+	if err != nil {
+		return nil, err
+	}
+	gtm.RedactVersion(version)
+
+	data, err := json.MarshalIndent(version, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+
+	return &mcp.ReadResourceResult{
+		Contents: []*mcp.ResourceContents{
+			{
+				URI:      req.Params.URI,
+				MIMEType: "application/json",
+				Text:     string(data),
+			},
+		},
+	}, nil
+}
+
+func handleDigestResource(ctx context.Context, req *mcp.ReadResourceRequest) (*mcp.ReadResourceResult, error) {
+	// The digest is produced with the scheduler's credential; only serve it
+	// to authenticated sessions.
+	if _, err := getClient(ctx); err != nil {
+		return nil, err
+	}
+
+	digest := gtm.LatestDigest.Get()
+	if digest == nil {
+		return nil, fmt.Errorf("no digest available: scheduled digests are disabled (set DIGEST_FILE) or the first run has not finished")
+	}
+
+	data, err := json.MarshalIndent(digest, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+
+	return &mcp.ReadResourceResult{
+		Contents: []*mcp.ResourceContents{
+			{
+				URI:      req.Params.URI,
+				MIMEType: "application/json",
+				Text:     string(data),
+			},
+		},
+	}, nil
+}
@@ -0,0 +1,37 @@
+package tools
+
+import "testing"
+
+func TestMatchEntityURI(t *testing.T) {
+	uri := "gtm://accounts/1/containers/2/workspaces/3/tags/42"
+	accountID, containerID, workspaceID, tagID, err := matchEntityURI(tmplTag, uri)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if accountID != "1" || containerID != "2" || workspaceID != "3" || tagID != "42" {
+		t.Errorf("got %s, %s, %s, %s", accountID, containerID, workspaceID, tagID)
+	}
+
+	// The list template must not claim single-entity URIs
+	if tmplTags.Regexp().MatchString(uri) {
+		t.Error("tags list template matched a single-tag URI")
+	}
+	if _, _, _, _, err := matchEntityURI(tmplTrigger, uri); err == nil {
+		t.Error("trigger template matched a tag URI")
+	}
+}
+
+func TestVersionURIs(t *testing.T) {
+	for _, uri := range []string{
+		"gtm://accounts/1/containers/2/versions/7",
+		"gtm://accounts/1/containers/2/versions/live",
+	} {
+		match := tmplVersion.Regexp().FindStringSubmatch(uri)
+		if len(match) < 4 || match[1] != "1" || match[2] != "2" {
+			t.Errorf("%s: match = %q", uri, match)
+		}
+		if tmplVersions.Regexp().MatchString(uri) {
+			t.Errorf("%s matched the versions list template", uri)
+		}
+	}
+}
@@ -0,0 +1,20 @@
+package tools
+
+import (
+	"context"
+
+	"github.com/paolobietolini/gtm-mcp-server/gtm"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// withSessionHandler adds the calling session's ID and user to the handler context.
+func withSessionHandler[In, Out any](handler mcp.ToolHandlerFor[In, Out]) mcp.ToolHandlerFor[In, Out] {
+	return func(ctx context.Context, req *mcp.CallToolRequest, input In) (*mcp.CallToolResult, Out, error) {
+		ctx = gtm.WithUser(ctx, userKey(ctx))
+		if req != nil && req.Session != nil {
+			ctx = gtm.WithSession(ctx, req.Session.ID())
+		}
+		return handler(ctx, req, input)
+	}
+}
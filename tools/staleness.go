@@ -0,0 +1,112 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/paolobietolini/gtm-mcp-server/gtm"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// stalenessTTL is how long the sync state of a workspace is reused, so a
+// run of mutations checks it once.
+const stalenessTTL = 2 * time.Minute
+
+// stalenessChecker caches the sync state of workspaces targeted by
+// mutating tools.
+type stalenessChecker struct {
+	mu      sync.Mutex
+	results map[string]*stalenessResult
+	now     func() time.Time
+	check   func(ctx context.Context, ref WorkspaceRef) (*gtm.WorkspaceSyncState, error)
+}
+
+type stalenessResult struct {
+	state     *gtm.WorkspaceSyncState
+	checkedAt time.Time
+}
+
+func newStalenessChecker() *stalenessChecker {
+	return &stalenessChecker{
+		results: make(map[string]*stalenessResult),
+		now:     time.Now,
+		check: func(ctx context.Context, ref WorkspaceRef) (*gtm.WorkspaceSyncState, error) {
+			wc, err := resolveWorkspace(ctx, ref.AccountID, ref.ContainerID, ref.WorkspaceID)
+			if err != nil {
+				return nil, err
+			}
+			return wc.Client.GetWorkspaceSyncState(ctx, ref.AccountID, ref.ContainerID, ref.WorkspaceID)
+		},
+	}
+}
+
+// warnings returns the sync warnings of a workspace. A failed check gives
+// no warnings: it must never fail the mutation it precedes.
+func (s *stalenessChecker) warnings(ctx context.Context, ref WorkspaceRef) []string {
+	path := ref.String()
+	s.mu.Lock()
+	for key, r := range s.results {
+		if s.now().Sub(r.checkedAt) >= stalenessTTL {
+			delete(s.results, key)
+		}
+	}
+	cached := s.results[path]
+	s.mu.Unlock()
+	if cached != nil {
+		return cached.state.Warnings(path)
+	}
+
+	state, err := s.check(ctx, ref)
+	if err != nil {
+		return nil
+	}
+	s.mu.Lock()
+	s.results[path] = &stalenessResult{state: state, checkedAt: s.now()}
+	s.mu.Unlock()
+	return state.Warnings(path)
+}
+
+// withStalenessWarning checks the workspace a mutating tool targets before
+// it runs, and adds a warning to a successful result when the workspace has
+// merge conflicts or is behind the latest container version. The warning is
+// an extra text block after the JSON output, so structured output keeps its
+// schema.
+func withStalenessWarning[In, Out any](checker *stalenessChecker, handler mcp.ToolHandlerFor[In, Out]) mcp.ToolHandlerFor[In, Out] {
+	fields := workspaceIDFields(reflect.TypeFor[In](), false)
+	if checker == nil || len(fields) != len(workspaceIDNames) {
+		return handler
+	}
+	return func(ctx context.Context, req *mcp.CallToolRequest, input In) (*mcp.CallToolResult, Out, error) {
+		v := reflect.ValueOf(input)
+		var ref WorkspaceRef
+		ref.AccountID = v.Field(fields["accountId"]).String()
+		ref.ContainerID = v.Field(fields["containerId"]).String()
+		ref.WorkspaceID = v.Field(fields["workspaceId"]).String()
+		if ref.AccountID == "" || ref.ContainerID == "" || ref.WorkspaceID == "" {
+			return handler(ctx, req, input)
+		}
+
+		warnings := checker.warnings(ctx, ref)
+		res, out, err := handler(ctx, req, input)
+		if err != nil || len(warnings) == 0 {
+			return res, out, err
+		}
+		if res == nil {
+			res = &mcp.CallToolResult{}
+		}
+		if res.Content == nil {
+			data, err := json.Marshal(out)
+			if err != nil {
+				return res, out, nil
+			}
+			res.Content = []mcp.Content{&mcp.TextContent{Text: string(data)}}
+		}
+		res.Content = append(res.Content, &mcp.TextContent{Text: "Warning: " + strings.Join(warnings, "\nWarning: ")})
+		return res, out, nil
+	}
+}
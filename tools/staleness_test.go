@@ -0,0 +1,53 @@
+package tools
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/paolobietolini/gtm-mcp-server/gtm"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+func TestWithStalenessWarning(t *testing.T) {
+	checks := 0
+	now := time.Now()
+	checker := newStalenessChecker()
+	checker.now = func() time.Time { return now }
+	checker.check = func(ctx context.Context, ref WorkspaceRef) (*gtm.WorkspaceSyncState, error) {
+		checks++
+		return &gtm.WorkspaceSyncState{Behind: true, LatestVersionID: "12"}, nil
+	}
+	handler := withStalenessWarning(checker, func(ctx context.Context, req *mcp.CallToolRequest, input contextTestInput) (*mcp.CallToolResult, map[string]string, error) {
+		return nil, map[string]string{"tagId": "7"}, nil
+	})
+	input := contextTestInput{AccountID: "1", ContainerID: "2", WorkspaceID: "3"}
+
+	for range 2 {
+		res, _, err := handler(context.Background(), nil, input)
+		if err != nil || res == nil || len(res.Content) != 2 {
+			t.Fatalf("res = %+v, err = %v", res, err)
+		}
+		if text := res.Content[1].(*mcp.TextContent).Text; !strings.Contains(text, "behind") {
+			t.Errorf("warning = %q", text)
+		}
+		if text := res.Content[0].(*mcp.TextContent).Text; text != `{"tagId":"7"}` {
+			t.Errorf("output = %q", text)
+		}
+	}
+	if checks != 1 {
+		t.Errorf("checks = %d, want 1 (cached)", checks)
+	}
+	now = now.Add(stalenessTTL)
+	handler(context.Background(), nil, input)
+	if checks != 2 {
+		t.Errorf("checks = %d after TTL, want 2", checks)
+	}
+
+	// Calls without a full workspace path are not checked
+	if res, _, _ := handler(context.Background(), nil, contextTestInput{AccountID: "1"}); res != nil {
+		t.Errorf("res = %+v", res)
+	}
+}
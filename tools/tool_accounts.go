@@ -1,17 +1,19 @@
-package gtm
+package tools
 
 import (
 	"context"
 
+	"github.com/paolobietolini/gtm-mcp-server/gtm"
+
 	"github.com/modelcontextprotocol/go-sdk/mcp"
 )
 
 type ListAccountsInput struct{}
 type ListAccountsOutput struct {
-	Accounts []Account `json:"accounts"`
+	Accounts []gtm.Account `json:"accounts"`
 }
 
-func registerListAccounts(server *mcp.Server) {
+func registerListAccounts(r *toolRegistry) {
 	handler := func(ctx context.Context, req *mcp.CallToolRequest, input ListAccountsInput) (*mcp.CallToolResult, ListAccountsOutput, error) {
 		client, err := getClient(ctx)
 		if err != nil {
@@ -26,7 +28,7 @@ func registerListAccounts(server *mcp.Server) {
 		return nil, ListAccountsOutput{Accounts: accounts}, nil
 	}
 
-	mcp.AddTool(server, &mcp.Tool{
+	addTool(r, &mcp.Tool{
 		Name:        "list_accounts",
 		Description: "List all GTM accounts accessible to the authenticated user",
 	}, handler)
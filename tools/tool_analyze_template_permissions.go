@@ -0,0 +1,92 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/paolobietolini/gtm-mcp-server/gtm"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	tagmanager "google.golang.org/api/tagmanager/v2"
+)
+
+// AnalyzeTemplatePermissionsInput is the input for analyze_template_permissions tool.
+type AnalyzeTemplatePermissionsInput struct {
+	GalleryOwner string `json:"galleryOwner,omitempty" jsonschema:"description:Owner of a Community Gallery template to review before import (with galleryRepository)"`
+	GalleryRepo  string `json:"galleryRepository,omitempty" jsonschema:"description:Repository of a Community Gallery template to review before import"`
+	GallerySha   string `json:"gallerySha,omitempty" jsonschema:"description:Commit SHA of the gallery template (optional, defaults to the latest)"`
+	AccountID    string `json:"accountId,omitempty" jsonschema:"description:The GTM account ID, to review a template already in a workspace"`
+	ContainerID  string `json:"containerId,omitempty" jsonschema:"description:The GTM container ID"`
+	WorkspaceID  string `json:"workspaceId,omitempty" jsonschema:"description:The GTM workspace ID"`
+	TemplateID   string `json:"templateId,omitempty" jsonschema:"description:The ID of a custom template in the workspace"`
+	TemplateData string `json:"templateData,omitempty" jsonschema:"description:Raw template code in .tpl format, to review a template from elsewhere"`
+}
+
+// AnalyzeTemplatePermissionsOutput is the output for analyze_template_permissions tool.
+type AnalyzeTemplatePermissionsOutput struct {
+	Source  string                        `json:"source"`
+	Report  *gtm.TemplatePermissionReport `json:"report"`
+	Message string                        `json:"message"`
+}
+
+func registerAnalyzeTemplatePermissions(r *toolRegistry) {
+	handler := func(ctx context.Context, req *mcp.CallToolRequest, input AnalyzeTemplatePermissionsInput) (*mcp.CallToolResult, AnalyzeTemplatePermissionsOutput, error) {
+		var data, source string
+		switch {
+		case input.TemplateData != "":
+			data, source = input.TemplateData, "templateData"
+		case input.GalleryOwner != "" || input.GalleryRepo != "":
+			if input.GalleryOwner == "" || input.GalleryRepo == "" {
+				return nil, AnalyzeTemplatePermissionsOutput{}, gtm.InvalidField("galleryRepository", "galleryOwner and galleryRepository are both required")
+			}
+			var err error
+			data, err = r.gallery.TemplateData(ctx, input.GalleryOwner, input.GalleryRepo, input.GallerySha)
+			if err != nil {
+				return nil, AnalyzeTemplatePermissionsOutput{}, err
+			}
+			source = "gallery " + input.GalleryOwner + "/" + input.GalleryRepo
+		case input.TemplateID != "":
+			wc, err := resolveWorkspace(ctx, input.AccountID, input.ContainerID, input.WorkspaceID)
+			if err != nil {
+				return nil, AnalyzeTemplatePermissionsOutput{}, err
+			}
+			path := fmt.Sprintf("%s/templates/%s", wc.WorkspacePath(), input.TemplateID)
+			template, err := gtm.RetryWithBackoff(ctx, func() (*tagmanager.CustomTemplate, error) {
+				return wc.Client.Service.Accounts.Containers.Workspaces.Templates.Get(path).Context(ctx).Do()
+			})
+			if err != nil {
+				return nil, AnalyzeTemplatePermissionsOutput{}, gtm.MapGoogleError(err)
+			}
+			data, source = template.TemplateData, path
+		default:
+			return nil, AnalyzeTemplatePermissionsOutput{}, gtm.InvalidField("templateData", "give galleryOwner and galleryRepository, a workspace templateId, or templateData")
+		}
+
+		report, err := gtm.AnalyzeTemplatePermissions(data)
+		if err != nil {
+			return nil, AnalyzeTemplatePermissionsOutput{}, err
+		}
+
+		var risky []string
+		for _, p := range report.Permissions {
+			if p.Risk == gtm.RiskHigh {
+				risky = append(risky, p.Summary)
+			}
+		}
+		message := fmt.Sprintf("%s requests %d permissions with %s risk overall.", report.DisplayName, len(report.Permissions), report.Risk)
+		if len(risky) > 0 {
+			message += " Review before import: " + strings.Join(risky, "; ") + "."
+		}
+		return nil, AnalyzeTemplatePermissionsOutput{
+			Source:  source,
+			Report:  report,
+			Message: message,
+		}, nil
+	}
+
+	addTool(r, &mcp.Tool{
+		Name:        "analyze_template_permissions",
+		Description: "Review the permissions a custom template requests (injected scripts, cookies read or set, pixels and requests with their domains, globals, data layer access) with a risk level each, from its .tpl permission sections. Works on a Community Gallery template before import, a template in a workspace, or raw template data. Read-only.",
+	}, handler)
+}
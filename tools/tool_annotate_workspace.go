@@ -0,0 +1,154 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/paolobietolini/gtm-mcp-server/gtm"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// AnnotateWorkspaceInput is the input for annotate_workspace tool.
+type AnnotateWorkspaceInput struct {
+	AccountID   string           `json:"accountId" jsonschema:"description:The GTM account ID"`
+	ContainerID string           `json:"containerId" jsonschema:"description:The GTM container ID"`
+	WorkspaceID string           `json:"workspaceId" jsonschema:"description:The GTM workspace ID"`
+	EntityTypes []string         `json:"entityTypes,omitempty" jsonschema:"description:Entity kinds to document: tag, trigger, variable (optional, defaults to all three)"`
+	MaxEntities int              `json:"maxEntities,omitempty" jsonschema:"description:How many entities without notes to document (optional, default 20, max 100)"`
+	Notes       []gtm.EntityNote `json:"notes,omitempty" jsonschema:"description:Notes from a previous dry run to write as they are (optional; with confirm: true skips generating new notes)"`
+	Confirm     bool             `json:"confirm" jsonschema:"description:Must be true to write notes. When false the generated notes are returned without modifying anything."`
+}
+
+// AnnotateWorkspaceOutput is the output for annotate_workspace tool.
+type AnnotateWorkspaceOutput struct {
+	Success  bool             `json:"success"`
+	DryRun   bool             `json:"dryRun,omitempty"`
+	Missing  int              `json:"missing"`
+	Updated  int              `json:"updated"`
+	Entities []gtm.EntityNote `json:"entities"`
+	Message  string           `json:"message"`
+}
+
+func registerAnnotateWorkspace(r *toolRegistry) {
+	handler := func(ctx context.Context, req *mcp.CallToolRequest, input AnnotateWorkspaceInput) (*mcp.CallToolResult, AnnotateWorkspaceOutput, error) {
+		wc, err := resolveWorkspace(ctx, input.AccountID, input.ContainerID, input.WorkspaceID)
+		if err != nil {
+			return nil, AnnotateWorkspaceOutput{}, err
+		}
+		kinds, err := gtm.EntityKinds(input.EntityTypes)
+		if err != nil {
+			return nil, AnnotateWorkspaceOutput{}, gtm.InvalidField("entityTypes", "%v", err)
+		}
+		limit := input.MaxEntities
+		switch {
+		case limit == 0:
+			limit = DefaultAnnotateEntities
+		case limit < 0 || limit > MaxAnnotateEntities:
+			return nil, AnnotateWorkspaceOutput{}, gtm.InvalidField("maxEntities", "maxEntities must be between 1 and %d", MaxAnnotateEntities)
+		}
+		if len(input.Notes) > 0 && !input.Confirm {
+			return nil, AnnotateWorkspaceOutput{}, gtm.InvalidField("notes", "notes are only written with confirm: true")
+		}
+
+		candidates, err := wc.Client.EntitiesWithoutNotes(ctx, wc.AccountID, wc.ContainerID, wc.WorkspaceID, kinds)
+		if err != nil {
+			return nil, AnnotateWorkspaceOutput{}, err
+		}
+		out := AnnotateWorkspaceOutput{Missing: len(candidates), Entities: []gtm.EntityNote{}}
+
+		if len(input.Notes) > 0 {
+			// Write the approved notes of a previous dry run
+			byKey := make(map[string]*gtm.NoteCandidate, len(candidates))
+			for i := range candidates {
+				byKey[gtm.EntityKey(candidates[i].EntityType, candidates[i].EntityID)] = &candidates[i]
+			}
+			gtm.ExpectProgress(ctx, len(input.Notes))
+			for _, n := range input.Notes {
+				c, ok := byKey[gtm.EntityKey(n.EntityType, n.EntityID)]
+				switch {
+				case !ok:
+					n.Error = "entity not found or already has notes"
+				case n.Notes == "":
+					n.Error = "notes are empty"
+				default:
+					n.Name = c.Name
+					if err := c.Apply(ctx, n.Notes); err != nil {
+						n.Error = gtm.MapGoogleError(err).Error()
+					} else {
+						out.Updated++
+					}
+				}
+				out.Entities = append(out.Entities, n)
+				gtm.StepProgress(ctx, "Wrote notes of %s %q", n.EntityType, n.Name)
+			}
+			gtm.RecentMutations.Mark(ctx, wc.WorkspacePath())
+			out.Success = out.Updated == len(input.Notes)
+			out.Message = fmt.Sprintf("Wrote notes of %d of %d entities", out.Updated, len(input.Notes))
+			return nil, out, nil
+		}
+
+		if len(candidates) == 0 {
+			out.Success = true
+			out.DryRun = !input.Confirm
+			out.Message = "Every selected entity already has notes"
+			return nil, out, nil
+		}
+		if !supportsSampling(req) {
+			return nil, AnnotateWorkspaceOutput{}, fmt.Errorf("annotate_workspace needs an MCP client that supports sampling; write notes with update_tag, update_trigger and update_variable instead")
+		}
+
+		out.Updated, out.Entities = annotateEntities(ctx, req.Session.CreateMessage, candidates[:min(limit, len(candidates))], input.Confirm)
+		if input.Confirm {
+			gtm.RecentMutations.Mark(ctx, wc.WorkspacePath())
+			out.Success = out.Updated == len(out.Entities)
+			out.Message = fmt.Sprintf("Wrote notes of %d of %d entities; %d entities had no notes", out.Updated, len(out.Entities), out.Missing)
+		} else {
+			out.Success = true
+			out.DryRun = true
+			out.Message = fmt.Sprintf("Dry run: drafted notes for %d of %d entities without notes. Review them, then call again with confirm: true and the approved entities as notes to write them.", len(out.Entities), out.Missing)
+		}
+		return nil, out, nil
+	}
+
+	addTool(r, &mcp.Tool{
+		Name:        "annotate_workspace",
+		Description: "Draft Notes for tags, triggers and variables that have none, using the client's model (MCP sampling) to describe each entity's purpose from its type and parameters. Without confirm: true only returns the drafts. Pass the approved drafts as notes with confirm: true to write them as they are.",
+	}, handler)
+}
+
+// annotateEntities drafts notes for each candidate and, with apply set,
+// writes them. It returns how many notes were written and the result per
+// entity.
+func annotateEntities(ctx context.Context, sample sampleFunc, candidates []gtm.NoteCandidate, apply bool) (int, []gtm.EntityNote) {
+	updated := 0
+	notes := make([]gtm.EntityNote, 0, len(candidates))
+	gtm.ExpectProgress(ctx, len(candidates))
+	for _, c := range candidates {
+		n := c.EntityNote
+		var err error
+		if n.Notes, err = draftNote(ctx, sample, c); err == nil && apply {
+			if err = c.Apply(ctx, n.Notes); err != nil {
+				err = gtm.MapGoogleError(err)
+			}
+		}
+		if err != nil {
+			n.Error = err.Error()
+		} else if apply {
+			updated++
+		}
+		notes = append(notes, n)
+		gtm.StepProgress(ctx, "Documented %s %q", n.EntityType, n.Name)
+	}
+	return updated, notes
+}
+
+// supportsSampling reports whether the calling client accepts sampling
+// requests.
+func supportsSampling(req *mcp.CallToolRequest) bool {
+	if req == nil || req.Session == nil {
+		return false
+	}
+	params := req.Session.InitializeParams()
+	return params != nil && params.Capabilities != nil && params.Capabilities.Sampling != nil
+}
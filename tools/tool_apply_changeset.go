@@ -0,0 +1,80 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/paolobietolini/gtm-mcp-server/gtm"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// ApplyChangesetInput is the input for apply_changeset tool.
+type ApplyChangesetInput struct {
+	AccountID   string                `json:"accountId" jsonschema:"description:The GTM account ID"`
+	ContainerID string                `json:"containerId" jsonschema:"description:The GTM container ID"`
+	WorkspaceID string                `json:"workspaceId" jsonschema:"description:The GTM workspace ID"`
+	Operations  []gtm.ChangeOperation `json:"operations" jsonschema:"description:Ordered create/update/delete operations on tags, triggers and variables (at most 100)"`
+	Confirm     bool                  `json:"confirm" jsonschema:"description:Must be true to apply the change set. When false the operations are checked and listed without changing anything."`
+}
+
+// ApplyChangesetOutput is the output for apply_changeset tool.
+type ApplyChangesetOutput struct {
+	Success bool `json:"success"`
+	DryRun  bool `json:"dryRun,omitempty"`
+	gtm.ChangesetResult
+	Planned []gtm.ChangesetStep `json:"planned,omitempty"`
+	Message string              `json:"message"`
+}
+
+func registerApplyChangeset(r *toolRegistry) {
+	handler := func(ctx context.Context, req *mcp.CallToolRequest, input ApplyChangesetInput) (*mcp.CallToolResult, ApplyChangesetOutput, error) {
+		wc, err := resolveWorkspace(ctx, input.AccountID, input.ContainerID, input.WorkspaceID)
+		if err != nil {
+			return nil, ApplyChangesetOutput{}, err
+		}
+
+		// Safety guard: check and list the operations first
+		if !input.Confirm {
+			planned, err := wc.Client.PlanChangeset(ctx, wc.AccountID, wc.ContainerID, wc.WorkspaceID, input.Operations)
+			if err != nil {
+				return nil, ApplyChangesetOutput{}, err
+			}
+			return nil, ApplyChangesetOutput{
+				DryRun:          true,
+				ChangesetResult: gtm.ChangesetResult{Applied: []gtm.ChangesetStep{}},
+				Planned:         planned,
+				Message:         fmt.Sprintf("Dry run: %d operations checked. Call again with confirm: true to apply them; if one fails, the ones before it are rolled back.", len(planned)),
+			}, nil
+		}
+
+		result, err := wc.Client.ApplyChangeset(ctx, wc.AccountID, wc.ContainerID, wc.WorkspaceID, input.Operations)
+		if result == nil {
+			return nil, ApplyChangesetOutput{}, err
+		}
+		out := ApplyChangesetOutput{ChangesetResult: *result}
+		if err == nil {
+			out.Success = true
+			out.Message = fmt.Sprintf("Applied %d operations", len(result.Applied))
+			return nil, out, nil
+		}
+
+		// Report the failure and the rollback rather than a bare error
+		failedUndos := 0
+		for _, step := range result.RolledBack {
+			if step.Error != "" {
+				failedUndos++
+			}
+		}
+		out.Message = err.Error()
+		if failedUndos > 0 {
+			out.Message += fmt.Sprintf(". WARNING: %d operations could not be rolled back; see rolledBack for the entities to fix by hand", failedUndos)
+		}
+		return nil, out, nil
+	}
+
+	addTool(r, &mcp.Tool{
+		Name:        "apply_changeset",
+		Description: "Apply an ordered list of create/update/delete operations on tags, triggers and variables as one unit: if any operation fails, the operations already applied are reverted and the result lists exactly what was rolled back. Later operations can refer to entities created earlier with ref and $ref:<name>. Without confirm: true only checks and lists the operations.",
+	}, handler)
+}
@@ -0,0 +1,124 @@
+package tools
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/paolobietolini/gtm-mcp-server/gtm"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// errApprovalsDisabled is returned by the approval tools when the server
+// runs mutating tools directly.
+var errApprovalsDisabled = errors.New("approval mode is not enabled on this server (set APPROVAL_MODE=true)")
+
+// ListPendingChangesInput is the input for list_pending_changes tool.
+type ListPendingChangesInput struct {
+	AccountID   string `json:"accountId,omitempty" jsonschema:"description:Only changes for this GTM account ID"`
+	ContainerID string `json:"containerId,omitempty" jsonschema:"description:Only changes for this GTM container ID"`
+}
+
+// ListPendingChangesOutput is the output for list_pending_changes tool.
+type ListPendingChangesOutput struct {
+	Changes []PendingChange `json:"changes"`
+	Count   int             `json:"count"`
+}
+
+func registerListPendingChanges(r *toolRegistry) {
+	handler := func(ctx context.Context, req *mcp.CallToolRequest, input ListPendingChangesInput) (*mcp.CallToolResult, ListPendingChangesOutput, error) {
+		if r.approvals == nil {
+			return nil, ListPendingChangesOutput{}, errApprovalsDisabled
+		}
+		changes := r.approvals.Visible(ctx, input.AccountID, input.ContainerID)
+		return nil, ListPendingChangesOutput{Changes: changes, Count: len(changes)}, nil
+	}
+
+	addTool(r, &mcp.Tool{
+		Name:        "list_pending_changes",
+		Description: "List mutating tool calls waiting for approval, oldest first (approvers see every change, other clients only their own): approval ID, tool, requesting client, account/container/workspace, redacted input and expiry.",
+	}, handler)
+}
+
+// ApproveChangeInput is the input for approve_change tool.
+type ApproveChangeInput struct {
+	ApprovalID string `json:"approvalId" jsonschema:"description:The approval ID returned by the held tool call or list_pending_changes"`
+}
+
+// ApproveChangeOutput is the output for approve_change tool.
+type ApproveChangeOutput struct {
+	Success bool          `json:"success"`
+	Change  PendingChange `json:"change"`
+	Result  any           `json:"result,omitempty"` // output of the approved tool call
+	Message string        `json:"message"`
+}
+
+func registerApproveChange(r *toolRegistry) {
+	handler := func(ctx context.Context, req *mcp.CallToolRequest, input ApproveChangeInput) (*mcp.CallToolResult, ApproveChangeOutput, error) {
+		if r.approvals == nil {
+			return nil, ApproveChangeOutput{}, errApprovalsDisabled
+		}
+		if input.ApprovalID == "" {
+			return nil, ApproveChangeOutput{}, gtm.InvalidField("approvalId", "approvalId is required")
+		}
+
+		change, result, err := r.approvals.Approve(ctx, req, input.ApprovalID)
+		if err != nil {
+			if change != nil {
+				return nil, ApproveChangeOutput{}, fmt.Errorf("approved %s failed: %w", change.Tool, err)
+			}
+			return nil, ApproveChangeOutput{}, err
+		}
+		return nil, ApproveChangeOutput{
+			Success: true,
+			Change:  *change,
+			Result:  result,
+			Message: fmt.Sprintf("Approved and ran %s", change.Tool),
+		}, nil
+	}
+
+	addTool(r, &mcp.Tool{
+		Name:        "approve_change",
+		Description: "Approve a held mutating tool call and run it with the requester's credentials. Only call this when the user explicitly approves the change; requesters cannot approve their own changes and the server may restrict it to designated approvers.",
+	}, handler)
+}
+
+// RejectChangeInput is the input for reject_change tool.
+type RejectChangeInput struct {
+	ApprovalID string `json:"approvalId" jsonschema:"description:The approval ID returned by the held tool call or list_pending_changes"`
+	Reason     string `json:"reason,omitempty" jsonschema:"description:Why the change is rejected"`
+}
+
+// RejectChangeOutput is the output for reject_change tool.
+type RejectChangeOutput struct {
+	Success bool          `json:"success"`
+	Change  PendingChange `json:"change"`
+	Message string        `json:"message"`
+}
+
+func registerRejectChange(r *toolRegistry) {
+	handler := func(ctx context.Context, req *mcp.CallToolRequest, input RejectChangeInput) (*mcp.CallToolResult, RejectChangeOutput, error) {
+		if r.approvals == nil {
+			return nil, RejectChangeOutput{}, errApprovalsDisabled
+		}
+		if input.ApprovalID == "" {
+			return nil, RejectChangeOutput{}, gtm.InvalidField("approvalId", "approvalId is required")
+		}
+
+		change, err := r.approvals.Reject(ctx, input.ApprovalID)
+		if err != nil {
+			return nil, RejectChangeOutput{}, err
+		}
+		message := fmt.Sprintf("Rejected %s; it will not run", change.Tool)
+		if input.Reason != "" {
+			message += ": " + input.Reason
+		}
+		return nil, RejectChangeOutput{Success: true, Change: *change, Message: message}, nil
+	}
+
+	addTool(r, &mcp.Tool{
+		Name:        "reject_change",
+		Description: "Reject a held mutating tool call so it never runs.",
+	}, handler)
+}
@@ -0,0 +1,79 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/paolobietolini/gtm-mcp-server/gtm"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// AuditWorkspaceInput is the input for audit_workspace tool.
+type AuditWorkspaceInput struct {
+	AccountID   string `json:"accountId" jsonschema:"description:The GTM account ID"`
+	ContainerID string `json:"containerId" jsonschema:"description:The GTM container ID"`
+	WorkspaceID string `json:"workspaceId" jsonschema:"description:The GTM workspace ID"`
+	FailOn      string `json:"failOn,omitempty" jsonschema:"description:Lowest severity that fails the audit: error (default), warning or info"`
+	PausedDays  int    `json:"pausedDays,omitempty" jsonschema:"description:Paused tags unmodified for at least this many days are warnings instead of info (optional, defaults to 30)"`
+}
+
+// AuditWorkspaceOutput is the output for audit_workspace tool.
+type AuditWorkspaceOutput struct {
+	Passed   bool                   `json:"passed"`
+	FailOn   string                 `json:"failOn"`
+	Counts   map[string]int         `json:"counts"`
+	Findings []gtm.WorkspaceFinding `json:"findings"`
+	Message  string                 `json:"message"`
+}
+
+func registerAuditWorkspace(r *toolRegistry) {
+	handler := func(ctx context.Context, req *mcp.CallToolRequest, input AuditWorkspaceInput) (*mcp.CallToolResult, AuditWorkspaceOutput, error) {
+		failOn := input.FailOn
+		if failOn == "" {
+			failOn = gtm.SeverityError
+		}
+		if _, ok := gtm.SeverityRank[failOn]; !ok {
+			return nil, AuditWorkspaceOutput{}, gtm.InvalidField("failOn", "failOn must be error, warning or info")
+		}
+		pausedDays := input.PausedDays
+		if pausedDays == 0 {
+			pausedDays = gtm.DefaultPausedTagDays
+		}
+		if pausedDays < 0 {
+			return nil, AuditWorkspaceOutput{}, gtm.InvalidField("pausedDays", "pausedDays must not be negative")
+		}
+
+		wc, err := resolveWorkspace(ctx, input.AccountID, input.ContainerID, input.WorkspaceID)
+		if err != nil {
+			return nil, AuditWorkspaceOutput{}, err
+		}
+		version, err := wc.Client.SnapshotWorkspace(ctx, wc.AccountID, wc.ContainerID, wc.WorkspaceID)
+		if err != nil {
+			return nil, AuditWorkspaceOutput{}, err
+		}
+
+		findings := gtm.AuditWorkspace(version, time.Now(), pausedDays)
+		out := AuditWorkspaceOutput{Passed: true, FailOn: failOn, Counts: gtm.CountSeverities(findings), Findings: findings}
+		failing := 0
+		for _, f := range findings {
+			if gtm.SeverityRank[f.Severity] <= gtm.SeverityRank[failOn] {
+				failing++
+			}
+		}
+		out.Passed = failing == 0
+		out.Message = fmt.Sprintf("%d errors, %d warnings, %d info.", out.Counts[gtm.SeverityError], out.Counts[gtm.SeverityWarning], out.Counts[gtm.SeverityInfo])
+		if out.Passed {
+			out.Message += fmt.Sprintf(" Audit passed (failOn: %s).", failOn)
+		} else {
+			out.Message += fmt.Sprintf(" Audit failed: %d findings at %s or above.", failing, failOn)
+		}
+		return nil, out, nil
+	}
+
+	addTool(r, &mcp.Tool{
+		Name:        "audit_workspace",
+		Description: "Audit a workspace with deterministic checks and return structured findings (rule, severity, entity path): duplicate tags (same type and parameters; an error when they fire on the same triggers), unused triggers and variables, paused tags, tags without notes and risky custom HTML (document.write, external scripts, eval). passed is false when a finding reaches failOn, for CI gating.",
+	}, handler)
+}
@@ -0,0 +1,138 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/paolobietolini/gtm-mcp-server/gtm"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// GetTagsInput is the input for get_tags tool.
+type GetTagsInput struct {
+	AccountID   string   `json:"accountId" jsonschema:"description:The GTM account ID"`
+	ContainerID string   `json:"containerId" jsonschema:"description:The GTM container ID"`
+	WorkspaceID string   `json:"workspaceId" jsonschema:"description:The GTM workspace ID"`
+	TagIDs      []string `json:"tagIds" jsonschema:"description:The tag IDs to retrieve (up to 50)"`
+}
+
+// GetTagsOutput is the output for get_tags tool.
+type GetTagsOutput struct {
+	Tags    []gtm.BatchItem[gtm.Tag] `json:"tags"`
+	Message string                   `json:"message"`
+}
+
+// GetTriggersInput is the input for get_triggers tool.
+type GetTriggersInput struct {
+	AccountID        string   `json:"accountId" jsonschema:"description:The GTM account ID"`
+	ContainerID      string   `json:"containerId" jsonschema:"description:The GTM container ID"`
+	WorkspaceID      string   `json:"workspaceId" jsonschema:"description:The GTM workspace ID"`
+	TriggerIDs       []string `json:"triggerIds" jsonschema:"description:The trigger IDs to retrieve (up to 50)"`
+	IncludeSensitive bool     `json:"includeSensitive,omitempty" jsonschema:"description:Return parameter values that look like secrets (API keys, tokens, passwords) instead of redacting them"`
+}
+
+// GetTriggersOutput is the output for get_triggers tool.
+type GetTriggersOutput struct {
+	Triggers []gtm.BatchItem[gtm.Trigger] `json:"triggers"`
+	Message  string                       `json:"message"`
+}
+
+// GetVariablesInput is the input for get_variables tool.
+type GetVariablesInput struct {
+	AccountID   string   `json:"accountId" jsonschema:"description:The GTM account ID"`
+	ContainerID string   `json:"containerId" jsonschema:"description:The GTM container ID"`
+	WorkspaceID string   `json:"workspaceId" jsonschema:"description:The GTM workspace ID"`
+	VariableIDs []string `json:"variableIds" jsonschema:"description:The variable IDs to retrieve (up to 50)"`
+}
+
+// GetVariablesOutput is the output for get_variables tool.
+type GetVariablesOutput struct {
+	Variables []gtm.BatchItem[gtm.Variable] `json:"variables"`
+	Message   string                        `json:"message"`
+}
+
+// batchMessage summarizes a batch get.
+func batchMessage(kind string, total, failed int) string {
+	if failed == 0 {
+		return fmt.Sprintf("Retrieved %d %s", total, kind)
+	}
+	return fmt.Sprintf("Retrieved %d of %d %s; see error on the others", total-failed, total, kind)
+}
+
+func registerGetTags(r *toolRegistry) {
+	handler := func(ctx context.Context, req *mcp.CallToolRequest, input GetTagsInput) (*mcp.CallToolResult, GetTagsOutput, error) {
+		wc, err := resolveWorkspace(ctx, input.AccountID, input.ContainerID, input.WorkspaceID)
+		if err != nil {
+			return nil, GetTagsOutput{}, err
+		}
+
+		tags, err := gtm.BatchGet(ctx, "tagIds", input.TagIDs, func(ctx context.Context, id string) (*gtm.Tag, error) {
+			return wc.Client.GetTag(ctx, wc.AccountID, wc.ContainerID, wc.WorkspaceID, id)
+		})
+		if err != nil {
+			return nil, GetTagsOutput{}, err
+		}
+
+		return nil, GetTagsOutput{Tags: tags, Message: batchMessage("tags", len(tags), gtm.BatchFailures(tags))}, nil
+	}
+
+	addTool(r, &mcp.Tool{
+		Name:        "get_tags",
+		Description: "Get up to 50 tags by ID in one call, fetched concurrently. An ID that cannot be fetched gets an error on its item instead of failing the call",
+	}, handler)
+}
+
+func registerGetTriggers(r *toolRegistry) {
+	handler := func(ctx context.Context, req *mcp.CallToolRequest, input GetTriggersInput) (*mcp.CallToolResult, GetTriggersOutput, error) {
+		wc, err := resolveWorkspace(ctx, input.AccountID, input.ContainerID, input.WorkspaceID)
+		if err != nil {
+			return nil, GetTriggersOutput{}, err
+		}
+
+		triggers, err := gtm.BatchGet(ctx, "triggerIds", input.TriggerIDs, func(ctx context.Context, id string) (*gtm.Trigger, error) {
+			return wc.Client.GetTrigger(ctx, wc.AccountID, wc.ContainerID, wc.WorkspaceID, id)
+		})
+		if err != nil {
+			return nil, GetTriggersOutput{}, err
+		}
+
+		if !input.IncludeSensitive {
+			for _, item := range triggers {
+				if item.Entity != nil {
+					gtm.RedactAPIParameters(item.Entity.Parameter)
+				}
+			}
+		}
+
+		return nil, GetTriggersOutput{Triggers: triggers, Message: batchMessage("triggers", len(triggers), gtm.BatchFailures(triggers))}, nil
+	}
+
+	addTool(r, &mcp.Tool{
+		Name:        "get_triggers",
+		Description: "Get up to 50 triggers by ID in one call, fetched concurrently. An ID that cannot be fetched gets an error on its item instead of failing the call",
+	}, handler)
+}
+
+func registerGetVariables(r *toolRegistry) {
+	handler := func(ctx context.Context, req *mcp.CallToolRequest, input GetVariablesInput) (*mcp.CallToolResult, GetVariablesOutput, error) {
+		wc, err := resolveWorkspace(ctx, input.AccountID, input.ContainerID, input.WorkspaceID)
+		if err != nil {
+			return nil, GetVariablesOutput{}, err
+		}
+
+		variables, err := gtm.BatchGet(ctx, "variableIds", input.VariableIDs, func(ctx context.Context, id string) (*gtm.Variable, error) {
+			return wc.Client.GetVariable(ctx, wc.AccountID, wc.ContainerID, wc.WorkspaceID, id)
+		})
+		if err != nil {
+			return nil, GetVariablesOutput{}, err
+		}
+
+		return nil, GetVariablesOutput{Variables: variables, Message: batchMessage("variables", len(variables), gtm.BatchFailures(variables))}, nil
+	}
+
+	addTool(r, &mcp.Tool{
+		Name:        "get_variables",
+		Description: "Get up to 50 variables by ID in one call, fetched concurrently. An ID that cannot be fetched gets an error on its item instead of failing the call",
+	}, handler)
+}
@@ -0,0 +1,176 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/paolobietolini/gtm-mcp-server/gtm"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// ListBlueprintsInput is the input for list_blueprints tool.
+type ListBlueprintsInput struct {
+	Name string `json:"name,omitempty" jsonschema:"description:Return only this blueprint, with its full tag, trigger and variable definitions"`
+}
+
+// BlueprintSummary describes a blueprint in list_blueprints.
+type BlueprintSummary struct {
+	Name         string                     `json:"name"`
+	Title        string                     `json:"title"`
+	Description  string                     `json:"description"`
+	BuiltIn      bool                       `json:"builtIn"`
+	Placeholders []gtm.BlueprintPlaceholder `json:"placeholders"`
+	Variables    []string                   `json:"variables,omitempty"`
+	Triggers     []string                   `json:"triggers,omitempty"`
+	Tags         []string                   `json:"tags,omitempty"`
+	Definition   *gtm.Blueprint             `json:"definition,omitempty"`
+}
+
+// ListBlueprintsOutput is the output for list_blueprints tool.
+type ListBlueprintsOutput struct {
+	Blueprints []BlueprintSummary `json:"blueprints"`
+	Count      int                `json:"count"`
+}
+
+func entityNames(entities []map[string]any) []string {
+	names := make([]string, 0, len(entities))
+	for _, e := range entities {
+		names = append(names, stringField(e, "name"))
+	}
+	return names
+}
+
+func registerListBlueprints(r *toolRegistry) {
+	handler := func(ctx context.Context, req *mcp.CallToolRequest, input ListBlueprintsInput) (*mcp.CallToolResult, ListBlueprintsOutput, error) {
+		blueprints := gtm.SortedBlueprints(r.blueprints)
+		if input.Name != "" {
+			b, ok := r.blueprints[input.Name]
+			if !ok {
+				return nil, ListBlueprintsOutput{}, fmt.Errorf("%w: no blueprint named %q", gtm.ErrNotFound, input.Name)
+			}
+			blueprints = []*gtm.Blueprint{b}
+		}
+
+		out := ListBlueprintsOutput{Blueprints: make([]BlueprintSummary, 0, len(blueprints))}
+		for _, b := range blueprints {
+			summary := BlueprintSummary{
+				Name:         b.Name,
+				Title:        b.Title,
+				Description:  b.Description,
+				BuiltIn:      b.BuiltIn,
+				Placeholders: b.Placeholders,
+				Variables:    entityNames(b.Variables),
+				Triggers:     entityNames(b.Triggers),
+				Tags:         entityNames(b.Tags),
+			}
+			if input.Name != "" {
+				summary.Definition = b
+			}
+			out.Blueprints = append(out.Blueprints, summary)
+		}
+		out.Count = len(out.Blueprints)
+		return nil, out, nil
+	}
+
+	addTool(r, &mcp.Tool{
+		Name:        "list_blueprints",
+		Description: "List the blueprints apply_blueprint can provision: ready-made sets of tags, triggers and variables such as GA4 base setup, GA4 ecommerce, consent mode and Google Ads conversions, with the placeholder values each one needs. Pass name to see a blueprint's full definitions.",
+	}, handler)
+}
+
+// ApplyBlueprintInput is the input for apply_blueprint tool.
+type ApplyBlueprintInput struct {
+	AccountID   string            `json:"accountId" jsonschema:"description:The GTM account ID"`
+	ContainerID string            `json:"containerId" jsonschema:"description:The GTM container ID"`
+	WorkspaceID string            `json:"workspaceId" jsonschema:"description:The GTM workspace ID"`
+	Blueprint   string            `json:"blueprint" jsonschema:"description:Name of the blueprint (see list_blueprints)"`
+	Values      map[string]string `json:"values,omitempty" jsonschema:"description:Placeholder values keyed by placeholder name (e.g. MEASUREMENT_ID: G-ABC123); placeholders with a default may be left out"`
+	Confirm     bool              `json:"confirm" jsonschema:"description:Must be true to create the entities. When false the entities are listed without changing anything."`
+}
+
+// ApplyBlueprintOutput is the output for apply_blueprint tool.
+type ApplyBlueprintOutput struct {
+	Success   bool   `json:"success"`
+	DryRun    bool   `json:"dryRun,omitempty"`
+	Blueprint string `json:"blueprint"`
+	gtm.ChangesetResult
+	Planned  []gtm.ChangesetStep     `json:"planned,omitempty"`
+	Existing []gtm.BlueprintExisting `json:"existing,omitempty"`
+	Message  string                  `json:"message"`
+}
+
+func registerApplyBlueprint(r *toolRegistry) {
+	handler := func(ctx context.Context, req *mcp.CallToolRequest, input ApplyBlueprintInput) (*mcp.CallToolResult, ApplyBlueprintOutput, error) {
+		b, ok := r.blueprints[input.Blueprint]
+		if !ok {
+			names := make([]string, 0, len(r.blueprints))
+			for _, b := range gtm.SortedBlueprints(r.blueprints) {
+				names = append(names, b.Name)
+			}
+			return nil, ApplyBlueprintOutput{}, gtm.InvalidField("blueprint", "unknown blueprint %q; available: %s", input.Blueprint, strings.Join(names, ", "))
+		}
+
+		wc, err := resolveWorkspace(ctx, input.AccountID, input.ContainerID, input.WorkspaceID)
+		if err != nil {
+			return nil, ApplyBlueprintOutput{}, err
+		}
+		existing, err := wc.Client.ListWorkspaceEntities(ctx, wc.AccountID, wc.ContainerID, wc.WorkspaceID)
+		if err != nil {
+			return nil, ApplyBlueprintOutput{}, err
+		}
+		ops, reused, err := b.Changeset(input.Values, existing)
+		if err != nil {
+			return nil, ApplyBlueprintOutput{}, err
+		}
+
+		out := ApplyBlueprintOutput{
+			Blueprint:       b.Name,
+			ChangesetResult: gtm.ChangesetResult{Applied: []gtm.ChangesetStep{}},
+			Existing:        reused,
+		}
+		if len(ops) == 0 {
+			out.Success = true
+			out.Message = fmt.Sprintf("Every entity of blueprint %s is already in the workspace; nothing to create", b.Name)
+			return nil, out, nil
+		}
+
+		// Safety guard: list what would be created first
+		if !input.Confirm {
+			planned, err := wc.Client.PlanChangeset(ctx, wc.AccountID, wc.ContainerID, wc.WorkspaceID, ops)
+			if err != nil {
+				return nil, ApplyBlueprintOutput{}, err
+			}
+			out.DryRun = true
+			out.Planned = planned
+			out.Message = fmt.Sprintf("Dry run: blueprint %s would create %d entities and reuse %d existing ones. Call again with confirm: true to create them; if one fails, the ones before it are deleted again.", b.Name, len(planned), len(reused))
+			return nil, out, nil
+		}
+
+		result, err := wc.Client.ApplyChangeset(ctx, wc.AccountID, wc.ContainerID, wc.WorkspaceID, ops)
+		if result == nil {
+			return nil, ApplyBlueprintOutput{}, err
+		}
+		out.ChangesetResult = *result
+		if err == nil {
+			out.Success = true
+			out.Message = fmt.Sprintf("Applied blueprint %s: created %d entities", b.Name, len(result.Applied))
+			return nil, out, nil
+		}
+
+		out.Message = err.Error()
+		for _, step := range result.RolledBack {
+			if step.Error != "" {
+				out.Message += ". WARNING: some created entities could not be deleted again; see rolledBack for the entities to remove by hand"
+				break
+			}
+		}
+		return nil, out, nil
+	}
+
+	addTool(r, &mcp.Tool{
+		Name:        "apply_blueprint",
+		Description: "Provision a blueprint from list_blueprints in a workspace: fills in the placeholder values and creates its variables, triggers and tags as one change set, reusing entities that already exist under the same name. If any creation fails, the entities created so far are deleted again. Without confirm: true only lists what would be created.",
+	}, handler)
+}
@@ -1,9 +1,11 @@
-package gtm
+package tools
 
 import (
 	"context"
 	"fmt"
 
+	"github.com/paolobietolini/gtm-mcp-server/gtm"
+
 	"github.com/modelcontextprotocol/go-sdk/mcp"
 )
 
@@ -16,10 +18,10 @@ type ListBuiltInVariablesInput struct {
 }
 
 type ListBuiltInVariablesOutput struct {
-	BuiltInVariables []BuiltInVariable `json:"builtInVariables"`
+	BuiltInVariables []gtm.BuiltInVariable `json:"builtInVariables"`
 }
 
-func registerListBuiltInVariables(server *mcp.Server) {
+func registerListBuiltInVariables(r *toolRegistry) {
 	handler := func(ctx context.Context, req *mcp.CallToolRequest, input ListBuiltInVariablesInput) (*mcp.CallToolResult, ListBuiltInVariablesOutput, error) {
 		wc, err := resolveWorkspace(ctx, input.AccountID, input.ContainerID, input.WorkspaceID)
 		if err != nil {
@@ -34,7 +36,7 @@ func registerListBuiltInVariables(server *mcp.Server) {
 		return nil, ListBuiltInVariablesOutput{BuiltInVariables: vars}, nil
 	}
 
-	mcp.AddTool(server, &mcp.Tool{
+	addTool(r, &mcp.Tool{
 		Name:        "list_built_in_variables",
 		Description: "List all enabled built-in variables in a GTM workspace",
 	}, handler)
@@ -50,12 +52,12 @@ type EnableBuiltInVariablesInput struct {
 }
 
 type EnableBuiltInVariablesOutput struct {
-	Success          bool              `json:"success"`
-	BuiltInVariables []BuiltInVariable `json:"builtInVariables"`
-	Message          string            `json:"message"`
+	Success          bool                  `json:"success"`
+	BuiltInVariables []gtm.BuiltInVariable `json:"builtInVariables"`
+	Message          string                `json:"message"`
 }
 
-func registerEnableBuiltInVariables(server *mcp.Server) {
+func registerEnableBuiltInVariables(r *toolRegistry) {
 	handler := func(ctx context.Context, req *mcp.CallToolRequest, input EnableBuiltInVariablesInput) (*mcp.CallToolResult, EnableBuiltInVariablesOutput, error) {
 		wc, err := resolveWorkspace(ctx, input.AccountID, input.ContainerID, input.WorkspaceID)
 		if err != nil {
@@ -78,7 +80,7 @@ func registerEnableBuiltInVariables(server *mcp.Server) {
 		}, nil
 	}
 
-	mcp.AddTool(server, &mcp.Tool{
+	addTool(r, &mcp.Tool{
 		Name:        "enable_built_in_variables",
 		Description: "Enable one or more built-in variable types in a GTM workspace",
 	}, handler)
@@ -99,7 +101,7 @@ type DisableBuiltInVariablesOutput struct {
 	Message string `json:"message"`
 }
 
-func registerDisableBuiltInVariables(server *mcp.Server) {
+func registerDisableBuiltInVariables(r *toolRegistry) {
 	handler := func(ctx context.Context, req *mcp.CallToolRequest, input DisableBuiltInVariablesInput) (*mcp.CallToolResult, DisableBuiltInVariablesOutput, error) {
 		if !input.Confirm {
 			return nil, DisableBuiltInVariablesOutput{
@@ -127,7 +129,7 @@ func registerDisableBuiltInVariables(server *mcp.Server) {
 		}, nil
 	}
 
-	mcp.AddTool(server, &mcp.Tool{
+	addTool(r, &mcp.Tool{
 		Name:        "disable_built_in_variables",
 		Description: "Disable one or more built-in variable types. Requires confirm: true as a safety guard.",
 	}, handler)
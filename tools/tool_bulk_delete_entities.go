@@ -0,0 +1,87 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/paolobietolini/gtm-mcp-server/gtm"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// BulkDeleteEntitiesInput is the input for bulk_delete_entities tool.
+type BulkDeleteEntitiesInput struct {
+	AccountID     string   `json:"accountId" jsonschema:"description:The GTM account ID"`
+	ContainerID   string   `json:"containerId" jsonschema:"description:The GTM container ID"`
+	WorkspaceID   string   `json:"workspaceId" jsonschema:"description:The GTM workspace ID"`
+	EntityTypes   []string `json:"entityTypes,omitempty" jsonschema:"description:Entity kinds to delete: tag, trigger, variable (optional, defaults to all three)"`
+	NamePattern   string   `json:"namePattern,omitempty" jsonschema:"description:Regular expression matched against entity names (e.g. '^UA - ' or '(?i)universal analytics')"`
+	Types         []string `json:"types,omitempty" jsonschema:"description:GTM types to match (e.g. ua, gaawc, customEvent). At least one of namePattern or types is required."`
+	ExpectedCount int      `json:"expectedCount,omitempty" jsonschema:"description:Number of matches reported by the dry run. Required with confirm: true."`
+	Confirm       bool     `json:"confirm" jsonschema:"description:Must be true to delete. When false the matching entities are listed without deleting anything."`
+}
+
+// BulkDeleteEntitiesOutput is the output for bulk_delete_entities tool.
+type BulkDeleteEntitiesOutput struct {
+	Success  bool                 `json:"success"`
+	DryRun   bool                 `json:"dryRun,omitempty"`
+	Matched  int                  `json:"matched"`
+	Deleted  int                  `json:"deleted"`
+	Items    []gtm.BulkDeleteItem `json:"items"`
+	Warnings []string             `json:"warnings,omitempty"`
+	Message  string               `json:"message"`
+}
+
+func registerBulkDeleteEntities(r *toolRegistry) {
+	handler := func(ctx context.Context, req *mcp.CallToolRequest, input BulkDeleteEntitiesInput) (*mcp.CallToolResult, BulkDeleteEntitiesOutput, error) {
+		wc, err := resolveWorkspace(ctx, input.AccountID, input.ContainerID, input.WorkspaceID)
+		if err != nil {
+			return nil, BulkDeleteEntitiesOutput{}, err
+		}
+
+		plan, err := wc.Client.PlanBulkDelete(ctx, wc.AccountID, wc.ContainerID, wc.WorkspaceID, gtm.BulkDeleteFilter{
+			EntityTypes: input.EntityTypes,
+			NamePattern: input.NamePattern,
+			Types:       input.Types,
+		})
+		if err != nil {
+			return nil, BulkDeleteEntitiesOutput{}, err
+		}
+
+		out := BulkDeleteEntitiesOutput{
+			Matched:  len(plan.Items),
+			Items:    plan.Items,
+			Warnings: plan.Warnings,
+		}
+
+		// Safety guard: always list the matches first
+		if !input.Confirm {
+			out.DryRun = true
+			out.Message = fmt.Sprintf("Dry run: %d entities match. Review the list, then call again with confirm: true and expectedCount: %d to delete them.",
+				len(plan.Items), len(plan.Items))
+			return nil, out, nil
+		}
+
+		// The workspace may have changed since the dry run
+		if input.ExpectedCount != len(plan.Items) {
+			out.DryRun = true
+			out.Message = fmt.Sprintf("Nothing deleted: %d entities match now but expectedCount is %d. Review the current list and retry with the matching expectedCount.",
+				len(plan.Items), input.ExpectedCount)
+			return nil, out, nil
+		}
+
+		out.Deleted = wc.Client.ExecuteBulkDelete(ctx, plan)
+		out.Success = out.Deleted == len(plan.Items)
+		if out.Success {
+			out.Message = fmt.Sprintf("Deleted %d entities", out.Deleted)
+		} else {
+			out.Message = fmt.Sprintf("Deleted %d of %d entities; see items for errors", out.Deleted, len(plan.Items))
+		}
+		return nil, out, nil
+	}
+
+	addTool(r, &mcp.Tool{
+		Name:        "bulk_delete_entities",
+		Description: "Delete all tags, triggers and/or variables in a workspace whose name matches a regular expression and/or whose type is in a list (e.g. clean up legacy UA tags). Without confirm: true only lists the matches. Deleting requires confirm: true plus expectedCount equal to the dry-run match count. Tags are deleted before triggers and variables.",
+	}, handler)
+}
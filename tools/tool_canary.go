@@ -0,0 +1,163 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/paolobietolini/gtm-mcp-server/gtm"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// CanaryReleaseInput is the input for canary_release tool.
+type CanaryReleaseInput struct {
+	AccountID   string `json:"accountId" jsonschema:"description:The GTM account ID"`
+	ContainerID string `json:"containerId" jsonschema:"description:The GTM container ID"`
+	VersionID   string `json:"versionId" jsonschema:"description:The container version to release to the canary environment"`
+	Environment string `json:"environment,omitempty" jsonschema:"description:Name of the canary environment (optional, defaults to canary; created if missing)"`
+	Percentage  int    `json:"percentage,omitempty" jsonschema:"description:Share of visitors (1-100) that should load the canary, used in the generated snippet (optional, defaults to 5)"`
+	Confirm     bool   `json:"confirm" jsonschema:"description:Must be true to deploy the version to the canary environment"`
+}
+
+// CanaryReleaseOutput is the output for canary_release tool.
+type CanaryReleaseOutput struct {
+	Success      bool                   `json:"success"`
+	Canary       *gtm.CanaryEnvironment `json:"canary,omitempty"`
+	Instructions string                 `json:"instructions,omitempty"`
+	Message      string                 `json:"message"`
+}
+
+func registerCanaryRelease(r *toolRegistry) {
+	handler := func(ctx context.Context, req *mcp.CallToolRequest, input CanaryReleaseInput) (*mcp.CallToolResult, CanaryReleaseOutput, error) {
+		if input.VersionID == "" {
+			return nil, CanaryReleaseOutput{}, fmt.Errorf("versionId is required")
+		}
+		percentage := input.Percentage
+		if percentage == 0 {
+			percentage = 5
+		}
+		if percentage < 1 || percentage > 100 {
+			return nil, CanaryReleaseOutput{}, fmt.Errorf("percentage must be between 1 and 100")
+		}
+		envName := input.Environment
+		if envName == "" {
+			envName = gtm.DefaultCanaryEnvironment
+		}
+
+		// Safety guard: require explicit confirmation
+		if !input.Confirm {
+			return nil, CanaryReleaseOutput{
+				Success: false,
+				Message: fmt.Sprintf("Canary release requires confirm: true. Version %s will be served by the %q environment to every visitor loading it.", input.VersionID, envName),
+			}, nil
+		}
+
+		cc, err := resolveContainer(ctx, input.AccountID, input.ContainerID)
+		if err != nil {
+			return nil, CanaryReleaseOutput{}, err
+		}
+
+		container, err := cc.Client.GetContainer(ctx, cc.ContainerPath())
+		if err != nil {
+			return nil, CanaryReleaseOutput{}, err
+		}
+
+		env, created, err := cc.Client.DeployToEnvironment(ctx, cc.AccountID, cc.ContainerID, envName, input.VersionID)
+		if err != nil {
+			return nil, CanaryReleaseOutput{}, err
+		}
+		canary := gtm.ToCanaryEnvironment(env, created, container, percentage)
+
+		instructions := fmt.Sprintf("Version %s is deployed to environment %q. To preview it yourself, load gtm.js with %s.", input.VersionID, envName, canary.QueryParams)
+		if canary.Snippet != "" {
+			instructions += fmt.Sprintf(" To roll it out to %d%% of visitors, replace the GTM <head> snippet on the site with the returned snippet; visitors are bucketed once and stay in their bucket. Monitor the canary (the dataLayer carries gtm_canary: true), then call promote_canary to publish the same version live.", percentage)
+		} else {
+			instructions += " Point the canary share of your traffic at this environment, then call promote_canary to publish the same version live."
+		}
+
+		return nil, CanaryReleaseOutput{
+			Success:      true,
+			Canary:       canary,
+			Instructions: instructions,
+			Message:      fmt.Sprintf("Version %s released to canary environment %q", input.VersionID, envName),
+		}, nil
+	}
+
+	addTool(r, &mcp.Tool{
+		Name:        "canary_release",
+		Description: "Deploy a container version to a dedicated canary environment (created if missing) for progressive rollout. Returns the environment parameters and, for web containers, a replacement GTM snippet that loads the canary for a sticky percentage of visitors. Follow up with promote_canary. Requires confirm: true.",
+	}, handler)
+}
+
+// PromoteCanaryInput is the input for promote_canary tool.
+type PromoteCanaryInput struct {
+	AccountID   string `json:"accountId" jsonschema:"description:The GTM account ID"`
+	ContainerID string `json:"containerId" jsonschema:"description:The GTM container ID"`
+	Environment string `json:"environment,omitempty" jsonschema:"description:Name of the canary environment (optional, defaults to canary)"`
+	Confirm     bool   `json:"confirm" jsonschema:"description:Must be true to publish the canary version live"`
+}
+
+// PromoteCanaryOutput is the output for promote_canary tool.
+type PromoteCanaryOutput struct {
+	Success         bool                  `json:"success"`
+	CanaryVersionID string                `json:"canaryVersionId,omitempty"`
+	PreviousLive    string                `json:"previousLiveVersionId,omitempty"`
+	Version         *gtm.PublishedVersion `json:"version,omitempty"`
+	Message         string                `json:"message"`
+}
+
+func registerPromoteCanary(r *toolRegistry) {
+	handler := func(ctx context.Context, req *mcp.CallToolRequest, input PromoteCanaryInput) (*mcp.CallToolResult, PromoteCanaryOutput, error) {
+		envName := input.Environment
+		if envName == "" {
+			envName = gtm.DefaultCanaryEnvironment
+		}
+
+		cc, err := resolveContainer(ctx, input.AccountID, input.ContainerID)
+		if err != nil {
+			return nil, PromoteCanaryOutput{}, err
+		}
+
+		env, err := cc.Client.FindEnvironment(ctx, cc.AccountID, cc.ContainerID, envName)
+		if err != nil {
+			return nil, PromoteCanaryOutput{}, err
+		}
+		if env == nil || env.ContainerVersionId == "" {
+			return nil, PromoteCanaryOutput{}, fmt.Errorf("%w: no canary release in environment %q; use canary_release first", gtm.ErrNotFound, envName)
+		}
+
+		live, err := cc.Client.GetLiveVersion(ctx, cc.AccountID, cc.ContainerID)
+		if err != nil {
+			return nil, PromoteCanaryOutput{}, err
+		}
+		out := PromoteCanaryOutput{CanaryVersionID: env.ContainerVersionId}
+		if live != nil {
+			out.PreviousLive = live.VersionID
+			if live.VersionID == env.ContainerVersionId {
+				out.Success = true
+				out.Message = fmt.Sprintf("Canary version %s is already live", env.ContainerVersionId)
+				return nil, out, nil
+			}
+		}
+
+		// Safety guard: require explicit confirmation
+		if !input.Confirm {
+			out.Message = fmt.Sprintf("Promoting requires confirm: true. WARNING: This will publish canary version %s to all visitors (currently live: %s).", env.ContainerVersionId, out.PreviousLive)
+			return nil, out, nil
+		}
+
+		version, err := cc.Client.PublishVersion(ctx, cc.AccountID, cc.ContainerID, env.ContainerVersionId)
+		if err != nil {
+			return nil, PromoteCanaryOutput{}, err
+		}
+		out.Success = true
+		out.Version = version
+		out.Message = fmt.Sprintf("Canary version %s is now LIVE. The canary snippet can be replaced with the standard GTM snippet.", version.VersionID)
+		return nil, out, nil
+	}
+
+	addTool(r, &mcp.Tool{
+		Name:        "promote_canary",
+		Description: "Publish the version currently deployed to the canary environment (see canary_release) as the live version. Requires confirm: true. WARNING: This pushes changes to your live website.",
+	}, handler)
+}
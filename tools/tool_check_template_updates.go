@@ -0,0 +1,67 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/paolobietolini/gtm-mcp-server/gtm"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// CheckTemplateUpdatesInput is the input for check_template_updates tool.
+type CheckTemplateUpdatesInput struct {
+	AccountID   string   `json:"accountId" jsonschema:"description:The GTM account ID"`
+	ContainerID string   `json:"containerId" jsonschema:"description:The GTM container ID"`
+	WorkspaceID string   `json:"workspaceId" jsonschema:"description:The GTM workspace ID"`
+	TemplateIDs []string `json:"templateIds,omitempty" jsonschema:"description:Only check these template IDs (optional, defaults to every gallery template)"`
+	Update      bool     `json:"update,omitempty" jsonschema:"description:Set to true to import the latest version of every outdated template. Without it the tool only reports"`
+}
+
+// CheckTemplateUpdatesOutput is the output for check_template_updates tool.
+type CheckTemplateUpdatesOutput struct {
+	Templates []gtm.TemplateUpdate `json:"templates"`
+	Outdated  int                  `json:"outdated"`
+	Updated   int                  `json:"updated"`
+	Message   string               `json:"message"`
+}
+
+func registerCheckTemplateUpdates(r *toolRegistry) {
+	handler := func(ctx context.Context, req *mcp.CallToolRequest, input CheckTemplateUpdatesInput) (*mcp.CallToolResult, CheckTemplateUpdatesOutput, error) {
+		wc, err := resolveWorkspace(ctx, input.AccountID, input.ContainerID, input.WorkspaceID)
+		if err != nil {
+			return nil, CheckTemplateUpdatesOutput{}, err
+		}
+
+		updates, err := wc.Client.CheckTemplateUpdates(ctx, wc.AccountID, wc.ContainerID, wc.WorkspaceID, r.gallery, input.TemplateIDs, input.Update)
+		if err != nil {
+			return nil, CheckTemplateUpdatesOutput{}, err
+		}
+
+		out := CheckTemplateUpdatesOutput{Templates: updates}
+		for _, u := range updates {
+			if u.Outdated {
+				out.Outdated++
+			}
+			if u.Updated {
+				out.Updated++
+			}
+		}
+		switch {
+		case len(updates) == 0:
+			out.Message = "No gallery templates in this workspace."
+		case input.Update:
+			out.Message = fmt.Sprintf("Updated %d of %d outdated gallery templates. Test the tags using them in preview before publishing.", out.Updated, out.Outdated)
+		case out.Outdated > 0:
+			out.Message = fmt.Sprintf("%d of %d gallery templates are outdated. Review their change notes and new permissions, then call again with update: true to import the latest versions.", out.Outdated, len(updates))
+		default:
+			out.Message = fmt.Sprintf("All %d gallery templates are up to date.", len(updates))
+		}
+		return nil, out, nil
+	}
+
+	addTool(r, &mcp.Tool{
+		Name:        "check_template_updates",
+		Description: "Compare each Community Gallery template in a workspace with the latest version released in its gallery repository and report the outdated ones, with change notes and any permissions the new version adds. With update: true, imports the latest version of every outdated template (templates edited in the workspace are skipped).",
+	}, handler)
+}
@@ -1,24 +1,27 @@
-package gtm
+package tools
 
 import (
 	"context"
 
+	"github.com/paolobietolini/gtm-mcp-server/gtm"
+
 	"github.com/modelcontextprotocol/go-sdk/mcp"
 )
 
 // -- List Clients --
 
 type ListClientsInput struct {
-	AccountID   string `json:"accountId" jsonschema:"description:The GTM account ID"`
-	ContainerID string `json:"containerId" jsonschema:"description:The GTM container ID"`
-	WorkspaceID string `json:"workspaceId" jsonschema:"description:The GTM workspace ID"`
+	AccountID        string `json:"accountId" jsonschema:"description:The GTM account ID"`
+	ContainerID      string `json:"containerId" jsonschema:"description:The GTM container ID"`
+	WorkspaceID      string `json:"workspaceId" jsonschema:"description:The GTM workspace ID"`
+	IncludeSensitive bool   `json:"includeSensitive,omitempty" jsonschema:"description:Return parameter values that look like secrets (API keys, tokens, passwords) instead of redacting them"`
 }
 
 type ListClientsOutput struct {
-	Clients []ClientInfo `json:"clients"`
+	Clients []gtm.ClientInfo `json:"clients"`
 }
 
-func registerListClients(server *mcp.Server) {
+func registerListClients(r *toolRegistry) {
 	handler := func(ctx context.Context, req *mcp.CallToolRequest, input ListClientsInput) (*mcp.CallToolResult, ListClientsOutput, error) {
 		wc, err := resolveWorkspace(ctx, input.AccountID, input.ContainerID, input.WorkspaceID)
 		if err != nil {
@@ -30,10 +33,14 @@ func registerListClients(server *mcp.Server) {
 			return nil, ListClientsOutput{}, err
 		}
 
+		if !input.IncludeSensitive {
+			gtm.RedactClients(clients)
+		}
+
 		return nil, ListClientsOutput{Clients: clients}, nil
 	}
 
-	mcp.AddTool(server, &mcp.Tool{
+	addTool(r, &mcp.Tool{
 		Name:        "list_clients",
 		Description: "List all clients in a GTM workspace (server-side containers only)",
 	}, handler)
@@ -42,17 +49,18 @@ func registerListClients(server *mcp.Server) {
 // -- Get Client --
 
 type GetClientInput struct {
-	AccountID   string `json:"accountId" jsonschema:"description:The GTM account ID"`
-	ContainerID string `json:"containerId" jsonschema:"description:The GTM container ID"`
-	WorkspaceID string `json:"workspaceId" jsonschema:"description:The GTM workspace ID"`
-	ClientID    string `json:"clientId" jsonschema:"description:The client ID to retrieve"`
+	AccountID        string `json:"accountId" jsonschema:"description:The GTM account ID"`
+	ContainerID      string `json:"containerId" jsonschema:"description:The GTM container ID"`
+	WorkspaceID      string `json:"workspaceId" jsonschema:"description:The GTM workspace ID"`
+	ClientID         string `json:"clientId" jsonschema:"description:The client ID to retrieve"`
+	IncludeSensitive bool   `json:"includeSensitive,omitempty" jsonschema:"description:Return parameter values that look like secrets (API keys, tokens, passwords) instead of redacting them"`
 }
 
 type GetClientOutput struct {
-	Client ClientInfo `json:"client"`
+	Client gtm.ClientInfo `json:"client"`
 }
 
-func registerGetClient(server *mcp.Server) {
+func registerGetClient(r *toolRegistry) {
 	handler := func(ctx context.Context, req *mcp.CallToolRequest, input GetClientInput) (*mcp.CallToolResult, GetClientOutput, error) {
 		wc, err := resolveWorkspace(ctx, input.AccountID, input.ContainerID, input.WorkspaceID)
 		if err != nil {
@@ -64,10 +72,14 @@ func registerGetClient(server *mcp.Server) {
 			return nil, GetClientOutput{}, err
 		}
 
+		if !input.IncludeSensitive {
+			gtm.RedactAPIParameters(cl.Parameter)
+		}
+
 		return nil, GetClientOutput{Client: *cl}, nil
 	}
 
-	mcp.AddTool(server, &mcp.Tool{
+	addTool(r, &mcp.Tool{
 		Name:        "get_client",
 		Description: "Get a specific client by ID (server-side containers only)",
 	}, handler)
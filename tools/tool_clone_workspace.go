@@ -0,0 +1,91 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/paolobietolini/gtm-mcp-server/gtm"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// CloneWorkspaceInput is the input for clone_workspace tool.
+type CloneWorkspaceInput struct {
+	AccountID         string `json:"accountId" jsonschema:"description:The GTM account ID"`
+	ContainerID       string `json:"containerId" jsonschema:"description:The GTM container ID"`
+	SourceWorkspaceID string `json:"sourceWorkspaceId,omitempty" jsonschema:"description:Workspace to clone (including unpublished changes)"`
+	SourceVersionID   string `json:"sourceVersionId,omitempty" jsonschema:"description:Version to clone instead of a workspace. Use 'live' for the published version."`
+	Name              string `json:"name" jsonschema:"description:Name of the new workspace"`
+	Description       string `json:"description,omitempty" jsonschema:"description:Description of the new workspace (optional)"`
+}
+
+// CloneWorkspaceOutput is the output for clone_workspace tool.
+type CloneWorkspaceOutput struct {
+	Success   bool             `json:"success"`
+	Workspace CreatedWorkspace `json:"workspace"`
+	Result    gtm.ImportResult `json:"result"`
+	Message   string           `json:"message"`
+}
+
+func registerCloneWorkspace(r *toolRegistry) {
+	handler := func(ctx context.Context, req *mcp.CallToolRequest, input CloneWorkspaceInput) (*mcp.CallToolResult, CloneWorkspaceOutput, error) {
+		if (input.SourceWorkspaceID == "") == (input.SourceVersionID == "") {
+			return nil, CloneWorkspaceOutput{}, fmt.Errorf("exactly one of sourceWorkspaceId or sourceVersionId is required")
+		}
+		if input.Name == "" {
+			return nil, CloneWorkspaceOutput{}, fmt.Errorf("name is required")
+		}
+
+		cc, err := resolveContainer(ctx, input.AccountID, input.ContainerID)
+		if err != nil {
+			return nil, CloneWorkspaceOutput{}, err
+		}
+
+		// Read the source before creating anything so a bad ID leaves no empty workspace behind
+		var export *gtm.ContainerExport
+		var source string
+		if input.SourceVersionID != "" {
+			export, err = cc.Client.ExportVersion(ctx, cc.AccountID, cc.ContainerID, input.SourceVersionID)
+			source = "version " + input.SourceVersionID
+		} else {
+			export, err = cc.Client.ExportWorkspace(ctx, cc.AccountID, cc.ContainerID, input.SourceWorkspaceID)
+			source = "workspace " + input.SourceWorkspaceID
+		}
+		if err != nil {
+			return nil, CloneWorkspaceOutput{}, err
+		}
+
+		created, err := cc.Client.CreateWorkspace(ctx, cc.AccountID, cc.ContainerID, input.Name, input.Description)
+		if err != nil {
+			return nil, CloneWorkspaceOutput{}, err
+		}
+		workspace := CreatedWorkspace{
+			WorkspaceID:   created.WorkspaceId,
+			Name:          created.Name,
+			Description:   created.Description,
+			Path:          created.Path,
+			TagManagerUrl: created.TagManagerUrl,
+		}
+
+		// Overwrite makes the new workspace an exact copy of the source
+		result, err := cc.Client.ImportContainer(ctx, cc.AccountID, cc.ContainerID, created.WorkspaceId, export, gtm.ImportOptions{
+			Mode:  gtm.ImportModeOverwrite,
+			Apply: true,
+		})
+		if err != nil {
+			return nil, CloneWorkspaceOutput{Workspace: workspace}, fmt.Errorf("workspace %s created but cloning %s failed: %w", created.WorkspaceId, source, err)
+		}
+
+		return nil, CloneWorkspaceOutput{
+			Success:   true,
+			Workspace: workspace,
+			Result:    *result,
+			Message:   fmt.Sprintf("Workspace %s cloned from %s", created.WorkspaceId, source),
+		}, nil
+	}
+
+	addTool(r, &mcp.Tool{
+		Name:        "clone_workspace",
+		Description: "Create a new workspace that is an exact copy of a source workspace or container version (use 'live' for the published version), so experiments can branch off a known-good state.",
+	}, handler)
+}
@@ -0,0 +1,106 @@
+package tools
+
+import (
+	"io"
+	"net/http"
+	"slices"
+	"strings"
+	"testing"
+)
+
+// cloneHandler serves version 5 with a single tag and answers creating
+// workspace 7, which starts out with a stale tag of its own. Version 9 and
+// workspace 8 do not exist.
+func cloneHandler(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	path := strings.TrimPrefix(r.URL.Path, "/tagmanager/v2/accounts/1/containers/2")
+	switch {
+	case r.Method == http.MethodGet && path == "":
+		io.WriteString(w, `{"path":"accounts/1/containers/2","accountId":"1","containerId":"2","name":"Web","publicId":"GTM-AAA","usageContext":["web"]}`)
+	case r.Method == http.MethodGet && path == "/versions/5":
+		io.WriteString(w, `{"path":"accounts/1/containers/2/versions/5","accountId":"1","containerId":"2","containerVersionId":"5",`+
+			`"container":{"accountId":"1","containerId":"2","usageContext":["web"]},`+
+			`"tag":[{"accountId":"1","containerId":"2","tagId":"10","name":"Live Tag","type":"html","path":"accounts/1/containers/2/versions/5/tags/10"}]}`)
+	case r.Method == http.MethodPost && path == "/workspaces":
+		io.WriteString(w, `{"path":"accounts/1/containers/2/workspaces/7","accountId":"1","containerId":"2","workspaceId":"7","name":"Experiment"}`)
+	case r.Method == http.MethodGet && path == "/workspaces/7/tags":
+		io.WriteString(w, `{"tag":[{"accountId":"1","containerId":"2","workspaceId":"7","tagId":"20","name":"Stale","type":"html","path":"accounts/1/containers/2/workspaces/7/tags/20"}]}`)
+	case r.Method == http.MethodGet && strings.HasPrefix(path, "/workspaces/7/"):
+		io.WriteString(w, `{}`)
+	case r.Method == http.MethodPost && path == "/workspaces/7/tags":
+		io.WriteString(w, `{"accountId":"1","containerId":"2","workspaceId":"7","tagId":"21","name":"Live Tag","type":"html","path":"accounts/1/containers/2/workspaces/7/tags/21"}`)
+	case r.Method == http.MethodDelete && path == "/workspaces/7/tags/20":
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		w.WriteHeader(http.StatusNotFound)
+		io.WriteString(w, `{"error":{"code":404,"message":"not found"}}`)
+	}
+}
+
+func TestCloneWorkspace_RequiresExactlyOneSource(t *testing.T) {
+	s := newToolSession(t, ToolOptions{}, cloneHandler)
+
+	for _, args := range []map[string]any{
+		{"accountId": "1", "containerId": "2", "name": "Experiment"},
+		{"accountId": "1", "containerId": "2", "name": "Experiment", "sourceVersionId": "5", "sourceWorkspaceId": "3"},
+	} {
+		if toolErr := s.call("clone_workspace", args, nil); toolErr == nil || !strings.Contains(toolErr.Message, "exactly one of sourceWorkspaceId or sourceVersionId") {
+			t.Errorf("clone_workspace %v returned %+v", args, toolErr)
+		}
+	}
+	if reqs := s.api.Requests(); len(reqs) != 0 {
+		t.Errorf("invalid clones issued %v", reqs)
+	}
+}
+
+func TestCloneWorkspace_BadSourceCreatesNothing(t *testing.T) {
+	s := newToolSession(t, ToolOptions{}, cloneHandler)
+
+	for _, args := range []map[string]any{
+		{"accountId": "1", "containerId": "2", "name": "Experiment", "sourceVersionId": "9"},
+		{"accountId": "1", "containerId": "2", "name": "Experiment", "sourceWorkspaceId": "8"},
+	} {
+		if toolErr := s.call("clone_workspace", args, nil); toolErr == nil {
+			t.Errorf("clone_workspace %v succeeded", args)
+		}
+	}
+	if m := s.api.Mutations(); len(m) != 0 {
+		t.Errorf("clones of a missing source issued %v", m)
+	}
+}
+
+func TestCloneWorkspace_OverwritesNewWorkspaceWithSource(t *testing.T) {
+	s := newToolSession(t, ToolOptions{}, cloneHandler)
+
+	var out CloneWorkspaceOutput
+	if toolErr := s.call("clone_workspace", map[string]any{"accountId": "1", "containerId": "2", "name": "Experiment", "sourceVersionId": "5"}, &out); toolErr != nil {
+		t.Fatalf("clone_workspace: %+v", toolErr)
+	}
+	if !out.Success || out.Workspace.WorkspaceID != "7" {
+		t.Errorf("clone = %+v", out)
+	}
+
+	reqs := s.api.Requests()
+	source := slices.Index(reqs, "GET accounts/1/containers/2/versions/5?alt=json&prettyPrint=false")
+	create := slices.Index(reqs, "POST accounts/1/containers/2/workspaces?alt=json&prettyPrint=false")
+	if source < 0 || create < 0 || source > create {
+		t.Fatalf("requests %v: want the source version read before the workspace is created", reqs)
+	}
+
+	// Overwrite mode replaces the new workspace's own stale tag with the
+	// source's and touches no other workspace
+	want := []string{
+		"POST accounts/1/containers/2/workspaces?alt=json&prettyPrint=false",
+		"POST accounts/1/containers/2/workspaces/7/tags?alt=json&prettyPrint=false",
+		"DELETE accounts/1/containers/2/workspaces/7/tags/20?alt=json&prettyPrint=false",
+	}
+	if m := s.api.Mutations(); !slices.Equal(sorted(m), sorted(want)) {
+		t.Errorf("clone issued %v, want %v", m, want)
+	}
+}
+
+func sorted(s []string) []string {
+	s = slices.Clone(s)
+	slices.Sort(s)
+	return s
+}
@@ -0,0 +1,95 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/paolobietolini/gtm-mcp-server/gtm"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// CombineContainersInput is the input for combine_containers tool.
+type CombineContainersInput struct {
+	AccountID                        string `json:"accountId" jsonschema:"description:The GTM account ID"`
+	ContainerID                      string `json:"containerId" jsonschema:"description:The GTM container ID that remains after combining"`
+	OtherContainerID                 string `json:"otherContainerId" jsonschema:"description:The GTM container ID merged into containerId"`
+	SettingSource                    string `json:"settingSource,omitempty" jsonschema:"description:Whose config settings to keep: current (default) or other"`
+	AllowUserPermissionFeatureUpdate bool   `json:"allowUserPermissionFeatureUpdate,omitempty" jsonschema:"description:Allow the user permissions feature to be enabled by this operation"`
+	Confirm                          bool   `json:"confirm" jsonschema:"description:Must be true to combine the containers. When false a dry-run preview is returned."`
+}
+
+// CombineContainersOutput is the output for combine_containers tool.
+type CombineContainersOutput struct {
+	Success   bool           `json:"success"`
+	DryRun    bool           `json:"dryRun,omitempty"`
+	Current   *gtm.Container `json:"current,omitempty"`
+	Other     *gtm.Container `json:"other,omitempty"`
+	Container *gtm.Container `json:"container,omitempty"`
+	Message   string         `json:"message"`
+}
+
+func registerCombineContainers(r *toolRegistry) {
+	handler := func(ctx context.Context, req *mcp.CallToolRequest, input CombineContainersInput) (*mcp.CallToolResult, CombineContainersOutput, error) {
+		if input.OtherContainerID == "" {
+			return nil, CombineContainersOutput{}, fmt.Errorf("otherContainerId is required")
+		}
+		if input.OtherContainerID == input.ContainerID {
+			return nil, CombineContainersOutput{}, fmt.Errorf("otherContainerId must differ from containerId")
+		}
+		switch input.SettingSource {
+		case "", "current", "other":
+		default:
+			return nil, CombineContainersOutput{}, fmt.Errorf("invalid settingSource '%s' (valid values: current, other)", input.SettingSource)
+		}
+
+		cc, err := resolveContainer(ctx, input.AccountID, input.ContainerID)
+		if err != nil {
+			return nil, CombineContainersOutput{}, err
+		}
+
+		current, err := cc.Client.GetContainer(ctx, cc.ContainerPath())
+		if err != nil {
+			return nil, CombineContainersOutput{}, err
+		}
+		other, err := cc.Client.GetContainer(ctx, gtm.BuildContainerPath(input.AccountID, input.OtherContainerID))
+		if err != nil {
+			return nil, CombineContainersOutput{}, err
+		}
+
+		settingSource := input.SettingSource
+		if settingSource == "" {
+			settingSource = "current"
+		}
+
+		// Safety guard: preview the combine until explicitly confirmed
+		if !input.Confirm {
+			return nil, CombineContainersOutput{
+				Success: false,
+				DryRun:  true,
+				Current: current,
+				Other:   other,
+				Message: fmt.Sprintf("Dry run: container %s (%s, tag IDs %v) will be merged into %s (%s, tag IDs %v), keeping the %s container's settings. Set confirm: true to proceed.",
+					other.Name, other.PublicID, other.TagIDs, current.Name, current.PublicID, current.TagIDs, settingSource),
+			}, nil
+		}
+
+		combined, err := cc.Client.CombineContainers(ctx, cc.ContainerPath(), input.OtherContainerID, settingSource, input.AllowUserPermissionFeatureUpdate)
+		if err != nil {
+			return nil, CombineContainersOutput{}, err
+		}
+
+		return nil, CombineContainersOutput{
+			Success:   true,
+			Current:   current,
+			Other:     other,
+			Container: combined,
+			Message:   fmt.Sprintf("Container %s merged into %s", other.PublicID, current.PublicID),
+		}, nil
+	}
+
+	addTool(r, &mcp.Tool{
+		Name:        "combine_containers",
+		Description: "Combine two GTM containers by merging otherContainerId into containerId. Without confirm: true returns a dry-run preview of both containers and their tag IDs.",
+	}, handler)
+}
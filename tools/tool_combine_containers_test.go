@@ -0,0 +1,78 @@
+package tools
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestCombineContainers_DryRunWithoutConfirm(t *testing.T) {
+	s := newToolSession(t, ToolOptions{}, containerHandler)
+
+	var out CombineContainersOutput
+	if toolErr := s.call("combine_containers", map[string]any{"accountId": "1", "containerId": "2", "otherContainerId": "3", "confirm": false}, &out); toolErr != nil {
+		t.Fatalf("combine_containers: %+v", toolErr)
+	}
+	if out.Success || !out.DryRun || out.Current == nil || out.Current.PublicID != "GTM-AAA" || out.Other == nil || out.Other.PublicID != "GTM-BBB" {
+		t.Errorf("dry run = %+v", out)
+	}
+	if !strings.Contains(out.Message, "keeping the current container's settings") {
+		t.Errorf("dry run message %q does not name the setting source", out.Message)
+	}
+	if m := s.api.Mutations(); len(m) != 0 {
+		t.Errorf("dry run issued %v", m)
+	}
+}
+
+func TestCombineContainers_Validation(t *testing.T) {
+	s := newToolSession(t, ToolOptions{}, containerHandler)
+
+	for _, tc := range []struct {
+		name string
+		args map[string]any
+		want string
+	}{
+		{"missing other", map[string]any{"accountId": "1", "containerId": "2", "otherContainerId": "", "confirm": true}, "otherContainerId is required"},
+		{"same container", map[string]any{"accountId": "1", "containerId": "2", "otherContainerId": "2", "confirm": true}, "must differ"},
+		{"bad setting source", map[string]any{"accountId": "1", "containerId": "2", "otherContainerId": "3", "settingSource": "both", "confirm": true}, "invalid settingSource"},
+	} {
+		if toolErr := s.call("combine_containers", tc.args, nil); toolErr == nil || !strings.Contains(toolErr.Message, tc.want) {
+			t.Errorf("%s: combine_containers returned %+v, want an error containing %q", tc.name, toolErr, tc.want)
+		}
+	}
+
+	if toolErr := s.call("combine_containers", map[string]any{"accountId": "1", "containerId": "2", "otherContainerId": "4", "confirm": true}, nil); toolErr == nil {
+		t.Error("combine_containers with an unknown other container succeeded")
+	}
+
+	if m := s.api.Mutations(); len(m) != 0 {
+		t.Errorf("invalid combines issued %v", m)
+	}
+}
+
+func TestCombineContainers_Confirmed(t *testing.T) {
+	s := newToolSession(t, ToolOptions{}, containerHandler)
+
+	var out CombineContainersOutput
+	if toolErr := s.call("combine_containers", map[string]any{
+		"accountId":        "1",
+		"containerId":      "2",
+		"otherContainerId": "3",
+		"settingSource":    "other",
+		"confirm":          true,
+	}, &out); toolErr != nil {
+		t.Fatalf("combine_containers: %+v", toolErr)
+	}
+	if !out.Success || out.Container == nil || len(out.Container.TagIDs) != 3 {
+		t.Errorf("combine = %+v", out)
+	}
+
+	m := s.api.Mutations()
+	if len(m) != 1 || !strings.HasPrefix(m[0], "POST accounts/1/containers/2:combine?") {
+		t.Fatalf("combine issued %v, want one combine call", m)
+	}
+	for _, param := range []string{"containerId=3", "settingSource=other", "allowUserPermissionFeatureUpdate=false"} {
+		if !strings.Contains(m[0], param) {
+			t.Errorf("combine call %q is missing %s", m[0], param)
+		}
+	}
+}
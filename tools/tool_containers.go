@@ -1,8 +1,10 @@
-package gtm
+package tools
 
 import (
 	"context"
 
+	"github.com/paolobietolini/gtm-mcp-server/gtm"
+
 	"github.com/modelcontextprotocol/go-sdk/mcp"
 )
 
@@ -10,10 +12,10 @@ type ListContainersInput struct {
 	AccountID string `json:"accountId" jsonschema:"description:The GTM account ID"`
 }
 type ListContainersOutput struct {
-	Containers []Container `json:"containers"`
+	Containers []gtm.Container `json:"containers"`
 }
 
-func registerListContainers(server *mcp.Server) {
+func registerListContainers(r *toolRegistry) {
 	handler := func(ctx context.Context, req *mcp.CallToolRequest, input ListContainersInput) (*mcp.CallToolResult, ListContainersOutput, error) {
 		client, err := resolveAccount(ctx, input.AccountID)
 		if err != nil {
@@ -28,7 +30,7 @@ func registerListContainers(server *mcp.Server) {
 		return nil, ListContainersOutput{Containers: containers}, nil
 	}
 
-	mcp.AddTool(server, &mcp.Tool{
+	addTool(r, &mcp.Tool{
 		Name:        "list_containers",
 		Description: "List all containers in a GTM account",
 	}, handler)
@@ -0,0 +1,110 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/paolobietolini/gtm-mcp-server/gtm"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// CopyEntitiesInput is the input for copy_entities tool.
+type CopyEntitiesInput struct {
+	AccountID         string   `json:"accountId" jsonschema:"description:The source GTM account ID"`
+	ContainerID       string   `json:"containerId" jsonschema:"description:The source GTM container ID"`
+	WorkspaceID       string   `json:"workspaceId" jsonschema:"description:The source GTM workspace ID"`
+	TargetAccountID   string   `json:"targetAccountId,omitempty" jsonschema:"description:The target GTM account ID (optional, defaults to the source account)"`
+	TargetContainerID string   `json:"targetContainerId" jsonschema:"description:The target GTM container ID (may be the source container)"`
+	TargetWorkspaceID string   `json:"targetWorkspaceId" jsonschema:"description:The target GTM workspace ID"`
+	Tags              []string `json:"tags,omitempty" jsonschema:"description:Tags to copy, by ID or name"`
+	Triggers          []string `json:"triggers,omitempty" jsonschema:"description:Triggers to copy, by ID or name"`
+	Variables         []string `json:"variables,omitempty" jsonschema:"description:Variables to copy, by ID or name"`
+	OnConflict        string   `json:"onConflict,omitempty" jsonschema:"description:What to do when a selected entity's name already exists in the target: rename (default) or overwrite"`
+	Confirm           bool     `json:"confirm" jsonschema:"description:Must be true to write changes. When false the planned changes are returned without modifying the target."`
+}
+
+// CopyEntitiesOutput is the output for copy_entities tool.
+type CopyEntitiesOutput struct {
+	Success bool             `json:"success"`
+	DryRun  bool             `json:"dryRun,omitempty"`
+	Result  gtm.ImportResult `json:"result"`
+	Message string           `json:"message"`
+}
+
+func registerCopyEntities(r *toolRegistry) {
+	handler := func(ctx context.Context, req *mcp.CallToolRequest, input CopyEntitiesInput) (*mcp.CallToolResult, CopyEntitiesOutput, error) {
+		sel := gtm.EntitySelection{Tags: input.Tags, Triggers: input.Triggers, Variables: input.Variables}
+		if sel.Empty() {
+			return nil, CopyEntitiesOutput{}, fmt.Errorf("at least one of tags, triggers or variables is required")
+		}
+
+		var mode string
+		switch input.OnConflict {
+		case "", "rename":
+			mode = gtm.ImportModeMergeRename
+		case "overwrite":
+			mode = gtm.ImportModeMergeOverwrite
+		default:
+			return nil, CopyEntitiesOutput{}, fmt.Errorf("invalid onConflict '%s' (valid values: rename, overwrite)", input.OnConflict)
+		}
+
+		targetAccountID := input.TargetAccountID
+		if targetAccountID == "" {
+			targetAccountID = input.AccountID
+		}
+		if err := gtm.ValidateWorkspacePath(targetAccountID, input.TargetContainerID, input.TargetWorkspaceID); err != nil {
+			return nil, CopyEntitiesOutput{}, fmt.Errorf("target: %w", err)
+		}
+		if targetAccountID == input.AccountID && input.TargetContainerID == input.ContainerID && input.TargetWorkspaceID == input.WorkspaceID {
+			return nil, CopyEntitiesOutput{}, fmt.Errorf("source and target workspace must differ")
+		}
+
+		wc, err := resolveWorkspace(ctx, input.AccountID, input.ContainerID, input.WorkspaceID)
+		if err != nil {
+			return nil, CopyEntitiesOutput{}, err
+		}
+
+		src, err := wc.Client.SnapshotWorkspace(ctx, wc.AccountID, wc.ContainerID, wc.WorkspaceID)
+		if err != nil {
+			return nil, CopyEntitiesOutput{}, err
+		}
+
+		partial, reuse, err := gtm.SelectForCopy(src, sel)
+		if err != nil {
+			return nil, CopyEntitiesOutput{}, err
+		}
+
+		result, err := wc.Client.ImportContainer(ctx, targetAccountID, input.TargetContainerID, input.TargetWorkspaceID, gtm.NewContainerExport(partial), gtm.ImportOptions{
+			Mode:  mode,
+			Apply: input.Confirm,
+			Reuse: reuse,
+		})
+		if err != nil {
+			return nil, CopyEntitiesOutput{}, err
+		}
+
+		// Safety guard: only the plan is computed until explicitly confirmed
+		if !input.Confirm {
+			return nil, CopyEntitiesOutput{
+				Success: false,
+				DryRun:  true,
+				Result:  *result,
+				Message: fmt.Sprintf("Dry run: %d creates (%d renamed), %d updates, %d existing dependencies reused. Set confirm: true to copy.",
+					result.Created, result.Renamed, result.Updated, result.Reused),
+			}, nil
+		}
+
+		return nil, CopyEntitiesOutput{
+			Success: true,
+			Result:  *result,
+			Message: fmt.Sprintf("Copied into workspace %s: %d created (%d renamed), %d updated, %d existing dependencies reused",
+				input.TargetWorkspaceID, result.Created, result.Renamed, result.Updated, result.Reused),
+		}, nil
+	}
+
+	addTool(r, &mcp.Tool{
+		Name:        "copy_entities",
+		Description: "Copy tags, triggers and variables (by ID or name) from one workspace to another, including across containers. Referenced triggers, variables, built-in variables and custom templates are copied too unless an entity with the same name already exists in the target. Trigger IDs are remapped. Without confirm: true returns the planned changes only.",
+	}, handler)
+}
@@ -0,0 +1,71 @@
+package tools
+
+import (
+	"context"
+
+	"github.com/paolobietolini/gtm-mcp-server/gtm"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// CreateClientInput is the input for create_client tool.
+type CreateClientInput struct {
+	AccountID      string          `json:"accountId" jsonschema:"description:The GTM account ID"`
+	ContainerID    string          `json:"containerId" jsonschema:"description:The GTM container ID"`
+	WorkspaceID    string          `json:"workspaceId" jsonschema:"description:The GTM workspace ID"`
+	Name           string          `json:"name" jsonschema:"description:Client name"`
+	Type           string          `json:"type" jsonschema:"description:Client type (e.g. __ga4 for GA4, __googtag for Google tag)"`
+	Priority       int64           `json:"priority,omitempty" jsonschema:"description:Client priority (optional, higher runs first)"`
+	Parameters     []gtm.Parameter `json:"parameters,omitempty" jsonschema:"description:Client parameters (optional). Each parameter: {type, key, value} or {type, key, list/map}, with values as strings"`
+	ParametersJSON string          `json:"parametersJson,omitempty" jsonschema:"description:Client parameters as a JSON array string (optional, legacy alternative to parameters)"`
+	Notes          string          `json:"notes,omitempty" jsonschema:"description:Client notes (optional)"`
+}
+
+// CreateClientOutput is the output for create_client tool.
+type CreateClientOutput struct {
+	Success bool              `json:"success"`
+	Client  gtm.CreatedClient `json:"client"`
+	Message string            `json:"message"`
+}
+
+func registerCreateClient(r *toolRegistry) {
+	handler := func(ctx context.Context, req *mcp.CallToolRequest, input CreateClientInput) (*mcp.CallToolResult, CreateClientOutput, error) {
+		wc, err := resolveWorkspace(ctx, input.AccountID, input.ContainerID, input.WorkspaceID)
+		if err != nil {
+			return nil, CreateClientOutput{}, err
+		}
+
+		if err := gtm.ValidateClientInput(input.Name, input.Type); err != nil {
+			return nil, CreateClientOutput{}, err
+		}
+
+		params, err := gtm.ParametersInput("parameters", input.Parameters, "parametersJson", input.ParametersJSON)
+		if err != nil {
+			return nil, CreateClientOutput{}, err
+		}
+
+		clientInput := &gtm.ClientInput{
+			Name:      input.Name,
+			Type:      input.Type,
+			Priority:  input.Priority,
+			Parameter: params,
+			Notes:     input.Notes,
+		}
+
+		cl, err := wc.Client.CreateClient(ctx, wc.AccountID, wc.ContainerID, wc.WorkspaceID, clientInput)
+		if err != nil {
+			return nil, CreateClientOutput{}, err
+		}
+
+		return nil, CreateClientOutput{
+			Success: true,
+			Client:  *cl,
+			Message: "Client created successfully",
+		}, nil
+	}
+
+	addTool(r, &mcp.Tool{
+		Name:        "create_client",
+		Description: "Create a new client in a GTM workspace (server-side containers only)",
+	}, handler)
+}
@@ -1,9 +1,11 @@
-package gtm
+package tools
 
 import (
 	"context"
 	"fmt"
 
+	"github.com/paolobietolini/gtm-mcp-server/gtm"
+
 	"github.com/modelcontextprotocol/go-sdk/mcp"
 	tagmanager "google.golang.org/api/tagmanager/v2"
 )
@@ -35,7 +37,7 @@ type CreatedContainer struct {
 	TagManagerUrl string   `json:"tagManagerUrl,omitempty"`
 }
 
-func registerCreateContainer(server *mcp.Server) {
+func registerCreateContainer(r *toolRegistry) {
 	handler := func(ctx context.Context, req *mcp.CallToolRequest, input CreateContainerInput) (*mcp.CallToolResult, CreateContainerOutput, error) {
 		client, err := resolveAccount(ctx, input.AccountID)
 		if err != nil {
@@ -72,7 +74,7 @@ func registerCreateContainer(server *mcp.Server) {
 
 		created, err := client.Service.Accounts.Containers.Create(parent, container).Context(ctx).Do()
 		if err != nil {
-			return nil, CreateContainerOutput{}, mapGoogleError(err)
+			return nil, CreateContainerOutput{}, gtm.MapGoogleError(err)
 		}
 
 		return nil, CreateContainerOutput{
@@ -89,7 +91,7 @@ func registerCreateContainer(server *mcp.Server) {
 		}, nil
 	}
 
-	mcp.AddTool(server, &mcp.Tool{
+	addTool(r, &mcp.Tool{
 		Name:        "create_container",
 		Description: "Create a new container in a GTM account. UsageContext specifies the container type (web, android, ios, amp, server).",
 	}, handler)
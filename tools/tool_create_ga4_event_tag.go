@@ -0,0 +1,86 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/paolobietolini/gtm-mcp-server/gtm"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// CreateGA4EventTagInput is the input for create_ga4_event_tag tool.
+type CreateGA4EventTagInput struct {
+	AccountID   string `json:"accountId" jsonschema:"description:The GTM account ID"`
+	ContainerID string `json:"containerId" jsonschema:"description:The GTM container ID"`
+	WorkspaceID string `json:"workspaceId" jsonschema:"description:The GTM workspace ID"`
+	Name        string `json:"name" jsonschema:"description:Tag name"`
+	gtm.GA4EventTag
+	FiringTriggerIDs   []string `json:"firingTriggerIds" jsonschema:"description:Array of trigger IDs that fire this tag"`
+	BlockingTriggerIDs []string `json:"blockingTriggerIds,omitempty" jsonschema:"description:Array of trigger IDs that block this tag (optional)"`
+	Notes              string   `json:"notes,omitempty" jsonschema:"description:Tag notes (optional)"`
+	Paused             bool     `json:"paused,omitempty" jsonschema:"description:Whether tag is paused (optional)"`
+	ParentFolderID     string   `json:"parentFolderId,omitempty" jsonschema:"description:Folder ID to create the tag in (optional, defaults to the workspace root)"`
+}
+
+func registerCreateGA4EventTag(r *toolRegistry) {
+	handler := func(ctx context.Context, req *mcp.CallToolRequest, input CreateGA4EventTagInput) (*mcp.CallToolResult, CreateTagOutput, error) {
+		wc, err := resolveWorkspace(ctx, input.AccountID, input.ContainerID, input.WorkspaceID)
+		if err != nil {
+			return nil, CreateTagOutput{}, err
+		}
+
+		if err := gtm.ValidateTagInput(input.Name, "gaawe", input.FiringTriggerIDs); err != nil {
+			return nil, CreateTagOutput{}, err
+		}
+		params, err := input.GA4EventTag.Parameters()
+		if err != nil {
+			return nil, CreateTagOutput{}, err
+		}
+
+		if input.ConfigTag != "" {
+			tags, err := wc.Client.ListTags(ctx, wc.AccountID, wc.ContainerID, wc.WorkspaceID)
+			if err != nil {
+				return nil, CreateTagOutput{}, err
+			}
+			if !hasGoogleTag(tags, input.ConfigTag) {
+				return nil, CreateTagOutput{}, gtm.InvalidField("configTag", "no Google tag (googtag or gaawc) named %q in the workspace", input.ConfigTag)
+			}
+		}
+
+		tag, err := wc.Client.CreateTag(ctx, wc.AccountID, wc.ContainerID, wc.WorkspaceID, &gtm.TagInput{
+			Name:              input.Name,
+			Type:              "gaawe",
+			FiringTriggerId:   input.FiringTriggerIDs,
+			BlockingTriggerId: input.BlockingTriggerIDs,
+			Parameter:         params,
+			Notes:             input.Notes,
+			Paused:            input.Paused,
+			ParentFolderId:    input.ParentFolderID,
+		})
+		if err != nil {
+			return nil, CreateTagOutput{}, err
+		}
+
+		return nil, CreateTagOutput{
+			Success: true,
+			Tag:     *tag,
+			Message: fmt.Sprintf("GA4 event tag for %s created successfully", input.EventName),
+		}, nil
+	}
+
+	addTool(r, &mcp.Tool{
+		Name:        "create_ga4_event_tag",
+		Description: "Create a GA4 event tag (gaawe) from plain fields: measurementId (or configTag, the name of a Google tag in the workspace), eventName, eventParameters and userProperties as name: value maps, and sendEcommerceData. Builds the gaawe parameter structure for you; prefer it over create_tag for GA4 events.",
+	}, handler)
+}
+
+// hasGoogleTag reports whether tags include a Google tag named name.
+func hasGoogleTag(tags []gtm.Tag, name string) bool {
+	for _, t := range tags {
+		if t.Name == name && (t.Type == "googtag" || t.Type == "gaawc") {
+			return true
+		}
+	}
+	return false
+}
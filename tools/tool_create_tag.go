@@ -0,0 +1,107 @@
+package tools
+
+import (
+	"context"
+
+	"github.com/paolobietolini/gtm-mcp-server/gtm"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// CreateTagInput is the input for create_tag tool.
+type CreateTagInput struct {
+	AccountID          string          `json:"accountId" jsonschema:"description:The GTM account ID"`
+	ContainerID        string          `json:"containerId" jsonschema:"description:The GTM container ID"`
+	WorkspaceID        string          `json:"workspaceId" jsonschema:"description:The GTM workspace ID"`
+	Name               string          `json:"name" jsonschema:"description:Tag name"`
+	Type               string          `json:"type" jsonschema:"description:Tag type (e.g. gaawe for GA4, html for Custom HTML)"`
+	FiringTriggerIDs   []string        `json:"firingTriggerIds" jsonschema:"description:Array of trigger IDs that fire this tag"`
+	BlockingTriggerIDs []string        `json:"blockingTriggerIds,omitempty" jsonschema:"description:Array of trigger IDs that block this tag (optional)"`
+	Parameters         []gtm.Parameter `json:"parameters,omitempty" jsonschema:"description:Tag parameters (optional). Each parameter: {type, key, value} or {type, key, list/map}, with values as strings"`
+	ParametersJSON     string          `json:"parametersJson,omitempty" jsonschema:"description:Tag parameters as a JSON array string (optional, legacy alternative to parameters)"`
+	Notes              string          `json:"notes,omitempty" jsonschema:"description:Tag notes (optional)"`
+	Paused             bool            `json:"paused,omitempty" jsonschema:"description:Whether tag is paused (optional)"`
+	ScheduleStart      string          `json:"scheduleStart,omitempty" jsonschema:"description:When the tag starts firing, ISO 8601 (e.g. 2026-03-01T09:00). Optional."`
+	ScheduleEnd        string          `json:"scheduleEnd,omitempty" jsonschema:"description:When the tag stops firing, ISO 8601 (e.g. 2026-03-31T23:59). Must be after scheduleStart. Optional."`
+	ScheduleTimezone   string          `json:"scheduleTimezone,omitempty" jsonschema:"description:IANA time zone for scheduleStart/scheduleEnd without a UTC offset (e.g. Europe/Rome). Defaults to UTC."`
+	Priority           *int            `json:"priority,omitempty" jsonschema:"description:Tag firing priority; higher numbers fire first when tags share a trigger (optional, default 0)"`
+	ConsentStatus      string          `json:"consentStatus,omitempty" jsonschema:"description:Additional consent checks: notSet, notNeeded or needed (optional; defaults to needed when consentTypes is given)"`
+	ConsentTypes       []string        `json:"consentTypes,omitempty" jsonschema:"description:Consent types that must be granted for the tag to fire when consentStatus is needed (e.g. ad_storage, analytics_storage, ad_user_data, ad_personalization)"`
+	ParentFolderID     string          `json:"parentFolderId,omitempty" jsonschema:"description:Folder ID to create the tag in (optional, defaults to the workspace root)"`
+}
+
+// CreateTagOutput is the output for create_tag tool.
+type CreateTagOutput struct {
+	Success bool           `json:"success"`
+	Tag     gtm.CreatedTag `json:"tag"`
+	Message string         `json:"message"`
+}
+
+func registerCreateTag(r *toolRegistry) {
+	handler := func(ctx context.Context, req *mcp.CallToolRequest, input CreateTagInput) (*mcp.CallToolResult, CreateTagOutput, error) {
+		wc, err := resolveWorkspace(ctx, input.AccountID, input.ContainerID, input.WorkspaceID)
+		if err != nil {
+			return nil, CreateTagOutput{}, err
+		}
+
+		tagInput, err := input.tagInput()
+		if err != nil {
+			return nil, CreateTagOutput{}, err
+		}
+
+		tag, err := wc.Client.CreateTag(ctx, wc.AccountID, wc.ContainerID, wc.WorkspaceID, tagInput)
+		if err != nil {
+			return nil, CreateTagOutput{}, err
+		}
+
+		return nil, CreateTagOutput{
+			Success: true,
+			Tag:     *tag,
+			Message: "Tag created successfully",
+		}, nil
+	}
+
+	addTool(r, &mcp.Tool{
+		Name:        "create_tag",
+		Description: "Create a new tag in a GTM workspace. Requires at least one firing trigger ID.",
+	}, handler)
+}
+
+// tagInput validates the input and converts it to the tag to create.
+func (input *CreateTagInput) tagInput() (*gtm.TagInput, error) {
+	// Validate tag input
+	if err := gtm.ValidateTagInput(input.Name, input.Type, input.FiringTriggerIDs); err != nil {
+		return nil, err
+	}
+
+	params, err := gtm.ParametersInput("parameters", input.Parameters, "parametersJson", input.ParametersJSON)
+	if err != nil {
+		return nil, err
+	}
+
+	// Convert ISO 8601 schedule times to the epoch millis GTM expects
+	scheduleStartMs, scheduleEndMs, err := gtm.ParseSchedule(input.ScheduleStart, input.ScheduleEnd, input.ScheduleTimezone)
+	if err != nil {
+		return nil, err
+	}
+
+	consent, err := gtm.ParseConsentSettings(input.ConsentStatus, input.ConsentTypes)
+	if err != nil {
+		return nil, err
+	}
+
+	return &gtm.TagInput{
+		Name:              input.Name,
+		Type:              input.Type,
+		FiringTriggerId:   input.FiringTriggerIDs,
+		BlockingTriggerId: input.BlockingTriggerIDs,
+		Parameter:         params,
+		Notes:             input.Notes,
+		Paused:            input.Paused,
+		ScheduleStartMs:   scheduleStartMs,
+		ScheduleEndMs:     scheduleEndMs,
+		ConsentSettings:   consent,
+		Priority:          input.Priority,
+		ParentFolderId:    input.ParentFolderID,
+	}, nil
+}
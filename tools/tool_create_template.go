@@ -1,9 +1,11 @@
-package gtm
+package tools
 
 import (
 	"context"
 	"fmt"
 
+	"github.com/paolobietolini/gtm-mcp-server/gtm"
+
 	"github.com/modelcontextprotocol/go-sdk/mcp"
 	tagmanager "google.golang.org/api/tagmanager/v2"
 )
@@ -28,7 +30,7 @@ type CreateTemplateOutput struct {
 	Message       string `json:"message"`
 }
 
-func registerCreateTemplate(server *mcp.Server) {
+func registerCreateTemplate(r *toolRegistry) {
 	handler := func(ctx context.Context, req *mcp.CallToolRequest, input CreateTemplateInput) (*mcp.CallToolResult, CreateTemplateOutput, error) {
 		wc, err := resolveWorkspace(ctx, input.AccountID, input.ContainerID, input.WorkspaceID)
 		if err != nil {
@@ -51,7 +53,7 @@ func registerCreateTemplate(server *mcp.Server) {
 
 		created, err := wc.Client.Service.Accounts.Containers.Workspaces.Templates.Create(parent, template).Context(ctx).Do()
 		if err != nil {
-			return nil, CreateTemplateOutput{}, mapGoogleError(err)
+			return nil, CreateTemplateOutput{}, gtm.MapGoogleError(err)
 		}
 
 		return nil, CreateTemplateOutput{
@@ -65,7 +67,7 @@ func registerCreateTemplate(server *mcp.Server) {
 		}, nil
 	}
 
-	mcp.AddTool(server, &mcp.Tool{
+	addTool(r, &mcp.Tool{
 		Name:        "create_template",
 		Description: "Create a new custom template in a GTM workspace. Requires the full template code in .tpl format. For gallery templates, use import_gallery_template instead.",
 	}, handler)
@@ -1,49 +1,49 @@
-package gtm
+package tools
 
 import (
 	"context"
-	"encoding/json"
+
+	"github.com/paolobietolini/gtm-mcp-server/gtm"
 
 	"github.com/modelcontextprotocol/go-sdk/mcp"
 )
 
 // CreateTransformationInput is the input for create_transformation tool.
 type CreateTransformationInput struct {
-	AccountID      string `json:"accountId" jsonschema:"description:The GTM account ID"`
-	ContainerID    string `json:"containerId" jsonschema:"description:The GTM container ID"`
-	WorkspaceID    string `json:"workspaceId" jsonschema:"description:The GTM workspace ID"`
-	Name           string `json:"name" jsonschema:"description:Transformation name"`
-	Type           string `json:"type" jsonschema:"description:Transformation type. Valid values: tf_exclude_params (exclude parameters from tags), tf_allow_params (allow only specified parameters), tf_augment_event (add/modify event parameters)"`
-	ParametersJSON string `json:"parametersJson,omitempty" jsonschema:"description:Transformation parameters as JSON array (optional). Each parameter: {type, key, value} or {type, key, list/map}"`
-	Notes          string `json:"notes,omitempty" jsonschema:"description:Transformation notes (optional)"`
+	AccountID      string          `json:"accountId" jsonschema:"description:The GTM account ID"`
+	ContainerID    string          `json:"containerId" jsonschema:"description:The GTM container ID"`
+	WorkspaceID    string          `json:"workspaceId" jsonschema:"description:The GTM workspace ID"`
+	Name           string          `json:"name" jsonschema:"description:Transformation name"`
+	Type           string          `json:"type" jsonschema:"description:Transformation type. Valid values: tf_exclude_params (exclude parameters from tags), tf_allow_params (allow only specified parameters), tf_augment_event (add/modify event parameters)"`
+	Parameters     []gtm.Parameter `json:"parameters,omitempty" jsonschema:"description:Transformation parameters (optional). Each parameter: {type, key, value} or {type, key, list/map}, with values as strings"`
+	ParametersJSON string          `json:"parametersJson,omitempty" jsonschema:"description:Transformation parameters as a JSON array string (optional, legacy alternative to parameters)"`
+	Notes          string          `json:"notes,omitempty" jsonschema:"description:Transformation notes (optional)"`
 }
 
 // CreateTransformationOutput is the output for create_transformation tool.
 type CreateTransformationOutput struct {
-	Success        bool                  `json:"success"`
-	Transformation CreatedTransformation `json:"transformation"`
-	Message        string                `json:"message"`
+	Success        bool                      `json:"success"`
+	Transformation gtm.CreatedTransformation `json:"transformation"`
+	Message        string                    `json:"message"`
 }
 
-func registerCreateTransformation(server *mcp.Server) {
+func registerCreateTransformation(r *toolRegistry) {
 	handler := func(ctx context.Context, req *mcp.CallToolRequest, input CreateTransformationInput) (*mcp.CallToolResult, CreateTransformationOutput, error) {
 		wc, err := resolveWorkspace(ctx, input.AccountID, input.ContainerID, input.WorkspaceID)
 		if err != nil {
 			return nil, CreateTransformationOutput{}, err
 		}
 
-		if err := ValidateTransformationInput(input.Name, input.Type); err != nil {
+		if err := gtm.ValidateTransformationInput(input.Name, input.Type); err != nil {
 			return nil, CreateTransformationOutput{}, err
 		}
 
-		var params []Parameter
-		if input.ParametersJSON != "" {
-			if err := json.Unmarshal([]byte(input.ParametersJSON), &params); err != nil {
-				return nil, CreateTransformationOutput{}, err
-			}
+		params, err := gtm.ParametersInput("parameters", input.Parameters, "parametersJson", input.ParametersJSON)
+		if err != nil {
+			return nil, CreateTransformationOutput{}, err
 		}
 
-		transformationInput := &TransformationInput{
+		transformationInput := &gtm.TransformationInput{
 			Name:      input.Name,
 			Type:      input.Type,
 			Parameter: params,
@@ -62,13 +62,13 @@ func registerCreateTransformation(server *mcp.Server) {
 		}, nil
 	}
 
-	mcp.AddTool(server, &mcp.Tool{
+	addTool(r, &mcp.Tool{
 		Name: "create_transformation",
 		Description: `Create a new transformation in a GTM workspace (server-side containers only).
 
 Type must be one of: tf_exclude_params, tf_allow_params, tf_augment_event.
 
-Each type uses a different table key and column names in parametersJson:
+Each type uses a different table key and column names in parameters:
 - tf_allow_params: "allowedParamsTable" with column "allowedParams"
 - tf_exclude_params: "excludedParamsTable" with column "excludedParams"
 - tf_augment_event: "augmentEventTable" with columns "paramName" and "paramValue"
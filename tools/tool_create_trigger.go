@@ -0,0 +1,130 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/paolobietolini/gtm-mcp-server/gtm"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// CreateTriggerInput is the input for create_trigger tool.
+type CreateTriggerInput struct {
+	AccountID                   string               `json:"accountId" jsonschema:"description:The GTM account ID"`
+	ContainerID                 string               `json:"containerId" jsonschema:"description:The GTM container ID"`
+	WorkspaceID                 string               `json:"workspaceId" jsonschema:"description:The GTM workspace ID"`
+	Name                        string               `json:"name" jsonschema:"description:Trigger name"`
+	Type                        string               `json:"type" jsonschema:"description:Trigger type (e.g. pageview, customEvent, linkClick, formSubmission, timer)"`
+	Filter                      []gtm.ConditionInput `json:"filter,omitempty" jsonschema:"description:Filter conditions for pageview triggers (optional). Each condition: {variable, op, value, negate} e.g. {variable: {{Page Path}}, op: startsWith, value: /checkout}, or raw GTM {type, parameter}"`
+	AutoEventFilter             []gtm.ConditionInput `json:"autoEventFilter,omitempty" jsonschema:"description:Auto-event filter for click/form triggers, same condition forms as filter (optional)"`
+	CustomEventFilter           []gtm.ConditionInput `json:"customEventFilter,omitempty" jsonschema:"description:Custom event filter for customEvent triggers, same condition forms as filter. REQUIRED for customEvent type (or customEventFilterJson). Must contain exactly one condition matching the event name."`
+	FilterJSON                  string               `json:"filterJson,omitempty" jsonschema:"description:Filter conditions as a JSON array string (optional, legacy alternative to filter)"`
+	AutoEventFilterJSON         string               `json:"autoEventFilterJson,omitempty" jsonschema:"description:Auto-event filter as a JSON array string (optional, legacy alternative to autoEventFilter)"`
+	CustomEventFilterJSON       string               `json:"customEventFilterJson,omitempty" jsonschema:"description:Custom event filter as a JSON array string (optional, legacy alternative to customEventFilter)"`
+	EventNameJSON               string               `json:"eventNameJson,omitempty" jsonschema:"description:Event name as JSON object {type, value} for timer triggers (optional)"`
+	EventName                   string               `json:"eventName,omitempty" jsonschema:"description:Event name for timer triggers, e.g. gtm.timer (optional, alternative to eventNameJson)"`
+	Interval                    *int64               `json:"interval,omitempty" jsonschema:"description:Timer triggers: milliseconds between firings (optional)"`
+	Limit                       *int64               `json:"limit,omitempty" jsonschema:"description:Timer triggers: maximum number of times to fire (optional)"`
+	VerticalScrollPercentages   []int                `json:"verticalScrollPercentages,omitempty" jsonschema:"description:Scroll triggers: vertical scroll depths to fire at, as percentages 1-100 (optional)"`
+	HorizontalScrollPercentages []int                `json:"horizontalScrollPercentages,omitempty" jsonschema:"description:Scroll triggers: horizontal scroll depths to fire at, as percentages 1-100 (optional)"`
+	Selector                    string               `json:"selector,omitempty" jsonschema:"description:Visibility triggers: CSS selector of the element to observe (optional)"`
+	VisiblePercentageMin        *int                 `json:"visiblePercentageMin,omitempty" jsonschema:"description:Visibility triggers: minimum percentage of the element that must be visible, 0-100 (optional)"`
+	VisiblePercentageMax        *int                 `json:"visiblePercentageMax,omitempty" jsonschema:"description:Visibility triggers: maximum percentage of the element that may be visible, 0-100 (optional)"`
+	Notes                       string               `json:"notes,omitempty" jsonschema:"description:Trigger notes (optional)"`
+	ParentFolderID              string               `json:"parentFolderId,omitempty" jsonschema:"description:Folder ID to create the trigger in (optional, defaults to the workspace root)"`
+}
+
+// CreateTriggerOutput is the output for create_trigger tool.
+type CreateTriggerOutput struct {
+	Success bool               `json:"success"`
+	Trigger gtm.CreatedTrigger `json:"trigger"`
+	Message string             `json:"message"`
+}
+
+func registerCreateTrigger(r *toolRegistry) {
+	handler := func(ctx context.Context, req *mcp.CallToolRequest, input CreateTriggerInput) (*mcp.CallToolResult, CreateTriggerOutput, error) {
+		wc, err := resolveWorkspace(ctx, input.AccountID, input.ContainerID, input.WorkspaceID)
+		if err != nil {
+			return nil, CreateTriggerOutput{}, err
+		}
+
+		triggerInput, err := input.triggerInput()
+		if err != nil {
+			return nil, CreateTriggerOutput{}, err
+		}
+
+		trigger, err := wc.Client.CreateTrigger(ctx, wc.AccountID, wc.ContainerID, wc.WorkspaceID, triggerInput)
+		if err != nil {
+			return nil, CreateTriggerOutput{}, err
+		}
+
+		return nil, CreateTriggerOutput{
+			Success: true,
+			Trigger: *trigger,
+			Message: "Trigger created successfully",
+		}, nil
+	}
+
+	addTool(r, &mcp.Tool{
+		Name:        "create_trigger",
+		Description: "Create a new trigger in a GTM workspace. Filters accept simple {variable, op, value, negate} conditions. Common types: pageview, customEvent, linkClick, formSubmission, timer, scrollDepth, elementVisibility. Timer, scroll and visibility triggers take their settings as typed fields (interval, limit, eventName, verticalScrollPercentages, selector, visiblePercentageMin/Max).",
+	}, handler)
+}
+
+// triggerInput validates the input and converts it to the trigger to create.
+func (input *CreateTriggerInput) triggerInput() (*gtm.TriggerInput, error) {
+	// Validate trigger input
+	if err := gtm.ValidateTriggerInput(input.Name, input.Type); err != nil {
+		return nil, err
+	}
+
+	filter, err := gtm.ConditionsInput("filter", input.Filter, "filterJson", input.FilterJSON)
+	if err != nil {
+		return nil, err
+	}
+	autoEventFilter, err := gtm.ConditionsInput("autoEventFilter", input.AutoEventFilter, "autoEventFilterJson", input.AutoEventFilterJSON)
+	if err != nil {
+		return nil, err
+	}
+	// Required for customEvent triggers, checked by gtm.ValidateTriggerFields
+	customEventFilter, err := gtm.ConditionsInput("customEventFilter", input.CustomEventFilter, "customEventFilterJson", input.CustomEventFilterJSON)
+	if err != nil {
+		return nil, err
+	}
+
+	// Parse event name JSON if provided
+	var eventName *gtm.Parameter
+	if input.EventNameJSON != "" && input.EventName != "" {
+		return nil, fmt.Errorf("provide either eventName or eventNameJson, not both")
+	}
+	if input.EventNameJSON != "" {
+		if eventName, err = gtm.ParseParameterJSON(input.EventNameJSON); err != nil {
+			return nil, err
+		}
+	} else if input.EventName != "" {
+		eventName = &gtm.Parameter{Type: gtm.ParamTemplate, Value: input.EventName}
+	}
+
+	triggerInput := &gtm.TriggerInput{
+		Name:                        input.Name,
+		Type:                        input.Type,
+		Filter:                      filter,
+		AutoEventFilter:             autoEventFilter,
+		CustomEventFilter:           customEventFilter,
+		EventName:                   eventName,
+		Notes:                       input.Notes,
+		ParentFolderId:              input.ParentFolderID,
+		Interval:                    input.Interval,
+		Limit:                       input.Limit,
+		VerticalScrollPercentages:   input.VerticalScrollPercentages,
+		HorizontalScrollPercentages: input.HorizontalScrollPercentages,
+		Selector:                    input.Selector,
+		VisiblePercentageMin:        input.VisiblePercentageMin,
+		VisiblePercentageMax:        input.VisiblePercentageMax,
+	}
+	if err := gtm.ValidateTriggerFields(triggerInput); err != nil {
+		return nil, err
+	}
+	return triggerInput, nil
+}
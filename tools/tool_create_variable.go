@@ -0,0 +1,88 @@
+package tools
+
+import (
+	"context"
+
+	"github.com/paolobietolini/gtm-mcp-server/gtm"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// CreateVariableInput is the input for create_variable tool.
+type CreateVariableInput struct {
+	AccountID      string           `json:"accountId" jsonschema:"description:The GTM account ID"`
+	ContainerID    string           `json:"containerId" jsonschema:"description:The GTM container ID"`
+	WorkspaceID    string           `json:"workspaceId" jsonschema:"description:The GTM workspace ID"`
+	Name           string           `json:"name" jsonschema:"description:Variable name"`
+	Type           string           `json:"type" jsonschema:"description:Variable type (e.g. c for Constant, v for Data Layer, k for Cookie, jsm for Custom JavaScript, gtes for Google tag Event Settings, gtcs for Google tag Configuration Settings)"`
+	Parameters     []gtm.Parameter  `json:"parameters,omitempty" jsonschema:"description:Variable parameters (required for most types). Each parameter: {type, key, value} or {type, key, list/map}, with values as strings"`
+	ParametersJSON string           `json:"parametersJson,omitempty" jsonschema:"description:Variable parameters as a JSON array string (legacy alternative to parameters)"`
+	Notes          string           `json:"notes,omitempty" jsonschema:"description:Variable notes (optional)"`
+	FormatValue    *gtm.FormatValue `json:"formatValue,omitempty" jsonschema:"description:Output formatting: caseConversionType and values to convert null, undefined, true or false to (optional)"`
+	ParentFolderID string           `json:"parentFolderId,omitempty" jsonschema:"description:Folder ID to create the variable in (optional, defaults to the workspace root)"`
+}
+
+// CreateVariableOutput is the output for create_variable tool.
+type CreateVariableOutput struct {
+	Success  bool                `json:"success"`
+	Variable gtm.CreatedVariable `json:"variable"`
+	Message  string              `json:"message"`
+}
+
+func registerCreateVariable(r *toolRegistry) {
+	handler := func(ctx context.Context, req *mcp.CallToolRequest, input CreateVariableInput) (*mcp.CallToolResult, CreateVariableOutput, error) {
+		wc, err := resolveWorkspace(ctx, input.AccountID, input.ContainerID, input.WorkspaceID)
+		if err != nil {
+			return nil, CreateVariableOutput{}, err
+		}
+
+		variableInput, err := input.variableInput()
+		if err != nil {
+			return nil, CreateVariableOutput{}, err
+		}
+
+		variable, err := wc.Client.CreateVariable(ctx, wc.AccountID, wc.ContainerID, wc.WorkspaceID, variableInput)
+		if err != nil {
+			return nil, CreateVariableOutput{}, err
+		}
+
+		return nil, CreateVariableOutput{
+			Success:  true,
+			Variable: *variable,
+			Message:  "Variable created successfully",
+		}, nil
+	}
+
+	addTool(r, &mcp.Tool{
+		Name:        "create_variable",
+		Description: "Create a new variable in a GTM workspace. Common types: c (Constant), v (Data Layer), k (Cookie), jsm (Custom JavaScript), u (URL), gtes (Google tag: Event Settings), gtcs (Google tag: Configuration Settings). Use get_variable_templates for the parameter structure of each type. Set formatValue to e.g. convert undefined to 0.",
+	}, handler)
+}
+
+// variableInput validates the input and converts it to the variable to create.
+func (input *CreateVariableInput) variableInput() (*gtm.VariableInput, error) {
+	// Validate variable input
+	if err := gtm.ValidateVariableInput(input.Name, input.Type); err != nil {
+		return nil, err
+	}
+
+	params, err := gtm.ParametersInput("parameters", input.Parameters, "parametersJson", input.ParametersJSON)
+	if err != nil {
+		return nil, err
+	}
+	if err := gtm.ValidateVariableParameters(input.Type, params); err != nil {
+		return nil, err
+	}
+	if err := gtm.ValidateFormatValue(input.FormatValue); err != nil {
+		return nil, err
+	}
+
+	return &gtm.VariableInput{
+		Name:           input.Name,
+		Type:           input.Type,
+		Parameter:      params,
+		Notes:          input.Notes,
+		ParentFolderId: input.ParentFolderID,
+		FormatValue:    input.FormatValue,
+	}, nil
+}
@@ -1,11 +1,10 @@
-package gtm
+package tools
 
 import (
 	"context"
 	"fmt"
 
 	"github.com/modelcontextprotocol/go-sdk/mcp"
-	tagmanager "google.golang.org/api/tagmanager/v2"
 )
 
 // CreateWorkspaceInput is the input for create_workspace tool.
@@ -32,7 +31,7 @@ type CreatedWorkspace struct {
 	TagManagerUrl string `json:"tagManagerUrl,omitempty"`
 }
 
-func registerCreateWorkspace(server *mcp.Server) {
+func registerCreateWorkspace(r *toolRegistry) {
 	handler := func(ctx context.Context, req *mcp.CallToolRequest, input CreateWorkspaceInput) (*mcp.CallToolResult, CreateWorkspaceOutput, error) {
 		cc, err := resolveContainer(ctx, input.AccountID, input.ContainerID)
 		if err != nil {
@@ -43,15 +42,9 @@ func registerCreateWorkspace(server *mcp.Server) {
 			return nil, CreateWorkspaceOutput{}, fmt.Errorf("name is required")
 		}
 
-		parent := cc.ContainerPath()
-		workspace := &tagmanager.Workspace{
-			Name:        input.Name,
-			Description: input.Description,
-		}
-
-		created, err := cc.Client.Service.Accounts.Containers.Workspaces.Create(parent, workspace).Context(ctx).Do()
+		created, err := cc.Client.CreateWorkspace(ctx, cc.AccountID, cc.ContainerID, input.Name, input.Description)
 		if err != nil {
-			return nil, CreateWorkspaceOutput{}, mapGoogleError(err)
+			return nil, CreateWorkspaceOutput{}, err
 		}
 
 		return nil, CreateWorkspaceOutput{
@@ -67,7 +60,7 @@ func registerCreateWorkspace(server *mcp.Server) {
 		}, nil
 	}
 
-	mcp.AddTool(server, &mcp.Tool{
+	addTool(r, &mcp.Tool{
 		Name:        "create_workspace",
 		Description: "Create a new workspace in a GTM container. Workspaces are used to make changes that can later be versioned and published.",
 	}, handler)
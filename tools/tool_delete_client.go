@@ -1,9 +1,11 @@
-package gtm
+package tools
 
 import (
 	"context"
 	"fmt"
 
+	"github.com/paolobietolini/gtm-mcp-server/gtm"
+
 	"github.com/modelcontextprotocol/go-sdk/mcp"
 )
 
@@ -22,7 +24,7 @@ type DeleteClientOutput struct {
 	Message string `json:"message"`
 }
 
-func registerDeleteClient(server *mcp.Server) {
+func registerDeleteClient(r *toolRegistry) {
 	handler := func(ctx context.Context, req *mcp.CallToolRequest, input DeleteClientInput) (*mcp.CallToolResult, DeleteClientOutput, error) {
 		if !input.Confirm {
 			return nil, DeleteClientOutput{
@@ -40,7 +42,7 @@ func registerDeleteClient(server *mcp.Server) {
 			return nil, DeleteClientOutput{}, fmt.Errorf("client ID is required")
 		}
 
-		path := BuildClientPath(wc.AccountID, wc.ContainerID, wc.WorkspaceID, input.ClientID)
+		path := gtm.BuildClientPath(wc.AccountID, wc.ContainerID, wc.WorkspaceID, input.ClientID)
 
 		if err := wc.Client.DeleteClient(ctx, path); err != nil {
 			return nil, DeleteClientOutput{}, err
@@ -52,7 +54,7 @@ func registerDeleteClient(server *mcp.Server) {
 		}, nil
 	}
 
-	mcp.AddTool(server, &mcp.Tool{
+	addTool(r, &mcp.Tool{
 		Name:        "delete_client",
 		Description: "Delete a client from a workspace. Requires confirm: true as a safety guard. Server-side containers only.",
 	}, handler)
@@ -0,0 +1,85 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/paolobietolini/gtm-mcp-server/gtm"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// DeleteContainerInput is the input for delete_container tool.
+type DeleteContainerInput struct {
+	AccountID         string `json:"accountId" jsonschema:"description:The GTM account ID"`
+	ContainerID       string `json:"containerId" jsonschema:"description:The GTM container ID"`
+	ConfirmationToken string `json:"confirmationToken,omitempty" jsonschema:"description:Token returned by the first delete_container call. Omit on the first call."`
+}
+
+// DeleteContainerOutput is the output for delete_container tool.
+type DeleteContainerOutput struct {
+	Success           bool           `json:"success"`
+	Container         *gtm.Container `json:"container,omitempty"`
+	ConfirmationToken string         `json:"confirmationToken,omitempty"`
+	BackupID          string         `json:"backupId,omitempty"`
+	Message           string         `json:"message"`
+}
+
+func registerDeleteContainer(r *toolRegistry) {
+	handler := func(ctx context.Context, req *mcp.CallToolRequest, input DeleteContainerInput) (*mcp.CallToolResult, DeleteContainerOutput, error) {
+		cc, err := resolveContainer(ctx, input.AccountID, input.ContainerID)
+		if err != nil {
+			return nil, DeleteContainerOutput{}, err
+		}
+
+		// Phase 1: snapshot the container and hand out a confirmation token
+		if input.ConfirmationToken == "" {
+			backup, err := cc.Client.BackupContainer(ctx, cc.AccountID, cc.ContainerID)
+			if err != nil {
+				return nil, DeleteContainerOutput{}, fmt.Errorf("failed to back up container before deletion: %w", err)
+			}
+
+			token, err := gtm.ContainerBackups.AddPending(userKey(ctx), backup)
+			if err != nil {
+				return nil, DeleteContainerOutput{}, err
+			}
+
+			return nil, DeleteContainerOutput{
+				Success: false,
+				Container: &gtm.Container{
+					ContainerID:  backup.ContainerID,
+					Name:         backup.Name,
+					PublicID:     backup.PublicID,
+					UsageContext: backup.UsageContext,
+				},
+				ConfirmationToken: token,
+				BackupID:          backup.BackupID,
+				Message: fmt.Sprintf("WARNING: This will permanently delete container %s (%s) and all its tags, triggers, variables and versions. A backup of the live version and %d workspaces was taken. Verify this is the right container, then call delete_container again with confirmationToken within %s.",
+					backup.Name, backup.PublicID, len(backup.Workspaces), gtm.DeleteTokenTTL),
+			}, nil
+		}
+
+		// Phase 2: delete with a valid token
+		backup, err := gtm.ContainerBackups.Consume(userKey(ctx), input.ConfirmationToken, cc.AccountID, cc.ContainerID)
+		if err != nil {
+			return nil, DeleteContainerOutput{}, err
+		}
+
+		if err := cc.Client.DeleteContainer(ctx, cc.ContainerPath()); err != nil {
+			return nil, DeleteContainerOutput{}, gtm.MapGoogleError(err)
+		}
+		gtm.ContainerBackups.MarkDeleted(backup)
+
+		return nil, DeleteContainerOutput{
+			Success:  true,
+			BackupID: backup.BackupID,
+			Message: fmt.Sprintf("Container %s deleted successfully. Its backup can be restored with restore_container (backupId %s) until %s.",
+				input.ContainerID, backup.BackupID, backup.ExpiresAt.Format("2006-01-02 15:04 MST")),
+		}, nil
+	}
+
+	addTool(r, &mcp.Tool{
+		Name:        "delete_container",
+		Description: "Delete a GTM container in two phases. The first call exports a full backup and returns a confirmationToken; the second call with that token deletes the container. WARNING: This permanently deletes the container and ALL its contents including tags, triggers, variables, and versions. The backup can be restored with restore_container for 7 days, by the same user, unless the server restarts first: backups are kept in memory only.",
+	}, handler)
+}
@@ -1,9 +1,11 @@
-package gtm
+package tools
 
 import (
 	"context"
 	"fmt"
 
+	"github.com/paolobietolini/gtm-mcp-server/gtm"
+
 	"github.com/modelcontextprotocol/go-sdk/mcp"
 )
 
@@ -12,7 +14,8 @@ type DeleteTagInput struct {
 	AccountID   string `json:"accountId" jsonschema:"description:The GTM account ID"`
 	ContainerID string `json:"containerId" jsonschema:"description:The GTM container ID"`
 	WorkspaceID string `json:"workspaceId" jsonschema:"description:The GTM workspace ID"`
-	TagID       string `json:"tagId" jsonschema:"description:The tag ID to delete"`
+	TagID       string `json:"tagId,omitempty" jsonschema:"description:The tag ID to delete (or use tagName)"`
+	TagName     string `json:"tagName,omitempty" jsonschema:"description:The exact tag name, instead of tagId"`
 	Confirm     bool   `json:"confirm" jsonschema:"description:Must be true to confirm deletion. This is a safety guard."`
 }
 
@@ -22,7 +25,7 @@ type DeleteTagOutput struct {
 	Message string `json:"message"`
 }
 
-func registerDeleteTag(server *mcp.Server) {
+func registerDeleteTag(r *toolRegistry) {
 	handler := func(ctx context.Context, req *mcp.CallToolRequest, input DeleteTagInput) (*mcp.CallToolResult, DeleteTagOutput, error) {
 		// Safety guard: require explicit confirmation
 		if !input.Confirm {
@@ -37,12 +40,12 @@ func registerDeleteTag(server *mcp.Server) {
 			return nil, DeleteTagOutput{}, err
 		}
 
-		// Validate tag ID
-		if input.TagID == "" {
-			return nil, DeleteTagOutput{}, fmt.Errorf("tag ID is required")
+		// Look the tag up by name if no ID was given
+		if input.TagID, err = wc.resolveTagID(ctx, input.TagID, input.TagName); err != nil {
+			return nil, DeleteTagOutput{}, err
 		}
 
-		path := BuildTagPath(wc.AccountID, wc.ContainerID, wc.WorkspaceID, input.TagID)
+		path := gtm.BuildTagPath(wc.AccountID, wc.ContainerID, wc.WorkspaceID, input.TagID)
 
 		if err := wc.Client.DeleteTag(ctx, path); err != nil {
 			return nil, DeleteTagOutput{}, err
@@ -54,7 +57,7 @@ func registerDeleteTag(server *mcp.Server) {
 		}, nil
 	}
 
-	mcp.AddTool(server, &mcp.Tool{
+	addTool(r, &mcp.Tool{
 		Name:        "delete_tag",
 		Description: "Delete a tag from a workspace. Requires confirm: true as a safety guard.",
 	}, handler)
@@ -1,9 +1,11 @@
-package gtm
+package tools
 
 import (
 	"context"
 	"fmt"
 
+	"github.com/paolobietolini/gtm-mcp-server/gtm"
+
 	"github.com/modelcontextprotocol/go-sdk/mcp"
 )
 
@@ -22,7 +24,7 @@ type DeleteTemplateOutput struct {
 	Message string `json:"message"`
 }
 
-func registerDeleteTemplate(server *mcp.Server) {
+func registerDeleteTemplate(r *toolRegistry) {
 	handler := func(ctx context.Context, req *mcp.CallToolRequest, input DeleteTemplateInput) (*mcp.CallToolResult, DeleteTemplateOutput, error) {
 		if !input.Confirm {
 			return nil, DeleteTemplateOutput{
@@ -44,7 +46,7 @@ func registerDeleteTemplate(server *mcp.Server) {
 
 		err = wc.Client.Service.Accounts.Containers.Workspaces.Templates.Delete(path).Context(ctx).Do()
 		if err != nil {
-			return nil, DeleteTemplateOutput{}, mapGoogleError(err)
+			return nil, DeleteTemplateOutput{}, gtm.MapGoogleError(err)
 		}
 
 		return nil, DeleteTemplateOutput{
@@ -53,7 +55,7 @@ func registerDeleteTemplate(server *mcp.Server) {
 		}, nil
 	}
 
-	mcp.AddTool(server, &mcp.Tool{
+	addTool(r, &mcp.Tool{
 		Name:        "delete_template",
 		Description: "Delete a custom template from a workspace. Requires confirm: true as a safety guard. Note: Templates that are in use by tags cannot be deleted.",
 	}, handler)
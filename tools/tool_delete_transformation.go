@@ -1,9 +1,11 @@
-package gtm
+package tools
 
 import (
 	"context"
 	"fmt"
 
+	"github.com/paolobietolini/gtm-mcp-server/gtm"
+
 	"github.com/modelcontextprotocol/go-sdk/mcp"
 )
 
@@ -22,7 +24,7 @@ type DeleteTransformationOutput struct {
 	Message string `json:"message"`
 }
 
-func registerDeleteTransformation(server *mcp.Server) {
+func registerDeleteTransformation(r *toolRegistry) {
 	handler := func(ctx context.Context, req *mcp.CallToolRequest, input DeleteTransformationInput) (*mcp.CallToolResult, DeleteTransformationOutput, error) {
 		if !input.Confirm {
 			return nil, DeleteTransformationOutput{
@@ -40,7 +42,7 @@ func registerDeleteTransformation(server *mcp.Server) {
 			return nil, DeleteTransformationOutput{}, fmt.Errorf("transformation ID is required")
 		}
 
-		path := BuildTransformationPath(wc.AccountID, wc.ContainerID, wc.WorkspaceID, input.TransformationID)
+		path := gtm.BuildTransformationPath(wc.AccountID, wc.ContainerID, wc.WorkspaceID, input.TransformationID)
 
 		if err := wc.Client.DeleteTransformation(ctx, path); err != nil {
 			return nil, DeleteTransformationOutput{}, err
@@ -52,7 +54,7 @@ func registerDeleteTransformation(server *mcp.Server) {
 		}, nil
 	}
 
-	mcp.AddTool(server, &mcp.Tool{
+	addTool(r, &mcp.Tool{
 		Name:        "delete_transformation",
 		Description: "Delete a transformation from a workspace. Requires confirm: true as a safety guard. Server-side containers only.",
 	}, handler)
@@ -1,9 +1,11 @@
-package gtm
+package tools
 
 import (
 	"context"
 	"fmt"
 
+	"github.com/paolobietolini/gtm-mcp-server/gtm"
+
 	"github.com/modelcontextprotocol/go-sdk/mcp"
 )
 
@@ -12,7 +14,8 @@ type DeleteTriggerInput struct {
 	AccountID   string `json:"accountId" jsonschema:"description:The GTM account ID"`
 	ContainerID string `json:"containerId" jsonschema:"description:The GTM container ID"`
 	WorkspaceID string `json:"workspaceId" jsonschema:"description:The GTM workspace ID"`
-	TriggerID   string `json:"triggerId" jsonschema:"description:The trigger ID to delete"`
+	TriggerID   string `json:"triggerId,omitempty" jsonschema:"description:The trigger ID to delete (or use triggerName)"`
+	TriggerName string `json:"triggerName,omitempty" jsonschema:"description:The exact trigger name, instead of triggerId"`
 	Confirm     bool   `json:"confirm" jsonschema:"description:Must be true to confirm deletion. This is a safety guard."`
 }
 
@@ -22,7 +25,7 @@ type DeleteTriggerOutput struct {
 	Message string `json:"message"`
 }
 
-func registerDeleteTrigger(server *mcp.Server) {
+func registerDeleteTrigger(r *toolRegistry) {
 	handler := func(ctx context.Context, req *mcp.CallToolRequest, input DeleteTriggerInput) (*mcp.CallToolResult, DeleteTriggerOutput, error) {
 		// Safety guard: require explicit confirmation
 		if !input.Confirm {
@@ -37,12 +40,12 @@ func registerDeleteTrigger(server *mcp.Server) {
 			return nil, DeleteTriggerOutput{}, err
 		}
 
-		// Validate trigger ID
-		if input.TriggerID == "" {
-			return nil, DeleteTriggerOutput{}, fmt.Errorf("trigger ID is required")
+		// Look the trigger up by name if no ID was given
+		if input.TriggerID, err = wc.resolveTriggerID(ctx, input.TriggerID, input.TriggerName); err != nil {
+			return nil, DeleteTriggerOutput{}, err
 		}
 
-		path := BuildTriggerPath(wc.AccountID, wc.ContainerID, wc.WorkspaceID, input.TriggerID)
+		path := gtm.BuildTriggerPath(wc.AccountID, wc.ContainerID, wc.WorkspaceID, input.TriggerID)
 
 		if err := wc.Client.DeleteTrigger(ctx, path); err != nil {
 			return nil, DeleteTriggerOutput{}, err
@@ -54,7 +57,7 @@ func registerDeleteTrigger(server *mcp.Server) {
 		}, nil
 	}
 
-	mcp.AddTool(server, &mcp.Tool{
+	addTool(r, &mcp.Tool{
 		Name:        "delete_trigger",
 		Description: "Delete a trigger from a workspace. Requires confirm: true as a safety guard. Note: Triggers that are members of a trigger group cannot be deleted until the trigger group is deleted first.",
 	}, handler)
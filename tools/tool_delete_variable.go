@@ -1,19 +1,22 @@
-package gtm
+package tools
 
 import (
 	"context"
 	"fmt"
 
+	"github.com/paolobietolini/gtm-mcp-server/gtm"
+
 	"github.com/modelcontextprotocol/go-sdk/mcp"
 )
 
 // DeleteVariableInput is the input for delete_variable tool.
 type DeleteVariableInput struct {
-	AccountID   string `json:"accountId" jsonschema:"description:The GTM account ID"`
-	ContainerID string `json:"containerId" jsonschema:"description:The GTM container ID"`
-	WorkspaceID string `json:"workspaceId" jsonschema:"description:The GTM workspace ID"`
-	VariableID  string `json:"variableId" jsonschema:"description:The variable ID to delete"`
-	Confirm     bool   `json:"confirm" jsonschema:"description:Must be true to confirm deletion. This is a safety guard."`
+	AccountID    string `json:"accountId" jsonschema:"description:The GTM account ID"`
+	ContainerID  string `json:"containerId" jsonschema:"description:The GTM container ID"`
+	WorkspaceID  string `json:"workspaceId" jsonschema:"description:The GTM workspace ID"`
+	VariableID   string `json:"variableId,omitempty" jsonschema:"description:The variable ID to delete (or use variableName)"`
+	VariableName string `json:"variableName,omitempty" jsonschema:"description:The exact variable name, instead of variableId"`
+	Confirm      bool   `json:"confirm" jsonschema:"description:Must be true to confirm deletion. This is a safety guard."`
 }
 
 // DeleteVariableOutput is the output for delete_variable tool.
@@ -22,7 +25,7 @@ type DeleteVariableOutput struct {
 	Message string `json:"message"`
 }
 
-func registerDeleteVariable(server *mcp.Server) {
+func registerDeleteVariable(r *toolRegistry) {
 	handler := func(ctx context.Context, req *mcp.CallToolRequest, input DeleteVariableInput) (*mcp.CallToolResult, DeleteVariableOutput, error) {
 		// Safety guard: require explicit confirmation
 		if !input.Confirm {
@@ -37,12 +40,12 @@ func registerDeleteVariable(server *mcp.Server) {
 			return nil, DeleteVariableOutput{}, err
 		}
 
-		// Validate variable ID
-		if input.VariableID == "" {
-			return nil, DeleteVariableOutput{}, fmt.Errorf("variable ID is required")
+		// Look the variable up by name if no ID was given
+		if input.VariableID, err = wc.resolveVariableID(ctx, input.VariableID, input.VariableName); err != nil {
+			return nil, DeleteVariableOutput{}, err
 		}
 
-		path := BuildVariablePath(wc.AccountID, wc.ContainerID, wc.WorkspaceID, input.VariableID)
+		path := gtm.BuildVariablePath(wc.AccountID, wc.ContainerID, wc.WorkspaceID, input.VariableID)
 
 		if err := wc.Client.DeleteVariable(ctx, path); err != nil {
 			return nil, DeleteVariableOutput{}, err
@@ -54,7 +57,7 @@ func registerDeleteVariable(server *mcp.Server) {
 		}, nil
 	}
 
-	mcp.AddTool(server, &mcp.Tool{
+	addTool(r, &mcp.Tool{
 		Name:        "delete_variable",
 		Description: "Delete a variable from a workspace. Requires confirm: true as a safety guard.",
 	}, handler)
@@ -0,0 +1,55 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/paolobietolini/gtm-mcp-server/gtm"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// DetectSecretsInput is the input for detect_secrets tool.
+type DetectSecretsInput struct {
+	AccountID   string   `json:"accountId" jsonschema:"description:The GTM account ID"`
+	ContainerID string   `json:"containerId" jsonschema:"description:The GTM container ID"`
+	WorkspaceID string   `json:"workspaceId" jsonschema:"description:The GTM workspace ID"`
+	EntityTypes []string `json:"entityTypes,omitempty" jsonschema:"description:Entity kinds to scan: tag, trigger, variable (optional, defaults to all three)"`
+}
+
+// DetectSecretsOutput is the output for detect_secrets tool.
+type DetectSecretsOutput struct {
+	Findings []gtm.SecretFinding `json:"findings"`
+	Counts   map[string]int      `json:"counts"`
+	Message  string              `json:"message"`
+}
+
+func registerDetectSecrets(r *toolRegistry) {
+	handler := func(ctx context.Context, req *mcp.CallToolRequest, input DetectSecretsInput) (*mcp.CallToolResult, DetectSecretsOutput, error) {
+		wc, err := resolveWorkspace(ctx, input.AccountID, input.ContainerID, input.WorkspaceID)
+		if err != nil {
+			return nil, DetectSecretsOutput{}, err
+		}
+
+		findings, err := wc.Client.DetectSecrets(ctx, wc.AccountID, wc.ContainerID, wc.WorkspaceID, input.EntityTypes)
+		if err != nil {
+			return nil, DetectSecretsOutput{}, err
+		}
+
+		counts := make(map[string]int)
+		for _, f := range findings {
+			counts[f.Severity]++
+		}
+		message := "No secrets or personal data found."
+		if len(findings) > 0 {
+			message = fmt.Sprintf("Found %d possible secrets or personal data (%d errors, %d warnings). Anything in a web container is public: revoke exposed credentials, then move them server-side or remove them.",
+				len(findings), counts[gtm.SeverityError], counts[gtm.SeverityWarning])
+		}
+		return nil, DetectSecretsOutput{Findings: findings, Counts: counts, Message: message}, nil
+	}
+
+	addTool(r, &mcp.Tool{
+		Name:        "detect_secrets",
+		Description: "Scan the tags, triggers and variables of a workspace (parameter values, constants, Custom HTML and notes) for values that look like API keys, access tokens, private keys, JWTs, bearer tokens or email addresses, and for literal values under secret-sounding keys. Matches are masked in the output.",
+	}, handler)
+}
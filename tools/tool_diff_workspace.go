@@ -0,0 +1,64 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/paolobietolini/gtm-mcp-server/gtm"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// DiffWorkspaceInput is the input for diff_workspace tool.
+type DiffWorkspaceInput struct {
+	AccountID        string `json:"accountId" jsonschema:"description:The GTM account ID"`
+	ContainerID      string `json:"containerId" jsonschema:"description:The GTM container ID"`
+	WorkspaceID      string `json:"workspaceId" jsonschema:"description:The GTM workspace ID"`
+	VersionID        string `json:"versionId,omitempty" jsonschema:"description:Version to compare against (optional, defaults to live)"`
+	IncludeSensitive bool   `json:"includeSensitive,omitempty" jsonschema:"description:Return parameter values that look like secrets (API keys, tokens, passwords) instead of redacting them"`
+}
+
+// DiffWorkspaceOutput is the output for diff_workspace tool.
+type DiffWorkspaceOutput struct {
+	Success       bool              `json:"success"`
+	BaseVersionID string            `json:"baseVersionId"`
+	Diff          gtm.ContainerDiff `json:"diff"`
+	Message       string            `json:"message"`
+}
+
+func registerDiffWorkspace(r *toolRegistry) {
+	handler := func(ctx context.Context, req *mcp.CallToolRequest, input DiffWorkspaceInput) (*mcp.CallToolResult, DiffWorkspaceOutput, error) {
+		wc, err := resolveWorkspace(ctx, input.AccountID, input.ContainerID, input.WorkspaceID)
+		if err != nil {
+			return nil, DiffWorkspaceOutput{}, err
+		}
+
+		versionID := input.VersionID
+		if versionID == "" {
+			versionID = "live"
+		}
+
+		wd, err := wc.Client.DiffWorkspace(ctx, wc.AccountID, wc.ContainerID, wc.WorkspaceID, versionID)
+		if err != nil {
+			return nil, DiffWorkspaceOutput{}, err
+		}
+
+		diff := wd.Diff
+		if !input.IncludeSensitive {
+			diff = gtm.DiffVersionsRedacted(wd.Base, wd.Head)
+		}
+
+		return nil, DiffWorkspaceOutput{
+			Success:       true,
+			BaseVersionID: wd.BaseVersionID,
+			Diff:          *diff,
+			Message: fmt.Sprintf("Workspace %s vs version %s: %d added, %d removed, %d modified",
+				wc.WorkspaceID, wd.BaseVersionID, diff.Added, diff.Removed, diff.Modified),
+		}, nil
+	}
+
+	addTool(r, &mcp.Tool{
+		Name:        "diff_workspace",
+		Description: "Compare a workspace against the live version (or a given versionId) and return added, removed and modified entities with field-level changes for modified ones. Parameters are compared by key; values that look like secrets are redacted unless includeSensitive is true. Use before publishing to review exactly what will change.",
+	}, handler)
+}
@@ -0,0 +1,136 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/paolobietolini/gtm-mcp-server/gtm"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// DuplicateTagInput is the input for duplicate_tag tool.
+type DuplicateTagInput struct {
+	AccountID          string          `json:"accountId" jsonschema:"description:The GTM account ID"`
+	ContainerID        string          `json:"containerId" jsonschema:"description:The GTM container ID"`
+	WorkspaceID        string          `json:"workspaceId" jsonschema:"description:The GTM workspace ID"`
+	TagID              string          `json:"tagId,omitempty" jsonschema:"description:The tag ID to duplicate (or use tagName)"`
+	TagName            string          `json:"tagName,omitempty" jsonschema:"description:The exact tag name, instead of tagId"`
+	Name               string          `json:"name" jsonschema:"description:Name of the copy"`
+	ParameterOverrides []gtm.Parameter `json:"parameterOverrides,omitempty" jsonschema:"description:Top-level parameters to set on the copy, replacing the original's parameter with the same key (optional), e.g. [{type: template, key: eventName, value: sign_up}]"`
+}
+
+// DuplicateTriggerInput is the input for duplicate_trigger tool.
+type DuplicateTriggerInput struct {
+	AccountID          string          `json:"accountId" jsonschema:"description:The GTM account ID"`
+	ContainerID        string          `json:"containerId" jsonschema:"description:The GTM container ID"`
+	WorkspaceID        string          `json:"workspaceId" jsonschema:"description:The GTM workspace ID"`
+	TriggerID          string          `json:"triggerId,omitempty" jsonschema:"description:The trigger ID to duplicate (or use triggerName)"`
+	TriggerName        string          `json:"triggerName,omitempty" jsonschema:"description:The exact trigger name, instead of triggerId"`
+	Name               string          `json:"name" jsonschema:"description:Name of the copy"`
+	ParameterOverrides []gtm.Parameter `json:"parameterOverrides,omitempty" jsonschema:"description:Top-level parameters to set on the copy, replacing the original's parameter with the same key (optional)"`
+}
+
+// DuplicateVariableInput is the input for duplicate_variable tool.
+type DuplicateVariableInput struct {
+	AccountID          string          `json:"accountId" jsonschema:"description:The GTM account ID"`
+	ContainerID        string          `json:"containerId" jsonschema:"description:The GTM container ID"`
+	WorkspaceID        string          `json:"workspaceId" jsonschema:"description:The GTM workspace ID"`
+	VariableID         string          `json:"variableId,omitempty" jsonschema:"description:The variable ID to duplicate (or use variableName)"`
+	VariableName       string          `json:"variableName,omitempty" jsonschema:"description:The exact variable name, instead of variableId"`
+	Name               string          `json:"name" jsonschema:"description:Name of the copy"`
+	ParameterOverrides []gtm.Parameter `json:"parameterOverrides,omitempty" jsonschema:"description:Top-level parameters to set on the copy, replacing the original's parameter with the same key (optional), e.g. [{type: template, key: value, value: G-NEW123}] for a constant"`
+}
+
+func registerDuplicateTag(r *toolRegistry) {
+	handler := func(ctx context.Context, req *mcp.CallToolRequest, input DuplicateTagInput) (*mcp.CallToolResult, CreateTagOutput, error) {
+		wc, err := resolveWorkspace(ctx, input.AccountID, input.ContainerID, input.WorkspaceID)
+		if err != nil {
+			return nil, CreateTagOutput{}, err
+		}
+		if input.TagID, err = wc.resolveTagID(ctx, input.TagID, input.TagName); err != nil {
+			return nil, CreateTagOutput{}, err
+		}
+		overrides, err := gtm.ParametersInput("parameterOverrides", input.ParameterOverrides, "", "")
+		if err != nil {
+			return nil, CreateTagOutput{}, err
+		}
+
+		tag, err := wc.Client.DuplicateTag(ctx, wc.AccountID, wc.ContainerID, wc.WorkspaceID, input.TagID, input.Name, overrides)
+		if err != nil {
+			return nil, CreateTagOutput{}, err
+		}
+		return nil, CreateTagOutput{
+			Success: true,
+			Tag:     *tag,
+			Message: fmt.Sprintf("Tag %s duplicated as %s", input.TagID, tag.TagID),
+		}, nil
+	}
+
+	addTool(r, &mcp.Tool{
+		Name:        "duplicate_tag",
+		Description: "Copy an existing tag within the same workspace under a new name, keeping every field (triggers, consent settings, schedule, setup/teardown tags, folder) and optionally overriding top-level parameters by key.",
+	}, handler)
+}
+
+func registerDuplicateTrigger(r *toolRegistry) {
+	handler := func(ctx context.Context, req *mcp.CallToolRequest, input DuplicateTriggerInput) (*mcp.CallToolResult, CreateTriggerOutput, error) {
+		wc, err := resolveWorkspace(ctx, input.AccountID, input.ContainerID, input.WorkspaceID)
+		if err != nil {
+			return nil, CreateTriggerOutput{}, err
+		}
+		if input.TriggerID, err = wc.resolveTriggerID(ctx, input.TriggerID, input.TriggerName); err != nil {
+			return nil, CreateTriggerOutput{}, err
+		}
+		overrides, err := gtm.ParametersInput("parameterOverrides", input.ParameterOverrides, "", "")
+		if err != nil {
+			return nil, CreateTriggerOutput{}, err
+		}
+
+		trigger, err := wc.Client.DuplicateTrigger(ctx, wc.AccountID, wc.ContainerID, wc.WorkspaceID, input.TriggerID, input.Name, overrides)
+		if err != nil {
+			return nil, CreateTriggerOutput{}, err
+		}
+		return nil, CreateTriggerOutput{
+			Success: true,
+			Trigger: *trigger,
+			Message: fmt.Sprintf("Trigger %s duplicated as %s", input.TriggerID, trigger.TriggerID),
+		}, nil
+	}
+
+	addTool(r, &mcp.Tool{
+		Name:        "duplicate_trigger",
+		Description: "Copy an existing trigger within the same workspace under a new name, keeping every field (filters, auto-event and custom event filters, wait/check settings, folder) and optionally overriding top-level parameters by key.",
+	}, handler)
+}
+
+func registerDuplicateVariable(r *toolRegistry) {
+	handler := func(ctx context.Context, req *mcp.CallToolRequest, input DuplicateVariableInput) (*mcp.CallToolResult, CreateVariableOutput, error) {
+		wc, err := resolveWorkspace(ctx, input.AccountID, input.ContainerID, input.WorkspaceID)
+		if err != nil {
+			return nil, CreateVariableOutput{}, err
+		}
+		if input.VariableID, err = wc.resolveVariableID(ctx, input.VariableID, input.VariableName); err != nil {
+			return nil, CreateVariableOutput{}, err
+		}
+		overrides, err := gtm.ParametersInput("parameterOverrides", input.ParameterOverrides, "", "")
+		if err != nil {
+			return nil, CreateVariableOutput{}, err
+		}
+
+		variable, err := wc.Client.DuplicateVariable(ctx, wc.AccountID, wc.ContainerID, wc.WorkspaceID, input.VariableID, input.Name, overrides)
+		if err != nil {
+			return nil, CreateVariableOutput{}, err
+		}
+		return nil, CreateVariableOutput{
+			Success:  true,
+			Variable: *variable,
+			Message:  fmt.Sprintf("Variable %s duplicated as %s", input.VariableID, variable.VariableID),
+		}, nil
+	}
+
+	addTool(r, &mcp.Tool{
+		Name:        "duplicate_variable",
+		Description: "Copy an existing variable within the same workspace under a new name, keeping every field (format value, folder and all parameters) and optionally overriding top-level parameters by key, e.g. a constant's value.",
+	}, handler)
+}
@@ -0,0 +1,124 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+
+	"github.com/paolobietolini/gtm-mcp-server/gtm"
+	"github.com/paolobietolini/gtm-mcp-server/middleware"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"google.golang.org/api/googleapi"
+)
+
+// Error codes of the tool error envelope.
+const (
+	ErrorCodeNotFound         = "NOT_FOUND"
+	ErrorCodeConflict         = "CONFLICT"
+	ErrorCodeRateLimit        = "RATE_LIMIT"
+	ErrorCodePermissionDenied = "PERMISSION_DENIED"
+	ErrorCodeMissingScope     = "MISSING_SCOPE"
+	ErrorCodeReadOnly         = "READ_ONLY"
+	ErrorCodeInvalidParameter = "INVALID_PARAMETER"
+	ErrorCodeTimeout          = "TIMEOUT"
+	ErrorCodeServerError      = "SERVER_ERROR"
+	ErrorCodeApprovalRequired = "APPROVAL_REQUIRED"
+	ErrorCodeRejected         = "REJECTED"
+	ErrorCodeUnknown          = "UNKNOWN"
+)
+
+// ToolError is the machine-readable envelope returned as the content of a
+// failed tool call, so clients can branch on Code instead of parsing Message.
+type ToolError struct {
+	Code       string `json:"code"`
+	Message    string `json:"message"`
+	Field      string `json:"field,omitempty"`      // offending input field, when known
+	Reason     string `json:"reason,omitempty"`     // Google API error reason, e.g. "notFound"
+	HTTPStatus int    `json:"httpStatus,omitempty"` // Google API response status
+	Suggestion string `json:"suggestion,omitempty"`
+	ApprovalID string `json:"approvalId,omitempty"` // pending change, for APPROVAL_REQUIRED and REJECTED
+	RequestID  string `json:"requestId,omitempty"`  // ID of the MCP request in the server logs
+}
+
+// Error renders the envelope as the JSON text of the tool result.
+func (e *ToolError) Error() string {
+	data, _ := json.Marshal(struct {
+		Error *ToolError `json:"error"`
+	}{e})
+	return string(data)
+}
+
+// errorSuggestions tell the model how to recover from each error code.
+var errorSuggestions = map[string]string{
+	ErrorCodeNotFound:         "Check the IDs; list the parent resource to find valid ones.",
+	ErrorCodeConflict:         "The entity changed since it was read. Get it again and retry with its current state.",
+	ErrorCodeRateLimit:        "Wait before retrying, and prefer fewer, broader requests such as get_workspace_overview.",
+	ErrorCodePermissionDenied: "The Google account lacks access to this GTM account or container; ask a GTM administrator for permission.",
+	ErrorCodeMissingScope:     "Reconnect and grant every requested permission, or ask the server administrator to request the scope.",
+	ErrorCodeReadOnly:         "Use list, get and search tools, or ask for write access.",
+	ErrorCodeInvalidParameter: "Fix the input and retry; the get_*_templates tools show valid parameter formats.",
+	ErrorCodeTimeout:          "Retry the call; narrow it if it keeps timing out.",
+	ErrorCodeServerError:      "Google's servers failed even after retries; try again in a few minutes.",
+	ErrorCodeApprovalRequired: "Tell the user the change awaits approval; an approver runs it with approve_change or discards it with reject_change.",
+	ErrorCodeRejected:         "Do not retry the same change; ask the user how to proceed.",
+}
+
+// classifyError builds the envelope of a tool error.
+func classifyError(err error) *ToolError {
+	var te *ToolError
+	if errors.As(err, &te) {
+		return te
+	}
+
+	e := &ToolError{Code: ErrorCodeUnknown, Message: err.Error()}
+	switch {
+	case errors.Is(err, gtm.ErrNotFound):
+		e.Code = ErrorCodeNotFound
+	case errors.Is(err, gtm.ErrConflict):
+		e.Code = ErrorCodeConflict
+	case errors.Is(err, gtm.ErrRateLimit):
+		e.Code = ErrorCodeRateLimit
+	case errors.Is(err, gtm.ErrMissingScope):
+		e.Code = ErrorCodeMissingScope
+	case errors.Is(err, gtm.ErrPermission):
+		e.Code = ErrorCodePermissionDenied
+	case errors.Is(err, gtm.ErrReadOnly):
+		e.Code = ErrorCodeReadOnly
+	case errors.Is(err, gtm.ErrInvalidRequest):
+		e.Code = ErrorCodeInvalidParameter
+	case errors.Is(err, gtm.ErrServerError):
+		e.Code = ErrorCodeServerError
+	case errors.Is(err, context.DeadlineExceeded):
+		e.Code = ErrorCodeTimeout
+	}
+
+	var fieldErr *gtm.FieldError
+	if errors.As(err, &fieldErr) {
+		e.Code = ErrorCodeInvalidParameter
+		e.Field = fieldErr.Field
+	}
+	var apiErr *googleapi.Error
+	if errors.As(err, &apiErr) {
+		e.HTTPStatus = apiErr.Code
+		if len(apiErr.Errors) > 0 {
+			e.Reason = apiErr.Errors[0].Reason
+		}
+	}
+	e.Suggestion = errorSuggestions[e.Code]
+	return e
+}
+
+// withErrorEnvelope returns tool errors as a JSON ToolError envelope, with
+// the ID of the request so the failure can be found in the server logs.
+func withErrorEnvelope[In, Out any](handler mcp.ToolHandlerFor[In, Out]) mcp.ToolHandlerFor[In, Out] {
+	return func(ctx context.Context, req *mcp.CallToolRequest, input In) (*mcp.CallToolResult, Out, error) {
+		res, out, err := handler(ctx, req, input)
+		if err != nil {
+			e := *classifyError(err)
+			e.RequestID = middleware.RequestIDFromContext(ctx)
+			err = &e
+		}
+		return res, out, err
+	}
+}
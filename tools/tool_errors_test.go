@@ -0,0 +1,93 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/paolobietolini/gtm-mcp-server/gtm"
+	"github.com/paolobietolini/gtm-mcp-server/middleware"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"google.golang.org/api/googleapi"
+)
+
+func TestClassifyError(t *testing.T) {
+	tests := []struct {
+		name       string
+		err        error
+		want       ToolError
+		suggestion bool
+	}{
+		{
+			name: "google not found",
+			err: gtm.MapGoogleError(&googleapi.Error{Code: 404, Message: "Not found",
+				Errors: []googleapi.ErrorItem{{Reason: "notFound"}}}),
+			want: ToolError{Code: ErrorCodeNotFound, Message: "resource not found: Not found", Reason: "notFound", HTTPStatus: 404},
+		},
+		{
+			name: "wrapped conflict",
+			err:  fmt.Errorf("failed to update tag: %w", gtm.MapGoogleError(&googleapi.Error{Code: 409, Message: "Fingerprint mismatch"})),
+			want: ToolError{Code: ErrorCodeConflict, Message: "failed to update tag: resource conflict - fingerprint mismatch: Fingerprint mismatch", HTTPStatus: 409},
+		},
+		{
+			name: "server error",
+			err:  gtm.MapGoogleError(&googleapi.Error{Code: 500, Message: "Internal error"}),
+			want: ToolError{Code: ErrorCodeServerError, Message: "Google backend error (HTTP 500): Internal error", HTTPStatus: 500},
+		},
+		{
+			name: "field",
+			err:  gtm.InvalidField("pageSize", "pageSize must be between 1 and 500"),
+			want: ToolError{Code: ErrorCodeInvalidParameter, Message: "pageSize must be between 1 and 500", Field: "pageSize"},
+		},
+		{
+			name: "timeout",
+			err:  fmt.Errorf("listing tags: %w", context.DeadlineExceeded),
+			want: ToolError{Code: ErrorCodeTimeout, Message: "listing tags: context deadline exceeded"},
+		},
+		{
+			name: "unclassified",
+			err:  errors.New("confirm must be true"),
+			want: ToolError{Code: ErrorCodeUnknown, Message: "confirm must be true"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := classifyError(tt.err)
+			tt.want.Suggestion = errorSuggestions[tt.want.Code]
+			if *got != tt.want {
+				t.Errorf("classifyError() = %+v, want %+v", *got, tt.want)
+			}
+		})
+	}
+}
+
+func TestToolError_JSON(t *testing.T) {
+	err := classifyError(fmt.Errorf("%w: read-only", gtm.ErrReadOnly))
+
+	var envelope struct {
+		Error map[string]any `json:"error"`
+	}
+	if jsonErr := json.Unmarshal([]byte(err.Error()), &envelope); jsonErr != nil {
+		t.Fatalf("error text is not JSON: %v", jsonErr)
+	}
+	if envelope.Error["code"] != ErrorCodeReadOnly || envelope.Error["suggestion"] == "" {
+		t.Errorf("envelope = %v", envelope.Error)
+	}
+	if !errors.Is(gtm.InvalidField("tagId", "tagId is required"), gtm.ErrInvalidRequest) {
+		t.Error("FieldError does not match ErrInvalidRequest")
+	}
+}
+
+func TestWithErrorEnvelope_RequestID(t *testing.T) {
+	handler := withErrorEnvelope(func(ctx context.Context, req *mcp.CallToolRequest, input struct{}) (*mcp.CallToolResult, struct{}, error) {
+		return nil, struct{}{}, fmt.Errorf("%w: tag 5", gtm.ErrNotFound)
+	})
+	_, _, err := handler(middleware.WithRequestID(context.Background(), "abc123"), nil, struct{}{})
+	var te *ToolError
+	if !errors.As(err, &te) || te.Code != ErrorCodeNotFound || te.RequestID != "abc123" {
+		t.Errorf("err = %v", err)
+	}
+}
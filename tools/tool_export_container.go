@@ -0,0 +1,85 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/paolobietolini/gtm-mcp-server/gtm"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// ExportContainerInput is the input for export_container tool.
+type ExportContainerInput struct {
+	AccountID        string `json:"accountId" jsonschema:"description:The GTM account ID"`
+	ContainerID      string `json:"containerId" jsonschema:"description:The GTM container ID"`
+	WorkspaceID      string `json:"workspaceId,omitempty" jsonschema:"description:Export the current state of this workspace (including unpublished changes)"`
+	VersionID        string `json:"versionId,omitempty" jsonschema:"description:Export this container version instead of a workspace. Use 'live' for the published version."`
+	IncludeSensitive bool   `json:"includeSensitive,omitempty" jsonschema:"description:Export parameter values that look like secrets (API keys, tokens, passwords) instead of redacting them. Required for a faithful re-import."`
+}
+
+// ExportContainerOutput is the output for export_container tool.
+type ExportContainerOutput struct {
+	Success    bool             `json:"success"`
+	Source     string           `json:"source"`
+	Counts     gtm.ExportCounts `json:"counts"`
+	ExportJSON string           `json:"exportJson"`
+	Redacted   int              `json:"redactedValues,omitempty"`
+	Message    string           `json:"message"`
+}
+
+func registerExportContainer(r *toolRegistry) {
+	handler := func(ctx context.Context, req *mcp.CallToolRequest, input ExportContainerInput) (*mcp.CallToolResult, ExportContainerOutput, error) {
+		if (input.WorkspaceID == "") == (input.VersionID == "") {
+			return nil, ExportContainerOutput{}, fmt.Errorf("exactly one of workspaceId or versionId is required")
+		}
+
+		cc, err := resolveContainer(ctx, input.AccountID, input.ContainerID)
+		if err != nil {
+			return nil, ExportContainerOutput{}, err
+		}
+
+		var export *gtm.ContainerExport
+		var source string
+		if input.VersionID != "" {
+			export, err = cc.Client.ExportVersion(ctx, cc.AccountID, cc.ContainerID, input.VersionID)
+			source = "version " + input.VersionID
+		} else {
+			export, err = cc.Client.ExportWorkspace(ctx, cc.AccountID, cc.ContainerID, input.WorkspaceID)
+			source = "workspace " + input.WorkspaceID
+		}
+		if err != nil {
+			return nil, ExportContainerOutput{}, err
+		}
+
+		redacted := 0
+		if !input.IncludeSensitive {
+			redacted = gtm.RedactVersion(export.ContainerVersion)
+		}
+
+		data, err := json.MarshalIndent(export, "", "    ")
+		if err != nil {
+			return nil, ExportContainerOutput{}, fmt.Errorf("failed to encode export: %w", err)
+		}
+
+		message := fmt.Sprintf("Exported %s of container %s in GTM UI format", source, input.ContainerID)
+		if redacted > 0 {
+			message += fmt.Sprintf(". %d sensitive values were replaced with %s; use includeSensitive: true for an export that can be re-imported as is", redacted, gtm.RedactedValue)
+		}
+
+		return nil, ExportContainerOutput{
+			Success:    true,
+			Source:     source,
+			Counts:     export.Counts(),
+			ExportJSON: string(data),
+			Redacted:   redacted,
+			Message:    message,
+		}, nil
+	}
+
+	addTool(r, &mcp.Tool{
+		Name:        "export_container",
+		Description: "Export a container in the same JSON format as the GTM UI 'Export Container' feature (exportFormatVersion 2). Provide workspaceId to export a workspace or versionId (or 'live') to export a version. The result can be stored in Git or imported into another container.",
+	}, handler)
+}
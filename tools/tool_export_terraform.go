@@ -0,0 +1,92 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/paolobietolini/gtm-mcp-server/gtm"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// ExportTerraformInput is the input for export_terraform tool.
+type ExportTerraformInput struct {
+	AccountID        string `json:"accountId" jsonschema:"description:The GTM account ID"`
+	ContainerID      string `json:"containerId" jsonschema:"description:The GTM container ID"`
+	WorkspaceID      string `json:"workspaceId,omitempty" jsonschema:"description:Export the current state of this workspace (including unpublished changes)"`
+	VersionID        string `json:"versionId,omitempty" jsonschema:"description:Export this container version instead of a workspace. Use 'live' for the published version."`
+	Format           string `json:"format,omitempty" jsonschema:"description:Output format: hcl (default) or json (Terraform JSON syntax for .tf.json files)"`
+	Provider         string `json:"provider,omitempty" jsonschema:"description:Provider source for required_providers (optional, defaults to mirefly/gtm)"`
+	IncludeSensitive bool   `json:"includeSensitive,omitempty" jsonschema:"description:Export parameter values that look like secrets (API keys, tokens, passwords) instead of redacting them"`
+}
+
+// ExportTerraformOutput is the output for export_terraform tool.
+type ExportTerraformOutput struct {
+	Success  bool             `json:"success"`
+	Source   string           `json:"source"`
+	Format   string           `json:"format"`
+	Counts   gtm.ExportCounts `json:"counts"`
+	Config   string           `json:"config"`
+	Redacted int              `json:"redactedValues,omitempty"`
+	Message  string           `json:"message"`
+}
+
+func registerExportTerraform(r *toolRegistry) {
+	handler := func(ctx context.Context, req *mcp.CallToolRequest, input ExportTerraformInput) (*mcp.CallToolResult, ExportTerraformOutput, error) {
+		if (input.WorkspaceID == "") == (input.VersionID == "") {
+			return nil, ExportTerraformOutput{}, fmt.Errorf("exactly one of workspaceId or versionId is required")
+		}
+		format := input.Format
+		if format == "" {
+			format = gtm.TerraformHCL
+		}
+
+		cc, err := resolveContainer(ctx, input.AccountID, input.ContainerID)
+		if err != nil {
+			return nil, ExportTerraformOutput{}, err
+		}
+
+		var export *gtm.ContainerExport
+		var source string
+		if input.VersionID != "" {
+			export, err = cc.Client.ExportVersion(ctx, cc.AccountID, cc.ContainerID, input.VersionID)
+			source = "version " + input.VersionID
+		} else {
+			export, err = cc.Client.ExportWorkspace(ctx, cc.AccountID, cc.ContainerID, input.WorkspaceID)
+			source = "workspace " + input.WorkspaceID
+		}
+		if err != nil {
+			return nil, ExportTerraformOutput{}, err
+		}
+
+		redacted := 0
+		if !input.IncludeSensitive {
+			redacted = gtm.RedactVersion(export.ContainerVersion)
+		}
+
+		config, err := gtm.RenderTerraform(export.ContainerVersion, gtm.TerraformOptions{Format: format, Provider: input.Provider})
+		if err != nil {
+			return nil, ExportTerraformOutput{}, err
+		}
+
+		message := fmt.Sprintf("Rendered %s of container %s as Terraform %s", source, input.ContainerID, format)
+		if redacted > 0 {
+			message += fmt.Sprintf(". %d sensitive values were replaced with %s; supply them via Terraform variables or use includeSensitive: true", redacted, gtm.RedactedValue)
+		}
+
+		return nil, ExportTerraformOutput{
+			Success:  true,
+			Source:   source,
+			Format:   format,
+			Counts:   export.Counts(),
+			Config:   config,
+			Redacted: redacted,
+			Message:  message,
+		}, nil
+	}
+
+	addTool(r, &mcp.Tool{
+		Name:        "export_terraform",
+		Description: "Render the tags, triggers and variables of a workspace or version as Terraform configuration (gtm_tag, gtm_trigger, gtm_variable resources) in HCL or Terraform JSON. Tag trigger IDs become resource references. Provide workspaceId, or versionId (or 'live').",
+	}, handler)
+}
@@ -0,0 +1,64 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/paolobietolini/gtm-mcp-server/gtm"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// FindOrphansInput is the input for find_orphans tool.
+type FindOrphansInput struct {
+	AccountID   string `json:"accountId" jsonschema:"description:The GTM account ID"`
+	ContainerID string `json:"containerId" jsonschema:"description:The GTM container ID"`
+	WorkspaceID string `json:"workspaceId" jsonschema:"description:The GTM workspace ID"`
+	PausedDays  int    `json:"pausedDays,omitempty" jsonschema:"description:Report paused tags not modified for at least this many days (optional, defaults to 30)"`
+}
+
+// FindOrphansOutput is the output for find_orphans tool.
+type FindOrphansOutput struct {
+	Success bool         `json:"success"`
+	Orphans *gtm.Orphans `json:"orphans"`
+	Total   int          `json:"total"`
+	Message string       `json:"message"`
+}
+
+func registerFindOrphans(r *toolRegistry) {
+	handler := func(ctx context.Context, req *mcp.CallToolRequest, input FindOrphansInput) (*mcp.CallToolResult, FindOrphansOutput, error) {
+		pausedDays := input.PausedDays
+		if pausedDays == 0 {
+			pausedDays = gtm.DefaultPausedTagDays
+		}
+		if pausedDays < 0 {
+			return nil, FindOrphansOutput{}, fmt.Errorf("pausedDays must not be negative")
+		}
+
+		wc, err := resolveWorkspace(ctx, input.AccountID, input.ContainerID, input.WorkspaceID)
+		if err != nil {
+			return nil, FindOrphansOutput{}, err
+		}
+
+		version, err := wc.Client.SnapshotWorkspace(ctx, wc.AccountID, wc.ContainerID, wc.WorkspaceID)
+		if err != nil {
+			return nil, FindOrphansOutput{}, err
+		}
+
+		orphans := gtm.FindOrphans(version, time.Now(), pausedDays)
+
+		return nil, FindOrphansOutput{
+			Success: true,
+			Orphans: orphans,
+			Total:   orphans.Total(),
+			Message: fmt.Sprintf("Found %d unused triggers, %d unreferenced variables and %d tags paused for %d+ days. Delete them with the delete tools or bulk_delete_entities.",
+				len(orphans.Triggers), len(orphans.Variables), len(orphans.PausedTags), pausedDays),
+		}, nil
+	}
+
+	addTool(r, &mcp.Tool{
+		Name:        "find_orphans",
+		Description: "Find unused entities in a workspace: triggers not attached to any tag (or trigger group), variables not referenced by any tag, trigger or variable, and tags paused and unmodified for pausedDays (default 30). Returns IDs and paths for direct deletion.",
+	}, handler)
+}
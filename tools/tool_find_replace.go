@@ -0,0 +1,86 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/paolobietolini/gtm-mcp-server/gtm"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// FindReplaceInput is the input for find_replace tool.
+type FindReplaceInput struct {
+	AccountID   string   `json:"accountId" jsonschema:"description:The GTM account ID"`
+	ContainerID string   `json:"containerId" jsonschema:"description:The GTM container ID"`
+	WorkspaceID string   `json:"workspaceId" jsonschema:"description:The GTM workspace ID"`
+	Find        string   `json:"find" jsonschema:"description:Text to search for in parameter values (e.g. an old measurement ID)"`
+	Replace     string   `json:"replace" jsonschema:"description:Replacement text. With regex: true, $1 etc. refer to capture groups."`
+	Regex       bool     `json:"regex,omitempty" jsonschema:"description:Treat find as a regular expression"`
+	EntityTypes []string `json:"entityTypes,omitempty" jsonschema:"description:Entity kinds to search: tag, trigger, variable (optional, defaults to all three)"`
+	Confirm     bool     `json:"confirm" jsonschema:"description:Must be true to write changes. When false the affected entities are listed without modifying anything."`
+}
+
+// FindReplaceOutput is the output for find_replace tool.
+type FindReplaceOutput struct {
+	Success  bool                   `json:"success"`
+	DryRun   bool                   `json:"dryRun,omitempty"`
+	Matched  int                    `json:"matched"`
+	Updated  int                    `json:"updated"`
+	Entities []gtm.FindReplaceMatch `json:"entities"`
+	Message  string                 `json:"message"`
+}
+
+func registerFindReplace(r *toolRegistry) {
+	handler := func(ctx context.Context, req *mcp.CallToolRequest, input FindReplaceInput) (*mcp.CallToolResult, FindReplaceOutput, error) {
+		wc, err := resolveWorkspace(ctx, input.AccountID, input.ContainerID, input.WorkspaceID)
+		if err != nil {
+			return nil, FindReplaceOutput{}, err
+		}
+
+		matches, err := wc.Client.FindReplace(ctx, wc.AccountID, wc.ContainerID, wc.WorkspaceID, gtm.FindReplaceOptions{
+			Find:        input.Find,
+			Replace:     input.Replace,
+			Regex:       input.Regex,
+			EntityTypes: input.EntityTypes,
+		}, input.Confirm)
+		if err != nil {
+			return nil, FindReplaceOutput{}, err
+		}
+
+		// Safety guard: only list affected entities until explicitly confirmed
+		if !input.Confirm {
+			return nil, FindReplaceOutput{
+				Success:  false,
+				DryRun:   true,
+				Matched:  len(matches),
+				Entities: matches,
+				Message:  fmt.Sprintf("Dry run: %d entities would change. Review the changes, then set confirm: true to apply them.", len(matches)),
+			}, nil
+		}
+
+		updated := 0
+		for _, m := range matches {
+			if m.Error == "" {
+				updated++
+			}
+		}
+		message := fmt.Sprintf("Updated %d entities", updated)
+		if updated < len(matches) {
+			message = fmt.Sprintf("Updated %d of %d entities; see entities for errors", updated, len(matches))
+		}
+
+		return nil, FindReplaceOutput{
+			Success:  updated == len(matches),
+			Matched:  len(matches),
+			Updated:  updated,
+			Entities: matches,
+			Message:  message,
+		}, nil
+	}
+
+	addTool(r, &mcp.Tool{
+		Name:        "find_replace",
+		Description: "Find and replace a string or regex in the parameter values of all tags, triggers (including filter conditions) and variables in a workspace, e.g. to swap an old GA4 measurement ID. Tag and trigger reference parameters are never changed. Without confirm: true lists affected entities with before/after values only.",
+	}, handler)
+}
@@ -1,8 +1,10 @@
-package gtm
+package tools
 
 import (
 	"context"
 
+	"github.com/paolobietolini/gtm-mcp-server/gtm"
+
 	"github.com/modelcontextprotocol/go-sdk/mcp"
 )
 
@@ -15,7 +17,7 @@ type ListFoldersInput struct {
 
 // ListFoldersOutput is the output for list_folders tool.
 type ListFoldersOutput struct {
-	Folders []Folder `json:"folders"`
+	Folders []gtm.Folder `json:"folders"`
 }
 
 // GetFolderEntitiesInput is the input for get_folder_entities tool.
@@ -28,10 +30,10 @@ type GetFolderEntitiesInput struct {
 
 // GetFolderEntitiesOutput is the output for get_folder_entities tool.
 type GetFolderEntitiesOutput struct {
-	Entities FolderEntities `json:"entities"`
+	Entities gtm.FolderEntities `json:"entities"`
 }
 
-func registerListFolders(server *mcp.Server) {
+func registerListFolders(r *toolRegistry) {
 	handler := func(ctx context.Context, req *mcp.CallToolRequest, input ListFoldersInput) (*mcp.CallToolResult, ListFoldersOutput, error) {
 		wc, err := resolveWorkspace(ctx, input.AccountID, input.ContainerID, input.WorkspaceID)
 		if err != nil {
@@ -46,13 +48,13 @@ func registerListFolders(server *mcp.Server) {
 		return nil, ListFoldersOutput{Folders: folders}, nil
 	}
 
-	mcp.AddTool(server, &mcp.Tool{
+	addTool(r, &mcp.Tool{
 		Name:        "list_folders",
 		Description: "List all folders (trigger groups) in a GTM workspace. Folders help organize tags, triggers, and variables.",
 	}, handler)
 }
 
-func registerGetFolderEntities(server *mcp.Server) {
+func registerGetFolderEntities(r *toolRegistry) {
 	handler := func(ctx context.Context, req *mcp.CallToolRequest, input GetFolderEntitiesInput) (*mcp.CallToolResult, GetFolderEntitiesOutput, error) {
 		wc, err := resolveWorkspace(ctx, input.AccountID, input.ContainerID, input.WorkspaceID)
 		if err != nil {
@@ -67,7 +69,7 @@ func registerGetFolderEntities(server *mcp.Server) {
 		return nil, GetFolderEntitiesOutput{Entities: *entities}, nil
 	}
 
-	mcp.AddTool(server, &mcp.Tool{
+	addTool(r, &mcp.Tool{
 		Name:        "get_folder_entities",
 		Description: "Get the tags, triggers, and variables inside a specific folder.",
 	}, handler)
@@ -0,0 +1,99 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/paolobietolini/gtm-mcp-server/gtm"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// ListGA4PropertiesInput is the input for list_ga4_properties tool.
+type ListGA4PropertiesInput struct {
+	Query string `json:"query,omitempty" jsonschema:"description:Only properties whose name or account name contains this text, ignoring case (optional)"`
+}
+
+// ListGA4PropertiesOutput is the output for list_ga4_properties tool.
+type ListGA4PropertiesOutput struct {
+	Properties []gtm.GA4Property `json:"properties"`
+	Count      int               `json:"count"`
+}
+
+// ListGA4DataStreamsInput is the input for list_ga4_data_streams tool.
+type ListGA4DataStreamsInput struct {
+	PropertyID   string `json:"propertyId,omitempty" jsonschema:"description:The GA4 property ID (or use propertyName)"`
+	PropertyName string `json:"propertyName,omitempty" jsonschema:"description:The exact GA4 property name, instead of propertyId"`
+}
+
+// ListGA4DataStreamsOutput is the output for list_ga4_data_streams tool.
+type ListGA4DataStreamsOutput struct {
+	PropertyID string              `json:"propertyId"`
+	Streams    []gtm.GA4DataStream `json:"streams"`
+	Message    string              `json:"message"`
+}
+
+func registerListGA4Properties(r *toolRegistry) {
+	handler := func(ctx context.Context, req *mcp.CallToolRequest, input ListGA4PropertiesInput) (*mcp.CallToolResult, ListGA4PropertiesOutput, error) {
+		client, err := getClient(ctx)
+		if err != nil {
+			return nil, ListGA4PropertiesOutput{}, err
+		}
+
+		properties, err := client.ListGA4Properties(ctx, input.Query)
+		if err != nil {
+			return nil, ListGA4PropertiesOutput{}, err
+		}
+		return nil, ListGA4PropertiesOutput{Properties: properties, Count: len(properties)}, nil
+	}
+
+	addTool(r, &mcp.Tool{
+		Name:        "list_ga4_properties",
+		Description: "List the Google Analytics 4 properties the user can access, with their account, optionally filtered by name. Use with list_ga4_data_streams to find the measurement ID (G-...) for GA4 tags instead of asking the user to paste it. Needs the analytics.readonly Google scope.",
+	}, handler)
+}
+
+func registerListGA4DataStreams(r *toolRegistry) {
+	handler := func(ctx context.Context, req *mcp.CallToolRequest, input ListGA4DataStreamsInput) (*mcp.CallToolResult, ListGA4DataStreamsOutput, error) {
+		if (input.PropertyID == "") == (input.PropertyName == "") {
+			return nil, ListGA4DataStreamsOutput{}, gtm.InvalidField("propertyId", "exactly one of propertyId and propertyName is required")
+		}
+		client, err := getClient(ctx)
+		if err != nil {
+			return nil, ListGA4DataStreamsOutput{}, err
+		}
+
+		propertyID := input.PropertyID
+		if propertyID == "" {
+			if propertyID, err = client.ResolveGA4PropertyID(ctx, input.PropertyName); err != nil {
+				return nil, ListGA4DataStreamsOutput{}, err
+			}
+		}
+		streams, err := client.ListGA4DataStreams(ctx, propertyID)
+		if err != nil {
+			return nil, ListGA4DataStreamsOutput{}, err
+		}
+
+		out := ListGA4DataStreamsOutput{PropertyID: propertyID, Streams: streams}
+		web := 0
+		for _, s := range streams {
+			if s.MeasurementID != "" {
+				web++
+			}
+		}
+		switch web {
+		case 0:
+			out.Message = fmt.Sprintf("Property %s has no web data stream, so there is no measurement ID for web GA4 tags.", propertyID)
+		case 1:
+			out.Message = "Use the web stream's measurementId in the Google tag and GA4 event tags."
+		default:
+			out.Message = fmt.Sprintf("Property %s has %d web data streams; pick the one whose defaultUri matches the site the container runs on.", propertyID, web)
+		}
+		return nil, out, nil
+	}
+
+	addTool(r, &mcp.Tool{
+		Name:        "list_ga4_data_streams",
+		Description: "List the data streams of a GA4 property, given by ID or name, with the measurement ID (G-...) and site URL of each web stream. Use it to set the measurement ID of Google tags and GA4 event tags from the property instead of a pasted ID. Needs the analytics.readonly Google scope.",
+	}, handler)
+}
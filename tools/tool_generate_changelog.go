@@ -0,0 +1,80 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/paolobietolini/gtm-mcp-server/gtm"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// GenerateChangelogInput is the input for generate_changelog tool.
+type GenerateChangelogInput struct {
+	AccountID   string `json:"accountId" jsonschema:"description:The GTM account ID"`
+	ContainerID string `json:"containerId" jsonschema:"description:The GTM container ID"`
+	Since       string `json:"since,omitempty" jsonschema:"description:Only versions created at or after this ISO 8601 time or date (e.g. 2026-03-01)"`
+	Until       string `json:"until,omitempty" jsonschema:"description:Only versions created before this ISO 8601 time or date"`
+	Limit       int    `json:"limit,omitempty" jsonschema:"description:Maximum number of versions, newest first (default and max 50)"`
+	Diff        bool   `json:"diff,omitempty" jsonschema:"description:Set to true to list the entities each version added, changed and removed compared with the previous version"`
+	Title       string `json:"title,omitempty" jsonschema:"description:Heading of the Markdown changelog (optional, defaults to 'Changelog for GTM-XXXX')"`
+}
+
+// GenerateChangelogOutput is the output for generate_changelog tool.
+type GenerateChangelogOutput struct {
+	gtm.Changelog
+	Markdown string `json:"markdown"`
+	Message  string `json:"message"`
+}
+
+func registerGenerateChangelog(r *toolRegistry) {
+	handler := func(ctx context.Context, req *mcp.CallToolRequest, input GenerateChangelogInput) (*mcp.CallToolResult, GenerateChangelogOutput, error) {
+		cc, err := resolveContainer(ctx, input.AccountID, input.ContainerID)
+		if err != nil {
+			return nil, GenerateChangelogOutput{}, err
+		}
+
+		opts := gtm.ChangelogOptions{Limit: input.Limit, Diff: input.Diff}
+		for _, bound := range []struct {
+			field, value string
+			t            *time.Time
+		}{{"since", input.Since, &opts.Since}, {"until", input.Until, &opts.Until}} {
+			ms, err := gtm.ParseScheduleTime(bound.value, "")
+			if err != nil {
+				return nil, GenerateChangelogOutput{}, gtm.InvalidField(bound.field, "%v", err)
+			}
+			if ms != 0 {
+				*bound.t = time.UnixMilli(ms).UTC()
+			}
+		}
+		if !opts.Since.IsZero() && !opts.Until.IsZero() && !opts.Until.After(opts.Since) {
+			return nil, GenerateChangelogOutput{}, gtm.InvalidField("until", "must be after since")
+		}
+
+		log, err := cc.Client.GenerateChangelog(ctx, cc.AccountID, cc.ContainerID, opts)
+		if err != nil {
+			return nil, GenerateChangelogOutput{}, err
+		}
+
+		title := input.Title
+		if title == "" {
+			title = "Changelog for container " + cc.ContainerID
+			if container, err := cc.Client.GetContainer(ctx, gtm.BuildContainerPath(cc.AccountID, cc.ContainerID)); err == nil && container.PublicID != "" {
+				title = "Changelog for " + container.PublicID
+			}
+		}
+
+		out := GenerateChangelogOutput{Changelog: *log, Markdown: log.Markdown(title)}
+		out.Message = fmt.Sprintf("%d versions in range.", len(log.Versions))
+		if log.Truncated {
+			out.Message += " Older versions were left out; narrow the range with since and until to see them."
+		}
+		return nil, out, nil
+	}
+
+	addTool(r, &mcp.Tool{
+		Name:        "generate_changelog",
+		Description: "Generate a Markdown changelog of container versions created in a date range, newest first, with each version's name and notes. With diff: true, also lists the tags, triggers, variables and other entities each version added, changed and removed. Suited for stakeholder reporting; fetches one version per entry, so keep ranges short on busy containers.",
+	}, handler)
+}
@@ -0,0 +1,106 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/paolobietolini/gtm-mcp-server/gtm"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	tagmanager "google.golang.org/api/tagmanager/v2"
+)
+
+// defaultMapDepth is how many hops around an entity a focused container map
+// includes: enough for tag, trigger and the variables the trigger uses.
+const defaultMapDepth = 2
+
+// GenerateContainerMapInput is the input for generate_container_map tool.
+type GenerateContainerMapInput struct {
+	AccountID   string `json:"accountId" jsonschema:"description:The GTM account ID"`
+	ContainerID string `json:"containerId" jsonschema:"description:The GTM container ID"`
+	WorkspaceID string `json:"workspaceId,omitempty" jsonschema:"description:Workspace to map (optional, mutually exclusive with versionId)"`
+	VersionID   string `json:"versionId,omitempty" jsonschema:"description:Version to map, or live (optional, defaults to live when no workspaceId is given)"`
+	Format      string `json:"format,omitempty" jsonschema:"description:Output format: mermaid (default) or dot"`
+	EntityType  string `json:"entityType,omitempty" jsonschema:"description:Only map the neighborhood of one entity: tag, trigger or variable (optional, with entityId or entityName)"`
+	EntityID    string `json:"entityId,omitempty" jsonschema:"description:ID of the entity to center the map on"`
+	EntityName  string `json:"entityName,omitempty" jsonschema:"description:Exact name of the entity to center the map on, instead of entityId"`
+	Depth       int    `json:"depth,omitempty" jsonschema:"description:How many dependency hops around the entity to include (default 2)"`
+}
+
+// GenerateContainerMapOutput is the output for generate_container_map tool.
+type GenerateContainerMapOutput struct {
+	Success bool   `json:"success"`
+	Format  string `json:"format"`
+	Diagram string `json:"diagram"`
+	Nodes   int    `json:"nodes"`
+	Edges   int    `json:"edges"`
+	Message string `json:"message"`
+}
+
+func registerGenerateContainerMap(r *toolRegistry) {
+	handler := func(ctx context.Context, req *mcp.CallToolRequest, input GenerateContainerMapInput) (*mcp.CallToolResult, GenerateContainerMapOutput, error) {
+		format := input.Format
+		if format == "" {
+			format = "mermaid"
+		}
+		if format != "mermaid" && format != "dot" {
+			return nil, GenerateContainerMapOutput{}, fmt.Errorf("invalid format '%s' (valid values: mermaid, dot)", input.Format)
+		}
+		if input.WorkspaceID != "" && input.VersionID != "" {
+			return nil, GenerateContainerMapOutput{}, fmt.Errorf("workspaceId and versionId are mutually exclusive")
+		}
+
+		cc, err := resolveContainer(ctx, input.AccountID, input.ContainerID)
+		if err != nil {
+			return nil, GenerateContainerMapOutput{}, err
+		}
+
+		var version *tagmanager.ContainerVersion
+		source := "workspace " + input.WorkspaceID
+		if input.WorkspaceID != "" {
+			version, err = cc.Client.SnapshotWorkspace(ctx, cc.AccountID, cc.ContainerID, input.WorkspaceID)
+		} else {
+			versionID := input.VersionID
+			if versionID == "" {
+				versionID = "live"
+			}
+			source = "version " + versionID
+			version, err = cc.Client.GetVersion(ctx, cc.AccountID, cc.ContainerID, versionID)
+		}
+		if err != nil {
+			return nil, GenerateContainerMapOutput{}, err
+		}
+
+		graph := gtm.BuildDependencyGraph(version)
+		if input.EntityType != "" {
+			id, err := gtm.FindEntity(version, input.EntityType, input.EntityID, input.EntityName)
+			if err != nil {
+				return nil, GenerateContainerMapOutput{}, err
+			}
+			depth := input.Depth
+			if depth <= 0 {
+				depth = defaultMapDepth
+			}
+			graph = graph.Neighborhood(gtm.GraphNodeID(input.EntityType, id), depth)
+			source = fmt.Sprintf("%s around %s %s", source, input.EntityType, id)
+		}
+		diagram := graph.Mermaid()
+		if format == "dot" {
+			diagram = graph.DOT()
+		}
+
+		return nil, GenerateContainerMapOutput{
+			Success: true,
+			Format:  format,
+			Diagram: diagram,
+			Nodes:   len(graph.Nodes),
+			Edges:   len(graph.Edges),
+			Message: fmt.Sprintf("Container map of %s: %d entities, %d dependencies", source, len(graph.Nodes), len(graph.Edges)),
+		}, nil
+	}
+
+	addTool(r, &mcp.Tool{
+		Name:        "generate_container_map",
+		Description: "Render the tag/trigger/variable dependency graph of a workspace or version as Mermaid (default) or Graphviz DOT text. Shows which triggers fire or block each tag, setup/teardown tags, trigger group members and which variables each entity references. Set entityType with entityId or entityName to map only the entities within depth hops of one entity, which keeps diagrams of large containers readable. Show the diagram to users whose client renders Mermaid; use it for documentation and architecture diagrams.",
+	}, handler)
+}
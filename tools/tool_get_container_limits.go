@@ -0,0 +1,61 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/paolobietolini/gtm-mcp-server/gtm"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// GetContainerLimitsInput is the input for get_container_limits tool.
+type GetContainerLimitsInput struct {
+	AccountID     string `json:"accountId" jsonschema:"description:The GTM account ID"`
+	ContainerID   string `json:"containerId" jsonschema:"description:The GTM container ID"`
+	VersionID     string `json:"versionId,omitempty" jsonschema:"description:Version to estimate the container size from (optional, defaults to the latest version; 'live' for the published one)"`
+	TagManager360 bool   `json:"tagManager360,omitempty" jsonschema:"description:Set to true for Tag Manager 360 containers, which have no workspace limit"`
+}
+
+// GetContainerLimitsOutput is the output for get_container_limits tool.
+type GetContainerLimitsOutput struct {
+	gtm.ContainerLimitsReport
+	Message string `json:"message"`
+}
+
+func registerGetContainerLimits(r *toolRegistry) {
+	handler := func(ctx context.Context, req *mcp.CallToolRequest, input GetContainerLimitsInput) (*mcp.CallToolResult, GetContainerLimitsOutput, error) {
+		cc, err := resolveContainer(ctx, input.AccountID, input.ContainerID)
+		if err != nil {
+			return nil, GetContainerLimitsOutput{}, err
+		}
+
+		report, err := cc.Client.GetContainerLimits(ctx, cc.AccountID, cc.ContainerID, input.VersionID, input.TagManager360)
+		if err != nil {
+			return nil, GetContainerLimitsOutput{}, err
+		}
+
+		var near []string
+		for _, l := range report.Limits {
+			if l.Status == gtm.LimitApproaching || l.Status == gtm.LimitReached {
+				near = append(near, fmt.Sprintf("%s %d%% (%s)", l.Resource, l.Percent, l.Status))
+			}
+		}
+		out := GetContainerLimitsOutput{ContainerLimitsReport: *report}
+		switch {
+		case len(near) > 0:
+			out.Message = "Close to GTM limits: " + strings.Join(near, ", ") + ". Version or delete workspaces, and trim the largest entities, before creating more."
+		case report.VersionID == "":
+			out.Message = "Within GTM limits. The container has no versions yet, so its size was not estimated."
+		default:
+			out.Message = "Within GTM limits."
+		}
+		return nil, out, nil
+	}
+
+	addTool(r, &mcp.Tool{
+		Name:        "get_container_limits",
+		Description: "Report a container's usage against Tag Manager limits: workspaces (3 per standard container, Default Workspace included) and container size (200 KB, estimated from the latest version with its largest entities), plus tag, trigger, variable and custom template counts. Each limit is ok, approaching (80% or more) or reached. Check before creating workspaces or large changes to avoid opaque API errors.",
+	}, handler)
+}
@@ -0,0 +1,45 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// GetContinuationInput is the input for get_continuation tool.
+type GetContinuationInput struct {
+	Token string `json:"token" jsonschema:"description:The continuation token from a truncated tool result"`
+}
+
+// GetContinuationOutput is the output for get_continuation tool.
+type GetContinuationOutput struct {
+	Chunk      string `json:"chunk"`
+	NextToken  string `json:"nextToken,omitempty"`
+	TotalBytes int    `json:"totalBytes"`
+}
+
+func registerGetContinuation(r *toolRegistry) {
+	handler := func(ctx context.Context, req *mcp.CallToolRequest, input GetContinuationInput) (*mcp.CallToolResult, GetContinuationOutput, error) {
+		if r.outputs == nil {
+			return nil, GetContinuationOutput{}, fmt.Errorf("tool results are not truncated on this server")
+		}
+
+		chunk, next, total, err := r.outputs.next(userKey(ctx), input.Token)
+		if err != nil {
+			return nil, GetContinuationOutput{}, err
+		}
+
+		// Return the chunk as plain text too, so it reads as a continuation
+		// of the previous one rather than an escaped JSON string
+		res := &mcp.CallToolResult{Content: []mcp.Content{&mcp.TextContent{
+			Text: chunk + "\n\n" + continuationNote(total, next),
+		}}}
+		return res, GetContinuationOutput{Chunk: chunk, NextToken: next, TotalBytes: total}, nil
+	}
+
+	addTool(r, &mcp.Tool{
+		Name:        "get_continuation",
+		Description: "Fetch the next chunk of a tool result that was truncated for size. Pass the token from the truncated result; each chunk ends with the token of the following one. Concatenate the chunks to get the full JSON output.",
+	}, handler)
+}
@@ -0,0 +1,74 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/paolobietolini/gtm-mcp-server/gtm"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// GetDependenciesInput is the input for get_dependencies tool.
+type GetDependenciesInput struct {
+	AccountID   string `json:"accountId" jsonschema:"description:The GTM account ID"`
+	ContainerID string `json:"containerId" jsonschema:"description:The GTM container ID"`
+	WorkspaceID string `json:"workspaceId" jsonschema:"description:The GTM workspace ID"`
+	EntityType  string `json:"entityType" jsonschema:"description:Entity type: tag, trigger or variable"`
+	EntityID    string `json:"entityId,omitempty" jsonschema:"description:The entity ID (or use entityName)"`
+	EntityName  string `json:"entityName,omitempty" jsonschema:"description:The exact entity name, instead of entityId"`
+}
+
+// GetDependenciesOutput is the output for get_dependencies tool.
+type GetDependenciesOutput struct {
+	Success      bool                    `json:"success"`
+	Dependencies *gtm.EntityDependencies `json:"dependencies"`
+	Message      string                  `json:"message"`
+}
+
+func registerGetDependencies(r *toolRegistry) {
+	handler := func(ctx context.Context, req *mcp.CallToolRequest, input GetDependenciesInput) (*mcp.CallToolResult, GetDependenciesOutput, error) {
+		if _, err := gtm.EntityKinds([]string{input.EntityType}); err != nil {
+			return nil, GetDependenciesOutput{}, err
+		}
+		if (input.EntityID == "") == (input.EntityName == "") {
+			return nil, GetDependenciesOutput{}, fmt.Errorf("exactly one of entityId or entityName is required")
+		}
+
+		wc, err := resolveWorkspace(ctx, input.AccountID, input.ContainerID, input.WorkspaceID)
+		if err != nil {
+			return nil, GetDependenciesOutput{}, err
+		}
+
+		version, err := wc.Client.SnapshotWorkspace(ctx, wc.AccountID, wc.ContainerID, wc.WorkspaceID)
+		if err != nil {
+			return nil, GetDependenciesOutput{}, err
+		}
+
+		id, err := gtm.FindEntity(version, input.EntityType, input.EntityID, input.EntityName)
+		if err != nil {
+			return nil, GetDependenciesOutput{}, err
+		}
+
+		deps := gtm.DependenciesOf(version, input.EntityType, id)
+
+		message := fmt.Sprintf("%s '%s' depends on %d entities and is used by %d", input.EntityType, deps.Entity.Name, len(deps.DependsOn), len(deps.UsedBy))
+		if len(deps.UsedBy) == 0 {
+			message += "; nothing references it, so it can be deleted without breaking other entities"
+		}
+		if len(deps.Unresolved) > 0 {
+			message += fmt.Sprintf(". %d references do not resolve to anything in the workspace", len(deps.Unresolved))
+		}
+
+		return nil, GetDependenciesOutput{
+			Success:      true,
+			Dependencies: deps,
+			Message:      message,
+		}, nil
+	}
+
+	addTool(r, &mcp.Tool{
+		Name:        "get_dependencies",
+		Description: "Show what a tag, trigger or variable references ({{Variable}} usages, firing/blocking triggers, setup/teardown tags, trigger group members) and what references it. Use before deleting or changing an entity to assess impact.",
+	}, handler)
+}
@@ -0,0 +1,63 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/paolobietolini/gtm-mcp-server/gtm"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// GetPreviewLinkInput is the input for get_preview_link tool.
+type GetPreviewLinkInput struct {
+	AccountID   string `json:"accountId" jsonschema:"description:The GTM account ID"`
+	ContainerID string `json:"containerId" jsonschema:"description:The GTM container ID"`
+	WorkspaceID string `json:"workspaceId,omitempty" jsonschema:"description:The GTM workspace ID to preview (required unless environment is given)"`
+	Environment string `json:"environment,omitempty" jsonschema:"description:Name of an environment to preview instead of a workspace, e.g. a user environment such as staging, or Live or Latest"`
+	URL         string `json:"url,omitempty" jsonschema:"description:The site URL to open in debug mode (optional when the environment has a default URL)"`
+}
+
+// GetPreviewLinkOutput is the output for get_preview_link tool.
+type GetPreviewLinkOutput struct {
+	Preview *gtm.PreviewLink `json:"preview"`
+	Message string           `json:"message"`
+}
+
+func registerGetPreviewLink(r *toolRegistry) {
+	handler := func(ctx context.Context, req *mcp.CallToolRequest, input GetPreviewLinkInput) (*mcp.CallToolResult, GetPreviewLinkOutput, error) {
+		var client *gtm.Client
+		if input.Environment == "" {
+			wc, err := resolveWorkspace(ctx, input.AccountID, input.ContainerID, input.WorkspaceID)
+			if err != nil {
+				return nil, GetPreviewLinkOutput{}, err
+			}
+			client = wc.Client
+		} else {
+			cc, err := resolveContainer(ctx, input.AccountID, input.ContainerID)
+			if err != nil {
+				return nil, GetPreviewLinkOutput{}, err
+			}
+			client = cc.Client
+		}
+
+		link, err := client.GetPreviewLink(ctx, input.AccountID, input.ContainerID, input.WorkspaceID, input.Environment, input.URL)
+		if err != nil {
+			return nil, GetPreviewLinkOutput{}, err
+		}
+
+		target := fmt.Sprintf("workspace %s", link.WorkspaceID)
+		if input.Environment != "" {
+			target = fmt.Sprintf("environment %q", link.EnvironmentName)
+		}
+		return nil, GetPreviewLinkOutput{
+			Preview: link,
+			Message: fmt.Sprintf("Open the Tag Assistant link to debug %s of %s on %s. Tag Assistant opens the site in a new window connected to debug mode; the link stops working if the environment's authorization is reset.", target, link.PublicID, link.SiteURL),
+		}, nil
+	}
+
+	addTool(r, &mcp.Tool{
+		Name:        "get_preview_link",
+		Description: "Build a Tag Assistant preview link that opens a workspace (or a named environment such as staging) in GTM debug mode on a site URL, so QA can start debugging straight away. Also returns the gtm.js query parameters selecting the environment. The workspace must have been previewed once in Tag Manager. Read-only.",
+	}, handler)
+}
@@ -1,9 +1,11 @@
-package gtm
+package tools
 
 import (
 	"context"
 	"fmt"
 
+	"github.com/paolobietolini/gtm-mcp-server/gtm"
+
 	"github.com/modelcontextprotocol/go-sdk/mcp"
 	tagmanager "google.golang.org/api/tagmanager/v2"
 )
@@ -28,7 +30,7 @@ type GetTemplateOutput struct {
 	TagManagerUrl    string                `json:"tagManagerUrl,omitempty"`
 }
 
-func registerGetTemplate(server *mcp.Server) {
+func registerGetTemplate(r *toolRegistry) {
 	handler := func(ctx context.Context, req *mcp.CallToolRequest, input GetTemplateInput) (*mcp.CallToolResult, GetTemplateOutput, error) {
 		wc, err := resolveWorkspace(ctx, input.AccountID, input.ContainerID, input.WorkspaceID)
 		if err != nil {
@@ -41,11 +43,11 @@ func registerGetTemplate(server *mcp.Server) {
 
 		path := fmt.Sprintf("%s/templates/%s", wc.WorkspacePath(), input.TemplateID)
 
-		template, err := retryWithBackoff(ctx, 3, func() (*tagmanager.CustomTemplate, error) {
+		template, err := gtm.RetryWithBackoff(ctx, func() (*tagmanager.CustomTemplate, error) {
 			return wc.Client.Service.Accounts.Containers.Workspaces.Templates.Get(path).Context(ctx).Do()
 		})
 		if err != nil {
-			return nil, GetTemplateOutput{}, mapGoogleError(err)
+			return nil, GetTemplateOutput{}, gtm.MapGoogleError(err)
 		}
 
 		output := GetTemplateOutput{
@@ -73,7 +75,7 @@ func registerGetTemplate(server *mcp.Server) {
 		return nil, output, nil
 	}
 
-	mcp.AddTool(server, &mcp.Tool{
+	addTool(r, &mcp.Tool{
 		Name:        "get_template",
 		Description: "Get a specific custom template by ID. Returns full template details including the template code.",
 	}, handler)
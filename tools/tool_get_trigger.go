@@ -0,0 +1,51 @@
+package tools
+
+import (
+	"context"
+
+	"github.com/paolobietolini/gtm-mcp-server/gtm"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+type GetTriggerInput struct {
+	AccountID        string `json:"accountId" jsonschema:"description:The GTM account ID"`
+	ContainerID      string `json:"containerId" jsonschema:"description:The GTM container ID"`
+	WorkspaceID      string `json:"workspaceId" jsonschema:"description:The GTM workspace ID"`
+	TriggerID        string `json:"triggerId,omitempty" jsonschema:"description:The trigger ID to retrieve (or use triggerName)"`
+	TriggerName      string `json:"triggerName,omitempty" jsonschema:"description:The exact trigger name, instead of triggerId"`
+	IncludeSensitive bool   `json:"includeSensitive,omitempty" jsonschema:"description:Return parameter values that look like secrets (API keys, tokens, passwords) instead of redacting them"`
+}
+type GetTriggerOutput struct {
+	Trigger gtm.Trigger `json:"trigger"`
+}
+
+func registerGetTrigger(r *toolRegistry) {
+	handler := func(ctx context.Context, req *mcp.CallToolRequest, input GetTriggerInput) (*mcp.CallToolResult, GetTriggerOutput, error) {
+		wc, err := resolveWorkspace(ctx, input.AccountID, input.ContainerID, input.WorkspaceID)
+		if err != nil {
+			return nil, GetTriggerOutput{}, err
+		}
+
+		// Look the trigger up by name if no ID was given
+		if input.TriggerID, err = wc.resolveTriggerID(ctx, input.TriggerID, input.TriggerName); err != nil {
+			return nil, GetTriggerOutput{}, err
+		}
+
+		trigger, err := wc.Client.GetTrigger(ctx, wc.AccountID, wc.ContainerID, wc.WorkspaceID, input.TriggerID)
+		if err != nil {
+			return nil, GetTriggerOutput{}, err
+		}
+
+		if !input.IncludeSensitive {
+			gtm.RedactAPIParameters(trigger.Parameter)
+		}
+
+		return nil, GetTriggerOutput{Trigger: *trigger}, nil
+	}
+
+	addTool(r, &mcp.Tool{
+		Name:        "get_trigger",
+		Description: "Get a specific trigger by ID or exact name",
+	}, handler)
+}
@@ -0,0 +1,46 @@
+package tools
+
+import (
+	"context"
+
+	"github.com/paolobietolini/gtm-mcp-server/gtm"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+type GetVariableInput struct {
+	AccountID    string `json:"accountId" jsonschema:"description:The GTM account ID"`
+	ContainerID  string `json:"containerId" jsonschema:"description:The GTM container ID"`
+	WorkspaceID  string `json:"workspaceId" jsonschema:"description:The GTM workspace ID"`
+	VariableID   string `json:"variableId,omitempty" jsonschema:"description:The variable ID to retrieve (or use variableName)"`
+	VariableName string `json:"variableName,omitempty" jsonschema:"description:The exact variable name, instead of variableId"`
+}
+type GetVariableOutput struct {
+	Variable gtm.Variable `json:"variable"`
+}
+
+func registerGetVariable(r *toolRegistry) {
+	handler := func(ctx context.Context, req *mcp.CallToolRequest, input GetVariableInput) (*mcp.CallToolResult, GetVariableOutput, error) {
+		wc, err := resolveWorkspace(ctx, input.AccountID, input.ContainerID, input.WorkspaceID)
+		if err != nil {
+			return nil, GetVariableOutput{}, err
+		}
+
+		// Look the variable up by name if no ID was given
+		if input.VariableID, err = wc.resolveVariableID(ctx, input.VariableID, input.VariableName); err != nil {
+			return nil, GetVariableOutput{}, err
+		}
+
+		variable, err := wc.Client.GetVariable(ctx, wc.AccountID, wc.ContainerID, wc.WorkspaceID, input.VariableID)
+		if err != nil {
+			return nil, GetVariableOutput{}, err
+		}
+
+		return nil, GetVariableOutput{Variable: *variable}, nil
+	}
+
+	addTool(r, &mcp.Tool{
+		Name:        "get_variable",
+		Description: "Get a specific variable by ID or exact name",
+	}, handler)
+}
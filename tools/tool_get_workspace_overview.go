@@ -0,0 +1,42 @@
+package tools
+
+import (
+	"context"
+
+	"github.com/paolobietolini/gtm-mcp-server/gtm"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// GetWorkspaceOverviewInput is the input for get_workspace_overview tool.
+type GetWorkspaceOverviewInput struct {
+	AccountID   string `json:"accountId" jsonschema:"description:The GTM account ID"`
+	ContainerID string `json:"containerId" jsonschema:"description:The GTM container ID"`
+	WorkspaceID string `json:"workspaceId" jsonschema:"description:The GTM workspace ID"`
+}
+
+// GetWorkspaceOverviewOutput is the output for get_workspace_overview tool.
+type GetWorkspaceOverviewOutput struct {
+	Overview gtm.WorkspaceOverview `json:"overview"`
+}
+
+func registerGetWorkspaceOverview(r *toolRegistry) {
+	handler := func(ctx context.Context, req *mcp.CallToolRequest, input GetWorkspaceOverviewInput) (*mcp.CallToolResult, GetWorkspaceOverviewOutput, error) {
+		wc, err := resolveWorkspace(ctx, input.AccountID, input.ContainerID, input.WorkspaceID)
+		if err != nil {
+			return nil, GetWorkspaceOverviewOutput{}, err
+		}
+
+		overview, err := wc.Client.GetWorkspaceOverview(ctx, wc.AccountID, wc.ContainerID, wc.WorkspaceID)
+		if err != nil {
+			return nil, GetWorkspaceOverviewOutput{}, err
+		}
+
+		return nil, GetWorkspaceOverviewOutput{Overview: *overview}, nil
+	}
+
+	addTool(r, &mcp.Tool{
+		Name:        "get_workspace_overview",
+		Description: "Get a compact snapshot of a workspace in one call: entity counts, tag/trigger/variable names and types, enabled built-in variables, folders, pending changes, and which tags each trigger fires. Start here to orient yourself before using list or get tools.",
+	}, handler)
+}
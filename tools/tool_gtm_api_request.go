@@ -0,0 +1,42 @@
+package tools
+
+import (
+	"context"
+
+	"github.com/paolobietolini/gtm-mcp-server/gtm"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// GTMAPIRequestInput is the input for gtm_api_request tool.
+type GTMAPIRequestInput struct {
+	Method string `json:"method" jsonschema:"description:HTTP method: GET, POST, PUT or DELETE"`
+	Path   string `json:"path" jsonschema:"description:API path relative to https://tagmanager.googleapis.com/tagmanager/v2/ with an optional query string, e.g. accounts/123/containers/456/workspaces/7/templates or accounts/123/containers/456/workspaces/7/tags/8?fingerprint=169"`
+	Body   any    `json:"body,omitempty" jsonschema:"description:JSON request body for POST and PUT"`
+}
+
+// GTMAPIRequestOutput is the output for gtm_api_request tool.
+type GTMAPIRequestOutput struct {
+	Response gtm.APIResponse `json:"response"`
+}
+
+func registerGTMAPIRequest(r *toolRegistry) {
+	handler := func(ctx context.Context, req *mcp.CallToolRequest, input GTMAPIRequestInput) (*mcp.CallToolResult, GTMAPIRequestOutput, error) {
+		client, err := getClient(ctx)
+		if err != nil {
+			return nil, GTMAPIRequestOutput{}, err
+		}
+
+		resp, err := client.APIRequest(ctx, input.Method, input.Path, input.Body)
+		if err != nil {
+			return nil, GTMAPIRequestOutput{}, err
+		}
+
+		return nil, GTMAPIRequestOutput{Response: *resp}, nil
+	}
+
+	addTool(r, &mcp.Tool{
+		Name:        "gtm_api_request",
+		Description: "Send a raw request to the Google Tag Manager API v2 with the user's credentials, as an escape hatch for API features the other tools don't cover. Prefer the typed tools whenever one fits: this tool does no validation of the request body. Updates need the entity's fingerprint as a query parameter. Calls are audited and blocked in read-only sessions.",
+	}, handler)
+}
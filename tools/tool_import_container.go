@@ -0,0 +1,83 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/paolobietolini/gtm-mcp-server/gtm"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// ImportContainerInput is the input for import_container tool.
+type ImportContainerInput struct {
+	AccountID   string `json:"accountId" jsonschema:"description:The GTM account ID"`
+	ContainerID string `json:"containerId" jsonschema:"description:The target GTM container ID"`
+	WorkspaceID string `json:"workspaceId" jsonschema:"description:The target GTM workspace ID"`
+	ExportJSON  string `json:"exportJson" jsonschema:"description:Container export JSON as produced by the GTM UI or export_container"`
+	Mode        string `json:"mode" jsonschema:"description:Import mode: overwrite (replace workspace contents), merge_overwrite (overwrite entities with the same name) or merge_rename (rename conflicting imported entities)"`
+	Confirm     bool   `json:"confirm" jsonschema:"description:Must be true to write changes. When false the planned changes are returned without modifying the workspace."`
+}
+
+// ImportContainerOutput is the output for import_container tool.
+type ImportContainerOutput struct {
+	Success bool             `json:"success"`
+	DryRun  bool             `json:"dryRun,omitempty"`
+	Mode    string           `json:"mode"`
+	Result  gtm.ImportResult `json:"result"`
+	Message string           `json:"message"`
+}
+
+func registerImportContainer(r *toolRegistry) {
+	handler := func(ctx context.Context, req *mcp.CallToolRequest, input ImportContainerInput) (*mcp.CallToolResult, ImportContainerOutput, error) {
+		if err := gtm.ValidateImportMode(input.Mode); err != nil {
+			return nil, ImportContainerOutput{}, err
+		}
+		if input.ExportJSON == "" {
+			return nil, ImportContainerOutput{}, fmt.Errorf("exportJson is required")
+		}
+
+		export, err := gtm.ParseContainerExport([]byte(input.ExportJSON))
+		if err != nil {
+			return nil, ImportContainerOutput{}, err
+		}
+
+		wc, err := resolveWorkspace(ctx, input.AccountID, input.ContainerID, input.WorkspaceID)
+		if err != nil {
+			return nil, ImportContainerOutput{}, err
+		}
+
+		result, err := wc.Client.ImportContainer(ctx, wc.AccountID, wc.ContainerID, wc.WorkspaceID, export, gtm.ImportOptions{
+			Mode:  input.Mode,
+			Apply: input.Confirm,
+		})
+		if err != nil {
+			return nil, ImportContainerOutput{}, err
+		}
+
+		// Safety guard: only the plan is computed until explicitly confirmed
+		if !input.Confirm {
+			return nil, ImportContainerOutput{
+				Success: false,
+				DryRun:  true,
+				Mode:    input.Mode,
+				Result:  *result,
+				Message: fmt.Sprintf("Dry run: %d creates, %d updates, %d renames, %d deletes planned. Set confirm: true to import.",
+					result.Created, result.Updated, result.Renamed, result.Deleted),
+			}, nil
+		}
+
+		return nil, ImportContainerOutput{
+			Success: true,
+			Mode:    input.Mode,
+			Result:  *result,
+			Message: fmt.Sprintf("Import complete: %d created, %d updated, %d renamed, %d deleted. Changes are in the workspace and not yet published.",
+				result.Created, result.Updated, result.Renamed, result.Deleted),
+		}, nil
+	}
+
+	addTool(r, &mcp.Tool{
+		Name:        "import_container",
+		Description: "Import GTM UI-style container export JSON into a workspace. Modes mirror the GTM UI: overwrite, merge_overwrite and merge_rename. Trigger, folder and custom template references are remapped to the new IDs and renamed variables are updated in {{references}}. Without confirm: true returns the planned changes only.",
+	}, handler)
+}
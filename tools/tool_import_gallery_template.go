@@ -1,9 +1,11 @@
-package gtm
+package tools
 
 import (
 	"context"
 	"fmt"
 
+	"github.com/paolobietolini/gtm-mcp-server/gtm"
+
 	"github.com/modelcontextprotocol/go-sdk/mcp"
 )
 
@@ -24,7 +26,7 @@ type ImportGalleryTemplateOutput struct {
 	Message  string       `json:"message"`
 }
 
-func registerImportGalleryTemplate(server *mcp.Server) {
+func registerImportGalleryTemplate(r *toolRegistry) {
 	handler := func(ctx context.Context, req *mcp.CallToolRequest, input ImportGalleryTemplateInput) (*mcp.CallToolResult, ImportGalleryTemplateOutput, error) {
 		wc, err := resolveWorkspace(ctx, input.AccountID, input.ContainerID, input.WorkspaceID)
 		if err != nil {
@@ -51,7 +53,7 @@ func registerImportGalleryTemplate(server *mcp.Server) {
 
 		template, err := call.Context(ctx).Do()
 		if err != nil {
-			return nil, ImportGalleryTemplateOutput{}, mapGoogleError(err)
+			return nil, ImportGalleryTemplateOutput{}, gtm.MapGoogleError(err)
 		}
 
 		result := TemplateInfo{
@@ -80,7 +82,7 @@ func registerImportGalleryTemplate(server *mcp.Server) {
 		}, nil
 	}
 
-	mcp.AddTool(server, &mcp.Tool{
+	addTool(r, &mcp.Tool{
 		Name:        "import_gallery_template",
 		Description: "Import a GTM Custom Template from the Community Template Gallery into a workspace. Returns the template type string to use when creating tags. Example: import_gallery_template(galleryOwner='iubenda', galleryRepository='gtm-cookie-solution')",
 	}, handler)
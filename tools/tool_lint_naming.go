@@ -0,0 +1,78 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/paolobietolini/gtm-mcp-server/gtm"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// LintNamingInput is the input for lint_naming tool.
+type LintNamingInput struct {
+	AccountID   string          `json:"accountId" jsonschema:"description:The GTM account ID"`
+	ContainerID string          `json:"containerId" jsonschema:"description:The GTM container ID"`
+	WorkspaceID string          `json:"workspaceId" jsonschema:"description:The GTM workspace ID"`
+	Rules       gtm.NamingRules `json:"rules,omitempty" jsonschema:"description:Naming rules keyed by entity type (tag, trigger, variable), each with a regex pattern and optional prefixes per GTM type. Replaces the server's rules for the types given"`
+}
+
+// LintNamingOutput is the output for lint_naming tool.
+type LintNamingOutput struct {
+	Passed     bool                  `json:"passed"`
+	Rules      gtm.NamingRules       `json:"rules"`
+	Violations []gtm.NamingViolation `json:"violations"`
+	Checked    int                   `json:"checked"`
+	Message    string                `json:"message"`
+}
+
+func registerLintNaming(r *toolRegistry) {
+	handler := func(ctx context.Context, req *mcp.CallToolRequest, input LintNamingInput) (*mcp.CallToolResult, LintNamingOutput, error) {
+		rules := make(gtm.NamingRules, len(r.namingRules)+len(input.Rules))
+		for entityType, rule := range r.namingRules {
+			rules[entityType] = rule
+		}
+		for entityType, rule := range input.Rules {
+			rules[entityType] = rule
+		}
+
+		wc, err := resolveWorkspace(ctx, input.AccountID, input.ContainerID, input.WorkspaceID)
+		if err != nil {
+			return nil, LintNamingOutput{}, err
+		}
+		entities, err := wc.Client.ListWorkspaceEntities(ctx, wc.AccountID, wc.ContainerID, wc.WorkspaceID)
+		if err != nil {
+			return nil, LintNamingOutput{}, err
+		}
+		violations, err := gtm.LintNames(rules, entities)
+		if err != nil {
+			return nil, LintNamingOutput{}, err
+		}
+
+		checked := 0
+		for entityType, n := range map[string]int{"tag": len(entities.Tags), "trigger": len(entities.Triggers), "variable": len(entities.Variables)} {
+			if _, ok := rules[entityType]; ok {
+				checked += n
+			}
+		}
+		suggested := 0
+		for _, v := range violations {
+			if v.Suggestion != "" {
+				suggested++
+			}
+		}
+		return nil, LintNamingOutput{
+			Passed:     len(violations) == 0,
+			Rules:      rules,
+			Violations: violations,
+			Checked:    checked,
+			Message: fmt.Sprintf("%d of %d names break the naming rules; %d have a suggested name. Rename with update_tag, update_trigger and update_variable.",
+				len(violations), checked, suggested),
+		}, nil
+	}
+
+	addTool(r, &mcp.Tool{
+		Name:        "lint_naming",
+		Description: "Check tag, trigger and variable names against naming rules (a regex per entity type, from the server configuration, the rules input or a default \"Kind - Detail\" convention) and list the violations with suggested compliant names built from per-type prefixes. Read-only: rename with the update tools.",
+	}, handler)
+}
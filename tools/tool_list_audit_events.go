@@ -0,0 +1,74 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+const (
+	defaultAuditLimit = 50
+	maxAuditLimit     = 500
+)
+
+// ListAuditEventsInput is the input for list_audit_events tool.
+type ListAuditEventsInput struct {
+	AccountID   string `json:"accountId,omitempty" jsonschema:"description:Only events for this GTM account ID"`
+	ContainerID string `json:"containerId,omitempty" jsonschema:"description:Only events for this GTM container ID"`
+	WorkspaceID string `json:"workspaceId,omitempty" jsonschema:"description:Only events for this GTM workspace ID"`
+	Tool        string `json:"tool,omitempty" jsonschema:"description:Only events for this tool name (e.g. publish_version)"`
+	ClientID    string `json:"clientId,omitempty" jsonschema:"description:Only events from this OAuth client ID or api-key:<name>"`
+	Since       string `json:"since,omitempty" jsonschema:"description:Only events at or after this RFC 3339 time (e.g. 2024-05-01T00:00:00Z)"`
+	Limit       int    `json:"limit,omitempty" jsonschema:"description:Maximum number of events to return (default 50, max 500)"`
+}
+
+// ListAuditEventsOutput is the output for list_audit_events tool.
+type ListAuditEventsOutput struct {
+	Events []AuditEvent `json:"events"`
+	Count  int          `json:"count"`
+}
+
+func registerListAuditEvents(r *toolRegistry) {
+	handler := func(ctx context.Context, req *mcp.CallToolRequest, input ListAuditEventsInput) (*mcp.CallToolResult, ListAuditEventsOutput, error) {
+		if r.audit == nil {
+			return nil, ListAuditEventsOutput{}, fmt.Errorf("audit log is not enabled on this server")
+		}
+
+		// Callers only see their own events; the file and webhook sinks
+		// hold every user's
+		filter := AuditFilter{
+			AccountID:   input.AccountID,
+			ContainerID: input.ContainerID,
+			WorkspaceID: input.WorkspaceID,
+			Tool:        input.Tool,
+			ClientID:    input.ClientID,
+			User:        userKey(ctx),
+		}
+		if input.Since != "" {
+			since, err := time.Parse(time.RFC3339, input.Since)
+			if err != nil {
+				return nil, ListAuditEventsOutput{}, fmt.Errorf("since must be an RFC 3339 time: %w", err)
+			}
+			filter.Since = since
+		}
+
+		limit := input.Limit
+		if limit <= 0 {
+			limit = defaultAuditLimit
+		}
+		limit = min(limit, maxAuditLimit)
+
+		events, err := r.audit.List(filter, limit)
+		if err != nil {
+			return nil, ListAuditEventsOutput{}, err
+		}
+		return nil, ListAuditEventsOutput{Events: events, Count: len(events)}, nil
+	}
+
+	addTool(r, &mcp.Tool{
+		Name:        "list_audit_events",
+		Description: "List your own recorded calls to mutating tools, newest first: tool, client ID, MCP session, account/container/workspace, entity path, redacted input summary and result. Filter by account, container, workspace, tool, client or start time.",
+	}, handler)
+}
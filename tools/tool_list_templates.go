@@ -1,9 +1,11 @@
-package gtm
+package tools
 
 import (
 	"context"
 	"fmt"
 
+	"github.com/paolobietolini/gtm-mcp-server/gtm"
+
 	"github.com/modelcontextprotocol/go-sdk/mcp"
 	tagmanager "google.golang.org/api/tagmanager/v2"
 )
@@ -37,7 +39,7 @@ type GalleryReferenceInfo struct {
 	GalleryTemplateId string `json:"galleryTemplateId,omitempty"`
 }
 
-func registerListTemplates(server *mcp.Server) {
+func registerListTemplates(r *toolRegistry) {
 	handler := func(ctx context.Context, req *mcp.CallToolRequest, input ListTemplatesInput) (*mcp.CallToolResult, ListTemplatesOutput, error) {
 		wc, err := resolveWorkspace(ctx, input.AccountID, input.ContainerID, input.WorkspaceID)
 		if err != nil {
@@ -45,11 +47,11 @@ func registerListTemplates(server *mcp.Server) {
 		}
 
 		parent := wc.WorkspacePath()
-		resp, err := retryWithBackoff(ctx, 3, func() (*tagmanager.ListTemplatesResponse, error) {
+		resp, err := gtm.RetryWithBackoff(ctx, func() (*tagmanager.ListTemplatesResponse, error) {
 			return wc.Client.Service.Accounts.Containers.Workspaces.Templates.List(parent).Context(ctx).Do()
 		})
 		if err != nil {
-			return nil, ListTemplatesOutput{}, mapGoogleError(err)
+			return nil, ListTemplatesOutput{}, gtm.MapGoogleError(err)
 		}
 
 		templates := make([]TemplateInfo, 0)
@@ -82,7 +84,7 @@ func registerListTemplates(server *mcp.Server) {
 		}, nil
 	}
 
-	mcp.AddTool(server, &mcp.Tool{
+	addTool(r, &mcp.Tool{
 		Name:        "list_templates",
 		Description: "List all GTM Custom Templates in a workspace. Returns template IDs and their type strings (cvt_{galleryTemplateId} for gallery templates) for use when creating tags.",
 	}, handler)
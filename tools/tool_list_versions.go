@@ -0,0 +1,52 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/paolobietolini/gtm-mcp-server/gtm"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// ListVersionsInput is the input for list_versions tool.
+type ListVersionsInput struct {
+	AccountID   string `json:"accountId" jsonschema:"description:The GTM account ID"`
+	ContainerID string `json:"containerId" jsonschema:"description:The GTM container ID"`
+}
+
+// ListVersionsOutput is the output for list_versions tool.
+type ListVersionsOutput struct {
+	Versions []gtm.VersionInfo `json:"versions"`
+}
+
+func registerListVersions(r *toolRegistry) {
+	handler := func(ctx context.Context, req *mcp.CallToolRequest, input ListVersionsInput) (*mcp.CallToolResult, ListVersionsOutput, error) {
+		// Validate required fields
+		if input.AccountID == "" {
+			return nil, ListVersionsOutput{}, fmt.Errorf("accountId is required")
+		}
+		if input.ContainerID == "" {
+			return nil, ListVersionsOutput{}, fmt.Errorf("containerId is required")
+		}
+
+		client, err := getClient(ctx)
+		if err != nil {
+			return nil, ListVersionsOutput{}, err
+		}
+
+		versions, err := client.ListVersions(ctx, input.AccountID, input.ContainerID)
+		if err != nil {
+			return nil, ListVersionsOutput{}, err
+		}
+
+		return nil, ListVersionsOutput{
+			Versions: versions,
+		}, nil
+	}
+
+	addTool(r, &mcp.Tool{
+		Name:        "list_versions",
+		Description: "List all container versions. Returns version headers with counts of tags, triggers, variables, and templates.",
+	}, handler)
+}
@@ -0,0 +1,93 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/paolobietolini/gtm-mcp-server/gtm"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// MoveTagIDInput is the input for move_tag_id tool.
+type MoveTagIDInput struct {
+	AccountID                        string `json:"accountId" jsonschema:"description:The GTM account ID"`
+	ContainerID                      string `json:"containerId" jsonschema:"description:The GTM container ID the tag ID is moved out of"`
+	TagID                            string `json:"tagId" jsonschema:"description:The Google tag ID to move out of the container (e.g. G-XXXXXXX or AW-123456)"`
+	TagName                          string `json:"tagName,omitempty" jsonschema:"description:Name for the newly created Google tag (optional)"`
+	CopySettings                     bool   `json:"copySettings,omitempty" jsonschema:"description:Copy tag settings to the new Google tag"`
+	CopyUsers                        bool   `json:"copyUsers,omitempty" jsonschema:"description:Copy users to the new Google tag"`
+	CopyTermsOfService               bool   `json:"copyTermsOfService,omitempty" jsonschema:"description:Accept terms of service copied to the new Google tag. The move fails if the current tag has terms of service and this is false."`
+	AllowUserPermissionFeatureUpdate bool   `json:"allowUserPermissionFeatureUpdate,omitempty" jsonschema:"description:Allow the user permissions feature to be enabled by this operation"`
+	Confirm                          bool   `json:"confirm" jsonschema:"description:Must be true to perform the move. When false a dry-run preview is returned."`
+}
+
+// MoveTagIDOutput is the output for move_tag_id tool.
+type MoveTagIDOutput struct {
+	Success   bool           `json:"success"`
+	DryRun    bool           `json:"dryRun,omitempty"`
+	Before    *gtm.Container `json:"before,omitempty"`
+	Container *gtm.Container `json:"container,omitempty"`
+	Message   string         `json:"message"`
+}
+
+func registerMoveTagID(r *toolRegistry) {
+	handler := func(ctx context.Context, req *mcp.CallToolRequest, input MoveTagIDInput) (*mcp.CallToolResult, MoveTagIDOutput, error) {
+		if input.TagID == "" {
+			return nil, MoveTagIDOutput{}, fmt.Errorf("tagId is required")
+		}
+
+		cc, err := resolveContainer(ctx, input.AccountID, input.ContainerID)
+		if err != nil {
+			return nil, MoveTagIDOutput{}, err
+		}
+
+		before, err := cc.Client.GetContainer(ctx, cc.ContainerPath())
+		if err != nil {
+			return nil, MoveTagIDOutput{}, err
+		}
+		if !slices.Contains(before.TagIDs, input.TagID) {
+			return nil, MoveTagIDOutput{
+				Success: false,
+				Before:  before,
+				Message: fmt.Sprintf("Tag ID %s is not part of container %s (tag IDs: %v)", input.TagID, before.PublicID, before.TagIDs),
+			}, nil
+		}
+
+		// Safety guard: preview the move until explicitly confirmed
+		if !input.Confirm {
+			return nil, MoveTagIDOutput{
+				Success: false,
+				DryRun:  true,
+				Before:  before,
+				Message: fmt.Sprintf("Dry run: tag ID %s will be removed from container %s (%s) and moved into a new Google tag. Remaining tag IDs: %v. Set confirm: true to proceed.",
+					input.TagID, before.Name, before.PublicID, slices.DeleteFunc(slices.Clone(before.TagIDs), func(id string) bool { return id == input.TagID })),
+			}, nil
+		}
+
+		after, err := cc.Client.MoveTagID(ctx, cc.ContainerPath(), gtm.MoveTagIDOptions{
+			TagID:                            input.TagID,
+			TagName:                          input.TagName,
+			CopySettings:                     input.CopySettings,
+			CopyUsers:                        input.CopyUsers,
+			CopyTermsOfService:               input.CopyTermsOfService,
+			AllowUserPermissionFeatureUpdate: input.AllowUserPermissionFeatureUpdate,
+		})
+		if err != nil {
+			return nil, MoveTagIDOutput{}, err
+		}
+
+		return nil, MoveTagIDOutput{
+			Success:   true,
+			Before:    before,
+			Container: after,
+			Message:   fmt.Sprintf("Tag ID %s moved out of container %s", input.TagID, before.PublicID),
+		}, nil
+	}
+
+	addTool(r, &mcp.Tool{
+		Name:        "move_tag_id",
+		Description: "Move a Google tag ID (e.g. G-XXXXXXX) out of a GTM container into a new Google tag. Without confirm: true returns a dry-run preview of the container's tag IDs before and after the move.",
+	}, handler)
+}
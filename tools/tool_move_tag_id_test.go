@@ -0,0 +1,95 @@
+package tools
+
+import (
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+// containerHandler answers GETs of containers 1/2 and 1/3 and the move and
+// combine calls on container 1/2.
+func containerHandler(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	path := strings.TrimPrefix(r.URL.Path, "/tagmanager/v2/")
+	switch {
+	case r.Method == http.MethodGet && path == "accounts/1/containers/2":
+		io.WriteString(w, `{"path":"accounts/1/containers/2","accountId":"1","containerId":"2","name":"Web","usageContext":["web"],"publicId":"GTM-AAA","tagIds":["G-1","AW-2"]}`)
+	case r.Method == http.MethodGet && path == "accounts/1/containers/3":
+		io.WriteString(w, `{"path":"accounts/1/containers/3","accountId":"1","containerId":"3","name":"Other","usageContext":["web"],"publicId":"GTM-BBB","tagIds":["G-3"]}`)
+	case r.Method == http.MethodPost && path == "accounts/1/containers/2:move_tag_id":
+		io.WriteString(w, `{"path":"accounts/1/containers/2","accountId":"1","containerId":"2","name":"Web","usageContext":["web"],"publicId":"GTM-AAA","tagIds":["AW-2"]}`)
+	case r.Method == http.MethodPost && path == "accounts/1/containers/2:combine":
+		io.WriteString(w, `{"path":"accounts/1/containers/2","accountId":"1","containerId":"2","name":"Web","usageContext":["web"],"publicId":"GTM-AAA","tagIds":["G-1","AW-2","G-3"]}`)
+	default:
+		w.WriteHeader(http.StatusNotFound)
+		io.WriteString(w, `{"error":{"code":404,"message":"not found"}}`)
+	}
+}
+
+func TestMoveTagID_DryRunWithoutConfirm(t *testing.T) {
+	s := newToolSession(t, ToolOptions{}, containerHandler)
+
+	var out MoveTagIDOutput
+	if toolErr := s.call("move_tag_id", map[string]any{"accountId": "1", "containerId": "2", "tagId": "G-1", "confirm": false}, &out); toolErr != nil {
+		t.Fatalf("move_tag_id: %+v", toolErr)
+	}
+	if out.Success || !out.DryRun || out.Before == nil || out.Before.PublicID != "GTM-AAA" {
+		t.Errorf("dry run = %+v", out)
+	}
+	if !strings.Contains(out.Message, "[AW-2]") {
+		t.Errorf("dry run message %q does not list the remaining tag IDs", out.Message)
+	}
+	if m := s.api.Mutations(); len(m) != 0 {
+		t.Errorf("dry run issued %v", m)
+	}
+}
+
+func TestMoveTagID_Validation(t *testing.T) {
+	s := newToolSession(t, ToolOptions{}, containerHandler)
+
+	if toolErr := s.call("move_tag_id", map[string]any{"accountId": "1", "containerId": "2", "tagId": "", "confirm": true}, nil); toolErr == nil || !strings.Contains(toolErr.Message, "tagId is required") {
+		t.Errorf("move_tag_id without tagId returned %+v", toolErr)
+	}
+
+	var out MoveTagIDOutput
+	if toolErr := s.call("move_tag_id", map[string]any{"accountId": "1", "containerId": "2", "tagId": "G-9", "confirm": true}, &out); toolErr != nil {
+		t.Fatalf("move_tag_id: %+v", toolErr)
+	}
+	if out.Success || out.DryRun || !strings.Contains(out.Message, "not part of container") {
+		t.Errorf("move of an unknown tag ID = %+v", out)
+	}
+
+	if m := s.api.Mutations(); len(m) != 0 {
+		t.Errorf("invalid moves issued %v", m)
+	}
+}
+
+func TestMoveTagID_Confirmed(t *testing.T) {
+	s := newToolSession(t, ToolOptions{}, containerHandler)
+
+	var out MoveTagIDOutput
+	if toolErr := s.call("move_tag_id", map[string]any{
+		"accountId":    "1",
+		"containerId":  "2",
+		"tagId":        "G-1",
+		"tagName":      "Moved",
+		"copySettings": true,
+		"confirm":      true,
+	}, &out); toolErr != nil {
+		t.Fatalf("move_tag_id: %+v", toolErr)
+	}
+	if !out.Success || out.Container == nil || len(out.Container.TagIDs) != 1 || out.Container.TagIDs[0] != "AW-2" {
+		t.Errorf("move = %+v", out)
+	}
+
+	m := s.api.Mutations()
+	if len(m) != 1 || !strings.HasPrefix(m[0], "POST accounts/1/containers/2:move_tag_id?") {
+		t.Fatalf("move issued %v, want one move_tag_id call", m)
+	}
+	for _, param := range []string{"tagId=G-1", "tagName=Moved", "copySettings=true", "copyUsers=false", "copyTermsOfService=false"} {
+		if !strings.Contains(m[0], param) {
+			t.Errorf("move_tag_id call %q is missing %s", m[0], param)
+		}
+	}
+}
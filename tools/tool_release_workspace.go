@@ -0,0 +1,142 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/paolobietolini/gtm-mcp-server/gtm"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// ReleaseWorkspaceInput is the input for release_workspace tool.
+type ReleaseWorkspaceInput struct {
+	AccountID     string `json:"accountId" jsonschema:"description:The GTM account ID"`
+	ContainerID   string `json:"containerId" jsonschema:"description:The GTM container ID"`
+	WorkspaceID   string `json:"workspaceId" jsonschema:"description:The GTM workspace ID to release"`
+	VersionName   string `json:"versionName,omitempty" jsonschema:"description:Version name (optional)"`
+	Notes         string `json:"notes,omitempty" jsonschema:"description:Release notes prepended to the generated change list (optional)"`
+	AllowWarnings bool   `json:"allowWarnings,omitempty" jsonschema:"description:Publish even if validation reports warnings (errors always block)"`
+	Confirm       bool   `json:"confirm" jsonschema:"description:Must be true to create and publish the version. When false only validation runs and a preview is returned."`
+}
+
+// ReleaseWorkspaceOutput is the output for release_workspace tool.
+type ReleaseWorkspaceOutput struct {
+	Success  bool                  `json:"success"`
+	DryRun   bool                  `json:"dryRun,omitempty"`
+	Steps    []ReleaseStep         `json:"steps"`
+	Issues   []gtm.ValidationIssue `json:"issues,omitempty"`
+	Changes  []gtm.WorkspaceChange `json:"changes,omitempty"` // pending changes being released
+	Notes    string                `json:"notes,omitempty"`
+	Version  *gtm.PublishedVersion `json:"version,omitempty"`
+	Verified bool                  `json:"verified"`
+	Message  string                `json:"message"`
+}
+
+// ReleaseStep reports the outcome of one stage of a release.
+type ReleaseStep struct {
+	Name   string `json:"name"`
+	Status string `json:"status"` // "ok", "failed" or "skipped"
+	Detail string `json:"detail,omitempty"`
+}
+
+func registerReleaseWorkspace(r *toolRegistry) {
+	handler := func(ctx context.Context, req *mcp.CallToolRequest, input ReleaseWorkspaceInput) (*mcp.CallToolResult, ReleaseWorkspaceOutput, error) {
+		wc, err := resolveWorkspace(ctx, input.AccountID, input.ContainerID, input.WorkspaceID)
+		if err != nil {
+			return nil, ReleaseWorkspaceOutput{}, err
+		}
+
+		out := ReleaseWorkspaceOutput{}
+		step := func(name, status, detail string) {
+			out.Steps = append(out.Steps, ReleaseStep{Name: name, Status: status, Detail: detail})
+		}
+		fail := func(name string, err error) (*mcp.CallToolResult, ReleaseWorkspaceOutput, error) {
+			step(name, "failed", err.Error())
+			out.Message = fmt.Sprintf("Release stopped at %s: %v", name, err)
+			return nil, out, nil
+		}
+
+		// 1. Pre-publish validation
+		status, err := wc.Client.GetWorkspaceStatus(ctx, wc.AccountID, wc.ContainerID, wc.WorkspaceID)
+		if err != nil {
+			return fail("validate", err)
+		}
+		if !status.HasChanges {
+			return fail("validate", fmt.Errorf("no changes in workspace to release"))
+		}
+
+		out.Issues, err = wc.Client.ValidateWorkspace(ctx, wc.AccountID, wc.ContainerID, wc.WorkspaceID)
+		if err != nil {
+			return fail("validate", err)
+		}
+		if gtm.HasValidationErrors(out.Issues) {
+			return fail("validate", fmt.Errorf("validation found errors, see issues"))
+		}
+		if len(out.Issues) > 0 && !input.AllowWarnings {
+			return fail("validate", fmt.Errorf("validation found %d warnings, set allowWarnings: true to release anyway", len(out.Issues)))
+		}
+		step("validate", "ok", fmt.Sprintf("%d changes, %d warnings", status.ChangeCount, len(out.Issues)))
+
+		out.Changes = status.Changes
+		out.Notes = gtm.GenerateReleaseNotes(input.Notes, status.Changes)
+
+		// Safety guard: stop after validation until explicitly confirmed
+		if !input.Confirm {
+			out.DryRun = true
+			step("create_version", "skipped", "confirm is false")
+			step("publish", "skipped", "confirm is false")
+			step("verify", "skipped", "confirm is false")
+			out.Message = "Validation passed. Set confirm: true to create and publish the version. WARNING: This will make the changes live on your website."
+			return nil, out, nil
+		}
+
+		// 2. Create version
+		created, err := wc.Client.CreateVersion(ctx, wc.AccountID, wc.ContainerID, wc.WorkspaceID, &gtm.VersionInput{
+			Name:  input.VersionName,
+			Notes: out.Notes,
+		})
+		if err != nil {
+			return fail("create_version", err)
+		}
+		step("create_version", "ok", fmt.Sprintf("version %s created", created.VersionID))
+
+		// 3. Publish
+		published, err := wc.Client.PublishVersion(ctx, wc.AccountID, wc.ContainerID, created.VersionID)
+		if err != nil {
+			return fail("publish", err)
+		}
+		out.Version = published
+		step("publish", "ok", fmt.Sprintf("version %s published", published.VersionID))
+
+		// 4. Verify the live version
+		live, err := wc.Client.GetLiveVersion(ctx, wc.AccountID, wc.ContainerID)
+		if err != nil {
+			return fail("verify", err)
+		}
+		if live == nil || live.VersionID != published.VersionID {
+			liveID := "none"
+			if live != nil {
+				liveID = live.VersionID
+			}
+			return fail("verify", fmt.Errorf("live version is %s, expected %s", liveID, published.VersionID))
+		}
+		out.Verified = true
+		step("verify", "ok", fmt.Sprintf("live version is %s", live.VersionID))
+
+		out.Success = true
+		out.Message = fmt.Sprintf("Version %s is now LIVE and verified", published.VersionID)
+		return nil, out, nil
+	}
+
+	addTool(r, &mcp.Tool{
+		Name:        "release_workspace",
+		Description: "Create and publish a version from a workspace in one step: validate (conflicts, missing triggers), return the pending changes, create a version with generated change notes, publish it and verify it is live, returning the live version ID. Without confirm: true only validation runs and the pending changes are returned. Requires confirm: true to publish. WARNING: This pushes changes to your live website.",
+	}, handler)
+
+	// The name users ask for when they want to "create and publish"
+	addTool(r, &mcp.Tool{
+		Name:        "create_and_publish",
+		Description: "Same as release_workspace: validate a workspace, create a version with generated change notes, publish it and verify it is live. Without confirm: true only validation runs and the pending changes are returned. Requires confirm: true to publish. WARNING: This pushes changes to your live website.",
+	}, handler)
+}
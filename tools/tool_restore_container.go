@@ -0,0 +1,108 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/paolobietolini/gtm-mcp-server/gtm"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// RestoreContainerInput is the input for restore_container tool.
+type RestoreContainerInput struct {
+	BackupID  string `json:"backupId,omitempty" jsonschema:"description:Backup ID returned by delete_container. Omit to list your restorable backups."`
+	AccountID string `json:"accountId,omitempty" jsonschema:"description:Account to restore into (optional, defaults to the original account)"`
+	Name      string `json:"name,omitempty" jsonschema:"description:Name for the restored container (optional, defaults to the original name)"`
+	Confirm   bool   `json:"confirm" jsonschema:"description:Must be true to create the container and import the backup"`
+}
+
+// RestoreContainerOutput is the output for restore_container tool.
+type RestoreContainerOutput struct {
+	Success   bool                  `json:"success"`
+	Backups   []gtm.ContainerBackup `json:"backups,omitempty"`
+	Container *gtm.Container        `json:"container,omitempty"`
+	Import    *gtm.ImportResult     `json:"import,omitempty"`
+	Message   string                `json:"message"`
+}
+
+func registerRestoreContainer(r *toolRegistry) {
+	handler := func(ctx context.Context, req *mcp.CallToolRequest, input RestoreContainerInput) (*mcp.CallToolResult, RestoreContainerOutput, error) {
+		if input.BackupID == "" {
+			backups := gtm.ContainerBackups.List(userKey(ctx))
+			return nil, RestoreContainerOutput{
+				Success: true,
+				Backups: backups,
+				Message: fmt.Sprintf("%d deleted containers can be restored", len(backups)),
+			}, nil
+		}
+
+		backup, err := gtm.ContainerBackups.Get(userKey(ctx), input.BackupID)
+		if err != nil {
+			return nil, RestoreContainerOutput{}, err
+		}
+		export := backup.RestoreExport()
+		if export == nil {
+			return nil, RestoreContainerOutput{}, fmt.Errorf("backup %s has no live version or workspace to restore", input.BackupID)
+		}
+
+		accountID := input.AccountID
+		if accountID == "" {
+			accountID = backup.AccountID
+		}
+		name := input.Name
+		if name == "" {
+			name = backup.Name
+		}
+
+		// Safety guard: require explicit confirmation
+		if !input.Confirm {
+			counts := export.Counts()
+			return nil, RestoreContainerOutput{
+				Success: false,
+				Backups: []gtm.ContainerBackup{*backup},
+				Message: fmt.Sprintf("Restore requires confirm: true. A new container %q will be created in account %s with %d tags, %d triggers and %d variables. The public ID (%s) cannot be reused; the new container gets a new GTM-ID.",
+					name, accountID, counts.Tags, counts.Triggers, counts.Variables, backup.PublicID),
+			}, nil
+		}
+
+		client, err := resolveAccount(ctx, accountID)
+		if err != nil {
+			return nil, RestoreContainerOutput{}, err
+		}
+
+		container, err := client.CreateContainerFromBackup(ctx, accountID, name, backup)
+		if err != nil {
+			return nil, RestoreContainerOutput{}, err
+		}
+
+		workspaces, err := client.ListWorkspaces(ctx, accountID, container.ContainerID)
+		if err != nil {
+			return nil, RestoreContainerOutput{}, err
+		}
+		if len(workspaces) == 0 {
+			return nil, RestoreContainerOutput{}, fmt.Errorf("restored container %s has no workspace", container.ContainerID)
+		}
+
+		result, err := client.ImportContainer(ctx, accountID, container.ContainerID, workspaces[0].WorkspaceID, export, gtm.ImportOptions{
+			Mode:  gtm.ImportModeOverwrite,
+			Apply: true,
+		})
+		if err != nil {
+			return nil, RestoreContainerOutput{Container: container}, fmt.Errorf("container %s created but import failed: %w", container.ContainerID, err)
+		}
+
+		return nil, RestoreContainerOutput{
+			Success:   true,
+			Container: container,
+			Import:    result,
+			Message: fmt.Sprintf("Container restored as %s (%s) in workspace %s. Review and publish to make it live.",
+				container.ContainerID, container.PublicID, workspaces[0].WorkspaceID),
+		}, nil
+	}
+
+	addTool(r, &mcp.Tool{
+		Name:        "restore_container",
+		Description: "Restore a container deleted with delete_container from its backup by creating a new container and importing the backed-up live version (or workspace). Only the user who deleted a container can restore it, and backups are kept in memory, so they are lost if the server restarts. Omit backupId to list your restorable backups. Requires confirm: true.",
+	}, handler)
+}
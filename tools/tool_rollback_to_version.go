@@ -0,0 +1,115 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/paolobietolini/gtm-mcp-server/auth"
+	"github.com/paolobietolini/gtm-mcp-server/gtm"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// RollbackToVersionInput is the input for rollback_to_version tool.
+type RollbackToVersionInput struct {
+	AccountID        string `json:"accountId" jsonschema:"description:The GTM account ID"`
+	ContainerID      string `json:"containerId" jsonschema:"description:The GTM container ID"`
+	VersionID        string `json:"versionId" jsonschema:"description:The previous version ID to publish again"`
+	Reason           string `json:"reason" jsonschema:"description:Why the live version is rolled back, recorded in the version description (e.g. 'purchase tag stopped firing after version 42')"`
+	RequestedBy      string `json:"requestedBy,omitempty" jsonschema:"description:Who asked for the rollback, recorded in the version description (optional, defaults to the MCP client ID)"`
+	Confirm          bool   `json:"confirm" jsonschema:"description:Must be true to publish the version. When false only the changes the rollback would make are returned."`
+	IncludeSensitive bool   `json:"includeSensitive,omitempty" jsonschema:"description:Return changed parameter values that look like secrets (API keys, tokens, passwords) instead of redacting them"`
+}
+
+// RollbackToVersionOutput is the output for rollback_to_version tool.
+type RollbackToVersionOutput struct {
+	Success         bool              `json:"success"`
+	DryRun          bool              `json:"dryRun,omitempty"`
+	PreviousVersion string            `json:"previousVersionId"`
+	Version         *gtm.VersionInfo  `json:"version,omitempty"`
+	Diff            gtm.ContainerDiff `json:"diff"`
+	Description     string            `json:"description,omitempty"` // appended to the version description
+	Message         string            `json:"message"`
+}
+
+func registerRollbackToVersion(r *toolRegistry) {
+	handler := func(ctx context.Context, req *mcp.CallToolRequest, input RollbackToVersionInput) (*mcp.CallToolResult, RollbackToVersionOutput, error) {
+		if err := gtm.ValidateContainerPath(input.AccountID, input.ContainerID); err != nil {
+			return nil, RollbackToVersionOutput{}, err
+		}
+		if input.VersionID == "" {
+			return nil, RollbackToVersionOutput{}, gtm.InvalidField("versionId", "versionId is required")
+		}
+		if input.Reason == "" {
+			return nil, RollbackToVersionOutput{}, gtm.InvalidField("reason", "reason is required: it is recorded in the version description")
+		}
+
+		client, err := getClient(ctx)
+		if err != nil {
+			return nil, RollbackToVersionOutput{}, err
+		}
+
+		live, err := client.GetVersion(ctx, input.AccountID, input.ContainerID, "live")
+		if err != nil {
+			return nil, RollbackToVersionOutput{}, err
+		}
+		if live.ContainerVersionId == input.VersionID {
+			return nil, RollbackToVersionOutput{}, gtm.InvalidField("versionId", "version %s is already live", input.VersionID)
+		}
+		target, err := client.GetVersion(ctx, input.AccountID, input.ContainerID, input.VersionID)
+		if err != nil {
+			return nil, RollbackToVersionOutput{}, err
+		}
+		if target.Deleted {
+			return nil, RollbackToVersionOutput{}, gtm.InvalidField("versionId", "version %s is deleted", input.VersionID)
+		}
+
+		requestedBy := input.RequestedBy
+		if requestedBy == "" {
+			requestedBy = "unknown"
+			if tokenInfo := auth.GetTokenInfo(ctx); tokenInfo != nil && tokenInfo.ClientID != "" {
+				requestedBy = tokenInfo.ClientID
+			}
+		}
+
+		diff := gtm.DiffVersionsRedacted(live, target)
+		if input.IncludeSensitive {
+			diff = gtm.DiffVersions(live, target)
+		}
+
+		out := RollbackToVersionOutput{
+			PreviousVersion: live.ContainerVersionId,
+			Version:         &gtm.VersionInfo{VersionID: target.ContainerVersionId, Name: target.Name, Path: target.Path},
+			Diff:            *diff,
+			Description: fmt.Sprintf("Rolled back to this version on %s by %s, replacing live version %s. Reason: %s",
+				time.Now().UTC().Format("2006-01-02 15:04 UTC"), requestedBy, live.ContainerVersionId, input.Reason),
+		}
+
+		// Safety guard: only preview the rollback until explicitly confirmed
+		if !input.Confirm {
+			out.DryRun = true
+			out.Message = fmt.Sprintf("Dry run: publishing version %s again replaces live version %s (%d added, %d removed, %d modified). Set confirm: true to roll back. WARNING: This changes your live website.",
+				input.VersionID, live.ContainerVersionId, out.Diff.Added, out.Diff.Removed, out.Diff.Modified)
+			return nil, out, nil
+		}
+
+		if _, err := client.PublishVersion(ctx, input.AccountID, input.ContainerID, input.VersionID); err != nil {
+			return nil, RollbackToVersionOutput{}, err
+		}
+
+		// The rollback is live at this point; a failed description update only
+		// loses the record
+		out.Success = true
+		out.Message = fmt.Sprintf("Version %s is LIVE again, replacing version %s", input.VersionID, live.ContainerVersionId)
+		if err := client.AppendVersionDescription(ctx, input.AccountID, input.ContainerID, input.VersionID, out.Description); err != nil {
+			out.Message += fmt.Sprintf(", but recording the rollback in the version description failed: %v", err)
+		}
+		return nil, out, nil
+	}
+
+	addTool(r, &mcp.Tool{
+		Name:        "rollback_to_version",
+		Description: "Roll back a container by publishing a previous version again, recording who asked and why in that version's description. Without confirm: true only shows what the rollback changes compared to the live version. WARNING: This changes your live website.",
+	}, handler)
+}
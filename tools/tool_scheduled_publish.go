@@ -0,0 +1,209 @@
+package tools
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/paolobietolini/gtm-mcp-server/auth"
+	"github.com/paolobietolini/gtm-mcp-server/gtm"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// errSchedulerDisabled is returned by the scheduled publish tools when the
+// server has no schedule file.
+var errSchedulerDisabled = errors.New("scheduled publishing is not enabled on this server (set PUBLISH_SCHEDULE_FILE)")
+
+// checkContainerAccess fails unless the caller's own credential can read the
+// container, since scheduled publishes run with the server's credential.
+func checkContainerAccess(ctx context.Context, accountID, containerID string) (*gtm.Client, error) {
+	if err := gtm.ValidateContainerPath(accountID, containerID); err != nil {
+		return nil, err
+	}
+	client, err := getClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := client.GetContainer(ctx, gtm.BuildContainerPath(accountID, containerID)); err != nil {
+		return nil, err
+	}
+	return client, nil
+}
+
+// grantedGoogleScopes looks up the scopes of a Google token; replaced in tests.
+var grantedGoogleScopes = auth.GrantedGoogleScopes
+
+// checkPublishAccess fails unless the caller's own Google grant includes the
+// publish scope. The server's credential publishes at the scheduled time, so
+// without this check any user who can read a container could publish it.
+func checkPublishAccess(ctx context.Context) error {
+	tokenSource, err := googleTokenSource(ctx)
+	if err != nil {
+		return err
+	}
+	scopes, err := grantedGoogleScopes(ctx, tokenSource)
+	if err != nil {
+		return err
+	}
+	if !slices.Contains(scopes, auth.GoogleScopePublish) {
+		return fmt.Errorf("%w: scheduling a publish needs your own Google grant of the tagmanager.publish scope, since the server publishes it with its own credential", gtm.ErrPermission)
+	}
+	return nil
+}
+
+// SchedulePublishInput is the input for schedule_publish tool.
+type SchedulePublishInput struct {
+	AccountID   string `json:"accountId" jsonschema:"description:The GTM account ID"`
+	ContainerID string `json:"containerId" jsonschema:"description:The GTM container ID"`
+	VersionID   string `json:"versionId" jsonschema:"description:The container version ID to publish"`
+	PublishAt   string `json:"publishAt" jsonschema:"description:When to publish, in ISO 8601 (e.g. 2026-03-01T09:00 or 2026-03-01T09:00:00+01:00)"`
+	TimeZone    string `json:"timeZone,omitempty" jsonschema:"description:IANA time zone for publishAt without a UTC offset (e.g. Europe/Rome, default UTC)"`
+	Note        string `json:"note,omitempty" jsonschema:"description:Why the publish is scheduled, shown by list_scheduled_publishes"`
+}
+
+// SchedulePublishOutput is the output for schedule_publish tool.
+type SchedulePublishOutput struct {
+	Success bool                  `json:"success"`
+	Publish *gtm.ScheduledPublish `json:"publish"`
+	Message string                `json:"message"`
+}
+
+func registerSchedulePublish(r *toolRegistry) {
+	handler := func(ctx context.Context, req *mcp.CallToolRequest, input SchedulePublishInput) (*mcp.CallToolResult, SchedulePublishOutput, error) {
+		if r.scheduler == nil {
+			return nil, SchedulePublishOutput{}, errSchedulerDisabled
+		}
+		if input.VersionID == "" {
+			return nil, SchedulePublishOutput{}, gtm.InvalidField("versionId", "versionId is required")
+		}
+		publishAt, err := gtm.ParseScheduleTime(input.PublishAt, input.TimeZone)
+		if err != nil {
+			return nil, SchedulePublishOutput{}, gtm.InvalidField("publishAt", "%v", err)
+		}
+		if publishAt == 0 {
+			return nil, SchedulePublishOutput{}, gtm.InvalidField("publishAt", "publishAt is required")
+		}
+
+		if err := checkPublishAccess(ctx); err != nil {
+			return nil, SchedulePublishOutput{}, err
+		}
+		client, err := checkContainerAccess(ctx, input.AccountID, input.ContainerID)
+		if err != nil {
+			return nil, SchedulePublishOutput{}, err
+		}
+		version, err := client.GetVersion(ctx, input.AccountID, input.ContainerID, input.VersionID)
+		if err != nil {
+			return nil, SchedulePublishOutput{}, err
+		}
+		if version.Deleted {
+			return nil, SchedulePublishOutput{}, gtm.InvalidField("versionId", "version %s is deleted", input.VersionID)
+		}
+
+		requestedBy := ""
+		if tokenInfo := auth.GetTokenInfo(ctx); tokenInfo != nil {
+			requestedBy = tokenInfo.ClientID
+		}
+		sp, err := r.scheduler.Schedule(gtm.ScheduledPublish{
+			AccountID:   input.AccountID,
+			ContainerID: input.ContainerID,
+			VersionID:   input.VersionID,
+			VersionName: version.Name,
+			PublishAt:   time.UnixMilli(publishAt).UTC(),
+			RequestedBy: requestedBy,
+			Note:        input.Note,
+		})
+		if err != nil {
+			return nil, SchedulePublishOutput{}, err
+		}
+
+		return nil, SchedulePublishOutput{
+			Success: true,
+			Publish: sp,
+			Message: fmt.Sprintf("Version %s will be published at %s (schedule ID %s). Cancel it with cancel_scheduled_publish.",
+				input.VersionID, sp.PublishAt.Format(time.RFC3339), sp.ID),
+		}, nil
+	}
+
+	addTool(r, &mcp.Tool{
+		Name:        "schedule_publish",
+		Description: "Schedule a container version to be published at a later time, e.g. for a campaign launch. The server publishes it with its own stored credential, so it runs even when no client is connected; scheduling needs your own grant of the tagmanager.publish scope. WARNING: This changes your live website at the scheduled time.",
+	}, handler)
+}
+
+// ListScheduledPublishesInput is the input for list_scheduled_publishes tool.
+type ListScheduledPublishesInput struct {
+	AccountID   string `json:"accountId" jsonschema:"description:The GTM account ID"`
+	ContainerID string `json:"containerId" jsonschema:"description:The GTM container ID"`
+	All         bool   `json:"all,omitempty" jsonschema:"description:Include published, failed, missed and canceled publishes of the last 30 days (default: pending only)"`
+}
+
+// ListScheduledPublishesOutput is the output for list_scheduled_publishes tool.
+type ListScheduledPublishesOutput struct {
+	Publishes []gtm.ScheduledPublish `json:"publishes"`
+	Count     int                    `json:"count"`
+}
+
+func registerListScheduledPublishes(r *toolRegistry) {
+	handler := func(ctx context.Context, req *mcp.CallToolRequest, input ListScheduledPublishesInput) (*mcp.CallToolResult, ListScheduledPublishesOutput, error) {
+		if r.scheduler == nil {
+			return nil, ListScheduledPublishesOutput{}, errSchedulerDisabled
+		}
+		if _, err := checkContainerAccess(ctx, input.AccountID, input.ContainerID); err != nil {
+			return nil, ListScheduledPublishesOutput{}, err
+		}
+
+		publishes := r.scheduler.List(input.AccountID, input.ContainerID, input.All)
+		return nil, ListScheduledPublishesOutput{Publishes: publishes, Count: len(publishes)}, nil
+	}
+
+	addTool(r, &mcp.Tool{
+		Name:        "list_scheduled_publishes",
+		Description: "List the scheduled publishes of a container, soonest first, with their status, version, requester and note.",
+	}, handler)
+}
+
+// CancelScheduledPublishInput is the input for cancel_scheduled_publish tool.
+type CancelScheduledPublishInput struct {
+	AccountID   string `json:"accountId" jsonschema:"description:The GTM account ID"`
+	ContainerID string `json:"containerId" jsonschema:"description:The GTM container ID"`
+	ScheduleID  string `json:"scheduleId" jsonschema:"description:The schedule ID returned by schedule_publish or list_scheduled_publishes"`
+}
+
+// CancelScheduledPublishOutput is the output for cancel_scheduled_publish tool.
+type CancelScheduledPublishOutput struct {
+	Success bool                  `json:"success"`
+	Publish *gtm.ScheduledPublish `json:"publish"`
+	Message string                `json:"message"`
+}
+
+func registerCancelScheduledPublish(r *toolRegistry) {
+	handler := func(ctx context.Context, req *mcp.CallToolRequest, input CancelScheduledPublishInput) (*mcp.CallToolResult, CancelScheduledPublishOutput, error) {
+		if r.scheduler == nil {
+			return nil, CancelScheduledPublishOutput{}, errSchedulerDisabled
+		}
+		if input.ScheduleID == "" {
+			return nil, CancelScheduledPublishOutput{}, gtm.InvalidField("scheduleId", "scheduleId is required")
+		}
+		if _, err := checkContainerAccess(ctx, input.AccountID, input.ContainerID); err != nil {
+			return nil, CancelScheduledPublishOutput{}, err
+		}
+
+		sp, err := r.scheduler.Cancel(input.AccountID, input.ContainerID, input.ScheduleID)
+		if err != nil {
+			return nil, CancelScheduledPublishOutput{}, err
+		}
+		return nil, CancelScheduledPublishOutput{
+			Success: true,
+			Publish: sp,
+			Message: fmt.Sprintf("Scheduled publish of version %s at %s is canceled", sp.VersionID, sp.PublishAt.Format(time.RFC3339)),
+		}, nil
+	}
+
+	addTool(r, &mcp.Tool{
+		Name:        "cancel_scheduled_publish",
+		Description: "Cancel a pending scheduled publish.",
+	}, handler)
+}
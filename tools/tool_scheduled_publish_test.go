@@ -0,0 +1,66 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/paolobietolini/gtm-mcp-server/auth"
+	"github.com/paolobietolini/gtm-mcp-server/gtm"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"golang.org/x/oauth2"
+)
+
+func TestSchedulePublish_RejectsCallerWithoutPublishScope(t *testing.T) {
+	old := grantedGoogleScopes
+	grantedGoogleScopes = func(ctx context.Context, tokenSource oauth2.TokenSource) ([]string, error) {
+		return []string{auth.GoogleScopeReadonly}, nil
+	}
+	defer func() { grantedGoogleScopes = old }()
+
+	scheduler, err := gtm.NewPublishScheduler(filepath.Join(t.TempDir(), "schedule.json"), slog.New(slog.NewTextHandler(io.Discard, nil)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	server := mcp.NewServer(&mcp.Implementation{Name: "test", Version: "0.0.0"}, nil)
+	if err := RegisterTools(server, ToolOptions{Scheduler: scheduler}); err != nil {
+		t.Fatalf("RegisterTools: %v", err)
+	}
+
+	ctx := context.Background()
+	serverTransport, clientTransport := mcp.NewInMemoryTransports()
+	tokenSource := oauth2.StaticTokenSource(&oauth2.Token{AccessToken: "read-only"})
+	serverSession, err := server.Connect(auth.LocalContext(ctx, tokenSource), serverTransport, nil)
+	if err != nil {
+		t.Fatalf("server connect: %v", err)
+	}
+	defer serverSession.Close()
+	client := mcp.NewClient(&mcp.Implementation{Name: "test-client", Version: "0.0.0"}, nil)
+	session, err := client.Connect(ctx, clientTransport, nil)
+	if err != nil {
+		t.Fatalf("client connect: %v", err)
+	}
+	defer session.Close()
+
+	res, err := session.CallTool(ctx, &mcp.CallToolParams{Name: "schedule_publish", Arguments: map[string]any{
+		"accountId":   "1",
+		"containerId": "2",
+		"versionId":   "3",
+		"publishAt":   time.Now().Add(time.Hour).UTC().Format(time.RFC3339),
+	}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var envelope struct{ Error ToolError }
+	if err := json.Unmarshal([]byte(res.Content[0].(*mcp.TextContent).Text), &envelope); err != nil || envelope.Error.Code != ErrorCodePermissionDenied {
+		t.Errorf("schedule_publish with a read-only grant returned %+v", res.Content[0])
+	}
+	if pending := scheduler.List("1", "2", true); len(pending) != 0 {
+		t.Errorf("publish was scheduled: %+v", pending)
+	}
+}
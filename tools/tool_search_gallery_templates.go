@@ -0,0 +1,51 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/paolobietolini/gtm-mcp-server/gtm"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// SearchGalleryTemplatesInput is the input for search_gallery_templates tool.
+type SearchGalleryTemplatesInput struct {
+	Query   string `json:"query" jsonschema:"description:Keywords such as a vendor or product name (e.g. 'iubenda', 'cookiebot', 'facebook pixel')"`
+	Kind    string `json:"kind,omitempty" jsonschema:"description:Only return templates of this kind: tag, variable or client (optional)"`
+	Context string `json:"context,omitempty" jsonschema:"description:Only return templates for this container type: web or server (optional)"`
+	Limit   int    `json:"limit,omitempty" jsonschema:"description:Maximum number of templates to return (optional, defaults to 10, at most 25)"`
+}
+
+// SearchGalleryTemplatesOutput is the output for search_gallery_templates tool.
+type SearchGalleryTemplatesOutput struct {
+	Templates []gtm.GalleryTemplate `json:"templates"`
+	Message   string                `json:"message"`
+}
+
+func registerSearchGalleryTemplates(r *toolRegistry) {
+	handler := func(ctx context.Context, req *mcp.CallToolRequest, input SearchGalleryTemplatesInput) (*mcp.CallToolResult, SearchGalleryTemplatesOutput, error) {
+		templates, err := r.gallery.Search(ctx, input.Query, gtm.GallerySearchOptions{
+			Kind:    input.Kind,
+			Context: input.Context,
+			Limit:   input.Limit,
+		})
+		if err != nil {
+			return nil, SearchGalleryTemplatesOutput{}, err
+		}
+
+		message := fmt.Sprintf("Found %d gallery templates for %q. Import one with import_gallery_template using its galleryOwner and galleryRepository.", len(templates), input.Query)
+		if len(templates) == 0 {
+			message = fmt.Sprintf("No gallery templates found for %q. Try fewer or different keywords, such as the vendor name alone.", input.Query)
+		}
+		return nil, SearchGalleryTemplatesOutput{
+			Templates: templates,
+			Message:   message,
+		}, nil
+	}
+
+	addTool(r, &mcp.Tool{
+		Name:        "search_gallery_templates",
+		Description: "Search the Community Template Gallery by keyword and return matching templates (galleryOwner/galleryRepository, display name, description, kind and web or server context), ready for import_gallery_template. Searches GitHub, where gallery templates live, and keeps only repositories holding a template.",
+	}, handler)
+}
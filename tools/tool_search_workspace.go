@@ -0,0 +1,59 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/paolobietolini/gtm-mcp-server/gtm"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// SearchWorkspaceInput is the input for search_workspace tool.
+type SearchWorkspaceInput struct {
+	AccountID        string   `json:"accountId" jsonschema:"description:The GTM account ID"`
+	ContainerID      string   `json:"containerId" jsonschema:"description:The GTM container ID"`
+	WorkspaceID      string   `json:"workspaceId" jsonschema:"description:The GTM workspace ID"`
+	Query            string   `json:"query" jsonschema:"description:Text to search for in names, notes, types and parameter values"`
+	Regex            bool     `json:"regex,omitempty" jsonschema:"description:Treat query as a regular expression"`
+	CaseSensitive    bool     `json:"caseSensitive,omitempty" jsonschema:"description:Match case exactly (default is case-insensitive)"`
+	EntityTypes      []string `json:"entityTypes,omitempty" jsonschema:"description:Entity kinds to search: tag, trigger, variable (optional, defaults to all three)"`
+	IncludeSensitive bool     `json:"includeSensitive,omitempty" jsonschema:"description:Search and return parameter values that look like secrets (API keys, tokens, passwords) instead of redacting them"`
+}
+
+// SearchWorkspaceOutput is the output for search_workspace tool.
+type SearchWorkspaceOutput struct {
+	Results *gtm.SearchResults `json:"results"`
+	Message string             `json:"message"`
+}
+
+func registerSearchWorkspace(r *toolRegistry) {
+	handler := func(ctx context.Context, req *mcp.CallToolRequest, input SearchWorkspaceInput) (*mcp.CallToolResult, SearchWorkspaceOutput, error) {
+		wc, err := resolveWorkspace(ctx, input.AccountID, input.ContainerID, input.WorkspaceID)
+		if err != nil {
+			return nil, SearchWorkspaceOutput{}, err
+		}
+
+		results, err := wc.Client.SearchWorkspace(ctx, wc.AccountID, wc.ContainerID, wc.WorkspaceID, gtm.SearchOptions{
+			Query:            input.Query,
+			Regex:            input.Regex,
+			CaseSensitive:    input.CaseSensitive,
+			EntityTypes:      input.EntityTypes,
+			IncludeSensitive: input.IncludeSensitive,
+		})
+		if err != nil {
+			return nil, SearchWorkspaceOutput{}, err
+		}
+
+		return nil, SearchWorkspaceOutput{
+			Results: results,
+			Message: fmt.Sprintf("Found %d matching entities (%d tags, %d triggers, %d variables)",
+				results.Total, len(results.Tags), len(results.Triggers), len(results.Variables)),
+		}, nil
+	}
+
+	addTool(r, &mcp.Tool{
+		Name:        "search_workspace",
+		Description: "Full text search across all tags, triggers and variables in a workspace. Matches names, notes, types and parameter values (including trigger filter conditions and Custom HTML) and returns matches grouped by entity type with the matching fields. Values that look like secrets are redacted before matching unless includeSensitive is true.",
+	}, handler)
+}
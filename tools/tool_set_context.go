@@ -0,0 +1,124 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/paolobietolini/gtm-mcp-server/gtm"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	tagmanager "google.golang.org/api/tagmanager/v2"
+)
+
+// SetContextInput is the input for set_context tool.
+type SetContextInput struct {
+	Alias       string `json:"alias,omitempty" jsonschema:"description:A configured workspace alias such as 'prod web'"`
+	AccountID   string `json:"accountId,omitempty" jsonschema:"description:The GTM account ID (instead of alias)"`
+	ContainerID string `json:"containerId,omitempty" jsonschema:"description:The GTM container ID"`
+	WorkspaceID string `json:"workspaceId,omitempty" jsonschema:"description:The GTM workspace ID (optional, omit to default only the account and container)"`
+	Clear       bool   `json:"clear,omitempty" jsonschema:"description:Remove the context, including the server default, so every call passes its IDs again"`
+}
+
+// SetContextOutput is the output for set_context tool.
+type SetContextOutput struct {
+	Context       *WorkspaceRef `json:"context,omitempty"`
+	Alias         string        `json:"alias,omitempty"`
+	ContainerName string        `json:"containerName,omitempty"`
+	PublicID      string        `json:"publicId,omitempty"`
+	WorkspaceName string        `json:"workspaceName,omitempty"`
+	Aliases       []string      `json:"aliases,omitempty"`
+	Message       string        `json:"message"`
+}
+
+func registerSetContext(r *toolRegistry) {
+	handler := func(ctx context.Context, req *mcp.CallToolRequest, input SetContextInput) (*mcp.CallToolResult, SetContextOutput, error) {
+		out := SetContextOutput{Aliases: r.contexts.config.aliasNames()}
+		ids := WorkspaceRef{AccountID: input.AccountID, ContainerID: input.ContainerID, WorkspaceID: input.WorkspaceID}
+
+		var ref WorkspaceRef
+		var alias string
+		switch {
+		case input.Clear:
+			if input.Alias != "" || ids != (WorkspaceRef{}) {
+				return nil, out, gtm.InvalidField("clear", "clear cannot be combined with alias or IDs")
+			}
+			r.contexts.set(ctx, WorkspaceRef{}, "")
+			out.Message = "Workspace context cleared; pass accountId, containerId and workspaceId to every call"
+			return nil, out, nil
+		case input.Alias != "":
+			if ids != (WorkspaceRef{}) {
+				return nil, out, gtm.InvalidField("alias", "pass either alias or IDs, not both")
+			}
+			var ok bool
+			alias, ref, ok = r.contexts.config.alias(input.Alias)
+			if !ok {
+				return nil, out, gtm.InvalidField("alias", "unknown alias %q (configured: %v)", input.Alias, out.Aliases)
+			}
+		case ids != (WorkspaceRef{}):
+			if err := ids.validate("context"); err != nil {
+				return nil, out, err
+			}
+			ref = ids
+		default:
+			// Report the current context
+			current, currentAlias, ok := r.contexts.current(ctx)
+			if !ok {
+				out.Message = "No workspace context; pass an alias or IDs to set one"
+				return nil, out, nil
+			}
+			out.Context, out.Alias = &current, currentAlias
+			out.Message = fmt.Sprintf("Calls default to %s", describeContext(current, currentAlias))
+			return nil, out, nil
+		}
+
+		// Look the IDs up so a transposed ID fails now rather than in a later call
+		if err := describeWorkspace(ctx, ref, &out); err != nil {
+			return nil, out, err
+		}
+		r.contexts.set(ctx, ref, alias)
+		out.Context, out.Alias = &ref, alias
+		out.Message = fmt.Sprintf("Calls now default to %s; tools taking accountId, containerId or workspaceId can omit them", describeContext(ref, alias))
+		return nil, out, nil
+	}
+
+	addTool(r, &mcp.Tool{
+		Name:        "set_context",
+		Description: "Set the default account, container and workspace of this session, by configured alias (e.g. 'prod web') or by IDs, so later calls can omit accountId, containerId and workspaceId. IDs a call passes explicitly always win. Call without arguments to show the current context and the configured aliases, or with clear: true to remove it.",
+	}, handler)
+}
+
+// describeWorkspace fetches the container and workspace of ref and records
+// their names in out.
+func describeWorkspace(ctx context.Context, ref WorkspaceRef, out *SetContextOutput) error {
+	client, err := resolveAccount(ctx, ref.AccountID)
+	if err != nil {
+		return err
+	}
+	if ref.ContainerID == "" {
+		return nil
+	}
+	container, err := client.GetContainer(ctx, gtm.BuildContainerPath(ref.AccountID, ref.ContainerID))
+	if err != nil {
+		return err
+	}
+	out.ContainerName, out.PublicID = container.Name, container.PublicID
+	if ref.WorkspaceID == "" {
+		return nil
+	}
+	path := gtm.BuildWorkspacePath(ref.AccountID, ref.ContainerID, ref.WorkspaceID)
+	workspace, err := gtm.RetryWithBackoff(ctx, func() (*tagmanager.Workspace, error) {
+		return client.Service.Accounts.Containers.Workspaces.Get(path).Context(ctx).Do()
+	})
+	if err != nil {
+		return gtm.MapGoogleError(err)
+	}
+	out.WorkspaceName = workspace.Name
+	return nil
+}
+
+func describeContext(ref WorkspaceRef, alias string) string {
+	if alias != "" {
+		return fmt.Sprintf("%q (%s)", alias, ref)
+	}
+	return ref.String()
+}
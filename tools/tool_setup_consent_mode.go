@@ -0,0 +1,104 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/paolobietolini/gtm-mcp-server/gtm"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// SetupConsentModeInput is the input for setup_consent_mode tool.
+type SetupConsentModeInput struct {
+	AccountID        string                `json:"accountId" jsonschema:"description:The GTM account ID"`
+	ContainerID      string                `json:"containerId" jsonschema:"description:The GTM container ID"`
+	WorkspaceID      string                `json:"workspaceId" jsonschema:"description:The GTM workspace ID"`
+	Defaults         []gtm.ConsentDefaults `json:"defaults" jsonschema:"description:Consent defaults: one entry without regions for every visitor, plus entries for specific regions (e.g. all denied for the EEA, granted elsewhere)"`
+	WaitForUpdate    int                   `json:"waitForUpdate,omitempty" jsonschema:"description:Milliseconds Google tags wait for the consent banner's update (e.g. 500; 0 does not wait)"`
+	AdsDataRedaction bool                  `json:"adsDataRedaction,omitempty" jsonschema:"description:Redact ad click identifiers while ad_storage is denied"`
+	URLPassthrough   bool                  `json:"urlPassthrough,omitempty" jsonschema:"description:Pass ad click information through URLs while storage is denied"`
+	UpdateEvent      string                `json:"updateEvent,omitempty" jsonschema:"description:Data layer event your consent banner pushes with the user's choices in a consent object (e.g. consent_update). When set, an update tag, its trigger and data layer variables are created too"`
+	Confirm          bool                  `json:"confirm" jsonschema:"description:Must be true to write the changes. When false the changes and the generated consent commands are listed without changing anything."`
+}
+
+// SetupConsentModeOutput is the output for setup_consent_mode tool.
+type SetupConsentModeOutput struct {
+	Success bool `json:"success"`
+	DryRun  bool `json:"dryRun,omitempty"`
+	gtm.ChangesetResult
+	Planned        []gtm.ChangesetStep     `json:"planned,omitempty"`
+	Existing       []gtm.BlueprintExisting `json:"existing,omitempty"`
+	DefaultTagHTML string                  `json:"defaultTagHtml"`
+	Message        string                  `json:"message"`
+}
+
+func registerSetupConsentMode(r *toolRegistry) {
+	handler := func(ctx context.Context, req *mcp.CallToolRequest, input SetupConsentModeInput) (*mcp.CallToolResult, SetupConsentModeOutput, error) {
+		setup := gtm.ConsentModeSetup{
+			Defaults:         input.Defaults,
+			WaitForUpdate:    input.WaitForUpdate,
+			AdsDataRedaction: input.AdsDataRedaction,
+			URLPassthrough:   input.URLPassthrough,
+			UpdateEvent:      input.UpdateEvent,
+		}
+		if err := setup.Validate(); err != nil {
+			return nil, SetupConsentModeOutput{}, err
+		}
+		wc, err := resolveWorkspace(ctx, input.AccountID, input.ContainerID, input.WorkspaceID)
+		if err != nil {
+			return nil, SetupConsentModeOutput{}, err
+		}
+		existing, err := wc.Client.ListWorkspaceEntities(ctx, wc.AccountID, wc.ContainerID, wc.WorkspaceID)
+		if err != nil {
+			return nil, SetupConsentModeOutput{}, err
+		}
+		ops, reused, err := setup.Changeset(existing)
+		if err != nil {
+			return nil, SetupConsentModeOutput{}, err
+		}
+
+		out := SetupConsentModeOutput{
+			ChangesetResult: gtm.ChangesetResult{Applied: []gtm.ChangesetStep{}},
+			Existing:        reused,
+			DefaultTagHTML:  setup.DefaultTagHTML(),
+		}
+
+		// Safety guard: list the changes first
+		if !input.Confirm {
+			planned, err := wc.Client.PlanChangeset(ctx, wc.AccountID, wc.ContainerID, wc.WorkspaceID, ops)
+			if err != nil {
+				return nil, SetupConsentModeOutput{}, err
+			}
+			out.DryRun = true
+			out.Planned = planned
+			out.Message = fmt.Sprintf("Dry run: %d changes listed. Call again with confirm: true to apply them; if one fails, the ones before it are rolled back.", len(planned))
+			return nil, out, nil
+		}
+
+		result, err := wc.Client.ApplyChangeset(ctx, wc.AccountID, wc.ContainerID, wc.WorkspaceID, ops)
+		if result == nil {
+			return nil, SetupConsentModeOutput{}, err
+		}
+		out.ChangesetResult = *result
+		if err == nil {
+			out.Success = true
+			out.Message = fmt.Sprintf("Consent mode set up with %d changes. Set consent checks on your tags and test in preview mode before publishing.", len(result.Applied))
+			return nil, out, nil
+		}
+
+		out.Message = err.Error()
+		for _, step := range result.RolledBack {
+			if step.Error != "" {
+				out.Message += ". WARNING: some changes could not be rolled back; see rolledBack for the entities to fix by hand"
+				break
+			}
+		}
+		return nil, out, nil
+	}
+
+	addTool(r, &mcp.Tool{
+		Name:        "setup_consent_mode",
+		Description: "Provision Consent Mode v2 scaffolding in a web workspace in one call: a Custom HTML tag on the built-in Consent Initialization - All Pages trigger issuing gtag consent default commands, with global and region-specific defaults, wait_for_update, ads_data_redaction and url_passthrough. Rerunning updates that tag. With updateEvent also creates an update tag reading the consent banner's data layer event. Without confirm: true only lists the changes.",
+	}, handler)
+}
@@ -0,0 +1,54 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/paolobietolini/gtm-mcp-server/gtm"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// SetupGA4BasicInput is the input for setup_ga4_basic tool.
+type SetupGA4BasicInput struct {
+	AccountID     string `json:"accountId" jsonschema:"description:The GTM account ID"`
+	ContainerID   string `json:"containerId" jsonschema:"description:The GTM container ID"`
+	WorkspaceID   string `json:"workspaceId" jsonschema:"description:The GTM workspace ID"`
+	MeasurementID string `json:"measurementId" jsonschema:"description:GA4 measurement ID (e.g. G-ABC123XYZ9)"`
+}
+
+// SetupGA4BasicOutput is the output for setup_ga4_basic tool.
+type SetupGA4BasicOutput struct {
+	Success bool `json:"success"`
+	gtm.GA4SetupResult
+	Message string `json:"message"`
+}
+
+func registerSetupGA4Basic(r *toolRegistry) {
+	handler := func(ctx context.Context, req *mcp.CallToolRequest, input SetupGA4BasicInput) (*mcp.CallToolResult, SetupGA4BasicOutput, error) {
+		if !gtm.MeasurementIDPattern.MatchString(input.MeasurementID) {
+			return nil, SetupGA4BasicOutput{}, gtm.InvalidField("measurementId", "measurementId must look like G-ABC123XYZ9")
+		}
+		wc, err := resolveWorkspace(ctx, input.AccountID, input.ContainerID, input.WorkspaceID)
+		if err != nil {
+			return nil, SetupGA4BasicOutput{}, err
+		}
+
+		result, err := wc.Client.SetupGA4Basic(ctx, wc.AccountID, wc.ContainerID, wc.WorkspaceID, input.MeasurementID)
+		if err != nil {
+			return nil, SetupGA4BasicOutput{}, err
+		}
+
+		message := fmt.Sprintf("GA4 %s is set up: created %d entities, %d already existed, enabled %d built-in variables",
+			input.MeasurementID, len(result.Created), len(result.Existing), len(result.EnabledBuiltInVariables))
+		if len(result.Created) == 0 && len(result.EnabledBuiltInVariables) == 0 {
+			message = fmt.Sprintf("GA4 %s was already set up; nothing changed", input.MeasurementID)
+		}
+		return nil, SetupGA4BasicOutput{Success: true, GA4SetupResult: *result, Message: message}, nil
+	}
+
+	addTool(r, &mcp.Tool{
+		Name:        "setup_ga4_basic",
+		Description: "One-shot GA4 setup for a web workspace: creates a constant variable holding the measurement ID and a Google tag firing on the built-in Initialization - All Pages trigger, and enables the standard built-in variables (Page URL, Page Hostname, Page Path, Referrer, Event). Idempotent: anything already in place, including a Google tag for the same ID under another name, is skipped. Returns what was created. Publish or create a version afterwards to make it live.",
+	}, handler)
+}
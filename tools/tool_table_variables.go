@@ -0,0 +1,108 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/paolobietolini/gtm-mcp-server/gtm"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// CreateLookupTableVariableInput is the input for create_lookup_table_variable tool.
+type CreateLookupTableVariableInput struct {
+	AccountID      string           `json:"accountId" jsonschema:"description:The GTM account ID"`
+	ContainerID    string           `json:"containerId" jsonschema:"description:The GTM container ID"`
+	WorkspaceID    string           `json:"workspaceId" jsonschema:"description:The GTM workspace ID"`
+	Name           string           `json:"name" jsonschema:"description:Variable name"`
+	Input          string           `json:"input" jsonschema:"description:Variable whose value is looked up, e.g. {{Page Hostname}} (braces optional)"`
+	Rows           []gtm.TableRow   `json:"rows" jsonschema:"description:Rows mapping input values to output values"`
+	DefaultValue   *string          `json:"defaultValue,omitempty" jsonschema:"description:Output when no row matches (optional; without it the variable is undefined)"`
+	Notes          string           `json:"notes,omitempty" jsonschema:"description:Variable notes (optional)"`
+	FormatValue    *gtm.FormatValue `json:"formatValue,omitempty" jsonschema:"description:Output formatting: caseConversionType and values to convert null, undefined, true or false to (optional)"`
+	ParentFolderID string           `json:"parentFolderId,omitempty" jsonschema:"description:Folder ID to create the variable in (optional, defaults to the workspace root)"`
+}
+
+// CreateRegexTableVariableInput is the input for create_regex_table_variable tool.
+type CreateRegexTableVariableInput struct {
+	AccountID    string         `json:"accountId" jsonschema:"description:The GTM account ID"`
+	ContainerID  string         `json:"containerId" jsonschema:"description:The GTM container ID"`
+	WorkspaceID  string         `json:"workspaceId" jsonschema:"description:The GTM workspace ID"`
+	Name         string         `json:"name" jsonschema:"description:Variable name"`
+	Input        string         `json:"input" jsonschema:"description:Variable matched against the patterns, e.g. {{Page Path}} (braces optional)"`
+	Rows         []gtm.TableRow `json:"rows" jsonschema:"description:Rows mapping regular expressions to output values, checked in order; the first match wins"`
+	DefaultValue *string        `json:"defaultValue,omitempty" jsonschema:"description:Output when no pattern matches (optional; without it the variable is undefined)"`
+	gtm.RegexTableOptions
+	Notes          string           `json:"notes,omitempty" jsonschema:"description:Variable notes (optional)"`
+	FormatValue    *gtm.FormatValue `json:"formatValue,omitempty" jsonschema:"description:Output formatting: caseConversionType and values to convert null, undefined, true or false to (optional)"`
+	ParentFolderID string           `json:"parentFolderId,omitempty" jsonschema:"description:Folder ID to create the variable in (optional, defaults to the workspace root)"`
+}
+
+func registerCreateLookupTableVariable(r *toolRegistry) {
+	handler := func(ctx context.Context, req *mcp.CallToolRequest, input CreateLookupTableVariableInput) (*mcp.CallToolResult, CreateVariableOutput, error) {
+		params, err := gtm.LookupTableParameters(input.Input, input.Rows, input.DefaultValue)
+		if err != nil {
+			return nil, CreateVariableOutput{}, err
+		}
+		return createTableVariable(ctx, input.AccountID, input.ContainerID, input.WorkspaceID, &gtm.VariableInput{
+			Name:           input.Name,
+			Type:           gtm.VariableTypeLookupTable,
+			Parameter:      params,
+			Notes:          input.Notes,
+			ParentFolderId: input.ParentFolderID,
+			FormatValue:    input.FormatValue,
+		}, fmt.Sprintf("Lookup table variable created with %d rows", len(input.Rows)))
+	}
+
+	addTool(r, &mcp.Tool{
+		Name:        "create_lookup_table_variable",
+		Description: "Create a Lookup Table variable (type smm) that maps exact values of an input variable to output values, e.g. hostnames to GA4 measurement IDs. Takes plain key/value rows and an optional default value and builds the map parameters for you.",
+	}, handler)
+}
+
+func registerCreateRegexTableVariable(r *toolRegistry) {
+	handler := func(ctx context.Context, req *mcp.CallToolRequest, input CreateRegexTableVariableInput) (*mcp.CallToolResult, CreateVariableOutput, error) {
+		params, err := gtm.RegexTableParameters(input.Input, input.Rows, input.DefaultValue, input.RegexTableOptions)
+		if err != nil {
+			return nil, CreateVariableOutput{}, err
+		}
+		return createTableVariable(ctx, input.AccountID, input.ContainerID, input.WorkspaceID, &gtm.VariableInput{
+			Name:           input.Name,
+			Type:           gtm.VariableTypeRegexTable,
+			Parameter:      params,
+			Notes:          input.Notes,
+			ParentFolderId: input.ParentFolderID,
+			FormatValue:    input.FormatValue,
+		}, fmt.Sprintf("Regex table variable created with %d rows", len(input.Rows)))
+	}
+
+	addTool(r, &mcp.Tool{
+		Name:        "create_regex_table_variable",
+		Description: "Create a RegEx Table variable (type remm) that matches an input variable against regular expressions in order and returns the value of the first matching row, e.g. page paths to content groups. Matching is full and case-insensitive with capture groups ($1) enabled unless partialMatch, caseSensitive or noCaptureGroups is set. Patterns use JavaScript regex syntax.",
+	}, handler)
+}
+
+// createTableVariable creates a lookup or regex table variable built by the
+// tools above.
+func createTableVariable(ctx context.Context, accountID, containerID, workspaceID string, variableInput *gtm.VariableInput, message string) (*mcp.CallToolResult, CreateVariableOutput, error) {
+	wc, err := resolveWorkspace(ctx, accountID, containerID, workspaceID)
+	if err != nil {
+		return nil, CreateVariableOutput{}, err
+	}
+	if err := gtm.ValidateVariableInput(variableInput.Name, variableInput.Type); err != nil {
+		return nil, CreateVariableOutput{}, err
+	}
+	if err := gtm.ValidateFormatValue(variableInput.FormatValue); err != nil {
+		return nil, CreateVariableOutput{}, err
+	}
+
+	variable, err := wc.Client.CreateVariable(ctx, wc.AccountID, wc.ContainerID, wc.WorkspaceID, variableInput)
+	if err != nil {
+		return nil, CreateVariableOutput{}, err
+	}
+	return nil, CreateVariableOutput{
+		Success:  true,
+		Variable: *variable,
+		Message:  message,
+	}, nil
+}
@@ -0,0 +1,95 @@
+package tools
+
+import (
+	"context"
+
+	"github.com/paolobietolini/gtm-mcp-server/gtm"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+type ListTagsInput struct {
+	AccountID   string `json:"accountId" jsonschema:"description:The GTM account ID"`
+	ContainerID string `json:"containerId" jsonschema:"description:The GTM container ID"`
+	WorkspaceID string `json:"workspaceId" jsonschema:"description:The GTM workspace ID"`
+	PageToken   string `json:"pageToken,omitempty" jsonschema:"description:The nextPageToken of a previous response, to fetch the next page"`
+	PageSize    int    `json:"pageSize,omitempty" jsonschema:"description:Number of tags per page (default 100, max 500)"`
+	All         bool   `json:"all,omitempty" jsonschema:"description:Return every tag in one response, up to 2000, ignoring pageSize"`
+	Compact     bool   `json:"compact,omitempty" jsonschema:"description:Return only the ID, name and type of each tag in 'compact' instead of full tags"`
+}
+type ListTagsOutput struct {
+	Tags          []gtm.Tag           `json:"tags,omitempty"`
+	Compact       []gtm.CompactEntity `json:"compact,omitempty"`
+	Total         int                 `json:"total"`
+	NextPageToken string              `json:"nextPageToken,omitempty"`
+}
+
+type GetTagInput struct {
+	AccountID   string `json:"accountId" jsonschema:"description:The GTM account ID"`
+	ContainerID string `json:"containerId" jsonschema:"description:The GTM container ID"`
+	WorkspaceID string `json:"workspaceId" jsonschema:"description:The GTM workspace ID"`
+	TagID       string `json:"tagId,omitempty" jsonschema:"description:The tag ID to retrieve (or use tagName)"`
+	TagName     string `json:"tagName,omitempty" jsonschema:"description:The exact tag name, instead of tagId"`
+}
+type GetTagOutput struct {
+	Tag gtm.Tag `json:"tag"`
+}
+
+func registerListTags(r *toolRegistry) {
+	handler := func(ctx context.Context, req *mcp.CallToolRequest, input ListTagsInput) (*mcp.CallToolResult, ListTagsOutput, error) {
+		wc, err := resolveWorkspace(ctx, input.AccountID, input.ContainerID, input.WorkspaceID)
+		if err != nil {
+			return nil, ListTagsOutput{}, err
+		}
+
+		tags, err := wc.Client.ListTags(ctx, wc.AccountID, wc.ContainerID, wc.WorkspaceID)
+		if err != nil {
+			return nil, ListTagsOutput{}, err
+		}
+
+		page, next, err := gtm.Paginate(tags, input.PageToken, input.PageSize, input.All)
+		if err != nil {
+			return nil, ListTagsOutput{}, err
+		}
+
+		output := ListTagsOutput{Total: len(tags), NextPageToken: next}
+		if input.Compact {
+			output.Compact = gtm.CompactTags(page)
+		} else {
+			output.Tags = page
+		}
+
+		return nil, output, nil
+	}
+
+	addTool(r, &mcp.Tool{
+		Name:        "list_tags",
+		Description: "List the tags in a GTM workspace, 100 per page by default. Pass nextPageToken as pageToken to fetch the next page, or all=true for every tag (up to 2000). Use compact=true to get only IDs, names and types",
+	}, handler)
+}
+
+func registerGetTag(r *toolRegistry) {
+	handler := func(ctx context.Context, req *mcp.CallToolRequest, input GetTagInput) (*mcp.CallToolResult, GetTagOutput, error) {
+		wc, err := resolveWorkspace(ctx, input.AccountID, input.ContainerID, input.WorkspaceID)
+		if err != nil {
+			return nil, GetTagOutput{}, err
+		}
+
+		// Look the tag up by name if no ID was given
+		if input.TagID, err = wc.resolveTagID(ctx, input.TagID, input.TagName); err != nil {
+			return nil, GetTagOutput{}, err
+		}
+
+		tag, err := wc.Client.GetTag(ctx, wc.AccountID, wc.ContainerID, wc.WorkspaceID, input.TagID)
+		if err != nil {
+			return nil, GetTagOutput{}, err
+		}
+
+		return nil, GetTagOutput{Tag: *tag}, nil
+	}
+
+	addTool(r, &mcp.Tool{
+		Name:        "get_tag",
+		Description: "Get a specific tag by ID or exact name",
+	}, handler)
+}
@@ -0,0 +1,90 @@
+package tools
+
+import (
+	"context"
+
+	"github.com/paolobietolini/gtm-mcp-server/gtm"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+type GetTagTemplatesInput struct{}
+type GetTagTemplatesOutput struct {
+	Templates []gtm.TagTemplate `json:"templates"`
+	Usage     string            `json:"usage"`
+}
+
+func registerGetTagTemplates(r *toolRegistry) {
+	handler := func(ctx context.Context, req *mcp.CallToolRequest, input GetTagTemplatesInput) (*mcp.CallToolResult, GetTagTemplatesOutput, error) {
+		templates := gtm.GetTagTemplates()
+		return nil, GetTagTemplatesOutput{
+			Templates: templates,
+			Usage: `These templates show the correct parameter structure for creating GTM tags.
+
+IMPORTANT - Common mistakes to avoid:
+1. For GA4 Event tags (gaawe), prefer create_ga4_event_tag; with create_tag, use measurementIdOverride with an empty measurementId
+2. Event parameters use name/value pairs in maps, NOT direct key names
+3. For ecommerce, set sendEcommerceData=true and getEcommerceDataFrom=dataLayer
+
+Copy the parameters JSON and modify values as needed when calling create_tag.`,
+		}, nil
+	}
+
+	addTool(r, &mcp.Tool{
+		Name:        "get_tag_templates",
+		Description: "Get example parameter structures for creating GTM tags. Use this BEFORE creating GA4 or complex tags to see the correct parameter format.",
+	}, handler)
+}
+
+type GetVariableTemplatesInput struct{}
+type GetVariableTemplatesOutput struct {
+	Templates []gtm.VariableTemplate `json:"templates"`
+	Usage     string                 `json:"usage"`
+}
+
+func registerGetVariableTemplates(r *toolRegistry) {
+	handler := func(ctx context.Context, req *mcp.CallToolRequest, input GetVariableTemplatesInput) (*mcp.CallToolResult, GetVariableTemplatesOutput, error) {
+		templates := gtm.GetVariableTemplates()
+		return nil, GetVariableTemplatesOutput{
+			Templates: templates,
+			Usage: `These templates show the correct parameter structure for creating GTM variables.
+
+Google tag settings variables (gtes, gtcs) hold parameter groups as lists of maps:
+1. gtes: eventSettingsTable (parameter/parameterValue) and userProperties (name/value)
+2. gtcs: configSettingsTable (parameter/parameterValue)
+3. Link them from a googtag or gaawe tag via eventSettingsVariable / configSettingsVariable
+
+Copy the parameters JSON and modify values as needed when calling create_variable.`,
+		}, nil
+	}
+
+	addTool(r, &mcp.Tool{
+		Name:        "get_variable_templates",
+		Description: "Get example parameter structures for creating GTM variables, including Google tag Event Settings (gtes) and Configuration Settings (gtcs) variables.",
+	}, handler)
+}
+
+type GetTriggerTemplatesInput struct{}
+type GetTriggerTemplatesOutput struct {
+	Templates []gtm.TriggerTemplate `json:"templates"`
+	Usage     string                `json:"usage"`
+}
+
+func registerGetTriggerTemplates(r *toolRegistry) {
+	handler := func(ctx context.Context, req *mcp.CallToolRequest, input GetTriggerTemplatesInput) (*mcp.CallToolResult, GetTriggerTemplatesOutput, error) {
+		templates := gtm.GetTriggerTemplates()
+		return nil, GetTriggerTemplatesOutput{
+			Templates: templates,
+			Usage: `These templates show the correct structure for creating GTM triggers.
+
+For customEvent triggers, use customEventFilterJson parameter.
+For pageview triggers with conditions, use the filter parameter.
+For click/form triggers with conditions, use autoEventFilterJson parameter.`,
+		}, nil
+	}
+
+	addTool(r, &mcp.Tool{
+		Name:        "get_trigger_templates",
+		Description: "Get example structures for creating GTM triggers. Use this to see the correct format for different trigger types.",
+	}, handler)
+}
@@ -1,24 +1,27 @@
-package gtm
+package tools
 
 import (
 	"context"
 
+	"github.com/paolobietolini/gtm-mcp-server/gtm"
+
 	"github.com/modelcontextprotocol/go-sdk/mcp"
 )
 
 // -- List Transformations --
 
 type ListTransformationsInput struct {
-	AccountID   string `json:"accountId" jsonschema:"description:The GTM account ID"`
-	ContainerID string `json:"containerId" jsonschema:"description:The GTM container ID"`
-	WorkspaceID string `json:"workspaceId" jsonschema:"description:The GTM workspace ID"`
+	AccountID        string `json:"accountId" jsonschema:"description:The GTM account ID"`
+	ContainerID      string `json:"containerId" jsonschema:"description:The GTM container ID"`
+	WorkspaceID      string `json:"workspaceId" jsonschema:"description:The GTM workspace ID"`
+	IncludeSensitive bool   `json:"includeSensitive,omitempty" jsonschema:"description:Return parameter values that look like secrets (API keys, tokens, passwords) instead of redacting them"`
 }
 
 type ListTransformationsOutput struct {
-	Transformations []TransformationInfo `json:"transformations"`
+	Transformations []gtm.TransformationInfo `json:"transformations"`
 }
 
-func registerListTransformations(server *mcp.Server) {
+func registerListTransformations(r *toolRegistry) {
 	handler := func(ctx context.Context, req *mcp.CallToolRequest, input ListTransformationsInput) (*mcp.CallToolResult, ListTransformationsOutput, error) {
 		wc, err := resolveWorkspace(ctx, input.AccountID, input.ContainerID, input.WorkspaceID)
 		if err != nil {
@@ -30,10 +33,14 @@ func registerListTransformations(server *mcp.Server) {
 			return nil, ListTransformationsOutput{}, err
 		}
 
+		if !input.IncludeSensitive {
+			gtm.RedactTransformations(transformations)
+		}
+
 		return nil, ListTransformationsOutput{Transformations: transformations}, nil
 	}
 
-	mcp.AddTool(server, &mcp.Tool{
+	addTool(r, &mcp.Tool{
 		Name:        "list_transformations",
 		Description: "List all transformations in a GTM workspace (server-side containers only)",
 	}, handler)
@@ -46,13 +53,14 @@ type GetTransformationInput struct {
 	ContainerID      string `json:"containerId" jsonschema:"description:The GTM container ID"`
 	WorkspaceID      string `json:"workspaceId" jsonschema:"description:The GTM workspace ID"`
 	TransformationID string `json:"transformationId" jsonschema:"description:The transformation ID to retrieve"`
+	IncludeSensitive bool   `json:"includeSensitive,omitempty" jsonschema:"description:Return parameter values that look like secrets (API keys, tokens, passwords) instead of redacting them"`
 }
 
 type GetTransformationOutput struct {
-	Transformation TransformationInfo `json:"transformation"`
+	Transformation gtm.TransformationInfo `json:"transformation"`
 }
 
-func registerGetTransformation(server *mcp.Server) {
+func registerGetTransformation(r *toolRegistry) {
 	handler := func(ctx context.Context, req *mcp.CallToolRequest, input GetTransformationInput) (*mcp.CallToolResult, GetTransformationOutput, error) {
 		wc, err := resolveWorkspace(ctx, input.AccountID, input.ContainerID, input.WorkspaceID)
 		if err != nil {
@@ -64,10 +72,14 @@ func registerGetTransformation(server *mcp.Server) {
 			return nil, GetTransformationOutput{}, err
 		}
 
+		if !input.IncludeSensitive {
+			gtm.RedactAPIParameters(t.Parameter)
+		}
+
 		return nil, GetTransformationOutput{Transformation: *t}, nil
 	}
 
-	mcp.AddTool(server, &mcp.Tool{
+	addTool(r, &mcp.Tool{
 		Name:        "get_transformation",
 		Description: "Get a specific transformation by ID (server-side containers only)",
 	}, handler)
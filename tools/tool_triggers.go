@@ -0,0 +1,63 @@
+package tools
+
+import (
+	"context"
+
+	"github.com/paolobietolini/gtm-mcp-server/gtm"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+type ListTriggersInput struct {
+	AccountID        string `json:"accountId" jsonschema:"description:The GTM account ID"`
+	ContainerID      string `json:"containerId" jsonschema:"description:The GTM container ID"`
+	WorkspaceID      string `json:"workspaceId" jsonschema:"description:The GTM workspace ID"`
+	PageToken        string `json:"pageToken,omitempty" jsonschema:"description:The nextPageToken of a previous response, to fetch the next page"`
+	PageSize         int    `json:"pageSize,omitempty" jsonschema:"description:Number of triggers per page (default 100, max 500)"`
+	All              bool   `json:"all,omitempty" jsonschema:"description:Return every trigger in one response, up to 2000, ignoring pageSize"`
+	Compact          bool   `json:"compact,omitempty" jsonschema:"description:Return only the ID, name and type of each trigger in 'compact' instead of full triggers with their filters and parameters"`
+	IncludeSensitive bool   `json:"includeSensitive,omitempty" jsonschema:"description:Return parameter values that look like secrets (API keys, tokens, passwords) instead of redacting them"`
+}
+type ListTriggersOutput struct {
+	Triggers      []gtm.Trigger       `json:"triggers,omitempty"`
+	Compact       []gtm.CompactEntity `json:"compact,omitempty"`
+	Total         int                 `json:"total"`
+	NextPageToken string              `json:"nextPageToken,omitempty"`
+}
+
+func registerListTriggers(r *toolRegistry) {
+	handler := func(ctx context.Context, req *mcp.CallToolRequest, input ListTriggersInput) (*mcp.CallToolResult, ListTriggersOutput, error) {
+		wc, err := resolveWorkspace(ctx, input.AccountID, input.ContainerID, input.WorkspaceID)
+		if err != nil {
+			return nil, ListTriggersOutput{}, err
+		}
+
+		triggers, err := wc.Client.ListTriggers(ctx, wc.AccountID, wc.ContainerID, wc.WorkspaceID)
+		if err != nil {
+			return nil, ListTriggersOutput{}, err
+		}
+
+		page, next, err := gtm.Paginate(triggers, input.PageToken, input.PageSize, input.All)
+		if err != nil {
+			return nil, ListTriggersOutput{}, err
+		}
+
+		output := ListTriggersOutput{Total: len(triggers), NextPageToken: next}
+		if input.Compact {
+			output.Compact = gtm.CompactTriggers(page)
+			return nil, output, nil
+		}
+
+		if !input.IncludeSensitive {
+			gtm.RedactTriggers(page)
+		}
+		output.Triggers = page
+
+		return nil, output, nil
+	}
+
+	addTool(r, &mcp.Tool{
+		Name:        "list_triggers",
+		Description: "List the triggers in a GTM workspace, 100 per page by default. Pass nextPageToken as pageToken to fetch the next page, or all=true for every trigger (up to 2000). Use compact=true to get only IDs, names and types instead of full filters and parameters",
+	}, handler)
+}
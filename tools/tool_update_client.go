@@ -0,0 +1,79 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/paolobietolini/gtm-mcp-server/gtm"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// UpdateClientInput is the input for update_client tool.
+type UpdateClientInput struct {
+	AccountID      string          `json:"accountId" jsonschema:"description:The GTM account ID"`
+	ContainerID    string          `json:"containerId" jsonschema:"description:The GTM container ID"`
+	WorkspaceID    string          `json:"workspaceId" jsonschema:"description:The GTM workspace ID"`
+	ClientID       string          `json:"clientId" jsonschema:"description:The client ID to update"`
+	Name           string          `json:"name" jsonschema:"description:Client name"`
+	Type           string          `json:"type" jsonschema:"description:Client type"`
+	Priority       int64           `json:"priority,omitempty" jsonschema:"description:Client priority (optional, higher runs first)"`
+	Parameters     []gtm.Parameter `json:"parameters,omitempty" jsonschema:"description:Client parameters (optional)"`
+	ParametersJSON string          `json:"parametersJson,omitempty" jsonschema:"description:Client parameters as a JSON array string (optional, legacy alternative to parameters)"`
+	Notes          string          `json:"notes,omitempty" jsonschema:"description:Client notes (optional)"`
+}
+
+// UpdateClientOutput is the output for update_client tool.
+type UpdateClientOutput struct {
+	Success bool              `json:"success"`
+	Client  gtm.CreatedClient `json:"client"`
+	Message string            `json:"message"`
+}
+
+func registerUpdateClient(r *toolRegistry) {
+	handler := func(ctx context.Context, req *mcp.CallToolRequest, input UpdateClientInput) (*mcp.CallToolResult, UpdateClientOutput, error) {
+		wc, err := resolveWorkspace(ctx, input.AccountID, input.ContainerID, input.WorkspaceID)
+		if err != nil {
+			return nil, UpdateClientOutput{}, err
+		}
+
+		if input.ClientID == "" {
+			return nil, UpdateClientOutput{}, fmt.Errorf("client ID is required")
+		}
+
+		if err := gtm.ValidateClientInput(input.Name, input.Type); err != nil {
+			return nil, UpdateClientOutput{}, err
+		}
+
+		path := gtm.BuildClientPath(wc.AccountID, wc.ContainerID, wc.WorkspaceID, input.ClientID)
+
+		params, err := gtm.ParametersInput("parameters", input.Parameters, "parametersJson", input.ParametersJSON)
+		if err != nil {
+			return nil, UpdateClientOutput{}, err
+		}
+
+		clientInput := &gtm.ClientInput{
+			Name:      input.Name,
+			Type:      input.Type,
+			Priority:  input.Priority,
+			Parameter: params,
+			Notes:     input.Notes,
+		}
+
+		cl, err := wc.Client.UpdateClient(ctx, path, clientInput)
+		if err != nil {
+			return nil, UpdateClientOutput{}, err
+		}
+
+		return nil, UpdateClientOutput{
+			Success: true,
+			Client:  *cl,
+			Message: "Client updated successfully",
+		}, nil
+	}
+
+	addTool(r, &mcp.Tool{
+		Name:        "update_client",
+		Description: "Update an existing client. Automatically handles fingerprint for concurrency control. Server-side containers only.",
+	}, handler)
+}
@@ -0,0 +1,111 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/paolobietolini/gtm-mcp-server/gtm"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// UpdateTagInput is the input for update_tag tool.
+// Omitted fields keep their current value.
+type UpdateTagInput struct {
+	AccountID          string          `json:"accountId" jsonschema:"description:The GTM account ID"`
+	ContainerID        string          `json:"containerId" jsonschema:"description:The GTM container ID"`
+	WorkspaceID        string          `json:"workspaceId" jsonschema:"description:The GTM workspace ID"`
+	TagID              string          `json:"tagId,omitempty" jsonschema:"description:The tag ID to update (or use tagName)"`
+	TagName            string          `json:"tagName,omitempty" jsonschema:"description:The exact tag name, instead of tagId"`
+	Name               *string         `json:"name,omitempty" jsonschema:"description:New tag name (optional)"`
+	Type               *string         `json:"type,omitempty" jsonschema:"description:New tag type (optional)"`
+	FiringTriggerIDs   []string        `json:"firingTriggerIds,omitempty" jsonschema:"description:Replace the trigger IDs that fire this tag (optional)"`
+	BlockingTriggerIDs []string        `json:"blockingTriggerIds,omitempty" jsonschema:"description:Replace the trigger IDs that block this tag (optional)"`
+	Parameters         []gtm.Parameter `json:"parameters,omitempty" jsonschema:"description:Replace all tag parameters (optional). Each parameter: {type, key, value} or {type, key, list/map}, with values as strings"`
+	ParametersJSON     string          `json:"parametersJson,omitempty" jsonschema:"description:Replace all tag parameters with this JSON array string (optional, legacy alternative to parameters)"`
+	Notes              *string         `json:"notes,omitempty" jsonschema:"description:New tag notes (optional)"`
+	Paused             *bool           `json:"paused,omitempty" jsonschema:"description:Pause (true) or unpause (false) the tag (optional)"`
+	ScheduleStart      string          `json:"scheduleStart,omitempty" jsonschema:"description:When the tag starts firing, ISO 8601 (e.g. 2026-03-01T09:00). Optional."`
+	ScheduleEnd        string          `json:"scheduleEnd,omitempty" jsonschema:"description:When the tag stops firing, ISO 8601 (e.g. 2026-03-31T23:59). Must be after the start. Optional."`
+	ScheduleTimezone   string          `json:"scheduleTimezone,omitempty" jsonschema:"description:IANA time zone for scheduleStart/scheduleEnd without a UTC offset (e.g. Europe/Rome). Defaults to UTC."`
+	Priority           *int            `json:"priority,omitempty" jsonschema:"description:New tag firing priority; higher numbers fire first when tags share a trigger (optional)"`
+	ConsentStatus      string          `json:"consentStatus,omitempty" jsonschema:"description:Replace the additional consent checks: notSet, notNeeded or needed (optional; defaults to needed when consentTypes is given)"`
+	ConsentTypes       []string        `json:"consentTypes,omitempty" jsonschema:"description:Consent types that must be granted for the tag to fire when consentStatus is needed (e.g. ad_storage, analytics_storage, ad_user_data, ad_personalization)"`
+	ParentFolderID     *string         `json:"parentFolderId,omitempty" jsonschema:"description:Move the tag to this folder ID (optional; clear parentFolderId to move it to the workspace root)"`
+	Clear              []string        `json:"clear,omitempty" jsonschema:"description:Fields to remove from the tag: notes, firingTriggerIds, blockingTriggerIds, parameters, scheduleStart, scheduleEnd, consentSettings, priority, parentFolderId (optional)"`
+}
+
+// UpdateTagOutput is the output for update_tag tool.
+type UpdateTagOutput struct {
+	Success bool           `json:"success"`
+	Tag     gtm.CreatedTag `json:"tag"`
+	Message string         `json:"message"`
+}
+
+func registerUpdateTag(r *toolRegistry) {
+	handler := func(ctx context.Context, req *mcp.CallToolRequest, input UpdateTagInput) (*mcp.CallToolResult, UpdateTagOutput, error) {
+		wc, err := resolveWorkspace(ctx, input.AccountID, input.ContainerID, input.WorkspaceID)
+		if err != nil {
+			return nil, UpdateTagOutput{}, err
+		}
+
+		// Look the tag up by name if no ID was given
+		if input.TagID, err = wc.resolveTagID(ctx, input.TagID, input.TagName); err != nil {
+			return nil, UpdateTagOutput{}, err
+		}
+
+		path := gtm.BuildTagPath(wc.AccountID, wc.ContainerID, wc.WorkspaceID, input.TagID)
+
+		patch := &gtm.TagPatch{
+			Name:              input.Name,
+			Type:              input.Type,
+			FiringTriggerId:   input.FiringTriggerIDs,
+			BlockingTriggerId: input.BlockingTriggerIDs,
+			Notes:             input.Notes,
+			Paused:            input.Paused,
+			Priority:          input.Priority,
+			ParentFolderId:    input.ParentFolderID,
+			Clear:             input.Clear,
+		}
+
+		if patch.Parameter, err = gtm.ParametersInput("parameters", input.Parameters, "parametersJson", input.ParametersJSON); err != nil {
+			return nil, UpdateTagOutput{}, err
+		}
+
+		// Convert ISO 8601 schedule times to the epoch millis GTM expects
+		if input.ScheduleStart != "" {
+			ms, err := gtm.ParseScheduleTime(input.ScheduleStart, input.ScheduleTimezone)
+			if err != nil {
+				return nil, UpdateTagOutput{}, fmt.Errorf("scheduleStart: %w", err)
+			}
+			patch.ScheduleStartMs = &ms
+		}
+		if input.ScheduleEnd != "" {
+			ms, err := gtm.ParseScheduleTime(input.ScheduleEnd, input.ScheduleTimezone)
+			if err != nil {
+				return nil, UpdateTagOutput{}, fmt.Errorf("scheduleEnd: %w", err)
+			}
+			patch.ScheduleEndMs = &ms
+		}
+
+		if patch.ConsentSettings, err = gtm.ParseConsentSettings(input.ConsentStatus, input.ConsentTypes); err != nil {
+			return nil, UpdateTagOutput{}, err
+		}
+
+		tag, err := wc.Client.UpdateTag(ctx, path, patch)
+		if err != nil {
+			return nil, UpdateTagOutput{}, err
+		}
+
+		return nil, UpdateTagOutput{
+			Success: true,
+			Tag:     *tag,
+			Message: "Tag updated successfully",
+		}, nil
+	}
+
+	addTool(r, &mcp.Tool{
+		Name:        "update_tag",
+		Description: "Update an existing tag. Only the fields you supply are changed; everything else (consent settings, schedule, folder, sequencing) is kept; set parentFolderId to move it into a folder. List fields in clear to remove them. Automatically handles fingerprint for concurrency control.",
+	}, handler)
+}
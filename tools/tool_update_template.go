@@ -1,9 +1,11 @@
-package gtm
+package tools
 
 import (
 	"context"
 	"fmt"
 
+	"github.com/paolobietolini/gtm-mcp-server/gtm"
+
 	"github.com/modelcontextprotocol/go-sdk/mcp"
 	tagmanager "google.golang.org/api/tagmanager/v2"
 )
@@ -30,7 +32,7 @@ type UpdateTemplateOutput struct {
 	Message       string `json:"message"`
 }
 
-func registerUpdateTemplate(server *mcp.Server) {
+func registerUpdateTemplate(r *toolRegistry) {
 	handler := func(ctx context.Context, req *mcp.CallToolRequest, input UpdateTemplateInput) (*mcp.CallToolResult, UpdateTemplateOutput, error) {
 		wc, err := resolveWorkspace(ctx, input.AccountID, input.ContainerID, input.WorkspaceID)
 		if err != nil {
@@ -49,7 +51,7 @@ func registerUpdateTemplate(server *mcp.Server) {
 		// Get current template to get fingerprint and current values
 		current, err := wc.Client.Service.Accounts.Containers.Workspaces.Templates.Get(path).Context(ctx).Do()
 		if err != nil {
-			return nil, UpdateTemplateOutput{}, mapGoogleError(err)
+			return nil, UpdateTemplateOutput{}, gtm.MapGoogleError(err)
 		}
 
 		// Build update with current values as defaults
@@ -69,7 +71,7 @@ func registerUpdateTemplate(server *mcp.Server) {
 
 		updated, err := wc.Client.Service.Accounts.Containers.Workspaces.Templates.Update(path, template).Context(ctx).Do()
 		if err != nil {
-			return nil, UpdateTemplateOutput{}, mapGoogleError(err)
+			return nil, UpdateTemplateOutput{}, gtm.MapGoogleError(err)
 		}
 
 		// Determine type
@@ -90,7 +92,7 @@ func registerUpdateTemplate(server *mcp.Server) {
 		}, nil
 	}
 
-	mcp.AddTool(server, &mcp.Tool{
+	addTool(r, &mcp.Tool{
 		Name:        "update_template",
 		Description: "Update an existing custom template. Automatically handles fingerprint for concurrency control. Note: Updating gallery templates may break the link to the gallery. IMPORTANT: To change the visible template name, update the 'displayName' field inside the ___INFO___ section of templateData - the 'name' parameter is only an internal identifier.",
 	}, handler)
@@ -0,0 +1,124 @@
+package tools
+
+import (
+	"context"
+
+	"github.com/paolobietolini/gtm-mcp-server/gtm"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// UpdateTriggerInput is the input for update_trigger tool.
+type UpdateTriggerInput struct {
+	AccountID                   string               `json:"accountId" jsonschema:"description:The GTM account ID"`
+	ContainerID                 string               `json:"containerId" jsonschema:"description:The GTM container ID"`
+	WorkspaceID                 string               `json:"workspaceId" jsonschema:"description:The GTM workspace ID"`
+	TriggerID                   string               `json:"triggerId,omitempty" jsonschema:"description:The trigger ID to update (or use triggerName)"`
+	TriggerName                 string               `json:"triggerName,omitempty" jsonschema:"description:The exact trigger name, instead of triggerId"`
+	Name                        string               `json:"name" jsonschema:"description:Trigger name"`
+	Type                        string               `json:"type" jsonschema:"description:Trigger type (e.g. pageview, customEvent, linkClick, triggerGroup)"`
+	Filter                      []gtm.ConditionInput `json:"filter,omitempty" jsonschema:"description:Filter conditions for pageview triggers (optional). Each condition: {variable, op, value, negate} e.g. {variable: {{Page Path}}, op: startsWith, value: /checkout}, or raw GTM {type, parameter}"`
+	AutoEventFilter             []gtm.ConditionInput `json:"autoEventFilter,omitempty" jsonschema:"description:Auto-event filter for click/form triggers, same condition forms as filter (optional)"`
+	CustomEventFilter           []gtm.ConditionInput `json:"customEventFilter,omitempty" jsonschema:"description:Custom event filter for customEvent triggers, same condition forms as filter (optional)"`
+	Parameters                  []gtm.Parameter      `json:"parameters,omitempty" jsonschema:"description:Trigger parameters. For triggerGroup type use: [{key: triggerIds, type: list, list: [{type: triggerReference, value: triggerId}, ...]}]"`
+	FilterJSON                  string               `json:"filterJson,omitempty" jsonschema:"description:Filter conditions as a JSON array string (optional, legacy alternative to filter)"`
+	AutoEventFilterJSON         string               `json:"autoEventFilterJson,omitempty" jsonschema:"description:Auto-event filter as a JSON array string (optional, legacy alternative to autoEventFilter)"`
+	CustomEventFilterJSON       string               `json:"customEventFilterJson,omitempty" jsonschema:"description:Custom event filter as a JSON array string (optional, legacy alternative to customEventFilter)"`
+	ParameterJSON               string               `json:"parameterJson,omitempty" jsonschema:"description:Trigger parameters as a JSON array string (optional, legacy alternative to parameters)"`
+	EventName                   string               `json:"eventName,omitempty" jsonschema:"description:Event name for timer triggers, e.g. gtm.timer (optional, keeps the current value when omitted)"`
+	Interval                    *int64               `json:"interval,omitempty" jsonschema:"description:Timer triggers: milliseconds between firings (optional, keeps the current value when omitted)"`
+	Limit                       *int64               `json:"limit,omitempty" jsonschema:"description:Timer triggers: maximum number of times to fire (optional, keeps the current value when omitted)"`
+	VerticalScrollPercentages   []int                `json:"verticalScrollPercentages,omitempty" jsonschema:"description:Scroll triggers: vertical scroll depths to fire at, as percentages 1-100 (optional, keeps the current value when omitted)"`
+	HorizontalScrollPercentages []int                `json:"horizontalScrollPercentages,omitempty" jsonschema:"description:Scroll triggers: horizontal scroll depths to fire at, as percentages 1-100 (optional, keeps the current value when omitted)"`
+	Selector                    string               `json:"selector,omitempty" jsonschema:"description:Visibility triggers: CSS selector of the element to observe (optional, keeps the current value when omitted)"`
+	VisiblePercentageMin        *int                 `json:"visiblePercentageMin,omitempty" jsonschema:"description:Visibility triggers: minimum percentage of the element that must be visible, 0-100 (optional, keeps the current value when omitted)"`
+	VisiblePercentageMax        *int                 `json:"visiblePercentageMax,omitempty" jsonschema:"description:Visibility triggers: maximum percentage of the element that may be visible, 0-100 (optional, keeps the current value when omitted)"`
+	Notes                       string               `json:"notes,omitempty" jsonschema:"description:Trigger notes (optional)"`
+	ParentFolderID              string               `json:"parentFolderId,omitempty" jsonschema:"description:Move the trigger to this folder ID (optional, keeps the current folder when omitted)"`
+}
+
+// UpdateTriggerOutput is the output for update_trigger tool.
+type UpdateTriggerOutput struct {
+	Success bool               `json:"success"`
+	Trigger gtm.CreatedTrigger `json:"trigger"`
+	Message string             `json:"message"`
+}
+
+func registerUpdateTrigger(r *toolRegistry) {
+	handler := func(ctx context.Context, req *mcp.CallToolRequest, input UpdateTriggerInput) (*mcp.CallToolResult, UpdateTriggerOutput, error) {
+		wc, err := resolveWorkspace(ctx, input.AccountID, input.ContainerID, input.WorkspaceID)
+		if err != nil {
+			return nil, UpdateTriggerOutput{}, err
+		}
+
+		// Look the trigger up by name if no ID was given
+		if input.TriggerID, err = wc.resolveTriggerID(ctx, input.TriggerID, input.TriggerName); err != nil {
+			return nil, UpdateTriggerOutput{}, err
+		}
+
+		// Validate trigger input
+		if err := gtm.ValidateTriggerInput(input.Name, input.Type); err != nil {
+			return nil, UpdateTriggerOutput{}, err
+		}
+
+		path := gtm.BuildTriggerPath(wc.AccountID, wc.ContainerID, wc.WorkspaceID, input.TriggerID)
+
+		filter, err := gtm.ConditionsInput("filter", input.Filter, "filterJson", input.FilterJSON)
+		if err != nil {
+			return nil, UpdateTriggerOutput{}, err
+		}
+		autoEventFilter, err := gtm.ConditionsInput("autoEventFilter", input.AutoEventFilter, "autoEventFilterJson", input.AutoEventFilterJSON)
+		if err != nil {
+			return nil, UpdateTriggerOutput{}, err
+		}
+		customEventFilter, err := gtm.ConditionsInput("customEventFilter", input.CustomEventFilter, "customEventFilterJson", input.CustomEventFilterJSON)
+		if err != nil {
+			return nil, UpdateTriggerOutput{}, err
+		}
+		// Trigger group members
+		params, err := gtm.ParametersInput("parameters", input.Parameters, "parameterJson", input.ParameterJSON)
+		if err != nil {
+			return nil, UpdateTriggerOutput{}, err
+		}
+
+		triggerInput := &gtm.TriggerInput{
+			Name:                        input.Name,
+			Type:                        input.Type,
+			Filter:                      filter,
+			AutoEventFilter:             autoEventFilter,
+			CustomEventFilter:           customEventFilter,
+			Parameter:                   params,
+			Notes:                       input.Notes,
+			ParentFolderId:              input.ParentFolderID,
+			Interval:                    input.Interval,
+			Limit:                       input.Limit,
+			VerticalScrollPercentages:   input.VerticalScrollPercentages,
+			HorizontalScrollPercentages: input.HorizontalScrollPercentages,
+			Selector:                    input.Selector,
+			VisiblePercentageMin:        input.VisiblePercentageMin,
+			VisiblePercentageMax:        input.VisiblePercentageMax,
+		}
+		if input.EventName != "" {
+			triggerInput.EventName = &gtm.Parameter{Type: gtm.ParamTemplate, Value: input.EventName}
+		}
+		if err := gtm.ValidateTriggerFields(triggerInput); err != nil {
+			return nil, UpdateTriggerOutput{}, err
+		}
+
+		trigger, err := wc.Client.UpdateTrigger(ctx, path, triggerInput)
+		if err != nil {
+			return nil, UpdateTriggerOutput{}, err
+		}
+
+		return nil, UpdateTriggerOutput{
+			Success: true,
+			Trigger: *trigger,
+			Message: "Trigger updated successfully",
+		}, nil
+	}
+
+	addTool(r, &mcp.Tool{
+		Name:        "update_trigger",
+		Description: "Update an existing trigger. For trigger groups, use parameters with format: [{\"key\": \"triggerIds\", \"type\": \"list\", \"list\": [{\"type\": \"triggerReference\", \"value\": \"<triggerId>\"}, ...]}]",
+	}, handler)
+}
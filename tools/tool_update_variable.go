@@ -0,0 +1,77 @@
+package tools
+
+import (
+	"context"
+
+	"github.com/paolobietolini/gtm-mcp-server/gtm"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// UpdateVariableInput is the input for update_variable tool.
+// Omitted fields keep their current value.
+type UpdateVariableInput struct {
+	AccountID      string           `json:"accountId" jsonschema:"description:The GTM account ID"`
+	ContainerID    string           `json:"containerId" jsonschema:"description:The GTM container ID"`
+	WorkspaceID    string           `json:"workspaceId" jsonschema:"description:The GTM workspace ID"`
+	VariableID     string           `json:"variableId,omitempty" jsonschema:"description:The variable ID to update (or use variableName)"`
+	VariableName   string           `json:"variableName,omitempty" jsonschema:"description:The exact variable name, instead of variableId"`
+	Name           *string          `json:"name,omitempty" jsonschema:"description:New variable name (optional)"`
+	Type           *string          `json:"type,omitempty" jsonschema:"description:New variable type (optional; e.g. c for Constant, v for Data Layer, k for Cookie, jsm for Custom JavaScript, gtes for Google tag Event Settings, gtcs for Google tag Configuration Settings). Usually requires parameters too."`
+	Parameters     []gtm.Parameter  `json:"parameters,omitempty" jsonschema:"description:Replace all variable parameters (optional). Each parameter: {type, key, value} or {type, key, list/map}, with values as strings"`
+	ParametersJSON string           `json:"parametersJson,omitempty" jsonschema:"description:Replace all variable parameters with this JSON array string (optional, legacy alternative to parameters)"`
+	Notes          *string          `json:"notes,omitempty" jsonschema:"description:New variable notes (optional)"`
+	FormatValue    *gtm.FormatValue `json:"formatValue,omitempty" jsonschema:"description:Replace the output formatting: caseConversionType and values to convert null, undefined, true or false to (optional)"`
+	ParentFolderID *string          `json:"parentFolderId,omitempty" jsonschema:"description:Move the variable to this folder ID (optional; clear parentFolderId to move it to the workspace root)"`
+	Clear          []string         `json:"clear,omitempty" jsonschema:"description:Fields to remove from the variable: notes, parameters, parentFolderId, formatValue (optional)"`
+}
+
+// UpdateVariableOutput is the output for update_variable tool.
+type UpdateVariableOutput struct {
+	Success  bool                `json:"success"`
+	Variable gtm.CreatedVariable `json:"variable"`
+	Message  string              `json:"message"`
+}
+
+func registerUpdateVariable(r *toolRegistry) {
+	handler := func(ctx context.Context, req *mcp.CallToolRequest, input UpdateVariableInput) (*mcp.CallToolResult, UpdateVariableOutput, error) {
+		wc, err := resolveWorkspace(ctx, input.AccountID, input.ContainerID, input.WorkspaceID)
+		if err != nil {
+			return nil, UpdateVariableOutput{}, err
+		}
+
+		// Look the variable up by name if no ID was given
+		if input.VariableID, err = wc.resolveVariableID(ctx, input.VariableID, input.VariableName); err != nil {
+			return nil, UpdateVariableOutput{}, err
+		}
+		path := gtm.BuildVariablePath(wc.AccountID, wc.ContainerID, wc.WorkspaceID, input.VariableID)
+
+		patch := &gtm.VariablePatch{
+			Name:           input.Name,
+			Type:           input.Type,
+			Notes:          input.Notes,
+			ParentFolderId: input.ParentFolderID,
+			FormatValue:    input.FormatValue,
+			Clear:          input.Clear,
+		}
+		if patch.Parameter, err = gtm.ParametersInput("parameters", input.Parameters, "parametersJson", input.ParametersJSON); err != nil {
+			return nil, UpdateVariableOutput{}, err
+		}
+
+		variable, err := wc.Client.UpdateVariable(ctx, path, patch)
+		if err != nil {
+			return nil, UpdateVariableOutput{}, err
+		}
+
+		return nil, UpdateVariableOutput{
+			Success:  true,
+			Variable: *variable,
+			Message:  "Variable updated successfully",
+		}, nil
+	}
+
+	addTool(r, &mcp.Tool{
+		Name:        "update_variable",
+		Description: "Update an existing variable. Only the fields you supply are changed (e.g. just the name, or just parameters); format value, folder and other settings are kept unless supplied. List fields in clear to remove them. Automatically handles fingerprint for concurrency control.",
+	}, handler)
+}
@@ -0,0 +1,136 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/paolobietolini/gtm-mcp-server/gtm"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// UpsertTagOutput is the output for upsert_tag tool.
+type UpsertTagOutput struct {
+	Success bool           `json:"success"`
+	Tag     gtm.CreatedTag `json:"tag"`
+	gtm.UpsertOutcome
+	Message string `json:"message"`
+}
+
+// UpsertTriggerOutput is the output for upsert_trigger tool.
+type UpsertTriggerOutput struct {
+	Success bool               `json:"success"`
+	Trigger gtm.CreatedTrigger `json:"trigger"`
+	gtm.UpsertOutcome
+	Message string `json:"message"`
+}
+
+// UpsertVariableOutput is the output for upsert_variable tool.
+type UpsertVariableOutput struct {
+	Success  bool                `json:"success"`
+	Variable gtm.CreatedVariable `json:"variable"`
+	gtm.UpsertOutcome
+	Message string `json:"message"`
+}
+
+// upsertMessage describes the outcome of an upsert.
+func upsertMessage(kind, name string, outcome gtm.UpsertOutcome) string {
+	switch outcome.Status {
+	case gtm.UpsertCreated:
+		return fmt.Sprintf("No %s named %q existed; created it.", kind, name)
+	case gtm.UpsertUpdated:
+		return fmt.Sprintf("Updated the existing %s %q: %s changed.", kind, name, strings.Join(outcome.ChangedFields, ", "))
+	}
+	return fmt.Sprintf("The existing %s %q already matches; nothing changed.", kind, name)
+}
+
+func registerUpsertTag(r *toolRegistry) {
+	handler := func(ctx context.Context, req *mcp.CallToolRequest, input CreateTagInput) (*mcp.CallToolResult, UpsertTagOutput, error) {
+		wc, err := resolveWorkspace(ctx, input.AccountID, input.ContainerID, input.WorkspaceID)
+		if err != nil {
+			return nil, UpsertTagOutput{}, err
+		}
+		tagInput, err := input.tagInput()
+		if err != nil {
+			return nil, UpsertTagOutput{}, err
+		}
+
+		tag, outcome, err := wc.Client.UpsertTag(ctx, wc.AccountID, wc.ContainerID, wc.WorkspaceID, tagInput)
+		if err != nil {
+			return nil, UpsertTagOutput{}, err
+		}
+
+		return nil, UpsertTagOutput{
+			Success:       true,
+			Tag:           *tag,
+			UpsertOutcome: outcome,
+			Message:       upsertMessage(gtm.EntityTypeTag, tag.Name, outcome),
+		}, nil
+	}
+
+	addTool(r, &mcp.Tool{
+		Name:        "upsert_tag",
+		Description: "Create a tag, or update the tag with the same name in the workspace instead of creating a duplicate. Takes the same arguments as create_tag. An existing tag gets the supplied fields and keeps the others; when nothing differs no change is made. Prefer this over create_tag in setup flows that may be re-run.",
+	}, handler)
+}
+
+func registerUpsertTrigger(r *toolRegistry) {
+	handler := func(ctx context.Context, req *mcp.CallToolRequest, input CreateTriggerInput) (*mcp.CallToolResult, UpsertTriggerOutput, error) {
+		wc, err := resolveWorkspace(ctx, input.AccountID, input.ContainerID, input.WorkspaceID)
+		if err != nil {
+			return nil, UpsertTriggerOutput{}, err
+		}
+		triggerInput, err := input.triggerInput()
+		if err != nil {
+			return nil, UpsertTriggerOutput{}, err
+		}
+
+		trigger, outcome, err := wc.Client.UpsertTrigger(ctx, wc.AccountID, wc.ContainerID, wc.WorkspaceID, triggerInput)
+		if err != nil {
+			return nil, UpsertTriggerOutput{}, err
+		}
+
+		return nil, UpsertTriggerOutput{
+			Success:       true,
+			Trigger:       *trigger,
+			UpsertOutcome: outcome,
+			Message:       upsertMessage(gtm.EntityTypeTrigger, trigger.Name, outcome),
+		}, nil
+	}
+
+	addTool(r, &mcp.Tool{
+		Name:        "upsert_trigger",
+		Description: "Create a trigger, or update the trigger with the same name in the workspace instead of creating a duplicate. Takes the same arguments as create_trigger; an existing trigger is updated as update_trigger does. When nothing differs no change is made. Prefer this over create_trigger in setup flows that may be re-run.",
+	}, handler)
+}
+
+func registerUpsertVariable(r *toolRegistry) {
+	handler := func(ctx context.Context, req *mcp.CallToolRequest, input CreateVariableInput) (*mcp.CallToolResult, UpsertVariableOutput, error) {
+		wc, err := resolveWorkspace(ctx, input.AccountID, input.ContainerID, input.WorkspaceID)
+		if err != nil {
+			return nil, UpsertVariableOutput{}, err
+		}
+		variableInput, err := input.variableInput()
+		if err != nil {
+			return nil, UpsertVariableOutput{}, err
+		}
+
+		variable, outcome, err := wc.Client.UpsertVariable(ctx, wc.AccountID, wc.ContainerID, wc.WorkspaceID, variableInput)
+		if err != nil {
+			return nil, UpsertVariableOutput{}, err
+		}
+
+		return nil, UpsertVariableOutput{
+			Success:       true,
+			Variable:      *variable,
+			UpsertOutcome: outcome,
+			Message:       upsertMessage(gtm.EntityTypeVariable, variable.Name, outcome),
+		}, nil
+	}
+
+	addTool(r, &mcp.Tool{
+		Name:        "upsert_variable",
+		Description: "Create a variable, or update the variable with the same name in the workspace instead of creating a duplicate. Takes the same arguments as create_variable. An existing variable gets the supplied fields and keeps the others; when nothing differs no change is made. Prefer this over create_variable in setup flows that may be re-run.",
+	}, handler)
+}
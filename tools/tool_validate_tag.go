@@ -0,0 +1,89 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/paolobietolini/gtm-mcp-server/gtm"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// ValidateTagToolInput is the input for validate_tag tool.
+type ValidateTagToolInput struct {
+	AccountID          string          `json:"accountId" jsonschema:"description:The GTM account ID"`
+	ContainerID        string          `json:"containerId" jsonschema:"description:The GTM container ID"`
+	WorkspaceID        string          `json:"workspaceId" jsonschema:"description:The GTM workspace ID"`
+	Name               string          `json:"name" jsonschema:"description:Tag name"`
+	Type               string          `json:"type" jsonschema:"description:Tag type (e.g. gaawe, html, img, or cvt_... for custom templates)"`
+	FiringTriggerIDs   []string        `json:"firingTriggerIds,omitempty" jsonschema:"description:Array of trigger IDs that fire this tag"`
+	BlockingTriggerIDs []string        `json:"blockingTriggerIds,omitempty" jsonschema:"description:Array of trigger IDs that block this tag (optional)"`
+	Parameters         []gtm.Parameter `json:"parameters,omitempty" jsonschema:"description:Tag parameters, as for create_tag"`
+	ParametersJSON     string          `json:"parametersJson,omitempty" jsonschema:"description:Tag parameters as a JSON array string, as for create_tag (legacy alternative to parameters)"`
+}
+
+// ValidateTagOutput is the output for validate_tag tool.
+type ValidateTagOutput struct {
+	Valid      bool               `json:"valid"`
+	Violations []gtm.TagViolation `json:"violations"`
+	Message    string             `json:"message"`
+}
+
+func registerValidateTag(r *toolRegistry) {
+	handler := func(ctx context.Context, req *mcp.CallToolRequest, input ValidateTagToolInput) (*mcp.CallToolResult, ValidateTagOutput, error) {
+		wc, err := resolveWorkspace(ctx, input.AccountID, input.ContainerID, input.WorkspaceID)
+		if err != nil {
+			return nil, ValidateTagOutput{}, err
+		}
+
+		payload := gtm.TagPayload{
+			Name:               input.Name,
+			Type:               input.Type,
+			FiringTriggerIDs:   input.FiringTriggerIDs,
+			BlockingTriggerIDs: input.BlockingTriggerIDs,
+		}
+
+		// Malformed parameters are reported as a violation, not a tool error
+		var parseViolation *gtm.TagViolation
+		if payload.Parameter, err = gtm.ParametersInput("parameters", input.Parameters, "parametersJson", input.ParametersJSON); err != nil {
+			field := "parameters"
+			if input.ParametersJSON != "" {
+				field = "parametersJson"
+			}
+			parseViolation = &gtm.TagViolation{Severity: gtm.SeverityError, Field: field, Message: err.Error()}
+		}
+
+		violations, err := wc.Client.ValidateTagPayload(ctx, wc.AccountID, wc.ContainerID, wc.WorkspaceID, payload)
+		if err != nil {
+			return nil, ValidateTagOutput{}, err
+		}
+		if parseViolation != nil {
+			violations = append([]gtm.TagViolation{*parseViolation}, violations...)
+		}
+
+		errs := 0
+		for _, v := range violations {
+			if v.Severity == gtm.SeverityError {
+				errs++
+			}
+		}
+
+		message := "Tag payload is valid; create_tag can be called with it"
+		if errs > 0 {
+			message = fmt.Sprintf("Tag payload has %d errors and %d warnings; fix the errors before calling create_tag", errs, len(violations)-errs)
+		} else if len(violations) > 0 {
+			message = fmt.Sprintf("Tag payload is valid with %d warnings", len(violations))
+		}
+
+		return nil, ValidateTagOutput{
+			Valid:      errs == 0,
+			Violations: violations,
+			Message:    message,
+		}, nil
+	}
+
+	addTool(r, &mcp.Tool{
+		Name:        "validate_tag",
+		Description: "Check a proposed tag without creating it: the type must be built in or a template in the workspace, known types (gaawe, gaawc, googtag, html, img) must have their required parameters, and firing/blocking trigger IDs must exist. Returns structured violations to fix before calling create_tag.",
+	}, handler)
+}
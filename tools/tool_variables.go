@@ -0,0 +1,58 @@
+package tools
+
+import (
+	"context"
+
+	"github.com/paolobietolini/gtm-mcp-server/gtm"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+type ListVariablesInput struct {
+	AccountID   string `json:"accountId" jsonschema:"description:The GTM account ID"`
+	ContainerID string `json:"containerId" jsonschema:"description:The GTM container ID"`
+	WorkspaceID string `json:"workspaceId" jsonschema:"description:The GTM workspace ID"`
+	PageToken   string `json:"pageToken,omitempty" jsonschema:"description:The nextPageToken of a previous response, to fetch the next page"`
+	PageSize    int    `json:"pageSize,omitempty" jsonschema:"description:Number of variables per page (default 100, max 500)"`
+	All         bool   `json:"all,omitempty" jsonschema:"description:Return every variable in one response, up to 2000, ignoring pageSize"`
+	Compact     bool   `json:"compact,omitempty" jsonschema:"description:Return only the ID, name and type of each variable in 'compact' instead of full variables"`
+}
+type ListVariablesOutput struct {
+	Variables     []gtm.Variable      `json:"variables,omitempty"`
+	Compact       []gtm.CompactEntity `json:"compact,omitempty"`
+	Total         int                 `json:"total"`
+	NextPageToken string              `json:"nextPageToken,omitempty"`
+}
+
+func registerListVariables(r *toolRegistry) {
+	handler := func(ctx context.Context, req *mcp.CallToolRequest, input ListVariablesInput) (*mcp.CallToolResult, ListVariablesOutput, error) {
+		wc, err := resolveWorkspace(ctx, input.AccountID, input.ContainerID, input.WorkspaceID)
+		if err != nil {
+			return nil, ListVariablesOutput{}, err
+		}
+
+		variables, err := wc.Client.ListVariables(ctx, wc.AccountID, wc.ContainerID, wc.WorkspaceID)
+		if err != nil {
+			return nil, ListVariablesOutput{}, err
+		}
+
+		page, next, err := gtm.Paginate(variables, input.PageToken, input.PageSize, input.All)
+		if err != nil {
+			return nil, ListVariablesOutput{}, err
+		}
+
+		output := ListVariablesOutput{Total: len(variables), NextPageToken: next}
+		if input.Compact {
+			output.Compact = gtm.CompactVariables(page)
+		} else {
+			output.Variables = page
+		}
+
+		return nil, output, nil
+	}
+
+	addTool(r, &mcp.Tool{
+		Name:        "list_variables",
+		Description: "List the variables in a GTM workspace, 100 per page by default. Pass nextPageToken as pageToken to fetch the next page, or all=true for every variable (up to 2000). Use compact=true to get only IDs, names and types",
+	}, handler)
+}
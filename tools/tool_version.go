@@ -1,9 +1,11 @@
-package gtm
+package tools
 
 import (
 	"context"
 	"fmt"
 
+	"github.com/paolobietolini/gtm-mcp-server/gtm"
+
 	"github.com/modelcontextprotocol/go-sdk/mcp"
 )
 
@@ -18,9 +20,9 @@ type CreateVersionInput struct {
 
 // CreateVersionOutput is the output for create_version tool.
 type CreateVersionOutput struct {
-	Success bool           `json:"success"`
-	Version CreatedVersion `json:"version"`
-	Message string         `json:"message"`
+	Success bool               `json:"success"`
+	Version gtm.CreatedVersion `json:"version"`
+	Message string             `json:"message"`
 }
 
 // PublishVersionInput is the input for publish_version tool.
@@ -33,15 +35,15 @@ type PublishVersionInput struct {
 
 // PublishVersionOutput is the output for publish_version tool.
 type PublishVersionOutput struct {
-	Success bool             `json:"success"`
-	Version PublishedVersion `json:"version"`
-	Message string           `json:"message"`
+	Success bool                 `json:"success"`
+	Version gtm.PublishedVersion `json:"version"`
+	Message string               `json:"message"`
 }
 
-func registerCreateVersion(server *mcp.Server) {
+func registerCreateVersion(r *toolRegistry) {
 	handler := func(ctx context.Context, req *mcp.CallToolRequest, input CreateVersionInput) (*mcp.CallToolResult, CreateVersionOutput, error) {
 		// Validate workspace path
-		if err := ValidateWorkspacePath(input.AccountID, input.ContainerID, input.WorkspaceID); err != nil {
+		if err := gtm.ValidateWorkspacePath(input.AccountID, input.ContainerID, input.WorkspaceID); err != nil {
 			return nil, CreateVersionOutput{}, err
 		}
 
@@ -70,7 +72,7 @@ func registerCreateVersion(server *mcp.Server) {
 			}, nil
 		}
 
-		versionInput := &VersionInput{
+		versionInput := &gtm.VersionInput{
 			Name:  input.Name,
 			Notes: input.Notes,
 		}
@@ -87,13 +89,13 @@ func registerCreateVersion(server *mcp.Server) {
 		}, nil
 	}
 
-	mcp.AddTool(server, &mcp.Tool{
+	addTool(r, &mcp.Tool{
 		Name:        "create_version",
 		Description: "Create a new container version from workspace changes. This snapshots the current workspace state but does not publish it.",
 	}, handler)
 }
 
-func registerPublishVersion(server *mcp.Server) {
+func registerPublishVersion(r *toolRegistry) {
 	handler := func(ctx context.Context, req *mcp.CallToolRequest, input PublishVersionInput) (*mcp.CallToolResult, PublishVersionOutput, error) {
 		// Safety guard: require explicit confirmation
 		if !input.Confirm {
@@ -125,7 +127,7 @@ func registerPublishVersion(server *mcp.Server) {
 		}, nil
 	}
 
-	mcp.AddTool(server, &mcp.Tool{
+	addTool(r, &mcp.Tool{
 		Name:        "publish_version",
 		Description: "Publish a container version to make it live. Requires confirm: true as a safety guard. WARNING: This pushes changes to your live website.",
 	}, handler)
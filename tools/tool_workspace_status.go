@@ -1,8 +1,10 @@
-package gtm
+package tools
 
 import (
 	"context"
 
+	"github.com/paolobietolini/gtm-mcp-server/gtm"
+
 	"github.com/modelcontextprotocol/go-sdk/mcp"
 )
 
@@ -12,10 +14,10 @@ type GetWorkspaceStatusInput struct {
 	WorkspaceID string `json:"workspaceId" jsonschema:"description:The GTM workspace ID"`
 }
 type GetWorkspaceStatusOutput struct {
-	Status WorkspaceStatus `json:"status"`
+	Status gtm.WorkspaceStatus `json:"status"`
 }
 
-func registerGetWorkspaceStatus(server *mcp.Server) {
+func registerGetWorkspaceStatus(r *toolRegistry) {
 	handler := func(ctx context.Context, req *mcp.CallToolRequest, input GetWorkspaceStatusInput) (*mcp.CallToolResult, GetWorkspaceStatusOutput, error) {
 		wc, err := resolveWorkspace(ctx, input.AccountID, input.ContainerID, input.WorkspaceID)
 		if err != nil {
@@ -30,7 +32,7 @@ func registerGetWorkspaceStatus(server *mcp.Server) {
 		return nil, GetWorkspaceStatusOutput{Status: *status}, nil
 	}
 
-	mcp.AddTool(server, &mcp.Tool{
+	addTool(r, &mcp.Tool{
 		Name:        "get_workspace_status",
 		Description: "Check if a workspace has pending changes or merge conflicts before versioning.",
 	}, handler)
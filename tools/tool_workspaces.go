@@ -1,8 +1,10 @@
-package gtm
+package tools
 
 import (
 	"context"
 
+	"github.com/paolobietolini/gtm-mcp-server/gtm"
+
 	"github.com/modelcontextprotocol/go-sdk/mcp"
 )
 
@@ -11,10 +13,10 @@ type ListWorkspacesInput struct {
 	ContainerID string `json:"containerId" jsonschema:"description:The GTM container ID"`
 }
 type ListWorkspacesOutput struct {
-	Workspaces []Workspace `json:"workspaces"`
+	Workspaces []gtm.Workspace `json:"workspaces"`
 }
 
-func registerListWorkspaces(server *mcp.Server) {
+func registerListWorkspaces(r *toolRegistry) {
 	handler := func(ctx context.Context, req *mcp.CallToolRequest, input ListWorkspacesInput) (*mcp.CallToolResult, ListWorkspacesOutput, error) {
 		cc, err := resolveContainer(ctx, input.AccountID, input.ContainerID)
 		if err != nil {
@@ -26,11 +28,21 @@ func registerListWorkspaces(server *mcp.Server) {
 			return nil, ListWorkspacesOutput{}, err
 		}
 
+		// Aggregate pending changes so the in-progress workspace is easy to spot
+		for i := range workspaces {
+			status, err := cc.Client.GetWorkspaceStatus(ctx, cc.AccountID, cc.ContainerID, workspaces[i].WorkspaceID)
+			if err != nil {
+				workspaces[i].Changes = &gtm.WorkspaceChangeSummary{Error: err.Error()}
+				continue
+			}
+			workspaces[i].Changes = gtm.SummarizeChanges(status)
+		}
+
 		return nil, ListWorkspacesOutput{Workspaces: workspaces}, nil
 	}
 
-	mcp.AddTool(server, &mcp.Tool{
+	addTool(r, &mcp.Tool{
 		Name:        "list_workspaces",
-		Description: "List all workspaces in a GTM container",
+		Description: "List all workspaces in a GTM container with their pending change counts (total, conflicts, and per tag/trigger/variable)",
 	}, handler)
 }
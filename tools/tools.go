@@ -0,0 +1,388 @@
+// Package tools exposes the gtm client to MCP clients as tools, prompts
+// and resources.
+package tools
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/paolobietolini/gtm-mcp-server/auth"
+	"github.com/paolobietolini/gtm-mcp-server/gtm"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"golang.org/x/oauth2"
+)
+
+// RegisterTools adds the GTM tools selected by opts to the MCP server.
+func RegisterTools(server *mcp.Server, opts ToolOptions) error {
+	r, err := newToolRegistry(server, opts)
+	if err != nil {
+		return err
+	}
+
+	// Read operations
+	registerSetContext(r)
+	registerListAccounts(r)
+	registerListContainers(r)
+	registerListWorkspaces(r)
+	registerGetContainerLimits(r)
+	registerListTags(r)
+	registerGetTag(r)
+	registerGetTags(r)
+	registerListTriggers(r)
+	registerGetTrigger(r)
+	registerGetTriggers(r)
+	registerListVariables(r)
+	registerGetVariable(r)
+	registerGetVariables(r)
+	registerListFolders(r)
+	registerGetFolderEntities(r)
+	registerListTemplates(r)
+	registerGetTemplate(r)
+	registerListVersions(r)
+	registerGenerateChangelog(r)
+	registerListAuditEvents(r)
+	registerListPendingChanges(r)
+	registerGetContinuation(r)
+
+	// Write operations
+	registerValidateTag(r)
+	registerCreateTag(r)
+	registerCreateGA4EventTag(r)
+	registerUpdateTag(r)
+	registerUpsertTag(r)
+	registerDeleteTag(r)
+	registerCreateTrigger(r)
+	registerUpdateTrigger(r)
+	registerUpsertTrigger(r)
+	registerDeleteTrigger(r)
+	registerCreateVariable(r)
+	registerCreateLookupTableVariable(r)
+	registerCreateRegexTableVariable(r)
+	registerUpdateVariable(r)
+	registerUpsertVariable(r)
+	registerDeleteVariable(r)
+	registerDuplicateTag(r)
+	registerDuplicateTrigger(r)
+	registerDuplicateVariable(r)
+	registerBulkDeleteEntities(r)
+	registerApplyChangeset(r)
+	registerApplyBlueprint(r)
+	registerSetupGA4Basic(r)
+	registerSetupConsentMode(r)
+	registerFindReplace(r)
+	registerAnnotateWorkspace(r)
+	registerCreateContainer(r)
+	registerDeleteContainer(r)
+	registerRestoreContainer(r)
+	registerMoveTagID(r)
+	registerCombineContainers(r)
+	registerCreateWorkspace(r)
+	registerCloneWorkspace(r)
+
+	// Workspace status
+	registerGetWorkspaceStatus(r)
+	registerGetWorkspaceOverview(r)
+	registerDiffWorkspace(r)
+	registerGenerateContainerMap(r)
+	registerSearchWorkspace(r)
+	registerGetDependencies(r)
+	registerFindOrphans(r)
+	registerAuditWorkspace(r)
+	registerDetectSecrets(r)
+	registerLintNaming(r)
+
+	// Version operations
+	registerCreateVersion(r)
+	registerPublishVersion(r)
+	registerReleaseWorkspace(r)
+	registerGetPreviewLink(r)
+	registerCanaryRelease(r)
+	registerPromoteCanary(r)
+	registerRollbackToVersion(r)
+	registerSchedulePublish(r)
+	registerListScheduledPublishes(r)
+	registerCancelScheduledPublish(r)
+
+	// Import/export
+	registerExportContainer(r)
+	registerExportTerraform(r)
+	registerImportContainer(r)
+	registerCopyEntities(r)
+
+	// Template operations
+	registerSearchGalleryTemplates(r)
+	registerAnalyzeTemplatePermissions(r)
+	registerImportGalleryTemplate(r)
+	registerCheckTemplateUpdates(r)
+	registerCreateTemplate(r)
+	registerUpdateTemplate(r)
+	registerDeleteTemplate(r)
+
+	// Built-in variables
+	registerListBuiltInVariables(r)
+	registerEnableBuiltInVariables(r)
+	registerDisableBuiltInVariables(r)
+
+	// Clients (server-side containers)
+	registerListClients(r)
+	registerGetClient(r)
+	registerCreateClient(r)
+	registerUpdateClient(r)
+	registerDeleteClient(r)
+
+	// Transformations (server-side containers)
+	registerListTransformations(r)
+	registerGetTransformation(r)
+	registerCreateTransformation(r)
+	registerUpdateTransformation(r)
+	registerDeleteTransformation(r)
+
+	// Templates (help LLMs with correct parameter formats)
+	registerGetTagTemplates(r)
+	registerGetTriggerTemplates(r)
+	registerGetVariableTemplates(r)
+	registerListBlueprints(r)
+
+	// Google Analytics lookups
+	registerListGA4Properties(r)
+	registerListGA4DataStreams(r)
+
+	// Raw API access for features the typed tools don't cover
+	registerGTMAPIRequest(r)
+
+	// Approval of held mutations
+	registerApproveChange(r)
+	registerRejectChange(r)
+
+	if err := r.checkOverrides(); err != nil {
+		return err
+	}
+	if err := r.checkDisabled(); err != nil {
+		return err
+	}
+
+	// Resources (URI-based read access)
+	RegisterResources(server)
+
+	// Prompts (template workflows)
+	RegisterPrompts(server)
+
+	return nil
+}
+
+// toolRegistry registers GTM tools on an MCP server, skipping any tool that is
+// not part of the configured profile and applying operator overrides.
+type toolRegistry struct {
+	server      *mcp.Server
+	allowed     map[string]bool // nil allows every tool
+	disabled    map[string]bool
+	overrides   map[string]ToolOverride
+	known       map[string]bool // every tool name seen, registered or not
+	reserved    map[string]bool // tool names registered outside the registry
+	readOnly    bool
+	scopes      []string              // granted Google scopes, nil = all
+	audit       *AuditLog             // nil disables the audit log
+	outputs     *outputGuard          // nil disables the output size limit
+	scheduler   *gtm.PublishScheduler // nil disables scheduled publishing
+	approvals   *ApprovalQueue        // nil runs mutating tools directly
+	idempotency *idempotencyStore
+	contexts    *sessionContexts
+	staleness   *stalenessChecker
+	blueprints  map[string]*gtm.Blueprint
+	namingRules gtm.NamingRules
+	gallery     *gtm.GalleryIndex
+}
+
+func newToolRegistry(server *mcp.Server, opts ToolOptions) (*toolRegistry, error) {
+	allowed, err := profileTools(opts.Profile)
+	if err != nil {
+		return nil, err
+	}
+	disabled := make(map[string]bool, len(opts.DisabledTools))
+	for _, name := range opts.DisabledTools {
+		disabled[name] = true
+	}
+	reserved := make(map[string]bool, len(opts.ReservedNames))
+	for _, name := range opts.ReservedNames {
+		reserved[name] = true
+	}
+	blueprints := opts.Blueprints
+	if blueprints == nil {
+		blueprints = gtm.BuiltInBlueprints()
+	}
+	namingRules := opts.NamingRules
+	if namingRules == nil {
+		namingRules = gtm.DefaultNamingRules
+	}
+	gallery := opts.Gallery
+	if gallery == nil {
+		gallery = gtm.NewGalleryIndex("")
+	}
+	var outputs *outputGuard
+	if opts.MaxOutputBytes > 0 {
+		outputs = newOutputGuard(opts.MaxOutputBytes)
+	}
+	return &toolRegistry{
+		server:      server,
+		allowed:     allowed,
+		disabled:    disabled,
+		overrides:   opts.Overrides,
+		known:       make(map[string]bool),
+		reserved:    reserved,
+		readOnly:    opts.ReadOnly,
+		scopes:      opts.GoogleScopes,
+		audit:       opts.Audit,
+		outputs:     outputs,
+		scheduler:   opts.Scheduler,
+		approvals:   opts.Approvals,
+		idempotency: newIdempotencyStore(),
+		contexts:    newSessionContexts(opts.WorkspaceContexts),
+		staleness:   newStalenessChecker(),
+		blueprints:  blueprints,
+		namingRules: namingRules,
+		gallery:     gallery,
+	}, nil
+}
+
+// addTool registers a typed tool handler if the tool is enabled in the registry.
+func addTool[In, Out any](r *toolRegistry, tool *mcp.Tool, handler mcp.ToolHandlerFor[In, Out]) {
+	r.known[tool.Name] = true
+	if (r.allowed != nil && !r.allowed[tool.Name]) || r.disabled[tool.Name] {
+		return
+	}
+	// Calls held for approval are checked for read-only access and scopes
+	// before they are queued, since they run later on another request
+	if !readTools[tool.Name] {
+		handler = withApproval(r.approvals, r.audit, tool.Name, handler)
+		handler = withReadOnlyGuard(tool.Name, r.readOnly, handler)
+	}
+	if scope := missingGoogleScope(tool.Name, r.scopes); scope != "" {
+		handler = missingScopeHandler[In, Out](tool.Name, scope)
+	}
+	if !readTools[tool.Name] {
+		handler = withAudit(r.audit, tool.Name, handler)
+		handler = withIdempotency(r.idempotency, tool.Name, handler)
+	}
+	if tool.Name != "get_continuation" {
+		handler = withOutputGuard(r.outputs, handler)
+	}
+	if !readTools[tool.Name] {
+		handler = withStalenessWarning(r.staleness, handler)
+	}
+	handler = withWorkspaceContext(r.contexts, handler)
+	handler = withSessionHandler(withProgress(withErrorEnvelope(handler)))
+	if tool.InputSchema == nil {
+		schema, err := inputSchema[In]()
+		if err != nil {
+			panic(fmt.Sprintf("tool %q: %v", tool.Name, err))
+		}
+		tool.InputSchema = schema
+	}
+	relaxWorkspaceIDs[In](tool)
+	if !readTools[tool.Name] {
+		addIdempotencyKey(tool)
+	}
+	if tool.Annotations == nil {
+		tool.Annotations = toolAnnotations(tool.Name)
+	}
+
+	override, ok := r.overrides[tool.Name]
+	if !ok {
+		mcp.AddTool(r.server, tool, handler)
+		return
+	}
+	if override.Hidden {
+		return
+	}
+
+	tool = override.apply(tool)
+	mcp.AddTool(r.server, tool, handler)
+	for _, alias := range override.Aliases {
+		aliased := *tool
+		aliased.Name = alias
+		mcp.AddTool(r.server, &aliased, handler)
+	}
+}
+
+// checkDisabled reports disabled tool names that don't match any GTM tool,
+// so a typo doesn't silently leave a tool exposed.
+func (r *toolRegistry) checkDisabled() error {
+	var unknown []string
+	for name := range r.disabled {
+		if !r.known[name] {
+			unknown = append(unknown, name)
+		}
+	}
+	if len(unknown) > 0 {
+		sort.Strings(unknown)
+		return fmt.Errorf("disabled tools reference unknown tools: %s", strings.Join(unknown, ", "))
+	}
+	return nil
+}
+
+// withReadOnlyGuard rejects calls to a mutating tool when the server runs in
+// read-only mode or the caller's token only has the gtm:read scope.
+func withReadOnlyGuard[In, Out any](name string, readOnly bool, handler mcp.ToolHandlerFor[In, Out]) mcp.ToolHandlerFor[In, Out] {
+	return func(ctx context.Context, req *mcp.CallToolRequest, input In) (*mcp.CallToolResult, Out, error) {
+		if readOnly || auth.IsReadOnly(ctx) {
+			var zero Out
+			return nil, zero, fmt.Errorf("%w: %s changes containers and is not available with read-only access; use list, get and search tools instead", gtm.ErrReadOnly, name)
+		}
+		return handler(ctx, req, input)
+	}
+}
+
+// userKey identifies the Google user behind a request: the Google grant of
+// an OAuth session, otherwise the client (API key or stdio user).
+func userKey(ctx context.Context) string {
+	tokenInfo := auth.GetTokenInfo(ctx)
+	if tokenInfo == nil {
+		return "default"
+	}
+	if tokenInfo.GoogleToken != nil && tokenInfo.GoogleToken.RefreshToken != "" {
+		sum := sha256.Sum256([]byte(tokenInfo.GoogleToken.RefreshToken))
+		return "google:" + hex.EncodeToString(sum[:8])
+	}
+	return "client:" + tokenInfo.ClientID
+}
+
+// gtmEndpoint replaces the Tag Manager API base URL in tests.
+var gtmEndpoint string
+
+// getClient creates a GTM client from the request context with auto-refreshing tokens.
+func getClient(ctx context.Context) (*gtm.Client, error) {
+	tokenSource, err := googleTokenSource(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return gtm.NewClientWithOptions(ctx, tokenSource, gtm.ClientOptions{User: userKey(ctx), Endpoint: gtmEndpoint})
+}
+
+// googleTokenSource returns the caller's Google token source.
+func googleTokenSource(ctx context.Context) (oauth2.TokenSource, error) {
+	// API key clients carry their own Google token source
+	if tokenSource := auth.GetGoogleTokenSource(ctx); tokenSource != nil {
+		return tokenSource, nil
+	}
+
+	tokenInfo := auth.GetTokenInfo(ctx)
+	if tokenInfo == nil || tokenInfo.GoogleToken == nil {
+		return nil, fmt.Errorf("not authenticated - please authenticate with Google first")
+	}
+
+	store := auth.GetTokenStore(ctx)
+	google := auth.GetGoogleProvider(ctx)
+
+	// Create auto-refreshing token source
+	return auth.NewAutoRefreshTokenSource(
+		store,
+		tokenInfo.AccessToken,
+		google.Config(),
+		tokenInfo.GoogleToken,
+	), nil
+}
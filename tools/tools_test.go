@@ -0,0 +1,118 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/paolobietolini/gtm-mcp-server/auth"
+	"github.com/paolobietolini/gtm-mcp-server/gtm"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"golang.org/x/oauth2"
+)
+
+// fakeAPI is a Tag Manager API test server that records every request.
+type fakeAPI struct {
+	mu       sync.Mutex
+	requests []string // "METHOD path" or "METHOD path?query", path relative to tagmanager/v2/
+}
+
+// Requests returns the recorded requests in order.
+func (f *fakeAPI) Requests() []string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]string(nil), f.requests...)
+}
+
+// Mutations returns the recorded requests that are not GETs.
+func (f *fakeAPI) Mutations() []string {
+	var out []string
+	for _, r := range f.Requests() {
+		if !strings.HasPrefix(r, http.MethodGet+" ") {
+			out = append(out, r)
+		}
+	}
+	return out
+}
+
+// toolSession is an MCP client session of a local user whose GTM tools call
+// a fake Tag Manager API.
+type toolSession struct {
+	t       *testing.T
+	session *mcp.ClientSession
+	api     *fakeAPI
+}
+
+// newToolSession registers the tools selected by opts and connects to them
+// as a local user. API requests are recorded and answered by handler.
+func newToolSession(t *testing.T, opts ToolOptions, handler http.HandlerFunc) *toolSession {
+	t.Helper()
+	api := &fakeAPI{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		req := r.Method + " " + strings.TrimPrefix(r.URL.Path, "/tagmanager/v2/")
+		if r.URL.RawQuery != "" {
+			req += "?" + r.URL.RawQuery
+		}
+		api.mu.Lock()
+		api.requests = append(api.requests, req)
+		api.mu.Unlock()
+		handler(w, r)
+	}))
+	t.Cleanup(srv.Close)
+
+	gtmEndpoint = srv.URL
+	t.Cleanup(func() { gtmEndpoint = "" })
+	gtm.SetListCacheTTL(0)
+	t.Cleanup(func() { gtm.SetListCacheTTL(gtm.DefaultListCacheTTL) })
+	gtm.SetQuota(gtm.QuotaOptions{})
+	t.Cleanup(func() { gtm.SetQuota(gtm.DefaultQuota) })
+
+	server := mcp.NewServer(&mcp.Implementation{Name: "test", Version: "0.0.0"}, nil)
+	if err := RegisterTools(server, opts); err != nil {
+		t.Fatalf("RegisterTools: %v", err)
+	}
+	ctx := context.Background()
+	tokenSource := oauth2.StaticTokenSource(&oauth2.Token{AccessToken: "test"})
+	serverTransport, clientTransport := mcp.NewInMemoryTransports()
+	serverSession, err := server.Connect(auth.LocalContext(ctx, tokenSource), serverTransport, nil)
+	if err != nil {
+		t.Fatalf("server connect: %v", err)
+	}
+	t.Cleanup(func() { serverSession.Close() })
+	client := mcp.NewClient(&mcp.Implementation{Name: "test-client", Version: "0.0.0"}, nil)
+	session, err := client.Connect(ctx, clientTransport, nil)
+	if err != nil {
+		t.Fatalf("client connect: %v", err)
+	}
+	t.Cleanup(func() { session.Close() })
+	return &toolSession{t: t, session: session, api: api}
+}
+
+// call calls a tool and decodes its structured output into out. It returns
+// the error envelope of a failed call, or nil.
+func (s *toolSession) call(name string, args map[string]any, out any) *ToolError {
+	s.t.Helper()
+	res, err := s.session.CallTool(context.Background(), &mcp.CallToolParams{Name: name, Arguments: args})
+	if err != nil {
+		s.t.Fatalf("call %s: %v", name, err)
+	}
+	if res.IsError {
+		var envelope struct{ Error ToolError }
+		if err := json.Unmarshal([]byte(res.Content[0].(*mcp.TextContent).Text), &envelope); err != nil {
+			s.t.Fatalf("call %s: invalid error %q", name, res.Content[0].(*mcp.TextContent).Text)
+		}
+		return &envelope.Error
+	}
+	if out != nil {
+		data, _ := json.Marshal(res.StructuredContent)
+		if err := json.Unmarshal(data, out); err != nil {
+			s.t.Fatalf("call %s: %v", name, err)
+		}
+	}
+	return nil
+}
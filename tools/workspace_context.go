@@ -0,0 +1,273 @@
+package tools
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"reflect"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/paolobietolini/gtm-mcp-server/gtm"
+
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"gopkg.in/yaml.v3"
+)
+
+// maxSessionContexts bounds how many session contexts are kept in memory.
+const maxSessionContexts = 1000
+
+// workspaceIDNames are the tool arguments a workspace context supplies, from
+// the top of the account/container/workspace hierarchy down.
+var workspaceIDNames = []string{"accountId", "containerId", "workspaceId"}
+
+// WorkspaceRef identifies a workspace, or a container when WorkspaceID is
+// empty.
+type WorkspaceRef struct {
+	AccountID   string `yaml:"accountId" json:"accountId"`
+	ContainerID string `yaml:"containerId" json:"containerId,omitempty"`
+	WorkspaceID string `yaml:"workspaceId" json:"workspaceId,omitempty"`
+}
+
+// id returns the ref's value of a workspaceIDNames argument.
+func (w WorkspaceRef) id(name string) string {
+	switch name {
+	case "accountId":
+		return w.AccountID
+	case "containerId":
+		return w.ContainerID
+	default:
+		return w.WorkspaceID
+	}
+}
+
+func (w WorkspaceRef) validate(field string) error {
+	if w.AccountID == "" {
+		return gtm.InvalidField(field+".accountId", "account ID is required")
+	}
+	if w.ContainerID == "" && w.WorkspaceID != "" {
+		return gtm.InvalidField(field+".containerId", "container ID is required with a workspace ID")
+	}
+	return nil
+}
+
+func (w WorkspaceRef) String() string {
+	switch {
+	case w.WorkspaceID != "":
+		return gtm.BuildWorkspacePath(w.AccountID, w.ContainerID, w.WorkspaceID)
+	case w.ContainerID != "":
+		return gtm.BuildContainerPath(w.AccountID, w.ContainerID)
+	}
+	return "accounts/" + w.AccountID
+}
+
+// WorkspaceContexts configures the workspace every session starts in and
+// the aliases set_context accepts, such as "prod web".
+type WorkspaceContexts struct {
+	// Default is the alias sessions start in. Empty leaves sessions
+	// without a context until they call set_context.
+	Default string                  `yaml:"default"`
+	Aliases map[string]WorkspaceRef `yaml:"aliases"`
+}
+
+// LoadWorkspaceContexts reads a YAML file of workspace aliases and the
+// default alias.
+func LoadWorkspaceContexts(path string) (*WorkspaceContexts, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read workspace contexts: %w", err)
+	}
+	var contexts WorkspaceContexts
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+	if err := dec.Decode(&contexts); err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("invalid workspace contexts: %w", err)
+	}
+	for name, ref := range contexts.Aliases {
+		if err := ref.validate("aliases." + name); err != nil {
+			return nil, fmt.Errorf("invalid workspace contexts: %w", err)
+		}
+	}
+	if contexts.Default != "" {
+		if _, _, ok := contexts.alias(contexts.Default); !ok {
+			return nil, fmt.Errorf("invalid workspace contexts: %w", gtm.InvalidField("default", "unknown alias %q", contexts.Default))
+		}
+	}
+	return &contexts, nil
+}
+
+// alias looks up an alias ignoring case and repeated spaces, and returns
+// its configured name.
+func (c *WorkspaceContexts) alias(name string) (string, WorkspaceRef, bool) {
+	if c == nil {
+		return "", WorkspaceRef{}, false
+	}
+	name = strings.Join(strings.Fields(name), " ")
+	for alias, ref := range c.Aliases {
+		if strings.EqualFold(strings.Join(strings.Fields(alias), " "), name) {
+			return alias, ref, true
+		}
+	}
+	return "", WorkspaceRef{}, false
+}
+
+// aliasNames returns the configured aliases, sorted.
+func (c *WorkspaceContexts) aliasNames() []string {
+	if c == nil {
+		return nil
+	}
+	names := make([]string, 0, len(c.Aliases))
+	for name := range c.Aliases {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// sessionContexts holds the workspace context of each session.
+type sessionContexts struct {
+	mu       sync.Mutex
+	config   *WorkspaceContexts // nil without a contexts file
+	sessions map[string]*sessionContext
+	now      func() time.Time
+}
+
+// sessionContext is the context a session chose; a zero ref means the
+// session cleared it.
+type sessionContext struct {
+	ref   WorkspaceRef
+	alias string
+	set   time.Time
+}
+
+func newSessionContexts(config *WorkspaceContexts) *sessionContexts {
+	return &sessionContexts{config: config, sessions: make(map[string]*sessionContext), now: time.Now}
+}
+
+// contextKey keys contexts by MCP session, or by user outside a session.
+func contextKey(ctx context.Context) string {
+	if id := gtm.SessionFromContext(ctx); id != "" {
+		return "session:" + id
+	}
+	return "user:" + userKey(ctx)
+}
+
+// current returns the caller's workspace context and its alias, falling
+// back to the configured default.
+func (s *sessionContexts) current(ctx context.Context) (WorkspaceRef, string, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if c, ok := s.sessions[contextKey(ctx)]; ok {
+		return c.ref, c.alias, c.ref != WorkspaceRef{}
+	}
+	if s.config != nil && s.config.Default != "" {
+		alias, ref, ok := s.config.alias(s.config.Default)
+		return ref, alias, ok
+	}
+	return WorkspaceRef{}, "", false
+}
+
+// set replaces the caller's context. A zero ref clears it, including the
+// configured default.
+func (s *sessionContexts) set(ctx context.Context, ref WorkspaceRef, alias string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	key := contextKey(ctx)
+	if _, ok := s.sessions[key]; !ok && len(s.sessions) >= maxSessionContexts {
+		s.evictOldest()
+	}
+	s.sessions[key] = &sessionContext{ref: ref, alias: alias, set: s.now()}
+}
+
+func (s *sessionContexts) evictOldest() {
+	var oldest string
+	for key, c := range s.sessions {
+		if oldest == "" || c.set.Before(s.sessions[oldest].set) {
+			oldest = key
+		}
+	}
+	delete(s.sessions, oldest)
+}
+
+// workspaceIDFields returns the struct field index of each
+// workspaceIDNames argument of a tool input type, or with requiredOnly of
+// each one without omitempty.
+func workspaceIDFields(rt reflect.Type, requiredOnly bool) map[string]int {
+	if rt.Kind() != reflect.Struct {
+		return nil
+	}
+	fields := make(map[string]int)
+	for i := range rt.NumField() {
+		f := rt.Field(i)
+		name, opts, _ := strings.Cut(f.Tag.Get("json"), ",")
+		if f.Type.Kind() == reflect.String && slices.Contains(workspaceIDNames, name) && !(requiredOnly && strings.Contains(opts, "omitempty")) {
+			fields[name] = i
+		}
+	}
+	return fields
+}
+
+// withWorkspaceContext fills the account, container and workspace IDs a
+// call omits from the caller's workspace context. IDs are only filled when
+// the IDs the call does pass match the context, so a call naming another
+// account never picks up the context's container.
+func withWorkspaceContext[In, Out any](contexts *sessionContexts, handler mcp.ToolHandlerFor[In, Out]) mcp.ToolHandlerFor[In, Out] {
+	fields := workspaceIDFields(reflect.TypeFor[In](), true)
+	if contexts == nil || len(fields) == 0 {
+		return handler
+	}
+	return func(ctx context.Context, req *mcp.CallToolRequest, input In) (*mcp.CallToolResult, Out, error) {
+		v := reflect.ValueOf(&input).Elem()
+		if ref, _, ok := contexts.current(ctx); ok && matchesContext(v, fields, ref) {
+			for name, i := range fields {
+				if v.Field(i).String() == "" {
+					v.Field(i).SetString(ref.id(name))
+				}
+			}
+		}
+		for _, name := range workspaceIDNames {
+			if i, ok := fields[name]; ok && v.Field(i).String() == "" {
+				var zero Out
+				return nil, zero, gtm.InvalidField(name, "%s is required; pass it or choose a workspace with set_context", name)
+			}
+		}
+		return handler(ctx, req, input)
+	}
+}
+
+// matchesContext reports whether every ID the call passes equals the
+// context's.
+func matchesContext(v reflect.Value, fields map[string]int, ref WorkspaceRef) bool {
+	for name, i := range fields {
+		if id := v.Field(i).String(); id != "" && id != ref.id(name) {
+			return false
+		}
+	}
+	return true
+}
+
+// relaxWorkspaceIDs makes the workspace ID arguments withWorkspaceContext
+// fills optional in a tool's input schema.
+func relaxWorkspaceIDs[In any](tool *mcp.Tool) {
+	schema, ok := tool.InputSchema.(*jsonschema.Schema)
+	fields := workspaceIDFields(reflect.TypeFor[In](), true)
+	if !ok || len(fields) == 0 {
+		return
+	}
+	schema.Required = slices.DeleteFunc(schema.Required, func(name string) bool {
+		_, ok := fields[name]
+		return ok
+	})
+	for name := range fields {
+		if prop := schema.Properties[name]; prop != nil {
+			prop.Description = strings.TrimSpace(prop.Description + " (defaults to the set_context workspace)")
+		}
+	}
+}
@@ -0,0 +1,120 @@
+package tools
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/paolobietolini/gtm-mcp-server/gtm"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+type contextTestInput struct {
+	AccountID   string `json:"accountId"`
+	ContainerID string `json:"containerId"`
+	WorkspaceID string `json:"workspaceId"`
+	TagID       string `json:"tagId,omitempty"`
+}
+
+func TestWithWorkspaceContext(t *testing.T) {
+	contexts := newSessionContexts(&WorkspaceContexts{
+		Default: "prod web",
+		Aliases: map[string]WorkspaceRef{"prod web": {AccountID: "1", ContainerID: "2", WorkspaceID: "3"}},
+	})
+	var got contextTestInput
+	handler := withWorkspaceContext(contexts, func(ctx context.Context, req *mcp.CallToolRequest, input contextTestInput) (*mcp.CallToolResult, any, error) {
+		got = input
+		return nil, nil, nil
+	})
+	ctx := gtm.WithSession(context.Background(), "s1")
+
+	// The configured default fills omitted IDs
+	if _, _, err := handler(ctx, nil, contextTestInput{TagID: "7"}); err != nil {
+		t.Fatal(err)
+	}
+	if got != (contextTestInput{"1", "2", "3", "7"}) {
+		t.Errorf("input = %+v", got)
+	}
+
+	// Matching IDs still get the rest filled
+	handler(ctx, nil, contextTestInput{AccountID: "1", ContainerID: "2"})
+	if got.WorkspaceID != "3" {
+		t.Errorf("input = %+v", got)
+	}
+
+	// A call naming another container gets nothing from the context
+	if _, _, err := handler(ctx, nil, contextTestInput{AccountID: "1", ContainerID: "9"}); !errors.Is(err, gtm.ErrInvalidRequest) {
+		t.Errorf("other container: err = %v, input = %+v", err, got)
+	}
+
+	// A session's context replaces the default, and clearing removes both
+	contexts.set(ctx, WorkspaceRef{AccountID: "4", ContainerID: "5", WorkspaceID: "6"}, "")
+	handler(ctx, nil, contextTestInput{})
+	if got.AccountID != "4" || got.WorkspaceID != "6" {
+		t.Errorf("input = %+v", got)
+	}
+	handler(gtm.WithSession(context.Background(), "s2"), nil, contextTestInput{})
+	if got.AccountID != "1" {
+		t.Errorf("other session: input = %+v", got)
+	}
+	contexts.set(ctx, WorkspaceRef{}, "")
+	if _, _, err := handler(ctx, nil, contextTestInput{}); !errors.Is(err, gtm.ErrInvalidRequest) {
+		t.Errorf("cleared: err = %v", err)
+	}
+}
+
+func TestLoadWorkspaceContexts(t *testing.T) {
+	dir := t.TempDir()
+	write := func(content string) string {
+		path := filepath.Join(dir, "contexts.yaml")
+		if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+
+	contexts, err := LoadWorkspaceContexts(write(`
+default: Prod  Web
+aliases:
+  prod web: {accountId: "1", containerId: "2", workspaceId: "3"}
+  prod server: {accountId: "1", containerId: "4"}
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if alias, ref, ok := contexts.alias("PROD web"); !ok || alias != "prod web" || ref.WorkspaceID != "3" {
+		t.Errorf("alias = %q, %+v, %v", alias, ref, ok)
+	}
+	if names := contexts.aliasNames(); !slices.Equal(names, []string{"prod server", "prod web"}) {
+		t.Errorf("aliasNames = %v", names)
+	}
+
+	for _, bad := range []string{
+		`default: missing`,
+		`aliases: {x: {containerId: "2"}}`,
+		`aliases: {x: {accountId: "1", workspaceId: "3"}}`,
+		`alias: {}`,
+	} {
+		if _, err := LoadWorkspaceContexts(write(bad)); err == nil {
+			t.Errorf("%s: no error", bad)
+		}
+	}
+}
+
+func TestRegisterTools_WorkspaceIDsOptional(t *testing.T) {
+	tools := registeredTools(t, ToolOptions{})
+	schema, _ := tools["list_tags"].InputSchema.(map[string]any)
+	required, _ := schema["required"].([]any)
+	for _, name := range required {
+		if name == "accountId" || name == "workspaceId" {
+			t.Errorf("list_tags requires %s", name)
+		}
+	}
+	if tools["set_context"] == nil {
+		t.Error("set_context not registered")
+	}
+}